@@ -0,0 +1,417 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stroomnetwork/btcwallet/snacl"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// DefaultInterval is the default time between two backups.
+	DefaultInterval = time.Hour
+
+	// DefaultGenerations is the default number of backups kept.
+	DefaultGenerations = 24
+
+	// filePrefix and fileSuffix surround the time a backup was taken in
+	// its file name.
+	filePrefix = "wallet-"
+	fileSuffix = ".backup"
+
+	// timeFormat is the format of the time in the file name of a backup,
+	// which sorts backups from the oldest to the most recent.
+	timeFormat = "20060102T150405.000Z"
+
+	// openTimeout is the time allowed to open a decrypted backup.
+	openTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNoPassphrase is returned when a Scheduler is created without a
+	// passphrase to encrypt the backups with.
+	ErrNoPassphrase = errors.New("no backup passphrase configured")
+
+	// ErrExists is returned by Restore when the database file to restore
+	// exists already.
+	ErrExists = errors.New("database file exists")
+)
+
+// Config houses the parameters of a Scheduler.
+type Config struct {
+	// DB is the database backed up.
+	DB walletdb.DB
+
+	// Dir is the directory the backups are written to.  It is created
+	// if it doesn't exist.
+	Dir string
+
+	// Passphrase is the passphrase the key of the backups is derived
+	// from.
+	Passphrase []byte
+
+	// Interval is the time between two backups.
+	Interval time.Duration
+
+	// Generations is the number of most recent backups kept.  Older
+	// backups are removed after a new one is written.
+	Generations int
+
+	// ScryptN, ScryptR and ScryptP are the scrypt parameters of the key
+	// derivation.  They default to the parameters of snacl.
+	ScryptN, ScryptR, ScryptP int
+}
+
+// Scheduler periodically writes encrypted backups of a database.
+type Scheduler struct {
+	cfg Config
+	key *snacl.SecretKey
+
+	// backupMu serializes the backups, and protects lastDigest, the
+	// digest of the snapshot of the last backup taken.
+	backupMu   sync.Mutex
+	lastDigest []byte
+
+	started bool
+	mu      sync.Mutex
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a Scheduler from cfg, filling unset options with their
+// defaults, and derives the key of the backups from the passphrase.
+func New(cfg *Config) (*Scheduler, error) {
+	if cfg.DB == nil {
+		return nil, errors.New("no database to back up")
+	}
+	if cfg.Dir == "" {
+		return nil, errors.New("no backup directory configured")
+	}
+	if len(cfg.Passphrase) == 0 {
+		return nil, ErrNoPassphrase
+	}
+
+	c := *cfg
+	if c.Interval <= 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.Generations <= 0 {
+		c.Generations = DefaultGenerations
+	}
+	if c.ScryptN == 0 {
+		c.ScryptN = snacl.DefaultN
+	}
+	if c.ScryptR == 0 {
+		c.ScryptR = snacl.DefaultR
+	}
+	if c.ScryptP == 0 {
+		c.ScryptP = snacl.DefaultP
+	}
+
+	pass := append([]byte{}, c.Passphrase...)
+	key, err := snacl.NewSecretKey(&pass, c.ScryptN, c.ScryptR, c.ScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive backup key: %w", err)
+	}
+
+	return &Scheduler{
+		cfg:  c,
+		key:  key,
+		quit: make(chan struct{}),
+	}, nil
+}
+
+// Start takes a backup, and then another one every interval until the
+// scheduler is stopped.  Failed backups are logged.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops taking backups and waits for a backup in progress to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// run takes the backups until the scheduler is stopped.
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		path, err := s.Backup()
+		switch {
+		case err != nil:
+			log.Errorf("Unable to back up wallet database: %v", err)
+		case path != "":
+			log.Infof("Wrote wallet backup %s", path)
+		default:
+			log.Debugf("Wallet database unchanged since the last " +
+				"backup")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Backup writes and verifies a backup of the database, and removes the
+// backups exceeding the configured number of generations.  The path of the
+// backup is returned, or an empty path if the database didn't change since
+// the last backup taken by the scheduler.
+func (s *Scheduler) Backup() (string, error) {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+
+	if err := os.MkdirAll(s.cfg.Dir, 0700); err != nil {
+		return "", err
+	}
+
+	name := filePrefix + time.Now().UTC().Format(timeFormat) + fileSuffix
+	path := filepath.Join(s.cfg.Dir, name)
+	digest, err := s.write(path)
+	if err != nil {
+		return "", err
+	}
+	if digest == nil {
+		return "", nil
+	}
+
+	if err := Verify(path, s.cfg.Passphrase); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("backup %s failed verification: %w", path,
+			err)
+	}
+	s.lastDigest = digest
+
+	if err := s.prune(); err != nil {
+		return path, fmt.Errorf("unable to remove old backups: %w", err)
+	}
+	return path, nil
+}
+
+// write writes the encrypted snapshot of the database to the path, and
+// returns the digest of the snapshot.  Nothing is written, and a nil digest
+// is returned, if the snapshot is identical to the last backup.
+func (s *Scheduler) write(path string) ([]byte, error) {
+	tmp, err := os.CreateTemp(s.cfg.Dir, ".backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	// The snapshot is hashed while it is encrypted, as it can only be
+	// read once.
+	pr, pw := io.Pipe()
+	h := sha256.New()
+	go func() {
+		pw.CloseWithError(s.cfg.DB.Copy(pw))
+	}()
+	err = Encrypt(tmp, io.TeeReader(pr, h), s.key)
+	pr.CloseWithError(err)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := h.Sum(nil)
+	if bytes.Equal(digest, s.lastDigest) {
+		return nil, nil
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	return digest, syncDir(s.cfg.Dir)
+}
+
+// prune removes the oldest backups exceeding the configured number of
+// generations.
+func (s *Scheduler) prune() error {
+	backups, err := List(s.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	for len(backups) > s.cfg.Generations {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		log.Debugf("Removed wallet backup %s", backups[0])
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// List returns the paths of the backups in the directory, from the oldest to
+// the most recent.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() ||
+			!strings.HasPrefix(name, filePrefix) ||
+			!strings.HasSuffix(name, fileSuffix) {
+
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// Verify decrypts the backup to a temporary file next to it, and checks that
+// the file is a consistent bbolt database by opening it read-only.
+func Verify(path string, passphrase []byte) error {
+	tmp, err := decryptTemp(path, filepath.Dir(path), passphrase)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	return checkDB(tmp)
+}
+
+// Restore decrypts the backup to the database file at dbPath, after checking
+// that it is a consistent bbolt database.  ErrExists is returned if the
+// database file exists.
+func Restore(path, dbPath string, passphrase []byte) error {
+	if _, err := os.Stat(dbPath); err == nil {
+		return ErrExists
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	dir := filepath.Dir(dbPath)
+	tmp, err := decryptTemp(path, dir, passphrase)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if err := checkDB(tmp); err != nil {
+		return err
+	}
+
+	// The database is linked rather than renamed, which would replace a
+	// database created since it was checked not to exist.
+	if err := os.Link(tmp, dbPath); err != nil {
+		if os.IsExist(err) {
+			return ErrExists
+		}
+		return err
+	}
+	return syncDir(dir)
+}
+
+// decryptTemp decrypts the backup to a new temporary file of the directory,
+// and returns the path of the file.
+func decryptTemp(path, dir string, passphrase []byte) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	tmp, err := os.CreateTemp(dir, ".restore-")
+	if err != nil {
+		return "", err
+	}
+	err = Decrypt(tmp, f, passphrase)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// checkDB opens the database file read-only and checks the consistency of its
+// pages.
+func checkDB(path string) error {
+	db, err := bolt.Open(path, 0600, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  openTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to open database: %w", err)
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		// The errors are all read, so the check finishes.
+		var checkErr error
+		for err := range tx.Check() {
+			if checkErr == nil {
+				checkErr = fmt.Errorf("inconsistent database: "+
+					"%w", err)
+			}
+		}
+		return checkErr
+	})
+}
+
+// syncDir flushes the entries of the directory to disk.  Directories can't
+// be synced on Windows.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
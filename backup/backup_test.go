@@ -0,0 +1,232 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/bdb"
+	"github.com/stroomnetwork/btcwallet/snacl"
+)
+
+var (
+	testPassphrase = []byte("backup passphrase")
+	bucketKey      = []byte("bucket")
+)
+
+// newTestKey returns a key derived from the test passphrase with cheap scrypt
+// parameters.
+func newTestKey(t *testing.T) *snacl.SecretKey {
+	t.Helper()
+
+	pass := append([]byte{}, testPassphrase...)
+	key, err := snacl.NewSecretKey(&pass, 16, 8, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// TestEncryptDecrypt ensures that data encrypted by Encrypt is decrypted by
+// Decrypt with the passphrase of the key, and that files that were modified,
+// cut off or extended fail to decrypt.
+func TestEncryptDecrypt(t *testing.T) {
+	key := newTestKey(t)
+
+	for _, size := range []int{0, 1, chunkSize, 2*chunkSize + 1} {
+		data := make([]byte, size)
+		if _, err := rand.Read(data); err != nil {
+			t.Fatal(err)
+		}
+
+		var encrypted bytes.Buffer
+		err := Encrypt(&encrypted, bytes.NewReader(data), key)
+		if err != nil {
+			t.Fatalf("size %d: Encrypt: %v", size, err)
+		}
+
+		var decrypted bytes.Buffer
+		err = Decrypt(&decrypted, bytes.NewReader(encrypted.Bytes()),
+			testPassphrase)
+		if err != nil {
+			t.Fatalf("size %d: Decrypt: %v", size, err)
+		}
+		if !bytes.Equal(decrypted.Bytes(), data) {
+			t.Fatalf("size %d: decrypted data doesn't match", size)
+		}
+	}
+
+	var encrypted bytes.Buffer
+	err := Encrypt(&encrypted, bytes.NewReader(make([]byte, 100)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := encrypted.Bytes()
+
+	flipped := append([]byte{}, file...)
+	flipped[len(flipped)-1] ^= 1
+
+	tests := []struct {
+		name       string
+		file       []byte
+		passphrase []byte
+		wantErr    error
+	}{{
+		name:       "wrong passphrase",
+		file:       file,
+		passphrase: []byte("wrong"),
+		wantErr:    snacl.ErrInvalidPassword,
+	}, {
+		name:       "not a backup",
+		file:       bytes.Repeat([]byte{1}, len(file)),
+		passphrase: testPassphrase,
+		wantErr:    ErrMalformed,
+	}, {
+		name:       "modified",
+		file:       flipped,
+		passphrase: testPassphrase,
+		wantErr:    ErrMalformed,
+	}, {
+		name:       "cut off",
+		file:       file[:len(file)-1],
+		passphrase: testPassphrase,
+		wantErr:    ErrMalformed,
+	}, {
+		name:       "extended",
+		file:       append(append([]byte{}, file...), 0),
+		passphrase: testPassphrase,
+		wantErr:    ErrMalformed,
+	}}
+	for _, test := range tests {
+		err := Decrypt(&bytes.Buffer{}, bytes.NewReader(test.file),
+			test.passphrase)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s: got error %v, want %v", test.name, err,
+				test.wantErr)
+		}
+	}
+}
+
+// putValue stores the value in the test bucket of the database.
+func putValue(t *testing.T, db walletdb.DB, value string) {
+	t.Helper()
+
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		b, err := tx.CreateTopLevelBucket(bucketKey)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("key"), []byte(value))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSchedulerRestore ensures that the scheduler writes a backup when the
+// database changed, keeps the configured number of generations, and that the
+// most recent backup restores the database.
+func TestSchedulerRestore(t *testing.T) {
+	dir := t.TempDir()
+	db, err := walletdb.Create(
+		"bdb", filepath.Join(dir, "wallet.db"), true, time.Second,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	backupDir := filepath.Join(dir, "backups")
+	s, err := New(&Config{
+		DB:          db,
+		Dir:         backupDir,
+		Passphrase:  testPassphrase,
+		Generations: 2,
+		ScryptN:     16,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, value := range []string{"1", "2", "", "3"} {
+		// An empty value leaves the database unchanged.
+		if value != "" {
+			putValue(t, db, value)
+		}
+
+		path, err := s.Backup()
+		if err != nil {
+			t.Fatalf("Backup: %v", err)
+		}
+		switch {
+		case value == "" && path != "":
+			t.Fatalf("backup %s written for an unchanged database",
+				path)
+		case value != "" && path == "":
+			t.Fatalf("no backup written after value %s", value)
+		case path != "":
+			paths = append(paths, path)
+		}
+	}
+
+	backups, err := List(backupDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[0] != paths[1] ||
+		backups[1] != paths[2] {
+
+		t.Fatalf("got backups %v, want the last two of %v", backups,
+			paths)
+	}
+
+	// The most recent backup restores the last value.
+	restored := filepath.Join(dir, "restored", "wallet.db")
+	if err := os.Mkdir(filepath.Dir(restored), 0700); err != nil {
+		t.Fatal(err)
+	}
+	err = Restore(backups[1], restored, []byte("wrong"))
+	if !errors.Is(err, snacl.ErrInvalidPassword) {
+		t.Fatalf("Restore: got error %v, want %v", err,
+			snacl.ErrInvalidPassword)
+	}
+	if err := Restore(backups[1], restored, testPassphrase); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	err = Restore(backups[1], restored, testPassphrase)
+	if err != ErrExists {
+		t.Fatalf("Restore: got error %v, want %v", err, ErrExists)
+	}
+
+	restoredDB, err := walletdb.Open("bdb", restored, true, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restoredDB.Close()
+	err = walletdb.View(restoredDB, func(tx walletdb.ReadTx) error {
+		v := tx.ReadBucket(bucketKey).Get([]byte("key"))
+		if string(v) != "3" {
+			t.Errorf("restored value %q, want \"3\"", v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the restored database is left in its directory.
+	entries, err := os.ReadDir(filepath.Dir(restored))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d files next to the restored database, want 1",
+			len(entries))
+	}
+}
@@ -0,0 +1,21 @@
+/*
+Package backup writes encrypted backups of a wallet database.
+
+A Scheduler periodically takes a snapshot of the database with its Copy
+method and writes it encrypted to a new file of the backup directory.  The key
+is derived from a passphrase with scrypt, using the snacl package, and the
+snapshot is sealed in chunks with NaCl secretbox, so a backup is authenticated
+as a whole: a chunk that was modified, reordered or cut off fails to decrypt.
+
+Each backup is verified after it is written by decrypting it to a temporary
+file and opening it read-only as a bbolt database, whose pages are checked for
+consistency.  A backup failing verification is removed.  Backups are named
+after the time they were taken, and only the most recent generations are
+kept.  A snapshot that is identical to the last backup taken by the scheduler
+is not written again, so a wallet that did not change since is not backed up
+more than once.
+
+Restore decrypts and verifies a backup into a new database file, and is used
+by the wallet Loader to restore a wallet that doesn't exist.
+*/
+package backup
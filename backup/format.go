@@ -0,0 +1,153 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/stroomnetwork/btcwallet/snacl"
+)
+
+const (
+	// chunkSize is the maximum size of the plaintext of a chunk.
+	chunkSize = 1 << 20
+
+	// chunkHeaderSize is the size of the index and final flag prefixed to
+	// the plaintext of each chunk.
+	chunkHeaderSize = 8 + 1
+
+	// maxSealedSize is the maximum size of a sealed chunk.
+	maxSealedSize = snacl.NonceSize + snacl.Overhead + chunkHeaderSize +
+		chunkSize
+)
+
+var (
+	// magic identifies the files written by Encrypt.
+	magic = []byte("btcwbak1")
+
+	// paramsSize is the size of the marshalled key parameters of a file.
+	paramsSize = len((&snacl.SecretKey{}).Marshal())
+
+	// ErrMalformed describes a file that is not a backup, or a backup that
+	// was modified or cut off.
+	ErrMalformed = errors.New("malformed backup")
+)
+
+// Encrypt writes the data read from r to w, encrypted with the key.  The
+// parameters of the key are written first, so the data can be decrypted with
+// the passphrase the key was derived from.
+//
+// The data is sealed in chunks, each of them prefixed with its index and
+// whether it is the last one before it is sealed, so that chunks can't be
+// reordered, duplicated or dropped without Decrypt failing.
+func Encrypt(w io.Writer, r io.Reader, key *snacl.SecretKey) error {
+	if _, err := w.Write(magic); err != nil {
+		return err
+	}
+	if _, err := w.Write(key.Marshal()); err != nil {
+		return err
+	}
+
+	r = bufio.NewReaderSize(r, chunkSize)
+	buf := make([]byte, chunkHeaderSize+chunkSize)
+	for index := uint64(0); ; index++ {
+		n, err := io.ReadFull(r, buf[chunkHeaderSize:])
+		final := false
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			final = true
+		case err != nil:
+			return err
+		}
+
+		// A full chunk may be followed by the end of the data, in
+		// which case the last chunk is empty.
+		binary.BigEndian.PutUint64(buf, index)
+		buf[8] = 0
+		if final {
+			buf[8] = 1
+		}
+		sealed, err := key.Encrypt(buf[:chunkHeaderSize+n])
+		if err != nil {
+			return err
+		}
+
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(sealed)))
+		if _, err := w.Write(size[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(sealed); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// Decrypt writes the data of a file written by Encrypt, read from r, to w.
+// snacl.ErrInvalidPassword is returned if the passphrase isn't the one the
+// key of the file was derived from, and ErrMalformed if the file was modified
+// or is incomplete.  Data may have been written to w when an error is
+// returned.
+func Decrypt(w io.Writer, r io.Reader, passphrase []byte) error {
+	r = bufio.NewReader(r)
+
+	header := make([]byte, len(magic)+paramsSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if !bytes.Equal(header[:len(magic)], magic) {
+		return ErrMalformed
+	}
+
+	var key snacl.SecretKey
+	if err := key.Unmarshal(header[len(magic):]); err != nil {
+		return ErrMalformed
+	}
+	if err := key.DeriveKey(&passphrase); err != nil {
+		return err
+	}
+	defer key.Zero()
+
+	for index := uint64(0); ; index++ {
+		var size [4]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n > maxSealedSize {
+			return ErrMalformed
+		}
+
+		sealed := make([]byte, n)
+		if _, err := io.ReadFull(r, sealed); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		chunk, err := key.Decrypt(sealed)
+		if err != nil || len(chunk) < chunkHeaderSize ||
+			binary.BigEndian.Uint64(chunk) != index {
+
+			return ErrMalformed
+		}
+
+		if _, err := w.Write(chunk[chunkHeaderSize:]); err != nil {
+			return err
+		}
+
+		if chunk[8] == 1 {
+			break
+		}
+	}
+
+	// Nothing may follow the last chunk.
+	if _, err := r.Read(make([]byte, 1)); err != io.EOF {
+		return ErrMalformed
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Package bip39 encodes entropy as mnemonic sentences, and derives the seed of
+// an HD wallet from them, as specified by BIP-0039 with the English word list.
+//
+// A mnemonic of 12 to 24 words encodes 128 to 256 bits of entropy followed by
+// the first bits of its SHA-256 hash as a checksum.  The seed is derived from
+// the mnemonic and an optional passphrase with PBKDF2-HMAC-SHA512, so the same
+// mnemonic restores a different wallet for each passphrase.
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// radix is the number of words of the word list.  Each word encodes
+	// radixBits bits.
+	radix     = 2048
+	radixBits = 11
+
+	// minWords and maxWords are the number of words of the mnemonics of
+	// 128 and 256 bits of entropy.  Every 3 words encode 32 bits of
+	// entropy and a bit of checksum.
+	minWords = 12
+	maxWords = 24
+
+	// seedIterations is the number of PBKDF2 iterations deriving a seed.
+	seedIterations = 2048
+
+	// SeedLen is the size of the seeds derived from mnemonics.
+	SeedLen = 64
+)
+
+var (
+	// ErrInvalidWordCount describes a mnemonic that doesn't have 12, 15,
+	// 18, 21 or 24 words.
+	ErrInvalidWordCount = errors.New("mnemonic must have 12, 15, 18, 21 " +
+		"or 24 words")
+
+	// ErrUnknownWord describes a mnemonic with a word that is not in the
+	// word list.
+	ErrUnknownWord = errors.New("unknown mnemonic word")
+
+	// ErrInvalidChecksum describes a mnemonic with a wrong checksum.
+	ErrInvalidChecksum = errors.New("invalid mnemonic checksum")
+)
+
+// NewMnemonic returns a mnemonic of the given number of words, 12, 15, 18, 21
+// or 24, encoding new random entropy.
+func NewMnemonic(words int) (string, error) {
+	if words < minWords || words > maxWords || words%3 != 0 {
+		return "", ErrInvalidWordCount
+	}
+
+	entropy := make([]byte, words/3*4)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return encode(entropy), nil
+}
+
+// encode returns the mnemonic of entropy, which must be 16 to 32 bytes and a
+// multiple of 4 bytes long.
+func encode(entropy []byte) string {
+	// The checksum bits follow the entropy, so the words are read from a
+	// buffer holding the first byte of its hash after it.
+	checksumBits := len(entropy) / 4
+	hash := sha256.Sum256(entropy)
+	data := append(entropy[:len(entropy):len(entropy)], hash[0])
+
+	words := make([]string, (len(entropy)*8+checksumBits)/radixBits)
+	for i := range words {
+		var index int
+		for bit := i * radixBits; bit < (i+1)*radixBits; bit++ {
+			index <<= 1
+			index |= int(data[bit/8]>>(7-bit%8)) & 1
+		}
+		words[i] = wordList[index]
+	}
+	return strings.Join(words, " ")
+}
+
+// decode returns the words of mnemonic, normalized, and the entropy they
+// encode after checking the checksum.
+func decode(mnemonic string) ([]string, []byte, error) {
+	words := strings.Fields(
+		strings.ToLower(norm.NFKD.String(mnemonic)),
+	)
+	if len(words) < minWords || len(words) > maxWords ||
+		len(words)%3 != 0 {
+
+		return nil, nil, ErrInvalidWordCount
+	}
+
+	// Every 3 words encode 32 bits of entropy and a bit of checksum,
+	// which fit in a byte after the entropy.
+	entropyLen := len(words) / 3 * 4
+	data := make([]byte, entropyLen+1)
+	for i, w := range words {
+		index, ok := wordIndex[w]
+		if !ok {
+			return nil, nil, ErrUnknownWord
+		}
+		for b := 0; b < radixBits; b++ {
+			bit := i*radixBits + b
+			if index>>(radixBits-1-b)&1 == 1 {
+				data[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	entropy := data[:entropyLen]
+	checksumBits := entropyLen / 4
+	hash := sha256.Sum256(entropy)
+	if hash[0]>>(8-checksumBits) != data[entropyLen]>>(8-checksumBits) {
+		return nil, nil, ErrInvalidChecksum
+	}
+	return words, entropy, nil
+}
+
+// Validate checks that mnemonic consists of 12 to 24 words of the word list
+// with a valid checksum.  Case and spacing are not significant.
+func Validate(mnemonic string) error {
+	_, _, err := decode(mnemonic)
+	return err
+}
+
+// Seed returns the seed of SeedLen bytes derived from mnemonic and passphrase,
+// which may be empty, after validating the mnemonic.  Both are normalized to
+// NFKD as required by BIP-0039, so a passphrase restores the same seed however
+// its characters were composed.
+func Seed(mnemonic string, passphrase []byte) ([]byte, error) {
+	words, _, err := decode(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	password := []byte(strings.Join(words, " "))
+	salt := append([]byte("mnemonic"), norm.NFKD.Bytes(passphrase)...)
+	return pbkdf2.Key(
+		password, salt, seedIterations, SeedLen, sha512.New,
+	), nil
+}
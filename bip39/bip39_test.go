@@ -0,0 +1,177 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestVectors checks mnemonics of the BIP-0039 test vectors, whose seeds are
+// derived with the passphrase TREZOR.
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{{
+		entropy: "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon about",
+		seed: "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa" +
+			"3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c" +
+			"4ab7c81b2f001698e7463b04",
+	}, {
+		entropy: "ffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo " +
+			"wrong",
+		seed: "ac27495480225222079d7be181583751e86f571027b0497b5b5d" +
+			"11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee3276" +
+			"51a14c34e18231052e48c069",
+	}, {
+		entropy: "b63a9c59a6e641f288ebc103017f1da9f8290b3da6bdef7b",
+		mnemonic: "renew stay biology evidence goat welcome casual " +
+			"join adapt armor shuffle fault little machine walk " +
+			"stumble urge swap",
+		seed: "9248d83e06f4cd98debf5b6f010542760df925ce46cf38a1bdb4" +
+			"e4de7d21f5c39366941c69e1bdbf2966e0f6e6dbece898a0e2f0" +
+			"a4c2b3e640953dfe8b7bbdc5",
+	}, {
+		entropy: "8080808080808080808080808080808080808080808080808080" +
+			"808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic " +
+			"avoid letter advice cage absurd amount doctor " +
+			"acoustic avoid letter advice cage absurd amount " +
+			"doctor acoustic bless",
+		seed: "c0c519bd0e91a2ed54357d9d1ebef6f5af218a153624cf4f2da9" +
+			"11a0ed8f7a09e2ef61af0aca007096df430022f7a2b6fb91661a" +
+			"9589097069720d015e4e982f",
+	}, {
+		entropy: "3e141609b97933b66a060dcddc71fad1d91677db872031e85f4c" +
+			"015c5e7e8982",
+		mnemonic: "dignity pass list indicate nasty swamp pool script " +
+			"soccer toe leaf photo multiply desk host tomato " +
+			"cradle drill spread actor shine dismiss champion " +
+			"exotic",
+		seed: "ff7f3184df8696d8bef94b6c03114dbee0ef89ff938712301d27" +
+			"ed8336ca89ef9635da20af07d4175f2bf5f3de130f39c9d9e8dd" +
+			"0472489c19b1a020a940da67",
+	}}
+	for _, test := range tests {
+		entropy, err := hex.DecodeString(test.entropy)
+		require.NoError(t, err)
+		require.Equal(t, test.mnemonic, encode(entropy))
+
+		_, decoded, err := decode(test.mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, entropy, decoded)
+
+		seed, err := Seed(test.mnemonic, []byte("TREZOR"))
+		require.NoError(t, err)
+		require.Equal(t, test.seed, hex.EncodeToString(seed))
+
+		// Case and spacing don't change the seed.
+		seed, err = Seed(
+			"  "+strings.ToUpper(test.mnemonic)+"\n",
+			[]byte("TREZOR"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, test.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestInvalidMnemonics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mnemonic string
+		err      error
+	}{{
+		"abandon abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon",
+		ErrInvalidWordCount,
+	}, {
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo " +
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo",
+		ErrInvalidWordCount,
+	}, {
+		"letter advice cage absurd amount doctor acoustic avoid " +
+			"letter advice caged above",
+		ErrUnknownWord,
+	}, {
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong",
+		ErrUnknownWord,
+	}, {
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo " +
+			"zoo zoo zoo wrong",
+		ErrInvalidChecksum,
+	}, {
+		"abandon abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon letter",
+		ErrInvalidChecksum,
+	}}
+	for _, test := range tests {
+		require.ErrorIs(t, Validate(test.mnemonic), test.err,
+			test.mnemonic)
+
+		_, err := Seed(test.mnemonic, nil)
+		require.ErrorIs(t, err, test.err, test.mnemonic)
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	t.Parallel()
+
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := NewMnemonic(words)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), words)
+		require.NoError(t, Validate(mnemonic))
+
+		seed, err := Seed(mnemonic, nil)
+		require.NoError(t, err)
+		require.Len(t, seed, SeedLen)
+	}
+
+	for _, words := range []int{0, 11, 13, 27} {
+		_, err := NewMnemonic(words)
+		require.ErrorIs(t, err, ErrInvalidWordCount)
+	}
+}
+
+// TestPassphraseNormalization checks that a passphrase derives the same seed
+// whether its accented characters are composed or not, and that a different
+// passphrase derives a different seed.
+func TestPassphraseNormalization(t *testing.T) {
+	t.Parallel()
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon " +
+		"abandon abandon abandon abandon abandon about"
+
+	composed, err := Seed(mnemonic, []byte("caf\u00e9"))
+	require.NoError(t, err)
+	decomposed, err := Seed(mnemonic, []byte("cafe\u0301"))
+	require.NoError(t, err)
+	require.Equal(t, composed, decomposed)
+
+	other, err := Seed(mnemonic, []byte("cafe"))
+	require.NoError(t, err)
+	require.NotEqual(t, composed, other)
+}
+
+// TestWordList checks the properties of the word list BIP-0039 relies on.
+func TestWordList(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, sort.StringsAreSorted(wordList[:]))
+	prefixes := make(map[string]bool)
+	for _, w := range wordList {
+		require.True(t, len(w) >= 3 && len(w) <= 8, w)
+		prefixes[w[:min(len(w), 4)]] = true
+	}
+	require.Len(t, prefixes, radix)
+}
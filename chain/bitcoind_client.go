@@ -64,6 +64,20 @@ type BitcoindClient struct {
 	watchedOutPoints map[wire.OutPoint]struct{}
 	watchedTxs       map[chainhash.Hash]struct{}
 
+	// addrScripts and outPointScripts hold the output scripts of the
+	// watched addresses and outpoints, which are matched against the
+	// compact filters of blocks when the node serves them. watchVersion
+	// is bumped on every change to the watch list.
+	//
+	// NOTE: These require the watchMtx to be held.
+	addrScripts     map[string][]byte
+	outPointScripts map[wire.OutPoint][]byte
+	watchVersion    uint64
+
+	// blockFilters tracks whether the backing node serves compact block
+	// filters. This must be used atomically.
+	blockFilters int32
+
 	// mempool keeps track of all relevant transactions that have yet to be
 	// confirmed. This is used to shortcut the filtering process of a
 	// transaction when a new confirmed transaction notification is
@@ -625,6 +639,9 @@ func (c *BitcoindClient) rescanHandler() {
 				c.watchedOutPoints = make(map[wire.OutPoint]struct{})
 				c.watchedAddresses = make(map[string]struct{})
 				c.watchedTxs = make(map[chainhash.Hash]struct{})
+				c.addrScripts = make(map[string][]byte)
+				c.outPointScripts = make(map[wire.OutPoint][]byte)
+				c.watchVersion++
 				c.watchMtx.Unlock()
 
 			// We're adding the addresses to our filter.
@@ -632,7 +649,9 @@ func (c *BitcoindClient) rescanHandler() {
 				c.watchMtx.Lock()
 				for _, addr := range update {
 					c.watchedAddresses[addr.String()] = struct{}{}
+					c.watchAddressScript(addr)
 				}
+				c.watchVersion++
 				c.watchMtx.Unlock()
 
 			// We're adding the outpoints to our filter.
@@ -641,21 +660,25 @@ func (c *BitcoindClient) rescanHandler() {
 				for _, op := range update {
 					c.watchedOutPoints[op] = struct{}{}
 				}
+				c.watchVersion++
 				c.watchMtx.Unlock()
 			case []*wire.OutPoint:
 				c.watchMtx.Lock()
 				for _, op := range update {
 					c.watchedOutPoints[*op] = struct{}{}
 				}
+				c.watchVersion++
 				c.watchMtx.Unlock()
 
 			// We're adding the outpoints that map to the scripts
 			// that we should scan for to our filter.
 			case map[wire.OutPoint]btcutil.Address:
 				c.watchMtx.Lock()
-				for op := range update {
+				for op, addr := range update {
 					c.watchedOutPoints[op] = struct{}{}
+					c.watchOutPointScript(op, addr)
 				}
+				c.watchVersion++
 				c.watchMtx.Unlock()
 
 			// We're adding the transactions to our filter.
@@ -664,12 +687,14 @@ func (c *BitcoindClient) rescanHandler() {
 				for _, txid := range update {
 					c.watchedTxs[txid] = struct{}{}
 				}
+				c.watchVersion++
 				c.watchMtx.Unlock()
 			case []*chainhash.Hash:
 				c.watchMtx.Lock()
 				for _, txid := range update {
 					c.watchedTxs[*txid] = struct{}{}
 				}
+				c.watchVersion++
 				c.watchMtx.Unlock()
 
 			// We're starting a rescan from the hash.
@@ -878,11 +903,13 @@ func (c *BitcoindClient) onRelevantTx(tx *wtxmgr.TxRecord,
 // progress. This will queue a RescanProgress notification to the caller with
 // the current rescan progress details.
 func (c *BitcoindClient) onRescanProgress(hash *chainhash.Hash, height int32,
-	timestamp time.Time) {
+	timestamp time.Time, bestHeight int32, eta time.Duration) {
 	n := &RescanProgress{
-		Hash:   *hash,
-		Height: height,
-		Time:   timestamp,
+		Hash:       *hash,
+		Height:     height,
+		Time:       timestamp,
+		BestHeight: bestHeight,
+		ETA:        eta,
 	}
 	select {
 	case c.notificationQueue.ChanIn() <- n:
@@ -1081,10 +1108,10 @@ func (c *BitcoindClient) reorg(currentBlock waddrmgr.BlockStamp,
 }
 
 // FilterBlocks scans the blocks contained in the FilterBlocksRequest for any
-// addresses of interest. Each block will be fetched and filtered sequentially,
-// returning a FilterBlocksResponse for the first block containing a matching
-// address. If no matches are found in the range of blocks requested, the
-// returned response will be nil.
+// addresses of interest. The blocks are prefetched concurrently and filtered
+// in order, returning a FilterBlocksResponse for the first block containing a
+// matching address. If no matches are found in the range of blocks requested,
+// the returned response will be nil.
 //
 // NOTE: This is part of the chain.Interface interface.
 func (c *BitcoindClient) FilterBlocks(
@@ -1092,18 +1119,35 @@ func (c *BitcoindClient) FilterBlocks(
 
 	blockFilterer := NewBlockFilterer(c.chainConn.cfg.ChainParams, req)
 
-	// Iterate over the requested blocks, fetching each from the rpc client.
-	// Each block will scanned using the reverse addresses indexes generated
-	// above, breaking out early if any addresses are found.
+	// If the node serves compact block filters, we'll only fetch the
+	// blocks whose filter matches any of the requested scripts.
+	watchList, err := buildFilterBlocksWatchList(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := c.newBlockPipeline(
+		len(req.Blocks), func(client blockBatchClient, start,
+			end int) ([]*fetchedBlock, error) {
+
+			return c.fetchFilterBlocks(
+				client, req.Blocks[start:end], watchList,
+			)
+		},
+	)
+	defer pipeline.Stop()
+
+	// Iterate over the requested blocks in order. Each block will scanned
+	// using the reverse addresses indexes generated above, breaking out
+	// early if any addresses are found.
 	for i, block := range req.Blocks {
-		// TODO(conner): add prefetching, since we already know we'll be
-		// fetching *every* block
-		rawBlock, err := c.GetBlock(&block.Hash)
+		fetched, err := pipeline.Next()
 		if err != nil {
 			return nil, err
 		}
+		if fetched.block == nil || !blockFilterer.FilterBlock(
+			fetched.block) {
 
-		if !blockFilterer.FilterBlock(rawBlock) {
 			continue
 		}
 
@@ -1165,44 +1209,60 @@ func (c *BitcoindClient) rescan(start chainhash.Hash) error {
 	}
 	headers.PushBack(previousHeader)
 
+	// Blocks are prefetched up to the best known block by a pipeline,
+	// which is restarted once the best block moves or after a reorg.
+	var (
+		pipeline    *blockPipeline
+		pipelineEnd int32
+	)
+	defer func() {
+		if pipeline != nil {
+			pipeline.Stop()
+		}
+	}()
+
+	eta := newRescanETA(previousHeader.Height)
+	lastProgress := time.Now()
+
+	// The watch list may change while blocks are prefetched, so blocks
+	// skipped because of their compact filter are matched against it
+	// again before they're scanned.
+	var watchList filterWatchList
+
 	// Cycle through all of the blocks known to bitcoind, being mindful of
 	// reorgs.
 	for i := previousHeader.Height + 1; i <= bestBlock.Height; i++ {
-		hash, err := c.GetBlockHash(int64(i))
+		if pipeline == nil {
+			pipeline = c.newRescanPipeline(i, bestBlock.Height)
+			pipelineEnd = bestBlock.Height
+		}
+
+		// Blocks before the wallet birthday and blocks that don't match
+		// the compact filters of the node only contain the header, as
+		// they won't match any of our filters.
+		fetched, err := pipeline.Next()
 		if err != nil {
 			return err
 		}
-
-		// If the previous header is before the wallet birthday, fetch
-		// the current header and construct a dummy block, rather than
-		// fetching the whole block itself. This speeds things up as we
-		// no longer have to fetch the whole block when we know it won't
-		// match any of our filters.
-		var block *wire.MsgBlock
-		afterBirthday := previousHeader.Time >= c.birthday.Unix()
-		if !afterBirthday {
-			header, err := c.GetBlockHeader(hash)
-			if err != nil {
-				return err
-			}
-			block = &wire.MsgBlock{
-				Header: *header,
-			}
-
-			afterBirthday = c.birthday.Before(header.Timestamp)
-			if afterBirthday {
-				c.onRescanProgress(
-					previousHash, i,
-					block.Header.Timestamp,
-				)
-			}
+		if fetched == nil {
+			return ErrBitcoindClientShuttingDown
+		}
+		block, err := c.rescanBlock(fetched, &watchList)
+		if err != nil {
+			return err
+		}
+		if fetched.birthdayCrossed {
+			c.onRescanProgress(
+				previousHash, i, block.Header.Timestamp,
+				bestBlock.Height, eta.estimate(i, bestBlock.Height),
+			)
 		}
 
-		if afterBirthday {
-			block, err = c.GetBlock(hash)
-			if err != nil {
-				return err
-			}
+		// The prefetched blocks may belong to a stale chain once we've
+		// been reorganized, so they're discarded.
+		if block.Header.PrevBlock.String() != previousHeader.Hash {
+			pipeline.Stop()
+			pipeline = nil
 		}
 
 		for block.Header.PrevBlock.String() != previousHeader.Hash {
@@ -1276,10 +1336,19 @@ func (c *BitcoindClient) rescan(start chainhash.Hash) error {
 		// Notify the block and any of its relevant transacations.
 		_ = c.filterBlock(block, i, true)
 
-		if i%10000 == 0 {
+		if i%10000 == 0 ||
+			time.Since(lastProgress) >= rescanProgressInterval {
+
 			c.onRescanProgress(
 				previousHash, i, block.Header.Timestamp,
+				bestBlock.Height, eta.estimate(i, bestBlock.Height),
 			)
+			lastProgress = time.Now()
+		}
+
+		if pipeline != nil && i == pipelineEnd {
+			pipeline.Stop()
+			pipeline = nil
 		}
 
 		// If we've reached the previously best known block, check to
@@ -1485,6 +1554,8 @@ func (c *BitcoindClient) filterTx(txDetails *btcutil.Tx,
 					Index: uint32(i),
 				}
 				c.watchedOutPoints[op] = struct{}{}
+				c.outPointScripts[op] = txOut.PkScript
+				c.watchVersion++
 			}
 		}
 	}
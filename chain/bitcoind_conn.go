@@ -97,6 +97,11 @@ type BitcoindConfig struct {
 	PrunedModeMaxPeers int
 
 	ConnectionTimeout time.Duration
+
+	// BlockPrefetch holds the concurrency and throughput limits of the
+	// pipeline prefetching blocks during rescans and recovery. Unset
+	// limits take their defaults.
+	BlockPrefetch BlockPrefetchConfig
 }
 
 // BitcoindConn represents a persistent client connection to a bitcoind node
@@ -115,6 +120,10 @@ type BitcoindConn struct {
 	// client is the RPC client to the bitcoind node.
 	client *rpcclient.Client
 
+	// clientCfg is the connection config of client, which is used to
+	// create the batch clients prefetching blocks.
+	clientCfg *rpcclient.ConnConfig
+
 	// prunedBlockDispatcher handles all of the pruned block requests.
 	//
 	// NOTE: This is nil when the bitcoind node is not pruned.
@@ -164,6 +173,7 @@ func NewBitcoindConn(cfg *BitcoindConfig) (*BitcoindConn, error) {
 	bc := &BitcoindConn{
 		cfg:                   *cfg,
 		client:                client,
+		clientCfg:             clientCfg,
 		prunedBlockDispatcher: nil,
 		rescanClients:         make(map[uint64]*BitcoindClient),
 		quit:                  make(chan struct{}),
@@ -381,6 +391,8 @@ func (c *BitcoindConn) NewBitcoindClient() *BitcoindClient {
 		watchedAddresses: make(map[string]struct{}),
 		watchedOutPoints: make(map[wire.OutPoint]struct{}),
 		watchedTxs:       make(map[chainhash.Hash]struct{}),
+		addrScripts:      make(map[string][]byte),
+		outPointScripts:  make(map[wire.OutPoint][]byte),
 
 		notificationQueue: NewConcurrentQueue(20),
 		txNtfns:           make(chan *wire.MsgTx, 1000),
@@ -0,0 +1,204 @@
+package chain
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/gcs"
+	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// blockFiltersUnknown signals that it is not yet known whether the
+	// backing bitcoind node serves compact block filters.
+	blockFiltersUnknown int32 = iota
+
+	// blockFiltersEnabled signals that the node was started with
+	// -blockfilterindex and serves basic BIP158 filters.
+	blockFiltersEnabled
+
+	// blockFiltersDisabled signals that the node doesn't serve compact
+	// block filters, so full blocks must be fetched.
+	blockFiltersDisabled
+)
+
+// filterWatchList caches the scripts the compact filters of blocks are
+// matched against during a rescan. It is only rebuilt once the watch list of
+// the client changes.
+type filterWatchList struct {
+	version uint64
+	built   bool
+
+	// scripts is the set of output scripts to match filters against.
+	scripts [][]byte
+
+	// complete is false if some watched items can't be matched against
+	// a compact filter, such as outpoints with an unknown script or
+	// transaction hashes, in which case full blocks must be scanned.
+	complete bool
+}
+
+// rescanWatchList updates l from the current watch list of the client and
+// returns the scripts to match compact filters against. The returned bool is
+// false if the watch list can't be fully expressed as scripts.
+func (c *BitcoindClient) rescanWatchList(l *filterWatchList) ([][]byte, bool) {
+	c.watchMtx.RLock()
+	defer c.watchMtx.RUnlock()
+
+	if l.built && l.version == c.watchVersion {
+		return l.scripts, l.complete
+	}
+
+	l.version = c.watchVersion
+	l.built = true
+	l.complete = len(c.watchedTxs) == 0 &&
+		len(c.addrScripts) == len(c.watchedAddresses) &&
+		len(c.outPointScripts) == len(c.watchedOutPoints)
+
+	l.scripts = l.scripts[:0]
+	if !l.complete {
+		return nil, false
+	}
+	for _, script := range c.addrScripts {
+		l.scripts = append(l.scripts, script)
+	}
+	for _, script := range c.outPointScripts {
+		l.scripts = append(l.scripts, script)
+	}
+
+	return l.scripts, true
+}
+
+// watchAddressScript records the output script of a watched address so it
+// can be matched against compact block filters.
+//
+// NOTE: This requires the watchMtx to be held.
+func (c *BitcoindClient) watchAddressScript(addr btcutil.Address) {
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		// Without a script the address can only be found by scanning
+		// full blocks, which rescanWatchList takes care of.
+		log.Debugf("Unable to create script for watched address "+
+			"%v: %v", addr, err)
+		return
+	}
+	c.addrScripts[addr.String()] = script
+}
+
+// watchOutPointScript records the output script of a watched outpoint so
+// that its spend can be matched against compact block filters.
+//
+// NOTE: This requires the watchMtx to be held.
+func (c *BitcoindClient) watchOutPointScript(op wire.OutPoint,
+	addr btcutil.Address) {
+
+	if addr == nil {
+		return
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		log.Debugf("Unable to create script for watched outpoint "+
+			"%v: %v", op, err)
+		return
+	}
+	c.outPointScripts[op] = script
+}
+
+// handleBlockFilter reports whether the result of a getblockfilter request
+// for the block with the given hash matches any of the scripts in the watch
+// list. The second return value is false if no filter could be obtained from
+// the backing node, in which case the full block must be scanned.
+func (c *BitcoindClient) handleBlockFilter(hash *chainhash.Hash,
+	res *btcjson.GetBlockFilterResult, err error,
+	watchList [][]byte) (bool, bool) {
+
+	switch {
+	case isBlockFilterUnsupported(err):
+		if atomic.SwapInt32(&c.blockFilters, blockFiltersDisabled) !=
+			blockFiltersDisabled {
+
+			log.Infof("bitcoind doesn't serve compact block "+
+				"filters (%v), scanning full blocks. Start "+
+				"bitcoind with -blockfilterindex to speed "+
+				"up rescans", err)
+		}
+		return false, false
+
+	case err != nil:
+		log.Debugf("Unable to fetch block filter for %v, scanning "+
+			"full block: %v", hash, err)
+		return false, false
+	}
+
+	if atomic.SwapInt32(&c.blockFilters, blockFiltersEnabled) ==
+		blockFiltersUnknown {
+
+		log.Infof("Using bitcoind compact block filters to scan blocks")
+	}
+
+	rawFilter, err := hex.DecodeString(res.Filter)
+	if err != nil {
+		log.Debugf("Invalid block filter for %v, scanning full "+
+			"block: %v", hash, err)
+		return false, false
+	}
+
+	matched, err := matchRawFilter(hash, rawFilter, watchList)
+	if err != nil {
+		log.Debugf("Unable to match block filter for %v, scanning "+
+			"full block: %v", hash, err)
+		return false, false
+	}
+
+	return matched, true
+}
+
+// matchRawFilter reports whether the serialized basic BIP158 filter of the
+// block with the given hash matches any of the scripts in the watch list.
+func matchRawFilter(hash *chainhash.Hash, rawFilter []byte,
+	watchList [][]byte) (bool, error) {
+
+	// A filter without any elements is serialized as a single zero byte,
+	// and it can't match anything.
+	if len(watchList) == 0 || len(rawFilter) < 4 {
+		return false, nil
+	}
+
+	filter, err := gcs.FromNBytes(
+		builder.DefaultP, builder.DefaultM, rawFilter,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	key := builder.DeriveKey(hash)
+	return filter.MatchAny(key, watchList)
+}
+
+// isBlockFilterUnsupported returns true if the error returned by
+// getblockfilter signals that the node doesn't serve compact filters at
+// all, rather than failing for a single block.
+func isBlockFilterUnsupported(err error) bool {
+	var rpcErr *btcjson.RPCError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	switch rpcErr.Code {
+	case btcjson.ErrRPCMethodNotFound.Code:
+		return true
+
+	case btcjson.ErrRPCMisc:
+		return strings.Contains(rpcErr.Message, "Index is not enabled")
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// testFilterAddr returns a deterministic P2WPKH address and its script.
+func testFilterAddr(t *testing.T, b byte) (btcutil.Address, []byte) {
+	t.Helper()
+
+	hash := make([]byte, 20)
+	hash[0] = b
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		hash, &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+
+	script, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	return addr, script
+}
+
+// TestMatchRawFilter checks that serialized basic filters are matched
+// against the output and previous output scripts of a block.
+func TestMatchRawFilter(t *testing.T) {
+	t.Parallel()
+
+	_, outScript := testFilterAddr(t, 1)
+	_, prevScript := testFilterAddr(t, 2)
+	_, otherScript := testFilterAddr(t, 3)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(wire.NewTxOut(1000, outScript))
+	block := &wire.MsgBlock{Transactions: []*wire.MsgTx{tx}}
+	blockHash := block.BlockHash()
+
+	filter, err := builder.BuildBasicFilter(block, [][]byte{prevScript})
+	require.NoError(t, err)
+	rawFilter, err := filter.NBytes()
+	require.NoError(t, err)
+
+	matched, err := matchRawFilter(
+		&blockHash, rawFilter, [][]byte{otherScript, outScript},
+	)
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = matchRawFilter(
+		&blockHash, rawFilter, [][]byte{prevScript},
+	)
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = matchRawFilter(
+		&blockHash, rawFilter, [][]byte{otherScript},
+	)
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	// The key is derived from the block hash, so the filter doesn't match
+	// for another block.
+	matched, err = matchRawFilter(
+		&chainhash.Hash{1}, rawFilter, [][]byte{outScript},
+	)
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	// Empty filters and watch lists never match.
+	matched, err = matchRawFilter(&blockHash, []byte{0}, [][]byte{outScript})
+	require.NoError(t, err)
+	require.False(t, matched)
+	matched, err = matchRawFilter(&blockHash, rawFilter, nil)
+	require.NoError(t, err)
+	require.False(t, matched)
+}
+
+// TestRescanWatchList checks that the watch list is only matched against
+// compact filters if all watched items have a known script.
+func TestRescanWatchList(t *testing.T) {
+	t.Parallel()
+
+	c := &BitcoindClient{
+		watchedAddresses: make(map[string]struct{}),
+		watchedOutPoints: make(map[wire.OutPoint]struct{}),
+		watchedTxs:       make(map[chainhash.Hash]struct{}),
+		addrScripts:      make(map[string][]byte),
+		outPointScripts:  make(map[wire.OutPoint][]byte),
+	}
+	var l filterWatchList
+
+	addr, addrScript := testFilterAddr(t, 1)
+	c.watchedAddresses[addr.String()] = struct{}{}
+	c.watchAddressScript(addr)
+	c.watchVersion++
+
+	scripts, ok := c.rescanWatchList(&l)
+	require.True(t, ok)
+	require.Equal(t, [][]byte{addrScript}, scripts)
+
+	// Outpoints watched together with their address can be matched.
+	opAddr, opScript := testFilterAddr(t, 2)
+	op := wire.OutPoint{Index: 1}
+	c.watchedOutPoints[op] = struct{}{}
+	c.watchOutPointScript(op, opAddr)
+	c.watchVersion++
+
+	scripts, ok = c.rescanWatchList(&l)
+	require.True(t, ok)
+	require.ElementsMatch(t, [][]byte{addrScript, opScript}, scripts)
+
+	// An outpoint without a script requires scanning full blocks.
+	c.watchedOutPoints[wire.OutPoint{Index: 2}] = struct{}{}
+	c.watchVersion++
+
+	_, ok = c.rescanWatchList(&l)
+	require.False(t, ok)
+
+	// So do watched transactions.
+	delete(c.watchedOutPoints, wire.OutPoint{Index: 2})
+	c.watchedTxs[chainhash.Hash{1}] = struct{}{}
+	c.watchVersion++
+
+	_, ok = c.rescanWatchList(&l)
+	require.False(t, ok)
+}
+
+// TestIsBlockFilterUnsupported checks which getblockfilter errors disable
+// the use of compact filters.
+func TestIsBlockFilterUnsupported(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isBlockFilterUnsupported(btcjson.NewRPCError(
+		btcjson.ErrRPCMisc, "Index is not enabled for filtertype basic",
+	)))
+	require.True(t, isBlockFilterUnsupported(btcjson.ErrRPCMethodNotFound))
+	require.False(t, isBlockFilterUnsupported(btcjson.NewRPCError(
+		btcjson.ErrRPCInvalidAddressOrKey, "Block not found",
+	)))
+	require.False(t, isBlockFilterUnsupported(nil))
+}
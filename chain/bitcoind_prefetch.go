@@ -0,0 +1,287 @@
+package chain
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+)
+
+// rescanProgressInterval is the maximum time between two RescanProgress
+// notifications of a bitcoind rescan.
+const rescanProgressInterval = 30 * time.Second
+
+// A compile-time check to ensure the batch client of rpcclient satisfies the
+// blockBatchClient interface.
+var _ blockBatchClient = (*rpcclient.Client)(nil)
+
+// newBlockBatchClient creates a client that queues block requests to the
+// node until Send is called.
+func (c *BitcoindConn) newBlockBatchClient() (blockBatchClient, error) {
+	cfg := *c.clientCfg
+	return rpcclient.NewBatch(&cfg)
+}
+
+// newBlockPipeline starts a pipeline prefetching n blocks with the limits
+// configured for the connection.
+func (c *BitcoindClient) newBlockPipeline(n int,
+	fetch blockFetcher) *blockPipeline {
+
+	return newBlockPipeline(
+		c.chainConn.cfg.BlockPrefetch, n,
+		c.chainConn.newBlockBatchClient, fetch,
+	)
+}
+
+// newRescanPipeline starts a pipeline prefetching the blocks between the
+// given heights, inclusive, for a rescan.
+func (c *BitcoindClient) newRescanPipeline(from, to int32) *blockPipeline {
+	return c.newBlockPipeline(
+		int(to-from+1), func(client blockBatchClient, start,
+			end int) ([]*fetchedBlock, error) {
+
+			return c.fetchRescanBlocks(
+				client, from+int32(start), from+int32(end)-1,
+			)
+		},
+	)
+}
+
+// fetchRescanBlocks fetches the blocks between the given heights, inclusive,
+// for a rescan. Only the headers are fetched of blocks before the birthday
+// of the client and of blocks whose compact filter doesn't match the watch
+// list, as they can't contain relevant transactions.
+func (c *BitcoindClient) fetchRescanBlocks(client blockBatchClient, from,
+	to int32) ([]*fetchedBlock, error) {
+
+	// The block before the range is fetched as well, since whether a
+	// block is after the birthday depends on the time of its parent.
+	n := int(to-from) + 2
+
+	hashResults := make([]rpcclient.FutureGetBlockHashResult, n)
+	for i := range hashResults {
+		hashResults[i] = client.GetBlockHashAsync(int64(from) - 1 +
+			int64(i))
+	}
+	if err := client.Send(); err != nil {
+		return nil, err
+	}
+	hashes := make([]*chainhash.Hash, n)
+	for i, res := range hashResults {
+		hash, err := res.Receive()
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+
+	headerResults := make([]rpcclient.FutureGetBlockHeaderResult, n)
+	for i, hash := range hashes {
+		headerResults[i] = client.GetBlockHeaderAsync(hash)
+	}
+	if err := client.Send(); err != nil {
+		return nil, err
+	}
+	headers := make([]*wire.BlockHeader, n)
+	for i, res := range headerResults {
+		header, err := res.Receive()
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = header
+	}
+
+	blocks := make([]*fetchedBlock, 0, n-1)
+	var needed []*fetchedBlock
+	for i := 1; i < n; i++ {
+		prevTime := headers[i-1].Timestamp.Unix()
+		block := &fetchedBlock{
+			height: from + int32(i-1),
+			hash:   *hashes[i],
+			block:  &wire.MsgBlock{Header: *headers[i]},
+		}
+		block.birthdayCrossed = prevTime < c.birthday.Unix() &&
+			c.birthday.Before(headers[i].Timestamp)
+
+		if prevTime >= c.birthday.Unix() || block.birthdayCrossed {
+			needed = append(needed, block)
+		}
+		blocks = append(blocks, block)
+	}
+
+	// Blocks skipped because of their filter record the version of the
+	// watch list they were matched against, so they can be matched again
+	// if it changes before they are scanned.
+	var watchList filterWatchList
+	if scripts, ok := c.rescanWatchList(&watchList); ok {
+		for _, block := range needed {
+			block.filterMiss = true
+			block.watchVersion = watchList.version
+		}
+		needed = c.batchMatchFilters(client, needed, scripts)
+		for _, block := range needed {
+			block.filterMiss = false
+		}
+	}
+
+	if err := c.batchGetBlocks(client, needed); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// rescanBlock returns the block of a rescan to filter. A block prefetched
+// without its transactions because its compact filter didn't match the watch
+// list is matched again if the watch list changed since, such as when new
+// addresses are watched during the rescan, and fetched in full on a match.
+func (c *BitcoindClient) rescanBlock(fetched *fetchedBlock,
+	watchList *filterWatchList) (*wire.MsgBlock, error) {
+
+	if !fetched.filterMiss {
+		return fetched.block, nil
+	}
+
+	scripts, ok := c.rescanWatchList(watchList)
+	if ok && watchList.version == fetched.watchVersion {
+		return fetched.block, nil
+	}
+	if ok {
+		filterType := btcjson.FilterTypeBasic
+		res, err := c.chainConn.client.GetBlockFilter(
+			fetched.hash, &filterType,
+		)
+		match, ok := c.handleBlockFilter(&fetched.hash, res, err, scripts)
+		if ok && !match {
+			fetched.watchVersion = watchList.version
+			return fetched.block, nil
+		}
+	}
+
+	log.Debugf("Watch list changed since block %d (%v) was prefetched, "+
+		"fetching full block", fetched.height, fetched.hash)
+
+	return c.GetBlock(&fetched.hash)
+}
+
+// fetchFilterBlocks fetches the given blocks for FilterBlocks. Blocks whose
+// compact filter doesn't match the watch list are returned without a block.
+func (c *BitcoindClient) fetchFilterBlocks(client blockBatchClient,
+	metas []wtxmgr.BlockMeta, watchList [][]byte) ([]*fetchedBlock, error) {
+
+	blocks := make([]*fetchedBlock, len(metas))
+	needed := make([]*fetchedBlock, len(metas))
+	for i, meta := range metas {
+		blocks[i] = &fetchedBlock{
+			height: meta.Height,
+			hash:   meta.Hash,
+		}
+		needed[i] = blocks[i]
+	}
+
+	needed = c.batchMatchFilters(client, needed, watchList)
+	if err := c.batchGetBlocks(client, needed); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// batchMatchFilters returns the blocks whose compact filter matches the
+// watch list, or for which no filter could be obtained. The passed slice is
+// reused for the result.
+func (c *BitcoindClient) batchMatchFilters(client blockBatchClient,
+	blocks []*fetchedBlock, watchList [][]byte) []*fetchedBlock {
+
+	if len(blocks) == 0 ||
+		atomic.LoadInt32(&c.blockFilters) == blockFiltersDisabled {
+
+		return blocks
+	}
+
+	filterType := btcjson.FilterTypeBasic
+	results := make([]rpcclient.FutureGetBlockFilterResult, len(blocks))
+	for i, block := range blocks {
+		results[i] = client.GetBlockFilterAsync(block.hash, &filterType)
+	}
+	if err := client.Send(); err != nil {
+		log.Debugf("Unable to fetch block filters, scanning full "+
+			"blocks: %v", err)
+		return blocks
+	}
+
+	matched := blocks[:0]
+	for i, block := range blocks {
+		res, err := results[i].Receive()
+		match, ok := c.handleBlockFilter(&block.hash, res, err, watchList)
+		if ok && !match {
+			continue
+		}
+		matched = append(matched, block)
+	}
+
+	return matched
+}
+
+// batchGetBlocks fetches the full blocks.
+func (c *BitcoindClient) batchGetBlocks(client blockBatchClient,
+	blocks []*fetchedBlock) error {
+
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	results := make([]rpcclient.FutureGetBlockResult, len(blocks))
+	for i, block := range blocks {
+		results[i] = client.GetBlockAsync(&block.hash)
+	}
+	if err := client.Send(); err != nil {
+		return err
+	}
+
+	for i, block := range blocks {
+		msgBlock, err := results[i].Receive()
+		if err != nil {
+			// Blocks the node has pruned are retrieved from its
+			// peers by the connection.
+			msgBlock, err = c.GetBlock(&block.hash)
+			if err != nil {
+				return err
+			}
+		}
+		block.block = msgBlock
+	}
+
+	return nil
+}
+
+// rescanETA estimates the remaining time of a rescan from the rate at which
+// blocks were processed so far.
+type rescanETA struct {
+	start       time.Time
+	startHeight int32
+}
+
+// newRescanETA starts estimating a rescan starting after the given height.
+func newRescanETA(startHeight int32) *rescanETA {
+	return &rescanETA{
+		start:       time.Now(),
+		startHeight: startHeight,
+	}
+}
+
+// estimate returns the estimated time until the rescan reaches bestHeight,
+// or zero if no estimate is possible yet.
+func (r *rescanETA) estimate(height, bestHeight int32) time.Duration {
+	done := height - r.startHeight
+	if done <= 0 || height >= bestHeight {
+		return 0
+	}
+
+	perBlock := time.Since(r.start) / time.Duration(done)
+	return perBlock * time.Duration(bestHeight-height)
+}
@@ -0,0 +1,215 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcChain is a bitcoind JSON-RPC server serving the blocks of a test chain
+// and their compact filters.
+type rpcChain struct {
+	t      *testing.T
+	srv    *httptest.Server
+	blocks []*wire.MsgBlock
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newRPCChain(t *testing.T, blocks []*wire.MsgBlock) *rpcChain {
+	c := &rpcChain{t: t, blocks: blocks, calls: make(map[string]int)}
+	c.srv = httptest.NewServer(c)
+	t.Cleanup(c.srv.Close)
+	return c
+}
+
+// numCalls returns the number of requests received for a method.
+func (c *rpcChain) numCalls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+func (c *rpcChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	require.NoError(c.t, json.NewDecoder(r.Body).Decode(&body))
+
+	var resp interface{}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var reqs []btcjson.Request
+		require.NoError(c.t, json.Unmarshal(body, &reqs))
+		resps := make([]btcjson.Response, len(reqs))
+		for i := range reqs {
+			resps[i] = c.handle(&reqs[i])
+		}
+		resp = resps
+	} else {
+		var req btcjson.Request
+		require.NoError(c.t, json.Unmarshal(body, &req))
+		resp = c.handle(&req)
+	}
+
+	require.NoError(c.t, json.NewEncoder(w).Encode(resp))
+}
+
+func (c *rpcChain) handle(req *btcjson.Request) btcjson.Response {
+	c.mu.Lock()
+	c.calls[req.Method]++
+	c.mu.Unlock()
+
+	var result interface{}
+	switch req.Method {
+	case "getblockhash":
+		var height int
+		require.NoError(c.t, json.Unmarshal(req.Params[0], &height))
+		result = c.blocks[height].BlockHash().String()
+
+	case "getblockheader":
+		var buf bytes.Buffer
+		require.NoError(c.t, c.block(req).Header.Serialize(&buf))
+		result = hex.EncodeToString(buf.Bytes())
+
+	case "getblockfilter":
+		filter, err := builder.BuildBasicFilter(c.block(req), nil)
+		require.NoError(c.t, err)
+		rawFilter, err := filter.NBytes()
+		require.NoError(c.t, err)
+		result = btcjson.GetBlockFilterResult{
+			Filter: hex.EncodeToString(rawFilter),
+			Header: (&chainhash.Hash{}).String(),
+		}
+
+	case "getblock":
+		var buf bytes.Buffer
+		require.NoError(c.t, c.block(req).Serialize(&buf))
+		result = hex.EncodeToString(buf.Bytes())
+
+	default:
+		c.t.Errorf("unexpected method %s", req.Method)
+	}
+
+	raw, err := json.Marshal(result)
+	require.NoError(c.t, err)
+	return btcjson.Response{Result: raw, ID: &req.ID}
+}
+
+// block returns the block whose hash is the first parameter of a request.
+func (c *rpcChain) block(req *btcjson.Request) *wire.MsgBlock {
+	var hash string
+	require.NoError(c.t, json.Unmarshal(req.Params[0], &hash))
+	for _, block := range c.blocks {
+		if block.BlockHash().String() == hash {
+			return block
+		}
+	}
+	c.t.Fatalf("unknown block %s", hash)
+	return nil
+}
+
+// TestRescanBlockWatchListChange checks that a block prefetched without its
+// transactions because its compact filter didn't match the watch list is
+// fetched in full when it is scanned after a matching address was added to
+// the watch list.
+func TestRescanBlockWatchListChange(t *testing.T) {
+	t.Parallel()
+
+	watchedAddr, _ := testFilterAddr(t, 1)
+	newAddr, newScript := testFilterAddr(t, 2)
+	_, otherScript := testFilterAddr(t, 3)
+
+	// The first block pays to an address that is never watched, and the
+	// second one to the address watched during the rescan.
+	blocks := make([]*wire.MsgBlock, 3)
+	for i, script := range [][]byte{otherScript, otherScript, newScript} {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{})
+		tx.AddTxOut(wire.NewTxOut(1000, script))
+		blocks[i] = &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Timestamp: time.Unix(int64(1000+i), 0),
+			},
+			Transactions: []*wire.MsgTx{tx},
+		}
+	}
+	node := newRPCChain(t, blocks)
+
+	cfg := &rpcclient.ConnConfig{
+		Host:         strings.TrimPrefix(node.srv.URL, "http://"),
+		User:         "user",
+		Pass:         "pass",
+		HTTPPostMode: true,
+		DisableTLS:   true,
+	}
+	rpcClient, err := rpcclient.New(cfg, nil)
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Shutdown)
+	batchClient, err := rpcclient.NewBatch(cfg)
+	require.NoError(t, err)
+	t.Cleanup(batchClient.Shutdown)
+
+	c := &BitcoindClient{
+		chainConn:        &BitcoindConn{client: rpcClient},
+		watchedAddresses: make(map[string]struct{}),
+		watchedOutPoints: make(map[wire.OutPoint]struct{}),
+		watchedTxs:       make(map[chainhash.Hash]struct{}),
+		addrScripts:      make(map[string][]byte),
+		outPointScripts:  make(map[wire.OutPoint][]byte),
+	}
+	c.watchedAddresses[watchedAddr.String()] = struct{}{}
+	c.watchAddressScript(watchedAddr)
+	c.watchVersion++
+
+	// Neither block matches the watch list when they are prefetched.
+	fetched, err := c.fetchRescanBlocks(batchClient, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, fetched, 2)
+	for _, block := range fetched {
+		require.True(t, block.filterMiss)
+		require.Empty(t, block.block.Transactions)
+	}
+	require.Zero(t, node.numCalls("getblock"))
+
+	// Scanning the first block before the watch list changes doesn't
+	// fetch it again.
+	var watchList filterWatchList
+	block, err := c.rescanBlock(fetched[0], &watchList)
+	require.NoError(t, err)
+	require.Empty(t, block.Transactions)
+	require.Equal(t, 2, node.numCalls("getblockfilter"))
+
+	// The address of the second block is watched before it is scanned,
+	// as done when a relevant transaction is found earlier in the rescan.
+	c.watchMtx.Lock()
+	c.watchedAddresses[newAddr.String()] = struct{}{}
+	c.watchAddressScript(newAddr)
+	c.watchVersion++
+	c.watchMtx.Unlock()
+
+	block, err = c.rescanBlock(fetched[1], &watchList)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions, 1)
+	require.Equal(t, blocks[2].BlockHash(), block.BlockHash())
+	require.Equal(t, 1, node.numCalls("getblock"))
+
+	// The first block is matched against the new watch list as well, but
+	// still isn't fetched in full.
+	block, err = c.rescanBlock(fetched[0], &watchList)
+	require.NoError(t, err)
+	require.Empty(t, block.Transactions)
+	require.Equal(t, 4, node.numCalls("getblockfilter"))
+	require.Equal(t, 1, node.numCalls("getblock"))
+}
@@ -0,0 +1,293 @@
+package chain
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// DefaultBlockPrefetchWorkers is the default number of batches of
+	// blocks that are fetched concurrently during rescans.
+	DefaultBlockPrefetchWorkers = 4
+
+	// DefaultBlockPrefetchBatchSize is the default number of blocks that
+	// are requested from the backend in a single batch.
+	DefaultBlockPrefetchBatchSize = 16
+)
+
+// BlockPrefetchConfig holds the limits of the pipeline that prefetches
+// blocks during rescans and recovery.
+type BlockPrefetchConfig struct {
+	// Workers is the maximum number of batches of blocks that are fetched
+	// concurrently. It also bounds the number of batches that are held
+	// in memory ahead of the block being filtered.
+	Workers int
+
+	// BatchSize is the number of blocks requested in a single batch.
+	BatchSize int
+
+	// MaxBlocksPerSecond limits the rate at which blocks are requested
+	// from the backend. Zero means unlimited.
+	MaxBlocksPerSecond int
+}
+
+// withDefaults returns a copy of the config with unset limits replaced by
+// their defaults.
+func (cfg BlockPrefetchConfig) withDefaults() BlockPrefetchConfig {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultBlockPrefetchWorkers
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBlockPrefetchBatchSize
+	}
+	return cfg
+}
+
+// fetchedBlock is a block returned by a blockPipeline.
+type fetchedBlock struct {
+	height int32
+	hash   chainhash.Hash
+
+	// block is the fetched block. It only holds the header if the block
+	// is known to be irrelevant, and is nil if not even the header is
+	// needed.
+	block *wire.MsgBlock
+
+	// birthdayCrossed is set for the first block of a rescan after the
+	// birthday of the client.
+	birthdayCrossed bool
+
+	// filterMiss is set if only the header was fetched because the
+	// compact filter of the block didn't match the watch list at
+	// watchVersion.
+	filterMiss   bool
+	watchVersion uint64
+}
+
+// blockFetcher fetches the blocks with the indices [start, end) of a
+// pipeline using the given batch client.
+type blockFetcher func(client blockBatchClient, start,
+	end int) ([]*fetchedBlock, error)
+
+// pipelineBatch is the result of fetching a single batch of blocks.
+type pipelineBatch struct {
+	blocks []*fetchedBlock
+	err    error
+}
+
+// blockPipeline prefetches a range of blocks with a bounded number of
+// concurrent batch requests, while returning them strictly in order.
+type blockPipeline struct {
+	cfg   BlockPrefetchConfig
+	n     int
+	fetch blockFetcher
+
+	// nextBatch is the index of the next batch to be fetched by a
+	// worker. This must be used atomically.
+	nextBatch int64
+
+	// slots bounds the number of batches that are in flight or waiting
+	// to be consumed.
+	slots chan struct{}
+
+	// results holds a channel per batch, which the worker fetching the
+	// batch delivers its result on.
+	results []chan pipelineBatch
+
+	// cur and curIdx are the batch being consumed and the index of the
+	// next block within it.
+	cur      pipelineBatch
+	curBatch int
+	curIdx   int
+
+	rateMtx   sync.Mutex
+	nextFetch time.Time
+
+	quit     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// newBlockPipeline starts a pipeline fetching n blocks using fetch. Each of
+// the workers uses its own batch client obtained from newClient, as the
+// requests queued on a batch client are shared until sent.
+func newBlockPipeline(cfg BlockPrefetchConfig, n int,
+	newClient func() (blockBatchClient, error),
+	fetch blockFetcher) *blockPipeline {
+
+	cfg = cfg.withDefaults()
+	numBatches := (n + cfg.BatchSize - 1) / cfg.BatchSize
+
+	p := &blockPipeline{
+		cfg:     cfg,
+		n:       n,
+		fetch:   fetch,
+		slots:   make(chan struct{}, cfg.Workers),
+		results: make([]chan pipelineBatch, numBatches),
+		quit:    make(chan struct{}),
+	}
+	for i := range p.results {
+		p.results[i] = make(chan pipelineBatch, 1)
+	}
+
+	workers := cfg.Workers
+	if workers > numBatches {
+		workers = numBatches
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker(newClient)
+	}
+
+	return p
+}
+
+// worker fetches batches in increasing order until all batches are fetched
+// or the pipeline is stopped.
+//
+// NOTE: This must be run as a goroutine.
+func (p *blockPipeline) worker(newClient func() (blockBatchClient, error)) {
+	defer p.wg.Done()
+
+	client, err := newClient()
+	if err != nil {
+		// Fail the next batch so the error surfaces in order, the
+		// remaining batches are picked up by the other workers.
+		p.failNext(err)
+		return
+	}
+	if client != nil {
+		defer client.Shutdown()
+	}
+
+	for {
+		// A slot is acquired before a batch is picked, so the worker
+		// holding the lowest outstanding batch can always proceed.
+		select {
+		case p.slots <- struct{}{}:
+		case <-p.quit:
+			return
+		}
+
+		batch := int(atomic.AddInt64(&p.nextBatch, 1) - 1)
+		if batch >= len(p.results) {
+			<-p.slots
+			return
+		}
+
+		start := batch * p.cfg.BatchSize
+		end := start + p.cfg.BatchSize
+		if end > p.n {
+			end = p.n
+		}
+		if !p.throttle(end - start) {
+			return
+		}
+
+		blocks, err := p.fetch(client, start, end)
+		p.results[batch] <- pipelineBatch{blocks: blocks, err: err}
+	}
+}
+
+// failNext fails the next batch that isn't picked up by a worker yet.
+func (p *blockPipeline) failNext(err error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-p.quit:
+		return
+	}
+
+	batch := int(atomic.AddInt64(&p.nextBatch, 1) - 1)
+	if batch >= len(p.results) {
+		<-p.slots
+		return
+	}
+	p.results[batch] <- pipelineBatch{err: err}
+}
+
+// throttle waits until n more blocks may be requested without exceeding
+// the configured rate. It returns false if the pipeline was stopped.
+func (p *blockPipeline) throttle(n int) bool {
+	if p.cfg.MaxBlocksPerSecond <= 0 {
+		return true
+	}
+
+	p.rateMtx.Lock()
+	now := time.Now()
+	if p.nextFetch.Before(now) {
+		p.nextFetch = now
+	}
+	wait := p.nextFetch.Sub(now)
+	p.nextFetch = p.nextFetch.Add(
+		time.Duration(n) * time.Second /
+			time.Duration(p.cfg.MaxBlocksPerSecond),
+	)
+	p.rateMtx.Unlock()
+
+	if wait <= 0 {
+		return true
+	}
+	select {
+	case <-time.After(wait):
+		return true
+	case <-p.quit:
+		return false
+	}
+}
+
+// Next returns the next block of the range, in order. It returns nil once
+// all blocks were returned or the pipeline was stopped.
+func (p *blockPipeline) Next() (*fetchedBlock, error) {
+	select {
+	case <-p.quit:
+		return nil, nil
+	default:
+	}
+
+	for p.cur.blocks == nil || p.curIdx >= len(p.cur.blocks) {
+		// Release the slot of the consumed batch, so a worker can move
+		// on to the next one.
+		if p.cur.blocks != nil {
+			<-p.slots
+			p.cur = pipelineBatch{}
+			p.curBatch++
+		}
+		if p.curBatch >= len(p.results) {
+			return nil, nil
+		}
+
+		select {
+		case batch := <-p.results[p.curBatch]:
+			if batch.err != nil {
+				return nil, batch.err
+			}
+			p.cur = batch
+			p.curIdx = 0
+
+			// Empty batches are skipped, releasing their slot.
+			if p.cur.blocks == nil {
+				p.cur.blocks = []*fetchedBlock{}
+			}
+
+		case <-p.quit:
+			return nil, nil
+		}
+	}
+
+	block := p.cur.blocks[p.curIdx]
+	p.curIdx++
+	return block, nil
+}
+
+// Stop stops the workers and waits for them to exit. Blocks that were not
+// returned yet are discarded.
+func (p *blockPipeline) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.quit)
+	})
+	p.wg.Wait()
+}
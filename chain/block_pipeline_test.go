@@ -0,0 +1,182 @@
+package chain
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// nilBatchClient is a client factory for pipelines whose fetcher doesn't use
+// the client.
+func nilBatchClient() (blockBatchClient, error) {
+	return nil, nil
+}
+
+// TestBlockPipelineOrder checks that blocks fetched out of order by
+// concurrent workers are returned in order, and that no more batches than
+// workers are in flight or waiting at any time.
+func TestBlockPipelineOrder(t *testing.T) {
+	t.Parallel()
+
+	const (
+		n       = 103
+		workers = 3
+	)
+
+	var (
+		mu          sync.Mutex
+		outstanding int
+		maxOut      int
+	)
+	fetch := func(_ blockBatchClient, start,
+		end int) ([]*fetchedBlock, error) {
+
+		mu.Lock()
+		outstanding++
+		if outstanding > maxOut {
+			maxOut = outstanding
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+
+		blocks := make([]*fetchedBlock, 0, end-start)
+		for i := start; i < end; i++ {
+			blocks = append(blocks, &fetchedBlock{height: int32(i)})
+		}
+		return blocks, nil
+	}
+
+	p := newBlockPipeline(BlockPrefetchConfig{
+		Workers:   workers,
+		BatchSize: 5,
+	}, n, nilBatchClient, fetch)
+	defer p.Stop()
+
+	for i := 0; i < n; i++ {
+		block, err := p.Next()
+		require.NoError(t, err)
+		require.EqualValues(t, i, block.height)
+
+		// A batch is outstanding until it was fully consumed.
+		if (i+1)%5 == 0 || i == n-1 {
+			mu.Lock()
+			outstanding--
+			mu.Unlock()
+		}
+	}
+
+	block, err := p.Next()
+	require.NoError(t, err)
+	require.Nil(t, block)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.LessOrEqual(t, maxOut, workers)
+}
+
+// TestBlockPipelineError checks that a failed batch is reported once the
+// blocks before it were consumed.
+func TestBlockPipelineError(t *testing.T) {
+	t.Parallel()
+
+	errFetch := errors.New("fetch failed")
+	fetch := func(_ blockBatchClient, start,
+		end int) ([]*fetchedBlock, error) {
+
+		if start == 4 {
+			return nil, errFetch
+		}
+		blocks := make([]*fetchedBlock, 0, end-start)
+		for i := start; i < end; i++ {
+			blocks = append(blocks, &fetchedBlock{height: int32(i)})
+		}
+		return blocks, nil
+	}
+
+	p := newBlockPipeline(BlockPrefetchConfig{
+		Workers:   2,
+		BatchSize: 2,
+	}, 10, nilBatchClient, fetch)
+	defer p.Stop()
+
+	for i := 0; i < 4; i++ {
+		block, err := p.Next()
+		require.NoError(t, err)
+		require.EqualValues(t, i, block.height)
+	}
+	_, err := p.Next()
+	require.ErrorIs(t, err, errFetch)
+
+	// A failing client factory is reported the same way.
+	errClient := errors.New("no client")
+	p = newBlockPipeline(BlockPrefetchConfig{Workers: 1}, 10,
+		func() (blockBatchClient, error) {
+			return nil, errClient
+		}, fetch,
+	)
+	defer p.Stop()
+
+	_, err = p.Next()
+	require.ErrorIs(t, err, errClient)
+}
+
+// TestBlockPipelineStop checks that stopping a pipeline doesn't wait for the
+// remaining blocks to be fetched.
+func TestBlockPipelineStop(t *testing.T) {
+	t.Parallel()
+
+	var fetched int32
+	fetch := func(_ blockBatchClient, start,
+		end int) ([]*fetchedBlock, error) {
+
+		atomic.AddInt32(&fetched, int32(end-start))
+		return []*fetchedBlock{{height: int32(start)}}, nil
+	}
+
+	p := newBlockPipeline(BlockPrefetchConfig{
+		Workers:            1,
+		BatchSize:          1,
+		MaxBlocksPerSecond: 10,
+	}, 1000, nilBatchClient, fetch)
+
+	_, err := p.Next()
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout stopping pipeline")
+	}
+
+	require.Less(t, atomic.LoadInt32(&fetched), int32(10))
+
+	block, err := p.Next()
+	require.NoError(t, err)
+	require.Nil(t, block)
+}
+
+// TestRescanETA checks the estimate of the remaining rescan time.
+func TestRescanETA(t *testing.T) {
+	t.Parallel()
+
+	eta := newRescanETA(100)
+	eta.start = time.Now().Add(-10 * time.Second)
+
+	require.Zero(t, eta.estimate(100, 200))
+	require.Zero(t, eta.estimate(200, 200))
+	require.InDelta(
+		t, float64(10*time.Second),
+		float64(eta.estimate(150, 200)), float64(time.Second),
+	)
+}
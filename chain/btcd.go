@@ -480,7 +480,11 @@ func (c *RPCClient) onRedeemingTx(tx *btcutil.Tx, block *btcjson.BlockDetails) {
 
 func (c *RPCClient) onRescanProgress(hash *chainhash.Hash, height int32, blkTime time.Time) {
 	select {
-	case c.enqueueNotification <- &RescanProgress{*hash, height, blkTime}:
+	case c.enqueueNotification <- &RescanProgress{
+		Hash:   *hash,
+		Height: height,
+		Time:   blkTime,
+	}:
 	case <-c.quit:
 	}
 }
@@ -120,6 +120,12 @@ type (
 		Hash   chainhash.Hash
 		Height int32
 		Time   time.Time
+
+		// BestHeight is the height the rescan is running to, and ETA
+		// the estimated time until it gets there. They are zero if the
+		// backend doesn't estimate the progress of rescans.
+		BestHeight int32
+		ETA        time.Duration
 	}
 
 	// RescanFinished is a notification that a previous rescan request
@@ -164,6 +170,37 @@ type batchClient interface {
 	Send() error
 }
 
+// blockBatchClient defines the batched requests used to prefetch blocks
+// during rescans.
+//
+// NOTE: the client returned from `rpcclient.NewBatch` will implement this
+// interface. The requests queued on the client are shared until Send is
+// called, so it must not be used concurrently.
+type blockBatchClient interface {
+	// GetBlockHashAsync queues a request for the hash of the block at the
+	// given height.
+	GetBlockHashAsync(blockHeight int64) rpcclient.FutureGetBlockHashResult
+
+	// GetBlockHeaderAsync queues a request for the header of a block.
+	GetBlockHeaderAsync(
+		blockHash *chainhash.Hash) rpcclient.FutureGetBlockHeaderResult
+
+	// GetBlockFilterAsync queues a request for the compact filter of a
+	// block.
+	GetBlockFilterAsync(blockHash chainhash.Hash,
+		filterType *btcjson.FilterTypeName) rpcclient.FutureGetBlockFilterResult
+
+	// GetBlockAsync queues a request for a full block.
+	GetBlockAsync(blockHash *chainhash.Hash) rpcclient.FutureGetBlockResult
+
+	// Send sends all queued requests to the server in a single batch and
+	// waits for their responses.
+	Send() error
+
+	// Shutdown shuts down the client.
+	Shutdown()
+}
+
 // getRawTxReceiver defines an interface that's used to receive response from
 // `GetRawTransactionAsync`.
 type getRawTxReceiver interface {
@@ -0,0 +1,104 @@
+// bakemacaroon restricts a macaroon with caveats and prints the new
+// macaroon.  The root key is not needed, so any holder of a macaroon can hand
+// out a more restricted macaroon to another client, for example a read-only
+// macaroon to a monitoring service.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/jessevdk/go-flags"
+	"github.com/stroomnetwork/btcwallet/cfgutil"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+)
+
+var walletDataDirectory = btcutil.AppDataDir("btcwallet", false)
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, args...)
+	fmt.Fprintln(os.Stderr)
+	os.Exit(1)
+}
+
+// Flags.
+var opts = struct {
+	Macaroon     string              `long:"macaroon" description:"File of the macaroon to restrict"`
+	Permissions  string              `long:"perms" description:"Comma separated permissions to allow (read, address, sign, send, admin)"`
+	Accounts     string              `long:"accounts" description:"Comma separated account numbers to allow"`
+	Wallets      []string            `long:"wallet" description:"Name of a wallet to allow, may be repeated (the default wallet is --wallet=\"\")"`
+	TxSpendLimit *cfgutil.AmountFlag `long:"txspendlimit" description:"Maximum amount in BTC a single transaction may send to other wallets and pay in fees -- Not cumulative, combine with --timeout"`
+	Timeout      time.Duration       `long:"timeout" description:"Duration after which the macaroon expires (e.g. 720h)"`
+	Output       string              `short:"o" long:"output" description:"Write the macaroon to this file instead of stdout"`
+}{
+	Macaroon: filepath.Join(walletDataDirectory, "mainnet", macaroons.AdminFilename),
+}
+
+func main() {
+	if _, err := flags.Parse(&opts); err != nil {
+		os.Exit(1)
+	}
+
+	m, err := macaroons.ReadFile(opts.Macaroon)
+	if err != nil {
+		fatalf("Cannot read macaroon: %v", err)
+	}
+
+	if opts.Permissions != "" {
+		var perms []macaroons.Permission
+		for _, s := range strings.Split(opts.Permissions, ",") {
+			perm := macaroons.Permission(strings.TrimSpace(s))
+			if !validPermission(perm) {
+				fatalf("Unknown permission `%s`", perm)
+			}
+			perms = append(perms, perm)
+		}
+		m = m.WithCaveat(macaroons.PermissionsCaveat(perms...))
+	}
+	if opts.Accounts != "" {
+		var accounts []uint32
+		for _, s := range strings.Split(opts.Accounts, ",") {
+			account, err := strconv.ParseUint(
+				strings.TrimSpace(s), 10, 32,
+			)
+			if err != nil {
+				fatalf("Invalid account number `%s`", s)
+			}
+			accounts = append(accounts, uint32(account))
+		}
+		m = m.WithCaveat(macaroons.AccountsCaveat(accounts...))
+	}
+	if len(opts.Wallets) != 0 {
+		m = m.WithCaveat(macaroons.WalletsCaveat(opts.Wallets...))
+	}
+	if opts.TxSpendLimit != nil {
+		m = m.WithCaveat(macaroons.TxSpendLimitCaveat(opts.TxSpendLimit.Amount))
+	}
+	if opts.Timeout > 0 {
+		expiry := time.Now().Add(opts.Timeout)
+		m = m.WithCaveat(macaroons.ExpiryCaveat(expiry))
+	}
+
+	if opts.Output == "" {
+		fmt.Println(m)
+		return
+	}
+	err = os.WriteFile(opts.Output, []byte(m.String()), 0600)
+	if err != nil {
+		fatalf("Cannot write macaroon: %v", err)
+	}
+}
+
+func validPermission(perm macaroons.Permission) bool {
+	for _, p := range macaroons.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+// verifyauditlog checks the chain of hashes of an RPC audit log with the key
+// of the log and prints the number of entries and the hash of the last entry.  The hash should be
+// compared to a copy kept elsewhere to detect entries removed from the end of
+// the log.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintf(os.Stderr, "Usage: %s <auditlogkey> <auditlog>\n",
+			os.Args[0])
+		os.Exit(2)
+	}
+
+	key, err := audit.ReadKey(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	f, err := os.Open(os.Args[2])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	seq, head, err := audit.Verify(f, key)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%d entries, head %s\n", seq, head)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// compactTxMaxSize is the number of bytes copied per transaction while
+// compacting.
+const compactTxMaxSize = 64 * 1024
+
+// compactCommand rewrites the database into a new file without free pages.
+type compactCommand struct {
+	Out string `long:"out" description:"Write the compacted database to this path instead of replacing the original"`
+}
+
+// Execute runs the compact command.
+func (c *compactCommand) Execute(args []string) error {
+	info, err := os.Stat(opts.DbPath)
+	if err != nil {
+		return err
+	}
+
+	// The source is opened read-write so that it stays exclusively locked
+	// until it has been replaced.
+	src, err := bolt.Open(opts.DbPath, 0600, &bolt.Options{
+		Timeout: opts.Timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer src.Close()
+
+	out := c.Out
+	if out == "" {
+		f, err := os.CreateTemp(
+			filepath.Dir(opts.DbPath), ".walletdbtool-compact",
+		)
+		if err != nil {
+			return err
+		}
+		out = f.Name()
+		f.Close()
+		defer os.Remove(out)
+	} else if _, err := os.Stat(out); err == nil {
+		return fmt.Errorf("%s already exists", out)
+	}
+
+	dst, err := bolt.Open(out, info.Mode().Perm(), &bolt.Options{
+		Timeout: opts.Timeout,
+	})
+	if err != nil {
+		return err
+	}
+	if err := bolt.Compact(dst, src, compactTxMaxSize); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	outInfo, err := os.Stat(out)
+	if err != nil {
+		return err
+	}
+
+	if c.Out == "" {
+		if err := os.Rename(out, opts.DbPath); err != nil {
+			return err
+		}
+		out = opts.DbPath
+	}
+
+	fmt.Printf("Compacted %s: %d -> %d bytes\n", out, info.Size(),
+		outInfo.Size())
+	return nil
+}
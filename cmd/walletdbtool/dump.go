@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// dumpCommand prints the bucket tree of the database.
+type dumpCommand struct {
+	Raw    bool   `long:"raw" description:"Print all keys and values as hex instead of decoding known records"`
+	Bucket string `long:"bucket" description:"Only print the top-level bucket with this name (e.g. waddrmgr or wtxmgr)"`
+}
+
+// Execute runs the dump command.
+func (c *dumpCommand) Execute(args []string) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	w := bufio.NewWriter(os.Stdout)
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		return tx.ForEachBucket(func(k []byte) error {
+			if c.Bucket != "" && string(k) != c.Bucket {
+				return nil
+			}
+			fmt.Fprintf(w, "%s/\n", keyString(k))
+			path := []string{string(k)}
+			return c.dumpBucket(w, tx.ReadBucket(k), path)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// dumpBucket writes the entries and nested buckets of b, indented by the
+// depth of path.
+func (c *dumpCommand) dumpBucket(w io.Writer, b walletdb.ReadBucket,
+	path []string) error {
+
+	indent := strings.Repeat("  ", len(path))
+	decode := decoderFor(path)
+	if c.Raw {
+		decode = nil
+	}
+
+	return b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			if nested := b.NestedReadBucket(k); nested != nil {
+				fmt.Fprintf(w, "%s%s/\n", indent,
+					bucketString(path, k))
+				return c.dumpBucket(
+					w, nested, append(path, string(k)),
+				)
+			}
+		}
+
+		if decode != nil {
+			if s, ok := decode(k, v); ok {
+				fmt.Fprintf(w, "%s%s\n", indent, s)
+				return nil
+			}
+		}
+		fmt.Fprintf(w, "%s%s: %x\n", indent, keyString(k), v)
+		return nil
+	})
+}
+
+// keyString returns k as text if it is printable ASCII, and as hex otherwise.
+func keyString(k []byte) string {
+	for _, b := range k {
+		if b < 0x20 || b > 0x7e {
+			return hex.EncodeToString(k)
+		}
+	}
+	return string(k)
+}
+
+// bucketString returns the display name of the nested bucket k of the bucket
+// at path.  Address manager scopes are shown as their derivation path.
+func bucketString(path []string, k []byte) string {
+	if len(path) == 2 && path[0] == string(waddrmgrNamespaceKey) &&
+		path[1] == "scope" {
+
+		if s, ok := scopeString(k); ok {
+			return s
+		}
+	}
+	return keyString(k)
+}
+
+// decoder formats a single key/value pair of a known bucket.  It returns false
+// if the pair does not have the expected format, in which case it is printed
+// as hex.
+type decoder func(k, v []byte) (string, bool)
+
+// decoderFor returns the decoder for the records of the bucket at path, or nil
+// if the records of the bucket are not known.
+func decoderFor(path []string) decoder {
+	switch {
+	case len(path) == 1 && path[0] == string(wtxmgrNamespaceKey):
+		return decodeTxStoreRoot
+
+	case len(path) == 2 && path[0] == string(wtxmgrNamespaceKey):
+		return txStoreDecoders[path[1]]
+
+	case len(path) == 2 && path[0] == string(waddrmgrNamespaceKey):
+		switch path[1] {
+		case "main":
+			return decodeManagerMain
+		case "sync":
+			return decodeManagerSync
+		case "scope-schema":
+			return decodeScopeSchema
+		}
+
+	case len(path) == 3 && path[0] == string(waddrmgrNamespaceKey) &&
+		path[1] == "scope":
+
+		return decodeScope
+
+	case len(path) == 4 && path[0] == string(waddrmgrNamespaceKey) &&
+		path[1] == "scope":
+
+		switch path[3] {
+		case "acctnameidx":
+			return decodeAccountNameIndex
+		case "acctididx":
+			return decodeAccountIDIndex
+		}
+	}
+
+	return nil
+}
+
+// hashString formats a serialized hash the way it is displayed elsewhere.
+func hashString(b []byte) string {
+	var hash chainhash.Hash
+	copy(hash[:], b)
+	return hash.String()
+}
+
+// outPointString formats a canonical serialized outpoint.
+func outPointString(b []byte) string {
+	return fmt.Sprintf("%s:%d", hashString(b[:32]), byteOrder.Uint32(b[32:36]))
+}
+
+// amountString formats a serialized amount.
+func amountString(b []byte) string {
+	return btcutil.Amount(byteOrder.Uint64(b)).String()
+}
+
+// unixString formats a serialized unix timestamp.
+func unixString(secs uint64) string {
+	return time.Unix(int64(secs), 0).UTC().Format(time.RFC3339)
+}
+
+// Address manager records.  Integers of the address manager are little endian
+// unless noted otherwise.
+
+func decodeManagerMain(k, v []byte) (string, bool) {
+	switch string(k) {
+	case "mgrver":
+		if len(v) == 4 {
+			return fmt.Sprintf("version: %d",
+				binary.LittleEndian.Uint32(v)), true
+		}
+	case "mgrcreated":
+		if len(v) == 8 {
+			return fmt.Sprintf("created: %s", unixString(
+				binary.LittleEndian.Uint64(v))), true
+		}
+	case "watchonly":
+		if len(v) == 1 {
+			return fmt.Sprintf("watching-only: %v", v[0] != 0), true
+		}
+	default:
+		return fmt.Sprintf("%s: <encrypted, %d bytes>",
+			keyString(k), len(v)), true
+	}
+	return "", false
+}
+
+func decodeManagerSync(k, v []byte) (string, bool) {
+	switch string(k) {
+	case "syncedto":
+		if len(v) < 36 {
+			break
+		}
+		s := fmt.Sprintf("synced to: %d %s",
+			int32(binary.LittleEndian.Uint32(v)), hashString(v[4:36]))
+		if len(v) == 40 {
+			s += " " + unixString(uint64(
+				binary.LittleEndian.Uint32(v[36:])))
+		}
+		return s, true
+	case "startblock":
+		if len(v) == 36 {
+			return fmt.Sprintf("start block: %d %s",
+				int32(binary.LittleEndian.Uint32(v)),
+				hashString(v[4:36])), true
+		}
+	case "birthday":
+		if len(v) == 8 {
+			return fmt.Sprintf("birthday: %s", unixString(
+				binary.BigEndian.Uint64(v))), true
+		}
+	case "birthdayblock":
+		if len(v) == 44 {
+			return fmt.Sprintf("birthday block: %d %s %s",
+				int32(binary.BigEndian.Uint32(v)),
+				hashString(v[4:36]), unixString(
+					binary.BigEndian.Uint64(v[36:]))), true
+		}
+	case "birthdayblockverified":
+		if len(v) == 2 {
+			return fmt.Sprintf("birthday block verified: %v",
+				binary.BigEndian.Uint16(v) != 0), true
+		}
+	default:
+		// Entries of the block index are keyed by big endian height.
+		if len(k) == 4 && len(v) == chainhash.HashSize {
+			return fmt.Sprintf("block %d: %s",
+				int32(binary.BigEndian.Uint32(k)),
+				hashString(v)), true
+		}
+	}
+	return "", false
+}
+
+// scopeString formats a serialized key scope as its derivation path prefix.
+func scopeString(k []byte) (string, bool) {
+	if len(k) != 8 {
+		return "", false
+	}
+	return fmt.Sprintf("m/%d'/%d'", binary.LittleEndian.Uint32(k),
+		binary.LittleEndian.Uint32(k[4:])), true
+}
+
+func decodeScopeSchema(k, v []byte) (string, bool) {
+	scope, ok := scopeString(k)
+	if !ok || len(v) != 2 {
+		return "", false
+	}
+	return fmt.Sprintf("%s: internal address type %d, external address "+
+		"type %d", scope, v[0], v[1]), true
+}
+
+func decodeScope(k, v []byte) (string, bool) {
+	return fmt.Sprintf("%s: <encrypted, %d bytes>", keyString(k),
+		len(v)), true
+}
+
+func decodeAccountNameIndex(k, v []byte) (string, bool) {
+	if len(k) < 4 || len(v) != 4 {
+		return "", false
+	}
+	return fmt.Sprintf("account %q: %d", k[4:],
+		binary.LittleEndian.Uint32(v)), true
+}
+
+func decodeAccountIDIndex(k, v []byte) (string, bool) {
+	if len(k) != 4 || len(v) < 4 {
+		return "", false
+	}
+	return fmt.Sprintf("account %d: %q", binary.LittleEndian.Uint32(k),
+		v[4:]), true
+}
+
+// Transaction store records.  Integers of the transaction store are big
+// endian.
+
+func decodeTxStoreRoot(k, v []byte) (string, bool) {
+	switch string(k) {
+	case "vers":
+		if len(v) == 4 {
+			return fmt.Sprintf("version: %d", byteOrder.Uint32(v)),
+				true
+		}
+	case "date":
+		if len(v) == 8 {
+			return fmt.Sprintf("created: %s",
+				unixString(byteOrder.Uint64(v))), true
+		}
+	case "bal":
+		if len(v) == 8 {
+			return fmt.Sprintf("mined balance: %s",
+				amountString(v)), true
+		}
+	}
+	return "", false
+}
+
+var txStoreDecoders = map[string]decoder{
+	string(bucketBlocks): func(k, v []byte) (string, bool) {
+		if len(k) != 4 || len(v) < 44 ||
+			len(v) != 44+32*int(byteOrder.Uint32(v[40:44])) {
+
+			return "", false
+		}
+		s := fmt.Sprintf("block %d %s %s:", int32(byteOrder.Uint32(k)),
+			hashString(v[:32]), unixString(byteOrder.Uint64(v[32:40])))
+		for i := 44; i < len(v); i += 32 {
+			s += " " + hashString(v[i:i+32])
+		}
+		return s, true
+	},
+	string(bucketTxRecords): func(k, v []byte) (string, bool) {
+		if len(k) != 68 || len(v) < 8 {
+			return "", false
+		}
+		return fmt.Sprintf("tx %s in block %d: received %s, %d bytes",
+			hashString(k[:32]), int32(byteOrder.Uint32(k[32:36])),
+			unixString(byteOrder.Uint64(v)), len(v)-8), true
+	},
+	string(bucketCredits): func(k, v []byte) (string, bool) {
+		if len(k) != 72 || (len(v) != 9 && len(v) != 81) {
+			return "", false
+		}
+		s := fmt.Sprintf("credit %s:%d in block %d: %s",
+			hashString(k[:32]), byteOrder.Uint32(k[68:72]),
+			int32(byteOrder.Uint32(k[32:36])), amountString(v))
+		if v[8]&creditFlagChange != 0 {
+			s += ", change"
+		}
+		if v[8]&creditFlagSpent != 0 {
+			s += ", spent"
+		}
+		if len(v) == 81 {
+			s += fmt.Sprintf(" by %s input %d in block %d",
+				hashString(v[9:41]), byteOrder.Uint32(v[77:81]),
+				int32(byteOrder.Uint32(v[41:45])))
+		}
+		return s, true
+	},
+	string(bucketUnspent): func(k, v []byte) (string, bool) {
+		if len(k) != 36 || len(v) != 36 {
+			return "", false
+		}
+		return fmt.Sprintf("unspent %s in block %d", outPointString(k),
+			int32(byteOrder.Uint32(v))), true
+	},
+	string(bucketDebits): func(k, v []byte) (string, bool) {
+		if len(k) != 72 || len(v) != 80 {
+			return "", false
+		}
+		return fmt.Sprintf("debit %s input %d in block %d: %s from "+
+			"%s:%d in block %d", hashString(k[:32]),
+			byteOrder.Uint32(k[68:72]), int32(byteOrder.Uint32(k[32:36])),
+			amountString(v), hashString(v[8:40]),
+			byteOrder.Uint32(v[76:80]),
+			int32(byteOrder.Uint32(v[40:44]))), true
+	},
+	string(bucketUnmined): func(k, v []byte) (string, bool) {
+		if len(k) != 32 || len(v) < 8 {
+			return "", false
+		}
+		return fmt.Sprintf("unmined tx %s: received %s, %d bytes",
+			hashString(k), unixString(byteOrder.Uint64(v)),
+			len(v)-8), true
+	},
+	string(bucketUnminedCredits): func(k, v []byte) (string, bool) {
+		if len(k) != 36 || len(v) != 9 {
+			return "", false
+		}
+		s := fmt.Sprintf("unmined credit %s: %s", outPointString(k),
+			amountString(v))
+		if v[8]&creditFlagChange != 0 {
+			s += ", change"
+		}
+		return s, true
+	},
+	string(bucketUnminedInputs): func(k, v []byte) (string, bool) {
+		if len(k) != 36 || len(v)%32 != 0 {
+			return "", false
+		}
+		s := fmt.Sprintf("unmined input %s spent by", outPointString(k))
+		for i := 0; i < len(v); i += 32 {
+			s += " " + hashString(v[i:i+32])
+		}
+		return s, true
+	},
+	string(bucketTxLabels): func(k, v []byte) (string, bool) {
+		if len(k) != 32 || len(v) < 2 {
+			return "", false
+		}
+		return fmt.Sprintf("label %s: %q", hashString(k), v[2:]), true
+	},
+	string(bucketLockedOutputs): func(k, v []byte) (string, bool) {
+		if len(k) != 36 || len(v) != 40 {
+			return "", false
+		}
+		return fmt.Sprintf("locked %s by %x until %s", outPointString(k),
+			v[:32], unixString(byteOrder.Uint64(v[32:]))), true
+	},
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stroomnetwork/btcwallet/netparams"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// exportCommand writes the transaction history of the wallet.
+type exportCommand struct {
+	Network     string `long:"network" description:"Network of the wallet (mainnet, testnet3, testnet4, regtest, simnet or signet)" default:"mainnet"`
+	PubPass     string `long:"pubpass" description:"Public passphrase of the wallet" default:"public"`
+	Format      string `long:"format" description:"Output format (csv or jsonl)" default:"csv"`
+	StartHeight int32  `long:"startheight" description:"Height of the first block to export transactions from"`
+	EndHeight   int32  `long:"endheight" description:"Height of the last block to export transactions from, or -1 to include unmined transactions" default:"-1"`
+	Account     string `long:"account" description:"Only export the entries of accounts with this name"`
+	Out         string `long:"out" description:"Write the history to this file instead of stdout"`
+}
+
+// chainParams returns the parameters of the network named by --network.
+func (c *exportCommand) chainParams() (*chaincfg.Params, error) {
+	for _, p := range []netparams.Params{
+		netparams.MainNetParams, netparams.TestNet3Params,
+		netparams.TestNet4Params, netparams.RegtestParams,
+		netparams.SimNetParams, netparams.SigNetParams,
+	} {
+		if p.Name == c.Network {
+			return p.Params, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown network %q", c.Network)
+}
+
+// Execute runs the export command.
+func (c *exportCommand) Execute(args []string) error {
+	params, err := c.chainParams()
+	if err != nil {
+		return err
+	}
+	format, err := wallet.ParseHistoryFormat(c.Format)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	w, err := wallet.Open(db, []byte(c.PubPass), nil, params, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open wallet: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	var f *os.File
+	if c.Out != "" {
+		f, err = os.OpenFile(
+			c.Out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600,
+		)
+		if err != nil {
+			return err
+		}
+		out = f
+	}
+
+	bw := bufio.NewWriter(out)
+	n, err := w.ExportHistory(bw, wallet.HistoryFilter{
+		StartHeight: c.StartHeight,
+		EndHeight:   c.EndHeight,
+		Account:     c.Account,
+	}, format)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(c.Out)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d entries\n", n)
+	return nil
+}
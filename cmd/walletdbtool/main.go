@@ -0,0 +1,108 @@
+// walletdbtool inspects and repairs a wallet database while the wallet is not
+// running.  It can dump the database contents with the address manager and
+// transaction store records decoded, verify the internal consistency of the
+// transaction store and the sync state, fix the inconsistencies it finds,
+// compact the database file, and export the transaction history of the wallet.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/bdb"
+	"github.com/jessevdk/go-flags"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+const defaultNet = "mainnet"
+
+var (
+	datadir = btcutil.AppDataDir("btcwallet", false)
+)
+
+// Flags shared by all commands.
+var opts = struct {
+	DbPath  string        `long:"db" description:"Path to wallet database"`
+	Timeout time.Duration `long:"timeout" description:"Timeout value when opening the wallet database"`
+}{
+	DbPath:  filepath.Join(datadir, defaultNet, wallet.WalletDBName),
+	Timeout: wallet.DefaultDBTimeout,
+}
+
+// Namespace keys of the wallet database, matching those used by the wallet
+// package.
+var (
+	waddrmgrNamespaceKey = []byte("waddrmgr")
+	wtxmgrNamespaceKey   = []byte("wtxmgr")
+)
+
+func main() {
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.SubcommandsOptional = false
+
+	commands := []struct {
+		name, short, long string
+		data              interface{}
+	}{{
+		"dump", "Print the contents of the database",
+		"Print every bucket of the database.  Records of the address " +
+			"manager and the transaction store are decoded " +
+			"unless --raw is given.",
+		&dumpCommand{},
+	}, {
+		"verify", "Check the database for inconsistencies",
+		"Check that the credits, debits and unspent output index of " +
+			"the transaction store agree with each other and " +
+			"with the mined balance, and that the synced-to " +
+			"block matches the block index.  Exits with a " +
+			"non-zero status if inconsistencies are found.",
+		&verifyCommand{},
+	}, {
+		"fix", "Repair inconsistencies found by verify",
+		"Repair the inconsistencies reported by verify, rewinding " +
+			"the synced-to block where needed so the wallet " +
+			"rescans the affected blocks on its next start.",
+		&fixCommand{},
+	}, {
+		"compact", "Rewrite the database file without free pages",
+		"Copy the database into a new file without free pages, " +
+			"replacing the original unless --out is given.",
+		&compactCommand{},
+	}, {
+		"export", "Write the transaction history of the wallet",
+		"Write every wallet transaction as CSV or JSON Lines, one " +
+			"entry per transaction and account, with its debits, " +
+			"credits, fee, net amount, label, block and " +
+			"confirmation state.  The public passphrase is needed " +
+			"to open the wallet.",
+		&exportCommand{},
+	}}
+	for _, c := range commands {
+		_, err := parser.AddCommand(c.name, c.short, c.long, c.data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if _, err := parser.Parse(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// openDB opens the wallet database named by the --db flag.
+func openDB() (walletdb.DB, error) {
+	if _, err := os.Stat(opts.DbPath); err != nil {
+		return nil, err
+	}
+
+	db, err := walletdb.Open("bdb", opts.DbPath, true, opts.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
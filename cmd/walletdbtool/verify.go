@@ -0,0 +1,536 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// The transaction store layout checked here is described in detail in
+// wtxmgr/db.go.  The transaction store is a separate module, so the tool reads
+// the buckets directly rather than through unexported wtxmgr functions.
+var byteOrder = binary.BigEndian
+
+var (
+	bucketBlocks         = []byte("b")
+	bucketTxRecords      = []byte("t")
+	bucketTxLabels       = []byte("l")
+	bucketCredits        = []byte("c")
+	bucketUnspent        = []byte("u")
+	bucketDebits         = []byte("d")
+	bucketUnmined        = []byte("m")
+	bucketUnminedCredits = []byte("mc")
+	bucketUnminedInputs  = []byte("mi")
+	bucketLockedOutputs  = []byte("lo")
+
+	rootMinedBalance = []byte("bal")
+)
+
+const (
+	creditFlagSpent  = 1 << 0
+	creditFlagChange = 1 << 1
+)
+
+// maxFixPasses bounds the number of check and repair rounds of the fix
+// command.  Repairing one record may expose an inconsistency in another, e.g.
+// deleting a credit changes the mined balance, so a single round is not always
+// enough.
+const maxFixPasses = 3
+
+// problem describes a single inconsistency of the transaction store.
+type problem struct {
+	desc string
+
+	// fix repairs the inconsistency.  It is nil if there is no safe
+	// repair.
+	fix func(ns walletdb.ReadWriteBucket) error
+}
+
+// verifyCommand reports the inconsistencies of the database.
+type verifyCommand struct{}
+
+// Execute runs the verify command.
+func (c *verifyCommand) Execute(args []string) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var found int
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		syncProblems, txProblems, err := checkDB(tx)
+		if err != nil {
+			return err
+		}
+		for _, p := range syncProblems {
+			fmt.Println(p)
+		}
+		for _, p := range txProblems {
+			fmt.Println(p.desc)
+		}
+		found = len(syncProblems) + len(txProblems)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if found != 0 {
+		return fmt.Errorf("%d inconsistencies found", found)
+	}
+	fmt.Println("No inconsistencies found")
+	return nil
+}
+
+// fixCommand repairs the inconsistencies of the database.
+type fixCommand struct {
+	DryRun bool `long:"dryrun" description:"Only print the repairs that would be made"`
+}
+
+// Execute runs the fix command.
+func (c *fixCommand) Execute(args []string) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if c.DryRun {
+		var unfixable int
+		err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+			syncProblems, txProblems, err := checkDB(tx)
+			if err != nil {
+				return err
+			}
+			for _, p := range syncProblems {
+				fmt.Println("would fix:", p)
+			}
+			for _, p := range txProblems {
+				if p.fix == nil {
+					fmt.Println("cannot fix:", p.desc)
+					unfixable++
+					continue
+				}
+				fmt.Println("would fix:", p.desc)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if unfixable != 0 {
+			return fmt.Errorf("%d inconsistencies cannot be fixed",
+				unfixable)
+		}
+		return nil
+	}
+
+	var remaining []problem
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+		if addrmgrNs == nil || txmgrNs == nil {
+			return errors.New("missing wallet namespace")
+		}
+
+		fixed, err := waddrmgr.RepairSyncState(addrmgrNs)
+		if err != nil {
+			return err
+		}
+		for _, p := range fixed {
+			fmt.Println("fixed:", p)
+		}
+
+		for pass := 0; pass < maxFixPasses; pass++ {
+			problems, err := checkTxStore(txmgrNs)
+			if err != nil {
+				return err
+			}
+
+			remaining = remaining[:0]
+			var progress bool
+			for _, p := range problems {
+				if p.fix == nil {
+					remaining = append(remaining, p)
+					continue
+				}
+				if err := p.fix(txmgrNs); err != nil {
+					return fmt.Errorf("%s: %w", p.desc, err)
+				}
+				fmt.Println("fixed:", p.desc)
+				progress = true
+			}
+			if !progress {
+				return nil
+			}
+		}
+
+		// Report what is left after the last pass.
+		problems, err := checkTxStore(txmgrNs)
+		if err != nil {
+			return err
+		}
+		remaining = problems
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range remaining {
+		fmt.Println("not fixed:", p.desc)
+	}
+	if len(remaining) != 0 {
+		return fmt.Errorf("%d inconsistencies remain", len(remaining))
+	}
+	return nil
+}
+
+// checkDB checks the sync state of the address manager and the transaction
+// store.
+func checkDB(tx walletdb.ReadTx) ([]string, []problem, error) {
+	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+	txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+	if addrmgrNs == nil || txmgrNs == nil {
+		return nil, nil, errors.New("missing wallet namespace")
+	}
+
+	syncProblems, err := waddrmgr.CheckSyncState(addrmgrNs)
+	if err != nil {
+		return nil, nil, err
+	}
+	txProblems, err := checkTxStore(txmgrNs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return syncProblems, txProblems, nil
+}
+
+// nestedBuckets returns the nested buckets of the transaction store that are
+// checked, or an error if any of them is missing.
+func nestedBuckets(ns walletdb.ReadBucket,
+	names ...[]byte) ([]walletdb.ReadBucket, error) {
+
+	buckets := make([]walletdb.ReadBucket, len(names))
+	for i, name := range names {
+		buckets[i] = ns.NestedReadBucket(name)
+		if buckets[i] == nil {
+			return nil, fmt.Errorf("missing bucket %q", name)
+		}
+	}
+	return buckets, nil
+}
+
+// creditString describes the credit with the given key.
+func creditString(k []byte) string {
+	return fmt.Sprintf("credit %s:%d in block %d", hashString(k[:32]),
+		byteOrder.Uint32(k[68:72]), int32(byteOrder.Uint32(k[32:36])))
+}
+
+// debitString describes the debit with the given key.
+func debitString(k []byte) string {
+	return fmt.Sprintf("debit %s input %d in block %d", hashString(k[:32]),
+		byteOrder.Uint32(k[68:72]), int32(byteOrder.Uint32(k[32:36])))
+}
+
+// unspentKey returns the unspent index key of the credit with the given key.
+func unspentKey(credKey []byte) []byte {
+	k := make([]byte, 36)
+	copy(k, credKey[:32])
+	copy(k[32:], credKey[68:72])
+	return k
+}
+
+// unspentCreditKey returns the credit key the unspent index records for the
+// outpoint, or nil if there is none.
+func unspentCreditKey(ns walletdb.ReadBucket, k []byte) []byte {
+	v := ns.NestedReadBucket(bucketUnspent).Get(k)
+	if len(v) != 36 {
+		return nil
+	}
+	credKey := make([]byte, 72)
+	copy(credKey, k[:32])
+	copy(credKey[32:68], v)
+	copy(credKey[68:72], k[32:36])
+	return credKey
+}
+
+// deleteUnspentFor removes the unspent index entry of the credit with the
+// given key, if the entry refers to that credit.
+func deleteUnspentFor(ns walletdb.ReadWriteBucket, credKey []byte) error {
+	k := unspentKey(credKey)
+	if !bytes.Equal(unspentCreditKey(ns, k), credKey) {
+		return nil
+	}
+	return ns.NestedReadWriteBucket(bucketUnspent).Delete(k)
+}
+
+// markUnspent clears the spent flag and spender of the credit with the given
+// key and adds it to the unspent index.
+func markUnspent(ns walletdb.ReadWriteBucket, credKey []byte) error {
+	credits := ns.NestedReadWriteBucket(bucketCredits)
+	v := make([]byte, 9)
+	copy(v, credits.Get(credKey))
+	v[8] &^= creditFlagSpent
+	if err := credits.Put(credKey, v); err != nil {
+		return err
+	}
+	return ns.NestedReadWriteBucket(bucketUnspent).Put(
+		unspentKey(credKey), credKey[32:68],
+	)
+}
+
+// markSpent marks the credit with the given key as spent by the debit with the
+// given key and removes it from the unspent index.
+func markSpent(ns walletdb.ReadWriteBucket, credKey, debitKey []byte) error {
+	credits := ns.NestedReadWriteBucket(bucketCredits)
+	v := make([]byte, 81)
+	copy(v, credits.Get(credKey)[:9])
+	v[8] |= creditFlagSpent
+	copy(v[9:], debitKey)
+	if err := credits.Put(credKey, v); err != nil {
+		return err
+	}
+	return deleteUnspentFor(ns, credKey)
+}
+
+// spends returns whether the input of the transaction record txRecord named
+// by the debit key spends the credit with the given key.
+func spends(txRecord, debitKey, credKey []byte) bool {
+	if len(txRecord) < 8 {
+		return false
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(txRecord[8:])); err != nil {
+		return false
+	}
+	index := byteOrder.Uint32(debitKey[68:72])
+	if index >= uint32(len(tx.TxIn)) {
+		return false
+	}
+	prevOut := &tx.TxIn[index].PreviousOutPoint
+	return bytes.Equal(prevOut.Hash[:], credKey[:32]) &&
+		prevOut.Index == byteOrder.Uint32(credKey[68:72])
+}
+
+// unspentTotal sums the amounts of all mined credits that are not spent by a
+// mined transaction.  This is what the mined balance of the store records.
+func unspentTotal(ns walletdb.ReadBucket) (btcutil.Amount, error) {
+	txRecords := ns.NestedReadBucket(bucketTxRecords)
+
+	var total btcutil.Amount
+	err := ns.NestedReadBucket(bucketCredits).ForEach(func(k, v []byte) error {
+		if len(k) != 72 || len(v) < 9 || v[8]&creditFlagSpent != 0 {
+			return nil
+		}
+		if txRecords.Get(k[:68]) == nil {
+			return nil
+		}
+		total += btcutil.Amount(byteOrder.Uint64(v))
+		return nil
+	})
+	return total, err
+}
+
+// checkTxStore checks that the credits, debits and unspent output index of the
+// transaction store agree with each other, and that the mined balance matches
+// the unspent credits.  The returned problems are ordered so that applying
+// their fixes in order is safe.
+func checkTxStore(ns walletdb.ReadBucket) ([]problem, error) {
+	buckets, err := nestedBuckets(
+		ns, bucketTxRecords, bucketCredits, bucketUnspent, bucketDebits,
+	)
+	if err != nil {
+		return nil, err
+	}
+	txRecords, credits, unspent, debits := buckets[0], buckets[1],
+		buckets[2], buckets[3]
+
+	var problems []problem
+	report := func(fix func(ns walletdb.ReadWriteBucket) error,
+		format string, args ...interface{}) {
+
+		problems = append(problems, problem{
+			desc: fmt.Sprintf(format, args...),
+			fix:  fix,
+		})
+	}
+
+	// Every credit must belong to a transaction record.  Unspent credits
+	// must be in the unspent index, and spent credits must refer to a
+	// debit which refers back to them.
+	err = credits.ForEach(func(k, v []byte) error {
+		k = append([]byte(nil), k...)
+		if len(k) != 72 || len(v) < 9 {
+			report(nil, "credit %x is malformed", k)
+			return nil
+		}
+
+		if txRecords.Get(k[:68]) == nil {
+			report(func(ns walletdb.ReadWriteBucket) error {
+				err := deleteUnspentFor(ns, k)
+				if err != nil {
+					return err
+				}
+				return ns.NestedReadWriteBucket(
+					bucketCredits).Delete(k)
+			}, "%s has no transaction record", creditString(k))
+			return nil
+		}
+
+		fixUnspent := func(ns walletdb.ReadWriteBucket) error {
+			return markUnspent(ns, k)
+		}
+		if v[8]&creditFlagSpent == 0 {
+			credKey := unspentCreditKey(ns, unspentKey(k))
+			if !bytes.Equal(credKey, k) {
+				report(fixUnspent, "unspent %s is missing "+
+					"from the unspent index",
+					creditString(k))
+			}
+			return nil
+		}
+
+		if len(v) < 81 {
+			report(fixUnspent, "spent %s does not record its "+
+				"spender", creditString(k))
+			return nil
+		}
+		debitKey := append([]byte(nil), v[9:81]...)
+		dv := debits.Get(debitKey)
+		if len(dv) >= 80 && bytes.Equal(dv[8:80], k) {
+			return nil
+		}
+
+		// The debit can be restored if the spending transaction is
+		// still recorded and its input spends this credit.
+		fix := fixUnspent
+		if spends(txRecords.Get(debitKey[:68]), debitKey, k) {
+			amount := append([]byte(nil), v[:8]...)
+			fix = func(ns walletdb.ReadWriteBucket) error {
+				dv := make([]byte, 80)
+				copy(dv, amount)
+				copy(dv[8:], k)
+				return ns.NestedReadWriteBucket(
+					bucketDebits).Put(debitKey, dv)
+			}
+		}
+		report(fix, "%s is spent by %s, which does not exist or "+
+			"spends another output", creditString(k),
+			debitString(debitKey))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every entry of the unspent index must refer to an unspent credit.
+	err = unspent.ForEach(func(k, v []byte) error {
+		k = append([]byte(nil), k...)
+		deleteEntry := func(ns walletdb.ReadWriteBucket) error {
+			return ns.NestedReadWriteBucket(bucketUnspent).Delete(k)
+		}
+		if len(k) != 36 || len(v) != 36 {
+			report(deleteEntry, "unspent index entry %x is "+
+				"malformed", k)
+			return nil
+		}
+
+		// Only delete the entry if it still refers to the same credit
+		// when the fix is applied, since an earlier fix may have
+		// pointed it to another one.
+		credKey := unspentCreditKey(ns, k)
+		deleteEntry = func(ns walletdb.ReadWriteBucket) error {
+			return deleteUnspentFor(ns, credKey)
+		}
+		cv := credits.Get(credKey)
+		switch {
+		case cv == nil:
+			report(deleteEntry, "unspent index entry %s refers "+
+				"to a missing %s", outPointString(k),
+				creditString(credKey))
+		case len(cv) >= 9 && cv[8]&creditFlagSpent != 0:
+			report(deleteEntry, "unspent index entry %s refers "+
+				"to spent %s", outPointString(k),
+				creditString(credKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every debit must spend an existing credit which is marked as spent
+	// by it.
+	err = debits.ForEach(func(k, v []byte) error {
+		k = append([]byte(nil), k...)
+		if len(k) != 72 || len(v) < 80 {
+			report(nil, "debit %x is malformed", k)
+			return nil
+		}
+
+		credKey := append([]byte(nil), v[8:80]...)
+		cv := credits.Get(credKey)
+		switch {
+		case cv == nil:
+			report(func(ns walletdb.ReadWriteBucket) error {
+				return ns.NestedReadWriteBucket(
+					bucketDebits).Delete(k)
+			}, "%s spends a missing %s", debitString(k),
+				creditString(credKey))
+
+		case len(cv) < 9:
+			// Reported as malformed above.
+
+		case cv[8]&creditFlagSpent == 0:
+			report(func(ns walletdb.ReadWriteBucket) error {
+				return markSpent(ns, credKey, k)
+			}, "%s spends %s, which is not marked spent",
+				debitString(k), creditString(credKey))
+
+		case len(cv) >= 81 && !bytes.Equal(cv[9:81], k):
+			report(nil, "%s spends %s, which is marked spent "+
+				"by %s", debitString(k), creditString(credKey),
+				debitString(cv[9:81]))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The mined balance must match the unspent credits.  The fix
+	// recomputes the total when it is applied, after the fixes above.
+	total, err := unspentTotal(ns)
+	if err != nil {
+		return nil, err
+	}
+	v := ns.Get(rootMinedBalance)
+	if len(v) != 8 || btcutil.Amount(byteOrder.Uint64(v)) != total {
+		var balance string
+		if len(v) == 8 {
+			balance = amountString(v)
+		} else {
+			balance = fmt.Sprintf("%x", v)
+		}
+		report(func(ns walletdb.ReadWriteBucket) error {
+			total, err := unspentTotal(ns)
+			if err != nil {
+				return err
+			}
+			var v [8]byte
+			byteOrder.PutUint64(v[:], uint64(total))
+			return ns.Put(rootMinedBalance, v[:])
+		}, "mined balance is %s, but the unspent credits total %v",
+			balance, total)
+	}
+
+	return problems, nil
+}
@@ -11,40 +11,23 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/stroomnetwork/btcwallet/bip39"
 	"github.com/stroomnetwork/btcwallet/internal/legacy/keystore"
+	"github.com/stroomnetwork/btcwallet/slip39"
 	"golang.org/x/term"
 )
 
 // ProvideSeed is used to prompt for the wallet seed which maybe required during
-// upgrades.
+// upgrades.  The seed may be entered as a BIP-39 mnemonic or SLIP-39 share
+// mnemonics.
 func ProvideSeed() ([]byte, error) {
-	reader := bufio.NewReader(os.Stdin)
-	for {
-		fmt.Print("Enter existing wallet seed: ")
-		seedStr, err := reader.ReadString('\n')
-		if err != nil {
-			return nil, err
-		}
-		seedStr = strings.TrimSpace(strings.ToLower(seedStr))
-
-		seed, err := hex.DecodeString(seedStr)
-		if err != nil || len(seed) < hdkeychain.MinSeedBytes ||
-			len(seed) > hdkeychain.MaxSeedBytes {
-
-			fmt.Printf("Invalid seed specified.  Must be a "+
-				"hexadecimal value that is at least %d bits and "+
-				"at most %d bits\n", hdkeychain.MinSeedBytes*8,
-				hdkeychain.MaxSeedBytes*8)
-			continue
-		}
-
-		return seed, nil
-	}
+	return existingSeed(bufio.NewReader(os.Stdin))
 }
 
 // ProvidePrivPassphrase is used to prompt for the private passphrase which
@@ -154,6 +137,41 @@ func promptPass(_ *bufio.Reader, prefix string, confirm bool) ([]byte, error) {
 	}
 }
 
+// promptOptionalPass prompts the user for a passphrase like promptPass, but
+// accepts an empty one.  Seeds restored from mnemonics and shares have no way
+// to tell a wrong passphrase, so the passphrase they were made with is
+// optional rather than required.
+func promptOptionalPass(prefix string, confirm bool) ([]byte, error) {
+	prompt := fmt.Sprintf("%s: ", prefix)
+	for {
+		fmt.Print(prompt)
+		pass, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Print("\n")
+		pass = bytes.TrimSpace(pass)
+
+		if !confirm || len(pass) == 0 {
+			return pass, nil
+		}
+
+		fmt.Print("Confirm passphrase: ")
+		confirm, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Print("\n")
+		confirm = bytes.TrimSpace(confirm)
+		if !bytes.Equal(pass, confirm) {
+			fmt.Println("The entered passphrases do not match")
+			continue
+		}
+
+		return pass, nil
+	}
+}
+
 // PrivatePass prompts the user for a private passphrase with varying behavior
 // depending on whether the passed legacy keystore exists.  When it does, the
 // user is prompted for the existing passphrase which is then used to unlock it.
@@ -262,63 +280,126 @@ func PublicPass(reader *bufio.Reader, privPass []byte,
 
 // Seed prompts the user whether they want to use an existing wallet generation
 // seed.  When the user answers no, a seed will be generated and displayed to
-// the user along with prompting them for confirmation.  When the user answers
-// yes, a the user is prompted for it.  All prompts are repeated until the user
-// enters a valid response.
-func Seed(reader *bufio.Reader) ([]byte, error) {
+// the user along with prompting them for confirmation.  The seed is generated
+// as a BIP-39 mnemonic of mnemonicWords words and an optional passphrase,
+// unless mnemonicWords is zero.  When the user answers yes, a the user is
+// prompted for it, either as a hexadecimal value, as a BIP-39 mnemonic or as
+// SLIP-39 share mnemonics.  All prompts are repeated until the user enters a
+// valid response.
+func Seed(reader *bufio.Reader, mnemonicWords int) ([]byte, error) {
 	// Ascertain the wallet generation seed.
 	useUserSeed, err := promptListBool(reader, "Do you have an "+
 		"existing wallet seed you want to use?", "no")
 	if err != nil {
 		return nil, err
 	}
-	if !useUserSeed {
-		seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+	if useUserSeed {
+		return existingSeed(reader)
+	}
+
+	var seed []byte
+	if mnemonicWords > 0 {
+		mnemonic, err := bip39.NewMnemonic(mnemonicWords)
+		if err != nil {
+			return nil, err
+		}
+		pass, err := promptOptionalPass("Enter a BIP-39 passphrase "+
+			"protecting the mnemonic (leave empty for none)", true)
+		if err != nil {
+			return nil, err
+		}
+		seed, err = bip39.Seed(mnemonic, pass)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Println("Your wallet generation mnemonic is:")
+		fmt.Println(mnemonic)
+		if len(pass) > 0 {
+			fmt.Println("The wallet can only be restored from the " +
+				"mnemonic\nwith the passphrase you entered.")
+		}
+	} else {
+		seed, err = hdkeychain.GenerateSeed(
+			hdkeychain.RecommendedSeedLen,
+		)
 		if err != nil {
 			return nil, err
 		}
 
 		fmt.Println("Your wallet generation seed is:")
 		fmt.Printf("%x\n", seed)
-		fmt.Println("IMPORTANT: Keep the seed in a safe place as you\n" +
-			"will NOT be able to restore your wallet without it.")
-		fmt.Println("Please keep in mind that anyone who has access\n" +
-			"to the seed can also restore your wallet thereby\n" +
-			"giving them access to all your funds, so it is\n" +
-			"imperative that you keep it in a secure location.")
-
-		for {
-			fmt.Print(`Once you have stored the seed in a safe ` +
-				`and secure location, enter "OK" to continue: `)
-			confirmSeed, err := reader.ReadString('\n')
-			if err != nil {
-				return nil, err
-			}
-			confirmSeed = strings.TrimSpace(confirmSeed)
-			confirmSeed = strings.Trim(confirmSeed, `"`)
-			if confirmSeed == "OK" {
-				break
-			}
-		}
+	}
 
-		return seed, nil
+	fmt.Println("IMPORTANT: Keep the seed in a safe place as you\n" +
+		"will NOT be able to restore your wallet without it.")
+	fmt.Println("Please keep in mind that anyone who has access\n" +
+		"to the seed can also restore your wallet thereby\n" +
+		"giving them access to all your funds, so it is\n" +
+		"imperative that you keep it in a secure location.")
+
+	for {
+		fmt.Print(`Once you have stored the seed in a safe ` +
+			`and secure location, enter "OK" to continue: `)
+		confirmSeed, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		confirmSeed = strings.TrimSpace(confirmSeed)
+		confirmSeed = strings.Trim(confirmSeed, `"`)
+		if confirmSeed == "OK" {
+			break
+		}
 	}
 
+	return seed, nil
+}
+
+// existingSeed prompts the user for an existing wallet seed until they enter a
+// valid one: a hexadecimal seed, a BIP-39 mnemonic followed by its passphrase,
+// or the SLIP-39 shares of the seed.  Mnemonics and shares are told apart by
+// their number of words, since no share has as many words as a mnemonic.
+func existingSeed(reader *bufio.Reader) ([]byte, error) {
 	for {
-		fmt.Print("Enter existing wallet seed: ")
+		fmt.Print("Enter existing wallet seed, BIP-39 mnemonic or " +
+			"first SLIP-39 share: ")
 		seedStr, err := reader.ReadString('\n')
 		if err != nil {
 			return nil, err
 		}
 		seedStr = strings.TrimSpace(strings.ToLower(seedStr))
 
+		switch words := len(strings.Fields(seedStr)); {
+		case words >= 12 && words <= 24 && words%3 == 0:
+			if err := bip39.Validate(seedStr); err != nil {
+				fmt.Printf("Invalid mnemonic specified: %v\n",
+					err)
+				continue
+			}
+			pass, err := promptOptionalPass("Enter the BIP-39 "+
+				"passphrase of the mnemonic (leave empty for "+
+				"none)", false)
+			if err != nil {
+				return nil, err
+			}
+			return bip39.Seed(seedStr, pass)
+
+		case words > 1:
+			if err := slip39.Validate(seedStr); err != nil {
+				fmt.Printf("Invalid share specified: %v\n", err)
+				continue
+			}
+			return seedFromShares(reader, seedStr)
+		}
+
 		seed, err := hex.DecodeString(seedStr)
 		if err != nil || len(seed) < hdkeychain.MinSeedBytes ||
 			len(seed) > hdkeychain.MaxSeedBytes {
 
 			fmt.Printf("Invalid seed specified.  Must be a "+
 				"hexadecimal value that is at least %d bits and "+
-				"at most %d bits\n", hdkeychain.MinSeedBytes*8,
+				"at most %d bits, or a mnemonic\n",
+				hdkeychain.MinSeedBytes*8,
 				hdkeychain.MaxSeedBytes*8)
 			continue
 		}
@@ -326,3 +407,40 @@ func Seed(reader *bufio.Reader) ([]byte, error) {
 		return seed, nil
 	}
 }
+
+// seedFromShares prompts the user for SLIP-39 shares following the first one
+// until there are enough of them to restore the seed, and then for the
+// passphrase the seed is encrypted with in the shares.
+func seedFromShares(reader *bufio.Reader, first string) ([]byte, error) {
+	shares := []string{first}
+	for {
+		_, err := slip39.Combine(shares, nil)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, slip39.ErrInsufficientShares) {
+			// The last share doesn't belong with the others.
+			fmt.Printf("Invalid share specified: %v\n", err)
+			shares = shares[:len(shares)-1]
+		}
+
+		fmt.Printf("Enter SLIP-39 share %d: ", len(shares)+1)
+		share, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		share = strings.TrimSpace(strings.ToLower(share))
+		if err := slip39.Validate(share); err != nil {
+			fmt.Printf("Invalid share specified: %v\n", err)
+			continue
+		}
+		shares = append(shares, share)
+	}
+
+	pass, err := promptOptionalPass("Enter the passphrase of the shares "+
+		"(leave empty for none)", false)
+	if err != nil {
+		return nil, err
+	}
+	return slip39.Combine(shares, pass)
+}
@@ -27,6 +27,6 @@ func PublicPass(_ *bufio.Reader, _, _, _ []byte) ([]byte, error) {
 	return nil, fmt.Errorf("prompt not supported in WebAssembly")
 }
 
-func Seed(_ *bufio.Reader) ([]byte, error) {
+func Seed(_ *bufio.Reader, _ int) ([]byte, error) {
 	return nil, fmt.Errorf("prompt not supported in WebAssembly")
 }
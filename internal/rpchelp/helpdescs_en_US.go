@@ -15,6 +15,10 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
 
+	// BackupWalletCmd help.
+	"backupwallet--synopsis":   "Writes a consistent copy of the wallet database to a file.",
+	"backupwallet-destination": "The file to write the copy to, or a directory to write it to using the wallet database filename",
+
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -29,6 +33,14 @@ var helpDescsEnUS = map[string]string{
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
 
+	// DumpWalletCmd help.
+	"dumpwallet--synopsis": "Writes all wallet private keys and pay-to-script-hash redeem scripts to a new file, in the text format used by Bitcoin Core.\n" +
+		"The wallet must be unlocked and existing files are not overwritten.",
+	"dumpwallet-filename": "The file to write the dump to",
+
+	// DumpWalletResult help.
+	"dumpwalletresult-filename": "The absolute path of the written dump",
+
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -83,6 +95,25 @@ var helpDescsEnUS = map[string]string{
 	"infowalletresult-keypoolsize":     "Unset",
 	"infowalletresult-keypoololdest":   "Unset",
 
+	// GetWalletInfoCmd help.
+	"getwalletinfo--synopsis": "Returns a JSON object containing the balances, key pool, lock and rescan state of the wallet.",
+
+	// GetWalletInfoResult help.
+	"getwalletinforesult-walletname":              "The name of the wallet, which is the empty string for the only wallet",
+	"getwalletinforesult-walletversion":           "The version of the address manager database",
+	"getwalletinforesult-balance":                 "The balance of all accounts calculated with one block confirmation, valued in bitcoin",
+	"getwalletinforesult-unconfirmed_balance":     "The balance of unconfirmed outputs of all accounts, valued in bitcoin",
+	"getwalletinforesult-immature_balance":        "The balance of immature coinbase outputs of all accounts, valued in bitcoin",
+	"getwalletinforesult-txcount":                 "The number of wallet transactions, including unmined ones",
+	"getwalletinforesult-keypoololdest":           "The Unix time of the wallet birthday",
+	"getwalletinforesult-keypoolsize":             "The number of derived but unused external addresses of the default accounts",
+	"getwalletinforesult-keypoolsize_hd_internal": "The number of derived but unused change addresses of the default accounts",
+	"getwalletinforesult-unlocked_until":          "The Unix time the wallet will be locked again, or 0 if the wallet is locked or unlocked without a timeout",
+	"getwalletinforesult-paytxfee":                "The fee rate used for authored transactions, in BTC/kB",
+	"getwalletinforesult-private_keys_enabled":    "Whether the wallet holds private keys",
+	"getwalletinforesult-avoid_reuse":             "Unset",
+	"getwalletinforesult-scanning":                "false if no rescan is running, otherwise an object with the number of seconds the rescan has been running (\"duration\") and the fraction of blocks rescanned (\"progress\")",
+
 	// GetNewAddressCmd help.
 	"getnewaddress--synopsis":   "Generates and returns a new payment address.",
 	"getnewaddress-account":     "DEPRECATED -- Account name the new address will belong to (default=\"default\")",
@@ -149,6 +180,13 @@ var helpDescsEnUS = map[string]string{
 	"importprivkey-label":     "Unused (must be unset or 'imported')",
 	"importprivkey-rescan":    "Rescan the blockchain (since the genesis block) for outputs controlled by the imported key",
 
+	// ImportWalletCmd help.
+	"importwallet--synopsis": "Imports the private keys and pay-to-script-hash redeem scripts of a wallet dump file in the text format used by Bitcoin Core, and rescans the blockchain for them from the earliest key time.\n" +
+		"The label=<account> of each key is not restored: btcwallet has no address labels, and imported private keys always belong to the imported account of the key scope matching their address type.\n" +
+		"Keys the wallet already knows, such as those derived from its seed, are skipped and keep their account.\n" +
+		"To restore the accounts of an HD wallet, restore it from its seed instead.",
+	"importwallet-filename": "The wallet dump file to import",
+
 	// KeypoolRefillCmd help.
 	"keypoolrefill--synopsis": "DEPRECATED -- This request does nothing since no keypool is maintained.",
 	"keypoolrefill-newsize":   "Unused",
@@ -160,6 +198,11 @@ var helpDescsEnUS = map[string]string{
 	"listaccounts--result0--key":   "The account name",
 	"listaccounts--result0--value": "The account balance valued in bitcoin",
 
+	// ListAddressGroupingsCmd help.
+	"listaddressgroupings--synopsis": "Returns groups of wallet addresses whose common ownership was made public by spending them as inputs of the same transaction or as its change.\n" +
+		"Each address is reported as an array of the address, its balance valued in bitcoin and its account.",
+	"listaddressgroupings--result0": "The address groups",
+
 	// ListLockUnspentCmd help.
 	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.",
 
@@ -255,6 +298,19 @@ var helpDescsEnUS = map[string]string{
 	"listunspentresult-confirmations": "The number of block confirmations of the transaction",
 	"listunspentresult-spendable":     "Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)",
 
+	// ListWalletsCmd help.
+	"listwallets--synopsis": "Returns the names of the loaded wallets.\n" +
+		"The default wallet is named by the empty string, and requests are made to the other wallets with the /wallet/<name> URI path.",
+	"listwallets--result0": "The names of the loaded wallets",
+
+	// LoadWalletCmd help.
+	"loadwallet--synopsis":  "Loads a wallet from the wallets directory, so requests can be made to it with the /wallet/<name> URI path.",
+	"loadwallet-walletname": "The name of the wallet directory",
+
+	// LoadWalletResult help.
+	"loadwalletresult-name":    "The name of the loaded wallet",
+	"loadwalletresult-warning": "Unset",
+
 	// LockUnspentCmd help.
 	"lockunspent--synopsis": "Locks or unlocks an unspent output.\n" +
 		"Locked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\n" +
@@ -371,11 +427,43 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
 
+	// UnloadWalletCmd help.
+	"unloadwallet--synopsis":  "Stops a loaded wallet and closes its database.",
+	"unloadwallet-walletname": "The name of the wallet to unload, or the wallet of the URI path if unset",
+
+	// CancelColdSpendCmd help.
+	"cancelcoldspend--synopsis": "Forgets a pending cold spend and unlocks its inputs.\n" +
+		"A signature made for it afterwards is refused by completecoldspend.",
+	"cancelcoldspend-txid": "The hash of the unsigned transaction of the cold spend",
+
+	// ClearReorgHaltCmd help.
+	"clearreorghalt--synopsis": "Resumes sending and publishing transactions after the wallet was halted by a chain reorganization deeper than the configured maximum.\n" +
+		"Only use this after verifying that the new chain is legitimate.",
+
+	// CompleteColdSpendCmd help.
+	"completecoldspend--synopsis": "Publishes a pending cold spend signed outside of the wallet.\n" +
+		"The signed PSBT must be for the unsigned transaction of the cold spend, and the signatures are checked against the outputs it spends before it is published.",
+	"completecoldspend-psbt":     "The signed PSBT, base64-encoded, with finalized or partial signatures",
+	"completecoldspend--result0": "The transaction ID of the published transaction",
+
 	// CreateNewAccountCmd help.
 	"createnewaccount--synopsis": "Creates a new account.\n" +
 		"The wallet must be unlocked for this request to succeed.",
 	"createnewaccount-account": "Name of the new account",
 
+	// ExportHistoryCmd help.
+	"exporthistory--synopsis": "Writes every wallet transaction to a new file, one entry per transaction and account, with the debits, credits, fee, net amount, label, block and confirmation state of each.\n" +
+		"Existing files are not overwritten.",
+	"exporthistory-filename":    "The file to write the history to",
+	"exporthistory-format":      "The format of the file: csv or jsonl (JSON Lines)",
+	"exporthistory-startheight": "The height of the first block to export transactions from",
+	"exporthistory-endheight":   "The height of the last block to export transactions from, or -1 to export up to the synced block and include unmined transactions",
+	"exporthistory-account":     "Only export the entries of accounts with this name",
+
+	// ExportHistoryResult help.
+	"exporthistoryresult-filename": "The absolute path of the written history",
+	"exporthistoryresult-entries":  "The number of entries written",
+
 	// ExportWatchingWalletCmd help.
 	"exportwatchingwallet--synopsis": "Creates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.",
 	"exportwatchingwallet-account":   "Unused (must be unset or \"*\")",
@@ -403,11 +491,59 @@ var helpDescsEnUS = map[string]string{
 	"listalltransactions--synopsis": "Returns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.",
 	"listalltransactions-account":   "Unused (must be unset or \"*\")",
 
+	// ListColdSpendsCmd help.
+	"listcoldspends--synopsis": "Returns the pending cold spends, oldest first.\n" +
+		"Spends from cold accounts, watch-only accounts of a wallet with private keys, are not signed but handed off as PSBTs to be signed outside of the wallet and published with completecoldspend.",
+
+	// ListColdSpendsResult help.
+	"listcoldspendsresult-txid":    "The hash of the unsigned transaction",
+	"listcoldspendsresult-account": "The cold account the transaction spends from",
+	"listcoldspendsresult-psbt":    "The unsigned PSBT, base64-encoded, with the outputs spent and the BIP-0032 derivation paths of the inputs and change",
+	"listcoldspendsresult-created": "The time the transaction was created, in seconds since 1 Jan 1970 GMT",
+
 	// RenameAccountCmd help.
 	"renameaccount--synopsis":  "Renames an account.",
 	"renameaccount-oldaccount": "The old account name to rename",
 	"renameaccount-newaccount": "The new name for the account",
 
+	// RotateSeedCmd help.
+	"rotateseed--synopsis": "Replaces the seed of the wallet with a new seed.\n" +
+		"Every account derived from the previous seed is renamed after the fingerprint of its root key and replaced by an account of the new seed under the same name.\n" +
+		"The outputs of the replaced accounts stay spendable, but their new addresses and change are derived from the new seed.\n" +
+		"The wallet must be unlocked for this request to succeed.",
+	"rotateseed-seed": "The new seed, hex-encoded, of 16 to 64 bytes",
+
+	// SetAccountSpendingModeCmd help.
+	"setaccountspendingmode--synopsis": "Sets the spending mode of a watch-only account, in every key scope it exists in.\n" +
+		"The spends of a cold account are recorded as pending cold spends, to be signed outside of the wallet, instead of being published.\n" +
+		"Watch-only accounts are hot until made cold, while the accounts with private keys are always hot.",
+	"setaccountspendingmode-account": "The name of the account",
+	"setaccountspendingmode-mode":    "The spending mode, 'hot' or 'cold'",
+
+	// SweepRetiredAccountCmd help.
+	"sweepretiredaccount--synopsis": "Spends the outputs of an account replaced by a seed rotation to the account of the new seed that replaced it, in every key scope, and publishes the transactions.\n" +
+		"The wallet must be unlocked for this request to succeed.",
+	"sweepretiredaccount-account":  "The name of the replaced account, as renamed by rotateseed",
+	"sweepretiredaccount-minconf":  "Only sweep outputs with at least this many confirmations",
+	"sweepretiredaccount-feerate":  "The fee rate of the sweeping transactions in BTC/kB, at least the minimum relay fee rate, which is the default",
+	"sweepretiredaccount--result0": "The transaction IDs of the sweeping transactions, one per key scope with outputs to sweep",
+
+	// WaitForConfirmationsCmd help.
+	"waitforconfirmations--synopsis": "Waits until a wallet transaction reaches a target number of block confirmations, or is reorganized back below it.\n" +
+		"The request returns as soon as the transaction's target depth state differs from 'reached', or when the timeout expires.",
+	"waitforconfirmations-txid":    "Hash of the wallet transaction to wait for",
+	"waitforconfirmations-nconf":   "The target number of block confirmations",
+	"waitforconfirmations-reached": "The target depth state last observed by the caller; use false to wait until the target is reached, and true to wait until it is reorganized back below the target",
+	"waitforconfirmations-timeout": "Maximum number of seconds to wait before returning the current state",
+
+	// WaitForConfirmationsResult help.
+	"waitforconfirmationsresult-txid":                "The transaction hash",
+	"waitforconfirmationsresult-confirmations":       "The number of block confirmations of the transaction",
+	"waitforconfirmationsresult-targetconfirmations": "The target number of block confirmations",
+	"waitforconfirmationsresult-blockhash":           "The hash of the block this transaction is mined in, or the empty string if unmined",
+	"waitforconfirmationsresult-blockheight":         "The height of the block this transaction is mined in, or -1 if unmined",
+	"waitforconfirmationsresult-reached":             "Whether the transaction has at least the target number of block confirmations",
+
 	// WalletIsLockedCmd help.
 	"walletislocked--synopsis": "Returns whether or not the wallet is locked.",
 	"walletislocked--result0":  "Whether the wallet is locked",
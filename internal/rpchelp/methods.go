@@ -7,7 +7,10 @@
 
 package rpchelp
 
-import "github.com/btcsuite/btcd/btcjson"
+import (
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/stroomnetwork/btcwallet/internal/walletjson"
+)
 
 // Common return types.
 var (
@@ -25,8 +28,10 @@ var Methods = []struct {
 	ResultTypes []interface{}
 }{
 	{"addmultisigaddress", returnsString},
+	{"backupwallet", nil},
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
 	{"dumpprivkey", returnsString},
+	{"dumpwallet", []interface{}{(*btcjson.DumpWalletResult)(nil)}},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
 	{"getaddressesbyaccount", returnsStringArray},
@@ -39,16 +44,21 @@ var Methods = []struct {
 	{"getreceivedbyaccount", returnsNumber},
 	{"getreceivedbyaddress", returnsNumber},
 	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
+	{"getwalletinfo", []interface{}{(*walletjson.GetWalletInfoResult)(nil)}},
 	{"help", append(returnsString, returnsString[0])},
 	{"importprivkey", nil},
+	{"importwallet", nil},
 	{"keypoolrefill", nil},
 	{"listaccounts", []interface{}{(*map[string]float64)(nil)}},
+	{"listaddressgroupings", []interface{}{(*[][][]interface{})(nil)}},
 	{"listlockunspent", []interface{}{(*[]btcjson.TransactionInput)(nil)}},
 	{"listreceivedbyaccount", []interface{}{(*[]btcjson.ListReceivedByAccountResult)(nil)}},
 	{"listreceivedbyaddress", []interface{}{(*[]btcjson.ListReceivedByAddressResult)(nil)}},
 	{"listsinceblock", []interface{}{(*btcjson.ListSinceBlockResult)(nil)}},
 	{"listtransactions", returnsLTRArray},
 	{"listunspent", []interface{}{(*btcjson.ListUnspentResult)(nil)}},
+	{"listwallets", returnsStringArray},
+	{"loadwallet", []interface{}{(*btcjson.LoadWalletResult)(nil)}},
 	{"lockunspent", returnsBool},
 	{"sendfrom", returnsString},
 	{"sendmany", returnsString},
@@ -56,18 +66,28 @@ var Methods = []struct {
 	{"settxfee", returnsBool},
 	{"signmessage", returnsString},
 	{"signrawtransaction", []interface{}{(*btcjson.SignRawTransactionResult)(nil)}},
+	{"unloadwallet", nil},
 	{"validateaddress", []interface{}{(*btcjson.ValidateAddressWalletResult)(nil)}},
 	{"verifymessage", returnsBool},
 	{"walletlock", nil},
 	{"walletpassphrase", nil},
 	{"walletpassphrasechange", nil},
+	{"cancelcoldspend", nil},
+	{"clearreorghalt", nil},
+	{"completecoldspend", returnsString},
 	{"createnewaccount", nil},
+	{"exporthistory", []interface{}{(*walletjson.ExportHistoryResult)(nil)}},
 	{"exportwatchingwallet", returnsString},
 	{"getbestblock", []interface{}{(*btcjson.GetBestBlockResult)(nil)}},
 	{"getunconfirmedbalance", returnsNumber},
 	{"listaddresstransactions", returnsLTRArray},
 	{"listalltransactions", returnsLTRArray},
+	{"listcoldspends", []interface{}{(*[]walletjson.ListColdSpendsResult)(nil)}},
 	{"renameaccount", nil},
+	{"rotateseed", nil},
+	{"setaccountspendingmode", nil},
+	{"sweepretiredaccount", returnsStringArray},
+	{"waitforconfirmations", []interface{}{(*walletjson.WaitForConfirmationsResult)(nil)}},
 	{"walletislocked", returnsBool},
 }
 
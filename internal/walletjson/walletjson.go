@@ -0,0 +1,223 @@
+// Package walletjson defines the btcwallet JSON-RPC extension commands and
+// results that are not provided by the btcjson package.  The commands are
+// registered with btcjson when the package is imported, so they can be
+// parsed and documented like any other command.
+package walletjson
+
+import "github.com/btcsuite/btcd/btcjson"
+
+// CancelColdSpendCmd defines the cancelcoldspend JSON-RPC command.
+type CancelColdSpendCmd struct {
+	TxID string
+}
+
+// NewCancelColdSpendCmd returns a new instance which can be used to issue a
+// cancelcoldspend JSON-RPC command.
+func NewCancelColdSpendCmd(txID string) *CancelColdSpendCmd {
+	return &CancelColdSpendCmd{
+		TxID: txID,
+	}
+}
+
+// ClearReorgHaltCmd defines the clearreorghalt JSON-RPC command.
+type ClearReorgHaltCmd struct{}
+
+// NewClearReorgHaltCmd returns a new instance which can be used to issue a
+// clearreorghalt JSON-RPC command.
+func NewClearReorgHaltCmd() *ClearReorgHaltCmd {
+	return &ClearReorgHaltCmd{}
+}
+
+// CompleteColdSpendCmd defines the completecoldspend JSON-RPC command.
+type CompleteColdSpendCmd struct {
+	Psbt string
+}
+
+// NewCompleteColdSpendCmd returns a new instance which can be used to issue a
+// completecoldspend JSON-RPC command.
+func NewCompleteColdSpendCmd(psbt string) *CompleteColdSpendCmd {
+	return &CompleteColdSpendCmd{
+		Psbt: psbt,
+	}
+}
+
+// ExportHistoryCmd defines the exporthistory JSON-RPC command.
+type ExportHistoryCmd struct {
+	Filename    string
+	Format      *string `jsonrpcdefault:"\"csv\""`
+	StartHeight *int32  `jsonrpcdefault:"0"`
+	EndHeight   *int32  `jsonrpcdefault:"-1"`
+	Account     *string
+}
+
+// NewExportHistoryCmd returns a new instance which can be used to issue an
+// exporthistory JSON-RPC command.
+func NewExportHistoryCmd(filename string, format *string, startHeight,
+	endHeight *int32, account *string) *ExportHistoryCmd {
+
+	return &ExportHistoryCmd{
+		Filename:    filename,
+		Format:      format,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Account:     account,
+	}
+}
+
+// ExportHistoryResult models the data returned by the exporthistory command.
+type ExportHistoryResult struct {
+	Filename string `json:"filename"`
+	Entries  int    `json:"entries"`
+}
+
+// ListColdSpendsCmd defines the listcoldspends JSON-RPC command.
+type ListColdSpendsCmd struct{}
+
+// NewListColdSpendsCmd returns a new instance which can be used to issue a
+// listcoldspends JSON-RPC command.
+func NewListColdSpendsCmd() *ListColdSpendsCmd {
+	return &ListColdSpendsCmd{}
+}
+
+// ListColdSpendsResult models the data returned by the listcoldspends
+// command.
+type ListColdSpendsResult struct {
+	TxID    string `json:"txid"`
+	Account string `json:"account"`
+	Psbt    string `json:"psbt"`
+	Created int64  `json:"created"`
+}
+
+// ListWalletsCmd defines the listwallets JSON-RPC command.
+type ListWalletsCmd struct{}
+
+// NewListWalletsCmd returns a new instance which can be used to issue a
+// listwallets JSON-RPC command.
+func NewListWalletsCmd() *ListWalletsCmd {
+	return &ListWalletsCmd{}
+}
+
+// RotateSeedCmd defines the rotateseed JSON-RPC command.
+type RotateSeedCmd struct {
+	Seed string
+}
+
+// NewRotateSeedCmd returns a new instance which can be used to issue a
+// rotateseed JSON-RPC command.
+func NewRotateSeedCmd(seed string) *RotateSeedCmd {
+	return &RotateSeedCmd{
+		Seed: seed,
+	}
+}
+
+// SetAccountSpendingModeCmd defines the setaccountspendingmode JSON-RPC
+// command.
+type SetAccountSpendingModeCmd struct {
+	Account string
+	Mode    string
+}
+
+// NewSetAccountSpendingModeCmd returns a new instance which can be used to
+// issue a setaccountspendingmode JSON-RPC command.
+func NewSetAccountSpendingModeCmd(account,
+	mode string) *SetAccountSpendingModeCmd {
+
+	return &SetAccountSpendingModeCmd{
+		Account: account,
+		Mode:    mode,
+	}
+}
+
+// SweepRetiredAccountCmd defines the sweepretiredaccount JSON-RPC command.
+type SweepRetiredAccountCmd struct {
+	Account string
+	MinConf *int `jsonrpcdefault:"1"`
+	FeeRate *float64
+}
+
+// NewSweepRetiredAccountCmd returns a new instance which can be used to issue
+// a sweepretiredaccount JSON-RPC command.
+func NewSweepRetiredAccountCmd(account string, minConf *int,
+	feeRate *float64) *SweepRetiredAccountCmd {
+
+	return &SweepRetiredAccountCmd{
+		Account: account,
+		MinConf: minConf,
+		FeeRate: feeRate,
+	}
+}
+
+// WaitForConfirmationsCmd defines the waitforconfirmations JSON-RPC command.
+type WaitForConfirmationsCmd struct {
+	TxID    string
+	NConf   *int32 `jsonrpcdefault:"1"`
+	Reached *bool  `jsonrpcdefault:"false"`
+	Timeout *int64 `jsonrpcdefault:"60"`
+}
+
+// NewWaitForConfirmationsCmd returns a new instance which can be used to
+// issue a waitforconfirmations JSON-RPC command.
+func NewWaitForConfirmationsCmd(txID string, nConf *int32, reached *bool,
+	timeout *int64) *WaitForConfirmationsCmd {
+
+	return &WaitForConfirmationsCmd{
+		TxID:    txID,
+		NConf:   nConf,
+		Reached: reached,
+		Timeout: timeout,
+	}
+}
+
+// WaitForConfirmationsResult models the data returned by the
+// waitforconfirmations command.
+type WaitForConfirmationsResult struct {
+	TxID                string `json:"txid"`
+	Confirmations       int32  `json:"confirmations"`
+	TargetConfirmations int32  `json:"targetconfirmations"`
+	BlockHash           string `json:"blockhash"`
+	BlockHeight         int32  `json:"blockheight"`
+	Reached             bool   `json:"reached"`
+}
+
+// GetWalletInfoResult models the data returned by the getwalletinfo command.
+// Unlike btcjson.GetWalletInfoResult, it includes the balances reported by
+// Bitcoin Core.
+type GetWalletInfoResult struct {
+	WalletName            string      `json:"walletname"`
+	WalletVersion         int         `json:"walletversion"`
+	Balance               float64     `json:"balance"`
+	UnconfirmedBalance    float64     `json:"unconfirmed_balance"`
+	ImmatureBalance       float64     `json:"immature_balance"`
+	TransactionCount      int         `json:"txcount"`
+	KeyPoolOldest         int64       `json:"keypoololdest"`
+	KeyPoolSize           int         `json:"keypoolsize"`
+	KeyPoolSizeHDInternal int         `json:"keypoolsize_hd_internal"`
+	UnlockedUntil         int64       `json:"unlocked_until"`
+	PayTransactionFee     float64     `json:"paytxfee"`
+	PrivateKeysEnabled    bool        `json:"private_keys_enabled"`
+	AvoidReuse            bool        `json:"avoid_reuse"`
+	Scanning              interface{} `json:"scanning"`
+}
+
+func init() {
+	flags := btcjson.UFWalletOnly
+
+	btcjson.MustRegisterCmd("cancelcoldspend", (*CancelColdSpendCmd)(nil),
+		flags)
+	btcjson.MustRegisterCmd("clearreorghalt", (*ClearReorgHaltCmd)(nil),
+		flags)
+	btcjson.MustRegisterCmd("completecoldspend",
+		(*CompleteColdSpendCmd)(nil), flags)
+	btcjson.MustRegisterCmd("exporthistory", (*ExportHistoryCmd)(nil),
+		flags)
+	btcjson.MustRegisterCmd("listcoldspends", (*ListColdSpendsCmd)(nil),
+		flags)
+	btcjson.MustRegisterCmd("listwallets", (*ListWalletsCmd)(nil), flags)
+	btcjson.MustRegisterCmd("rotateseed", (*RotateSeedCmd)(nil), flags)
+	btcjson.MustRegisterCmd("setaccountspendingmode",
+		(*SetAccountSpendingModeCmd)(nil), flags)
+	btcjson.MustRegisterCmd("sweepretiredaccount",
+		(*SweepRetiredAccountCmd)(nil), flags)
+	btcjson.MustRegisterCmd("waitforconfirmations",
+		(*WaitForConfirmationsCmd)(nil), flags)
+}
@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"math"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+var (
+	chainSyncedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "chain_synced"),
+		"Whether the wallet is synced to its chain backend.",
+		[]string{"wallet"}, nil,
+	)
+	syncedHeightDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "synced_height"),
+		"Height of the last block the wallet is synced to.",
+		[]string{"wallet"}, nil,
+	)
+	syncHeightLagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "sync_height_lag"),
+		"Number of blocks the wallet is behind the best block of its "+
+			"chain backend.",
+		[]string{"wallet"}, nil,
+	)
+	unminedTxsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "unmined_transactions"),
+		"Number of transactions of the wallet not yet mined.",
+		[]string{"wallet"}, nil,
+	)
+	utxosDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "utxos"),
+		"Number of unspent outputs of the account.",
+		[]string{"wallet", "account"}, nil,
+	)
+	utxoValueDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "utxo_value_satoshis"),
+		"Value of the unspent outputs of the account.",
+		[]string{"wallet", "account"}, nil,
+	)
+	rescanInProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "rescan_in_progress"),
+		"Whether the wallet is rescanning the chain.",
+		[]string{"wallet"}, nil,
+	)
+	rescanProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "rescan_progress"),
+		"Fraction of the blocks of the running rescan that were "+
+			"rescanned.",
+		[]string{"wallet"}, nil,
+	)
+)
+
+// walletCollector collects the state of the loaded wallets when the metrics
+// are scraped.
+type walletCollector struct {
+	wallets *wallet.MultiLoader
+}
+
+func newWalletCollector(wallets *wallet.MultiLoader) *walletCollector {
+	return &walletCollector{wallets: wallets}
+}
+
+// Describe implements prometheus.Collector.
+func (c *walletCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- chainSyncedDesc
+	ch <- syncedHeightDesc
+	ch <- syncHeightLagDesc
+	ch <- unminedTxsDesc
+	ch <- utxosDesc
+	ch <- utxoValueDesc
+	ch <- rescanInProgressDesc
+	ch <- rescanProgressDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *walletCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.wallets.LoadedWallets() {
+		w, ok := c.wallets.Wallet(name)
+		if !ok {
+			continue
+		}
+		collectWallet(ch, name, w)
+	}
+}
+
+// collectWallet sends the metrics of the named wallet.  Metrics that can't be
+// read are left out of the scrape.
+func collectWallet(ch chan<- prometheus.Metric, name string, w *wallet.Wallet) {
+	gauge := func(desc *prometheus.Desc, v float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc,
+			prometheus.GaugeValue, v, append([]string{name},
+				labels...)...)
+	}
+
+	gauge(chainSyncedDesc, boolValue(w.ChainSynced()))
+
+	synced := w.Manager.SyncedTo()
+	gauge(syncedHeightDesc, float64(synced.Height))
+	if chainClient := w.ChainClient(); chainClient != nil {
+		_, bestHeight, err := chainClient.GetBestBlock()
+		if err != nil {
+			log.Debugf("Unable to query best block of wallet %q: %v",
+				name, err)
+		} else {
+			gauge(syncHeightLagDesc,
+				float64(bestHeight-synced.Height))
+		}
+	}
+
+	unmined, err := w.UnminedTxHashes()
+	if err != nil {
+		log.Warnf("Unable to list unmined transactions of wallet %q: %v",
+			name, err)
+	} else {
+		gauge(unminedTxsDesc, float64(len(unmined)))
+	}
+
+	unspent, err := w.ListUnspent(0, math.MaxInt32, "")
+	if err != nil {
+		log.Warnf("Unable to list unspent outputs of wallet %q: %v",
+			name, err)
+	} else {
+		counts := make(map[string]int)
+		values := make(map[string]btcutil.Amount)
+		for _, output := range unspent {
+			amount, err := btcutil.NewAmount(output.Amount)
+			if err != nil {
+				continue
+			}
+			counts[output.Account]++
+			values[output.Account] += amount
+		}
+		for account, n := range counts {
+			gauge(utxosDesc, float64(n), account)
+			gauge(utxoValueDesc, float64(values[account]), account)
+		}
+	}
+
+	status := w.Scanning()
+	gauge(rescanInProgressDesc, boolValue(status != nil))
+	if status != nil {
+		gauge(rescanProgressDesc, status.Progress())
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
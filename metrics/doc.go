@@ -0,0 +1,37 @@
+/*
+Package metrics exports the state and the operations of the wallets of a
+process as Prometheus metrics, and serves the health probes of the process.
+
+The metrics of a wallet are labelled with its name, which is empty for the
+default wallet.  The state of the loaded wallets is read each time the metrics
+are scraped:
+
+  - btcwallet_chain_synced: whether the wallet is synced to its chain backend.
+  - btcwallet_synced_height: the height of the last block the wallet is
+    synced to.
+  - btcwallet_sync_height_lag: the number of blocks the wallet is behind the
+    best block of its chain backend.
+  - btcwallet_unmined_transactions: the number of transactions of the wallet
+    not yet mined.
+  - btcwallet_utxos and btcwallet_utxo_value_satoshis: the number and value of
+    the unspent outputs of each account.
+  - btcwallet_rescan_in_progress and btcwallet_rescan_progress: whether a
+    rescan is running, and the fraction of its blocks rescanned.
+
+The operations of the wallets and RPC servers are observed as they happen:
+
+  - btcwallet_rpc_request_duration_seconds: the latency of the RPC requests of
+    each server and method.
+  - btcwallet_broadcast_failures_total: the transactions the chain backend
+    refused to broadcast, by the chain error of the refusal.
+  - btcwallet_db_transaction_duration_seconds: the duration of the database
+    transactions of the wallets.
+
+Metrics implements wallet.Metrics, and must be given to the wallet loaders
+with wallet.WithMetrics to observe the wallets.
+
+The liveness probe reports that the process serves requests.  The readiness
+probe reports whether the required wallets are loaded, and whether every
+loaded wallet is synced to a chain backend that is itself current.
+*/
+package metrics
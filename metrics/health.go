@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+const (
+	// LivenessPath is the conventional path of the liveness probe.
+	LivenessPath = "/healthz"
+
+	// ReadinessPath is the conventional path of the readiness probe.
+	ReadinessPath = "/readyz"
+
+	// MetricsPath is the conventional path of the metrics.
+	MetricsPath = "/metrics"
+)
+
+// WalletStatus is the readiness of a wallet.
+type WalletStatus struct {
+	// Loaded is whether the wallet is loaded.
+	Loaded bool `json:"loaded"`
+
+	// ChainSynced is whether the wallet is synced to its chain backend.
+	ChainSynced bool `json:"chain_synced"`
+
+	// BackendCurrent is whether the chain backend of the wallet believes
+	// it is synced to the best block of the network.
+	BackendCurrent bool `json:"backend_current"`
+}
+
+// Ready returns whether the wallet is ready to serve requests.
+func (s WalletStatus) Ready() bool {
+	return s.Loaded && s.ChainSynced && s.BackendCurrent
+}
+
+// Readiness checks whether the wallets of a loader are ready to serve
+// requests.
+type Readiness struct {
+	wallets  *wallet.MultiLoader
+	required []string
+}
+
+// NewReadiness returns the readiness check of the wallets of the loader.  The
+// required wallets must be loaded to be ready, while other wallets are only
+// checked while they are loaded.
+func NewReadiness(wallets *wallet.MultiLoader, required ...string) *Readiness {
+	return &Readiness{wallets: wallets, required: required}
+}
+
+// Check returns the status of the required and the loaded wallets, and
+// whether they are all ready.
+func (r *Readiness) Check() (map[string]WalletStatus, bool) {
+	statuses := make(map[string]WalletStatus)
+	for _, name := range r.required {
+		statuses[name] = WalletStatus{}
+	}
+	for _, name := range r.wallets.LoadedWallets() {
+		statuses[name] = WalletStatus{}
+	}
+
+	ready := true
+	for name := range statuses {
+		status := walletStatus(r.wallets, name)
+		statuses[name] = status
+		ready = ready && status.Ready()
+	}
+	return statuses, ready
+}
+
+func walletStatus(wallets *wallet.MultiLoader, name string) WalletStatus {
+	w, ok := wallets.Wallet(name)
+	if !ok {
+		return WalletStatus{}
+	}
+	status := WalletStatus{
+		Loaded:      true,
+		ChainSynced: w.ChainSynced(),
+	}
+	if chainClient := w.ChainClient(); chainClient != nil {
+		status.BackendCurrent = chainClient.IsCurrent()
+	}
+	return status
+}
+
+// readinessResponse is the body of a response of the readiness probe.
+type readinessResponse struct {
+	Ready   bool                    `json:"ready"`
+	Wallets map[string]WalletStatus `json:"wallets"`
+}
+
+// ServeHTTP serves the readiness probe.  It responds with the status of the
+// wallets, with the 200 status code if they are ready and 503 otherwise.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	statuses, ready := r.Check()
+	code := http.StatusOK
+	if !ready {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(readinessResponse{
+		Ready:   ready,
+		Wallets: statuses,
+	})
+}
+
+// Liveness is the liveness probe, which always responds with the 200 status
+// code while the process serves requests.
+var Liveness http.Handler = http.HandlerFunc(
+	func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte("ok\n"))
+	},
+)
+
+// NewHandler returns the HTTP handler serving the metrics at MetricsPath, the
+// liveness probe at LivenessPath and the readiness probe at ReadinessPath.
+func NewHandler(m *Metrics, readiness *Readiness) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, m.Handler())
+	mux.Handle(LivenessPath, Liveness)
+	mux.Handle(ReadinessPath, readiness)
+	return mux
+}
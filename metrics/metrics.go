@@ -0,0 +1,180 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stroomnetwork/btcwallet/chain"
+	"github.com/stroomnetwork/btcwallet/wallet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// namespace is the prefix of the names of the metrics.
+const namespace = "btcwallet"
+
+const (
+	// ServerGRPC is the server label of the requests to the gRPC server.
+	ServerGRPC = "grpc"
+
+	// ServerLegacy is the server label of the requests to the legacy RPC
+	// server.
+	ServerLegacy = "legacy"
+)
+
+// Metrics holds the Prometheus metrics of a process.
+//
+// Metrics is safe for concurrent access.
+type Metrics struct {
+	registry          *prometheus.Registry
+	rpcDuration       *prometheus.HistogramVec
+	broadcastFailures *prometheus.CounterVec
+	dbTxDuration      *prometheus.HistogramVec
+}
+
+// Ensure Metrics implements wallet.Metrics.
+var _ wallet.Metrics = (*Metrics)(nil)
+
+// New returns the metrics of a process, including the metrics of the Go
+// runtime and of the process itself.  The state of the wallets is exported
+// once the wallet loader is registered with CollectWallets.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_request_duration_seconds",
+			Help: "Duration of the RPC requests handled by the " +
+				"RPC servers.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"server", "method", "code"}),
+		broadcastFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "broadcast_failures_total",
+			Help: "Transactions the chain backend refused to " +
+				"broadcast, by the error of the refusal.",
+		}, []string{"error"}),
+		dbTxDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_transaction_duration_seconds",
+			Help: "Duration of the database transactions of the " +
+				"wallets.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 4, 9),
+		}, []string{"mode"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(
+			collectors.ProcessCollectorOpts{},
+		),
+		m.rpcDuration,
+		m.broadcastFailures,
+		m.dbTxDuration,
+	)
+	return m
+}
+
+// CollectWallets exports the state of the wallets loaded by the loader.  It
+// may only be called once.
+func (m *Metrics) CollectWallets(wallets *wallet.MultiLoader) error {
+	return m.registry.Register(newWalletCollector(wallets))
+}
+
+// Handler returns the HTTP handler serving the metrics to Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// ObserveDBTx records the duration of a database transaction of a wallet.
+//
+// This is part of the wallet.Metrics interface.
+func (m *Metrics) ObserveDBTx(readWrite bool, d time.Duration) {
+	mode := "read"
+	if readWrite {
+		mode = "write"
+	}
+	m.dbTxDuration.WithLabelValues(mode).Observe(d.Seconds())
+}
+
+// BroadcastFailed counts a transaction the chain backend refused to
+// broadcast.
+//
+// This is part of the wallet.Metrics interface.
+func (m *Metrics) BroadcastFailed(err error) {
+	m.broadcastFailures.WithLabelValues(broadcastErrorLabel(err)).Inc()
+}
+
+// ObserveRequest records the duration of a request to the legacy RPC server.
+// Its signature is that of legacyrpc.Options.ObserveRequest.
+func (m *Metrics) ObserveRequest(method string, d time.Duration,
+	failed bool) {
+
+	code := "OK"
+	if failed {
+		code = "Error"
+	}
+	m.rpcDuration.WithLabelValues(ServerLegacy, method, code).
+		Observe(d.Seconds())
+}
+
+// UnaryServerInterceptor returns the interceptor recording the duration of
+// the unary requests to a gRPC server.  Streaming requests last as long as
+// their client listens, so their duration isn't recorded.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.rpcDuration.WithLabelValues(
+			ServerGRPC, info.FullMethod, status.Code(err).String(),
+		).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// broadcastErrorLabel returns the label of the error refusing a broadcast,
+// which is the snake cased message of the chain error type of the refusal.
+func broadcastErrorLabel(err error) string {
+	var rpcErr chain.RPCErr
+	switch {
+	case errors.As(err, &rpcErr):
+		return labelValue(rpcErr.Error())
+	case errors.Is(err, chain.ErrBackendVersion):
+		return labelValue(chain.ErrBackendVersion.Error())
+	case errors.Is(err, chain.ErrInvalidParam):
+		return labelValue(chain.ErrInvalidParam.Error())
+	case errors.Is(err, chain.ErrUndefined):
+		return labelValue(chain.ErrUndefined.Error())
+	default:
+		return "other"
+	}
+}
+
+// labelValue converts a message to a lower case label value, with runs of
+// other characters than letters and digits replaced by an underscore.
+func labelValue(msg string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(msg) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if sep && b.Len() != 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			sep = false
+			continue
+		}
+		sep = true
+	}
+	return b.String()
+}
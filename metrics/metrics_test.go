@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	_ "github.com/btcsuite/btcwallet/walletdb/bdb"
+	"github.com/stroomnetwork/btcwallet/chain"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// TestBroadcastErrorLabel checks the labels of the errors refusing a
+// broadcast.
+func TestBroadcastErrorLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{chain.ErrInsufficientFee, "insufficient_fee"},
+		{chain.ErrMempoolMinFeeNotMet, "mempool_min_fee_not_met"},
+		{fmt.Errorf("wrapped: %w", chain.ErrNonBIP68Final),
+			"non_bip68_final"},
+		{chain.ErrUndefined, "undefined"},
+		{errors.New("connection refused"), "other"},
+	}
+	for _, test := range tests {
+		if got := broadcastErrorLabel(test.err); got != test.want {
+			t.Errorf("label of %v: got %q, want %q", test.err, got,
+				test.want)
+		}
+	}
+}
+
+// TestHandler checks the metrics and probes served for a wallet that isn't
+// connected to a chain backend.
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	wallets := wallet.NewMultiLoader(
+		&chaincfg.TestNet3Params, t.TempDir(), true, 10*time.Second,
+		250, wallet.WithMetrics(m),
+		wallet.WithWalletSyncRetryInterval(10*time.Millisecond),
+	)
+	if err := m.CollectWallets(wallets); err != nil {
+		t.Fatalf("unable to collect wallets: %v", err)
+	}
+	readiness := NewReadiness(wallets, wallet.DefaultWalletName)
+	server := httptest.NewServer(NewHandler(m, readiness))
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("unable to get %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", path, err)
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	if code, _ := get(LivenessPath); code != http.StatusOK {
+		t.Fatalf("liveness status %d, want %d", code, http.StatusOK)
+	}
+
+	// The required wallet isn't loaded yet.
+	code, body := get(ReadinessPath)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("readiness status %d, want %d", code,
+			http.StatusServiceUnavailable)
+	}
+	var resp readinessResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("unable to decode readiness: %v", err)
+	}
+	if resp.Ready || resp.Wallets[wallet.DefaultWalletName].Loaded {
+		t.Fatalf("unexpected readiness %s", body)
+	}
+
+	_, err := wallets.DefaultLoader().CreateNewWallet(
+		[]byte("public"), []byte("private"), nil, time.Now(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	defer func() {
+		if err := wallets.UnloadAll(); err != nil {
+			t.Errorf("unable to unload wallets: %v", err)
+		}
+	}()
+
+	// The wallet is loaded, but not synced to a chain backend.
+	_, body = get(ReadinessPath)
+	resp = readinessResponse{}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("unable to decode readiness: %v", err)
+	}
+	want := WalletStatus{Loaded: true}
+	if resp.Ready || resp.Wallets[wallet.DefaultWalletName] != want {
+		t.Fatalf("unexpected readiness %s", body)
+	}
+
+	m.ObserveRequest("getbalance", time.Millisecond, false)
+	m.BroadcastFailed(chain.ErrDust)
+
+	code, body = get(MetricsPath)
+	if code != http.StatusOK {
+		t.Fatalf("metrics status %d, want %d", code, http.StatusOK)
+	}
+	for _, line := range []string{
+		`btcwallet_chain_synced{wallet=""} 0`,
+		`btcwallet_unmined_transactions{wallet=""} 0`,
+		`btcwallet_rescan_in_progress{wallet=""} 0`,
+		`btcwallet_broadcast_failures_total{error="dust"} 1`,
+		`btcwallet_rpc_request_duration_seconds_count{code="OK",` +
+			`method="getbalance",server="legacy"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("metrics do not contain %q", line)
+		}
+	}
+
+	// Creating the wallet wrote to its database.
+	if !strings.Contains(body,
+		`btcwallet_db_transaction_duration_seconds_count{mode="write"}`) {
+
+		t.Error("metrics do not contain database transactions")
+	}
+}
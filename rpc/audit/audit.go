@@ -0,0 +1,271 @@
+// Package audit implements an append-only, tamper-evident log of RPC calls.
+//
+// The log is a file of JSON entries, one per line.  Every entry records the
+// hash of the previous entry and its own hash, which covers the previous hash
+// and all other fields of the entry.  Modifying, removing or reordering
+// entries therefore breaks the chain of hashes, which Verify detects.  The
+// hashes are HMAC-SHA256 with a secret key kept outside of the log, so that
+// whoever can write the log can't recompute the chain of a modified log
+// without also holding the key.  Truncating the end of the log is only
+// detected by comparing the head hash of the log to a copy kept elsewhere,
+// such as the hash reported by Head when the log is opened.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// maxEntrySize is the maximum size of an entry read by Verify.
+	maxEntrySize = 16 * 1024 * 1024
+
+	// keyLen is the length of the keys created by OpenKey.
+	keyLen = 32
+)
+
+// ErrTampered is returned by Verify when the chain of hashes of a log is
+// broken.
+var ErrTampered = errors.New("audit log was tampered with")
+
+// Entry is an entry of the audit log.
+type Entry struct {
+	// Seq is the sequence number of the entry, starting at one.
+	Seq uint64 `json:"seq"`
+
+	// Time is the time the call completed.
+	Time time.Time `json:"time"`
+
+	// Server is the RPC server that handled the call.
+	Server string `json:"server"`
+
+	// Caller identifies the credentials of the caller.
+	Caller string `json:"caller"`
+
+	// RemoteAddr is the network address of the caller.
+	RemoteAddr string `json:"remoteaddr,omitempty"`
+
+	// Method is the called method.
+	Method string `json:"method"`
+
+	// Wallet is the name of the wallet the call was made to, which is
+	// empty for the default wallet.
+	Wallet string `json:"wallet,omitempty"`
+
+	// Params are the parameters of the call, with secrets removed.
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// Result is the result of the call, if it is recorded for the method.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error is the error of a failed call.
+	Error string `json:"error,omitempty"`
+
+	// Prev is the hash of the previous entry.
+	Prev string `json:"prev"`
+
+	// Hash is the hash of the entry.
+	Hash string `json:"hash"`
+}
+
+// hash returns the HMAC of the entry with the key, which covers every field
+// but Hash.
+func (e *Entry) hash(key []byte) (string, error) {
+	c := *e
+	c.Hash = ""
+	b, err := json.Marshal(&c)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(b)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// ReadKey reads the key of a log from the file at the path.
+func ReadKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keyLen {
+		return nil, fmt.Errorf("audit log key %s has invalid length %d",
+			path, len(key))
+	}
+	return key, nil
+}
+
+// OpenKey reads the key of a log from the file at the path, creating a new
+// random key if the file doesn't exist.  The key must be kept apart from the
+// log, and is needed to verify it.
+func OpenKey(path string) ([]byte, error) {
+	key, err := ReadKey(path)
+	if !errors.Is(err, os.ErrNotExist) {
+		return key, err
+	}
+
+	key = make([]byte, keyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// genesisHash is the previous hash of the first entry.
+var genesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+// Log is an open audit log.  A nil Log records nothing.
+type Log struct {
+	mu   sync.Mutex
+	f    *os.File
+	key  []byte
+	seq  uint64
+	head string
+}
+
+// Open opens the audit log at the path for appending, creating it if it
+// doesn't exist.  Entries are hashed with the key.  The existing entries are
+// verified first.
+func Open(path string, key []byte) (*Log, error) {
+	if len(key) == 0 {
+		return nil, errors.New("audit log key is empty")
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	seq, head, err := verify(f, key)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	key = append([]byte(nil), key...)
+	return &Log{f: f, key: key, seq: seq, head: head}, nil
+}
+
+// Head returns the number of entries of the log and the hash of the last
+// entry.
+func (l *Log) Head() (uint64, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.seq, l.head
+}
+
+// Record appends the entry to the log.  The sequence number and hashes of the
+// entry are set by the log, and the time if it is zero.
+func (l *Log) Record(e *Entry) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f == nil {
+		return os.ErrClosed
+	}
+	e.Seq = l.seq + 1
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	e.Prev = l.head
+	hash, err := e.hash(l.key)
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := l.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	if err := l.f.Sync(); err != nil {
+		return err
+	}
+	l.seq = e.Seq
+	l.head = e.Hash
+	return nil
+}
+
+// Close closes the log.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// Verify checks the chain of hashes of the log read from r with the key of
+// the log, and returns the number of entries and the hash of the last entry.
+func Verify(r io.Reader, key []byte) (uint64, string, error) {
+	return verify(r, key)
+}
+
+func verify(r io.Reader, key []byte) (uint64, string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxEntrySize)
+
+	seq, head := uint64(0), genesisHash
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return 0, "", fmt.Errorf("%w: entry %d: %v",
+				ErrTampered, seq+1, err)
+		}
+		if e.Seq != seq+1 {
+			return 0, "", fmt.Errorf("%w: entry %d has sequence "+
+				"number %d", ErrTampered, seq+1, e.Seq)
+		}
+		if e.Prev != head {
+			return 0, "", fmt.Errorf("%w: entry %d does not "+
+				"follow the previous entry", ErrTampered, e.Seq)
+		}
+		hash, err := e.hash(key)
+		if err != nil {
+			return 0, "", err
+		}
+		if !hmac.Equal([]byte(hash), []byte(e.Hash)) {
+			return 0, "", fmt.Errorf("%w: entry %d has an "+
+				"invalid hash", ErrTampered, e.Seq)
+		}
+		seq, head = e.Seq, e.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, "", err
+	}
+	return seq, head, nil
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAuditLog checks that entries are chained across reopening the log and
+// that modifications, and verifying with the wrong key, are detected.
+func TestAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	key, err := OpenKey(filepath.Join(dir, "keys", "audit.key"))
+	if err != nil {
+		t.Fatalf("unable to create key: %v", err)
+	}
+	reopenedKey, err := OpenKey(filepath.Join(dir, "keys", "audit.key"))
+	if err != nil || !bytes.Equal(key, reopenedKey) {
+		t.Fatalf("reopened key %x (%v), want %x", reopenedKey, err,
+			key)
+	}
+
+	l, err := Open(path, key)
+	if err != nil {
+		t.Fatalf("unable to open log: %v", err)
+	}
+	err = l.Record(&Entry{
+		Server: "legacy",
+		Caller: "user:alice",
+		Method: "sendtoaddress",
+		Params: json.RawMessage(`["addr", 0.5]`),
+		Result: json.RawMessage(`"txid"`),
+	})
+	if err != nil {
+		t.Fatalf("unable to record entry: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = Open(path, key)
+	if err != nil {
+		t.Fatalf("unable to reopen log: %v", err)
+	}
+	if seq, _ := l.Head(); seq != 1 {
+		t.Fatalf("reopened log has %d entries, want 1", seq)
+	}
+	err = l.Record(&Entry{
+		Server: "grpc",
+		Caller: "macaroon:00",
+		Method: "/walletrpc.WalletService/ChangePassphrase",
+		Error:  "invalid passphrase",
+	})
+	if err != nil {
+		t.Fatalf("unable to record entry: %v", err)
+	}
+	_, head := l.Head()
+	l.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq, verifiedHead, err := Verify(bytes.NewReader(b), key)
+	if err != nil {
+		t.Fatalf("unable to verify log: %v", err)
+	}
+	if seq != 2 || verifiedHead != head {
+		t.Fatalf("verified %d entries with head %s, want 2 with %s",
+			seq, verifiedHead, head)
+	}
+
+	lines := bytes.SplitAfter(b, []byte("\n"))
+	tampered := [][]byte{
+		bytes.Replace(b, []byte("0.5"), []byte("5.0"), 1),
+		append(append([]byte(nil), lines[1]...), lines[0]...),
+		lines[1],
+	}
+	for i, log := range tampered {
+		_, _, err := Verify(bytes.NewReader(log), key)
+		if !errors.Is(err, ErrTampered) {
+			t.Errorf("tampered log %d: expected ErrTampered, got %v",
+				i, err)
+		}
+	}
+
+	// The chain can't be verified, or recomputed, without the key.
+	_, _, err = Verify(bytes.NewReader(b), make([]byte, len(key)))
+	if !errors.Is(err, ErrTampered) {
+		t.Errorf("wrong key: expected ErrTampered, got %v", err)
+	}
+
+	var nilLog *Log
+	if err := nilLog.Record(&Entry{}); err != nil {
+		t.Errorf("nil log: %v", err)
+	}
+}
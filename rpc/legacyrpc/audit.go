@@ -0,0 +1,82 @@
+package legacyrpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+)
+
+// auditServer is the server name of audit log entries of the legacy server.
+const auditServer = "legacyrpc"
+
+// redactedParams are the indexes of the parameters of methods that are
+// secrets and never written to the audit log.
+var redactedParams = map[string][]int{
+	"encryptwallet":          {0},
+	"importprivkey":          {0},
+	"rotateseed":             {0},
+	"signrawtransaction":     {2},
+	"walletpassphrase":       {0},
+	"walletpassphrasechange": {0, 1},
+}
+
+// caller identifies the credentials of a client, which is the identifier of
+// its macaroon or the username of HTTP Basic authentication.
+func (s *Server) caller(mac *macaroons.Macaroon) string {
+	if mac != nil {
+		return "macaroon:" + hex.EncodeToString(mac.ID())
+	}
+	return "user:" + s.username
+}
+
+// recordAudit appends a request to the named wallet that may change the state
+// of the wallet to the audit log, if enabled.  Requests that only query the
+// wallet or derive addresses are not recorded, and results are only recorded
+// for methods that send transactions, as other results may contain secrets.
+func (s *Server) recordAudit(caller, remoteAddr, walletName string,
+	req *btcjson.Request, result interface{}, jsonErr *btcjson.RPCError) {
+
+	if s.auditLog == nil {
+		return
+	}
+	mp := lookupPermission(req.Method)
+	if mp.perm == macaroons.PermRead || mp.perm == macaroons.PermAddress {
+		return
+	}
+
+	params := make([]json.RawMessage, len(req.Params))
+	copy(params, req.Params)
+	for _, i := range redactedParams[req.Method] {
+		if i < len(params) {
+			params[i] = json.RawMessage(`"<redacted>"`)
+		}
+	}
+	entry := &audit.Entry{
+		Server:     auditServer,
+		Caller:     caller,
+		RemoteAddr: remoteAddr,
+		Method:     req.Method,
+		Wallet:     walletName,
+	}
+	var err error
+	entry.Params, err = json.Marshal(params)
+	if err != nil {
+		log.Errorf("Cannot marshal audited parameters: %v", err)
+	}
+	switch {
+	case jsonErr != nil:
+		entry.Error = jsonErr.Error()
+	case mp.perm == macaroons.PermSend && result != nil:
+		entry.Result, err = json.Marshal(result)
+		if err != nil {
+			log.Errorf("Cannot marshal audited result: %v", err)
+		}
+	}
+	if err := s.auditLog.Record(entry); err != nil {
+		log.Errorf("Cannot record %s request in the audit log: %v",
+			req.Method, err)
+	}
+}
@@ -0,0 +1,93 @@
+package legacyrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
+)
+
+// TestRecordAudit ensures that only state-changing requests are audited and
+// that secrets are redacted.
+func TestRecordAudit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	key, err := audit.OpenKey(filepath.Join(dir, "audit.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditLog, err := audit.Open(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{username: "alice", auditLog: auditLog}
+
+	request := func(method string, params ...string) *btcjson.Request {
+		req := &btcjson.Request{Method: method}
+		for _, p := range params {
+			req.Params = append(req.Params, json.RawMessage(p))
+		}
+		return req
+	}
+	caller := s.caller(nil)
+	s.recordAudit(caller, "127.0.0.1:1", "", request("getbalance"), 1.0,
+		nil)
+	s.recordAudit(caller, "127.0.0.1:1", "",
+		request("walletpassphrase", `"hunter2"`, `60`), nil, nil)
+	s.recordAudit(caller, "127.0.0.1:1", "bob",
+		request("sendtoaddress", `"addr"`, `0.1`), "txid", nil)
+	s.recordAudit(caller, "127.0.0.1:1", "",
+		request("rotateseed", `"5eed5eed5eed5eed5eed5eed5eed5eed"`),
+		nil, nil)
+	s.recordAudit(caller, "127.0.0.1:1", "",
+		request("dumpprivkey", `"addr"`), "secret", nil)
+	auditLog.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("hunter2")) {
+		t.Errorf("passphrase written to the audit log")
+	}
+	if bytes.Contains(b, []byte("5eed")) {
+		t.Errorf("rotateseed seed written to the audit log")
+	}
+	if bytes.Contains(b, []byte("secret")) {
+		t.Errorf("result of dumpprivkey written to the audit log")
+	}
+
+	var methods []string
+	for _, line := range bytes.Split(bytes.TrimSpace(b), []byte("\n")) {
+		var e audit.Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Caller != "user:alice" {
+			t.Errorf("%s: caller %s", e.Method, e.Caller)
+		}
+		if e.Method == "sendtoaddress" && string(e.Result) != `"txid"` {
+			t.Errorf("sendtoaddress result %s not recorded", e.Result)
+		}
+		if e.Method == "sendtoaddress" && e.Wallet != "bob" {
+			t.Errorf("sendtoaddress wallet %q not recorded", e.Wallet)
+		}
+		methods = append(methods, e.Method)
+	}
+	want := []string{
+		"walletpassphrase", "sendtoaddress", "rotateseed",
+		"dumpprivkey",
+	}
+	if len(methods) != len(want) {
+		t.Fatalf("audited %v, want %v", methods, want)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Fatalf("audited %v, want %v", methods, want)
+		}
+	}
+}
@@ -4,11 +4,45 @@
 
 package legacyrpc
 
+import (
+	"time"
+
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+	"github.com/stroomnetwork/btcwallet/rpc/ratelimit"
+	"go.opentelemetry.io/otel/trace"
+)
+
 // Options contains the required options for running the legacy RPC server.
 type Options struct {
 	Username string
 	Password string
 
+	// Macaroons, if not nil, enables authentication with macaroons sent
+	// as bearer tokens.  HTTP Basic authentication is disabled if the
+	// username and password are both empty.
+	Macaroons *macaroons.Service
+
+	// RateLimiter, if not nil, limits the rate of requests of each
+	// credential to each method.
+	RateLimiter *ratelimit.Limiter
+
+	// AuditLog, if not nil, records the requests that may change the state
+	// of the wallet.
+	AuditLog *audit.Log
+
+	// ObserveRequest, if not nil, is called with the method, duration and
+	// failure of each handled request.
+	ObserveRequest func(method string, d time.Duration, failed bool)
+
+	// TracerProvider, if not nil, provides the tracer of the spans of the
+	// requests instead of the global tracer provider.
+	TracerProvider trace.TracerProvider
+
+	// PublicPassphrase is the public passphrase of the wallets loaded
+	// with the loadwallet method.
+	PublicPassphrase []byte
+
 	MaxPOSTClients      int64
 	MaxWebsocketClients int64
 }
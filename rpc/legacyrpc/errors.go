@@ -37,10 +37,18 @@ type (
 	}
 )
 
+// errRPCWalletAlreadyLoaded is the code of the error of loading a wallet that
+// is loaded already.  It is Bitcoin Core's RPC_WALLET_ALREADY_LOADED, which
+// btcjson does not define.
+const errRPCWalletAlreadyLoaded btcjson.RPCErrorCode = -35
+
 // Errors variables that are defined once here to avoid duplication below.
+// The codes and messages of common failures match those of Bitcoin Core, as
+// clients written for Core check them.
 var (
-	ErrNeedPositiveAmount = InvalidParameterError{
-		errors.New("amount must be positive"),
+	ErrNeedPositiveAmount = btcjson.RPCError{
+		Code:    btcjson.ErrRPCType,
+		Message: "Invalid amount for send",
 	}
 
 	ErrNeedPositiveMinconf = InvalidParameterError{
@@ -63,13 +71,33 @@ var (
 	}
 
 	ErrUnloadedWallet = btcjson.RPCError{
-		Code:    btcjson.ErrRPCWallet,
-		Message: "Request requires a wallet but wallet has not loaded yet",
+		Code:    btcjson.ErrRPCWalletNotFound,
+		Message: "Requested wallet does not exist or is not loaded",
+	}
+
+	ErrWalletAlreadyLoaded = btcjson.RPCError{
+		Code:    errRPCWalletAlreadyLoaded,
+		Message: "Wallet is already loaded.",
+	}
+
+	ErrInvalidWalletName = btcjson.RPCError{
+		Code:    btcjson.ErrRPCInvalidParameter,
+		Message: "Invalid wallet name",
 	}
 
 	ErrWalletUnlockNeeded = btcjson.RPCError{
 		Code:    btcjson.ErrRPCWalletUnlockNeeded,
-		Message: "Enter the wallet passphrase with walletpassphrase first",
+		Message: "Error: Please enter the wallet passphrase with walletpassphrase first.",
+	}
+
+	ErrWalletPassphraseIncorrect = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletPassphraseIncorrect,
+		Message: "Error: The wallet passphrase entered was incorrect.",
+	}
+
+	ErrWalletInsufficientFunds = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletInsufficientFunds,
+		Message: "Insufficient funds",
 	}
 
 	ErrNotImportedAccount = btcjson.RPCError{
@@ -82,6 +110,11 @@ var (
 		Message: "No information for transaction",
 	}
 
+	ErrRateLimited = btcjson.RPCError{
+		Code:    btcjson.ErrRPCMisc,
+		Message: "Rate limit exceeded",
+	}
+
 	ErrReservedAccountName = btcjson.RPCError{
 		Code:    btcjson.ErrRPCInvalidParameter,
 		Message: "Account name is reserved by RPC server",
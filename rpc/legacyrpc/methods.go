@@ -7,17 +7,24 @@ package legacyrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/rpcclient"
@@ -26,8 +33,10 @@ import (
 	"github.com/btcsuite/btcwallet/wallet/txrules"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/stroomnetwork/btcwallet/chain"
+	"github.com/stroomnetwork/btcwallet/internal/walletjson"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
 	"github.com/stroomnetwork/btcwallet/wallet"
+	"github.com/stroomnetwork/btcwallet/wallet/txauthor"
 )
 
 const (
@@ -52,14 +61,29 @@ func confirms(txHeight, curHeight int32) int32 {
 // or any of the above special error classes, the server will respond with
 // the JSON-RPC appropriate error code.  All other errors use the wallet
 // catch-all error code, btcjson.ErrRPCWallet.
-type requestHandler func(interface{}, *wallet.Wallet) (interface{}, error)
+type requestHandler func(context.Context, interface{}, *wallet.Wallet) (interface{}, error)
 
 // requestHandlerChain is a requestHandler that also takes a parameter for
-type requestHandlerChainRequired func(interface{}, *wallet.Wallet, *chain.RPCClient) (interface{}, error)
+type requestHandlerChainRequired func(context.Context, interface{}, *wallet.Wallet, *chain.RPCClient) (interface{}, error)
+
+// walletsRequest is the context of the requests that load and unload wallets,
+// which are handled by the loader of the wallets rather than by a wallet.
+type walletsRequest struct {
+	wallets       *wallet.MultiLoader
+	pubPassphrase []byte
+
+	// walletName is the name of the wallet the request was made to.
+	walletName string
+}
+
+// requestHandlerWallets is a handler for requests that load and unload
+// wallets.
+type requestHandlerWallets func(interface{}, *walletsRequest) (interface{}, error)
 
 var rpcHandlers = map[string]struct {
-	handler          requestHandler
-	handlerWithChain requestHandlerChainRequired
+	handler            requestHandler
+	handlerWithChain   requestHandlerChainRequired
+	handlerWithWallets requestHandlerWallets
 
 	// Function variables cannot be compared against anything but nil, so
 	// use a boolean to record whether help generation is necessary.  This
@@ -73,8 +97,10 @@ var rpcHandlers = map[string]struct {
 }{
 	// Reference implementation wallet methods (implemented)
 	"addmultisigaddress":     {handler: addMultiSigAddress},
+	"backupwallet":           {handler: backupWallet},
 	"createmultisig":         {handler: createMultiSig},
 	"dumpprivkey":            {handler: dumpPrivKey},
+	"dumpwallet":             {handler: dumpWallet},
 	"getaccount":             {handler: getAccount},
 	"getaccountaddress":      {handler: getAccountAddress},
 	"getaddressesbyaccount":  {handler: getAddressesByAccount},
@@ -87,16 +113,21 @@ var rpcHandlers = map[string]struct {
 	"getreceivedbyaccount":   {handler: getReceivedByAccount},
 	"getreceivedbyaddress":   {handler: getReceivedByAddress},
 	"gettransaction":         {handler: getTransaction},
+	"getwalletinfo":          {handler: getWalletInfo},
 	"help":                   {handler: helpNoChainRPC, handlerWithChain: helpWithChainRPC},
 	"importprivkey":          {handler: importPrivKey},
+	"importwallet":           {handler: importWallet},
 	"keypoolrefill":          {handler: keypoolRefill},
 	"listaccounts":           {handler: listAccounts},
+	"listaddressgroupings":   {handler: listAddressGroupings},
 	"listlockunspent":        {handler: listLockUnspent},
 	"listreceivedbyaccount":  {handler: listReceivedByAccount},
 	"listreceivedbyaddress":  {handler: listReceivedByAddress},
 	"listsinceblock":         {handlerWithChain: listSinceBlock},
 	"listtransactions":       {handler: listTransactions},
 	"listunspent":            {handler: listUnspent},
+	"listwallets":            {handlerWithWallets: listWallets},
+	"loadwallet":             {handlerWithWallets: loadWallet},
 	"lockunspent":            {handler: lockUnspent},
 	"sendfrom":               {handlerWithChain: sendFrom},
 	"sendmany":               {handler: sendMany},
@@ -104,19 +135,13 @@ var rpcHandlers = map[string]struct {
 	"settxfee":               {handler: setTxFee},
 	"signmessage":            {handler: signMessage},
 	"signrawtransaction":     {handlerWithChain: signRawTransaction},
+	"unloadwallet":           {handlerWithWallets: unloadWallet},
 	"validateaddress":        {handler: validateAddress},
 	"verifymessage":          {handler: verifyMessage},
 	"walletlock":             {handler: walletLock},
 	"walletpassphrase":       {handler: walletPassphrase},
 	"walletpassphrasechange": {handler: walletPassphraseChange},
 
-	// Reference implementation methods (still unimplemented)
-	"backupwallet":         {handler: unimplemented, noHelp: true},
-	"dumpwallet":           {handler: unimplemented, noHelp: true},
-	"getwalletinfo":        {handler: unimplemented, noHelp: true},
-	"importwallet":         {handler: unimplemented, noHelp: true},
-	"listaddressgroupings": {handler: unimplemented, noHelp: true},
-
 	// Reference methods which can't be implemented by btcwallet due to
 	// design decision differences
 	"encryptwallet": {handler: unsupported, noHelp: true},
@@ -124,8 +149,12 @@ var rpcHandlers = map[string]struct {
 	"setaccount":    {handler: unsupported, noHelp: true},
 
 	// Extensions to the reference client JSON-RPC API
-	"createnewaccount": {handler: createNewAccount},
-	"getbestblock":     {handler: getBestBlock},
+	"cancelcoldspend":   {handler: cancelColdSpend},
+	"clearreorghalt":    {handler: clearReorgHalt},
+	"completecoldspend": {handler: completeColdSpend},
+	"createnewaccount":  {handler: createNewAccount},
+	"exporthistory":     {handler: exportHistory},
+	"getbestblock":      {handler: getBestBlock},
 	// This was an extension but the reference implementation added it as
 	// well, but with a different API (no account parameter).  It's listed
 	// here because it hasn't been update to use the reference
@@ -133,7 +162,12 @@ var rpcHandlers = map[string]struct {
 	"getunconfirmedbalance":   {handler: getUnconfirmedBalance},
 	"listaddresstransactions": {handler: listAddressTransactions},
 	"listalltransactions":     {handler: listAllTransactions},
+	"listcoldspends":          {handler: listColdSpends},
 	"renameaccount":           {handler: renameAccount},
+	"rotateseed":              {handler: rotateSeed},
+	"setaccountspendingmode":  {handler: setAccountSpendingMode},
+	"sweepretiredaccount":     {handler: sweepRetiredAccount},
+	"waitforconfirmations":    {handler: waitForConfirmations},
 	"walletislocked":          {handler: walletIsLocked},
 }
 
@@ -148,7 +182,7 @@ func unimplemented(interface{}, *wallet.Wallet) (interface{}, error) {
 
 // unsupported handles a standard bitcoind RPC request which is
 // unsupported by btcwallet due to design differences.
-func unsupported(interface{}, *wallet.Wallet) (interface{}, error) {
+func unsupported(context.Context, interface{}, *wallet.Wallet) (interface{}, error) {
 	return nil, &btcjson.RPCError{
 		Code:    -1,
 		Message: "Request unsupported by btcwallet",
@@ -161,21 +195,24 @@ func unsupported(interface{}, *wallet.Wallet) (interface{}, error) {
 type lazyHandler func() (interface{}, *btcjson.RPCError)
 
 // lazyApplyHandler looks up the best request handler func for the method,
-// returning a closure that will execute it with the (required) wallet and
-// (optional) consensus RPC server.  If no handlers are found and the
-// chainClient is not nil, the returned handler performs RPC passthrough.
-func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient chain.Interface) lazyHandler {
+// returning a closure that will execute it with the context of the request,
+// the (required) wallet and (optional) consensus RPC server.  If no handlers
+// are found and the chainClient is not nil, the returned handler performs RPC
+// passthrough.
+func lazyApplyHandler(ctx context.Context, request *btcjson.Request,
+	w *wallet.Wallet, chainClient chain.Interface) lazyHandler {
+
 	handlerData, ok := rpcHandlers[request.Method]
 	if ok && handlerData.handlerWithChain != nil && w != nil && chainClient != nil {
 		return func() (interface{}, *btcjson.RPCError) {
 			cmd, err := btcjson.UnmarshalCmd(request)
 			if err != nil {
-				return nil, btcjson.ErrRPCInvalidRequest
+				return nil, unmarshalCmdError(err)
 			}
 			switch client := chainClient.(type) {
 			case *chain.RPCClient:
-				resp, err := handlerData.handlerWithChain(cmd,
-					w, client)
+				resp, err := handlerData.handlerWithChain(ctx,
+					cmd, w, client)
 				if err != nil {
 					return nil, jsonError(err)
 				}
@@ -192,9 +229,9 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 		return func() (interface{}, *btcjson.RPCError) {
 			cmd, err := btcjson.UnmarshalCmd(request)
 			if err != nil {
-				return nil, btcjson.ErrRPCInvalidRequest
+				return nil, unmarshalCmdError(err)
 			}
-			resp, err := handlerData.handler(cmd, w)
+			resp, err := handlerData.handler(ctx, cmd, w)
 			if err != nil {
 				return nil, jsonError(err)
 			}
@@ -227,6 +264,54 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 	}
 }
 
+// lazyApplyWalletsHandler returns a closure that will execute the handler of
+// a request that loads or unloads wallets.
+func lazyApplyWalletsHandler(request *btcjson.Request,
+	handler requestHandlerWallets, wr *walletsRequest) lazyHandler {
+
+	return func() (interface{}, *btcjson.RPCError) {
+		if wr.wallets == nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCUnimplemented,
+				Message: "Method unimplemented",
+			}
+		}
+		cmd, err := btcjson.UnmarshalCmd(request)
+		if err != nil {
+			return nil, unmarshalCmdError(err)
+		}
+		resp, err := handler(cmd, wr)
+		if err != nil {
+			return nil, jsonError(err)
+		}
+		return resp, nil
+	}
+}
+
+// unmarshalCmdError returns the JSON-RPC error for parameters of a request
+// that could not be unmarshaled.  Like Bitcoin Core, parameters of the wrong
+// type are reported as type errors.
+func unmarshalCmdError(err error) *btcjson.RPCError {
+	var e btcjson.Error
+	if !errors.As(err, &e) {
+		return btcjson.ErrRPCInvalidRequest
+	}
+	switch e.ErrorCode {
+	case btcjson.ErrInvalidType:
+		return &btcjson.RPCError{
+			Code:    btcjson.ErrRPCType,
+			Message: e.Description,
+		}
+	case btcjson.ErrUnregisteredMethod:
+		return btcjson.ErrRPCMethodNotFound
+	default:
+		return &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParams.Code,
+			Message: e.Description,
+		}
+	}
+}
+
 // makeResponse makes the JSON-RPC response struct for the result and error
 // returned by a requestHandler.  The returned response is not ready for
 // marshaling and sending off to a client, but must be
@@ -254,7 +339,8 @@ func makeResponse(id, result interface{}, err error) btcjson.Response {
 	}
 }
 
-// jsonError creates a JSON-RPC error from the Go error.
+// jsonError creates a JSON-RPC error from the Go error.  Common wallet
+// failures are reported with the codes and messages of Bitcoin Core.
 func jsonError(err error) *btcjson.RPCError {
 	if err == nil {
 		return nil
@@ -272,10 +358,30 @@ func jsonError(err error) *btcjson.RPCError {
 		code = btcjson.ErrRPCInvalidParameter
 	case ParseError:
 		code = btcjson.ErrRPCParse.Code
-	case waddrmgr.ManagerError:
-		if e.ErrorCode == waddrmgr.ErrWrongPassphrase {
-			code = btcjson.ErrRPCWalletPassphraseIncorrect
-		}
+	}
+
+	var (
+		managerErr     waddrmgr.ManagerError
+		inputSourceErr txauthor.InputSourceError
+	)
+	switch {
+	case errors.As(err, &managerErr) &&
+		managerErr.ErrorCode == waddrmgr.ErrWrongPassphrase:
+		return &ErrWalletPassphraseIncorrect
+	case errors.As(err, &managerErr) &&
+		managerErr.ErrorCode == waddrmgr.ErrLocked:
+		return &ErrWalletUnlockNeeded
+	case errors.As(err, &inputSourceErr):
+		return &ErrWalletInsufficientFunds
+	case errors.Is(err, txrules.ErrAmountNegative):
+		return &ErrNeedPositiveAmount
+	case errors.Is(err, wallet.ErrNotLoaded),
+		errors.Is(err, wallet.ErrNotExists):
+		return &ErrUnloadedWallet
+	case errors.Is(err, wallet.ErrLoaded):
+		return &ErrWalletAlreadyLoaded
+	case errors.Is(err, wallet.ErrInvalidWalletName):
+		return &ErrInvalidWalletName
 	}
 	return &btcjson.RPCError{
 		Code:    code,
@@ -320,7 +426,7 @@ func makeMultiSigScript(w *wallet.Wallet, keys []string, nRequired int) ([]byte,
 
 // addMultiSigAddress handles an addmultisigaddress request by adding a
 // multisig address to the given wallet.
-func addMultiSigAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func addMultiSigAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.AddMultisigAddressCmd)
 
 	// If an account is specified, ensure that is the imported account.
@@ -352,7 +458,7 @@ func addMultiSigAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error)
 
 // createMultiSig handles an createmultisig request by returning a
 // multisig address for the given inputs.
-func createMultiSig(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func createMultiSig(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.CreateMultisigCmd)
 
 	script, err := makeMultiSigScript(w, cmd.Keys, cmd.NRequired)
@@ -372,10 +478,46 @@ func createMultiSig(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	}, nil
 }
 
+// backupWallet handles a backupwallet request by writing a consistent copy
+// of the wallet database to the destination.  If the destination is a
+// directory, the copy is named after the wallet database.  The copy is
+// written to a temporary file first, so an existing backup is only replaced
+// by a complete one.
+func backupWallet(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.BackupWalletCmd)
+
+	dest, err := filepath.Abs(cmd.Destination)
+	if err != nil {
+		return nil, InvalidParameterError{err}
+	}
+	if fi, err := os.Stat(dest); err == nil && fi.IsDir() {
+		dest = filepath.Join(dest, wallet.WalletDBName)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(dest), filepath.Base(dest)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	err = w.Database().Copy(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("wallet backup failed: %w", err)
+	}
+
+	return nil, os.Rename(f.Name(), dest)
+}
+
 // dumpPrivKey handles a dumpprivkey request with the private key
 // for a single address, or an appropriate error if the wallet
 // is locked.
-func dumpPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func dumpPrivKey(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.DumpPrivKeyCmd)
 
 	addr, err := decodeAddress(cmd.Address, w.ChainParams())
@@ -392,10 +534,57 @@ func dumpPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return key, err
 }
 
+// dumpWallet handles a dumpwallet request by writing the private keys and
+// redeem scripts of the wallet to a new file, in the text format of Bitcoin
+// Core.  Existing files are never overwritten.
+func dumpWallet(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.DumpWalletCmd)
+
+	filename, err := filepath.Abs(cmd.Filename)
+	if err != nil {
+		return nil, InvalidParameterError{err}
+	}
+
+	dump, err := w.DumpWallet()
+	if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, fs.ErrExist) {
+		return nil, &btcjson.RPCError{
+			Code: btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("%s already exists. If you are sure "+
+				"this is what you want, move it out of the way "+
+				"first", filename),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeWalletDump(f, dump, time.Now())
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return nil, err
+	}
+
+	return &btcjson.DumpWalletResult{Filename: filename}, nil
+}
+
 // getAddressesByAccount handles a getaddressesbyaccount request by returning
 // all addresses for an account, or an error if the requested account does
 // not exist.
-func getAddressesByAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getAddressesByAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetAddressesByAccountCmd)
 
 	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
@@ -418,7 +607,7 @@ func getAddressesByAccount(icmd interface{}, w *wallet.Wallet) (interface{}, err
 // getBalance handles a getbalance request by returning the balance for an
 // account (wallet), or an error if the requested account does not
 // exist.
-func getBalance(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getBalance(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetBalanceCmd)
 
 	var balance btcutil.Amount
@@ -449,7 +638,7 @@ func getBalance(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
 // getBestBlock handles a getbestblock request by returning a JSON object
 // with the height and hash of the most recently processed block.
-func getBestBlock(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getBestBlock(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	blk := w.Manager.SyncedTo()
 	result := &btcjson.GetBestBlockResult{
 		Hash:   blk.Hash.String(),
@@ -460,14 +649,14 @@ func getBestBlock(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
 // getBestBlockHash handles a getbestblockhash request by returning the hash
 // of the most recently processed block.
-func getBestBlockHash(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getBestBlockHash(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	blk := w.Manager.SyncedTo()
 	return blk.Hash.String(), nil
 }
 
 // getBlockCount handles a getblockcount request by returning the chain height
 // of the most recently processed block.
-func getBlockCount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getBlockCount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	blk := w.Manager.SyncedTo()
 	return blk.Height, nil
 }
@@ -475,7 +664,7 @@ func getBlockCount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 // getInfo handles a getinfo request by returning the a structure containing
 // information about the current state of btcwallet.
 // exist.
-func getInfo(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+func getInfo(ctx context.Context, icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
 	// Call down to btcd for all of the information in this command known
 	// by them.
 	info, err := chainClient.GetInfo()
@@ -493,29 +682,24 @@ func getInfo(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (
 	info.WalletVersion = int32(waddrmgr.LatestMgrVersion)
 	info.Balance = bal.ToBTC()
 	info.PaytxFee = float64(txrules.DefaultRelayFeePerKb)
-	// We don't set the following since they don't make much sense in the
-	// wallet architecture:
-	//  - unlocked_until
-	//  - errors
+	// We don't set unlocked_until since it doesn't make much sense in the
+	// wallet architecture.  Errors only report a reorg halt.
+	info.Errors = ""
+	if halt := w.ReorgHalted(); halt != nil {
+		info.Errors = "Wallet halted: " + halt.Error()
+	}
 
 	return info, nil
 }
 
+// decodeAddress decodes an address of the network, returning the error of
+// Bitcoin Core for invalid addresses.
 func decodeAddress(s string, params *chaincfg.Params) (btcutil.Address, error) {
 	addr, err := btcutil.DecodeAddress(s, params)
-	if err != nil {
-		msg := fmt.Sprintf("Invalid address %q: decode failed with %#q", s, err)
+	if err != nil || !addr.IsForNet(params) {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInvalidAddressOrKey,
-			Message: msg,
-		}
-	}
-	if !addr.IsForNet(params) {
-		msg := fmt.Sprintf("Invalid address %q: not intended for use on %s",
-			addr, params.Name)
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCInvalidAddressOrKey,
-			Message: msg,
+			Message: "Invalid Bitcoin address: " + s,
 		}
 	}
 	return addr, nil
@@ -523,7 +707,7 @@ func decodeAddress(s string, params *chaincfg.Params) (btcutil.Address, error) {
 
 // getAccount handles a getaccount request by returning the account name
 // associated with a single address.
-func getAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetAccountCmd)
 
 	addr, err := decodeAddress(cmd.Address, w.ChainParams())
@@ -550,7 +734,7 @@ func getAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 // If the most recently-requested address has been used, a new address (the
 // next chained address in the keypool) is used.  This can fail if the keypool
 // runs out (and will return btcjson.ErrRPCWalletKeypoolRanOut if that happens).
-func getAccountAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getAccountAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetAccountAddressCmd)
 
 	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
@@ -567,7 +751,7 @@ func getAccountAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error)
 
 // getUnconfirmedBalance handles a getunconfirmedbalance extension request
 // by returning the current unconfirmed balance of an account.
-func getUnconfirmedBalance(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getUnconfirmedBalance(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetUnconfirmedBalanceCmd)
 
 	acctName := defaultAccountName
@@ -586,9 +770,52 @@ func getUnconfirmedBalance(icmd interface{}, w *wallet.Wallet) (interface{}, err
 	return (bals.Total - bals.Spendable).ToBTC(), nil
 }
 
+// getWalletInfo handles a getwalletinfo request by returning the balances,
+// key pool, lock and rescan state of the wallet.
+func getWalletInfo(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	bals, err := w.CalculateWalletBalances(1)
+	if err != nil {
+		return nil, err
+	}
+	txCount, err := w.TransactionCount()
+	if err != nil {
+		return nil, err
+	}
+	external, internal, err := w.KeyPoolSize()
+	if err != nil {
+		return nil, err
+	}
+
+	unconfirmed := bals.Total - bals.Spendable - bals.ImmatureReward
+	result := &walletjson.GetWalletInfoResult{
+		WalletVersion:         int(waddrmgr.LatestMgrVersion),
+		Balance:               bals.Spendable.ToBTC(),
+		UnconfirmedBalance:    unconfirmed.ToBTC(),
+		ImmatureBalance:       bals.ImmatureReward.ToBTC(),
+		TransactionCount:      txCount,
+		KeyPoolOldest:         w.Manager.Birthday().Unix(),
+		KeyPoolSize:           external,
+		KeyPoolSizeHDInternal: internal,
+		PayTransactionFee:     txrules.DefaultRelayFeePerKb.ToBTC(),
+		PrivateKeysEnabled:    !w.Manager.WatchOnly(),
+		Scanning:              false,
+	}
+	if unlockedUntil := w.UnlockedUntil(); !unlockedUntil.IsZero() {
+		result.UnlockedUntil = unlockedUntil.Unix()
+	}
+	if status := w.Scanning(); status != nil {
+		result.Scanning = btcjson.ScanProgress{
+			Duration: int(time.Since(status.Started).Seconds()),
+			Progress: status.Progress(),
+		}
+	}
+
+	return result, nil
+}
+
 // importPrivKey handles an importprivkey request by parsing
 // a WIF-encoded private key and adding it to an account.
-func importPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func importPrivKey(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ImportPrivKeyCmd)
 
 	// Ensure that private keys are only imported to the correct account.
@@ -625,16 +852,90 @@ func importPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, err
 }
 
+// importWallet handles an importwallet request by importing the private keys
+// and redeem scripts of a wallet dump file, and rescanning the chain for
+// them starting at the earliest key time of the dump.
+func importWallet(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.ImportWalletCmd)
+
+	f, err := os.Open(cmd.Filename)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Cannot open wallet dump file: %v", err),
+		}
+	}
+	defer f.Close()
+
+	dump, err := parseWalletDump(f, w.ChainParams())
+	if err != nil {
+		return nil, InvalidParameterError{err}
+	}
+
+	_, err = w.ImportDump(dump)
+	if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	return nil, err
+}
+
 // keypoolRefill handles the keypoolrefill command. Since we handle the keypool
 // automatically this does nothing since refilling is never manually required.
-func keypoolRefill(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func keypoolRefill(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, nil
 }
 
+// cancelColdSpend handles a cancelcoldspend extension request by forgetting a
+// pending cold spend and unlocking its inputs.
+func cancelColdSpend(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.CancelColdSpendCmd)
+
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		return nil, DeserializationError{err}
+	}
+
+	err = w.CancelColdSpend(*txHash)
+	if errors.Is(err, wallet.ErrColdSpendNotFound) {
+		return nil, InvalidParameterError{err}
+	}
+	return nil, err
+}
+
+// clearReorgHalt handles the clearreorghalt extension request by resuming
+// sending and publishing transactions after the wallet was halted by a deep
+// chain reorganization.
+func clearReorgHalt(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	return nil, w.ClearReorgHalt()
+}
+
+// completeColdSpend handles a completecoldspend extension request by
+// publishing a pending cold spend signed outside of the wallet.
+func completeColdSpend(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.CompleteColdSpendCmd)
+
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(cmd.Psbt), true)
+	if err != nil {
+		return nil, DeserializationError{err}
+	}
+
+	tx, err := w.CompleteColdSpend(ctx, packet, "")
+	if errors.Is(err, wallet.ErrColdSpendNotFound) ||
+		errors.Is(err, wallet.ErrColdSpendInvalid) {
+
+		return nil, InvalidParameterError{err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.TxHash().String(), nil
+}
+
 // createNewAccount handles a createnewaccount request by creating and
 // returning a new account. If the last account has no transaction history
 // as per BIP 0044 a new account cannot be created so an error will be returned.
-func createNewAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func createNewAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.CreateNewAccountCmd)
 
 	// The wildcard * is reserved by the rpc server with the special meaning
@@ -654,9 +955,69 @@ func createNewAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, err
 }
 
+// exportHistory handles an exporthistory extension request by writing the
+// transaction history of the wallet to a new file.  Existing files are never
+// overwritten.
+func exportHistory(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.ExportHistoryCmd)
+
+	filename, err := filepath.Abs(cmd.Filename)
+	if err != nil {
+		return nil, InvalidParameterError{err}
+	}
+	format, err := wallet.ParseHistoryFormat(*cmd.Format)
+	if err != nil {
+		return nil, InvalidParameterError{err}
+	}
+	filter := wallet.HistoryFilter{
+		StartHeight: *cmd.StartHeight,
+		EndHeight:   *cmd.EndHeight,
+	}
+	if cmd.Account != nil && *cmd.Account != "*" {
+		filter.Account = *cmd.Account
+	}
+	if filter.StartHeight < 0 || (filter.EndHeight != -1 &&
+		filter.EndHeight < filter.StartHeight) {
+
+		return nil, InvalidParameterError{
+			errors.New("invalid block height range"),
+		}
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, fs.ErrExist) {
+		return nil, &btcjson.RPCError{
+			Code: btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("%s already exists. If you are sure "+
+				"this is what you want, move it out of the way "+
+				"first", filename),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := w.ExportHistory(f, filter, format)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return nil, err
+	}
+
+	return &walletjson.ExportHistoryResult{
+		Filename: filename,
+		Entries:  n,
+	}, nil
+}
+
 // renameAccount handles a renameaccount request by renaming an account.
 // If the account does not exist an appropriate error will be returned.
-func renameAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func renameAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.RenameAccountCmd)
 
 	// The wildcard * is reserved by the rpc server with the special meaning
@@ -673,12 +1034,137 @@ func renameAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, w.RenameAccount(waddrmgr.KeyScopeBIP0044, account, cmd.NewAccount)
 }
 
+// rotateSeed handles a rotateseed extension request by replacing the seed of
+// the wallet.  The accounts of the previous seed are retired and replaced by
+// accounts of the new seed.
+func rotateSeed(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.RotateSeedCmd)
+
+	seed, err := hex.DecodeString(cmd.Seed)
+	if err != nil {
+		return nil, DeserializationError{err}
+	}
+	if len(seed) < hdkeychain.MinSeedBytes ||
+		len(seed) > hdkeychain.MaxSeedBytes {
+
+		return nil, InvalidParameterError{hdkeychain.ErrInvalidSeedLen}
+	}
+
+	return nil, w.RotateSeed(seed)
+}
+
+// setAccountSpendingMode handles a setaccountspendingmode extension request by
+// setting the spending mode of an account in every key scope it exists in.
+func setAccountSpendingMode(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.SetAccountSpendingModeCmd)
+
+	var mode waddrmgr.SpendingMode
+	switch cmd.Mode {
+	case waddrmgr.SpendingModeHot.String():
+		mode = waddrmgr.SpendingModeHot
+	case waddrmgr.SpendingModeCold.String():
+		mode = waddrmgr.SpendingModeCold
+	default:
+		return nil, InvalidParameterError{
+			fmt.Errorf("unknown spending mode %q", cmd.Mode),
+		}
+	}
+
+	found := false
+	for _, scopedMgr := range w.Manager.ActiveScopedKeyManagers() {
+		scope := scopedMgr.Scope()
+		account, err := w.AccountNumber(scope, cmd.Account)
+		if waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+
+		err = w.SetAccountSpendingMode(scope, account, mode)
+		if waddrmgr.IsError(err, waddrmgr.ErrInvalidAccount) ||
+			waddrmgr.IsError(err, waddrmgr.ErrWatchingOnly) {
+
+			return nil, InvalidParameterError{err}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, &ErrAccountNameNotFound
+	}
+
+	return nil, nil
+}
+
+// sweepRetiredAccount handles a sweepretiredaccount extension request by
+// sweeping the outputs of an account retired by a seed rotation to the
+// account that replaced it, in each key scope in which it has outputs.
+func sweepRetiredAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.SweepRetiredAccountCmd)
+
+	if *cmd.MinConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+
+	// Sweeps paying less than the minimum relay fee rate wouldn't be
+	// relayed.
+	feeRate := txrules.DefaultRelayFeePerKb
+	if cmd.FeeRate != nil {
+		var err error
+		feeRate, err = btcutil.NewAmount(*cmd.FeeRate)
+		if err != nil {
+			return nil, InvalidParameterError{err}
+		}
+		if feeRate < txrules.DefaultRelayFeePerKb {
+			return nil, InvalidParameterError{fmt.Errorf("fee "+
+				"rate is below the minimum relay fee rate "+
+				"of %v/kB", txrules.DefaultRelayFeePerKb)}
+		}
+	}
+
+	found := false
+	txids := []string{}
+	for _, scopedMgr := range w.Manager.ActiveScopedKeyManagers() {
+		scope := scopedMgr.Scope()
+		account, err := w.AccountNumber(scope, cmd.Account)
+		if waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+
+		tx, err := w.SweepRetiredAccount(
+			ctx, scope, account, int32(*cmd.MinConf), feeRate, "",
+		)
+		if errors.Is(err, wallet.ErrNothingToSweep) {
+			continue
+		}
+		if errors.Is(err, wallet.ErrAccountNotRetired) {
+			return nil, InvalidParameterError{err}
+		}
+		if err != nil {
+			return nil, err
+		}
+		txids = append(txids, tx.TxHash().String())
+	}
+	if !found {
+		return nil, &ErrAccountNameNotFound
+	}
+
+	return txids, nil
+}
+
 // getNewAddress handles a getnewaddress request by returning a new
 // address for an account.  If the account does not exist an appropriate
 // error is returned.
 // TODO: Follow BIP 0044 and warn if number of unused addresses exceeds
 // the gap limit.
-func getNewAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getNewAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetNewAddressCmd)
 
 	acctName := defaultAccountName
@@ -715,7 +1201,7 @@ func getNewAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 //
 // Note: bitcoind allows specifying the account as an optional parameter,
 // but ignores the parameter.
-func getRawChangeAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getRawChangeAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetRawChangeAddressCmd)
 
 	acctName := defaultAccountName
@@ -749,7 +1235,7 @@ func getRawChangeAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error
 
 // getReceivedByAccount handles a getreceivedbyaccount request by returning
 // the total amount received by addresses of an account.
-func getReceivedByAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getReceivedByAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetReceivedByAccountCmd)
 
 	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.Account)
@@ -775,7 +1261,7 @@ func getReceivedByAccount(icmd interface{}, w *wallet.Wallet) (interface{}, erro
 
 // getReceivedByAddress handles a getreceivedbyaddress request by returning
 // the total amount received by a single address.
-func getReceivedByAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getReceivedByAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetReceivedByAddressCmd)
 
 	addr, err := decodeAddress(cmd.Address, w.ChainParams())
@@ -792,7 +1278,7 @@ func getReceivedByAddress(icmd interface{}, w *wallet.Wallet) (interface{}, erro
 
 // getTransaction handles a gettransaction request by returning details about
 // a single transaction saved by wallet.
-func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func getTransaction(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.GetTransactionCmd)
 
 	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
@@ -953,14 +1439,14 @@ var helpDescsMu sync.Mutex // Help may execute concurrently, so synchronize acce
 // associated with a consensus RPC client.  The additional RPC client is used to
 // include help messages for methods implemented by the consensus server via RPC
 // passthrough.
-func helpWithChainRPC(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+func helpWithChainRPC(ctx context.Context, icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
 	return help(icmd, w, chainClient)
 }
 
 // helpNoChainRPC handles the help request when the RPC server has not been
 // associated with a consensus RPC client.  No help messages are included for
 // passthrough requests.
-func helpNoChainRPC(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func helpNoChainRPC(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return help(icmd, w, nil)
 }
 
@@ -1050,7 +1536,7 @@ func help(icmd interface{}, _ *wallet.Wallet, chainClient *chain.RPCClient) (int
 
 // listAccounts handles a listaccounts request by returning a map of account
 // names to their balances.
-func listAccounts(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listAccounts(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListAccountsCmd)
 
 	accountBalances := map[string]float64{}
@@ -1065,9 +1551,35 @@ func listAccounts(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return accountBalances, nil
 }
 
+// listAddressGroupings handles a listaddressgroupings request by returning
+// the wallet addresses grouped by common ownership, as made public by
+// spending them together as inputs or as change.  Each address is reported
+// as an array of the address, its balance and its account.
+func listAddressGroupings(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	groupings, err := w.AddressGroupings()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][][]interface{}, 0, len(groupings))
+	for _, grouping := range groupings {
+		group := make([][]interface{}, 0, len(grouping))
+		for _, entry := range grouping {
+			group = append(group, []interface{}{
+				entry.Address.EncodeAddress(),
+				entry.Balance.ToBTC(),
+				entry.Account,
+			})
+		}
+		result = append(result, group)
+	}
+
+	return result, nil
+}
+
 // listLockUnspent handles a listlockunspent request by returning an slice of
 // all locked outpoints.
-func listLockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listLockUnspent(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return w.LockedOutpoints(), nil
 }
 
@@ -1084,7 +1596,7 @@ func listLockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 //	           default: one;
 //	"includeempty": whether or not to include addresses that have no transactions -
 //	                default: false.
-func listReceivedByAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listReceivedByAccount(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListReceivedByAccountCmd)
 
 	results, err := w.TotalReceivedForAccounts(
@@ -1119,7 +1631,7 @@ func listReceivedByAccount(icmd interface{}, w *wallet.Wallet) (interface{}, err
 //	           default: one;
 //	"includeempty": whether or not to include addresses that have no transactions -
 //	                default: false.
-func listReceivedByAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listReceivedByAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListReceivedByAddressCmd)
 
 	// Intermediate data for each address.
@@ -1210,7 +1722,7 @@ func listReceivedByAddress(icmd interface{}, w *wallet.Wallet) (interface{}, err
 
 // listSinceBlock handles a listsinceblock request by returning an array of maps
 // with details of sent and received wallet transactions since the given block.
-func listSinceBlock(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+func listSinceBlock(ctx context.Context, icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListSinceBlockCmd)
 
 	syncBlock := w.Manager.SyncedTo()
@@ -1254,7 +1766,7 @@ func listSinceBlock(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCCl
 
 // listTransactions handles a listtransactions request by returning an
 // array of maps with details of sent and recevied wallet transactions.
-func listTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listTransactions(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListTransactionsCmd)
 
 	// TODO: ListTransactions does not currently understand the difference
@@ -1279,7 +1791,7 @@ func listTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 // transactions.  The form of the reply is identical to listtransactions,
 // but the array elements are limited to transaction details which are
 // about the addresess included in the request.
-func listAddressTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listAddressTransactions(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListAddressTransactionsCmd)
 
 	if cmd.Account != nil && *cmd.Account != "*" {
@@ -1302,11 +1814,39 @@ func listAddressTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, e
 	return w.ListAddressTransactions(hash160Map)
 }
 
+// listColdSpends handles a listcoldspends extension request by returning the
+// pending cold spends, oldest first.
+func listColdSpends(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	spends, err := w.ColdSpends()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]walletjson.ListColdSpendsResult, 0, len(spends))
+	for _, spend := range spends {
+		account, err := w.AccountName(spend.KeyScope, spend.Account)
+		if err != nil {
+			return nil, err
+		}
+		packet, err := spend.Packet.B64Encode()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, walletjson.ListColdSpendsResult{
+			TxID:    spend.TxHash().String(),
+			Account: account,
+			Psbt:    packet,
+			Created: spend.Created.Unix(),
+		})
+	}
+	return results, nil
+}
+
 // listAllTransactions handles a listalltransactions request by returning
 // a map with details of sent and recevied wallet transactions.  This is
 // similar to ListTransactions, except it takes only a single optional
 // argument for the account name and replies with all transactions.
-func listAllTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listAllTransactions(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListAllTransactionsCmd)
 
 	if cmd.Account != nil && *cmd.Account != "*" {
@@ -1320,7 +1860,7 @@ func listAllTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error
 }
 
 // listUnspent handles the listunspent command.
-func listUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func listUnspent(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ListUnspentCmd)
 
 	if cmd.Addresses != nil && len(*cmd.Addresses) > 0 {
@@ -1333,8 +1873,31 @@ func listUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return w.ListUnspent(int32(*cmd.MinConf), int32(*cmd.MaxConf), "")
 }
 
+// listWallets handles the listwallets command by returning the names of the
+// loaded wallets.  The default wallet is named by the empty string.
+func listWallets(icmd interface{}, wr *walletsRequest) (interface{}, error) {
+	names := wr.wallets.LoadedWallets()
+	if names == nil {
+		names = []string{}
+	}
+	return names, nil
+}
+
+// loadWallet handles the loadwallet command by loading the named wallet from
+// the wallets directory.  Requests are made to the loaded wallet with the
+// /wallet/<name> URI path.
+func loadWallet(icmd interface{}, wr *walletsRequest) (interface{}, error) {
+	cmd := icmd.(*btcjson.LoadWalletCmd)
+
+	_, err := wr.wallets.LoadWallet(cmd.WalletName, wr.pubPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &btcjson.LoadWalletResult{Name: cmd.WalletName}, nil
+}
+
 // lockUnspent handles the lockunspent command.
-func lockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func lockUnspent(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.LockUnspentCmd)
 
 	switch {
@@ -1364,9 +1927,9 @@ func lockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 func makeOutputs(pairs map[string]btcutil.Amount, chainParams *chaincfg.Params) ([]*wire.TxOut, error) {
 	outputs := make([]*wire.TxOut, 0, len(pairs))
 	for addrStr, amt := range pairs {
-		addr, err := btcutil.DecodeAddress(addrStr, chainParams)
+		addr, err := decodeAddress(addrStr, chainParams)
 		if err != nil {
-			return nil, fmt.Errorf("cannot decode address: %w", err)
+			return nil, err
 		}
 
 		pkScript, err := txscript.PayToAddrScript(addr)
@@ -1382,7 +1945,7 @@ func makeOutputs(pairs map[string]btcutil.Amount, chainParams *chaincfg.Params)
 // sendPairs creates and sends payment transactions.
 // It returns the transaction hash in string format upon success
 // All errors are returned in btcjson.RPCError format
-func sendPairs(w *wallet.Wallet, amounts map[string]btcutil.Amount,
+func sendPairs(ctx context.Context, w *wallet.Wallet, amounts map[string]btcutil.Amount,
 	keyScope waddrmgr.KeyScope, account uint32, minconf int32,
 	feeSatPerKb btcutil.Amount) (string, error) {
 
@@ -1391,24 +1954,13 @@ func sendPairs(w *wallet.Wallet, amounts map[string]btcutil.Amount,
 		return "", err
 	}
 	tx, err := w.SendOutputs(
-		outputs, &keyScope, account, minconf, feeSatPerKb,
+		ctx, outputs, &keyScope, account, minconf, feeSatPerKb,
 		wallet.CoinSelectionLargest, "",
 	)
 	if err != nil {
-		if err == txrules.ErrAmountNegative {
-			return "", ErrNeedPositiveAmount
-		}
-		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
-			return "", &ErrWalletUnlockNeeded
-		}
-		if _, ok := err.(btcjson.RPCError); ok {
-			return "", err
-		}
-
-		return "", &btcjson.RPCError{
-			Code:    btcjson.ErrRPCInternal.Code,
-			Message: err.Error(),
-		}
+		// Insufficient funds, a locked wallet and negative amounts
+		// are translated to the errors of Bitcoin Core by jsonError.
+		return "", err
 	}
 
 	txHashStr := tx.TxHash().String()
@@ -1425,7 +1977,7 @@ func isNilOrEmpty(s *string) bool {
 // address.  Leftover inputs not sent to the payment address or a fee for
 // the miner are sent back to a new address in the wallet.  Upon success,
 // the TxID for the created transaction is returned.
-func sendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+func sendFrom(ctx context.Context, icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
 	cmd := icmd.(*btcjson.SendFromCmd)
 
 	// Transaction comments are not yet supported.  Error instead of
@@ -1461,7 +2013,7 @@ func sendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient)
 		cmd.ToAddress: amt,
 	}
 
-	return sendPairs(w, pairs, waddrmgr.KeyScopeBIP0044, account, minConf,
+	return sendPairs(ctx, w, pairs, waddrmgr.KeyScopeBIP0044, account, minConf,
 		txrules.DefaultRelayFeePerKb)
 }
 
@@ -1470,7 +2022,7 @@ func sendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient)
 // payment addresses.  Leftover inputs not sent to the payment address
 // or a fee for the miner are sent back to a new address in the wallet.
 // Upon success, the TxID for the created transaction is returned.
-func sendMany(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func sendMany(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.SendManyCmd)
 
 	// Transaction comments are not yet supported.  Error instead of
@@ -1503,7 +2055,7 @@ func sendMany(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		pairs[k] = amt
 	}
 
-	return sendPairs(w, pairs, waddrmgr.KeyScopeBIP0044, account, minConf, txrules.DefaultRelayFeePerKb)
+	return sendPairs(ctx, w, pairs, waddrmgr.KeyScopeBIP0044, account, minConf, txrules.DefaultRelayFeePerKb)
 }
 
 // sendToAddress handles a sendtoaddress RPC request by creating a new
@@ -1511,7 +2063,7 @@ func sendMany(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 // payment address.  Leftover inputs not sent to the payment address or a fee
 // for the miner are sent back to a new address in the wallet.  Upon success,
 // the TxID for the created transaction is returned.
-func sendToAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func sendToAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.SendToAddressCmd)
 
 	// Transaction comments are not yet supported.  Error instead of
@@ -1539,12 +2091,12 @@ func sendToAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	}
 
 	// sendtoaddress always spends from the default account, this matches bitcoind
-	return sendPairs(w, pairs, waddrmgr.KeyScopeBIP0044, waddrmgr.DefaultAccountNum, 1,
+	return sendPairs(ctx, w, pairs, waddrmgr.KeyScopeBIP0044, waddrmgr.DefaultAccountNum, 1,
 		txrules.DefaultRelayFeePerKb)
 }
 
 // setTxFee sets the transaction fee per kilobyte added to transactions.
-func setTxFee(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func setTxFee(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.SetTxFeeCmd)
 
 	// Check that amount is not negative.
@@ -1558,7 +2110,7 @@ func setTxFee(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
 // signMessage signs the given message with the private key for the given
 // address
-func signMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func signMessage(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.SignMessageCmd)
 
 	addr, err := decodeAddress(cmd.Address, w.ChainParams())
@@ -1566,22 +2118,16 @@ func signMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		return nil, err
 	}
 
-	privKey, err := w.PrivKeyForAddress(addr)
+	sigbytes, err := w.SignMessage(addr, cmd.Message)
 	if err != nil {
 		return nil, err
 	}
 
-	var buf bytes.Buffer
-	_ = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
-	_ = wire.WriteVarString(&buf, 0, cmd.Message)
-	messageHash := chainhash.DoubleHashB(buf.Bytes())
-	sigbytes := ecdsa.SignCompact(privKey, messageHash, true)
-
 	return base64.StdEncoding.EncodeToString(sigbytes), nil
 }
 
 // signRawTransaction handles the signrawtransaction command.
-func signRawTransaction(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+func signRawTransaction(ctx context.Context, icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
 	cmd := icmd.(*btcjson.SignRawTransactionCmd)
 
 	serializedTx, err := decodeHexStr(cmd.RawTx)
@@ -1754,8 +2300,20 @@ func signRawTransaction(icmd interface{}, w *wallet.Wallet, chainClient *chain.R
 	}, nil
 }
 
+// unloadWallet handles the unloadwallet command by unloading the named
+// wallet, or the wallet the request was made to if no name is given.
+func unloadWallet(icmd interface{}, wr *walletsRequest) (interface{}, error) {
+	cmd := icmd.(*btcjson.UnloadWalletCmd)
+
+	name := wr.walletName
+	if cmd.WalletName != nil {
+		name = *cmd.WalletName
+	}
+	return nil, wr.wallets.UnloadWallet(name)
+}
+
 // validateAddress handles the validateaddress command.
-func validateAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func validateAddress(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ValidateAddressCmd)
 
 	result := btcjson.ValidateAddressWalletResult{}
@@ -1838,7 +2396,7 @@ func validateAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
 // verifyMessage handles the verifymessage command by verifying the provided
 // compact signature for the given address and message.
-func verifyMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func verifyMessage(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.VerifyMessageCmd)
 
 	addr, err := decodeAddress(cmd.Address, w.ChainParams())
@@ -1880,17 +2438,86 @@ func verifyMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	}
 }
 
+// waitForConfirmations handles the waitforconfirmations extension request
+// by long-polling the confirmation depth of a wallet transaction.  The
+// request returns as soon as the transaction's target depth state differs
+// from the state the caller last observed, or when the timeout expires, and
+// always reports the latest known state.
+func waitForConfirmations(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.WaitForConfirmationsCmd)
+
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+	if *cmd.NConf < 1 {
+		return nil, InvalidParameterError{
+			errors.New("nconf must be positive"),
+		}
+	}
+	if *cmd.Timeout < 0 {
+		return nil, InvalidParameterError{
+			errors.New("timeout must not be negative"),
+		}
+	}
+
+	client, err := w.ConfirmationNotifications(txHash, *cmd.NConf)
+	if err == wallet.ErrNoTx {
+		return nil, &ErrNoTransactionInfo
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer client.Done()
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), time.Duration(*cmd.Timeout)*time.Second,
+	)
+	defer cancel()
+
+	// The first notification describes the current state and is always
+	// delivered without delay.
+	n := <-client.C
+	for n.Reached == *cmd.Reached {
+		select {
+		case n = <-client.C:
+		case <-ctx.Done():
+			return marshalConfirmation(n), nil
+		}
+	}
+	return marshalConfirmation(n), nil
+}
+
+// marshalConfirmation creates the waitforconfirmations result for a
+// confirmation notification.
+func marshalConfirmation(n *wallet.ConfirmationNotification) *walletjson.WaitForConfirmationsResult {
+	result := &walletjson.WaitForConfirmationsResult{
+		TxID:                n.TxHash.String(),
+		Confirmations:       n.Confirmations,
+		TargetConfirmations: n.TargetConfirmations,
+		BlockHeight:         n.BlockHeight,
+		Reached:             n.Reached,
+	}
+	if n.BlockHash != nil {
+		result.BlockHash = n.BlockHash.String()
+	}
+	return result
+}
+
 // walletIsLocked handles the walletislocked extension request by
 // returning the current lock state (false for unlocked, true for locked)
 // of an account.
-func walletIsLocked(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func walletIsLocked(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return w.Locked(), nil
 }
 
 // walletLock handles a walletlock request by locking the all account
 // wallets, returning an error if any wallet is not encrypted (for example,
 // a watching-only wallet).
-func walletLock(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func walletLock(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	w.Lock()
 	return nil, nil
 }
@@ -1898,15 +2525,11 @@ func walletLock(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 // walletPassphrase responds to the walletpassphrase request by unlocking
 // the wallet.  The decryption key is saved in the wallet until timeout
 // seconds expires, after which the wallet is locked.
-func walletPassphrase(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func walletPassphrase(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.WalletPassphraseCmd)
 
 	timeout := time.Second * time.Duration(cmd.Timeout)
-	var unlockAfter <-chan time.Time
-	if timeout != 0 {
-		unlockAfter = time.After(timeout)
-	}
-	err := w.Unlock([]byte(cmd.Passphrase), unlockAfter)
+	err := w.UnlockFor([]byte(cmd.Passphrase), timeout)
 	return nil, err
 }
 
@@ -1917,16 +2540,13 @@ func walletPassphrase(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 //
 // If the old passphrase is correct and the passphrase is changed, all
 // wallets will be immediately locked.
-func walletPassphraseChange(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+func walletPassphraseChange(ctx context.Context, icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.WalletPassphraseChangeCmd)
 
 	err := w.ChangePrivatePassphrase([]byte(cmd.OldPassphrase),
 		[]byte(cmd.NewPassphrase))
 	if waddrmgr.IsError(err, waddrmgr.ErrWrongPassphrase) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCWalletPassphraseIncorrect,
-			Message: "Incorrect passphrase",
-		}
+		return nil, &ErrWalletPassphraseIncorrect
 	}
 	return nil, err
 }
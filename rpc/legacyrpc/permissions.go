@@ -0,0 +1,346 @@
+package legacyrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stroomnetwork/btcwallet/internal/walletjson"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// methodPermission is the permission required by a method, and whether the
+// method concerns every account of the wallet.
+type methodPermission struct {
+	perm        macaroons.Permission
+	allAccounts bool
+}
+
+// methodPermissions describes the methods handled by the wallet and the stop
+// method for checking them against the caveats of macaroons.  The accounts
+// and amounts of single requests are found by requestAccounts.
+var methodPermissions = map[string]methodPermission{
+	// Reference implementation wallet methods (implemented)
+	"addmultisigaddress":     {perm: macaroons.PermAddress},
+	"backupwallet":           {perm: macaroons.PermAdmin, allAccounts: true},
+	"createmultisig":         {perm: macaroons.PermRead},
+	"dumpprivkey":            {perm: macaroons.PermAdmin, allAccounts: true},
+	"dumpwallet":             {perm: macaroons.PermAdmin, allAccounts: true},
+	"getaccount":             {perm: macaroons.PermRead},
+	"getaccountaddress":      {perm: macaroons.PermAddress},
+	"getaddressesbyaccount":  {perm: macaroons.PermRead},
+	"getbalance":             {perm: macaroons.PermRead},
+	"getbestblockhash":       {perm: macaroons.PermRead},
+	"getblockcount":          {perm: macaroons.PermRead},
+	"getinfo":                {perm: macaroons.PermRead, allAccounts: true},
+	"getnewaddress":          {perm: macaroons.PermAddress},
+	"getrawchangeaddress":    {perm: macaroons.PermAddress},
+	"getreceivedbyaccount":   {perm: macaroons.PermRead},
+	"getreceivedbyaddress":   {perm: macaroons.PermRead, allAccounts: true},
+	"gettransaction":         {perm: macaroons.PermRead, allAccounts: true},
+	"getwalletinfo":          {perm: macaroons.PermRead, allAccounts: true},
+	"help":                   {perm: macaroons.PermRead},
+	"importprivkey":          {perm: macaroons.PermAdmin, allAccounts: true},
+	"importwallet":           {perm: macaroons.PermAdmin, allAccounts: true},
+	"keypoolrefill":          {perm: macaroons.PermAddress},
+	"listaccounts":           {perm: macaroons.PermRead, allAccounts: true},
+	"listaddressgroupings":   {perm: macaroons.PermRead, allAccounts: true},
+	"listlockunspent":        {perm: macaroons.PermRead, allAccounts: true},
+	"listreceivedbyaccount":  {perm: macaroons.PermRead, allAccounts: true},
+	"listreceivedbyaddress":  {perm: macaroons.PermRead, allAccounts: true},
+	"listsinceblock":         {perm: macaroons.PermRead, allAccounts: true},
+	"listtransactions":       {perm: macaroons.PermRead},
+	"listunspent":            {perm: macaroons.PermRead, allAccounts: true},
+	"lockunspent":            {perm: macaroons.PermSend, allAccounts: true},
+	"sendfrom":               {perm: macaroons.PermSend},
+	"sendmany":               {perm: macaroons.PermSend},
+	"sendtoaddress":          {perm: macaroons.PermSend},
+	"settxfee":               {perm: macaroons.PermSend, allAccounts: true},
+	"signmessage":            {perm: macaroons.PermSign},
+	"signrawtransaction":     {perm: macaroons.PermSign, allAccounts: true},
+	"validateaddress":        {perm: macaroons.PermRead},
+	"verifymessage":          {perm: macaroons.PermRead},
+	"walletlock":             {perm: macaroons.PermSign, allAccounts: true},
+	"walletpassphrase":       {perm: macaroons.PermSign, allAccounts: true},
+	"walletpassphrasechange": {perm: macaroons.PermAdmin, allAccounts: true},
+
+	// Reference implementation methods (still unimplemented)
+	"encryptwallet": {perm: macaroons.PermAdmin, allAccounts: true},
+	"move":          {perm: macaroons.PermAdmin, allAccounts: true},
+	"setaccount":    {perm: macaroons.PermAdmin, allAccounts: true},
+
+	// Reference implementation methods that load and unload wallets.
+	// The wallets of the requests are found by requestWallets.
+	"listwallets":  {perm: macaroons.PermRead, allAccounts: true},
+	"loadwallet":   {perm: macaroons.PermAdmin, allAccounts: true},
+	"unloadwallet": {perm: macaroons.PermAdmin, allAccounts: true},
+
+	// Extensions to the reference client JSON-RPC API
+	"cancelcoldspend":   {perm: macaroons.PermSend, allAccounts: true},
+	"clearreorghalt":    {perm: macaroons.PermAdmin, allAccounts: true},
+	"completecoldspend": {perm: macaroons.PermSend, allAccounts: true},
+	"createnewaccount":  {perm: macaroons.PermAdmin, allAccounts: true},
+	"exporthistory":     {perm: macaroons.PermAdmin, allAccounts: true},
+	"getbestblock":      {perm: macaroons.PermRead},
+	// This was an extension but the reference implementation added it as
+	// well, but with a different API (no account parameter).  It's listed
+	// here because it hasn't been update to use the reference
+	// implemenation's API.
+	"getunconfirmedbalance":   {perm: macaroons.PermRead},
+	"listaddresstransactions": {perm: macaroons.PermRead},
+	"listalltransactions":     {perm: macaroons.PermRead, allAccounts: true},
+	"listcoldspends":          {perm: macaroons.PermRead, allAccounts: true},
+	"renameaccount":           {perm: macaroons.PermAdmin},
+	"rotateseed":              {perm: macaroons.PermAdmin, allAccounts: true},
+	"setaccountspendingmode":  {perm: macaroons.PermAdmin, allAccounts: true},
+	"sweepretiredaccount":     {perm: macaroons.PermAdmin, allAccounts: true},
+	"waitforconfirmations":    {perm: macaroons.PermRead, allAccounts: true},
+	"walletislocked":          {perm: macaroons.PermRead},
+
+	"stop": {perm: macaroons.PermAdmin, allAccounts: true},
+}
+
+// chainReadMethods are the methods passed through to the chain server that
+// only query it.  Other passed through methods require the admin permission,
+// except for sendrawtransaction.
+var chainReadMethods = map[string]struct{}{
+	"decoderawtransaction": {},
+	"decodescript":         {},
+	"estimatefee":          {},
+	"estimatesmartfee":     {},
+	"getblock":             {},
+	"getblockchaininfo":    {},
+	"getblockhash":         {},
+	"getblockheader":       {},
+	"getdifficulty":        {},
+	"getmempoolinfo":       {},
+	"getnetworkinfo":       {},
+	"getrawmempool":        {},
+	"getrawtransaction":    {},
+	"gettxout":             {},
+}
+
+// lookupPermission returns the permission required by a method, including
+// the methods passed through to the chain server.
+func lookupPermission(method string) methodPermission {
+	if mp, ok := methodPermissions[method]; ok {
+		return mp
+	}
+	if _, ok := chainReadMethods[method]; ok {
+		return methodPermission{perm: macaroons.PermRead}
+	}
+	if method == "sendrawtransaction" {
+		return methodPermission{perm: macaroons.PermSend, allAccounts: true}
+	}
+	return methodPermission{perm: macaroons.PermAdmin, allAccounts: true}
+}
+
+// macaroonRequest describes the request to the named wallet for checking it
+// against the caveats of a macaroon.  Account names are resolved with the
+// wallet, which may be nil before it is loaded.
+func macaroonRequest(req *btcjson.Request, walletName string,
+	w *wallet.Wallet) (*macaroons.Request, error) {
+
+	mp := lookupPermission(req.Method)
+	mreq := &macaroons.Request{
+		Method:      req.Method,
+		Permission:  mp.perm,
+		AllAccounts: mp.allAccounts,
+		Wallet:      walletName,
+	}
+
+	_, handled := methodPermissions[req.Method]
+	switch {
+	case req.Method == "sendrawtransaction":
+		cmd, err := btcjson.UnmarshalCmd(req)
+		if err != nil {
+			return nil, err
+		}
+		return mreq, rawTxSpend(
+			mreq, cmd.(*btcjson.SendRawTransactionCmd).HexTx, w,
+		)
+
+	case !handled, req.Method == "stop":
+		return mreq, nil
+	}
+
+	cmd, err := btcjson.UnmarshalCmd(req)
+	if err != nil {
+		return nil, err
+	}
+	requestWallets(mreq, cmd)
+	if err := requestAccounts(mreq, cmd, w); err != nil {
+		return nil, err
+	}
+	return mreq, nil
+}
+
+// requestWallets sets the wallet loaded or unloaded by the command.  Listing
+// the loaded wallets is not limited to a wallet.
+func requestWallets(mreq *macaroons.Request, icmd interface{}) {
+	switch cmd := icmd.(type) {
+	case *btcjson.LoadWalletCmd:
+		mreq.Wallet = cmd.WalletName
+	case *btcjson.UnloadWalletCmd:
+		mreq.Wallet = stringOr(cmd.WalletName, mreq.Wallet)
+	case *walletjson.ListWalletsCmd:
+		mreq.AllWallets = true
+	}
+}
+
+// requestAccounts sets the accounts and spent amount of the command.  Only
+// the amounts of transactions given by the client are known here.
+// Requests for all accounts, given as "*" or by leaving out the account, are
+// marked as such.
+func requestAccounts(mreq *macaroons.Request, icmd interface{},
+	w *wallet.Wallet) error {
+
+	addAccount := func(scope waddrmgr.KeyScope, name string) error {
+		if w == nil {
+			return &ErrUnloadedWallet
+		}
+		account, err := w.AccountNumber(scope, name)
+		if err != nil {
+			return err
+		}
+		mreq.Accounts = append(mreq.Accounts, account)
+		return nil
+	}
+	addOptionalAccount := func(name *string) error {
+		if name == nil || *name == "*" {
+			mreq.AllAccounts = true
+			return nil
+		}
+		return addAccount(waddrmgr.KeyScopeBIP0044, *name)
+	}
+
+	switch cmd := icmd.(type) {
+	case *btcjson.GetBalanceCmd:
+		return addOptionalAccount(cmd.Account)
+	case *btcjson.GetUnconfirmedBalanceCmd:
+		return addOptionalAccount(cmd.Account)
+	case *btcjson.ListTransactionsCmd:
+		return addOptionalAccount(cmd.Account)
+	case *btcjson.ListAddressTransactionsCmd:
+		return addOptionalAccount(cmd.Account)
+
+	case *btcjson.GetNewAddressCmd:
+		return addAccount(
+			addressTypeScope(cmd.AddressType),
+			stringOr(cmd.Account, defaultAccountName),
+		)
+	case *btcjson.GetRawChangeAddressCmd:
+		return addAccount(
+			addressTypeScope(cmd.AddressType),
+			stringOr(cmd.Account, defaultAccountName),
+		)
+	case *btcjson.GetAccountAddressCmd:
+		return addAccount(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	case *btcjson.GetAddressesByAccountCmd:
+		return addAccount(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	case *btcjson.GetReceivedByAccountCmd:
+		return addAccount(waddrmgr.KeyScopeBIP0044, cmd.Account)
+	case *btcjson.RenameAccountCmd:
+		return addAccount(waddrmgr.KeyScopeBIP0044, cmd.OldAccount)
+	case *btcjson.KeyPoolRefillCmd:
+		mreq.Accounts = append(mreq.Accounts, waddrmgr.DefaultAccountNum)
+
+	case *btcjson.GetAccountCmd:
+		return addressAccount(mreq, cmd.Address, w)
+	case *btcjson.AddMultisigAddressCmd:
+		return addOptionalAccount(cmd.Account)
+	case *btcjson.SignMessageCmd:
+		return addressAccount(mreq, cmd.Address, w)
+
+	// The transactions created by the send methods are checked against
+	// the spend limit by the wallet before they are published.
+	case *btcjson.SendFromCmd:
+		return addAccount(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
+	case *btcjson.SendManyCmd:
+		return addAccount(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
+	case *btcjson.SendToAddressCmd:
+		mreq.Accounts = append(mreq.Accounts, waddrmgr.DefaultAccountNum)
+
+	case *btcjson.SignRawTransactionCmd:
+		return rawTxSpend(mreq, cmd.RawTx, w)
+	}
+	return nil
+}
+
+// addressAccount adds the account of a wallet address to the request.
+// Addresses unknown to the wallet are not limited to an account.
+func addressAccount(mreq *macaroons.Request, address string,
+	w *wallet.Wallet) error {
+
+	if w == nil {
+		return &ErrUnloadedWallet
+	}
+	addr, err := decodeAddress(address, w.ChainParams())
+	if err != nil {
+		return err
+	}
+	account, err := w.AccountOfAddress(addr)
+	switch {
+	case waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound):
+		mreq.AllAccounts = true
+		return nil
+	case err != nil:
+		return err
+	}
+	mreq.Accounts = append(mreq.Accounts, account)
+	return nil
+}
+
+// rawTxSpend sets the amount the hex encoded transaction pays to addresses
+// outside of the wallet and in fees as the spent amount of the request.  The
+// spent amount is marked unknown if the wallet doesn't know the value of one
+// of the outputs spent by the transaction.
+func rawTxSpend(mreq *macaroons.Request, hexTx string,
+	w *wallet.Wallet) error {
+
+	if w == nil {
+		return &ErrUnloadedWallet
+	}
+	serializedTx, err := hex.DecodeString(hexTx)
+	if err != nil {
+		return DeserializationError{err}
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+		return DeserializationError{err}
+	}
+	mreq.Spend, err = macaroons.ExternalSpend(&tx, w, w, w.ChainParams())
+	if errors.Is(err, macaroons.ErrUnknownInputs) {
+		mreq.SpendUnknown = true
+		return nil
+	}
+	return err
+}
+
+// addressTypeScope returns the key scope of the address type parameter of
+// getnewaddress and getrawchangeaddress.
+func addressTypeScope(addressType *string) waddrmgr.KeyScope {
+	if addressType == nil {
+		return waddrmgr.KeyScopeBIP0044
+	}
+	switch *addressType {
+	case "p2sh-segwit":
+		return waddrmgr.KeyScopeBIP0049Plus
+	case "bech32":
+		return waddrmgr.KeyScopeBIP0084
+	default:
+		return waddrmgr.KeyScopeBIP0044
+	}
+}
+
+// stringOr returns the string s points to, or def if s is nil.
+func stringOr(s *string, def string) string {
+	if s == nil {
+		return def
+	}
+	return *s
+}
@@ -0,0 +1,195 @@
+package legacyrpc
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/stroomnetwork/btcwallet/internal/walletjson"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+)
+
+// TestMethodPermissions ensures that every method handled by the wallet
+// requires a permission when used with macaroons.
+func TestMethodPermissions(t *testing.T) {
+	for method := range rpcHandlers {
+		if _, ok := methodPermissions[method]; !ok {
+			t.Errorf("method %s has no permission", method)
+		}
+	}
+}
+
+// TestMacaroonRequest checks the requests described for macaroons for
+// methods that don't require a loaded wallet.  The requests are made to the
+// wallet named alice.
+func TestMacaroonRequest(t *testing.T) {
+	tests := []struct {
+		cmd         interface{}
+		method      string
+		perm        macaroons.Permission
+		allAccounts bool
+		accounts    int
+		spend       btcutil.Amount
+
+		// wallet is the wallet of the request, if not the wallet
+		// the request was made to.
+		wallet     string
+		allWallets bool
+	}{
+		{
+			cmd:    btcjson.NewGetBlockCountCmd(),
+			method: "getblockcount",
+			perm:   macaroons.PermRead,
+		},
+		{
+			cmd:         btcjson.NewGetBalanceCmd(nil, nil),
+			method:      "getbalance",
+			perm:        macaroons.PermRead,
+			allAccounts: true,
+		},
+		{
+			cmd: btcjson.NewSendToAddressCmd(
+				"addr", 0.5, nil, nil,
+			),
+			// The created transaction is checked when it is
+			// published rather than the requested amount.
+			method:   "sendtoaddress",
+			perm:     macaroons.PermSend,
+			accounts: 1,
+		},
+		{
+			cmd:    btcjson.NewGetBlockHashCmd(1),
+			method: "getblockhash",
+			perm:   macaroons.PermRead,
+		},
+		{
+			cmd:         btcjson.NewPingCmd(),
+			method:      "ping",
+			perm:        macaroons.PermAdmin,
+			allAccounts: true,
+		},
+		{
+			cmd:         btcjson.NewStopCmd(),
+			method:      "stop",
+			perm:        macaroons.PermAdmin,
+			allAccounts: true,
+		},
+		{
+			cmd:         btcjson.NewLoadWalletCmd("bob"),
+			method:      "loadwallet",
+			perm:        macaroons.PermAdmin,
+			allAccounts: true,
+			wallet:      "bob",
+		},
+		{
+			cmd:         btcjson.NewUnloadWalletCmd(nil),
+			method:      "unloadwallet",
+			perm:        macaroons.PermAdmin,
+			allAccounts: true,
+		},
+		{
+			cmd:         walletjson.NewListWalletsCmd(),
+			method:      "listwallets",
+			perm:        macaroons.PermRead,
+			allAccounts: true,
+			allWallets:  true,
+		},
+	}
+
+	for _, test := range tests {
+		b, err := btcjson.MarshalCmd(btcjson.RpcVersion1, 1, test.cmd)
+		if err != nil {
+			t.Fatalf("%s: %v", test.method, err)
+		}
+		var req btcjson.Request
+		if err := json.Unmarshal(b, &req); err != nil {
+			t.Fatalf("%s: %v", test.method, err)
+		}
+		mreq, err := macaroonRequest(&req, "alice", nil)
+		if err != nil {
+			t.Errorf("%s: %v", test.method, err)
+			continue
+		}
+		if mreq.Permission != test.perm {
+			t.Errorf("%s: permission %s, want %s", test.method,
+				mreq.Permission, test.perm)
+		}
+		if mreq.AllAccounts != test.allAccounts {
+			t.Errorf("%s: all accounts %v, want %v", test.method,
+				mreq.AllAccounts, test.allAccounts)
+		}
+		if len(mreq.Accounts) != test.accounts {
+			t.Errorf("%s: %d accounts, want %d", test.method,
+				len(mreq.Accounts), test.accounts)
+		}
+		wantWallet := test.wallet
+		if wantWallet == "" {
+			wantWallet = "alice"
+		}
+		if mreq.Wallet != wantWallet {
+			t.Errorf("%s: wallet %q, want %q", test.method,
+				mreq.Wallet, wantWallet)
+		}
+		if mreq.AllWallets != test.allWallets {
+			t.Errorf("%s: all wallets %v, want %v", test.method,
+				mreq.AllWallets, test.allWallets)
+		}
+		if mreq.Spend != test.spend {
+			t.Errorf("%s: spend %v, want %v", test.method,
+				mreq.Spend, test.spend)
+		}
+	}
+}
+
+// TestCheckAuthHeader checks authentication with macaroons and that HTTP
+// Basic authentication is disabled without a username and password.
+func TestCheckAuthHeader(t *testing.T) {
+	svc := macaroons.NewService([]byte("0123456789abcdef0123456789abcdef"))
+	s := &Server{
+		authsha:   sha256.Sum256(httpBasicAuth("", "")),
+		macaroons: svc,
+	}
+	mac, err := svc.NewMacaroon(macaroons.PermissionsCaveat(macaroons.PermRead))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := macaroons.NewService([]byte("another root key")).NewMacaroon()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		ok     bool
+	}{
+		{"macaroon", "Bearer " + mac.String(), true},
+		{"foreign macaroon", "Bearer " + other.String(), false},
+		{"malformed macaroon", "Bearer 00", false},
+		{"empty basic auth", string(httpBasicAuth("", "")), false},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("POST", "/", nil)
+		r.Header.Set("Authorization", test.header)
+		got, err := s.checkAuthHeader(r)
+		switch {
+		case test.ok && (err != nil || got == nil):
+			t.Errorf("%s: rejected: %v", test.name, err)
+		case !test.ok && err == nil:
+			t.Errorf("%s: accepted", test.name)
+		}
+	}
+
+	req := &btcjson.Request{Method: "walletlock"}
+	if jsonErr := s.authorize(mac, "", req); jsonErr == nil {
+		t.Errorf("read-only macaroon may lock the wallet")
+	}
+	req = &btcjson.Request{Method: "getblockcount"}
+	if jsonErr := s.authorize(mac, "", req); jsonErr != nil {
+		t.Errorf("read-only macaroon may not get the block count: %v",
+			jsonErr)
+	}
+}
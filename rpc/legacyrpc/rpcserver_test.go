@@ -5,10 +5,21 @@
 package legacyrpc
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/stroomnetwork/btcwallet/tracing"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"github.com/stroomnetwork/btcwallet/wallet"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func TestThrottle(t *testing.T) {
@@ -48,3 +59,204 @@ func TestThrottle(t *testing.T) {
 		t.Fatalf("status codes: want: %v, got: %v", want, got)
 	}
 }
+
+// TestBatchRequests ensures that the responses to a batch of requests are
+// written in the order of the requests, in the format of their JSON-RPC
+// version.
+func TestBatchRequests(t *testing.T) {
+	s := &Server{}
+
+	post := func(body string) string {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		s.postClientRPC(w, r, nil)
+		return strings.TrimSpace(w.Body.String())
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "batch",
+			body: `[
+				{"jsonrpc":"2.0","method":"getblockcount","id":1},
+				1,
+				{"jsonrpc":"2.0","method":"authenticate","params":["u","p"],"id":2},
+				{"method":"getblockcount","params":[],"id":"three"}
+			]`,
+			want: `[` +
+				`{"jsonrpc":"2.0","error":{"code":-1,"message":"Chain RPC is inactive"},"id":1},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null},` +
+				`{"jsonrpc":"1.0","result":null,"error":{"code":-1,"message":"Chain RPC is inactive"},"id":"three"}` +
+				`]`,
+		},
+		{
+			name: "empty batch",
+			body: `[]`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+		},
+		{
+			name: "invalid batch",
+			body: `[{"method":`,
+			want: `{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`,
+		},
+		{
+			name: "notifications",
+			body: `[
+				{"jsonrpc":"2.0","method":"getblockcount"},
+				{"jsonrpc":"2.0","method":"getblockcount","id":1},
+				{"jsonrpc":"2.0","method":"getblockcount","params":[]},
+				{"jsonrpc":"2.0","method":"getblockcount","id":null}
+			]`,
+			want: `[` +
+				`{"jsonrpc":"2.0","error":{"code":-1,"message":"Chain RPC is inactive"},"id":1},` +
+				`{"jsonrpc":"2.0","error":{"code":-1,"message":"Chain RPC is inactive"},"id":null}` +
+				`]`,
+		},
+		{
+			name: "only notifications",
+			body: `[
+				{"jsonrpc":"2.0","method":"getblockcount"},
+				{"jsonrpc":"2.0","method":"getblockcount","params":[]}
+			]`,
+			want: ``,
+		},
+		{
+			name: "only authenticate",
+			body: `[{"jsonrpc":"2.0","method":"authenticate","params":["u","p"],"id":1}]`,
+			want: ``,
+		},
+	}
+	for _, test := range tests {
+		if got := post(test.body); got != test.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, got,
+				test.want)
+		}
+	}
+}
+
+// TestJSONErrorCoreCodes checks that common failures are reported with the
+// error codes of Bitcoin Core.
+func TestJSONErrorCoreCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		code btcjson.RPCErrorCode
+	}{
+		{
+			err: fmt.Errorf("create tx: %w", waddrmgr.ManagerError{
+				ErrorCode: waddrmgr.ErrLocked,
+			}),
+			code: btcjson.ErrRPCWalletUnlockNeeded,
+		},
+		{
+			err: waddrmgr.ManagerError{
+				ErrorCode: waddrmgr.ErrWrongPassphrase,
+			},
+			code: btcjson.ErrRPCWalletPassphraseIncorrect,
+		},
+		{
+			err:  insufficientFunds{},
+			code: btcjson.ErrRPCWalletInsufficientFunds,
+		},
+		{
+			err:  wallet.ErrNotLoaded,
+			code: btcjson.ErrRPCWalletNotFound,
+		},
+		{
+			err:  txrules.ErrAmountNegative,
+			code: btcjson.ErrRPCType,
+		},
+		{
+			err:  errors.New("other"),
+			code: btcjson.ErrRPCWallet,
+		},
+	}
+	for _, test := range tests {
+		if got := jsonError(test.err); got.Code != test.code {
+			t.Errorf("%v: code %d, want %d", test.err, got.Code,
+				test.code)
+		}
+	}
+
+	_, err := decodeAddress("notanaddress", &chaincfg.MainNetParams)
+	if e := jsonError(err); e.Code != btcjson.ErrRPCInvalidAddressOrKey ||
+		e.Message != "Invalid Bitcoin address: notanaddress" {
+
+		t.Errorf("invalid address error %v", e)
+	}
+}
+
+// insufficientFunds is an input source error like the one returned by
+// txauthor when the inputs can't pay for the outputs.
+type insufficientFunds struct{}
+
+func (insufficientFunds) InputSourceError() {}
+func (insufficientFunds) Error() string     { return "insufficient funds" }
+
+// TestWalletRouting checks that requests to the /wallet/<name> URI path are
+// made to the named wallet.
+func TestWalletRouting(t *testing.T) {
+	paths := map[string]string{
+		"/":             "",
+		"/ws":           "",
+		"/wallet/":      "",
+		"/wallet/alice": "alice",
+		"/wallet/bob/":  "bob",
+	}
+	for path, want := range paths {
+		if got := requestWalletName(path); got != want {
+			t.Errorf("%s: wallet %q, want %q", path, got, want)
+		}
+	}
+
+	s := &Server{}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/wallet/alice", strings.NewReader(
+		`{"jsonrpc":"2.0","method":"getbalance","id":1}`,
+	))
+	s.postClientRPC(w, r, nil)
+	want := `{"jsonrpc":"2.0","error":{"code":-18,"message":"Requested ` +
+		`wallet does not exist or is not loaded"},"id":1}`
+	if got := strings.TrimSpace(w.Body.String()); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestRequestSpan checks that a request is handled in a span that is a child
+// of the trace context of its HTTP headers, and that the span reports the
+// error of the request.
+func TestRequestSpan(t *testing.T) {
+	t.Parallel()
+
+	provider, exporter := tracing.NewMemoryProvider()
+	s := &Server{tracer: provider.Tracer(tracerName)}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/wallet/alice", strings.NewReader(
+		`{"jsonrpc":"2.0","method":"getbalance","id":1}`,
+	))
+	r.Header.Set("traceparent",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	s.postClientRPC(w, r, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "legacyrpc.getbalance" {
+		t.Errorf("unexpected span name %q", span.Name)
+	}
+	traceID := span.SpanContext.TraceID().String()
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected trace ID %s", traceID)
+	}
+	if parent := span.Parent.SpanID().String(); parent != "00f067aa0ba902b7" {
+		t.Errorf("unexpected parent span ID %s", parent)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("unexpected status %v", span.Status)
+	}
+}
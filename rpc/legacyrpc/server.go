@@ -5,6 +5,8 @@
 package legacyrpc
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
@@ -13,19 +15,33 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/websocket"
 	"github.com/stroomnetwork/btcwallet/chain"
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+	"github.com/stroomnetwork/btcwallet/rpc/ratelimit"
+	"github.com/stroomnetwork/btcwallet/tracing"
 	"github.com/stroomnetwork/btcwallet/wallet"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracerName is the instrumentation name of the spans of the requests.
+const tracerName = "github.com/stroomnetwork/btcwallet/rpc/legacyrpc"
+
 type websocketClient struct {
 	conn          *websocket.Conn
 	authenticated bool
+	macaroon      *macaroons.Macaroon // nil with HTTP Basic auth
 	remoteAddr    string
 	allRequests   chan []byte
 	responses     chan []byte
@@ -33,10 +49,13 @@ type websocketClient struct {
 	wg            sync.WaitGroup
 }
 
-func newWebsocketClient(c *websocket.Conn, authenticated bool, remoteAddr string) *websocketClient {
+func newWebsocketClient(c *websocket.Conn, authenticated bool,
+	macaroon *macaroons.Macaroon, remoteAddr string) *websocketClient {
+
 	return &websocketClient{
 		conn:          c,
 		authenticated: authenticated,
+		macaroon:      macaroon,
 		remoteAddr:    remoteAddr,
 		allRequests:   make(chan []byte),
 		responses:     make(chan []byte),
@@ -56,14 +75,22 @@ func (c *websocketClient) send(b []byte) error {
 // Server holds the items the RPC server may need to access (auth,
 // config, shutdown, etc.)
 type Server struct {
-	httpServer   http.Server
-	wallet       *wallet.Wallet
-	walletLoader *wallet.Loader
-	chainClient  chain.Interface
-	handlerMu    sync.Mutex
+	httpServer    http.Server
+	wallet        *wallet.Wallet
+	wallets       *wallet.MultiLoader
+	pubPassphrase []byte
+	chainClient   chain.Interface
+	handlerMu     sync.Mutex
 
 	listeners []net.Listener
 	authsha   [sha256.Size]byte
+	basicAuth bool
+	username  string
+	macaroons *macaroons.Service
+	limiter   *ratelimit.Limiter
+	auditLog  *audit.Log
+	observe   func(method string, d time.Duration, failed bool)
+	tracer    trace.Tracer // nil to use the global tracer provider
 	upgrader  websocket.Upgrader
 
 	maxPostClients      int64 // Max concurrent HTTP POST clients.
@@ -83,8 +110,10 @@ func jsonAuthFail(w http.ResponseWriter) {
 }
 
 // NewServer creates a new server for serving legacy RPC client connections,
-// both HTTP POST and websocket.
-func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Listener) *Server {
+// both HTTP POST and websocket.  HTTP POST requests to the /wallet/<name> URI
+// path are made to the named wallet of the loader, and all other requests to
+// the default wallet.
+func NewServer(opts *Options, wallets *wallet.MultiLoader, listeners []net.Listener) *Server {
 	serveMux := http.NewServeMux()
 	const rpcAuthTimeoutSeconds = 10
 
@@ -96,13 +125,20 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 			// handshake within the allowed timeframe.
 			ReadTimeout: time.Second * rpcAuthTimeoutSeconds,
 		},
-		walletLoader:        walletLoader,
+		wallets:             wallets,
+		pubPassphrase:       opts.PublicPassphrase,
 		maxPostClients:      opts.MaxPOSTClients,
 		maxWebsocketClients: opts.MaxWebsocketClients,
 		listeners:           listeners,
 		// A hash of the HTTP basic auth string is used for a constant
 		// time comparison.
-		authsha: sha256.Sum256(httpBasicAuth(opts.Username, opts.Password)),
+		authsha:   sha256.Sum256(httpBasicAuth(opts.Username, opts.Password)),
+		basicAuth: opts.Username != "" || opts.Password != "",
+		username:  opts.Username,
+		macaroons: opts.Macaroons,
+		limiter:   opts.RateLimiter,
+		auditLog:  opts.AuditLog,
+		observe:   opts.ObserveRequest,
 		upgrader: websocket.Upgrader{
 			// Allow all origins.
 			CheckOrigin: func(r *http.Request) bool { return true },
@@ -110,6 +146,9 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 		quit:                make(chan struct{}),
 		requestShutdownChan: make(chan struct{}, 1),
 	}
+	if opts.TracerProvider != nil {
+		server.tracer = opts.TracerProvider.Tracer(tracerName)
+	}
 
 	serveMux.Handle("/", throttledFn(opts.MaxPOSTClients,
 		func(w http.ResponseWriter, r *http.Request) {
@@ -117,20 +156,22 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 			w.Header().Set("Content-Type", "application/json")
 			r.Close = true
 
-			if err := server.checkAuthHeader(r); err != nil {
+			mac, err := server.checkAuthHeader(r)
+			if err != nil {
 				log.Warnf("Unauthorized client connection attempt")
 				jsonAuthFail(w)
 				return
 			}
 			server.wg.Add(1)
-			server.postClientRPC(w, r)
+			server.postClientRPC(w, r, mac)
 			server.wg.Done()
 		}))
 
 	serveMux.Handle("/ws", throttledFn(opts.MaxWebsocketClients,
 		func(w http.ResponseWriter, r *http.Request) {
 			authenticated := false
-			switch server.checkAuthHeader(r) {
+			mac, err := server.checkAuthHeader(r)
+			switch err {
 			case nil:
 				authenticated = true
 			case ErrNoAuth:
@@ -150,7 +191,8 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 					r.RemoteAddr, err)
 				return
 			}
-			wsc := newWebsocketClient(conn, authenticated, r.RemoteAddr)
+			wsc := newWebsocketClient(conn, authenticated, mac,
+				r.RemoteAddr)
 			server.websocketClientRPC(wsc)
 		}))
 
@@ -261,51 +303,206 @@ func (s *Server) SetChainServer(chainClient chain.Interface) {
 	s.handlerMu.Unlock()
 }
 
+// requestWallet returns the named wallet requests are made to, and whether
+// it is loaded.
+func (s *Server) requestWallet(walletName string) (*wallet.Wallet, bool) {
+	if s.wallets != nil {
+		return s.wallets.Wallet(walletName)
+	}
+
+	s.handlerMu.Lock()
+	w := s.wallet
+	s.handlerMu.Unlock()
+	return w, walletName == wallet.DefaultWalletName && w != nil
+}
+
 // handlerClosure creates a closure function for handling requests of the given
-// method.  This may be a request that is handled directly by btcwallet, or
-// a chain server request that is handled by passing the request down to btcd.
+// method to the named wallet.  This may be a request that is handled directly
+// by btcwallet, or a chain server request that is handled by passing the
+// request down to btcd.
+//
+// The request is handled in a span that is a child of the trace context of
+// ctx, and ends when the returned handler returns.
 //
 // NOTE: These handlers do not handle special cases, such as the authenticate
 // method.  Each of these must be checked beforehand (the method is already
 // known) and handled accordingly.
-func (s *Server) handlerClosure(request *btcjson.Request) lazyHandler {
+func (s *Server) handlerClosure(ctx context.Context, walletName string,
+	request *btcjson.Request) lazyHandler {
+
+	tracer := s.tracer
+	if tracer == nil {
+		tracer = otel.Tracer(tracerName)
+	}
+	ctx, span := tracer.Start(ctx, "legacyrpc."+request.Method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.method", request.Method),
+			attribute.String("wallet.name", walletName),
+		),
+	)
+	h := s.walletHandler(ctx, walletName, request)
+	return func() (interface{}, *btcjson.RPCError) {
+		start := time.Now()
+		res, jsonErr := h()
+		if s.observe != nil {
+			s.observe(request.Method, time.Since(start),
+				jsonErr != nil)
+		}
+		if jsonErr != nil {
+			span.SetAttributes(attribute.Int(
+				"rpc.jsonrpc.error_code", int(jsonErr.Code),
+			))
+			span.SetStatus(codes.Error, jsonErr.Message)
+		}
+		span.End()
+		return res, jsonErr
+	}
+}
+
+// walletHandler returns the handler of a request to the named wallet, without
+// the observation of the request.
+func (s *Server) walletHandler(ctx context.Context, walletName string,
+	request *btcjson.Request) lazyHandler {
+
+	if h := rpcHandlers[request.Method].handlerWithWallets; h != nil {
+		return lazyApplyWalletsHandler(request, h, &walletsRequest{
+			wallets:       s.wallets,
+			pubPassphrase: s.pubPassphrase,
+			walletName:    walletName,
+		})
+	}
+
+	// Requests to named wallets that are not loaded fail rather than
+	// being passed through to the chain server.
+	w, ok := s.requestWallet(walletName)
+	if !ok && walletName != wallet.DefaultWalletName {
+		return func() (interface{}, *btcjson.RPCError) {
+			return nil, &ErrUnloadedWallet
+		}
+	}
+
 	s.handlerMu.Lock()
 	// With the lock held, make copies of these pointers for the closure.
-	wallet := s.wallet
 	chainClient := s.chainClient
-	if wallet != nil && chainClient == nil {
-		chainClient = wallet.ChainClient()
+	if w != nil && chainClient == nil {
+		chainClient = w.ChainClient()
 		s.chainClient = chainClient
 	}
 	s.handlerMu.Unlock()
 
-	return lazyApplyHandler(request, wallet, chainClient)
+	// Named wallets have their own connection to the chain server.
+	if w != nil && walletName != wallet.DefaultWalletName {
+		if c := w.ChainClient(); c != nil {
+			chainClient = c
+		}
+	}
+
+	return lazyApplyHandler(ctx, request, w, chainClient)
+}
+
+// requestWalletName returns the name of the wallet of the /wallet/<name> URI
+// path, or the default wallet for other paths.
+func requestWalletName(path string) string {
+	const prefix = "/wallet/"
+	if !strings.HasPrefix(path, prefix) {
+		return wallet.DefaultWalletName
+	}
+	return strings.TrimSuffix(path[len(prefix):], "/")
 }
 
 // ErrNoAuth represents an error where authentication could not succeed
 // due to a missing Authorization HTTP header.
 var ErrNoAuth = errors.New("no auth")
 
-// checkAuthHeader checks the HTTP Basic authentication supplied by a client
-// in the HTTP request r.  It errors with ErrNoAuth if the request does not
-// contain the Authorization header, or another non-nil error if the
-// authentication was provided but incorrect.
+// checkAuthHeader checks the HTTP Basic authentication or the macaroon
+// bearer token supplied by a client in the HTTP request r.  It errors with
+// ErrNoAuth if the request does not contain the Authorization header, or
+// another non-nil error if the authentication was provided but incorrect.
+// The macaroon is returned when the client authenticated with one, and must
+// be checked for each request with authorize.
 //
-// This check is time-constant.
-func (s *Server) checkAuthHeader(r *http.Request) error {
+// The check of HTTP Basic authentication is time-constant.
+func (s *Server) checkAuthHeader(r *http.Request) (*macaroons.Macaroon, error) {
 	authhdr := r.Header["Authorization"]
 	if len(authhdr) == 0 {
-		return ErrNoAuth
+		return nil, ErrNoAuth
 	}
 
+	if token, ok := strings.CutPrefix(authhdr[0], "Bearer "); ok {
+		if s.macaroons == nil {
+			return nil, errors.New("bad auth")
+		}
+		mac, err := macaroons.Decode(strings.TrimSpace(token))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.macaroons.Restrictions(mac); err != nil {
+			return nil, err
+		}
+		return mac, nil
+	}
+
+	if !s.basicAuth {
+		return nil, errors.New("bad auth")
+	}
 	authsha := sha256.Sum256([]byte(authhdr[0]))
 	cmp := subtle.ConstantTimeCompare(authsha[:], s.authsha[:])
 	if cmp != 1 {
-		return errors.New("bad auth")
+		return nil, errors.New("bad auth")
+	}
+	return nil, nil
+}
+
+// authorize checks that the caveats of the macaroon a client authenticated
+// with allow the request to the named wallet.  Clients authenticated with
+// HTTP Basic authentication, for which the macaroon is nil, may make any
+// request.
+func (s *Server) authorize(mac *macaroons.Macaroon, walletName string,
+	req *btcjson.Request) *btcjson.RPCError {
+
+	if mac == nil {
+		return nil
+	}
+
+	w, _ := s.requestWallet(walletName)
+	mreq, err := macaroonRequest(req, walletName, w)
+	if err != nil {
+		return jsonError(err)
+	}
+	if err := s.macaroons.Authorize(mac, mreq); err != nil {
+		log.Warnf("Denied %s request: %v", req.Method, err)
+		return &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidRequest.Code,
+			Message: err.Error(),
+		}
 	}
 	return nil
 }
 
+// spendContext makes the wallet check the transactions it publishes for a
+// request of a client authenticated with a macaroon against the transaction
+// spend limit of the macaroon.  This covers the methods creating
+// transactions, such as sendtoaddress, with the fee and change of the
+// transaction they publish accounted for.
+func (s *Server) spendContext(ctx context.Context, mac *macaroons.Macaroon,
+	walletName, method string) context.Context {
+
+	if mac == nil {
+		return ctx
+	}
+	w, ok := s.requestWallet(walletName)
+	if !ok {
+		return ctx
+	}
+	return wallet.WithSpendCheck(ctx, func(tx *wire.MsgTx) error {
+		return s.macaroons.AuthorizeTx(
+			mac, method, tx, w, w, w.ChainParams(),
+		)
+	})
+}
+
 // throttledFn wraps an http.HandlerFunc with throttling of concurrent active
 // clients by responding with an HTTP 429 when the threshold is crossed.
 func throttledFn(threshold int64, f http.HandlerFunc) http.Handler {
@@ -346,6 +543,9 @@ func idPointer(id interface{}) (p *interface{}) {
 // authenticate request and checks the supplied username and passphrase
 // against the server auth.
 func (s *Server) invalidAuth(req *btcjson.Request) bool {
+	if !s.basicAuth {
+		return true
+	}
 	cmd, err := btcjson.UnmarshalCmd(req)
 	if err != nil {
 		return false
@@ -438,10 +638,37 @@ out:
 				break out
 			}
 
+			caller := s.caller(wsc.macaroon)
+			var jsonErr *btcjson.RPCError
+			if !s.limiter.Allow(caller, req.Method) {
+				jsonErr = &ErrRateLimited
+			} else if jsonErr = s.authorize(wsc.macaroon,
+				wallet.DefaultWalletName, &req); jsonErr != nil {
+
+				s.recordAudit(caller, wsc.remoteAddr,
+					wallet.DefaultWalletName, &req, nil,
+					jsonErr)
+			}
+			if jsonErr != nil {
+				resp := makeResponse(req.ID, nil, jsonErr)
+				// Expected to never fail.
+				mresp, err := json.Marshal(resp)
+				if err != nil {
+					panic(err)
+				}
+				err = wsc.send(mresp)
+				if err != nil {
+					break out
+				}
+				continue
+			}
+
 			switch req.Method {
 			case "stop":
-				resp := makeResponse(req.ID,
-					"btcwallet stopping.", nil)
+				const res = "btcwallet stopping."
+				s.recordAudit(caller, wsc.remoteAddr,
+					wallet.DefaultWalletName, &req, res, nil)
+				resp := makeResponse(req.ID, res, nil)
 				mresp, err := json.Marshal(resp)
 				// Expected to never fail.
 				if err != nil {
@@ -456,13 +683,21 @@ out:
 
 			default:
 				req := req // Copy for the closure
-				f := s.handlerClosure(&req)
+				ctx := s.spendContext(
+					context.Background(), wsc.macaroon,
+					wallet.DefaultWalletName, req.Method,
+				)
+				f := s.handlerClosure(ctx,
+					wallet.DefaultWalletName, &req)
 				wsc.wg.Add(1)
 				go func() {
 					resp, jsonErr := f()
-					mresp, err := btcjson.MarshalResponse(
-						btcjson.RpcVersion1, req.ID,
-						resp, jsonErr,
+					s.recordAudit(caller, wsc.remoteAddr,
+						wallet.DefaultWalletName, &req,
+						resp, jsonErr)
+					mresp, err := marshalResponse(
+						req.Jsonrpc, req.ID, resp,
+						jsonErr,
 					)
 					if err != nil {
 						log.Errorf("Unable to marshal "+
@@ -545,8 +780,58 @@ func (s *Server) websocketClientRPC(wsc *websocketClient) {
 // that may be read from a client.  This is currently limited to 4MB.
 const maxRequestSize = 1024 * 1024 * 4
 
-// postClientRPC processes and replies to a JSON-RPC client request.
-func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
+// maxBatchConcurrency is the maximum number of requests of a batch that are
+// handled concurrently.
+const maxBatchConcurrency = 8
+
+// marshalResponse marshals the response to a request of the JSON-RPC version.
+// JSON-RPC 2.0 responses contain either the result or the error, while 1.0
+// responses always contain both.
+func marshalResponse(version btcjson.RPCVersion, id interface{},
+	result interface{}, jsonErr *btcjson.RPCError) ([]byte, error) {
+
+	if version != btcjson.RpcVersion2 {
+		return btcjson.MarshalResponse(
+			btcjson.RpcVersion1, id, result, jsonErr,
+		)
+	}
+
+	if jsonErr != nil {
+		return json.Marshal(&struct {
+			Jsonrpc btcjson.RPCVersion `json:"jsonrpc"`
+			Error   *btcjson.RPCError  `json:"error"`
+			ID      interface{}        `json:"id"`
+		}{version, jsonErr, id})
+	}
+	marshalledResult, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&struct {
+		Jsonrpc btcjson.RPCVersion `json:"jsonrpc"`
+		Result  json.RawMessage    `json:"result"`
+		ID      interface{}        `json:"id"`
+	}{version, marshalledResult, id})
+}
+
+// unmarshalError returns the error of a request that could not be
+// unmarshaled, which is a parse error for invalid JSON.
+func unmarshalError(request []byte) *btcjson.RPCError {
+	if !json.Valid(request) {
+		return btcjson.ErrRPCParse
+	}
+	return btcjson.ErrRPCInvalidRequest
+}
+
+// postClientRPC processes and replies to a JSON-RPC client request, or to a
+// JSON-RPC 2.0 batch of requests, made to the wallet of the URI path.  The
+// requests are checked against the caveats of the macaroon the client
+// authenticated with, if any.
+func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request,
+	mac *macaroons.Macaroon) {
+
+	walletName := requestWalletName(r.URL.Path)
+
 	body := http.MaxBytesReader(w, r.Body, maxRequestSize)
 	rpcRequest, err := io.ReadAll(body)
 	if err != nil {
@@ -556,6 +841,12 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rpcRequest = bytes.TrimSpace(rpcRequest)
+	if len(rpcRequest) != 0 && rpcRequest[0] == '[' {
+		s.postClientBatch(w, r, mac, walletName, rpcRequest)
+		return
+	}
+
 	// First check whether wallet has a handler for this request's method.
 	// If unfound, the request is sent to the chain server for further
 	// processing.  While checking the methods, disallow authenticate
@@ -565,7 +856,7 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		resp, err := btcjson.MarshalResponse(
 			btcjson.RpcVersion1, req.ID, nil,
-			btcjson.ErrRPCInvalidRequest,
+			unmarshalError(rpcRequest),
 		)
 		if err != nil {
 			log.Errorf("Unable to marshal response: %v", err)
@@ -581,26 +872,24 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create the response and error from the request.  Two special cases
-	// are handled for the authenticate and stop request methods.
-	var res interface{}
-	var jsonErr *btcjson.RPCError
-	var stop bool
-	switch req.Method {
-	case "authenticate":
+	if req.Method == "authenticate" {
 		// Drop it.
 		return
-	case "stop":
-		stop = true
-		res = "btcwallet stopping"
-	default:
-		res, jsonErr = s.handlerClosure(&req)()
 	}
+	caller := s.caller(mac)
+	if !s.limiter.Allow(caller, req.Method) {
+		log.Warnf("Rate limit of %s requests exceeded by %s",
+			req.Method, caller)
+		http.Error(w, "429 Too Many Requests",
+			http.StatusTooManyRequests)
+		return
+	}
+	ctx := tracing.Extract(r.Context(), r.Header)
+	res, jsonErr, stop := s.servePOSTRequest(ctx, mac, caller,
+		r.RemoteAddr, walletName, &req)
 
 	// Marshal and send.
-	mresp, err := btcjson.MarshalResponse(
-		btcjson.RpcVersion1, req.ID, res, jsonErr,
-	)
+	mresp, err := marshalResponse(req.Jsonrpc, req.ID, res, jsonErr)
 	if err != nil {
 		log.Errorf("Unable to marshal response: %v", err)
 		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
@@ -616,6 +905,168 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// postClientBatch processes and replies to a JSON-RPC 2.0 batch of requests.
+// The requests are handled concurrently, and the responses are written in the
+// order of the requests.  As with single requests, authenticate requests are
+// not responded to, and neither are notifications.  A batch without any
+// response is replied to with an empty body.
+func (s *Server) postClientBatch(w http.ResponseWriter, r *http.Request,
+	mac *macaroons.Macaroon, walletName string, batch []byte) {
+
+	writeResponse := func(resp []byte) {
+		if _, err := w.Write(resp); err != nil {
+			log.Warnf("Unable to respond to client: %v", err)
+		}
+	}
+
+	// An invalid or empty batch is responded to with a single error.
+	var rawRequests []json.RawMessage
+	err := json.Unmarshal(batch, &rawRequests)
+	if err != nil || len(rawRequests) == 0 {
+		jsonErr := unmarshalError(batch)
+		if err == nil {
+			jsonErr = btcjson.ErrRPCInvalidRequest
+		}
+		resp, err := marshalResponse(btcjson.RpcVersion2, nil, nil,
+			jsonErr)
+		if err != nil {
+			log.Errorf("Unable to marshal response: %v", err)
+			http.Error(w, "500 Internal Server Error",
+				http.StatusInternalServerError)
+			return
+		}
+		writeResponse(resp)
+		return
+	}
+
+	var (
+		ctx       = tracing.Extract(r.Context(), r.Header)
+		caller    = s.caller(mac)
+		responses = make([][]byte, len(rawRequests))
+		stop      int32
+		sem       = make(chan struct{}, maxBatchConcurrency)
+		wg        sync.WaitGroup
+	)
+	for i, rawRequest := range rawRequests {
+		var req btcjson.Request
+		var jsonErr *btcjson.RPCError
+		switch err := json.Unmarshal(rawRequest, &req); {
+		case err != nil:
+			jsonErr = btcjson.ErrRPCInvalidRequest
+		case req.Method == "authenticate":
+			continue
+		case !s.limiter.Allow(caller, req.Method):
+			jsonErr = &ErrRateLimited
+		}
+
+		// Notifications are handled, but never responded to, even
+		// with an error.
+		notification := jsonErr != btcjson.ErrRPCInvalidRequest &&
+			isNotification(&req, rawRequest)
+		if jsonErr != nil {
+			if notification {
+				continue
+			}
+			resp, err := marshalResponse(btcjson.RpcVersion2,
+				req.ID, nil, jsonErr)
+			if err != nil {
+				log.Errorf("Unable to marshal response: %v", err)
+				continue
+			}
+			responses[i] = resp
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			res, jsonErr, stopRequested := s.servePOSTRequest(
+				ctx, mac, caller, r.RemoteAddr, walletName, &req,
+			)
+			if stopRequested {
+				atomic.StoreInt32(&stop, 1)
+			}
+			if notification {
+				return
+			}
+			resp, err := marshalResponse(req.Jsonrpc, req.ID, res,
+				jsonErr)
+			if err != nil {
+				log.Errorf("Unable to marshal response: %v", err)
+				resp, _ = marshalResponse(req.Jsonrpc, req.ID,
+					nil, btcjson.ErrRPCInternal)
+			}
+			responses[i] = resp
+		}(i)
+	}
+	wg.Wait()
+
+	var buf bytes.Buffer
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		if buf.Len() == 0 {
+			buf.WriteByte('[')
+		} else {
+			buf.WriteByte(',')
+		}
+		buf.Write(resp)
+	}
+	if buf.Len() != 0 {
+		buf.WriteByte(']')
+		writeResponse(buf.Bytes())
+	}
+
+	if atomic.LoadInt32(&stop) != 0 {
+		s.requestProcessShutdown()
+	}
+}
+
+// isNotification reports whether a request of a batch is a JSON-RPC 2.0
+// notification, which has no id member and is not responded to.
+func isNotification(req *btcjson.Request, rawRequest []byte) bool {
+	if req.Jsonrpc != btcjson.RpcVersion2 {
+		return false
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(rawRequest, &members); err != nil {
+		return false
+	}
+	_, ok := members["id"]
+	return !ok
+}
+
+// servePOSTRequest authorizes and handles a request of an HTTP POST client to
+// the named wallet, and reports whether the client requested the process to
+// stop.  The stop request is special cased as the response must be written
+// before the process shuts down.
+func (s *Server) servePOSTRequest(ctx context.Context,
+	mac *macaroons.Macaroon, caller, remoteAddr, walletName string,
+	req *btcjson.Request) (interface{}, *btcjson.RPCError, bool) {
+
+	var res interface{}
+	var stop bool
+	jsonErr := s.authorize(mac, walletName, req)
+	switch {
+	case jsonErr != nil:
+		// Denied by the caveats of the macaroon.
+	case req.Method == "stop":
+		stop = true
+		res = "btcwallet stopping"
+	default:
+		ctx := s.spendContext(ctx, mac, walletName, req.Method)
+		res, jsonErr = s.handlerClosure(ctx, walletName, req)()
+	}
+	s.recordAudit(caller, remoteAddr, walletName, req, res, jsonErr)
+	return res, jsonErr, stop
+}
+
 func (s *Server) requestProcessShutdown() {
 	select {
 	case s.requestShutdownChan <- struct{}{}:
@@ -0,0 +1,222 @@
+package legacyrpc
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// The functions in this file read and write wallet dumps in the text format
+// of the dumpwallet and importwallet commands of Bitcoin Core.  Each key is
+// written on a line of its own:
+//
+//	<WIF> <time> label=<account>|change=1 # addr=<address> hdkeypath=<path>
+//
+// and each redeem script as:
+//
+//	<script hex> <time> script=1 # addr=<address>
+//
+// Lines starting with '#' are comments.
+
+// dumpTimeFormat is the ISO 8601 format of the times in a wallet dump.
+const dumpTimeFormat = "2006-01-02T15:04:05Z"
+
+// formatDumpTime formats a key time for a wallet dump.  Unknown times are
+// written as 0.
+func formatDumpTime(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return t.UTC().Format(dumpTimeFormat)
+}
+
+// parseDumpTime parses a key time of a wallet dump.  Like Bitcoin Core,
+// times that can't be parsed are treated as unknown.
+func parseDumpTime(s string) time.Time {
+	t, err := time.Parse(dumpTimeFormat, s)
+	if err != nil || t.Unix() <= 0 {
+		return time.Time{}
+	}
+	return t
+}
+
+// encodeDumpString percent-encodes the characters of a label that would
+// break the line format of a wallet dump.
+func encodeDumpString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x80 || c == '%' {
+			fmt.Fprintf(&b, "%%%02x", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// decodeDumpString reverses encodeDumpString.  Malformed escapes are kept
+// as they are.
+func decodeDumpString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			c, err := hex.DecodeString(s[i+1 : i+3])
+			if err == nil {
+				b.WriteByte(c[0])
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// writeWalletDump writes the wallet dump in the text format of Bitcoin Core.
+// The block the wallet is synced to is reported as the best block.
+func writeWalletDump(wr io.Writer, dump *wallet.WalletDump,
+	created time.Time) error {
+
+	bw := bufio.NewWriter(wr)
+
+	fmt.Fprintf(bw, "# Wallet dump created by btcwallet\n")
+	fmt.Fprintf(bw, "# * Created on %s\n", formatDumpTime(created))
+	fmt.Fprintf(bw, "# * Best block at time of backup was %d (%v),\n",
+		dump.SyncedTo.Height, dump.SyncedTo.Hash)
+	fmt.Fprintf(bw, "#   mined on %s\n",
+		formatDumpTime(dump.SyncedTo.Timestamp))
+	fmt.Fprintf(bw, "\n")
+
+	for _, key := range dump.Keys {
+		kind := "label=" + encodeDumpString(key.Account)
+		if key.Internal {
+			kind = "change=1"
+		}
+		fmt.Fprintf(bw, "%s %s %s # addr=%s", key.WIF,
+			formatDumpTime(key.Time), kind,
+			key.Address.EncodeAddress())
+		if key.DerivationPath != "" {
+			fmt.Fprintf(bw, " hdkeypath=%s", key.DerivationPath)
+		}
+		fmt.Fprintf(bw, "\n")
+	}
+	fmt.Fprintf(bw, "\n")
+
+	for _, script := range dump.Scripts {
+		fmt.Fprintf(bw, "%x %s script=1 # addr=%s\n", script.Script,
+			formatDumpTime(script.Time),
+			script.Address.EncodeAddress())
+	}
+	fmt.Fprintf(bw, "\n")
+	fmt.Fprintf(bw, "# End of dump\n")
+
+	return bw.Flush()
+}
+
+// parseWalletDump reads a wallet dump in the text format of Bitcoin Core.
+// The labels of the keys are returned as their account.  They are informative
+// only: ImportDump places all new keys in the imported account.
+func parseWalletDump(r io.Reader,
+	params *chaincfg.Params) (*wallet.WalletDump, error) {
+
+	dump := &wallet.WalletDump{}
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Everything after the first field starting with '#' is a
+		// comment.
+		fields := strings.Fields(line)
+		var comment []string
+		for i, field := range fields {
+			if strings.HasPrefix(field, "#") {
+				fields, comment = fields[:i], fields[i:]
+				break
+			}
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: missing key time",
+				lineNum)
+		}
+
+		var addrStr string
+		for _, field := range comment {
+			if strings.HasPrefix(field, "addr=") {
+				addrStr = strings.TrimPrefix(field, "addr=")
+			}
+		}
+		var addr btcutil.Address
+		if addrStr != "" {
+			var err error
+			addr, err = btcutil.DecodeAddress(addrStr, params)
+			if err != nil || !addr.IsForNet(params) {
+				return nil, fmt.Errorf("line %d: invalid "+
+					"address %q", lineNum, addrStr)
+			}
+		}
+
+		keyTime := parseDumpTime(fields[1])
+
+		var (
+			isScript bool
+			label    string
+		)
+		for _, field := range fields[2:] {
+			switch {
+			case field == "script=1":
+				isScript = true
+
+			case strings.HasPrefix(field, "label="):
+				label = decodeDumpString(
+					strings.TrimPrefix(field, "label="),
+				)
+			}
+		}
+
+		if isScript {
+			script, err := hex.DecodeString(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid "+
+					"script: %v", lineNum, err)
+			}
+			dump.Scripts = append(dump.Scripts, wallet.DumpedScript{
+				Script:  script,
+				Address: addr,
+				Time:    keyTime,
+			})
+			continue
+		}
+
+		wif, err := btcutil.DecodeWIF(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid private key: "+
+				"%v", lineNum, err)
+		}
+		if !wif.IsForNet(params) {
+			return nil, fmt.Errorf("line %d: private key is not "+
+				"intended for %s", lineNum, params.Name)
+		}
+		dump.Keys = append(dump.Keys, wallet.DumpedKey{
+			WIF:     wif,
+			Address: addr,
+			Time:    keyTime,
+			Account: label,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return dump, nil
+}
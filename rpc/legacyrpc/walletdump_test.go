@@ -0,0 +1,105 @@
+package legacyrpc
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// TestWalletDumpRoundTrip checks that wallet dumps written in the text format
+// of Bitcoin Core are parsed back into the same keys and scripts.
+func TestWalletDumpRoundTrip(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+
+	privKey, _ := btcec.PrivKeyFromBytes(bytes.Repeat([]byte{1}, 32))
+	wif, err := btcutil.NewWIF(privKey, params, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(wif.SerializePubKey()), params,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := []byte{0x51}
+	scriptAddr, err := btcutil.NewAddressScriptHash(script, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	dump := &wallet.WalletDump{
+		Keys: []wallet.DumpedKey{{
+			WIF:            wif,
+			Address:        keyAddr,
+			Time:           keyTime,
+			Account:        "savings 100%",
+			DerivationPath: "m/84'/1'/0'/0/7",
+		}, {
+			WIF:      wif,
+			Address:  keyAddr,
+			Internal: true,
+		}},
+		Scripts: []wallet.DumpedScript{{
+			Script:  script,
+			Address: scriptAddr,
+		}},
+		SyncedTo: waddrmgr.BlockStamp{Height: 100},
+	}
+
+	var buf bytes.Buffer
+	if err := writeWalletDump(&buf, dump, keyTime); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	for _, want := range []string{
+		"label=savings%20100%25 # addr=" + keyAddr.EncodeAddress() +
+			" hdkeypath=m/84'/1'/0'/0/7\n",
+		" 0 change=1 # addr=",
+		"51 0 script=1 # addr=" + scriptAddr.EncodeAddress() + "\n",
+		"# End of dump\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("dump does not contain %q:\n%s", want, text)
+		}
+	}
+
+	parsed, err := parseWalletDump(strings.NewReader(text), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Keys) != 2 || len(parsed.Scripts) != 1 {
+		t.Fatalf("parsed %d keys and %d scripts, want 2 and 1",
+			len(parsed.Keys), len(parsed.Scripts))
+	}
+	key := parsed.Keys[0]
+	if key.WIF.String() != wif.String() ||
+		key.Address.EncodeAddress() != keyAddr.EncodeAddress() ||
+		!key.Time.Equal(keyTime) || key.Account != "savings 100%" {
+
+		t.Fatalf("unexpected parsed key: %+v", key)
+	}
+	if !parsed.Keys[1].Time.IsZero() {
+		t.Fatalf("unknown key time parsed as %v", parsed.Keys[1].Time)
+	}
+	if !reflect.DeepEqual(parsed.Scripts[0].Script, script) {
+		t.Fatalf("unexpected parsed script %x", parsed.Scripts[0].Script)
+	}
+
+	// Keys for other networks are rejected.
+	_, err = parseWalletDump(
+		strings.NewReader(text), &chaincfg.MainNetParams,
+	)
+	if err == nil {
+		t.Fatal("parsed dump for wrong network")
+	}
+}
@@ -0,0 +1,291 @@
+package macaroons
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+)
+
+// Permission is a set of RPC methods a macaroon may be allowed to call.
+type Permission string
+
+const (
+	// PermRead allows querying the wallet and the chain.
+	PermRead Permission = "read"
+
+	// PermAddress allows deriving new addresses.
+	PermAddress Permission = "address"
+
+	// PermSign allows creating signatures with the keys of the wallet,
+	// including signed transactions that are not published.
+	PermSign Permission = "sign"
+
+	// PermSend allows publishing transactions.
+	PermSend Permission = "send"
+
+	// PermAdmin allows managing the wallet, such as unlocking it,
+	// importing and exporting keys, and stopping the server.
+	PermAdmin Permission = "admin"
+)
+
+// Permissions are all permissions, which a macaroon without a permission
+// caveat has.
+var Permissions = []Permission{
+	PermRead, PermAddress, PermSign, PermSend, PermAdmin,
+}
+
+// Caveat conditions.  A caveat is the condition, a space, and its value.
+const (
+	condPermissions  = "perms"
+	condAccounts     = "accounts"
+	condWallets      = "wallets"
+	condTxSpendLimit = "txspendlimit"
+	condExpires      = "expires"
+
+	// condSpendLimit is the former name of condTxSpendLimit, which is
+	// still accepted so that macaroons baked with it keep working.  It
+	// limits single transactions as well.
+	condSpendLimit = "spendlimit"
+)
+
+// ErrPermissionDenied is returned when the caveats of a macaroon don't allow
+// a request.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// PermissionsCaveat returns the caveat limiting a macaroon to the
+// permissions.
+func PermissionsCaveat(perms ...Permission) string {
+	strs := make([]string, len(perms))
+	for i, perm := range perms {
+		strs[i] = string(perm)
+	}
+	return condPermissions + " " + strings.Join(strs, ",")
+}
+
+// AccountsCaveat returns the caveat limiting a macaroon to the account
+// numbers.  Requests that are not limited to one of the accounts, such as
+// queries of the balance of the whole wallet, are denied.
+func AccountsCaveat(accounts ...uint32) string {
+	strs := make([]string, len(accounts))
+	for i, account := range accounts {
+		strs[i] = strconv.FormatUint(uint64(account), 10)
+	}
+	return condAccounts + " " + strings.Join(strs, ",")
+}
+
+// WalletsCaveat returns the caveat limiting a macaroon to the named wallets
+// of a process serving several wallets.  The default wallet is named by the
+// empty string.  Requests that are not made to one of the wallets, such as
+// listing the loaded wallets, are denied.
+func WalletsCaveat(names ...string) string {
+	return condWallets + " " + strings.Join(names, ",")
+}
+
+// TxSpendLimitCaveat returns the caveat limiting the amount a single
+// transaction signed or published with a macaroon may pay to addresses
+// outside of the wallet and in fees.  The limit is not cumulative: a macaroon
+// may make any number of transactions within it, so it should be combined
+// with an expiry caveat to bound the amount a leaked macaroon can move.
+func TxSpendLimitCaveat(limit btcutil.Amount) string {
+	return condTxSpendLimit + " " + strconv.FormatInt(int64(limit), 10)
+}
+
+// ExpiryCaveat returns the caveat making a macaroon invalid after the time.
+func ExpiryCaveat(t time.Time) string {
+	return condExpires + " " + t.UTC().Format(time.RFC3339)
+}
+
+// Restrictions are the combined restrictions of the caveats of a macaroon.
+type Restrictions struct {
+	// Permissions are the allowed permissions, or nil if all permissions
+	// are allowed.
+	Permissions map[Permission]bool
+
+	// Accounts are the allowed account numbers, or nil if all accounts
+	// are allowed.
+	Accounts map[uint32]bool
+
+	// Wallets are the names of the allowed wallets, or nil if all wallets
+	// are allowed.
+	Wallets map[string]bool
+
+	// TxSpendLimit is the maximum amount a single transaction may
+	// spend, or negative if spending is not limited.
+	TxSpendLimit btcutil.Amount
+
+	// Expiry is the time the macaroon expires at, or zero if it doesn't
+	// expire.
+	Expiry time.Time
+}
+
+// ParseCaveats returns the restrictions of the caveats.  Repeated conditions
+// restrict each other further.  Unknown conditions are an error, so that
+// macaroons are never granted more than their caveats intend.
+func ParseCaveats(caveats []string) (*Restrictions, error) {
+	r := &Restrictions{TxSpendLimit: -1}
+	for _, caveat := range caveats {
+		cond, value, _ := strings.Cut(caveat, " ")
+		switch cond {
+		case condPermissions:
+			perms := make(map[Permission]bool)
+			for _, perm := range strings.Split(value, ",") {
+				perm := Permission(strings.TrimSpace(perm))
+				if r.Permissions == nil || r.Permissions[perm] {
+					perms[perm] = true
+				}
+			}
+			r.Permissions = perms
+
+		case condAccounts:
+			accounts := make(map[uint32]bool)
+			for _, s := range strings.Split(value, ",") {
+				account, err := strconv.ParseUint(
+					strings.TrimSpace(s), 10, 32,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("invalid caveat "+
+						"%q: %v", caveat, err)
+				}
+				acct := uint32(account)
+				if r.Accounts == nil || r.Accounts[acct] {
+					accounts[acct] = true
+				}
+			}
+			r.Accounts = accounts
+
+		case condWallets:
+			wallets := make(map[string]bool)
+			for _, name := range strings.Split(value, ",") {
+				name = strings.TrimSpace(name)
+				if r.Wallets == nil || r.Wallets[name] {
+					wallets[name] = true
+				}
+			}
+			r.Wallets = wallets
+
+		case condTxSpendLimit, condSpendLimit:
+			limit, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || limit < 0 {
+				return nil, fmt.Errorf("invalid caveat %q",
+					caveat)
+			}
+			if r.TxSpendLimit < 0 ||
+				btcutil.Amount(limit) < r.TxSpendLimit {
+
+				r.TxSpendLimit = btcutil.Amount(limit)
+			}
+
+		case condExpires:
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid caveat %q: %v",
+					caveat, err)
+			}
+			if r.Expiry.IsZero() || t.Before(r.Expiry) {
+				r.Expiry = t
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown caveat %q", caveat)
+		}
+	}
+	return r, nil
+}
+
+// Request describes what an RPC request does, as far as the caveats of
+// macaroons are concerned.
+type Request struct {
+	// Method is the name of the method, used in errors.
+	Method string
+
+	// Permission is the permission required by the method.
+	Permission Permission
+
+	// Accounts are the accounts the request is limited to.
+	Accounts []uint32
+
+	// AllAccounts is set if the request is not limited to any accounts,
+	// for example because it queries the whole wallet.
+	AllAccounts bool
+
+	// Wallet is the name of the wallet the request is made to.
+	Wallet string
+
+	// AllWallets is set if the request is not made to a single wallet,
+	// for example because it lists the loaded wallets.
+	AllWallets bool
+
+	// Spend is the amount the transaction of the request pays to
+	// addresses outside of the wallet and in fees.  Requests that make
+	// the wallet create and publish a transaction leave it zero, as the
+	// transaction is checked with AuthorizeTx before it is published.
+	Spend btcutil.Amount
+
+	// SpendUnknown is set if the request spends outputs whose value is
+	// unknown, so that the fee it pays, and therefore its spent amount,
+	// can't be determined.
+	SpendUnknown bool
+}
+
+// Allow checks that the restrictions allow the request at the time.
+func (r *Restrictions) Allow(req *Request, now time.Time) error {
+	if !r.Expiry.IsZero() && now.After(r.Expiry) {
+		return fmt.Errorf("%w: macaroon expired at %v",
+			ErrPermissionDenied, r.Expiry)
+	}
+	if r.Permissions != nil && !r.Permissions[req.Permission] {
+		return fmt.Errorf("%w: %s requires the %s permission",
+			ErrPermissionDenied, req.Method, req.Permission)
+	}
+	if r.Wallets != nil {
+		if req.AllWallets {
+			return fmt.Errorf("%w: %s is not limited to the "+
+				"allowed wallets", ErrPermissionDenied,
+				req.Method)
+		}
+		if !r.Wallets[req.Wallet] {
+			return fmt.Errorf("%w: wallet %q is not allowed",
+				ErrPermissionDenied, req.Wallet)
+		}
+	}
+	if r.Accounts != nil {
+		if req.AllAccounts {
+			return fmt.Errorf("%w: %s is not limited to the "+
+				"allowed accounts", ErrPermissionDenied,
+				req.Method)
+		}
+		for _, account := range req.Accounts {
+			if !r.Accounts[account] {
+				return fmt.Errorf("%w: account %d is not "+
+					"allowed", ErrPermissionDenied, account)
+			}
+		}
+	}
+	return r.AllowSpend(req.Method, req.Spend, req.SpendUnknown)
+}
+
+// AllowSpend checks that the transaction spend limit allows a transaction of
+// the method spending the amount.  Transactions spending outputs of unknown
+// value are denied under a limit.
+func (r *Restrictions) AllowSpend(method string, spend btcutil.Amount,
+	spendUnknown bool) error {
+
+	if r.TxSpendLimit < 0 {
+		return nil
+	}
+	if spendUnknown {
+		return fmt.Errorf("%w: %s spends outputs of unknown value "+
+			"under a transaction spend limit", ErrPermissionDenied,
+			method)
+	}
+	if spend > r.TxSpendLimit {
+		return fmt.Errorf("%w: spending %v exceeds the transaction "+
+			"limit of %v", ErrPermissionDenied, spend,
+			r.TxSpendLimit)
+	}
+	return nil
+}
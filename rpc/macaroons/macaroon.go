@@ -0,0 +1,188 @@
+// Package macaroons implements the capability tokens used to authenticate and
+// authorize RPC clients.
+//
+// A macaroon is a bearer token made of an identifier, a list of caveats and a
+// signature.  The signature of a new macaroon is the HMAC-SHA256 of its
+// identifier keyed by a root key only known to the server.  Every caveat added
+// to the macaroon replaces the signature with the HMAC of the caveat keyed by
+// the previous signature.  The holder of a macaroon can therefore restrict it
+// further by adding caveats, but can't remove any caveat without the root key.
+//
+// The caveats restrict the permissions, accounts, spent amounts and lifetime
+// of a macaroon, and are enforced by the RPC servers for each request.
+package macaroons
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// macaroonVersion is the version of the serialization of macaroons.
+const macaroonVersion = 1
+
+// maxFieldLen is the maximum length of the identifier and each caveat of a
+// serialized macaroon.
+const maxFieldLen = 4096
+
+var (
+	// ErrInvalidSignature is returned when the signature of a macaroon
+	// does not match its identifier and caveats.
+	ErrInvalidSignature = errors.New("invalid macaroon signature")
+
+	// ErrMalformed is returned when a serialized macaroon can't be
+	// parsed.
+	ErrMalformed = errors.New("malformed macaroon")
+)
+
+// Macaroon is a capability token.  Macaroons are immutable; adding a caveat
+// returns a new macaroon.
+type Macaroon struct {
+	id      []byte
+	caveats []string
+	sig     [sha256.Size]byte
+}
+
+// chainSignature returns the HMAC of the data keyed by the key.
+func chainSignature(key, data []byte) [sha256.Size]byte {
+	var sig [sha256.Size]byte
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	copy(sig[:], mac.Sum(nil))
+	return sig
+}
+
+// New creates a macaroon without caveats with the identifier, signed by the
+// root key.
+func New(rootKey, id []byte) *Macaroon {
+	return &Macaroon{
+		id:  append([]byte(nil), id...),
+		sig: chainSignature(rootKey, id),
+	}
+}
+
+// ID returns the identifier of the macaroon.
+func (m *Macaroon) ID() []byte {
+	return append([]byte(nil), m.id...)
+}
+
+// Caveats returns the caveats of the macaroon in the order they were added.
+func (m *Macaroon) Caveats() []string {
+	return append([]string(nil), m.caveats...)
+}
+
+// WithCaveat returns a copy of the macaroon restricted by the caveat.
+func (m *Macaroon) WithCaveat(caveat string) *Macaroon {
+	caveats := make([]string, len(m.caveats), len(m.caveats)+1)
+	copy(caveats, m.caveats)
+	return &Macaroon{
+		id:      m.id,
+		caveats: append(caveats, caveat),
+		sig:     chainSignature(m.sig[:], []byte(caveat)),
+	}
+}
+
+// Verify checks that the macaroon was created with the root key and that its
+// caveats were not tampered with.
+func (m *Macaroon) Verify(rootKey []byte) error {
+	sig := chainSignature(rootKey, m.id)
+	for _, caveat := range m.caveats {
+		sig = chainSignature(sig[:], []byte(caveat))
+	}
+	if !hmac.Equal(sig[:], m.sig[:]) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// MarshalBinary serializes the macaroon.
+func (m *Macaroon) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	var lenBuf [binary.MaxVarintLen64]byte
+	writeField := func(b []byte) {
+		n := binary.PutUvarint(lenBuf[:], uint64(len(b)))
+		buf.Write(lenBuf[:n])
+		buf.Write(b)
+	}
+
+	buf.WriteByte(macaroonVersion)
+	writeField(m.id)
+	n := binary.PutUvarint(lenBuf[:], uint64(len(m.caveats)))
+	buf.Write(lenBuf[:n])
+	for _, caveat := range m.caveats {
+		writeField([]byte(caveat))
+	}
+	buf.Write(m.sig[:])
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary deserializes a macaroon serialized by MarshalBinary.
+func (m *Macaroon) UnmarshalBinary(b []byte) error {
+	r := bytes.NewReader(b)
+	readField := func() ([]byte, error) {
+		n, err := binary.ReadUvarint(r)
+		if err != nil || n > maxFieldLen || n > uint64(r.Len()) {
+			return nil, ErrMalformed
+		}
+		field := make([]byte, n)
+		_, _ = r.Read(field)
+		return field, nil
+	}
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return ErrMalformed
+	}
+	if version != macaroonVersion {
+		return fmt.Errorf("%w: unknown version %d", ErrMalformed,
+			version)
+	}
+	id, err := readField()
+	if err != nil {
+		return err
+	}
+	numCaveats, err := binary.ReadUvarint(r)
+	if err != nil || numCaveats > uint64(r.Len()) {
+		return ErrMalformed
+	}
+	caveats := make([]string, 0, numCaveats)
+	for i := uint64(0); i < numCaveats; i++ {
+		caveat, err := readField()
+		if err != nil {
+			return err
+		}
+		caveats = append(caveats, string(caveat))
+	}
+	var sig [sha256.Size]byte
+	if r.Len() != len(sig) {
+		return ErrMalformed
+	}
+	_, _ = r.Read(sig[:])
+
+	*m = Macaroon{id: id, caveats: caveats, sig: sig}
+	return nil
+}
+
+// String returns the hex encoding of the serialized macaroon, which is the
+// form macaroons are passed to the RPC servers in.
+func (m *Macaroon) String() string {
+	b, _ := m.MarshalBinary()
+	return hex.EncodeToString(b)
+}
+
+// Decode parses a hex encoded macaroon.
+func Decode(s string) (*Macaroon, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	m := new(Macaroon)
+	if err := m.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
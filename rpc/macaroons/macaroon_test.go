@@ -0,0 +1,324 @@
+package macaroons
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var testRootKey = []byte("0123456789abcdef0123456789abcdef")
+
+// TestMacaroonEncoding ensures macaroons survive serialization and that
+// their signatures are checked.
+func TestMacaroonEncoding(t *testing.T) {
+	m := New(testRootKey, []byte("id")).
+		WithCaveat(PermissionsCaveat(PermRead)).
+		WithCaveat(AccountsCaveat(0, 1))
+
+	decoded, err := Decode(m.String())
+	if err != nil {
+		t.Fatalf("unable to decode macaroon: %v", err)
+	}
+	if err := decoded.Verify(testRootKey); err != nil {
+		t.Fatalf("decoded macaroon is invalid: %v", err)
+	}
+	if decoded.String() != m.String() {
+		t.Fatalf("decoded macaroon %s differs from %s", decoded, m)
+	}
+
+	err = decoded.Verify([]byte("another root key"))
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature with wrong root key, "+
+			"got %v", err)
+	}
+
+	// Removing a caveat must invalidate the macaroon.
+	stripped := &Macaroon{
+		id:      decoded.id,
+		caveats: decoded.caveats[:1],
+		sig:     decoded.sig,
+	}
+	if err := stripped.Verify(testRootKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for removed caveat, "+
+			"got %v", err)
+	}
+
+	for _, s := range []string{"", "zz", "00", "01"} {
+		if _, err := Decode(s); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Decode(%q): expected ErrMalformed, got %v", s,
+				err)
+		}
+	}
+}
+
+// TestRestrictions checks that caveats restrict requests as intended.
+func TestRestrictions(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		caveats []string
+		req     Request
+		allowed bool
+	}{
+		{
+			name:    "no caveats",
+			req:     Request{Permission: PermAdmin, AllAccounts: true},
+			allowed: true,
+		},
+		{
+			name:    "read only send",
+			caveats: []string{PermissionsCaveat(PermRead)},
+			req:     Request{Permission: PermSend},
+		},
+		{
+			name: "intersected permissions",
+			caveats: []string{
+				PermissionsCaveat(PermRead, PermSend),
+				PermissionsCaveat(PermRead),
+			},
+			req: Request{Permission: PermSend},
+		},
+		{
+			name:    "allowed account",
+			caveats: []string{AccountsCaveat(1, 2)},
+			req: Request{
+				Permission: PermAddress,
+				Accounts:   []uint32{2},
+			},
+			allowed: true,
+		},
+		{
+			name:    "other account",
+			caveats: []string{AccountsCaveat(1, 2)},
+			req: Request{
+				Permission: PermAddress,
+				Accounts:   []uint32{0},
+			},
+		},
+		{
+			name:    "all accounts",
+			caveats: []string{AccountsCaveat(1)},
+			req:     Request{Permission: PermRead, AllAccounts: true},
+		},
+		{
+			name:    "allowed wallet",
+			caveats: []string{WalletsCaveat("alice", "bob")},
+			req:     Request{Permission: PermRead, Wallet: "bob"},
+			allowed: true,
+		},
+		{
+			name:    "other wallet",
+			caveats: []string{WalletsCaveat("alice", "bob")},
+			req:     Request{Permission: PermRead},
+		},
+		{
+			name: "intersected wallets",
+			caveats: []string{
+				WalletsCaveat("", "alice"),
+				WalletsCaveat(""),
+			},
+			req:     Request{Permission: PermRead},
+			allowed: true,
+		},
+		{
+			name:    "all wallets",
+			caveats: []string{WalletsCaveat("alice")},
+			req:     Request{Permission: PermRead, AllWallets: true},
+		},
+		{
+			name: "within spend limit",
+			caveats: []string{
+				TxSpendLimitCaveat(btcutil.Amount(1000)),
+				TxSpendLimitCaveat(btcutil.Amount(5000)),
+			},
+			req:     Request{Permission: PermSend, Spend: 1000},
+			allowed: true,
+		},
+		{
+			name: "over spend limit",
+			caveats: []string{
+				TxSpendLimitCaveat(btcutil.Amount(5000)),
+				TxSpendLimitCaveat(btcutil.Amount(1000)),
+			},
+			req: Request{Permission: PermSend, Spend: 1001},
+		},
+		{
+			name:    "former spend limit name",
+			caveats: []string{"spendlimit 1000"},
+			req:     Request{Permission: PermSend, Spend: 1001},
+		},
+		{
+			name:    "unknown spend under spend limit",
+			caveats: []string{TxSpendLimitCaveat(btcutil.Amount(5000))},
+			req: Request{
+				Permission:   PermSend,
+				SpendUnknown: true,
+			},
+		},
+		{
+			name:    "unknown spend without spend limit",
+			caveats: []string{PermissionsCaveat(PermSend)},
+			req: Request{
+				Permission:   PermSend,
+				SpendUnknown: true,
+			},
+			allowed: true,
+		},
+		{
+			name:    "not expired",
+			caveats: []string{ExpiryCaveat(now.Add(time.Hour))},
+			req:     Request{Permission: PermRead},
+			allowed: true,
+		},
+		{
+			name:    "expired",
+			caveats: []string{ExpiryCaveat(now.Add(-time.Hour))},
+			req:     Request{Permission: PermRead},
+		},
+	}
+
+	for _, test := range tests {
+		r, err := ParseCaveats(test.caveats)
+		if err != nil {
+			t.Fatalf("%s: unable to parse caveats: %v", test.name,
+				err)
+		}
+		err = r.Allow(&test.req, now)
+		switch {
+		case test.allowed && err != nil:
+			t.Errorf("%s: request denied: %v", test.name, err)
+		case !test.allowed && !errors.Is(err, ErrPermissionDenied):
+			t.Errorf("%s: expected ErrPermissionDenied, got %v",
+				test.name, err)
+		}
+	}
+
+	if _, err := ParseCaveats([]string{"ipaddr 127.0.0.1"}); err == nil {
+		t.Errorf("unknown caveat was accepted")
+	}
+}
+
+// testOwner owns the addresses of its scripts.
+type testOwner map[string]bool
+
+func (o testOwner) HaveAddress(addr btcutil.Address) (bool, error) {
+	return o[addr.EncodeAddress()], nil
+}
+
+// TestExternalSpend checks that the fee paid by a transaction is counted as
+// spent along with its outputs to addresses outside of the wallet.
+func TestExternalSpend(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	newScript := func(b byte) ([]byte, btcutil.Address) {
+		addr, err := btcutil.NewAddressWitnessPubKeyHash(
+			bytes.Repeat([]byte{b}, 20), params,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return script, addr
+	}
+	changeScript, changeAddr := newScript(1)
+	externalScript, _ := newScript(2)
+	owner := testOwner{changeAddr.EncodeAddress(): true}
+
+	// The transaction pays 1000 to an external address and the rest of
+	// its 100000 input to the wallet, save for a 50000 fee.
+	prevOut := wire.OutPoint{Index: 1}
+	tx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{PreviousOutPoint: prevOut}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(1000, externalScript),
+			wire.NewTxOut(49000, changeScript),
+		},
+	}
+	prevOuts := txscript.NewMultiPrevOutFetcher(
+		map[wire.OutPoint]*wire.TxOut{
+			prevOut: wire.NewTxOut(100000, changeScript),
+		},
+	)
+	spend, err := ExternalSpend(tx, prevOuts, owner, params)
+	if err != nil {
+		t.Fatalf("unable to compute spend: %v", err)
+	}
+	if spend != 51000 {
+		t.Errorf("spend %v, want %v", spend, btcutil.Amount(51000))
+	}
+
+	// A spend limit can't be checked without the values of the inputs.
+	noPrevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	_, err = ExternalSpend(tx, noPrevOuts, owner, params)
+	if !errors.Is(err, ErrUnknownInputs) {
+		t.Errorf("expected ErrUnknownInputs, got %v", err)
+	}
+
+	// The inputs of a PSBT are described by their UTXOs.
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet.Inputs[0].WitnessUtxo = wire.NewTxOut(100000, changeScript)
+	spend, err = ExternalSpend(
+		tx, PsbtPrevOuts(packet, noPrevOuts), owner, params,
+	)
+	if err != nil {
+		t.Fatalf("unable to compute PSBT spend: %v", err)
+	}
+	if spend != 51000 {
+		t.Errorf("PSBT spend %v, want %v", spend,
+			btcutil.Amount(51000))
+	}
+}
+
+// TestService checks the default macaroons written by a service.
+func TestService(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenService(dir)
+	if err != nil {
+		t.Fatalf("unable to open service: %v", err)
+	}
+	if err := s.WriteDefaultMacaroons(dir); err != nil {
+		t.Fatalf("unable to write macaroons: %v", err)
+	}
+
+	// Reopening the service must keep the root key.
+	s, err = OpenService(dir)
+	if err != nil {
+		t.Fatalf("unable to reopen service: %v", err)
+	}
+	readOnly, err := ReadFile(filepath.Join(dir, ReadOnlyFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Authorize(readOnly, &Request{Permission: PermRead})
+	if err != nil {
+		t.Fatalf("read-only macaroon denied read: %v", err)
+	}
+	err = s.Authorize(readOnly, &Request{Permission: PermSend})
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("read-only macaroon allowed send: %v", err)
+	}
+
+	admin, err := ReadFile(filepath.Join(dir, AdminFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	restricted := admin.WithCaveat(PermissionsCaveat(PermRead))
+	err = s.Authorize(restricted, &Request{Permission: PermAdmin})
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("attenuated admin macaroon allowed admin: %v", err)
+	}
+}
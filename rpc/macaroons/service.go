@@ -0,0 +1,253 @@
+package macaroons
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// RootKeyFilename is the name of the file holding the root key.
+	RootKeyFilename = "macaroons.key"
+
+	// AdminFilename is the name of the file of the default macaroon with
+	// all permissions.
+	AdminFilename = "admin.macaroon"
+
+	// ReadOnlyFilename is the name of the file of the default macaroon
+	// that may only query the wallet.
+	ReadOnlyFilename = "readonly.macaroon"
+
+	rootKeyLen = 32
+	idLen      = 16
+)
+
+// Service creates and checks the macaroons of a root key.
+type Service struct {
+	rootKey []byte
+}
+
+// NewService creates a service for the root key.
+func NewService(rootKey []byte) *Service {
+	return &Service{rootKey: append([]byte(nil), rootKey...)}
+}
+
+// OpenService opens the root key in the directory, creating a new random
+// root key if none exists.  Removing the root key file revokes all
+// macaroons created with it.
+func OpenService(dir string) (*Service, error) {
+	path := filepath.Join(dir, RootKeyFilename)
+	rootKey, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if len(rootKey) != rootKeyLen {
+			return nil, fmt.Errorf("root key %s has invalid "+
+				"length %d", path, len(rootKey))
+		}
+		return NewService(rootKey), nil
+
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	rootKey = make([]byte, rootKeyLen)
+	if _, err := rand.Read(rootKey); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(path, rootKey, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return NewService(rootKey), nil
+}
+
+// NewMacaroon creates a macaroon with a random identifier and the caveats.
+func (s *Service) NewMacaroon(caveats ...string) (*Macaroon, error) {
+	id := make([]byte, idLen)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	m := New(s.rootKey, id)
+	for _, caveat := range caveats {
+		m = m.WithCaveat(caveat)
+	}
+	return m, nil
+}
+
+// Restrictions verifies the macaroon and returns the restrictions of its
+// caveats.
+func (s *Service) Restrictions(m *Macaroon) (*Restrictions, error) {
+	if err := m.Verify(s.rootKey); err != nil {
+		return nil, err
+	}
+	return ParseCaveats(m.caveats)
+}
+
+// Authorize checks that the macaroon is valid and allows the request.
+func (s *Service) Authorize(m *Macaroon, req *Request) error {
+	r, err := s.Restrictions(m)
+	if err != nil {
+		return err
+	}
+	return r.Allow(req, time.Now())
+}
+
+// AuthorizeTx checks that the macaroon allows the transaction, signed or
+// about to be published on behalf of a request of the method, under its
+// transaction spend limit.  The spent amount is found by ExternalSpend.
+func (s *Service) AuthorizeTx(m *Macaroon, method string, tx *wire.MsgTx,
+	prevOuts txscript.PrevOutputFetcher, owner AddressOwner,
+	params *chaincfg.Params) error {
+
+	r, err := s.Restrictions(m)
+	if err != nil {
+		return err
+	}
+	spend, err := ExternalSpend(tx, prevOuts, owner, params)
+	spendUnknown := errors.Is(err, ErrUnknownInputs)
+	if err != nil && !spendUnknown {
+		return err
+	}
+	return r.AllowSpend(method, spend, spendUnknown)
+}
+
+// WriteDefaultMacaroons writes the admin and read-only macaroons to the
+// directory, unless they exist already.
+func (s *Service) WriteDefaultMacaroons(dir string) error {
+	defaults := []struct {
+		filename string
+		caveats  []string
+	}{
+		{AdminFilename, nil},
+		{ReadOnlyFilename, []string{PermissionsCaveat(PermRead)}},
+	}
+	for _, d := range defaults {
+		path := filepath.Join(dir, d.filename)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		m, err := s.NewMacaroon(d.caveats...)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(path, []byte(m.String()), 0600)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddressOwner reports whether an address belongs to a wallet.
+type AddressOwner interface {
+	HaveAddress(btcutil.Address) (bool, error)
+}
+
+// ErrUnknownInputs is returned by ExternalSpend when the value of an output
+// spent by the transaction is unknown, so that the fee it pays can't be
+// determined.
+var ErrUnknownInputs = errors.New("value of spent outputs is unknown")
+
+// ExternalSpend returns the value of the outputs of the transaction that
+// don't pay to the wallet plus the fee it pays, which is the amount spent by
+// the transaction as far as transaction spend limits are concerned.  The values of the
+// outputs it spends are fetched from prevOuts.  If one of them is unknown,
+// the amount paid to addresses outside of the wallet is returned with
+// ErrUnknownInputs.  A transaction without inputs pays no fee.
+func ExternalSpend(tx *wire.MsgTx, prevOuts txscript.PrevOutputFetcher,
+	owner AddressOwner, params *chaincfg.Params) (btcutil.Amount, error) {
+
+	var spend, outputValue btcutil.Amount
+	for _, txOut := range tx.TxOut {
+		outputValue += btcutil.Amount(txOut.Value)
+
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			txOut.PkScript, params,
+		)
+		if err == nil && len(addrs) == 1 {
+			mine, err := owner.HaveAddress(addrs[0])
+			if err != nil {
+				return 0, err
+			}
+			if mine {
+				continue
+			}
+		}
+		spend += btcutil.Amount(txOut.Value)
+	}
+
+	var inputValue btcutil.Amount
+	for _, txIn := range tx.TxIn {
+		prevOut := prevOuts.FetchPrevOutput(txIn.PreviousOutPoint)
+		if prevOut == nil {
+			return spend, ErrUnknownInputs
+		}
+		inputValue += btcutil.Amount(prevOut.Value)
+	}
+	if len(tx.TxIn) != 0 && inputValue > outputValue {
+		spend += inputValue - outputValue
+	}
+	return spend, nil
+}
+
+// PsbtPrevOuts returns the outputs spent by the inputs of the PSBT, as
+// described by their witness or non-witness UTXOs.  The outputs spent by
+// inputs without them are fetched from fallback.
+func PsbtPrevOuts(packet *psbt.Packet,
+	fallback txscript.PrevOutputFetcher) txscript.PrevOutputFetcher {
+
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut)
+	for i, txIn := range packet.UnsignedTx.TxIn {
+		if i >= len(packet.Inputs) {
+			break
+		}
+		op := txIn.PreviousOutPoint
+		in := &packet.Inputs[i]
+		switch {
+		case in.WitnessUtxo != nil:
+			prevOuts[op] = in.WitnessUtxo
+
+		case in.NonWitnessUtxo != nil &&
+			in.NonWitnessUtxo.TxHash() == op.Hash &&
+			op.Index < uint32(len(in.NonWitnessUtxo.TxOut)):
+
+			prevOuts[op] = in.NonWitnessUtxo.TxOut[op.Index]
+		}
+	}
+	return &psbtPrevOuts{prevOuts: prevOuts, fallback: fallback}
+}
+
+type psbtPrevOuts struct {
+	prevOuts map[wire.OutPoint]*wire.TxOut
+	fallback txscript.PrevOutputFetcher
+}
+
+func (f *psbtPrevOuts) FetchPrevOutput(op wire.OutPoint) *wire.TxOut {
+	if prevOut, ok := f.prevOuts[op]; ok {
+		return prevOut
+	}
+	return f.fallback.FetchPrevOutput(op)
+}
+
+// ReadFile reads a hex encoded macaroon from a file, such as the default
+// macaroons written by WriteDefaultMacaroons.
+func ReadFile(path string) (*Macaroon, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(strings.TrimSpace(string(b)))
+}
@@ -0,0 +1,157 @@
+// Package ratelimit limits the rate of RPC requests of each caller to each
+// method with token buckets.
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AnyMethod is the method of rules applying to methods without a rule of
+// their own.
+const AnyMethod = "*"
+
+// maxBuckets is the number of buckets above which buckets that are full, and
+// therefore equivalent to new buckets, are removed.
+const maxBuckets = 10000
+
+// Rule limits the rate of requests of each caller to a method.
+type Rule struct {
+	// Method is the method the rule applies to, or AnyMethod.  Methods of
+	// the gRPC server may be given by their full or their short name.
+	Method string
+
+	// Rate is the sustained number of requests allowed per second.
+	Rate float64
+
+	// Burst is the number of requests allowed at once.
+	Burst int
+}
+
+// ParseRule parses a rule in the form method:rate[:burst].  The burst
+// defaults to the rate rounded up, and at least one.
+func ParseRule(s string) (Rule, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return Rule{}, fmt.Errorf("invalid rate limit %q: expected "+
+			"method:rate[:burst]", s)
+	}
+	rate, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || rate <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q: rate must "+
+			"be a positive number of requests per second", s)
+	}
+	burst := int(rate)
+	if float64(burst) < rate {
+		burst++
+	}
+	if len(parts) == 3 {
+		burst, err = strconv.Atoi(parts[2])
+		if err != nil || burst < 1 {
+			return Rule{}, fmt.Errorf("invalid rate limit %q: "+
+				"burst must be a positive integer", s)
+		}
+	}
+	return Rule{Method: parts[0], Rate: rate, Burst: burst}, nil
+}
+
+// bucketKey identifies the bucket of a caller and a method.
+type bucketKey struct {
+	caller string
+	method string
+}
+
+// bucket is a token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter limits the rate of requests of each caller to each method.  A nil
+// Limiter allows all requests.
+type Limiter struct {
+	rules map[string]Rule
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+// New creates a limiter of the rules.
+func New(rules []Rule) *Limiter {
+	l := &Limiter{
+		rules:   make(map[string]Rule, len(rules)),
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+	for _, rule := range rules {
+		l.rules[rule.Method] = rule
+	}
+	return l
+}
+
+// rule returns the rule of the method.
+func (l *Limiter) rule(method string) (Rule, bool) {
+	if rule, ok := l.rules[method]; ok {
+		return rule, true
+	}
+	if i := strings.LastIndexByte(method, '/'); i >= 0 {
+		if rule, ok := l.rules[method[i+1:]]; ok {
+			return rule, true
+		}
+	}
+	rule, ok := l.rules[AnyMethod]
+	return rule, ok
+}
+
+// Allow reports whether the caller may make a request to the method now, and
+// takes a token from its bucket if so.  Requests of the same caller to
+// different methods are limited independently.
+func (l *Limiter) Allow(caller, method string) bool {
+	if l == nil {
+		return true
+	}
+	rule, ok := l.rule(method)
+	if !ok {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	key := bucketKey{caller: caller, method: method}
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			l.prune(now)
+		}
+		b = &bucket{tokens: float64(rule.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * rule.Rate
+	if b.tokens > float64(rule.Burst) {
+		b.tokens = float64(rule.Burst)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune removes the buckets that have refilled.  The mutex must be held.
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		rule, _ := l.rule(key.method)
+		tokens := b.tokens + now.Sub(b.last).Seconds()*rule.Rate
+		if tokens >= float64(rule.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseRule checks the parsing of rate limits.
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		s     string
+		rule  Rule
+		valid bool
+	}{
+		{"sendtoaddress:0.5", Rule{"sendtoaddress", 0.5, 1}, true},
+		{"*:10:20", Rule{"*", 10, 20}, true},
+		{"getinfo:2.5", Rule{"getinfo", 2.5, 3}, true},
+		{"getinfo", Rule{}, false},
+		{":1", Rule{}, false},
+		{"getinfo:0", Rule{}, false},
+		{"getinfo:1:0", Rule{}, false},
+		{"getinfo:1:2:3", Rule{}, false},
+	}
+	for _, test := range tests {
+		rule, err := ParseRule(test.s)
+		switch {
+		case test.valid && err != nil:
+			t.Errorf("%q: %v", test.s, err)
+		case !test.valid && err == nil:
+			t.Errorf("%q: invalid rule accepted", test.s)
+		case rule != test.rule:
+			t.Errorf("%q: got %+v, want %+v", test.s, rule, test.rule)
+		}
+	}
+}
+
+// TestLimiter checks that callers and methods are limited independently and
+// that buckets refill over time.
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := New([]Rule{
+		{Method: "sendtoaddress", Rate: 1, Burst: 2},
+		{Method: "CreateTransaction", Rate: 1, Burst: 1},
+		{Method: AnyMethod, Rate: 100, Burst: 100},
+	})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if !l.Allow("alice", "sendtoaddress") {
+			t.Fatalf("request %d within burst denied", i)
+		}
+	}
+	if l.Allow("alice", "sendtoaddress") {
+		t.Fatalf("request over burst allowed")
+	}
+	if !l.Allow("bob", "sendtoaddress") {
+		t.Fatalf("request of other caller denied")
+	}
+	if !l.Allow("alice", "getinfo") {
+		t.Fatalf("request to other method denied")
+	}
+
+	now = now.Add(time.Second)
+	if !l.Allow("alice", "sendtoaddress") {
+		t.Fatalf("request after refill denied")
+	}
+	if l.Allow("alice", "sendtoaddress") {
+		t.Fatalf("refill exceeded the rate")
+	}
+
+	const method = "/walletrpc.v2.WalletService/CreateTransaction"
+	if !l.Allow("alice", method) || l.Allow("alice", method) {
+		t.Fatalf("short method name rule not applied")
+	}
+
+	var nilLimiter *Limiter
+	if !nilLimiter.Allow("alice", "sendtoaddress") {
+		t.Fatalf("nil limiter denied request")
+	}
+}
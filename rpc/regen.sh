@@ -1,3 +1,12 @@
 #!/bin/sh
 
-protoc -I. api.proto --go_out=plugins=grpc:walletrpc
+# The HTTP annotations of api.proto are defined in the googleapis protos
+# vendored by grpc-gateway.  The REST gateway and its OpenAPI specification are
+# generated by protoc-gen-grpc-gateway and protoc-gen-swagger of the same
+# version as the grpc-gateway module in go.mod.
+GOOGLEAPIS=$(go list -m -f '{{.Dir}}' github.com/grpc-ecosystem/grpc-gateway)/third_party/googleapis
+
+protoc -I. -I"$GOOGLEAPIS" api.proto --go_out=plugins=grpc:walletrpc \
+	--grpc-gateway_out=logtostderr=true:walletrpc \
+	--swagger_out=logtostderr=true:walletrpc
+protoc -I. v2/api.proto --go_out=plugins=grpc,paths=source_relative:walletrpc
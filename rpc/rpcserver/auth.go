@@ -0,0 +1,396 @@
+package rpcserver
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+	pb "github.com/stroomnetwork/btcwallet/rpc/walletrpc"
+	pbv2 "github.com/stroomnetwork/btcwallet/rpc/walletrpc/v2"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// MacaroonMetadataKey is the gRPC metadata key of the hex encoded macaroon
+// clients authenticate with.
+const MacaroonMetadataKey = "macaroon"
+
+// methodPermission is the permission required by a gRPC method, and whether
+// the method concerns every account of the wallet.
+type methodPermission struct {
+	perm        macaroons.Permission
+	allAccounts bool
+}
+
+// methodPermissions describes every gRPC method for checking it against the
+// caveats of macaroons.  Methods missing from the table are denied.  The
+// accounts and amounts of single requests are found by describeRequest.
+var methodPermissions = map[string]methodPermission{
+	"/walletrpc.VersionService/Version": {perm: macaroons.PermRead},
+
+	"/walletrpc.WalletLoaderService/WalletExists":      {perm: macaroons.PermRead},
+	"/walletrpc.WalletLoaderService/CreateWallet":      {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.WalletLoaderService/OpenWallet":        {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.WalletLoaderService/CloseWallet":       {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.WalletLoaderService/StartConsensusRpc": {perm: macaroons.PermAdmin, allAccounts: true},
+
+	"/walletrpc.WalletService/Ping":                      {perm: macaroons.PermRead},
+	"/walletrpc.WalletService/Network":                   {perm: macaroons.PermRead},
+	"/walletrpc.WalletService/AccountNumber":             {perm: macaroons.PermRead},
+	"/walletrpc.WalletService/Accounts":                  {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.WalletService/Balance":                   {perm: macaroons.PermRead},
+	"/walletrpc.WalletService/GetTransactions":           {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.WalletService/TransactionNotifications":  {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.WalletService/SpentnessNotifications":    {perm: macaroons.PermRead},
+	"/walletrpc.WalletService/AccountNotifications":      {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.WalletService/ConfirmationNotifications": {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.WalletService/NextAddress":               {perm: macaroons.PermAddress},
+	"/walletrpc.WalletService/SignTransaction":           {perm: macaroons.PermSign, allAccounts: true},
+	"/walletrpc.WalletService/FundTransaction":           {perm: macaroons.PermSend},
+	"/walletrpc.WalletService/PublishTransaction":        {perm: macaroons.PermSend, allAccounts: true},
+	"/walletrpc.WalletService/RenameAccount":             {perm: macaroons.PermAdmin},
+	"/walletrpc.WalletService/NextAccount":               {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.WalletService/ImportPrivateKey":          {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.WalletService/ChangePassphrase":          {perm: macaroons.PermAdmin, allAccounts: true},
+
+	"/walletrpc.v2.WalletService/Ping":                      {perm: macaroons.PermRead},
+	"/walletrpc.v2.WalletService/Network":                   {perm: macaroons.PermRead},
+	"/walletrpc.v2.WalletService/Accounts":                  {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/Balance":                   {perm: macaroons.PermRead},
+	"/walletrpc.v2.WalletService/ListUnspent":               {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/ListLeasedOutputs":         {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/GetTransaction":            {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/RescanStatus":              {perm: macaroons.PermRead},
+	"/walletrpc.v2.WalletService/TransactionNotifications":  {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/SpentnessNotifications":    {perm: macaroons.PermRead},
+	"/walletrpc.v2.WalletService/AccountNotifications":      {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/ConfirmationNotifications": {perm: macaroons.PermRead, allAccounts: true},
+	"/walletrpc.v2.WalletService/NextAddress":               {perm: macaroons.PermAddress},
+	"/walletrpc.v2.WalletService/SignMessage":               {perm: macaroons.PermSign},
+	"/walletrpc.v2.WalletService/FinalizePsbt":              {perm: macaroons.PermSign},
+	"/walletrpc.v2.WalletService/FundPsbt":                  {perm: macaroons.PermSend},
+	"/walletrpc.v2.WalletService/CreateTransaction":         {perm: macaroons.PermSend},
+	"/walletrpc.v2.WalletService/SendOutputs":               {perm: macaroons.PermSend},
+	"/walletrpc.v2.WalletService/SignTransaction":           {perm: macaroons.PermSign, allAccounts: true},
+	"/walletrpc.v2.WalletService/LeaseOutput":               {perm: macaroons.PermSend, allAccounts: true},
+	"/walletrpc.v2.WalletService/ReleaseOutput":             {perm: macaroons.PermSend, allAccounts: true},
+	"/walletrpc.v2.WalletService/PublishTransaction":        {perm: macaroons.PermSend, allAccounts: true},
+	"/walletrpc.v2.WalletService/LabelTransaction":          {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/ChangePassphrase":          {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/CreateAccount":             {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/RenameAccount":             {perm: macaroons.PermAdmin},
+	"/walletrpc.v2.WalletService/ImportAccount":             {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/ImportPrivateKey":          {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/ImportPublicKey":           {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/ImportTaprootScript":       {perm: macaroons.PermAdmin, allAccounts: true},
+	"/walletrpc.v2.WalletService/Rescan":                    {perm: macaroons.PermAdmin, allAccounts: true},
+}
+
+// Authenticator checks the macaroons of gRPC requests.
+type Authenticator struct {
+	service *macaroons.Service
+	wallets *wallet.MultiLoader
+}
+
+// NewAuthenticator creates an authenticator of macaroons of the service.  The
+// wallet of a request, loaded by the loader, is used to find the accounts and
+// spent amounts of the request.
+func NewAuthenticator(service *macaroons.Service,
+	wallets *wallet.MultiLoader) *Authenticator {
+
+	return &Authenticator{service: service, wallets: wallets}
+}
+
+// walletSelector is implemented by the requests of version 2 of the wallet
+// service, which select the wallet they are made to.
+type walletSelector interface {
+	GetWallet() string
+}
+
+// requestWalletName returns the name of the wallet a request is made to.
+// Requests without a wallet field are made to the default wallet.
+func requestWalletName(req interface{}) string {
+	if ws, ok := req.(walletSelector); ok {
+		return ws.GetWallet()
+	}
+	return wallet.DefaultWalletName
+}
+
+// UnaryServerInterceptor returns the interceptor authorizing unary requests.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		mac, err := macaroonFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.authorize(mac, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		ctx = a.spendContext(ctx, mac, info.FullMethod, req)
+		return handler(context.WithValue(ctx, macaroonKey{}, mac), req)
+	}
+}
+
+// StreamServerInterceptor returns the interceptor authorizing streaming
+// requests.  Every message received from the client is authorized.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		mac, err := macaroonFromContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		if _, ok := methodPermissions[info.FullMethod]; !ok {
+			return status.Errorf(codes.PermissionDenied,
+				"%s is not allowed with macaroons",
+				info.FullMethod)
+		}
+		ctx := context.WithValue(ss.Context(), macaroonKey{}, mac)
+		return handler(srv, &authorizedStream{
+			ServerStream: ss,
+			ctx:          ctx,
+			auth:         a,
+			mac:          mac,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+// authorizedStream is a server stream authorizing every received message.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	auth   *Authenticator
+	mac    *macaroons.Macaroon
+	method string
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.auth.authorize(s.mac, s.method, m)
+}
+
+// spendContext makes the wallet of the request check the transactions it
+// publishes for the request against the transaction spend limit of the
+// macaroon.
+func (a *Authenticator) spendContext(ctx context.Context,
+	mac *macaroons.Macaroon, method string,
+	req interface{}) context.Context {
+
+	w, ok := a.wallets.Wallet(requestWalletName(req))
+	if !ok {
+		return ctx
+	}
+	return wallet.WithSpendCheck(ctx, func(tx *wire.MsgTx) error {
+		return a.service.AuthorizeTx(
+			mac, method, tx, w, w, w.ChainParams(),
+		)
+	})
+}
+
+// macaroonKey is the context key of the macaroon of an authorized request.
+type macaroonKey struct{}
+
+// authorizedMacaroon returns the macaroon an authorized request was
+// authenticated with, if any.
+func authorizedMacaroon(ctx context.Context) (*macaroons.Macaroon, bool) {
+	mac, ok := ctx.Value(macaroonKey{}).(*macaroons.Macaroon)
+	return mac, ok
+}
+
+// macaroonFromContext decodes the macaroon of the metadata of the request.
+func macaroonFromContext(ctx context.Context) (*macaroons.Macaroon, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MacaroonMetadataKey)
+	if len(values) != 1 {
+		return nil, status.Errorf(codes.Unauthenticated,
+			"expected 1 macaroon, got %d", len(values))
+	}
+	mac, err := macaroons.Decode(strings.TrimSpace(values[0]))
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	return mac, nil
+}
+
+// authorize checks that the caveats of the macaroon allow the request.
+func (a *Authenticator) authorize(mac *macaroons.Macaroon, method string,
+	req interface{}) error {
+
+	restrictions, err := a.service.Restrictions(mac)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	mp, ok := methodPermissions[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied,
+			"%s is not allowed with macaroons", method)
+	}
+	mreq := &macaroons.Request{
+		Method:      method,
+		Permission:  mp.perm,
+		AllAccounts: mp.allAccounts,
+		Wallet:      requestWalletName(req),
+	}
+	w, _ := a.wallets.Wallet(mreq.Wallet)
+	if err := describeRequest(mreq, req, w); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return translateError(err)
+	}
+	err = restrictions.Allow(mreq, time.Now())
+	if errors.Is(err, macaroons.ErrPermissionDenied) {
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+	return err
+}
+
+// describeRequest sets the accounts and spent amount of the request.  The
+// wallet is nil before it is loaded.
+func describeRequest(mreq *macaroons.Request, req interface{},
+	w *wallet.Wallet) error {
+
+	addAccount := func(account uint32) {
+		mreq.Accounts = append(mreq.Accounts, account)
+	}
+	addSpend := func(tx *wire.MsgTx,
+		prevOuts txscript.PrevOutputFetcher) error {
+
+		spend, err := macaroons.ExternalSpend(
+			tx, prevOuts, w, w.ChainParams(),
+		)
+		switch {
+		case errors.Is(err, macaroons.ErrUnknownInputs):
+			mreq.SpendUnknown = true
+		case err != nil:
+			return err
+		}
+		mreq.Spend += spend
+		return nil
+	}
+	addOutputs := func(outputs []*wire.TxOut) error {
+		if w == nil {
+			return status.Errorf(codes.FailedPrecondition,
+				"wallet is not loaded")
+		}
+		return addSpend(&wire.MsgTx{TxOut: outputs}, w)
+	}
+	addTx := func(serializedTx []byte) error {
+		var tx wire.MsgTx
+		err := tx.Deserialize(bytes.NewReader(serializedTx))
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument,
+				"Bytes do not represent a valid raw "+
+					"transaction: %v", err)
+		}
+		if w == nil {
+			return status.Errorf(codes.FailedPrecondition,
+				"wallet is not loaded")
+		}
+		return addSpend(&tx, w)
+	}
+	addPsbt := func(b []byte) error {
+		packet, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument,
+				"Bytes do not represent a valid PSBT: %v", err)
+		}
+		if w == nil {
+			return status.Errorf(codes.FailedPrecondition,
+				"wallet is not loaded")
+		}
+		return addSpend(
+			packet.UnsignedTx, macaroons.PsbtPrevOuts(packet, w),
+		)
+	}
+
+	switch r := req.(type) {
+	case *pb.BalanceRequest:
+		addAccount(r.AccountNumber)
+	case *pb.SpentnessNotificationsRequest:
+		addAccount(r.Account)
+	case *pb.NextAddressRequest:
+		addAccount(r.Account)
+	case *pb.RenameAccountRequest:
+		addAccount(r.AccountNumber)
+	case *pb.FundTransactionRequest:
+		addAccount(r.Account)
+		mreq.Spend = btcutil.Amount(r.TargetAmount)
+	case *pb.SignTransactionRequest:
+		return addTx(r.SerializedTransaction)
+	case *pb.PublishTransactionRequest:
+		return addTx(r.SignedTransaction)
+
+	case *pbv2.BalanceRequest:
+		if r.AllAccounts {
+			mreq.AllAccounts = true
+		} else {
+			addAccount(r.AccountNumber)
+		}
+	case *pbv2.SpentnessNotificationsRequest:
+		addAccount(r.Account)
+	case *pbv2.RenameAccountRequest:
+		addAccount(r.AccountNumber)
+	case *pbv2.NextAddressRequest:
+		addAccount(r.Account)
+	case *pbv2.SignMessageRequest:
+		if w == nil {
+			return status.Errorf(codes.FailedPrecondition,
+				"wallet is not loaded")
+		}
+		addr, err := btcutil.DecodeAddress(r.Address, w.ChainParams())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument,
+				"address: %v", err)
+		}
+		account, err := w.AccountOfAddress(addr)
+		if err != nil {
+			return err
+		}
+		addAccount(account)
+	case *pbv2.FinalizePsbtRequest:
+		addAccount(r.Account)
+		return addPsbt(r.Psbt)
+	case *pbv2.FundPsbtRequest:
+		addAccount(r.Account)
+		return addPsbt(r.Psbt)
+	case *pbv2.CreateTransactionRequest:
+		addAccount(r.Account)
+		outputs := make([]*wire.TxOut, len(r.Outputs))
+		for i, output := range r.Outputs {
+			outputs[i] = wire.NewTxOut(output.Amount, output.PkScript)
+		}
+		return addOutputs(outputs)
+	case *pbv2.SendOutputsRequest:
+		// The transaction is checked against the spend limit when it
+		// is published.
+		addAccount(r.Account)
+	case *pbv2.SignTransactionRequest:
+		return addTx(r.SerializedTransaction)
+	case *pbv2.PublishTransactionRequest:
+		return addTx(r.SignedTransaction)
+	}
+	return nil
+}
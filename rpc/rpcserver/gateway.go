@@ -0,0 +1,249 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/stroomnetwork/btcwallet/rpc/walletrpc"
+	"github.com/stroomnetwork/btcwallet/tracing"
+)
+
+const (
+	// GatewayOpenAPIPath is the path of the OpenAPI specification of the
+	// REST gateway.
+	GatewayOpenAPIPath = "/v1/openapi.json"
+
+	// gatewayNotificationsPath is the path prefix of the notification
+	// streams of the REST gateway.
+	gatewayNotificationsPath = "/v1/notifications/"
+
+	// gatewayMacaroonHeader is the HTTP header of the hex encoded macaroon
+	// of a REST request.
+	gatewayMacaroonHeader = "Macaroon"
+)
+
+// notificationStream opens a notification stream of the WalletService with
+// the request parsed from the query parameters, and returns the function
+// receiving its notifications.
+type notificationStream func(ctx context.Context, c pb.WalletServiceClient,
+	params url.Values) (func() (proto.Message, error), error)
+
+// notificationStreams maps the names of the notification streams of the REST
+// gateway, which are the last element of their paths, to their methods.
+var notificationStreams = map[string]notificationStream{
+	"transactions": func(ctx context.Context, c pb.WalletServiceClient,
+		params url.Values) (func() (proto.Message, error), error) {
+
+		req := new(pb.TransactionNotificationsRequest)
+		if err := populateQueryParameters(req, params); err != nil {
+			return nil, err
+		}
+		stream, err := c.TransactionNotifications(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (proto.Message, error) { return stream.Recv() }, nil
+	},
+	"spentness": func(ctx context.Context, c pb.WalletServiceClient,
+		params url.Values) (func() (proto.Message, error), error) {
+
+		req := new(pb.SpentnessNotificationsRequest)
+		if err := populateQueryParameters(req, params); err != nil {
+			return nil, err
+		}
+		stream, err := c.SpentnessNotifications(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (proto.Message, error) { return stream.Recv() }, nil
+	},
+	"accounts": func(ctx context.Context, c pb.WalletServiceClient,
+		params url.Values) (func() (proto.Message, error), error) {
+
+		req := new(pb.AccountNotificationsRequest)
+		if err := populateQueryParameters(req, params); err != nil {
+			return nil, err
+		}
+		stream, err := c.AccountNotifications(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (proto.Message, error) { return stream.Recv() }, nil
+	},
+	"confirmations": func(ctx context.Context, c pb.WalletServiceClient,
+		params url.Values) (func() (proto.Message, error), error) {
+
+		req := new(pb.ConfirmationNotificationsRequest)
+		if err := populateQueryParameters(req, params); err != nil {
+			return nil, err
+		}
+		stream, err := c.ConfirmationNotifications(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (proto.Message, error) { return stream.Recv() }, nil
+	},
+}
+
+func populateQueryParameters(msg proto.Message, params url.Values) error {
+	return runtime.PopulateQueryParameters(msg, params,
+		utilities.NewDoubleArray(nil))
+}
+
+// gateway serves the notification streams of the REST gateway.
+type gateway struct {
+	mux       *runtime.ServeMux
+	marshaler runtime.Marshaler
+	client    pb.WalletServiceClient
+}
+
+// NewGateway returns a REST/JSON gateway of the VersionService and version 1
+// of the WalletService.  Requests are forwarded over the client connection to
+// the gRPC server serving the services, so they are authenticated, limited
+// and audited like gRPC requests, and the macaroon of a request is read from
+// its Macaroon header.
+//
+// The routes of the gateway are given by the HTTP annotations of api.proto,
+// and its OpenAPI specification is served at GatewayOpenAPIPath.  As the
+// notification streams can't be annotated, they are served as server-sent
+// events at /v1/notifications/transactions, spentness, accounts and
+// confirmations, with the fields of their requests given as query
+// parameters.
+func NewGateway(ctx context.Context, conn *grpc.ClientConn) (http.Handler,
+	error) {
+
+	marshaler := &runtime.JSONPb{OrigName: true, EmitDefaults: true}
+	gwmux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+	)
+	err := pb.RegisterVersionServiceHandler(ctx, gwmux, conn)
+	if err != nil {
+		return nil, err
+	}
+	err = pb.RegisterWalletServiceHandler(ctx, gwmux, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &gateway{
+		mux:       gwmux,
+		marshaler: marshaler,
+		client:    pb.NewWalletServiceClient(conn),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", gwmux)
+	mux.HandleFunc(GatewayOpenAPIPath, serveOpenAPI)
+	mux.HandleFunc(gatewayNotificationsPath, g.serveNotifications)
+	return mux, nil
+}
+
+// gatewayHeaderMatcher forwards the macaroon and the trace context of a REST
+// request as gRPC metadata, in addition to the headers forwarded by default.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == gatewayMacaroonHeader {
+		return MacaroonMetadataKey, true
+	}
+	if tracing.IsPropagationHeader(key) {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func serveOpenAPI(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(pb.OpenAPISpec)
+}
+
+// serveNotifications streams the notifications of the stream named by the
+// request path as server-sent events, until the stream ends or the client
+// disconnects.  Each event is named after the stream, and its data is the
+// JSON encoding of the notification.  An error of the stream is sent as an
+// error event with its gRPC status code and message.
+func (g *gateway) serveNotifications(w http.ResponseWriter, req *http.Request) {
+	name := strings.TrimPrefix(req.URL.Path, gatewayNotificationsPath)
+	open, ok := notificationStreams[name]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported",
+			http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	ctx, err := runtime.AnnotateContext(ctx, g.mux, req)
+	if err != nil {
+		runtime.HTTPError(ctx, g.mux, g.marshaler, w, req, err)
+		return
+	}
+	recv, err := open(ctx, g.client, req.URL.Query())
+	if err != nil {
+		runtime.HTTPError(ctx, g.mux, g.marshaler, w, req, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		msg, err := recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				writeErrorEvent(w, err)
+				flusher.Flush()
+			}
+			return
+		}
+		data, err := g.marshaler.Marshal(msg)
+		if err != nil {
+			writeErrorEvent(w, err)
+			flusher.Flush()
+			return
+		}
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeErrorEvent writes an error event with the gRPC status of the error.
+func writeErrorEvent(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	data, _ := json.Marshal(struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+	}{int32(st.Code()), st.Message()})
+	_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+}
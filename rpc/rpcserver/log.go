@@ -23,8 +23,13 @@ import (
 	"github.com/btcsuite/btclog"
 )
 
+// log is the logger of the RPC handlers.  It is disabled until UseLogger is
+// called.
+var log = btclog.Disabled
+
 // UseLogger sets the logger to use for the gRPC server.
 func UseLogger(l btclog.Logger) {
+	log = l
 	grpclog.SetLogger(logger{l}) // nolint:staticcheck
 }
 
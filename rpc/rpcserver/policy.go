@@ -0,0 +1,163 @@
+package rpcserver
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+	"github.com/stroomnetwork/btcwallet/rpc/ratelimit"
+)
+
+// auditServer is the server name of audit log entries of the gRPC server.
+const auditServer = "grpc"
+
+// secretFields are substrings of the names of request fields that are
+// secrets and never written to the audit log.
+var secretFields = []string{"Passphrase", "PrivateKey", "Seed"}
+
+// RequestPolicy limits the rate of gRPC requests and records the requests
+// that may change the state of the wallet in an audit log.
+type RequestPolicy struct {
+	limiter  *ratelimit.Limiter
+	auditLog *audit.Log
+}
+
+// NewRequestPolicy creates a request policy.  Either the limiter or the audit
+// log may be nil to disable rate limits or auditing.
+func NewRequestPolicy(limiter *ratelimit.Limiter,
+	auditLog *audit.Log) *RequestPolicy {
+
+	return &RequestPolicy{limiter: limiter, auditLog: auditLog}
+}
+
+// UnaryServerInterceptor returns the interceptor applying the policy to unary
+// requests.
+func (p *RequestPolicy) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		caller, remoteAddr := requestCaller(ctx)
+		if !p.limiter.Allow(caller, info.FullMethod) {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"rate limit of %s exceeded", info.FullMethod)
+		}
+		resp, err := handler(ctx, req)
+		p.recordAudit(caller, remoteAddr, info.FullMethod, req, resp,
+			err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor applying the rate limits to
+// streaming requests.  Streams only send notifications and are not audited.
+func (p *RequestPolicy) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		caller, _ := requestCaller(ss.Context())
+		if !p.limiter.Allow(caller, info.FullMethod) {
+			return status.Errorf(codes.ResourceExhausted,
+				"rate limit of %s exceeded", info.FullMethod)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// requestCaller identifies the credentials and returns the network address of
+// the caller.  Callers are identified by the identifier of the macaroon they
+// were authorized with, or by their host without macaroons.  The interceptors
+// of the policy must therefore be chained after those of the Authenticator.
+func requestCaller(ctx context.Context) (string, string) {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	if mac, ok := authorizedMacaroon(ctx); ok {
+		return "macaroon:" + hex.EncodeToString(mac.ID()), remoteAddr
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "host:" + host, remoteAddr
+}
+
+// recordAudit appends a request that may change the state of the wallet to
+// the audit log, if enabled.  Results are only recorded for methods that send
+// transactions, as other results may contain secrets.
+func (p *RequestPolicy) recordAudit(caller, remoteAddr, method string,
+	req, resp interface{}, err error) {
+
+	if p.auditLog == nil {
+		return
+	}
+	mp, ok := methodPermissions[method]
+	if ok && (mp.perm == macaroons.PermRead ||
+		mp.perm == macaroons.PermAddress) {
+		return
+	}
+
+	entry := &audit.Entry{
+		Server:     auditServer,
+		Caller:     caller,
+		RemoteAddr: remoteAddr,
+		Method:     method,
+		Wallet:     requestWalletName(req),
+	}
+	var marshalErr error
+	if msg, ok := req.(proto.Message); ok {
+		entry.Params, marshalErr = json.Marshal(redactSecrets(msg))
+		if marshalErr != nil {
+			log.Errorf("Cannot marshal audited request: %v",
+				marshalErr)
+		}
+	}
+	switch {
+	case err != nil:
+		entry.Error = err.Error()
+	case mp.perm == macaroons.PermSend && resp != nil:
+		entry.Result, marshalErr = json.Marshal(resp)
+		if marshalErr != nil {
+			log.Errorf("Cannot marshal audited response: %v",
+				marshalErr)
+		}
+	}
+	if err := p.auditLog.Record(entry); err != nil {
+		log.Errorf("Cannot record %s request in the audit log: %v",
+			method, err)
+	}
+}
+
+// redactSecrets returns a copy of the request without its secret fields.
+func redactSecrets(msg proto.Message) proto.Message {
+	msg = proto.Clone(msg)
+	v := reflect.ValueOf(msg).Elem()
+	if v.Kind() != reflect.Struct {
+		return msg
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		for _, secret := range secretFields {
+			f := v.Field(i)
+			if strings.Contains(t.Field(i).Name, secret) && f.CanSet() {
+				f.Set(reflect.Zero(f.Type()))
+				break
+			}
+		}
+	}
+	return msg
+}
@@ -0,0 +1,213 @@
+package rpcserver
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/stroomnetwork/btcwallet/rpc/walletrpc"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// walletRouter serves the WalletService for the wallet of a loader.  Requests
+// are handled by the walletServer of the wallet loaded when they are made.
+type walletRouter struct {
+	loader *wallet.Loader
+}
+
+// StartWalletService creates an implementation of version 1 of the
+// WalletService serving the wallet of the loader and registers it with the
+// gRPC server.  As the wallet is looked up by each request, the service may
+// be registered before the wallet is loaded, and keeps serving it when it is
+// loaded again.  Version 1 only serves the default wallet, while version 2 is
+// started with StartWalletServiceV2.
+func StartWalletService(server *grpc.Server, loader *wallet.Loader) {
+	pb.RegisterWalletServiceServer(server, &walletRouter{loader})
+}
+
+// server returns the server of the loaded wallet.
+func (r *walletRouter) server() (*walletServer, error) {
+	w, ok := r.loader.LoadedWallet()
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"wallet is not loaded")
+	}
+	return &walletServer{w}, nil
+}
+
+func (r *walletRouter) Ping(ctx context.Context, req *pb.PingRequest) (
+	*pb.PingResponse, error) {
+
+	return &pb.PingResponse{}, nil
+}
+
+func (r *walletRouter) Network(ctx context.Context, req *pb.NetworkRequest) (
+	*pb.NetworkResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.Network(ctx, req)
+}
+
+func (r *walletRouter) AccountNumber(ctx context.Context, req *pb.AccountNumberRequest) (
+	*pb.AccountNumberResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.AccountNumber(ctx, req)
+}
+
+func (r *walletRouter) Accounts(ctx context.Context, req *pb.AccountsRequest) (
+	*pb.AccountsResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.Accounts(ctx, req)
+}
+
+func (r *walletRouter) Balance(ctx context.Context, req *pb.BalanceRequest) (
+	*pb.BalanceResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.Balance(ctx, req)
+}
+
+func (r *walletRouter) GetTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (
+	*pb.GetTransactionsResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransactions(ctx, req)
+}
+
+func (r *walletRouter) ChangePassphrase(ctx context.Context, req *pb.ChangePassphraseRequest) (
+	*pb.ChangePassphraseResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.ChangePassphrase(ctx, req)
+}
+
+func (r *walletRouter) RenameAccount(ctx context.Context, req *pb.RenameAccountRequest) (
+	*pb.RenameAccountResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.RenameAccount(ctx, req)
+}
+
+func (r *walletRouter) NextAccount(ctx context.Context, req *pb.NextAccountRequest) (
+	*pb.NextAccountResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.NextAccount(ctx, req)
+}
+
+func (r *walletRouter) NextAddress(ctx context.Context, req *pb.NextAddressRequest) (
+	*pb.NextAddressResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.NextAddress(ctx, req)
+}
+
+func (r *walletRouter) ImportPrivateKey(ctx context.Context, req *pb.ImportPrivateKeyRequest) (
+	*pb.ImportPrivateKeyResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportPrivateKey(ctx, req)
+}
+
+func (r *walletRouter) FundTransaction(ctx context.Context, req *pb.FundTransactionRequest) (
+	*pb.FundTransactionResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.FundTransaction(ctx, req)
+}
+
+func (r *walletRouter) SignTransaction(ctx context.Context, req *pb.SignTransactionRequest) (
+	*pb.SignTransactionResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.SignTransaction(ctx, req)
+}
+
+func (r *walletRouter) PublishTransaction(ctx context.Context, req *pb.PublishTransactionRequest) (
+	*pb.PublishTransactionResponse, error) {
+
+	s, err := r.server()
+	if err != nil {
+		return nil, err
+	}
+	return s.PublishTransaction(ctx, req)
+}
+
+func (r *walletRouter) TransactionNotifications(req *pb.TransactionNotificationsRequest,
+	svr pb.WalletService_TransactionNotificationsServer) error {
+
+	s, err := r.server()
+	if err != nil {
+		return err
+	}
+	return s.TransactionNotifications(req, svr)
+}
+
+func (r *walletRouter) SpentnessNotifications(req *pb.SpentnessNotificationsRequest,
+	svr pb.WalletService_SpentnessNotificationsServer) error {
+
+	s, err := r.server()
+	if err != nil {
+		return err
+	}
+	return s.SpentnessNotifications(req, svr)
+}
+
+func (r *walletRouter) AccountNotifications(req *pb.AccountNotificationsRequest,
+	svr pb.WalletService_AccountNotificationsServer) error {
+
+	s, err := r.server()
+	if err != nil {
+		return err
+	}
+	return s.AccountNotifications(req, svr)
+}
+
+func (r *walletRouter) ConfirmationNotifications(req *pb.ConfirmationNotificationsRequest,
+	svr pb.WalletService_ConfirmationNotificationsServer) error {
+
+	s, err := r.server()
+	if err != nil {
+		return err
+	}
+	return s.ConfirmationNotifications(req, svr)
+}
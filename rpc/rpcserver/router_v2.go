@@ -0,0 +1,348 @@
+package rpcserver
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/stroomnetwork/btcwallet/rpc/walletrpc/v2"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// walletRouterV2 serves version 2 of the WalletService for every wallet of a
+// MultiLoader.  Requests are handled by the walletServerV2 of the wallet
+// selected by their wallet field.
+type walletRouterV2 struct {
+	wallets *wallet.MultiLoader
+}
+
+// StartWalletServiceV2 creates an implementation of version 2 of the
+// WalletService serving the wallets of the loader and registers it with the
+// gRPC server.  As wallets are selected by each request, the service may be
+// registered before any wallet is loaded.
+func StartWalletServiceV2(server *grpc.Server, wallets *wallet.MultiLoader) {
+	pb.RegisterWalletServiceServer(server, &walletRouterV2{wallets})
+}
+
+// server returns the server of the named wallet.
+func (r *walletRouterV2) server(name string) (*walletServerV2, error) {
+	w, ok := r.wallets.Wallet(name)
+	if !ok {
+		if name == wallet.DefaultWalletName {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"wallet is not loaded")
+		}
+		return nil, status.Errorf(codes.NotFound,
+			"wallet %q is not loaded", name)
+	}
+	return &walletServerV2{w}, nil
+}
+
+func (r *walletRouterV2) Ping(ctx context.Context, req *pb.PingRequest) (
+	*pb.PingResponse, error) {
+
+	return &pb.PingResponse{}, nil
+}
+
+func (r *walletRouterV2) Network(ctx context.Context, req *pb.NetworkRequest) (
+	*pb.NetworkResponse, error) {
+
+	return &pb.NetworkResponse{
+		ActiveNetwork: uint32(r.wallets.ChainParams().Net),
+	}, nil
+}
+
+func (r *walletRouterV2) Accounts(ctx context.Context,
+	req *pb.AccountsRequest) (*pb.AccountsResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.Accounts(ctx, req)
+}
+
+func (r *walletRouterV2) Balance(ctx context.Context, req *pb.BalanceRequest) (
+	*pb.BalanceResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.Balance(ctx, req)
+}
+
+func (r *walletRouterV2) ListUnspent(ctx context.Context,
+	req *pb.ListUnspentRequest) (*pb.ListUnspentResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListUnspent(ctx, req)
+}
+
+func (r *walletRouterV2) ListLeasedOutputs(ctx context.Context,
+	req *pb.ListLeasedOutputsRequest) (*pb.ListLeasedOutputsResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListLeasedOutputs(ctx, req)
+}
+
+func (r *walletRouterV2) GetTransaction(ctx context.Context,
+	req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransaction(ctx, req)
+}
+
+func (r *walletRouterV2) RescanStatus(ctx context.Context,
+	req *pb.RescanStatusRequest) (*pb.RescanStatusResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.RescanStatus(ctx, req)
+}
+
+func (r *walletRouterV2) TransactionNotifications(
+	req *pb.TransactionNotificationsRequest,
+	svr pb.WalletService_TransactionNotificationsServer) error {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return err
+	}
+	return s.TransactionNotifications(req, svr)
+}
+
+func (r *walletRouterV2) SpentnessNotifications(
+	req *pb.SpentnessNotificationsRequest,
+	svr pb.WalletService_SpentnessNotificationsServer) error {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return err
+	}
+	return s.SpentnessNotifications(req, svr)
+}
+
+func (r *walletRouterV2) AccountNotifications(
+	req *pb.AccountNotificationsRequest,
+	svr pb.WalletService_AccountNotificationsServer) error {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return err
+	}
+	return s.AccountNotifications(req, svr)
+}
+
+func (r *walletRouterV2) ConfirmationNotifications(
+	req *pb.ConfirmationNotificationsRequest,
+	svr pb.WalletService_ConfirmationNotificationsServer) error {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return err
+	}
+	return s.ConfirmationNotifications(req, svr)
+}
+
+func (r *walletRouterV2) ChangePassphrase(ctx context.Context,
+	req *pb.ChangePassphraseRequest) (*pb.ChangePassphraseResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ChangePassphrase(ctx, req)
+}
+
+func (r *walletRouterV2) CreateAccount(ctx context.Context,
+	req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateAccount(ctx, req)
+}
+
+func (r *walletRouterV2) RenameAccount(ctx context.Context,
+	req *pb.RenameAccountRequest) (*pb.RenameAccountResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.RenameAccount(ctx, req)
+}
+
+func (r *walletRouterV2) NextAddress(ctx context.Context,
+	req *pb.NextAddressRequest) (*pb.NextAddressResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.NextAddress(ctx, req)
+}
+
+func (r *walletRouterV2) LeaseOutput(ctx context.Context,
+	req *pb.LeaseOutputRequest) (*pb.LeaseOutputResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.LeaseOutput(ctx, req)
+}
+
+func (r *walletRouterV2) ReleaseOutput(ctx context.Context,
+	req *pb.ReleaseOutputRequest) (*pb.ReleaseOutputResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ReleaseOutput(ctx, req)
+}
+
+func (r *walletRouterV2) CreateTransaction(ctx context.Context,
+	req *pb.CreateTransactionRequest) (*pb.CreateTransactionResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateTransaction(ctx, req)
+}
+
+func (r *walletRouterV2) SendOutputs(ctx context.Context,
+	req *pb.SendOutputsRequest) (*pb.SendOutputsResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.SendOutputs(ctx, req)
+}
+
+func (r *walletRouterV2) SignTransaction(ctx context.Context,
+	req *pb.SignTransactionRequest) (*pb.SignTransactionResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignTransaction(ctx, req)
+}
+
+func (r *walletRouterV2) FundPsbt(ctx context.Context,
+	req *pb.FundPsbtRequest) (*pb.FundPsbtResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.FundPsbt(ctx, req)
+}
+
+func (r *walletRouterV2) FinalizePsbt(ctx context.Context,
+	req *pb.FinalizePsbtRequest) (*pb.FinalizePsbtResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.FinalizePsbt(ctx, req)
+}
+
+func (r *walletRouterV2) PublishTransaction(ctx context.Context,
+	req *pb.PublishTransactionRequest) (*pb.PublishTransactionResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.PublishTransaction(ctx, req)
+}
+
+func (r *walletRouterV2) LabelTransaction(ctx context.Context,
+	req *pb.LabelTransactionRequest) (*pb.LabelTransactionResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.LabelTransaction(ctx, req)
+}
+
+func (r *walletRouterV2) SignMessage(ctx context.Context,
+	req *pb.SignMessageRequest) (*pb.SignMessageResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignMessage(ctx, req)
+}
+
+func (r *walletRouterV2) ImportAccount(ctx context.Context,
+	req *pb.ImportAccountRequest) (*pb.ImportAccountResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportAccount(ctx, req)
+}
+
+func (r *walletRouterV2) ImportPrivateKey(ctx context.Context,
+	req *pb.ImportPrivateKeyRequest) (*pb.ImportPrivateKeyResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportPrivateKey(ctx, req)
+}
+
+func (r *walletRouterV2) ImportPublicKey(ctx context.Context,
+	req *pb.ImportPublicKeyRequest) (*pb.ImportPublicKeyResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportPublicKey(ctx, req)
+}
+
+func (r *walletRouterV2) ImportTaprootScript(ctx context.Context,
+	req *pb.ImportTaprootScriptRequest) (*pb.ImportTaprootScriptResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportTaprootScript(ctx, req)
+}
+
+func (r *walletRouterV2) Rescan(ctx context.Context, req *pb.RescanRequest) (
+	*pb.RescanResponse, error) {
+
+	s, err := r.server(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	return s.Rescan(ctx, req)
+}
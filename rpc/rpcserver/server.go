@@ -32,22 +32,26 @@ import (
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
 	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/stroomnetwork/btcwallet/cfgutil"
 	"github.com/stroomnetwork/btcwallet/chain"
 	"github.com/stroomnetwork/btcwallet/internal/zero"
 	"github.com/stroomnetwork/btcwallet/netparams"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
 	pb "github.com/stroomnetwork/btcwallet/rpc/walletrpc"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
 	"github.com/stroomnetwork/btcwallet/wallet"
+	"github.com/stroomnetwork/btcwallet/wallet/txauthor"
 )
 
 // Public API version constants
 const (
-	semverString = "2.0.1"
+	semverString = "2.2.0"
 	semverMajor  = 2
-	semverMinor  = 0
-	semverPatch  = 1
+	semverMinor  = 2
+	semverPatch  = 0
 )
 
 // translateError creates a new gRPC error with an appropriate error code for
@@ -65,7 +69,8 @@ func errorCode(err error) codes.Code {
 	// waddrmgr.IsError is convenient, but not granular enough when the
 	// underlying error has to be checked.  Unwrap the underlying error
 	// if it exists.
-	if e, ok := err.(waddrmgr.ManagerError); ok {
+	var e waddrmgr.ManagerError
+	if errors.As(err, &e) {
 		// For these waddrmgr error codes, the underlying error isn't
 		// needed to determine the grpc error code.
 		switch e.ErrorCode {
@@ -77,21 +82,70 @@ func errorCode(err error) codes.Code {
 			return codes.InvalidArgument
 		case waddrmgr.ErrDuplicateAccount:
 			return codes.AlreadyExists
+		case waddrmgr.ErrDuplicateAddress, waddrmgr.ErrAlreadyExists:
+			return codes.AlreadyExists
+		case waddrmgr.ErrAddressNotFound, waddrmgr.ErrScopeNotFound,
+			waddrmgr.ErrNoExist, waddrmgr.ErrBlockNotFound:
+
+			return codes.NotFound
+		case waddrmgr.ErrLocked, waddrmgr.ErrWatchingOnly,
+			waddrmgr.ErrBirthdayBlockNotSet:
+
+			return codes.FailedPrecondition
+		case waddrmgr.ErrInvalidKeyType, waddrmgr.ErrWrongNet,
+			waddrmgr.ErrEmptyPassphrase, waddrmgr.ErrCoinTypeTooHigh,
+			waddrmgr.ErrAccountNumTooHigh:
+
+			return codes.InvalidArgument
+		case waddrmgr.ErrTooManyAddresses:
+			return codes.ResourceExhausted
 		}
 
+		if e.Err == nil {
+			return codes.Unknown
+		}
 		err = e.Err
 	}
 
-	switch err {
-	case wallet.ErrLoaded:
+	// Coin selection failures are reported by the input source.
+	var inputSourceErr txauthor.InputSourceError
+	if errors.As(err, &inputSourceErr) {
+		return codes.FailedPrecondition
+	}
+
+	switch {
+	case errors.Is(err, wallet.ErrWalletHalted),
+		errors.Is(err, wallet.ErrLoaded),
+		errors.Is(err, wallet.ErrNotLoaded),
+		errors.Is(err, wallet.ErrNotSynced),
+		errors.Is(err, wallet.ErrTxUnsigned),
+		errors.Is(err, wtxmgr.ErrOutputAlreadyLocked),
+		errors.Is(err, wtxmgr.ErrOutputUnlockNotAllowed):
+
 		return codes.FailedPrecondition
-	case walletdb.ErrDbNotOpen:
+	case errors.Is(err, wallet.ErrWalletShuttingDown):
+		return codes.Unavailable
+	case errors.Is(err, macaroons.ErrPermissionDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, walletdb.ErrDbNotOpen):
 		return codes.Aborted
-	case walletdb.ErrDbExists:
+	case errors.Is(err, walletdb.ErrDbExists),
+		errors.Is(err, wallet.ErrExists),
+		errors.Is(err, wallet.ErrTxLabelExists):
+
 		return codes.AlreadyExists
-	case walletdb.ErrDbDoesNotExist:
+	case errors.Is(err, walletdb.ErrDbDoesNotExist),
+		errors.Is(err, wallet.ErrNoTx),
+		errors.Is(err, wallet.ErrUnknownTransaction),
+		errors.Is(err, wallet.ErrNotMine),
+		errors.Is(err, wtxmgr.ErrUnknownOutput):
+
 		return codes.NotFound
-	case hdkeychain.ErrInvalidSeedLen:
+	case errors.Is(err, hdkeychain.ErrInvalidSeedLen),
+		errors.Is(err, txrules.ErrAmountNegative),
+		errors.Is(err, txrules.ErrAmountExceedsMax),
+		errors.Is(err, txrules.ErrOutputIsDust):
+
 		return codes.InvalidArgument
 	default:
 		return codes.Unknown
@@ -132,13 +186,6 @@ func (*versionServer) Version(ctx context.Context, req *pb.VersionRequest) (*pb.
 	}, nil
 }
 
-// StartWalletService creates an implementation of the WalletService and
-// registers it with the gRPC server.
-func StartWalletService(server *grpc.Server, wallet *wallet.Wallet) {
-	service := &walletServer{wallet}
-	pb.RegisterWalletServiceServer(server, service)
-}
-
 func (s *walletServer) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
 	return &pb.PingResponse{}, nil
 }
@@ -502,7 +549,7 @@ func (s *walletServer) PublishTransaction(ctx context.Context, req *pb.PublishTr
 			"Bytes do not represent a valid raw transaction: %v", err)
 	}
 
-	err = s.wallet.PublishTransaction(&msgTx, "")
+	err = s.wallet.PublishTransaction(ctx, &msgTx, "")
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -670,6 +717,50 @@ func (s *walletServer) AccountNotifications(req *pb.AccountNotificationsRequest,
 	}
 }
 
+func (s *walletServer) ConfirmationNotifications(req *pb.ConfirmationNotificationsRequest,
+	svr pb.WalletService_ConfirmationNotificationsServer) error {
+
+	txHash, err := chainhash.NewHash(req.TransactionHash)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument,
+			"transaction_hash: %v", err)
+	}
+	if req.TargetConfirmations < 1 {
+		return status.Errorf(codes.InvalidArgument,
+			"target_confirmations must be positive")
+	}
+
+	n, err := s.wallet.ConfirmationNotifications(txHash,
+		req.TargetConfirmations)
+	if err != nil {
+		return translateError(err)
+	}
+	defer n.Done()
+
+	ctxDone := svr.Context().Done()
+	for {
+		select {
+		case v := <-n.C:
+			resp := pb.ConfirmationNotificationsResponse{
+				TransactionHash: v.TxHash[:],
+				Confirmations:   v.Confirmations,
+				BlockHeight:     v.BlockHeight,
+				Reached:         v.Reached,
+			}
+			if v.BlockHash != nil {
+				resp.BlockHash = v.BlockHash[:]
+			}
+			err := svr.Send(&resp)
+			if err != nil {
+				return translateError(err)
+			}
+
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+
 // StartWalletLoaderService creates an implementation of the WalletLoaderService
 // and registers it with the gRPC server.
 func StartWalletLoaderService(server *grpc.Server, loader *wallet.Loader,
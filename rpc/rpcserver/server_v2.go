@@ -0,0 +1,1167 @@
+package rpcserver
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stroomnetwork/btcwallet/internal/zero"
+	pb "github.com/stroomnetwork/btcwallet/rpc/walletrpc/v2"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// walletServerV2 provides version 2 of the wallet service for RPC clients
+// for a single wallet.  Requests are routed to it by walletRouterV2.
+type walletServerV2 struct {
+	wallet *wallet.Wallet
+}
+
+// unlock unlocks the wallet with the passphrase until the returned function
+// is called.
+func (s *walletServerV2) unlock(passphrase []byte) (func(), error) {
+	lock := make(chan time.Time, 1)
+	err := s.wallet.Unlock(passphrase, lock)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return func() {
+		lock <- time.Time{} // send matters, not the value
+	}, nil
+}
+
+// unmarshalKeyScope returns the key scope of the request, or nil if it is not
+// set.
+func unmarshalKeyScope(scope *pb.KeyScope) *waddrmgr.KeyScope {
+	if scope == nil {
+		return nil
+	}
+	return &waddrmgr.KeyScope{Purpose: scope.Purpose, Coin: scope.Coin}
+}
+
+func marshalKeyScope(scope waddrmgr.KeyScope) *pb.KeyScope {
+	return &pb.KeyScope{Purpose: scope.Purpose, Coin: scope.Coin}
+}
+
+// unmarshalAddressType returns the address type and the default key scope of
+// the addresses of the type.
+func unmarshalAddressType(t pb.AddressType) (waddrmgr.AddressType,
+	waddrmgr.KeyScope, error) {
+
+	switch t {
+	case pb.AddressType_PUBKEY_HASH:
+		return waddrmgr.PubKeyHash, waddrmgr.KeyScopeBIP0044, nil
+	case pb.AddressType_NESTED_WITNESS_PUBKEY_HASH:
+		return waddrmgr.NestedWitnessPubKey,
+			waddrmgr.KeyScopeBIP0049Plus, nil
+	case pb.AddressType_ADDRESS_TYPE_UNSPECIFIED,
+		pb.AddressType_WITNESS_PUBKEY_HASH:
+
+		return waddrmgr.WitnessPubKey, waddrmgr.KeyScopeBIP0084, nil
+	case pb.AddressType_TAPROOT_PUBKEY:
+		return waddrmgr.TaprootPubKey, waddrmgr.KeyScopeBIP0086, nil
+	default:
+		return 0, waddrmgr.KeyScope{}, status.Errorf(
+			codes.InvalidArgument, "address_type=%v", t)
+	}
+}
+
+func unmarshalCoinSelectionStrategy(s pb.CoinSelectionStrategy) (
+	wallet.CoinSelectionStrategy, error) {
+
+	switch s {
+	case pb.CoinSelectionStrategy_LARGEST:
+		return wallet.CoinSelectionLargest, nil
+	case pb.CoinSelectionStrategy_RANDOM:
+		return wallet.CoinSelectionRandom, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"coin_selection_strategy=%v", s)
+	}
+}
+
+func unmarshalOutPoint(op *pb.OutPoint) (wire.OutPoint, error) {
+	if op == nil {
+		return wire.OutPoint{}, status.Errorf(codes.InvalidArgument,
+			"outpoint is required")
+	}
+	hash, err := chainhash.NewHash(op.TransactionHash)
+	if err != nil {
+		return wire.OutPoint{}, status.Errorf(codes.InvalidArgument,
+			"outpoint: %v", err)
+	}
+	return wire.OutPoint{Hash: *hash, Index: op.Index}, nil
+}
+
+func marshalOutPoint(op *wire.OutPoint) *pb.OutPoint {
+	return &pb.OutPoint{TransactionHash: op.Hash[:], Index: op.Index}
+}
+
+func unmarshalLockID(id []byte) (wtxmgr.LockID, error) {
+	var lockID wtxmgr.LockID
+	if len(id) != len(lockID) {
+		return lockID, status.Errorf(codes.InvalidArgument,
+			"id must be %d bytes", len(lockID))
+	}
+	copy(lockID[:], id)
+	return lockID, nil
+}
+
+func unmarshalPsbt(b []byte) (*psbt.Packet, error) {
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Bytes do not represent a valid PSBT: %v", err)
+	}
+	return packet, nil
+}
+
+func serializePsbt(packet *psbt.Packet) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := packet.Serialize(&buf); err != nil {
+		return nil, translateError(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func serializeTx(tx *wire.MsgTx) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, translateError(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func marshalAccount(a *waddrmgr.AccountProperties,
+	balance btcutil.Amount) *pb.AccountsResponse_Account {
+
+	account := &pb.AccountsResponse_Account{
+		Scope:                marshalKeyScope(a.KeyScope),
+		AccountNumber:        a.AccountNumber,
+		AccountName:          a.AccountName,
+		TotalBalance:         int64(balance),
+		ExternalKeyCount:     a.ExternalKeyCount,
+		InternalKeyCount:     a.InternalKeyCount,
+		ImportedKeyCount:     a.ImportedKeyCount,
+		MasterKeyFingerprint: a.MasterKeyFingerprint,
+		WatchOnly:            a.IsWatchOnly,
+	}
+	if a.AccountPubKey != nil {
+		account.ExtendedPublicKey = a.AccountPubKey.String()
+	}
+	return account
+}
+
+func marshalTransactionDetailsV2(tx *wallet.TransactionSummary) *pb.TransactionDetails {
+	debits := make([]*pb.TransactionDetails_Input, len(tx.MyInputs))
+	for i := range tx.MyInputs {
+		input := &tx.MyInputs[i]
+		debits[i] = &pb.TransactionDetails_Input{
+			Index:           input.Index,
+			PreviousAccount: input.PreviousAccount,
+			PreviousAmount:  int64(input.PreviousAmount),
+		}
+	}
+	credits := make([]*pb.TransactionDetails_Output, len(tx.MyOutputs))
+	for i := range tx.MyOutputs {
+		output := &tx.MyOutputs[i]
+		credits[i] = &pb.TransactionDetails_Output{
+			Index:    output.Index,
+			Account:  output.Account,
+			Internal: output.Internal,
+		}
+	}
+	return &pb.TransactionDetails{
+		Hash:        tx.Hash[:],
+		Transaction: tx.Transaction,
+		Debits:      debits,
+		Credits:     credits,
+		Fee:         int64(tx.Fee),
+		Timestamp:   tx.Timestamp,
+		Label:       tx.Label,
+	}
+}
+
+func marshalBlocksV2(v []wallet.Block) []*pb.BlockDetails {
+	blocks := make([]*pb.BlockDetails, len(v))
+	for i := range v {
+		block := &v[i]
+		txs := make([]*pb.TransactionDetails, len(block.Transactions))
+		for j := range block.Transactions {
+			txs[j] = marshalTransactionDetailsV2(&block.Transactions[j])
+		}
+		blocks[i] = &pb.BlockDetails{
+			Hash:         block.Hash[:],
+			Height:       block.Height,
+			Timestamp:    block.Timestamp,
+			Transactions: txs,
+		}
+	}
+	return blocks
+}
+
+// keyScopeOrDefault returns the key scope of the request, or BIP0084 if it
+// is not set.
+func keyScopeOrDefault(scope *pb.KeyScope) waddrmgr.KeyScope {
+	if s := unmarshalKeyScope(scope); s != nil {
+		return *s
+	}
+	return waddrmgr.KeyScopeBIP0084
+}
+
+func (s *walletServerV2) Accounts(ctx context.Context, req *pb.AccountsRequest) (
+	*pb.AccountsResponse, error) {
+
+	var scopes []waddrmgr.KeyScope
+	if scope := unmarshalKeyScope(req.Scope); scope != nil {
+		scopes = append(scopes, *scope)
+	} else {
+		for _, mgr := range s.wallet.Manager.ActiveScopedKeyManagers() {
+			scopes = append(scopes, mgr.Scope())
+		}
+	}
+
+	resp := &pb.AccountsResponse{}
+	for _, scope := range scopes {
+		result, err := s.wallet.Accounts(scope)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		for i := range result.Accounts {
+			a := &result.Accounts[i]
+			resp.Accounts = append(resp.Accounts, marshalAccount(
+				&a.AccountProperties, a.TotalBalance,
+			))
+		}
+		resp.CurrentBlockHash = result.CurrentBlockHash[:]
+		resp.CurrentBlockHeight = result.CurrentBlockHeight
+	}
+	return resp, nil
+}
+
+func (s *walletServerV2) Balance(ctx context.Context, req *pb.BalanceRequest) (
+	*pb.BalanceResponse, error) {
+
+	var (
+		bals wallet.Balances
+		err  error
+	)
+	if req.AllAccounts {
+		bals, err = s.wallet.CalculateWalletBalances(
+			req.RequiredConfirmations,
+		)
+	} else {
+		bals, err = s.wallet.CalculateAccountBalances(
+			req.AccountNumber, req.RequiredConfirmations,
+		)
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.BalanceResponse{
+		Total:          int64(bals.Total),
+		Spendable:      int64(bals.Spendable),
+		ImmatureReward: int64(bals.ImmatureReward),
+	}, nil
+}
+
+func (s *walletServerV2) ListUnspent(ctx context.Context, req *pb.ListUnspentRequest) (
+	*pb.ListUnspentResponse, error) {
+
+	maxConfs := req.MaxConfirmations
+	if maxConfs == 0 {
+		maxConfs = math.MaxInt32
+	}
+	results, err := s.wallet.ListUnspent(
+		req.MinConfirmations, maxConfs, req.AccountName,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	outputs := make([]*pb.ListUnspentResponse_Output, 0, len(results))
+	for _, result := range results {
+		hash, err := chainhash.NewHashFromStr(result.TxID)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		amount, err := btcutil.NewAmount(result.Amount)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		pkScript, err := hex.DecodeString(result.ScriptPubKey)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		redeemScript, err := hex.DecodeString(result.RedeemScript)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		outputs = append(outputs, &pb.ListUnspentResponse_Output{
+			Outpoint: &pb.OutPoint{
+				TransactionHash: hash[:],
+				Index:           result.Vout,
+			},
+			Address:       result.Address,
+			AccountName:   result.Account,
+			Amount:        int64(amount),
+			PkScript:      pkScript,
+			RedeemScript:  redeemScript,
+			Confirmations: result.Confirmations,
+			Spendable:     result.Spendable,
+		})
+	}
+	return &pb.ListUnspentResponse{Outputs: outputs}, nil
+}
+
+func (s *walletServerV2) ListLeasedOutputs(ctx context.Context,
+	req *pb.ListLeasedOutputsRequest) (*pb.ListLeasedOutputsResponse, error) {
+
+	results, err := s.wallet.ListLeasedOutputs()
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	outputs := make([]*pb.ListLeasedOutputsResponse_LeasedOutput, len(results))
+	for i, result := range results {
+		outputs[i] = &pb.ListLeasedOutputsResponse_LeasedOutput{
+			Id:         result.LockID[:],
+			Outpoint:   marshalOutPoint(&result.Outpoint),
+			Expiration: result.Expiration.Unix(),
+			Amount:     result.Value,
+			PkScript:   result.PkScript,
+		}
+	}
+	return &pb.ListLeasedOutputsResponse{Outputs: outputs}, nil
+}
+
+func (s *walletServerV2) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (
+	*pb.GetTransactionResponse, error) {
+
+	txHash, err := chainhash.NewHash(req.TransactionHash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"transaction_hash: %v", err)
+	}
+
+	result, err := s.wallet.GetTransaction(*txHash)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	resp := &pb.GetTransactionResponse{
+		Transaction:   marshalTransactionDetailsV2(&result.Summary),
+		Confirmations: result.Confirmations,
+	}
+	if result.BlockHash != nil {
+		resp.BlockHash = result.BlockHash[:]
+		resp.BlockHeight = result.Height
+		resp.BlockTimestamp = result.Timestamp
+	}
+	return resp, nil
+}
+
+func (s *walletServerV2) RescanStatus(ctx context.Context, req *pb.RescanStatusRequest) (
+	*pb.RescanStatusResponse, error) {
+
+	scanning := s.wallet.Scanning()
+	if scanning == nil {
+		return &pb.RescanStatusResponse{}, nil
+	}
+	return &pb.RescanStatusResponse{
+		Scanning:    true,
+		Started:     scanning.Started.Unix(),
+		StartHeight: scanning.StartHeight,
+		Height:      scanning.Height,
+		BestHeight:  scanning.BestHeight,
+		Progress:    scanning.Progress(),
+	}, nil
+}
+
+func (s *walletServerV2) TransactionNotifications(
+	req *pb.TransactionNotificationsRequest,
+	svr pb.WalletService_TransactionNotificationsServer) error {
+
+	n := s.wallet.NtfnServer.TransactionNotifications()
+	defer n.Done()
+
+	ctxDone := svr.Context().Done()
+	for {
+		select {
+		case v := <-n.C:
+			unmined := make(
+				[]*pb.TransactionDetails,
+				len(v.UnminedTransactions),
+			)
+			for i := range v.UnminedTransactions {
+				unmined[i] = marshalTransactionDetailsV2(
+					&v.UnminedTransactions[i],
+				)
+			}
+			resp := pb.TransactionNotificationsResponse{
+				AttachedBlocks:           marshalBlocksV2(v.AttachedBlocks),
+				DetachedBlocks:           marshalHashes(v.DetachedBlocks),
+				UnminedTransactions:      unmined,
+				UnminedTransactionHashes: marshalHashes(v.UnminedTransactionHashes),
+			}
+			if err := svr.Send(&resp); err != nil {
+				return translateError(err)
+			}
+
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+
+func (s *walletServerV2) SpentnessNotifications(
+	req *pb.SpentnessNotificationsRequest,
+	svr pb.WalletService_SpentnessNotificationsServer) error {
+
+	if req.NoNotifyUnspent && req.NoNotifySpent {
+		return status.Errorf(codes.InvalidArgument,
+			"no_notify_unspent and no_notify_spent may not both "+
+				"be true")
+	}
+
+	n := s.wallet.NtfnServer.AccountSpentnessNotifications(req.Account)
+	defer n.Done()
+
+	ctxDone := svr.Context().Done()
+	for {
+		select {
+		case v := <-n.C:
+			spenderHash, spenderIndex, spent := v.Spender()
+			if (spent && req.NoNotifySpent) ||
+				(!spent && req.NoNotifyUnspent) {
+
+				continue
+			}
+			resp := pb.SpentnessNotificationsResponse{
+				TransactionHash: v.Hash()[:],
+				OutputIndex:     v.Index(),
+			}
+			if spent {
+				resp.Spender = &pb.SpentnessNotificationsResponse_Spender{
+					TransactionHash: spenderHash[:],
+					InputIndex:      spenderIndex,
+				}
+			}
+			if err := svr.Send(&resp); err != nil {
+				return translateError(err)
+			}
+
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+
+func (s *walletServerV2) AccountNotifications(
+	req *pb.AccountNotificationsRequest,
+	svr pb.WalletService_AccountNotificationsServer) error {
+
+	n := s.wallet.NtfnServer.AccountNotifications()
+	defer n.Done()
+
+	ctxDone := svr.Context().Done()
+	for {
+		select {
+		case v := <-n.C:
+			resp := pb.AccountNotificationsResponse{
+				AccountNumber:    v.AccountNumber,
+				AccountName:      v.AccountName,
+				ExternalKeyCount: v.ExternalKeyCount,
+				InternalKeyCount: v.InternalKeyCount,
+				ImportedKeyCount: v.ImportedKeyCount,
+			}
+			if err := svr.Send(&resp); err != nil {
+				return translateError(err)
+			}
+
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+
+func (s *walletServerV2) ConfirmationNotifications(
+	req *pb.ConfirmationNotificationsRequest,
+	svr pb.WalletService_ConfirmationNotificationsServer) error {
+
+	txHash, err := chainhash.NewHash(req.TransactionHash)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument,
+			"transaction_hash: %v", err)
+	}
+	if req.TargetConfirmations < 1 {
+		return status.Errorf(codes.InvalidArgument,
+			"target_confirmations must be positive")
+	}
+
+	n, err := s.wallet.ConfirmationNotifications(
+		txHash, req.TargetConfirmations,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	defer n.Done()
+
+	ctxDone := svr.Context().Done()
+	for {
+		select {
+		case v := <-n.C:
+			resp := pb.ConfirmationNotificationsResponse{
+				TransactionHash: v.TxHash[:],
+				Confirmations:   v.Confirmations,
+				BlockHeight:     v.BlockHeight,
+				Reached:         v.Reached,
+			}
+			if v.BlockHash != nil {
+				resp.BlockHash = v.BlockHash[:]
+			}
+			if err := svr.Send(&resp); err != nil {
+				return translateError(err)
+			}
+
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+
+func (s *walletServerV2) ChangePassphrase(ctx context.Context,
+	req *pb.ChangePassphraseRequest) (*pb.ChangePassphraseResponse, error) {
+
+	defer func() {
+		zero.Bytes(req.OldPassphrase)
+		zero.Bytes(req.NewPassphrase)
+	}()
+
+	var err error
+	switch req.Key {
+	case pb.ChangePassphraseRequest_PRIVATE:
+		err = s.wallet.ChangePrivatePassphrase(
+			req.OldPassphrase, req.NewPassphrase,
+		)
+	case pb.ChangePassphraseRequest_PUBLIC:
+		err = s.wallet.ChangePublicPassphrase(
+			req.OldPassphrase, req.NewPassphrase,
+		)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "key=%v",
+			req.Key)
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.ChangePassphraseResponse{}, nil
+}
+
+func (s *walletServerV2) CreateAccount(ctx context.Context,
+	req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"name may not be empty")
+	}
+
+	// Deriving the account key requires the private keys.
+	relock, err := s.unlock(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	account, err := s.wallet.NextAccount(
+		keyScopeOrDefault(req.Scope), req.Name,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.CreateAccountResponse{AccountNumber: account}, nil
+}
+
+func (s *walletServerV2) RenameAccount(ctx context.Context,
+	req *pb.RenameAccountRequest) (*pb.RenameAccountResponse, error) {
+
+	err := s.wallet.RenameAccount(
+		keyScopeOrDefault(req.Scope), req.AccountNumber, req.NewName,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.RenameAccountResponse{}, nil
+}
+
+func (s *walletServerV2) NextAddress(ctx context.Context, req *pb.NextAddressRequest) (
+	*pb.NextAddressResponse, error) {
+
+	_, scope, err := unmarshalAddressType(req.AddressType)
+	if err != nil {
+		return nil, err
+	}
+
+	var addr btcutil.Address
+	if req.Change {
+		addr, err = s.wallet.NewChangeAddress(req.Account, scope)
+	} else {
+		addr, err = s.wallet.NewAddress(req.Account, scope)
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.NextAddressResponse{Address: addr.EncodeAddress()}, nil
+}
+
+func (s *walletServerV2) LeaseOutput(ctx context.Context, req *pb.LeaseOutputRequest) (
+	*pb.LeaseOutputResponse, error) {
+
+	id, err := unmarshalLockID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	op, err := unmarshalOutPoint(req.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+	if req.DurationSeconds == 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"duration_seconds must be positive")
+	}
+
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	expiration, err := s.wallet.LeaseOutput(id, op, duration)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.LeaseOutputResponse{Expiration: expiration.Unix()}, nil
+}
+
+func (s *walletServerV2) ReleaseOutput(ctx context.Context, req *pb.ReleaseOutputRequest) (
+	*pb.ReleaseOutputResponse, error) {
+
+	id, err := unmarshalLockID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	op, err := unmarshalOutPoint(req.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.wallet.ReleaseOutput(id, op); err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.ReleaseOutputResponse{}, nil
+}
+
+func (s *walletServerV2) CreateTransaction(ctx context.Context,
+	req *pb.CreateTransactionRequest) (*pb.CreateTransactionResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	outputs, totalOutput, err := unmarshalOutputs(req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := unmarshalCoinSelectionStrategy(
+		req.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signing the transaction requires the private keys.
+	if !req.DryRun {
+		relock, err := s.unlock(req.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		defer relock()
+	}
+
+	tx, err := s.wallet.CreateSimpleTx(
+		ctx, unmarshalKeyScope(req.Scope), req.Account, outputs,
+		req.MinConfirmations, btcutil.Amount(req.FeeSatPerKb), strategy,
+		req.DryRun,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	serializedTx, err := serializeTx(tx.Tx)
+	if err != nil {
+		return nil, err
+	}
+	prevAmounts := make([]int64, len(tx.PrevInputValues))
+	for i, amount := range tx.PrevInputValues {
+		prevAmounts[i] = int64(amount)
+	}
+
+	return &pb.CreateTransactionResponse{
+		Transaction:       serializedTx,
+		ChangeOutputIndex: int32(tx.ChangeIndex),
+		TotalInput:        int64(tx.TotalInput),
+		Fee:               int64(tx.TotalInput) - totalOutput,
+		PreviousPkScripts: tx.PrevScripts,
+		PreviousAmounts:   prevAmounts,
+	}, nil
+}
+
+// unmarshalOutputs returns the outputs of a request, of which there must be
+// at least one, and their total value.
+func unmarshalOutputs(v []*pb.CreateTransactionRequest_Output) (
+	[]*wire.TxOut, int64, error) {
+
+	if len(v) == 0 {
+		return nil, 0, status.Errorf(codes.InvalidArgument,
+			"at least one output is required")
+	}
+	outputs := make([]*wire.TxOut, len(v))
+	var total int64
+	for i, output := range v {
+		outputs[i] = wire.NewTxOut(output.Amount, output.PkScript)
+		total += output.Amount
+	}
+	return outputs, total, nil
+}
+
+func (s *walletServerV2) SendOutputs(ctx context.Context,
+	req *pb.SendOutputsRequest) (*pb.SendOutputsResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	outputs, _, err := unmarshalOutputs(req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := unmarshalCoinSelectionStrategy(
+		req.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	relock, err := s.unlock(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	tx, err := s.wallet.SendOutputs(
+		ctx, outputs, unmarshalKeyScope(req.Scope), req.Account,
+		req.MinConfirmations, btcutil.Amount(req.FeeSatPerKb), strategy,
+		req.Label,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	serializedTx, err := serializeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	txHash := tx.TxHash()
+	return &pb.SendOutputsResponse{
+		Transaction:     serializedTx,
+		TransactionHash: txHash[:],
+	}, nil
+}
+
+func (s *walletServerV2) SignTransaction(ctx context.Context,
+	req *pb.SignTransactionRequest) (*pb.SignTransactionResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	var tx wire.MsgTx
+	err := tx.Deserialize(bytes.NewReader(req.SerializedTransaction))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Bytes do not represent a valid raw transaction: %v", err)
+	}
+
+	relock, err := s.unlock(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	invalidSigs, err := s.wallet.SignTransaction(
+		&tx, txscript.SigHashAll, nil, nil, nil,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	unsigned := make([]uint32, len(invalidSigs))
+	for i, e := range invalidSigs {
+		unsigned[i] = e.InputIndex
+	}
+
+	serializedTx, err := serializeTx(&tx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignTransactionResponse{
+		Transaction:          serializedTx,
+		UnsignedInputIndexes: unsigned,
+	}, nil
+}
+
+func (s *walletServerV2) FundPsbt(ctx context.Context, req *pb.FundPsbtRequest) (
+	*pb.FundPsbtResponse, error) {
+
+	packet, err := unmarshalPsbt(req.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := unmarshalCoinSelectionStrategy(
+		req.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	changeIndex, err := s.wallet.FundPsbt(
+		packet, unmarshalKeyScope(req.Scope), req.MinConfirmations,
+		req.Account, btcutil.Amount(req.FeeSatPerKb), strategy,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	funded, err := serializePsbt(packet)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.FundPsbtResponse{
+		Psbt:              funded,
+		ChangeOutputIndex: changeIndex,
+	}, nil
+}
+
+func (s *walletServerV2) FinalizePsbt(ctx context.Context, req *pb.FinalizePsbtRequest) (
+	*pb.FinalizePsbtResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	packet, err := unmarshalPsbt(req.Psbt)
+	if err != nil {
+		return nil, err
+	}
+
+	relock, err := s.unlock(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	err = s.wallet.FinalizePsbt(
+		unmarshalKeyScope(req.Scope), req.Account, packet,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	finalTx, err := psbt.Extract(packet)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	serializedTx, err := serializeTx(finalTx)
+	if err != nil {
+		return nil, err
+	}
+	finalized, err := serializePsbt(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FinalizePsbtResponse{
+		Psbt:        finalized,
+		Transaction: serializedTx,
+	}, nil
+}
+
+func (s *walletServerV2) PublishTransaction(ctx context.Context,
+	req *pb.PublishTransactionRequest) (*pb.PublishTransactionResponse, error) {
+
+	var msgTx wire.MsgTx
+	err := msgTx.Deserialize(bytes.NewReader(req.SignedTransaction))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Bytes do not represent a valid raw transaction: %v", err)
+	}
+
+	err = s.wallet.PublishTransaction(ctx, &msgTx, req.Label)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.PublishTransactionResponse{}, nil
+}
+
+func (s *walletServerV2) LabelTransaction(ctx context.Context,
+	req *pb.LabelTransactionRequest) (*pb.LabelTransactionResponse, error) {
+
+	txHash, err := chainhash.NewHash(req.TransactionHash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"transaction_hash: %v", err)
+	}
+
+	err = s.wallet.LabelTransaction(*txHash, req.Label, req.Overwrite)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.LabelTransactionResponse{}, nil
+}
+
+func (s *walletServerV2) SignMessage(ctx context.Context, req *pb.SignMessageRequest) (
+	*pb.SignMessageResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	addr, err := btcutil.DecodeAddress(req.Address, s.wallet.ChainParams())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"address: %v", err)
+	}
+
+	relock, err := s.unlock(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	sig, err := s.wallet.SignMessage(addr, req.Message)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.SignMessageResponse{Signature: sig}, nil
+}
+
+func (s *walletServerV2) ImportAccount(ctx context.Context, req *pb.ImportAccountRequest) (
+	*pb.ImportAccountResponse, error) {
+
+	accountPubKey, err := hdkeychain.NewKeyFromString(req.ExtendedPublicKey)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extended_public_key: %v", err)
+	}
+
+	// Without an address type, the type is derived from the version of
+	// the extended key.
+	var addrType *waddrmgr.AddressType
+	if req.AddressType != pb.AddressType_ADDRESS_TYPE_UNSPECIFIED {
+		t, _, err := unmarshalAddressType(req.AddressType)
+		if err != nil {
+			return nil, err
+		}
+		addrType = &t
+	}
+
+	if !req.DryRun {
+		props, err := s.wallet.ImportAccount(
+			req.Name, accountPubKey, req.MasterKeyFingerprint,
+			addrType,
+		)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		return &pb.ImportAccountResponse{
+			Account: marshalAccount(props, 0),
+		}, nil
+	}
+
+	props, external, internal, err := s.wallet.ImportAccountDryRun(
+		req.Name, accountPubKey, req.MasterKeyFingerprint, addrType,
+		req.DryRunAddressCount,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	resp := &pb.ImportAccountResponse{Account: marshalAccount(props, 0)}
+	for _, addr := range external {
+		resp.DryRunExternalAddresses = append(
+			resp.DryRunExternalAddresses, addr.Address().EncodeAddress(),
+		)
+	}
+	for _, addr := range internal {
+		resp.DryRunInternalAddresses = append(
+			resp.DryRunInternalAddresses, addr.Address().EncodeAddress(),
+		)
+	}
+	return resp, nil
+}
+
+func (s *walletServerV2) ImportPrivateKey(ctx context.Context,
+	req *pb.ImportPrivateKeyRequest) (*pb.ImportPrivateKeyResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	wif, err := btcutil.DecodeWIF(req.PrivateKeyWif)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"private_key_wif: %v", err)
+	}
+	_, scope, err := unmarshalAddressType(req.AddressType)
+	if err != nil {
+		return nil, err
+	}
+
+	relock, err := s.unlock(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer relock()
+
+	addr, err := s.wallet.ImportPrivateKey(scope, wif, nil, req.Rescan)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.ImportPrivateKeyResponse{Address: addr}, nil
+}
+
+func (s *walletServerV2) ImportPublicKey(ctx context.Context,
+	req *pb.ImportPublicKeyRequest) (*pb.ImportPublicKeyResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.PublicKey)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"public_key: %v", err)
+	}
+	addrType, _, err := unmarshalAddressType(req.AddressType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.wallet.ImportPublicKey(pubKey, addrType); err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.ImportPublicKeyResponse{}, nil
+}
+
+func (s *walletServerV2) ImportTaprootScript(ctx context.Context,
+	req *pb.ImportTaprootScriptRequest) (*pb.ImportTaprootScriptResponse, error) {
+
+	internalKey, err := btcec.ParsePubKey(req.InternalKey)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"internal_key: %v", err)
+	}
+	if len(req.Leaves) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"at least one leaf is required")
+	}
+	leaves := make([]txscript.TapLeaf, len(req.Leaves))
+	for i, leaf := range req.Leaves {
+		leaves[i] = txscript.NewTapLeaf(
+			txscript.TapscriptLeafVersion(leaf.LeafVersion),
+			leaf.Script,
+		)
+	}
+
+	scope := waddrmgr.KeyScopeBIP0086
+	if s := unmarshalKeyScope(req.Scope); s != nil {
+		scope = *s
+	}
+
+	tapscript := waddrmgr.Tapscript{
+		Type: waddrmgr.TapscriptTypeFullTree,
+		ControlBlock: &txscript.ControlBlock{
+			InternalKey: internalKey,
+		},
+		Leaves: leaves,
+	}
+	addr, err := s.wallet.ImportTaprootScript(
+		scope, &tapscript, nil, 1, false,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.ImportTaprootScriptResponse{
+		Address: addr.Address().EncodeAddress(),
+	}, nil
+}
+
+// Rescan starts a rescan and returns without waiting for it to finish.  The
+// progress of the rescan is reported by RescanStatus.
+func (s *walletServerV2) Rescan(ctx context.Context, req *pb.RescanRequest) (
+	*pb.RescanResponse, error) {
+
+	if req.StartHeight < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"start_height may not be negative")
+	}
+
+	addrStrs := req.Addresses
+	if len(addrStrs) == 0 {
+		var err error
+		addrStrs, err = s.wallet.SortedActivePaymentAddresses()
+		if err != nil {
+			return nil, translateError(err)
+		}
+	}
+	addrs := make([]btcutil.Address, len(addrStrs))
+	for i, addrStr := range addrStrs {
+		addr, err := btcutil.DecodeAddress(
+			addrStr, s.wallet.ChainParams(),
+		)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"address %q: %v", addrStr, err)
+		}
+		addrs[i] = addr
+	}
+
+	if s.wallet.ChainClient() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"wallet is not connected to a chain server")
+	}
+	startBlock, err := s.wallet.GetBlockStamp(uint64(req.StartHeight))
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	errChan := s.wallet.SubmitRescan(&wallet.RescanJob{
+		Addrs:      addrs,
+		OutPoints:  make(map[wire.OutPoint]btcutil.Address),
+		BlockStamp: *startBlock,
+	})
+	go func() {
+		if err := <-errChan; err != nil {
+			log.Errorf("Rescan from height %d failed: %v",
+				req.StartHeight, err)
+		}
+	}()
+
+	return &pb.RescanResponse{}, nil
+}
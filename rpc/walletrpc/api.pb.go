@@ -1,75 +1,17 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // source: api.proto
 
-/*
-Package walletrpc is a generated protocol buffer package.
-
-It is generated from these files:
-	api.proto
-
-It has these top-level messages:
-	VersionRequest
-	VersionResponse
-	TransactionDetails
-	BlockDetails
-	AccountBalance
-	PingRequest
-	PingResponse
-	NetworkRequest
-	NetworkResponse
-	AccountNumberRequest
-	AccountNumberResponse
-	AccountsRequest
-	AccountsResponse
-	RenameAccountRequest
-	RenameAccountResponse
-	NextAccountRequest
-	NextAccountResponse
-	NextAddressRequest
-	NextAddressResponse
-	ImportPrivateKeyRequest
-	ImportPrivateKeyResponse
-	BalanceRequest
-	BalanceResponse
-	GetTransactionsRequest
-	GetTransactionsResponse
-	ChangePassphraseRequest
-	ChangePassphraseResponse
-	FundTransactionRequest
-	FundTransactionResponse
-	SignTransactionRequest
-	SignTransactionResponse
-	PublishTransactionRequest
-	PublishTransactionResponse
-	TransactionNotificationsRequest
-	TransactionNotificationsResponse
-	SpentnessNotificationsRequest
-	SpentnessNotificationsResponse
-	AccountNotificationsRequest
-	AccountNotificationsResponse
-	CreateWalletRequest
-	CreateWalletResponse
-	OpenWalletRequest
-	OpenWalletResponse
-	CloseWalletRequest
-	CloseWalletResponse
-	WalletExistsRequest
-	WalletExistsResponse
-	StartConsensusRpcRequest
-	StartConsensusRpcResponse
-*/
 package walletrpc
 
 import (
+	context "context"
 	fmt "fmt"
-
 	proto "github.com/golang/protobuf/proto"
-
-	math "math"
-
-	context "golang.org/x/net/context"
-
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -81,7 +23,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type NextAddressRequest_Kind int32
 
@@ -94,6 +36,7 @@ var NextAddressRequest_Kind_name = map[int32]string{
 	0: "BIP0044_EXTERNAL",
 	1: "BIP0044_INTERNAL",
 }
+
 var NextAddressRequest_Kind_value = map[string]int32{
 	"BIP0044_EXTERNAL": 0,
 	"BIP0044_INTERNAL": 1,
@@ -102,7 +45,10 @@ var NextAddressRequest_Kind_value = map[string]int32{
 func (x NextAddressRequest_Kind) String() string {
 	return proto.EnumName(NextAddressRequest_Kind_name, int32(x))
 }
-func (NextAddressRequest_Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{17, 0} }
+
+func (NextAddressRequest_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17, 0}
+}
 
 type ChangePassphraseRequest_Key int32
 
@@ -115,6 +61,7 @@ var ChangePassphraseRequest_Key_name = map[int32]string{
 	0: "PRIVATE",
 	1: "PUBLIC",
 }
+
 var ChangePassphraseRequest_Key_value = map[string]int32{
 	"PRIVATE": 0,
 	"PUBLIC":  1,
@@ -123,31 +70,78 @@ var ChangePassphraseRequest_Key_value = map[string]int32{
 func (x ChangePassphraseRequest_Key) String() string {
 	return proto.EnumName(ChangePassphraseRequest_Key_name, int32(x))
 }
+
 func (ChangePassphraseRequest_Key) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{25, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25, 0}
 }
 
 type VersionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *VersionRequest) Reset()                    { *m = VersionRequest{} }
-func (m *VersionRequest) String() string            { return proto.CompactTextString(m) }
-func (*VersionRequest) ProtoMessage()               {}
-func (*VersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
+func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
+func (*VersionRequest) ProtoMessage()    {}
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
+
+func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VersionRequest.Unmarshal(m, b)
+}
+func (m *VersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VersionRequest.Marshal(b, m, deterministic)
+}
+func (m *VersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionRequest.Merge(m, src)
+}
+func (m *VersionRequest) XXX_Size() int {
+	return xxx_messageInfo_VersionRequest.Size(m)
+}
+func (m *VersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionRequest proto.InternalMessageInfo
 
 type VersionResponse struct {
-	VersionString string `protobuf:"bytes,1,opt,name=version_string,json=versionString" json:"version_string,omitempty"`
-	Major         uint32 `protobuf:"varint,2,opt,name=major" json:"major,omitempty"`
-	Minor         uint32 `protobuf:"varint,3,opt,name=minor" json:"minor,omitempty"`
-	Patch         uint32 `protobuf:"varint,4,opt,name=patch" json:"patch,omitempty"`
-	Prerelease    string `protobuf:"bytes,5,opt,name=prerelease" json:"prerelease,omitempty"`
-	BuildMetadata string `protobuf:"bytes,6,opt,name=build_metadata,json=buildMetadata" json:"build_metadata,omitempty"`
+	VersionString        string   `protobuf:"bytes,1,opt,name=version_string,json=versionString,proto3" json:"version_string,omitempty"`
+	Major                uint32   `protobuf:"varint,2,opt,name=major,proto3" json:"major,omitempty"`
+	Minor                uint32   `protobuf:"varint,3,opt,name=minor,proto3" json:"minor,omitempty"`
+	Patch                uint32   `protobuf:"varint,4,opt,name=patch,proto3" json:"patch,omitempty"`
+	Prerelease           string   `protobuf:"bytes,5,opt,name=prerelease,proto3" json:"prerelease,omitempty"`
+	BuildMetadata        string   `protobuf:"bytes,6,opt,name=build_metadata,json=buildMetadata,proto3" json:"build_metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
+func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
+func (*VersionResponse) ProtoMessage()    {}
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
 }
 
-func (m *VersionResponse) Reset()                    { *m = VersionResponse{} }
-func (m *VersionResponse) String() string            { return proto.CompactTextString(m) }
-func (*VersionResponse) ProtoMessage()               {}
-func (*VersionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VersionResponse.Unmarshal(m, b)
+}
+func (m *VersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VersionResponse.Marshal(b, m, deterministic)
+}
+func (m *VersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionResponse.Merge(m, src)
+}
+func (m *VersionResponse) XXX_Size() int {
+	return xxx_messageInfo_VersionResponse.Size(m)
+}
+func (m *VersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionResponse proto.InternalMessageInfo
 
 func (m *VersionResponse) GetVersionString() string {
 	if m != nil {
@@ -192,18 +186,41 @@ func (m *VersionResponse) GetBuildMetadata() string {
 }
 
 type TransactionDetails struct {
-	Hash        []byte                       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	Transaction []byte                       `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	Debits      []*TransactionDetails_Input  `protobuf:"bytes,3,rep,name=debits" json:"debits,omitempty"`
-	Credits     []*TransactionDetails_Output `protobuf:"bytes,4,rep,name=credits" json:"credits,omitempty"`
-	Fee         int64                        `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
-	Timestamp   int64                        `protobuf:"varint,6,opt,name=timestamp" json:"timestamp,omitempty"`
+	Hash                 []byte                       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Transaction          []byte                       `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Debits               []*TransactionDetails_Input  `protobuf:"bytes,3,rep,name=debits,proto3" json:"debits,omitempty"`
+	Credits              []*TransactionDetails_Output `protobuf:"bytes,4,rep,name=credits,proto3" json:"credits,omitempty"`
+	Fee                  int64                        `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	Timestamp            int64                        `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
-func (m *TransactionDetails) String() string            { return proto.CompactTextString(m) }
-func (*TransactionDetails) ProtoMessage()               {}
-func (*TransactionDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (m *TransactionDetails) Reset()         { *m = TransactionDetails{} }
+func (m *TransactionDetails) String() string { return proto.CompactTextString(m) }
+func (*TransactionDetails) ProtoMessage()    {}
+func (*TransactionDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+
+func (m *TransactionDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionDetails.Unmarshal(m, b)
+}
+func (m *TransactionDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionDetails.Marshal(b, m, deterministic)
+}
+func (m *TransactionDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionDetails.Merge(m, src)
+}
+func (m *TransactionDetails) XXX_Size() int {
+	return xxx_messageInfo_TransactionDetails.Size(m)
+}
+func (m *TransactionDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionDetails proto.InternalMessageInfo
 
 func (m *TransactionDetails) GetHash() []byte {
 	if m != nil {
@@ -248,15 +265,38 @@ func (m *TransactionDetails) GetTimestamp() int64 {
 }
 
 type TransactionDetails_Input struct {
-	Index           uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
-	PreviousAccount uint32 `protobuf:"varint,2,opt,name=previous_account,json=previousAccount" json:"previous_account,omitempty"`
-	PreviousAmount  int64  `protobuf:"varint,3,opt,name=previous_amount,json=previousAmount" json:"previous_amount,omitempty"`
+	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	PreviousAccount      uint32   `protobuf:"varint,2,opt,name=previous_account,json=previousAccount,proto3" json:"previous_account,omitempty"`
+	PreviousAmount       int64    `protobuf:"varint,3,opt,name=previous_amount,json=previousAmount,proto3" json:"previous_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionDetails_Input) Reset()         { *m = TransactionDetails_Input{} }
+func (m *TransactionDetails_Input) String() string { return proto.CompactTextString(m) }
+func (*TransactionDetails_Input) ProtoMessage()    {}
+func (*TransactionDetails_Input) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2, 0}
 }
 
-func (m *TransactionDetails_Input) Reset()                    { *m = TransactionDetails_Input{} }
-func (m *TransactionDetails_Input) String() string            { return proto.CompactTextString(m) }
-func (*TransactionDetails_Input) ProtoMessage()               {}
-func (*TransactionDetails_Input) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
+func (m *TransactionDetails_Input) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionDetails_Input.Unmarshal(m, b)
+}
+func (m *TransactionDetails_Input) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionDetails_Input.Marshal(b, m, deterministic)
+}
+func (m *TransactionDetails_Input) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionDetails_Input.Merge(m, src)
+}
+func (m *TransactionDetails_Input) XXX_Size() int {
+	return xxx_messageInfo_TransactionDetails_Input.Size(m)
+}
+func (m *TransactionDetails_Input) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionDetails_Input.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionDetails_Input proto.InternalMessageInfo
 
 func (m *TransactionDetails_Input) GetIndex() uint32 {
 	if m != nil {
@@ -280,15 +320,38 @@ func (m *TransactionDetails_Input) GetPreviousAmount() int64 {
 }
 
 type TransactionDetails_Output struct {
-	Index    uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
-	Account  uint32 `protobuf:"varint,2,opt,name=account" json:"account,omitempty"`
-	Internal bool   `protobuf:"varint,3,opt,name=internal" json:"internal,omitempty"`
+	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Account              uint32   `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
+	Internal             bool     `protobuf:"varint,3,opt,name=internal,proto3" json:"internal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransactionDetails_Output) Reset()                    { *m = TransactionDetails_Output{} }
-func (m *TransactionDetails_Output) String() string            { return proto.CompactTextString(m) }
-func (*TransactionDetails_Output) ProtoMessage()               {}
-func (*TransactionDetails_Output) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 1} }
+func (m *TransactionDetails_Output) Reset()         { *m = TransactionDetails_Output{} }
+func (m *TransactionDetails_Output) String() string { return proto.CompactTextString(m) }
+func (*TransactionDetails_Output) ProtoMessage()    {}
+func (*TransactionDetails_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2, 1}
+}
+
+func (m *TransactionDetails_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionDetails_Output.Unmarshal(m, b)
+}
+func (m *TransactionDetails_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionDetails_Output.Marshal(b, m, deterministic)
+}
+func (m *TransactionDetails_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionDetails_Output.Merge(m, src)
+}
+func (m *TransactionDetails_Output) XXX_Size() int {
+	return xxx_messageInfo_TransactionDetails_Output.Size(m)
+}
+func (m *TransactionDetails_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionDetails_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionDetails_Output proto.InternalMessageInfo
 
 func (m *TransactionDetails_Output) GetIndex() uint32 {
 	if m != nil {
@@ -312,16 +375,39 @@ func (m *TransactionDetails_Output) GetInternal() bool {
 }
 
 type BlockDetails struct {
-	Hash         []byte                `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	Height       int32                 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
-	Timestamp    int64                 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
-	Transactions []*TransactionDetails `protobuf:"bytes,4,rep,name=transactions" json:"transactions,omitempty"`
+	Hash                 []byte                `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               int32                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp            int64                 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Transactions         []*TransactionDetails `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *BlockDetails) Reset()                    { *m = BlockDetails{} }
-func (m *BlockDetails) String() string            { return proto.CompactTextString(m) }
-func (*BlockDetails) ProtoMessage()               {}
-func (*BlockDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (m *BlockDetails) Reset()         { *m = BlockDetails{} }
+func (m *BlockDetails) String() string { return proto.CompactTextString(m) }
+func (*BlockDetails) ProtoMessage()    {}
+func (*BlockDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+}
+
+func (m *BlockDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockDetails.Unmarshal(m, b)
+}
+func (m *BlockDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockDetails.Marshal(b, m, deterministic)
+}
+func (m *BlockDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockDetails.Merge(m, src)
+}
+func (m *BlockDetails) XXX_Size() int {
+	return xxx_messageInfo_BlockDetails.Size(m)
+}
+func (m *BlockDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockDetails proto.InternalMessageInfo
 
 func (m *BlockDetails) GetHash() []byte {
 	if m != nil {
@@ -352,14 +438,37 @@ func (m *BlockDetails) GetTransactions() []*TransactionDetails {
 }
 
 type AccountBalance struct {
-	Account      uint32 `protobuf:"varint,1,opt,name=account" json:"account,omitempty"`
-	TotalBalance int64  `protobuf:"varint,2,opt,name=total_balance,json=totalBalance" json:"total_balance,omitempty"`
+	Account              uint32   `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	TotalBalance         int64    `protobuf:"varint,2,opt,name=total_balance,json=totalBalance,proto3" json:"total_balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AccountBalance) Reset()                    { *m = AccountBalance{} }
-func (m *AccountBalance) String() string            { return proto.CompactTextString(m) }
-func (*AccountBalance) ProtoMessage()               {}
-func (*AccountBalance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *AccountBalance) Reset()         { *m = AccountBalance{} }
+func (m *AccountBalance) String() string { return proto.CompactTextString(m) }
+func (*AccountBalance) ProtoMessage()    {}
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+}
+
+func (m *AccountBalance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountBalance.Unmarshal(m, b)
+}
+func (m *AccountBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountBalance.Marshal(b, m, deterministic)
+}
+func (m *AccountBalance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountBalance.Merge(m, src)
+}
+func (m *AccountBalance) XXX_Size() int {
+	return xxx_messageInfo_AccountBalance.Size(m)
+}
+func (m *AccountBalance) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountBalance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountBalance proto.InternalMessageInfo
 
 func (m *AccountBalance) GetAccount() uint32 {
 	if m != nil {
@@ -376,37 +485,129 @@ func (m *AccountBalance) GetTotalBalance() int64 {
 }
 
 type PingRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingRequest) Reset()         { *m = PingRequest{} }
+func (m *PingRequest) String() string { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()    {}
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+}
+
+func (m *PingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
+}
+func (m *PingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingRequest.Marshal(b, m, deterministic)
+}
+func (m *PingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingRequest.Merge(m, src)
+}
+func (m *PingRequest) XXX_Size() int {
+	return xxx_messageInfo_PingRequest.Size(m)
+}
+func (m *PingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingRequest.DiscardUnknown(m)
 }
 
-func (m *PingRequest) Reset()                    { *m = PingRequest{} }
-func (m *PingRequest) String() string            { return proto.CompactTextString(m) }
-func (*PingRequest) ProtoMessage()               {}
-func (*PingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+var xxx_messageInfo_PingRequest proto.InternalMessageInfo
 
 type PingResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingResponse) Reset()         { *m = PingResponse{} }
+func (m *PingResponse) String() string { return proto.CompactTextString(m) }
+func (*PingResponse) ProtoMessage()    {}
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+}
+
+func (m *PingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
+}
+func (m *PingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingResponse.Marshal(b, m, deterministic)
+}
+func (m *PingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResponse.Merge(m, src)
+}
+func (m *PingResponse) XXX_Size() int {
+	return xxx_messageInfo_PingResponse.Size(m)
+}
+func (m *PingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResponse.DiscardUnknown(m)
 }
 
-func (m *PingResponse) Reset()                    { *m = PingResponse{} }
-func (m *PingResponse) String() string            { return proto.CompactTextString(m) }
-func (*PingResponse) ProtoMessage()               {}
-func (*PingResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
 type NetworkRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NetworkRequest) Reset()                    { *m = NetworkRequest{} }
-func (m *NetworkRequest) String() string            { return proto.CompactTextString(m) }
-func (*NetworkRequest) ProtoMessage()               {}
-func (*NetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (m *NetworkRequest) Reset()         { *m = NetworkRequest{} }
+func (m *NetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*NetworkRequest) ProtoMessage()    {}
+func (*NetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+}
+
+func (m *NetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkRequest.Unmarshal(m, b)
+}
+func (m *NetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *NetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkRequest.Merge(m, src)
+}
+func (m *NetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_NetworkRequest.Size(m)
+}
+func (m *NetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkRequest proto.InternalMessageInfo
 
 type NetworkResponse struct {
-	ActiveNetwork uint32 `protobuf:"varint,1,opt,name=active_network,json=activeNetwork" json:"active_network,omitempty"`
+	ActiveNetwork        uint32   `protobuf:"varint,1,opt,name=active_network,json=activeNetwork,proto3" json:"active_network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NetworkResponse) Reset()                    { *m = NetworkResponse{} }
-func (m *NetworkResponse) String() string            { return proto.CompactTextString(m) }
-func (*NetworkResponse) ProtoMessage()               {}
-func (*NetworkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *NetworkResponse) Reset()         { *m = NetworkResponse{} }
+func (m *NetworkResponse) String() string { return proto.CompactTextString(m) }
+func (*NetworkResponse) ProtoMessage()    {}
+func (*NetworkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+}
+
+func (m *NetworkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkResponse.Unmarshal(m, b)
+}
+func (m *NetworkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkResponse.Marshal(b, m, deterministic)
+}
+func (m *NetworkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkResponse.Merge(m, src)
+}
+func (m *NetworkResponse) XXX_Size() int {
+	return xxx_messageInfo_NetworkResponse.Size(m)
+}
+func (m *NetworkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkResponse proto.InternalMessageInfo
 
 func (m *NetworkResponse) GetActiveNetwork() uint32 {
 	if m != nil {
@@ -416,13 +617,36 @@ func (m *NetworkResponse) GetActiveNetwork() uint32 {
 }
 
 type AccountNumberRequest struct {
-	AccountName string `protobuf:"bytes,1,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	AccountName          string   `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountNumberRequest) Reset()         { *m = AccountNumberRequest{} }
+func (m *AccountNumberRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountNumberRequest) ProtoMessage()    {}
+func (*AccountNumberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+}
+
+func (m *AccountNumberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountNumberRequest.Unmarshal(m, b)
+}
+func (m *AccountNumberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountNumberRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountNumberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountNumberRequest.Merge(m, src)
+}
+func (m *AccountNumberRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountNumberRequest.Size(m)
+}
+func (m *AccountNumberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountNumberRequest.DiscardUnknown(m)
 }
 
-func (m *AccountNumberRequest) Reset()                    { *m = AccountNumberRequest{} }
-func (m *AccountNumberRequest) String() string            { return proto.CompactTextString(m) }
-func (*AccountNumberRequest) ProtoMessage()               {}
-func (*AccountNumberRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+var xxx_messageInfo_AccountNumberRequest proto.InternalMessageInfo
 
 func (m *AccountNumberRequest) GetAccountName() string {
 	if m != nil {
@@ -432,13 +656,36 @@ func (m *AccountNumberRequest) GetAccountName() string {
 }
 
 type AccountNumberResponse struct {
-	AccountNumber uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountNumberResponse) Reset()         { *m = AccountNumberResponse{} }
+func (m *AccountNumberResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountNumberResponse) ProtoMessage()    {}
+func (*AccountNumberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+
+func (m *AccountNumberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountNumberResponse.Unmarshal(m, b)
+}
+func (m *AccountNumberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountNumberResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountNumberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountNumberResponse.Merge(m, src)
+}
+func (m *AccountNumberResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountNumberResponse.Size(m)
+}
+func (m *AccountNumberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountNumberResponse.DiscardUnknown(m)
 }
 
-func (m *AccountNumberResponse) Reset()                    { *m = AccountNumberResponse{} }
-func (m *AccountNumberResponse) String() string            { return proto.CompactTextString(m) }
-func (*AccountNumberResponse) ProtoMessage()               {}
-func (*AccountNumberResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+var xxx_messageInfo_AccountNumberResponse proto.InternalMessageInfo
 
 func (m *AccountNumberResponse) GetAccountNumber() uint32 {
 	if m != nil {
@@ -448,23 +695,69 @@ func (m *AccountNumberResponse) GetAccountNumber() uint32 {
 }
 
 type AccountsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AccountsRequest) Reset()                    { *m = AccountsRequest{} }
-func (m *AccountsRequest) String() string            { return proto.CompactTextString(m) }
-func (*AccountsRequest) ProtoMessage()               {}
-func (*AccountsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (m *AccountsRequest) Reset()         { *m = AccountsRequest{} }
+func (m *AccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountsRequest) ProtoMessage()    {}
+func (*AccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+
+func (m *AccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsRequest.Unmarshal(m, b)
+}
+func (m *AccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsRequest.Merge(m, src)
+}
+func (m *AccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountsRequest.Size(m)
+}
+func (m *AccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsRequest proto.InternalMessageInfo
 
 type AccountsResponse struct {
-	Accounts           []*AccountsResponse_Account `protobuf:"bytes,1,rep,name=accounts" json:"accounts,omitempty"`
-	CurrentBlockHash   []byte                      `protobuf:"bytes,2,opt,name=current_block_hash,json=currentBlockHash,proto3" json:"current_block_hash,omitempty"`
-	CurrentBlockHeight int32                       `protobuf:"varint,3,opt,name=current_block_height,json=currentBlockHeight" json:"current_block_height,omitempty"`
+	Accounts             []*AccountsResponse_Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	CurrentBlockHash     []byte                      `protobuf:"bytes,2,opt,name=current_block_hash,json=currentBlockHash,proto3" json:"current_block_hash,omitempty"`
+	CurrentBlockHeight   int32                       `protobuf:"varint,3,opt,name=current_block_height,json=currentBlockHeight,proto3" json:"current_block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
-func (m *AccountsResponse) Reset()                    { *m = AccountsResponse{} }
-func (m *AccountsResponse) String() string            { return proto.CompactTextString(m) }
-func (*AccountsResponse) ProtoMessage()               {}
-func (*AccountsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *AccountsResponse) Reset()         { *m = AccountsResponse{} }
+func (m *AccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse) ProtoMessage()    {}
+func (*AccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+
+func (m *AccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsResponse.Unmarshal(m, b)
+}
+func (m *AccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsResponse.Merge(m, src)
+}
+func (m *AccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountsResponse.Size(m)
+}
+func (m *AccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsResponse proto.InternalMessageInfo
 
 func (m *AccountsResponse) GetAccounts() []*AccountsResponse_Account {
 	if m != nil {
@@ -488,18 +781,41 @@ func (m *AccountsResponse) GetCurrentBlockHeight() int32 {
 }
 
 type AccountsResponse_Account struct {
-	AccountNumber    uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
-	AccountName      string `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
-	TotalBalance     int64  `protobuf:"varint,3,opt,name=total_balance,json=totalBalance" json:"total_balance,omitempty"`
-	ExternalKeyCount uint32 `protobuf:"varint,4,opt,name=external_key_count,json=externalKeyCount" json:"external_key_count,omitempty"`
-	InternalKeyCount uint32 `protobuf:"varint,5,opt,name=internal_key_count,json=internalKeyCount" json:"internal_key_count,omitempty"`
-	ImportedKeyCount uint32 `protobuf:"varint,6,opt,name=imported_key_count,json=importedKeyCount" json:"imported_key_count,omitempty"`
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName          string   `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	TotalBalance         int64    `protobuf:"varint,3,opt,name=total_balance,json=totalBalance,proto3" json:"total_balance,omitempty"`
+	ExternalKeyCount     uint32   `protobuf:"varint,4,opt,name=external_key_count,json=externalKeyCount,proto3" json:"external_key_count,omitempty"`
+	InternalKeyCount     uint32   `protobuf:"varint,5,opt,name=internal_key_count,json=internalKeyCount,proto3" json:"internal_key_count,omitempty"`
+	ImportedKeyCount     uint32   `protobuf:"varint,6,opt,name=imported_key_count,json=importedKeyCount,proto3" json:"imported_key_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AccountsResponse_Account) Reset()                    { *m = AccountsResponse_Account{} }
-func (m *AccountsResponse_Account) String() string            { return proto.CompactTextString(m) }
-func (*AccountsResponse_Account) ProtoMessage()               {}
-func (*AccountsResponse_Account) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
+func (m *AccountsResponse_Account) Reset()         { *m = AccountsResponse_Account{} }
+func (m *AccountsResponse_Account) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse_Account) ProtoMessage()    {}
+func (*AccountsResponse_Account) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12, 0}
+}
+
+func (m *AccountsResponse_Account) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsResponse_Account.Unmarshal(m, b)
+}
+func (m *AccountsResponse_Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsResponse_Account.Marshal(b, m, deterministic)
+}
+func (m *AccountsResponse_Account) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsResponse_Account.Merge(m, src)
+}
+func (m *AccountsResponse_Account) XXX_Size() int {
+	return xxx_messageInfo_AccountsResponse_Account.Size(m)
+}
+func (m *AccountsResponse_Account) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsResponse_Account.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsResponse_Account proto.InternalMessageInfo
 
 func (m *AccountsResponse_Account) GetAccountNumber() uint32 {
 	if m != nil {
@@ -544,14 +860,37 @@ func (m *AccountsResponse_Account) GetImportedKeyCount() uint32 {
 }
 
 type RenameAccountRequest struct {
-	AccountNumber uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
-	NewName       string `protobuf:"bytes,2,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	NewName              string   `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameAccountRequest) Reset()         { *m = RenameAccountRequest{} }
+func (m *RenameAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameAccountRequest) ProtoMessage()    {}
+func (*RenameAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 
-func (m *RenameAccountRequest) Reset()                    { *m = RenameAccountRequest{} }
-func (m *RenameAccountRequest) String() string            { return proto.CompactTextString(m) }
-func (*RenameAccountRequest) ProtoMessage()               {}
-func (*RenameAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *RenameAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameAccountRequest.Unmarshal(m, b)
+}
+func (m *RenameAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *RenameAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameAccountRequest.Merge(m, src)
+}
+func (m *RenameAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameAccountRequest.Size(m)
+}
+func (m *RenameAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameAccountRequest proto.InternalMessageInfo
 
 func (m *RenameAccountRequest) GetAccountNumber() uint32 {
 	if m != nil {
@@ -568,22 +907,68 @@ func (m *RenameAccountRequest) GetNewName() string {
 }
 
 type RenameAccountResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameAccountResponse) Reset()         { *m = RenameAccountResponse{} }
+func (m *RenameAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*RenameAccountResponse) ProtoMessage()    {}
+func (*RenameAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+
+func (m *RenameAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameAccountResponse.Unmarshal(m, b)
+}
+func (m *RenameAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *RenameAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameAccountResponse.Merge(m, src)
+}
+func (m *RenameAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_RenameAccountResponse.Size(m)
+}
+func (m *RenameAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameAccountResponse.DiscardUnknown(m)
 }
 
-func (m *RenameAccountResponse) Reset()                    { *m = RenameAccountResponse{} }
-func (m *RenameAccountResponse) String() string            { return proto.CompactTextString(m) }
-func (*RenameAccountResponse) ProtoMessage()               {}
-func (*RenameAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+var xxx_messageInfo_RenameAccountResponse proto.InternalMessageInfo
 
 type NextAccountRequest struct {
-	Passphrase  []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
-	AccountName string `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	Passphrase           []byte   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	AccountName          string   `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextAccountRequest) Reset()         { *m = NextAccountRequest{} }
+func (m *NextAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*NextAccountRequest) ProtoMessage()    {}
+func (*NextAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+
+func (m *NextAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextAccountRequest.Unmarshal(m, b)
+}
+func (m *NextAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *NextAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextAccountRequest.Merge(m, src)
+}
+func (m *NextAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_NextAccountRequest.Size(m)
+}
+func (m *NextAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextAccountRequest.DiscardUnknown(m)
 }
 
-func (m *NextAccountRequest) Reset()                    { *m = NextAccountRequest{} }
-func (m *NextAccountRequest) String() string            { return proto.CompactTextString(m) }
-func (*NextAccountRequest) ProtoMessage()               {}
-func (*NextAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+var xxx_messageInfo_NextAccountRequest proto.InternalMessageInfo
 
 func (m *NextAccountRequest) GetPassphrase() []byte {
 	if m != nil {
@@ -600,13 +985,36 @@ func (m *NextAccountRequest) GetAccountName() string {
 }
 
 type NextAccountResponse struct {
-	AccountNumber uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextAccountResponse) Reset()         { *m = NextAccountResponse{} }
+func (m *NextAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*NextAccountResponse) ProtoMessage()    {}
+func (*NextAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+
+func (m *NextAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextAccountResponse.Unmarshal(m, b)
+}
+func (m *NextAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *NextAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextAccountResponse.Merge(m, src)
+}
+func (m *NextAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_NextAccountResponse.Size(m)
+}
+func (m *NextAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextAccountResponse.DiscardUnknown(m)
 }
 
-func (m *NextAccountResponse) Reset()                    { *m = NextAccountResponse{} }
-func (m *NextAccountResponse) String() string            { return proto.CompactTextString(m) }
-func (*NextAccountResponse) ProtoMessage()               {}
-func (*NextAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+var xxx_messageInfo_NextAccountResponse proto.InternalMessageInfo
 
 func (m *NextAccountResponse) GetAccountNumber() uint32 {
 	if m != nil {
@@ -616,14 +1024,37 @@ func (m *NextAccountResponse) GetAccountNumber() uint32 {
 }
 
 type NextAddressRequest struct {
-	Account uint32                  `protobuf:"varint,1,opt,name=account" json:"account,omitempty"`
-	Kind    NextAddressRequest_Kind `protobuf:"varint,2,opt,name=kind,enum=walletrpc.NextAddressRequest_Kind" json:"kind,omitempty"`
+	Account              uint32                  `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	Kind                 NextAddressRequest_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=walletrpc.NextAddressRequest_Kind" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func (m *NextAddressRequest) Reset()                    { *m = NextAddressRequest{} }
-func (m *NextAddressRequest) String() string            { return proto.CompactTextString(m) }
-func (*NextAddressRequest) ProtoMessage()               {}
-func (*NextAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *NextAddressRequest) Reset()         { *m = NextAddressRequest{} }
+func (m *NextAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*NextAddressRequest) ProtoMessage()    {}
+func (*NextAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+
+func (m *NextAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextAddressRequest.Unmarshal(m, b)
+}
+func (m *NextAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *NextAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextAddressRequest.Merge(m, src)
+}
+func (m *NextAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_NextAddressRequest.Size(m)
+}
+func (m *NextAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextAddressRequest proto.InternalMessageInfo
 
 func (m *NextAddressRequest) GetAccount() uint32 {
 	if m != nil {
@@ -640,13 +1071,36 @@ func (m *NextAddressRequest) GetKind() NextAddressRequest_Kind {
 }
 
 type NextAddressResponse struct {
-	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextAddressResponse) Reset()         { *m = NextAddressResponse{} }
+func (m *NextAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*NextAddressResponse) ProtoMessage()    {}
+func (*NextAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+
+func (m *NextAddressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextAddressResponse.Unmarshal(m, b)
+}
+func (m *NextAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextAddressResponse.Marshal(b, m, deterministic)
+}
+func (m *NextAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextAddressResponse.Merge(m, src)
+}
+func (m *NextAddressResponse) XXX_Size() int {
+	return xxx_messageInfo_NextAddressResponse.Size(m)
+}
+func (m *NextAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextAddressResponse.DiscardUnknown(m)
 }
 
-func (m *NextAddressResponse) Reset()                    { *m = NextAddressResponse{} }
-func (m *NextAddressResponse) String() string            { return proto.CompactTextString(m) }
-func (*NextAddressResponse) ProtoMessage()               {}
-func (*NextAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+var xxx_messageInfo_NextAddressResponse proto.InternalMessageInfo
 
 func (m *NextAddressResponse) GetAddress() string {
 	if m != nil {
@@ -656,16 +1110,39 @@ func (m *NextAddressResponse) GetAddress() string {
 }
 
 type ImportPrivateKeyRequest struct {
-	Passphrase    []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
-	Account       uint32 `protobuf:"varint,2,opt,name=account" json:"account,omitempty"`
-	PrivateKeyWif string `protobuf:"bytes,3,opt,name=private_key_wif,json=privateKeyWif" json:"private_key_wif,omitempty"`
-	Rescan        bool   `protobuf:"varint,4,opt,name=rescan" json:"rescan,omitempty"`
+	Passphrase           []byte   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Account              uint32   `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
+	PrivateKeyWif        string   `protobuf:"bytes,3,opt,name=private_key_wif,json=privateKeyWif,proto3" json:"private_key_wif,omitempty"`
+	Rescan               bool     `protobuf:"varint,4,opt,name=rescan,proto3" json:"rescan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImportPrivateKeyRequest) Reset()                    { *m = ImportPrivateKeyRequest{} }
-func (m *ImportPrivateKeyRequest) String() string            { return proto.CompactTextString(m) }
-func (*ImportPrivateKeyRequest) ProtoMessage()               {}
-func (*ImportPrivateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (m *ImportPrivateKeyRequest) Reset()         { *m = ImportPrivateKeyRequest{} }
+func (m *ImportPrivateKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportPrivateKeyRequest) ProtoMessage()    {}
+func (*ImportPrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+
+func (m *ImportPrivateKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPrivateKeyRequest.Unmarshal(m, b)
+}
+func (m *ImportPrivateKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPrivateKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportPrivateKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPrivateKeyRequest.Merge(m, src)
+}
+func (m *ImportPrivateKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportPrivateKeyRequest.Size(m)
+}
+func (m *ImportPrivateKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPrivateKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPrivateKeyRequest proto.InternalMessageInfo
 
 func (m *ImportPrivateKeyRequest) GetPassphrase() []byte {
 	if m != nil {
@@ -696,22 +1173,68 @@ func (m *ImportPrivateKeyRequest) GetRescan() bool {
 }
 
 type ImportPrivateKeyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImportPrivateKeyResponse) Reset()                    { *m = ImportPrivateKeyResponse{} }
-func (m *ImportPrivateKeyResponse) String() string            { return proto.CompactTextString(m) }
-func (*ImportPrivateKeyResponse) ProtoMessage()               {}
-func (*ImportPrivateKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (m *ImportPrivateKeyResponse) Reset()         { *m = ImportPrivateKeyResponse{} }
+func (m *ImportPrivateKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportPrivateKeyResponse) ProtoMessage()    {}
+func (*ImportPrivateKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+
+func (m *ImportPrivateKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPrivateKeyResponse.Unmarshal(m, b)
+}
+func (m *ImportPrivateKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPrivateKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportPrivateKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPrivateKeyResponse.Merge(m, src)
+}
+func (m *ImportPrivateKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportPrivateKeyResponse.Size(m)
+}
+func (m *ImportPrivateKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPrivateKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPrivateKeyResponse proto.InternalMessageInfo
 
 type BalanceRequest struct {
-	AccountNumber         uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
-	RequiredConfirmations int32  `protobuf:"varint,2,opt,name=required_confirmations,json=requiredConfirmations" json:"required_confirmations,omitempty"`
+	AccountNumber         uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	RequiredConfirmations int32    `protobuf:"varint,2,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
-func (m *BalanceRequest) Reset()                    { *m = BalanceRequest{} }
-func (m *BalanceRequest) String() string            { return proto.CompactTextString(m) }
-func (*BalanceRequest) ProtoMessage()               {}
-func (*BalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (m *BalanceRequest) Reset()         { *m = BalanceRequest{} }
+func (m *BalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*BalanceRequest) ProtoMessage()    {}
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+}
+
+func (m *BalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceRequest.Unmarshal(m, b)
+}
+func (m *BalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *BalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceRequest.Merge(m, src)
+}
+func (m *BalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_BalanceRequest.Size(m)
+}
+func (m *BalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceRequest proto.InternalMessageInfo
 
 func (m *BalanceRequest) GetAccountNumber() uint32 {
 	if m != nil {
@@ -728,15 +1251,38 @@ func (m *BalanceRequest) GetRequiredConfirmations() int32 {
 }
 
 type BalanceResponse struct {
-	Total          int64 `protobuf:"varint,1,opt,name=total" json:"total,omitempty"`
-	Spendable      int64 `protobuf:"varint,2,opt,name=spendable" json:"spendable,omitempty"`
-	ImmatureReward int64 `protobuf:"varint,3,opt,name=immature_reward,json=immatureReward" json:"immature_reward,omitempty"`
+	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Spendable            int64    `protobuf:"varint,2,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	ImmatureReward       int64    `protobuf:"varint,3,opt,name=immature_reward,json=immatureReward,proto3" json:"immature_reward,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceResponse) Reset()         { *m = BalanceResponse{} }
+func (m *BalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*BalanceResponse) ProtoMessage()    {}
+func (*BalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+}
+
+func (m *BalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceResponse.Unmarshal(m, b)
+}
+func (m *BalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *BalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceResponse.Merge(m, src)
+}
+func (m *BalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_BalanceResponse.Size(m)
+}
+func (m *BalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceResponse.DiscardUnknown(m)
 }
 
-func (m *BalanceResponse) Reset()                    { *m = BalanceResponse{} }
-func (m *BalanceResponse) String() string            { return proto.CompactTextString(m) }
-func (*BalanceResponse) ProtoMessage()               {}
-func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+var xxx_messageInfo_BalanceResponse proto.InternalMessageInfo
 
 func (m *BalanceResponse) GetTotal() int64 {
 	if m != nil {
@@ -768,24 +1314,47 @@ type GetTransactionsRequest struct {
 	// If both options are excluded, transaction results are created for transactions since the
 	// genesis block.
 	StartingBlockHash   []byte `protobuf:"bytes,1,opt,name=starting_block_hash,json=startingBlockHash,proto3" json:"starting_block_hash,omitempty"`
-	StartingBlockHeight int32  `protobuf:"zigzag32,2,opt,name=starting_block_height,json=startingBlockHeight" json:"starting_block_height,omitempty"`
+	StartingBlockHeight int32  `protobuf:"zigzag32,2,opt,name=starting_block_height,json=startingBlockHeight,proto3" json:"starting_block_height,omitempty"`
 	// Optionally specify the last block that transaction results may appear in.
 	// Either the ending block hash or height may be specified, but not both.
 	// If both are excluded, transaction results are created for all transactions
 	// through the best block, and include all unmined transactions.
 	EndingBlockHash   []byte `protobuf:"bytes,3,opt,name=ending_block_hash,json=endingBlockHash,proto3" json:"ending_block_hash,omitempty"`
-	EndingBlockHeight int32  `protobuf:"varint,4,opt,name=ending_block_height,json=endingBlockHeight" json:"ending_block_height,omitempty"`
+	EndingBlockHeight int32  `protobuf:"varint,4,opt,name=ending_block_height,json=endingBlockHeight,proto3" json:"ending_block_height,omitempty"`
 	// Include at least this many of the newest transactions if they exist.
 	// Cannot be used when the ending block hash is specified.
 	//
 	// TODO: remove until spec adds it back in some way.
-	MinimumRecentTransactions int32 `protobuf:"varint,5,opt,name=minimum_recent_transactions,json=minimumRecentTransactions" json:"minimum_recent_transactions,omitempty"`
+	MinimumRecentTransactions int32    `protobuf:"varint,5,opt,name=minimum_recent_transactions,json=minimumRecentTransactions,proto3" json:"minimum_recent_transactions,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{} `json:"-"`
+	XXX_unrecognized          []byte   `json:"-"`
+	XXX_sizecache             int32    `json:"-"`
 }
 
-func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
-func (m *GetTransactionsRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionsRequest) ProtoMessage()               {}
-func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (m *GetTransactionsRequest) Reset()         { *m = GetTransactionsRequest{} }
+func (m *GetTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsRequest) ProtoMessage()    {}
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *GetTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionsRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionsRequest.Merge(m, src)
+}
+func (m *GetTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionsRequest.Size(m)
+}
+func (m *GetTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionsRequest proto.InternalMessageInfo
 
 func (m *GetTransactionsRequest) GetStartingBlockHash() []byte {
 	if m != nil {
@@ -823,14 +1392,37 @@ func (m *GetTransactionsRequest) GetMinimumRecentTransactions() int32 {
 }
 
 type GetTransactionsResponse struct {
-	MinedTransactions   []*BlockDetails       `protobuf:"bytes,1,rep,name=mined_transactions,json=minedTransactions" json:"mined_transactions,omitempty"`
-	UnminedTransactions []*TransactionDetails `protobuf:"bytes,2,rep,name=unmined_transactions,json=unminedTransactions" json:"unmined_transactions,omitempty"`
+	MinedTransactions    []*BlockDetails       `protobuf:"bytes,1,rep,name=mined_transactions,json=minedTransactions,proto3" json:"mined_transactions,omitempty"`
+	UnminedTransactions  []*TransactionDetails `protobuf:"bytes,2,rep,name=unmined_transactions,json=unminedTransactions,proto3" json:"unmined_transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *GetTransactionsResponse) Reset()                    { *m = GetTransactionsResponse{} }
-func (m *GetTransactionsResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionsResponse) ProtoMessage()               {}
-func (*GetTransactionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (m *GetTransactionsResponse) Reset()         { *m = GetTransactionsResponse{} }
+func (m *GetTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsResponse) ProtoMessage()    {}
+func (*GetTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *GetTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionsResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionsResponse.Merge(m, src)
+}
+func (m *GetTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionsResponse.Size(m)
+}
+func (m *GetTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionsResponse proto.InternalMessageInfo
 
 func (m *GetTransactionsResponse) GetMinedTransactions() []*BlockDetails {
 	if m != nil {
@@ -847,15 +1439,38 @@ func (m *GetTransactionsResponse) GetUnminedTransactions() []*TransactionDetails
 }
 
 type ChangePassphraseRequest struct {
-	Key           ChangePassphraseRequest_Key `protobuf:"varint,1,opt,name=key,enum=walletrpc.ChangePassphraseRequest_Key" json:"key,omitempty"`
-	OldPassphrase []byte                      `protobuf:"bytes,2,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
-	NewPassphrase []byte                      `protobuf:"bytes,3,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+	Key                  ChangePassphraseRequest_Key `protobuf:"varint,1,opt,name=key,proto3,enum=walletrpc.ChangePassphraseRequest_Key" json:"key,omitempty"`
+	OldPassphrase        []byte                      `protobuf:"bytes,2,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
+	NewPassphrase        []byte                      `protobuf:"bytes,3,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ChangePassphraseRequest) Reset()         { *m = ChangePassphraseRequest{} }
+func (m *ChangePassphraseRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangePassphraseRequest) ProtoMessage()    {}
+func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *ChangePassphraseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangePassphraseRequest.Unmarshal(m, b)
+}
+func (m *ChangePassphraseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangePassphraseRequest.Marshal(b, m, deterministic)
+}
+func (m *ChangePassphraseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangePassphraseRequest.Merge(m, src)
+}
+func (m *ChangePassphraseRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangePassphraseRequest.Size(m)
+}
+func (m *ChangePassphraseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangePassphraseRequest.DiscardUnknown(m)
 }
 
-func (m *ChangePassphraseRequest) Reset()                    { *m = ChangePassphraseRequest{} }
-func (m *ChangePassphraseRequest) String() string            { return proto.CompactTextString(m) }
-func (*ChangePassphraseRequest) ProtoMessage()               {}
-func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+var xxx_messageInfo_ChangePassphraseRequest proto.InternalMessageInfo
 
 func (m *ChangePassphraseRequest) GetKey() ChangePassphraseRequest_Key {
 	if m != nil {
@@ -879,25 +1494,71 @@ func (m *ChangePassphraseRequest) GetNewPassphrase() []byte {
 }
 
 type ChangePassphraseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangePassphraseResponse) Reset()         { *m = ChangePassphraseResponse{} }
+func (m *ChangePassphraseResponse) String() string { return proto.CompactTextString(m) }
+func (*ChangePassphraseResponse) ProtoMessage()    {}
+func (*ChangePassphraseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *ChangePassphraseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangePassphraseResponse.Unmarshal(m, b)
+}
+func (m *ChangePassphraseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangePassphraseResponse.Marshal(b, m, deterministic)
+}
+func (m *ChangePassphraseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangePassphraseResponse.Merge(m, src)
+}
+func (m *ChangePassphraseResponse) XXX_Size() int {
+	return xxx_messageInfo_ChangePassphraseResponse.Size(m)
+}
+func (m *ChangePassphraseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangePassphraseResponse.DiscardUnknown(m)
 }
 
-func (m *ChangePassphraseResponse) Reset()                    { *m = ChangePassphraseResponse{} }
-func (m *ChangePassphraseResponse) String() string            { return proto.CompactTextString(m) }
-func (*ChangePassphraseResponse) ProtoMessage()               {}
-func (*ChangePassphraseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+var xxx_messageInfo_ChangePassphraseResponse proto.InternalMessageInfo
 
 type FundTransactionRequest struct {
-	Account                  uint32 `protobuf:"varint,1,opt,name=account" json:"account,omitempty"`
-	TargetAmount             int64  `protobuf:"varint,2,opt,name=target_amount,json=targetAmount" json:"target_amount,omitempty"`
-	RequiredConfirmations    int32  `protobuf:"varint,3,opt,name=required_confirmations,json=requiredConfirmations" json:"required_confirmations,omitempty"`
-	IncludeImmatureCoinbases bool   `protobuf:"varint,4,opt,name=include_immature_coinbases,json=includeImmatureCoinbases" json:"include_immature_coinbases,omitempty"`
-	IncludeChangeScript      bool   `protobuf:"varint,5,opt,name=include_change_script,json=includeChangeScript" json:"include_change_script,omitempty"`
+	Account                  uint32   `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	TargetAmount             int64    `protobuf:"varint,2,opt,name=target_amount,json=targetAmount,proto3" json:"target_amount,omitempty"`
+	RequiredConfirmations    int32    `protobuf:"varint,3,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	IncludeImmatureCoinbases bool     `protobuf:"varint,4,opt,name=include_immature_coinbases,json=includeImmatureCoinbases,proto3" json:"include_immature_coinbases,omitempty"`
+	IncludeChangeScript      bool     `protobuf:"varint,5,opt,name=include_change_script,json=includeChangeScript,proto3" json:"include_change_script,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
-func (m *FundTransactionRequest) Reset()                    { *m = FundTransactionRequest{} }
-func (m *FundTransactionRequest) String() string            { return proto.CompactTextString(m) }
-func (*FundTransactionRequest) ProtoMessage()               {}
-func (*FundTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (m *FundTransactionRequest) Reset()         { *m = FundTransactionRequest{} }
+func (m *FundTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*FundTransactionRequest) ProtoMessage()    {}
+func (*FundTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+
+func (m *FundTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundTransactionRequest.Unmarshal(m, b)
+}
+func (m *FundTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *FundTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundTransactionRequest.Merge(m, src)
+}
+func (m *FundTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_FundTransactionRequest.Size(m)
+}
+func (m *FundTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FundTransactionRequest proto.InternalMessageInfo
 
 func (m *FundTransactionRequest) GetAccount() uint32 {
 	if m != nil {
@@ -935,15 +1596,38 @@ func (m *FundTransactionRequest) GetIncludeChangeScript() bool {
 }
 
 type FundTransactionResponse struct {
-	SelectedOutputs []*FundTransactionResponse_PreviousOutput `protobuf:"bytes,1,rep,name=selected_outputs,json=selectedOutputs" json:"selected_outputs,omitempty"`
-	TotalAmount     int64                                     `protobuf:"varint,2,opt,name=total_amount,json=totalAmount" json:"total_amount,omitempty"`
-	ChangePkScript  []byte                                    `protobuf:"bytes,3,opt,name=change_pk_script,json=changePkScript,proto3" json:"change_pk_script,omitempty"`
+	SelectedOutputs      []*FundTransactionResponse_PreviousOutput `protobuf:"bytes,1,rep,name=selected_outputs,json=selectedOutputs,proto3" json:"selected_outputs,omitempty"`
+	TotalAmount          int64                                     `protobuf:"varint,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	ChangePkScript       []byte                                    `protobuf:"bytes,3,opt,name=change_pk_script,json=changePkScript,proto3" json:"change_pk_script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
 }
 
-func (m *FundTransactionResponse) Reset()                    { *m = FundTransactionResponse{} }
-func (m *FundTransactionResponse) String() string            { return proto.CompactTextString(m) }
-func (*FundTransactionResponse) ProtoMessage()               {}
-func (*FundTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (m *FundTransactionResponse) Reset()         { *m = FundTransactionResponse{} }
+func (m *FundTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*FundTransactionResponse) ProtoMessage()    {}
+func (*FundTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+
+func (m *FundTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundTransactionResponse.Unmarshal(m, b)
+}
+func (m *FundTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *FundTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundTransactionResponse.Merge(m, src)
+}
+func (m *FundTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_FundTransactionResponse.Size(m)
+}
+func (m *FundTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FundTransactionResponse proto.InternalMessageInfo
 
 func (m *FundTransactionResponse) GetSelectedOutputs() []*FundTransactionResponse_PreviousOutput {
 	if m != nil {
@@ -967,12 +1651,15 @@ func (m *FundTransactionResponse) GetChangePkScript() []byte {
 }
 
 type FundTransactionResponse_PreviousOutput struct {
-	TransactionHash []byte `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
-	OutputIndex     uint32 `protobuf:"varint,2,opt,name=output_index,json=outputIndex" json:"output_index,omitempty"`
-	Amount          int64  `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
-	PkScript        []byte `protobuf:"bytes,4,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
-	ReceiveTime     int64  `protobuf:"varint,5,opt,name=receive_time,json=receiveTime" json:"receive_time,omitempty"`
-	FromCoinbase    bool   `protobuf:"varint,6,opt,name=from_coinbase,json=fromCoinbase" json:"from_coinbase,omitempty"`
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	OutputIndex          uint32   `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	PkScript             []byte   `protobuf:"bytes,4,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	ReceiveTime          int64    `protobuf:"varint,5,opt,name=receive_time,json=receiveTime,proto3" json:"receive_time,omitempty"`
+	FromCoinbase         bool     `protobuf:"varint,6,opt,name=from_coinbase,json=fromCoinbase,proto3" json:"from_coinbase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FundTransactionResponse_PreviousOutput) Reset() {
@@ -981,9 +1668,27 @@ func (m *FundTransactionResponse_PreviousOutput) Reset() {
 func (m *FundTransactionResponse_PreviousOutput) String() string { return proto.CompactTextString(m) }
 func (*FundTransactionResponse_PreviousOutput) ProtoMessage()    {}
 func (*FundTransactionResponse_PreviousOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{28, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28, 0}
+}
+
+func (m *FundTransactionResponse_PreviousOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundTransactionResponse_PreviousOutput.Unmarshal(m, b)
+}
+func (m *FundTransactionResponse_PreviousOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundTransactionResponse_PreviousOutput.Marshal(b, m, deterministic)
+}
+func (m *FundTransactionResponse_PreviousOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundTransactionResponse_PreviousOutput.Merge(m, src)
+}
+func (m *FundTransactionResponse_PreviousOutput) XXX_Size() int {
+	return xxx_messageInfo_FundTransactionResponse_PreviousOutput.Size(m)
+}
+func (m *FundTransactionResponse_PreviousOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundTransactionResponse_PreviousOutput.DiscardUnknown(m)
 }
 
+var xxx_messageInfo_FundTransactionResponse_PreviousOutput proto.InternalMessageInfo
+
 func (m *FundTransactionResponse_PreviousOutput) GetTransactionHash() []byte {
 	if m != nil {
 		return m.TransactionHash
@@ -1034,13 +1739,36 @@ type SignTransactionRequest struct {
 	// will be signed.  Rather than returning an incompletely signed
 	// transaction if any of the inputs to be signed can not be, the RPC
 	// immediately errors.
-	InputIndexes []uint32 `protobuf:"varint,3,rep,packed,name=input_indexes,json=inputIndexes" json:"input_indexes,omitempty"`
+	InputIndexes         []uint32 `protobuf:"varint,3,rep,packed,name=input_indexes,json=inputIndexes,proto3" json:"input_indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignTransactionRequest) Reset()         { *m = SignTransactionRequest{} }
+func (m *SignTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SignTransactionRequest) ProtoMessage()    {}
+func (*SignTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 
-func (m *SignTransactionRequest) Reset()                    { *m = SignTransactionRequest{} }
-func (m *SignTransactionRequest) String() string            { return proto.CompactTextString(m) }
-func (*SignTransactionRequest) ProtoMessage()               {}
-func (*SignTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (m *SignTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignTransactionRequest.Unmarshal(m, b)
+}
+func (m *SignTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SignTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignTransactionRequest.Merge(m, src)
+}
+func (m *SignTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SignTransactionRequest.Size(m)
+}
+func (m *SignTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignTransactionRequest proto.InternalMessageInfo
 
 func (m *SignTransactionRequest) GetPassphrase() []byte {
 	if m != nil {
@@ -1065,13 +1793,36 @@ func (m *SignTransactionRequest) GetInputIndexes() []uint32 {
 
 type SignTransactionResponse struct {
 	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	UnsignedInputIndexes []uint32 `protobuf:"varint,2,rep,packed,name=unsigned_input_indexes,json=unsignedInputIndexes" json:"unsigned_input_indexes,omitempty"`
+	UnsignedInputIndexes []uint32 `protobuf:"varint,2,rep,packed,name=unsigned_input_indexes,json=unsignedInputIndexes,proto3" json:"unsigned_input_indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignTransactionResponse) Reset()         { *m = SignTransactionResponse{} }
+func (m *SignTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SignTransactionResponse) ProtoMessage()    {}
+func (*SignTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+
+func (m *SignTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignTransactionResponse.Unmarshal(m, b)
+}
+func (m *SignTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SignTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignTransactionResponse.Merge(m, src)
+}
+func (m *SignTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SignTransactionResponse.Size(m)
+}
+func (m *SignTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignTransactionResponse.DiscardUnknown(m)
 }
 
-func (m *SignTransactionResponse) Reset()                    { *m = SignTransactionResponse{} }
-func (m *SignTransactionResponse) String() string            { return proto.CompactTextString(m) }
-func (*SignTransactionResponse) ProtoMessage()               {}
-func (*SignTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+var xxx_messageInfo_SignTransactionResponse proto.InternalMessageInfo
 
 func (m *SignTransactionResponse) GetTransaction() []byte {
 	if m != nil {
@@ -1088,13 +1839,36 @@ func (m *SignTransactionResponse) GetUnsignedInputIndexes() []uint32 {
 }
 
 type PublishTransactionRequest struct {
-	SignedTransaction []byte `protobuf:"bytes,1,opt,name=signed_transaction,json=signedTransaction,proto3" json:"signed_transaction,omitempty"`
+	SignedTransaction    []byte   `protobuf:"bytes,1,opt,name=signed_transaction,json=signedTransaction,proto3" json:"signed_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PublishTransactionRequest) Reset()                    { *m = PublishTransactionRequest{} }
-func (m *PublishTransactionRequest) String() string            { return proto.CompactTextString(m) }
-func (*PublishTransactionRequest) ProtoMessage()               {}
-func (*PublishTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (m *PublishTransactionRequest) Reset()         { *m = PublishTransactionRequest{} }
+func (m *PublishTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishTransactionRequest) ProtoMessage()    {}
+func (*PublishTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+
+func (m *PublishTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishTransactionRequest.Unmarshal(m, b)
+}
+func (m *PublishTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishTransactionRequest.Merge(m, src)
+}
+func (m *PublishTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishTransactionRequest.Size(m)
+}
+func (m *PublishTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishTransactionRequest proto.InternalMessageInfo
 
 func (m *PublishTransactionRequest) GetSignedTransaction() []byte {
 	if m != nil {
@@ -1104,27 +1878,71 @@ func (m *PublishTransactionRequest) GetSignedTransaction() []byte {
 }
 
 type PublishTransactionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PublishTransactionResponse) Reset()                    { *m = PublishTransactionResponse{} }
-func (m *PublishTransactionResponse) String() string            { return proto.CompactTextString(m) }
-func (*PublishTransactionResponse) ProtoMessage()               {}
-func (*PublishTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (m *PublishTransactionResponse) Reset()         { *m = PublishTransactionResponse{} }
+func (m *PublishTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishTransactionResponse) ProtoMessage()    {}
+func (*PublishTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+}
+
+func (m *PublishTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishTransactionResponse.Unmarshal(m, b)
+}
+func (m *PublishTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishTransactionResponse.Merge(m, src)
+}
+func (m *PublishTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishTransactionResponse.Size(m)
+}
+func (m *PublishTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishTransactionResponse proto.InternalMessageInfo
 
 type TransactionNotificationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TransactionNotificationsRequest) Reset()         { *m = TransactionNotificationsRequest{} }
 func (m *TransactionNotificationsRequest) String() string { return proto.CompactTextString(m) }
 func (*TransactionNotificationsRequest) ProtoMessage()    {}
 func (*TransactionNotificationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{33}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+
+func (m *TransactionNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionNotificationsRequest.Unmarshal(m, b)
+}
+func (m *TransactionNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *TransactionNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionNotificationsRequest.Merge(m, src)
+}
+func (m *TransactionNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_TransactionNotificationsRequest.Size(m)
+}
+func (m *TransactionNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionNotificationsRequest.DiscardUnknown(m)
 }
 
+var xxx_messageInfo_TransactionNotificationsRequest proto.InternalMessageInfo
+
 type TransactionNotificationsResponse struct {
 	// Sorted by increasing height.  This is a repeated field so many new blocks
 	// in a new best chain can be notified at once during a reorganize.
-	AttachedBlocks []*BlockDetails `protobuf:"bytes,1,rep,name=attached_blocks,json=attachedBlocks" json:"attached_blocks,omitempty"`
+	AttachedBlocks []*BlockDetails `protobuf:"bytes,1,rep,name=attached_blocks,json=attachedBlocks,proto3" json:"attached_blocks,omitempty"`
 	// If there was a chain reorganize, there may have been blocks with wallet
 	// transactions that are no longer in the best chain.  These are those
 	// block's hashes.
@@ -1134,18 +1952,39 @@ type TransactionNotificationsResponse struct {
 	// be moved to mempool and included here if they are not mined or double spent
 	// in the new chain.  Additonally, if no new blocks were attached but a relevant
 	// unmined transaction is seen by the wallet, it will be reported here.
-	UnminedTransactions []*TransactionDetails `protobuf:"bytes,3,rep,name=unmined_transactions,json=unminedTransactions" json:"unmined_transactions,omitempty"`
+	UnminedTransactions []*TransactionDetails `protobuf:"bytes,3,rep,name=unmined_transactions,json=unminedTransactions,proto3" json:"unmined_transactions,omitempty"`
 	// Instead of notifying all of the removed unmined transactions,
 	// just send all of the current hashes.
 	UnminedTransactionHashes [][]byte `protobuf:"bytes,4,rep,name=unmined_transaction_hashes,json=unminedTransactionHashes,proto3" json:"unmined_transaction_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
 func (m *TransactionNotificationsResponse) Reset()         { *m = TransactionNotificationsResponse{} }
 func (m *TransactionNotificationsResponse) String() string { return proto.CompactTextString(m) }
 func (*TransactionNotificationsResponse) ProtoMessage()    {}
 func (*TransactionNotificationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+
+func (m *TransactionNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionNotificationsResponse.Unmarshal(m, b)
+}
+func (m *TransactionNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *TransactionNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionNotificationsResponse.Merge(m, src)
 }
+func (m *TransactionNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_TransactionNotificationsResponse.Size(m)
+}
+func (m *TransactionNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionNotificationsResponse proto.InternalMessageInfo
 
 func (m *TransactionNotificationsResponse) GetAttachedBlocks() []*BlockDetails {
 	if m != nil {
@@ -1176,15 +2015,38 @@ func (m *TransactionNotificationsResponse) GetUnminedTransactionHashes() [][]byt
 }
 
 type SpentnessNotificationsRequest struct {
-	Account         uint32 `protobuf:"varint,1,opt,name=account" json:"account,omitempty"`
-	NoNotifyUnspent bool   `protobuf:"varint,2,opt,name=no_notify_unspent,json=noNotifyUnspent" json:"no_notify_unspent,omitempty"`
-	NoNotifySpent   bool   `protobuf:"varint,3,opt,name=no_notify_spent,json=noNotifySpent" json:"no_notify_spent,omitempty"`
+	Account              uint32   `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	NoNotifyUnspent      bool     `protobuf:"varint,2,opt,name=no_notify_unspent,json=noNotifyUnspent,proto3" json:"no_notify_unspent,omitempty"`
+	NoNotifySpent        bool     `protobuf:"varint,3,opt,name=no_notify_spent,json=noNotifySpent,proto3" json:"no_notify_spent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpentnessNotificationsRequest) Reset()         { *m = SpentnessNotificationsRequest{} }
+func (m *SpentnessNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*SpentnessNotificationsRequest) ProtoMessage()    {}
+func (*SpentnessNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
 }
 
-func (m *SpentnessNotificationsRequest) Reset()                    { *m = SpentnessNotificationsRequest{} }
-func (m *SpentnessNotificationsRequest) String() string            { return proto.CompactTextString(m) }
-func (*SpentnessNotificationsRequest) ProtoMessage()               {}
-func (*SpentnessNotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (m *SpentnessNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentnessNotificationsRequest.Unmarshal(m, b)
+}
+func (m *SpentnessNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentnessNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *SpentnessNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentnessNotificationsRequest.Merge(m, src)
+}
+func (m *SpentnessNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_SpentnessNotificationsRequest.Size(m)
+}
+func (m *SpentnessNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentnessNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpentnessNotificationsRequest proto.InternalMessageInfo
 
 func (m *SpentnessNotificationsRequest) GetAccount() uint32 {
 	if m != nil {
@@ -1208,18 +2070,39 @@ func (m *SpentnessNotificationsRequest) GetNoNotifySpent() bool {
 }
 
 type SpentnessNotificationsResponse struct {
-	TransactionHash []byte                                  `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
-	OutputIndex     uint32                                  `protobuf:"varint,2,opt,name=output_index,json=outputIndex" json:"output_index,omitempty"`
-	Spender         *SpentnessNotificationsResponse_Spender `protobuf:"bytes,3,opt,name=spender" json:"spender,omitempty"`
+	TransactionHash      []byte                                  `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	OutputIndex          uint32                                  `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	Spender              *SpentnessNotificationsResponse_Spender `protobuf:"bytes,3,opt,name=spender,proto3" json:"spender,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
 }
 
 func (m *SpentnessNotificationsResponse) Reset()         { *m = SpentnessNotificationsResponse{} }
 func (m *SpentnessNotificationsResponse) String() string { return proto.CompactTextString(m) }
 func (*SpentnessNotificationsResponse) ProtoMessage()    {}
 func (*SpentnessNotificationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{36}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+}
+
+func (m *SpentnessNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentnessNotificationsResponse.Unmarshal(m, b)
+}
+func (m *SpentnessNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentnessNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *SpentnessNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentnessNotificationsResponse.Merge(m, src)
+}
+func (m *SpentnessNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_SpentnessNotificationsResponse.Size(m)
+}
+func (m *SpentnessNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentnessNotificationsResponse.DiscardUnknown(m)
 }
 
+var xxx_messageInfo_SpentnessNotificationsResponse proto.InternalMessageInfo
+
 func (m *SpentnessNotificationsResponse) GetTransactionHash() []byte {
 	if m != nil {
 		return m.TransactionHash
@@ -1242,8 +2125,11 @@ func (m *SpentnessNotificationsResponse) GetSpender() *SpentnessNotificationsRes
 }
 
 type SpentnessNotificationsResponse_Spender struct {
-	TransactionHash []byte `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
-	InputIndex      uint32 `protobuf:"varint,2,opt,name=input_index,json=inputIndex" json:"input_index,omitempty"`
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	InputIndex           uint32   `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SpentnessNotificationsResponse_Spender) Reset() {
@@ -1252,8 +2138,26 @@ func (m *SpentnessNotificationsResponse_Spender) Reset() {
 func (m *SpentnessNotificationsResponse_Spender) String() string { return proto.CompactTextString(m) }
 func (*SpentnessNotificationsResponse_Spender) ProtoMessage()    {}
 func (*SpentnessNotificationsResponse_Spender) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{36, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36, 0}
+}
+
+func (m *SpentnessNotificationsResponse_Spender) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentnessNotificationsResponse_Spender.Unmarshal(m, b)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentnessNotificationsResponse_Spender.Marshal(b, m, deterministic)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentnessNotificationsResponse_Spender.Merge(m, src)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_Size() int {
+	return xxx_messageInfo_SpentnessNotificationsResponse_Spender.Size(m)
 }
+func (m *SpentnessNotificationsResponse_Spender) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentnessNotificationsResponse_Spender.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpentnessNotificationsResponse_Spender proto.InternalMessageInfo
 
 func (m *SpentnessNotificationsResponse_Spender) GetTransactionHash() []byte {
 	if m != nil {
@@ -1270,25 +2174,71 @@ func (m *SpentnessNotificationsResponse_Spender) GetInputIndex() uint32 {
 }
 
 type AccountNotificationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountNotificationsRequest) Reset()         { *m = AccountNotificationsRequest{} }
+func (m *AccountNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountNotificationsRequest) ProtoMessage()    {}
+func (*AccountNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+}
+
+func (m *AccountNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountNotificationsRequest.Unmarshal(m, b)
+}
+func (m *AccountNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountNotificationsRequest.Merge(m, src)
+}
+func (m *AccountNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountNotificationsRequest.Size(m)
+}
+func (m *AccountNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountNotificationsRequest.DiscardUnknown(m)
 }
 
-func (m *AccountNotificationsRequest) Reset()                    { *m = AccountNotificationsRequest{} }
-func (m *AccountNotificationsRequest) String() string            { return proto.CompactTextString(m) }
-func (*AccountNotificationsRequest) ProtoMessage()               {}
-func (*AccountNotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+var xxx_messageInfo_AccountNotificationsRequest proto.InternalMessageInfo
 
 type AccountNotificationsResponse struct {
-	AccountNumber    uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
-	AccountName      string `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
-	ExternalKeyCount uint32 `protobuf:"varint,3,opt,name=external_key_count,json=externalKeyCount" json:"external_key_count,omitempty"`
-	InternalKeyCount uint32 `protobuf:"varint,4,opt,name=internal_key_count,json=internalKeyCount" json:"internal_key_count,omitempty"`
-	ImportedKeyCount uint32 `protobuf:"varint,5,opt,name=imported_key_count,json=importedKeyCount" json:"imported_key_count,omitempty"`
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName          string   `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	ExternalKeyCount     uint32   `protobuf:"varint,3,opt,name=external_key_count,json=externalKeyCount,proto3" json:"external_key_count,omitempty"`
+	InternalKeyCount     uint32   `protobuf:"varint,4,opt,name=internal_key_count,json=internalKeyCount,proto3" json:"internal_key_count,omitempty"`
+	ImportedKeyCount     uint32   `protobuf:"varint,5,opt,name=imported_key_count,json=importedKeyCount,proto3" json:"imported_key_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountNotificationsResponse) Reset()         { *m = AccountNotificationsResponse{} }
+func (m *AccountNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountNotificationsResponse) ProtoMessage()    {}
+func (*AccountNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+
+func (m *AccountNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountNotificationsResponse.Unmarshal(m, b)
+}
+func (m *AccountNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountNotificationsResponse.Merge(m, src)
+}
+func (m *AccountNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountNotificationsResponse.Size(m)
+}
+func (m *AccountNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountNotificationsResponse.DiscardUnknown(m)
 }
 
-func (m *AccountNotificationsResponse) Reset()                    { *m = AccountNotificationsResponse{} }
-func (m *AccountNotificationsResponse) String() string            { return proto.CompactTextString(m) }
-func (*AccountNotificationsResponse) ProtoMessage()               {}
-func (*AccountNotificationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+var xxx_messageInfo_AccountNotificationsResponse proto.InternalMessageInfo
 
 func (m *AccountNotificationsResponse) GetAccountNumber() uint32 {
 	if m != nil {
@@ -1325,16 +2275,157 @@ func (m *AccountNotificationsResponse) GetImportedKeyCount() uint32 {
 	return 0
 }
 
+type ConfirmationNotificationsRequest struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TargetConfirmations  int32    `protobuf:"varint,2,opt,name=target_confirmations,json=targetConfirmations,proto3" json:"target_confirmations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmationNotificationsRequest) Reset()         { *m = ConfirmationNotificationsRequest{} }
+func (m *ConfirmationNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmationNotificationsRequest) ProtoMessage()    {}
+func (*ConfirmationNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
+
+func (m *ConfirmationNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmationNotificationsRequest.Unmarshal(m, b)
+}
+func (m *ConfirmationNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmationNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ConfirmationNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmationNotificationsRequest.Merge(m, src)
+}
+func (m *ConfirmationNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmationNotificationsRequest.Size(m)
+}
+func (m *ConfirmationNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmationNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmationNotificationsRequest proto.InternalMessageInfo
+
+func (m *ConfirmationNotificationsRequest) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *ConfirmationNotificationsRequest) GetTargetConfirmations() int32 {
+	if m != nil {
+		return m.TargetConfirmations
+	}
+	return 0
+}
+
+type ConfirmationNotificationsResponse struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Confirmations        int32    `protobuf:"varint,2,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight          int32    `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Reached              bool     `protobuf:"varint,5,opt,name=reached,proto3" json:"reached,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmationNotificationsResponse) Reset()         { *m = ConfirmationNotificationsResponse{} }
+func (m *ConfirmationNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfirmationNotificationsResponse) ProtoMessage()    {}
+func (*ConfirmationNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+}
+
+func (m *ConfirmationNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmationNotificationsResponse.Unmarshal(m, b)
+}
+func (m *ConfirmationNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmationNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ConfirmationNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmationNotificationsResponse.Merge(m, src)
+}
+func (m *ConfirmationNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ConfirmationNotificationsResponse.Size(m)
+}
+func (m *ConfirmationNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmationNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmationNotificationsResponse proto.InternalMessageInfo
+
+func (m *ConfirmationNotificationsResponse) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *ConfirmationNotificationsResponse) GetConfirmations() int32 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+func (m *ConfirmationNotificationsResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ConfirmationNotificationsResponse) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ConfirmationNotificationsResponse) GetReached() bool {
+	if m != nil {
+		return m.Reached
+	}
+	return false
+}
+
 type CreateWalletRequest struct {
-	PublicPassphrase  []byte `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
-	PrivatePassphrase []byte `protobuf:"bytes,2,opt,name=private_passphrase,json=privatePassphrase,proto3" json:"private_passphrase,omitempty"`
-	Seed              []byte `protobuf:"bytes,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	PublicPassphrase     []byte   `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
+	PrivatePassphrase    []byte   `protobuf:"bytes,2,opt,name=private_passphrase,json=privatePassphrase,proto3" json:"private_passphrase,omitempty"`
+	Seed                 []byte   `protobuf:"bytes,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWalletRequest) Reset()         { *m = CreateWalletRequest{} }
+func (m *CreateWalletRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWalletRequest) ProtoMessage()    {}
+func (*CreateWalletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+}
+
+func (m *CreateWalletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWalletRequest.Unmarshal(m, b)
+}
+func (m *CreateWalletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWalletRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWalletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWalletRequest.Merge(m, src)
+}
+func (m *CreateWalletRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWalletRequest.Size(m)
+}
+func (m *CreateWalletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWalletRequest.DiscardUnknown(m)
 }
 
-func (m *CreateWalletRequest) Reset()                    { *m = CreateWalletRequest{} }
-func (m *CreateWalletRequest) String() string            { return proto.CompactTextString(m) }
-func (*CreateWalletRequest) ProtoMessage()               {}
-func (*CreateWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+var xxx_messageInfo_CreateWalletRequest proto.InternalMessageInfo
 
 func (m *CreateWalletRequest) GetPublicPassphrase() []byte {
 	if m != nil {
@@ -1358,21 +2449,67 @@ func (m *CreateWalletRequest) GetSeed() []byte {
 }
 
 type CreateWalletResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWalletResponse) Reset()         { *m = CreateWalletResponse{} }
+func (m *CreateWalletResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateWalletResponse) ProtoMessage()    {}
+func (*CreateWalletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+
+func (m *CreateWalletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWalletResponse.Unmarshal(m, b)
+}
+func (m *CreateWalletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWalletResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateWalletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWalletResponse.Merge(m, src)
+}
+func (m *CreateWalletResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateWalletResponse.Size(m)
+}
+func (m *CreateWalletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWalletResponse.DiscardUnknown(m)
 }
 
-func (m *CreateWalletResponse) Reset()                    { *m = CreateWalletResponse{} }
-func (m *CreateWalletResponse) String() string            { return proto.CompactTextString(m) }
-func (*CreateWalletResponse) ProtoMessage()               {}
-func (*CreateWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+var xxx_messageInfo_CreateWalletResponse proto.InternalMessageInfo
 
 type OpenWalletRequest struct {
-	PublicPassphrase []byte `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
+	PublicPassphrase     []byte   `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenWalletRequest) Reset()         { *m = OpenWalletRequest{} }
+func (m *OpenWalletRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenWalletRequest) ProtoMessage()    {}
+func (*OpenWalletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+}
+
+func (m *OpenWalletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenWalletRequest.Unmarshal(m, b)
+}
+func (m *OpenWalletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenWalletRequest.Marshal(b, m, deterministic)
+}
+func (m *OpenWalletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenWalletRequest.Merge(m, src)
+}
+func (m *OpenWalletRequest) XXX_Size() int {
+	return xxx_messageInfo_OpenWalletRequest.Size(m)
+}
+func (m *OpenWalletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenWalletRequest.DiscardUnknown(m)
 }
 
-func (m *OpenWalletRequest) Reset()                    { *m = OpenWalletRequest{} }
-func (m *OpenWalletRequest) String() string            { return proto.CompactTextString(m) }
-func (*OpenWalletRequest) ProtoMessage()               {}
-func (*OpenWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+var xxx_messageInfo_OpenWalletRequest proto.InternalMessageInfo
 
 func (m *OpenWalletRequest) GetPublicPassphrase() []byte {
 	if m != nil {
@@ -1382,45 +2519,160 @@ func (m *OpenWalletRequest) GetPublicPassphrase() []byte {
 }
 
 type OpenWalletResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenWalletResponse) Reset()         { *m = OpenWalletResponse{} }
+func (m *OpenWalletResponse) String() string { return proto.CompactTextString(m) }
+func (*OpenWalletResponse) ProtoMessage()    {}
+func (*OpenWalletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+
+func (m *OpenWalletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenWalletResponse.Unmarshal(m, b)
+}
+func (m *OpenWalletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenWalletResponse.Marshal(b, m, deterministic)
+}
+func (m *OpenWalletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenWalletResponse.Merge(m, src)
+}
+func (m *OpenWalletResponse) XXX_Size() int {
+	return xxx_messageInfo_OpenWalletResponse.Size(m)
+}
+func (m *OpenWalletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenWalletResponse.DiscardUnknown(m)
 }
 
-func (m *OpenWalletResponse) Reset()                    { *m = OpenWalletResponse{} }
-func (m *OpenWalletResponse) String() string            { return proto.CompactTextString(m) }
-func (*OpenWalletResponse) ProtoMessage()               {}
-func (*OpenWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+var xxx_messageInfo_OpenWalletResponse proto.InternalMessageInfo
 
 type CloseWalletRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseWalletRequest) Reset()         { *m = CloseWalletRequest{} }
+func (m *CloseWalletRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseWalletRequest) ProtoMessage()    {}
+func (*CloseWalletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+
+func (m *CloseWalletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseWalletRequest.Unmarshal(m, b)
+}
+func (m *CloseWalletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseWalletRequest.Marshal(b, m, deterministic)
+}
+func (m *CloseWalletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseWalletRequest.Merge(m, src)
+}
+func (m *CloseWalletRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseWalletRequest.Size(m)
+}
+func (m *CloseWalletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseWalletRequest.DiscardUnknown(m)
 }
 
-func (m *CloseWalletRequest) Reset()                    { *m = CloseWalletRequest{} }
-func (m *CloseWalletRequest) String() string            { return proto.CompactTextString(m) }
-func (*CloseWalletRequest) ProtoMessage()               {}
-func (*CloseWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+var xxx_messageInfo_CloseWalletRequest proto.InternalMessageInfo
 
 type CloseWalletResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseWalletResponse) Reset()         { *m = CloseWalletResponse{} }
+func (m *CloseWalletResponse) String() string { return proto.CompactTextString(m) }
+func (*CloseWalletResponse) ProtoMessage()    {}
+func (*CloseWalletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+
+func (m *CloseWalletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseWalletResponse.Unmarshal(m, b)
+}
+func (m *CloseWalletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseWalletResponse.Marshal(b, m, deterministic)
+}
+func (m *CloseWalletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseWalletResponse.Merge(m, src)
+}
+func (m *CloseWalletResponse) XXX_Size() int {
+	return xxx_messageInfo_CloseWalletResponse.Size(m)
+}
+func (m *CloseWalletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseWalletResponse.DiscardUnknown(m)
 }
 
-func (m *CloseWalletResponse) Reset()                    { *m = CloseWalletResponse{} }
-func (m *CloseWalletResponse) String() string            { return proto.CompactTextString(m) }
-func (*CloseWalletResponse) ProtoMessage()               {}
-func (*CloseWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+var xxx_messageInfo_CloseWalletResponse proto.InternalMessageInfo
 
 type WalletExistsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WalletExistsRequest) Reset()         { *m = WalletExistsRequest{} }
+func (m *WalletExistsRequest) String() string { return proto.CompactTextString(m) }
+func (*WalletExistsRequest) ProtoMessage()    {}
+func (*WalletExistsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
+}
+
+func (m *WalletExistsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletExistsRequest.Unmarshal(m, b)
+}
+func (m *WalletExistsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletExistsRequest.Marshal(b, m, deterministic)
+}
+func (m *WalletExistsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletExistsRequest.Merge(m, src)
+}
+func (m *WalletExistsRequest) XXX_Size() int {
+	return xxx_messageInfo_WalletExistsRequest.Size(m)
+}
+func (m *WalletExistsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletExistsRequest.DiscardUnknown(m)
 }
 
-func (m *WalletExistsRequest) Reset()                    { *m = WalletExistsRequest{} }
-func (m *WalletExistsRequest) String() string            { return proto.CompactTextString(m) }
-func (*WalletExistsRequest) ProtoMessage()               {}
-func (*WalletExistsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+var xxx_messageInfo_WalletExistsRequest proto.InternalMessageInfo
 
 type WalletExistsResponse struct {
-	Exists bool `protobuf:"varint,1,opt,name=exists" json:"exists,omitempty"`
+	Exists               bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WalletExistsResponse) Reset()         { *m = WalletExistsResponse{} }
+func (m *WalletExistsResponse) String() string { return proto.CompactTextString(m) }
+func (*WalletExistsResponse) ProtoMessage()    {}
+func (*WalletExistsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+}
+
+func (m *WalletExistsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletExistsResponse.Unmarshal(m, b)
+}
+func (m *WalletExistsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletExistsResponse.Marshal(b, m, deterministic)
+}
+func (m *WalletExistsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletExistsResponse.Merge(m, src)
+}
+func (m *WalletExistsResponse) XXX_Size() int {
+	return xxx_messageInfo_WalletExistsResponse.Size(m)
+}
+func (m *WalletExistsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletExistsResponse.DiscardUnknown(m)
 }
 
-func (m *WalletExistsResponse) Reset()                    { *m = WalletExistsResponse{} }
-func (m *WalletExistsResponse) String() string            { return proto.CompactTextString(m) }
-func (*WalletExistsResponse) ProtoMessage()               {}
-func (*WalletExistsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+var xxx_messageInfo_WalletExistsResponse proto.InternalMessageInfo
 
 func (m *WalletExistsResponse) GetExists() bool {
 	if m != nil {
@@ -1430,16 +2682,39 @@ func (m *WalletExistsResponse) GetExists() bool {
 }
 
 type StartConsensusRpcRequest struct {
-	NetworkAddress string `protobuf:"bytes,1,opt,name=network_address,json=networkAddress" json:"network_address,omitempty"`
-	Username       string `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
-	Password       []byte `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	Certificate    []byte `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	NetworkAddress       string   `protobuf:"bytes,1,opt,name=network_address,json=networkAddress,proto3" json:"network_address,omitempty"`
+	Username             string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password             []byte   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Certificate          []byte   `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartConsensusRpcRequest) Reset()         { *m = StartConsensusRpcRequest{} }
+func (m *StartConsensusRpcRequest) String() string { return proto.CompactTextString(m) }
+func (*StartConsensusRpcRequest) ProtoMessage()    {}
+func (*StartConsensusRpcRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+}
+
+func (m *StartConsensusRpcRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartConsensusRpcRequest.Unmarshal(m, b)
+}
+func (m *StartConsensusRpcRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartConsensusRpcRequest.Marshal(b, m, deterministic)
+}
+func (m *StartConsensusRpcRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartConsensusRpcRequest.Merge(m, src)
+}
+func (m *StartConsensusRpcRequest) XXX_Size() int {
+	return xxx_messageInfo_StartConsensusRpcRequest.Size(m)
+}
+func (m *StartConsensusRpcRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartConsensusRpcRequest.DiscardUnknown(m)
 }
 
-func (m *StartConsensusRpcRequest) Reset()                    { *m = StartConsensusRpcRequest{} }
-func (m *StartConsensusRpcRequest) String() string            { return proto.CompactTextString(m) }
-func (*StartConsensusRpcRequest) ProtoMessage()               {}
-func (*StartConsensusRpcRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+var xxx_messageInfo_StartConsensusRpcRequest proto.InternalMessageInfo
 
 func (m *StartConsensusRpcRequest) GetNetworkAddress() string {
 	if m != nil {
@@ -1470,14 +2745,39 @@ func (m *StartConsensusRpcRequest) GetCertificate() []byte {
 }
 
 type StartConsensusRpcResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartConsensusRpcResponse) Reset()         { *m = StartConsensusRpcResponse{} }
+func (m *StartConsensusRpcResponse) String() string { return proto.CompactTextString(m) }
+func (*StartConsensusRpcResponse) ProtoMessage()    {}
+func (*StartConsensusRpcResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+}
+
+func (m *StartConsensusRpcResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartConsensusRpcResponse.Unmarshal(m, b)
+}
+func (m *StartConsensusRpcResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartConsensusRpcResponse.Marshal(b, m, deterministic)
+}
+func (m *StartConsensusRpcResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartConsensusRpcResponse.Merge(m, src)
+}
+func (m *StartConsensusRpcResponse) XXX_Size() int {
+	return xxx_messageInfo_StartConsensusRpcResponse.Size(m)
+}
+func (m *StartConsensusRpcResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartConsensusRpcResponse.DiscardUnknown(m)
 }
 
-func (m *StartConsensusRpcResponse) Reset()                    { *m = StartConsensusRpcResponse{} }
-func (m *StartConsensusRpcResponse) String() string            { return proto.CompactTextString(m) }
-func (*StartConsensusRpcResponse) ProtoMessage()               {}
-func (*StartConsensusRpcResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+var xxx_messageInfo_StartConsensusRpcResponse proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterEnum("walletrpc.NextAddressRequest_Kind", NextAddressRequest_Kind_name, NextAddressRequest_Kind_value)
+	proto.RegisterEnum("walletrpc.ChangePassphraseRequest_Key", ChangePassphraseRequest_Key_name, ChangePassphraseRequest_Key_value)
 	proto.RegisterType((*VersionRequest)(nil), "walletrpc.VersionRequest")
 	proto.RegisterType((*VersionResponse)(nil), "walletrpc.VersionResponse")
 	proto.RegisterType((*TransactionDetails)(nil), "walletrpc.TransactionDetails")
@@ -1522,6 +2822,8 @@ func init() {
 	proto.RegisterType((*SpentnessNotificationsResponse_Spender)(nil), "walletrpc.SpentnessNotificationsResponse.Spender")
 	proto.RegisterType((*AccountNotificationsRequest)(nil), "walletrpc.AccountNotificationsRequest")
 	proto.RegisterType((*AccountNotificationsResponse)(nil), "walletrpc.AccountNotificationsResponse")
+	proto.RegisterType((*ConfirmationNotificationsRequest)(nil), "walletrpc.ConfirmationNotificationsRequest")
+	proto.RegisterType((*ConfirmationNotificationsResponse)(nil), "walletrpc.ConfirmationNotificationsResponse")
 	proto.RegisterType((*CreateWalletRequest)(nil), "walletrpc.CreateWalletRequest")
 	proto.RegisterType((*CreateWalletResponse)(nil), "walletrpc.CreateWalletResponse")
 	proto.RegisterType((*OpenWalletRequest)(nil), "walletrpc.OpenWalletRequest")
@@ -1532,47 +2834,229 @@ func init() {
 	proto.RegisterType((*WalletExistsResponse)(nil), "walletrpc.WalletExistsResponse")
 	proto.RegisterType((*StartConsensusRpcRequest)(nil), "walletrpc.StartConsensusRpcRequest")
 	proto.RegisterType((*StartConsensusRpcResponse)(nil), "walletrpc.StartConsensusRpcResponse")
-	proto.RegisterEnum("walletrpc.NextAddressRequest_Kind", NextAddressRequest_Kind_name, NextAddressRequest_Kind_value)
-	proto.RegisterEnum("walletrpc.ChangePassphraseRequest_Key", ChangePassphraseRequest_Key_name, ChangePassphraseRequest_Key_value)
+}
+
+func init() {
+	proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c)
+}
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 2685 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x3a, 0x5b, 0x6f, 0x1b, 0xc7,
+	0xd5, 0x59, 0x51, 0x17, 0xea, 0xf0, 0x3e, 0xa4, 0x24, 0x6a, 0x6d, 0xc9, 0xd2, 0xda, 0x89, 0x6d,
+	0xc5, 0x91, 0x6c, 0x7d, 0xce, 0x87, 0xd4, 0x0d, 0xdc, 0xd8, 0xaa, 0xd3, 0xa8, 0x76, 0x65, 0x61,
+	0x65, 0xc7, 0x29, 0x50, 0x94, 0x58, 0x71, 0x47, 0xd2, 0x54, 0xe4, 0xec, 0x7a, 0x77, 0x29, 0x59,
+	0x29, 0x0a, 0x04, 0x29, 0xda, 0xb7, 0xbe, 0x34, 0x7d, 0x28, 0x5a, 0xe4, 0xa5, 0xbf, 0xa0, 0x40,
+	0x5f, 0xfa, 0xd8, 0xfc, 0x83, 0x02, 0x7d, 0x6b, 0x5f, 0xfb, 0x0b, 0xfa, 0x0b, 0x8a, 0xb9, 0x91,
+	0x33, 0xdc, 0x25, 0x25, 0x05, 0x7d, 0xd3, 0x9e, 0xfb, 0x9c, 0x39, 0xb7, 0x39, 0x14, 0xcc, 0x7a,
+	0x21, 0x59, 0x0f, 0xa3, 0x20, 0x09, 0xd0, 0xec, 0xa9, 0xd7, 0xe9, 0xe0, 0x24, 0x0a, 0xdb, 0xf6,
+	0xd5, 0xc3, 0x20, 0x38, 0xec, 0xe0, 0x0d, 0x2f, 0x24, 0x1b, 0x1e, 0xa5, 0x41, 0xe2, 0x25, 0x24,
+	0xa0, 0xb1, 0x20, 0x74, 0xaa, 0x50, 0xfe, 0x14, 0x47, 0x31, 0x09, 0xa8, 0x8b, 0x5f, 0xf7, 0x70,
+	0x9c, 0x38, 0xdf, 0x58, 0x50, 0xe9, 0x83, 0xe2, 0x30, 0xa0, 0x31, 0x46, 0x6f, 0x43, 0xf9, 0x44,
+	0x80, 0x5a, 0x71, 0x12, 0x11, 0x7a, 0xd8, 0xb4, 0x56, 0xac, 0x5b, 0xb3, 0x6e, 0x49, 0x42, 0xf7,
+	0x38, 0x10, 0x35, 0x60, 0xaa, 0xeb, 0xfd, 0x2c, 0x88, 0x9a, 0x13, 0x2b, 0xd6, 0xad, 0x92, 0x2b,
+	0x3e, 0x38, 0x94, 0xd0, 0x20, 0x6a, 0xe6, 0x24, 0x94, 0x50, 0x01, 0x0d, 0xbd, 0xa4, 0x7d, 0xd4,
+	0x9c, 0x14, 0x50, 0xfe, 0x81, 0x96, 0x01, 0xc2, 0x08, 0x47, 0xb8, 0x83, 0xbd, 0x18, 0x37, 0xa7,
+	0xb8, 0x12, 0x0d, 0xc2, 0x0c, 0xd9, 0xef, 0x91, 0x8e, 0xdf, 0xea, 0xe2, 0xc4, 0xf3, 0xbd, 0xc4,
+	0x6b, 0x4e, 0x0b, 0x43, 0x38, 0xf4, 0x47, 0x12, 0xe8, 0xfc, 0x2d, 0x07, 0xe8, 0x45, 0xe4, 0xd1,
+	0xd8, 0x6b, 0xb3, 0xc3, 0x7e, 0x1f, 0x27, 0x1e, 0xe9, 0xc4, 0x08, 0xc1, 0xe4, 0x91, 0x17, 0x1f,
+	0x71, 0xe3, 0x8b, 0x2e, 0xff, 0x1b, 0xad, 0x40, 0x21, 0x19, 0x50, 0x72, 0xcb, 0x8b, 0xae, 0x0e,
+	0x42, 0xdf, 0x85, 0x69, 0x1f, 0xef, 0x93, 0x24, 0x6e, 0xe6, 0x56, 0x72, 0xb7, 0x0a, 0x9b, 0xd7,
+	0xd7, 0xfb, 0xce, 0x5d, 0x4f, 0x2b, 0x59, 0xdf, 0xa6, 0x61, 0x2f, 0x71, 0x25, 0x0b, 0x7a, 0x08,
+	0x33, 0xed, 0x08, 0xfb, 0x8c, 0x7b, 0x92, 0x73, 0xdf, 0x18, 0xcf, 0xfd, 0xbc, 0x97, 0x30, 0x76,
+	0xc5, 0x84, 0xaa, 0x90, 0x3b, 0xc0, 0xc2, 0x13, 0x39, 0x97, 0xfd, 0x89, 0xae, 0xc2, 0x6c, 0x42,
+	0xba, 0x38, 0x4e, 0xbc, 0x6e, 0xc8, 0x4f, 0x9f, 0x73, 0x07, 0x00, 0xfb, 0x35, 0x4c, 0x71, 0x03,
+	0x98, 0x7f, 0x09, 0xf5, 0xf1, 0x1b, 0x7e, 0xd8, 0x92, 0x2b, 0x3e, 0xd0, 0x6d, 0xa8, 0x86, 0x11,
+	0x3e, 0x21, 0x41, 0x2f, 0x6e, 0x79, 0xed, 0x76, 0xd0, 0xa3, 0x89, 0xbc, 0xac, 0x8a, 0x82, 0x3f,
+	0x12, 0x60, 0x74, 0x13, 0x2a, 0x03, 0xd2, 0x2e, 0xa7, 0xcc, 0x71, 0x6d, 0xe5, 0x3e, 0x25, 0x87,
+	0xda, 0x2f, 0x60, 0x5a, 0x58, 0x3d, 0x42, 0x67, 0x13, 0x66, 0x4c, 0x55, 0xea, 0x13, 0xd9, 0x90,
+	0x27, 0x34, 0xc1, 0x11, 0xf5, 0x3a, 0x5c, 0x76, 0xde, 0xed, 0x7f, 0x3b, 0x7f, 0xb4, 0xa0, 0xf8,
+	0xb8, 0x13, 0xb4, 0x8f, 0xc7, 0x5d, 0xde, 0x3c, 0x4c, 0x1f, 0x61, 0x72, 0x78, 0x24, 0x24, 0x4f,
+	0xb9, 0xf2, 0xcb, 0xf4, 0x51, 0x6e, 0xc8, 0x47, 0xe8, 0x11, 0x14, 0xb5, 0xfb, 0x55, 0x17, 0xb3,
+	0x34, 0xf6, 0x62, 0x5c, 0x83, 0xc5, 0x79, 0x0e, 0x65, 0xe9, 0xa7, 0xc7, 0x5e, 0xc7, 0xa3, 0x6d,
+	0xac, 0x9f, 0xd2, 0x32, 0x4f, 0x79, 0x1d, 0x4a, 0x49, 0x90, 0x78, 0x9d, 0xd6, 0xbe, 0x20, 0xe5,
+	0xb6, 0xe6, 0xdc, 0x22, 0x07, 0x4a, 0x76, 0xa7, 0x04, 0x85, 0x5d, 0x42, 0x0f, 0x55, 0x12, 0x96,
+	0xa1, 0x28, 0x3e, 0x45, 0x02, 0xb2, 0x34, 0xdd, 0xc1, 0xc9, 0x69, 0x10, 0x1d, 0x2b, 0x8a, 0x0f,
+	0xa0, 0xd2, 0x87, 0x0c, 0xb2, 0x94, 0xd9, 0x77, 0x82, 0x5b, 0x54, 0x60, 0xa4, 0x25, 0x25, 0x01,
+	0x95, 0xe4, 0xce, 0x77, 0xa0, 0x21, 0x6d, 0xdf, 0xe9, 0x75, 0xf7, 0x71, 0x24, 0x25, 0xa2, 0x55,
+	0x28, 0x4a, 0x93, 0x5b, 0xd4, 0xeb, 0x62, 0x99, 0xe2, 0x05, 0x09, 0xdb, 0xf1, 0xba, 0xd8, 0x79,
+	0x08, 0x73, 0x43, 0xac, 0xba, 0x6a, 0xc9, 0xcb, 0x31, 0x03, 0xd5, 0x1a, 0xb9, 0x53, 0x83, 0x8a,
+	0xe4, 0x8f, 0xd5, 0x39, 0xfe, 0x9a, 0x83, 0xea, 0x00, 0x26, 0xc5, 0x7d, 0x0f, 0xf2, 0x92, 0x31,
+	0x6e, 0x5a, 0xa9, 0xa4, 0x1b, 0x26, 0x57, 0x00, 0xb7, 0xcf, 0x84, 0xee, 0x00, 0x6a, 0xf7, 0xa2,
+	0x08, 0xd3, 0xa4, 0xb5, 0xcf, 0x82, 0xa8, 0xc5, 0x43, 0x47, 0x24, 0x77, 0x55, 0x62, 0x78, 0x74,
+	0x7d, 0xc2, 0xc2, 0xe8, 0x2e, 0x34, 0x86, 0xa8, 0x45, 0x50, 0xe5, 0x78, 0x50, 0x21, 0x83, 0x9e,
+	0x63, 0xec, 0x2f, 0x27, 0x60, 0x46, 0x25, 0xca, 0xc5, 0xce, 0x9e, 0x72, 0xef, 0x44, 0xca, 0xbd,
+	0xe9, 0x48, 0xc9, 0xa5, 0x23, 0x85, 0x1d, 0x0d, 0xbf, 0x11, 0x49, 0xd2, 0x3a, 0xc6, 0x67, 0x2d,
+	0x11, 0x73, 0xa2, 0x8a, 0x56, 0x15, 0xe6, 0x29, 0x3e, 0xdb, 0xe2, 0xc6, 0xdd, 0x01, 0x44, 0x68,
+	0x8a, 0x7a, 0x4a, 0x50, 0x13, 0x9a, 0x41, 0xdd, 0x0d, 0x83, 0x28, 0xc1, 0xbe, 0x46, 0x3d, 0x2d,
+	0xa9, 0x25, 0x46, 0x51, 0x3b, 0x9f, 0x41, 0xc3, 0xc5, 0xec, 0x2c, 0xca, 0xff, 0x32, 0x90, 0x2e,
+	0xe8, 0x90, 0x45, 0xc8, 0x53, 0x7c, 0xaa, 0x3b, 0x63, 0x86, 0xe2, 0x53, 0x1e, 0x67, 0x0b, 0x30,
+	0x37, 0x24, 0x59, 0xe6, 0xc1, 0x2b, 0x40, 0x3b, 0xf8, 0x4d, 0x32, 0xa4, 0x90, 0x75, 0x0d, 0x2f,
+	0x8e, 0xc3, 0xa3, 0x88, 0x75, 0x0d, 0x51, 0x20, 0x34, 0xc8, 0x05, 0x5c, 0xef, 0x7c, 0x08, 0x75,
+	0x43, 0xf0, 0xe5, 0xe2, 0xfa, 0x0f, 0x96, 0xb4, 0xcb, 0xf7, 0x23, 0x1c, 0xab, 0xd8, 0x1e, 0x53,
+	0x13, 0xfe, 0x1f, 0x26, 0x8f, 0x09, 0xf5, 0xb9, 0x25, 0xe5, 0x4d, 0x47, 0x0b, 0xee, 0xb4, 0x98,
+	0xf5, 0xa7, 0x84, 0xfa, 0x2e, 0xa7, 0x77, 0x36, 0x61, 0x92, 0x7d, 0xa1, 0x06, 0x54, 0x1f, 0x6f,
+	0xef, 0xde, 0xbd, 0x7b, 0xff, 0x7e, 0xeb, 0xc9, 0x67, 0x2f, 0x9e, 0xb8, 0x3b, 0x8f, 0x9e, 0x55,
+	0xdf, 0xd2, 0xa1, 0xdb, 0x3b, 0x12, 0x6a, 0x39, 0x1b, 0x50, 0x37, 0x84, 0xca, 0xa3, 0x31, 0xe3,
+	0x04, 0x48, 0x66, 0xba, 0xfa, 0x74, 0xbe, 0xb2, 0x60, 0x61, 0x9b, 0x5f, 0xf6, 0x6e, 0x44, 0x4e,
+	0xbc, 0x04, 0x3f, 0xc5, 0x67, 0x17, 0x75, 0xf5, 0xe8, 0x62, 0xff, 0x0e, 0xeb, 0x27, 0x5c, 0x1c,
+	0x0f, 0xad, 0x53, 0x72, 0xc0, 0xc3, 0x7b, 0xd6, 0x2d, 0x85, 0x7d, 0x2d, 0xaf, 0xc8, 0x01, 0xab,
+	0xe9, 0x11, 0x8e, 0xdb, 0x1e, 0xe5, 0x31, 0x9d, 0x77, 0xe5, 0x97, 0x63, 0x43, 0x33, 0x6d, 0x94,
+	0x0c, 0x0b, 0x0a, 0x65, 0x99, 0x1e, 0x97, 0x8c, 0xc1, 0xf7, 0x61, 0x3e, 0xc2, 0xaf, 0x7b, 0x24,
+	0xc2, 0x7e, 0xab, 0x1d, 0xd0, 0x03, 0x12, 0x75, 0xc5, 0x78, 0x24, 0x1b, 0xca, 0x9c, 0xc2, 0x6e,
+	0xe9, 0x48, 0x87, 0x42, 0xa5, 0xaf, 0x4f, 0xba, 0xb3, 0x01, 0x53, 0x3c, 0x4d, 0xb9, 0x9e, 0x9c,
+	0x2b, 0x3e, 0x58, 0x23, 0x8a, 0x43, 0x4c, 0x7d, 0x6f, 0xbf, 0xa3, 0xea, 0xfe, 0x00, 0xc0, 0x5a,
+	0x2c, 0xe9, 0x76, 0xbd, 0xa4, 0x17, 0xe1, 0x56, 0x84, 0x4f, 0xbd, 0xc8, 0x57, 0x2d, 0x56, 0x81,
+	0x5d, 0x0e, 0x75, 0x7e, 0x3f, 0x01, 0xf3, 0x3f, 0xc0, 0x89, 0xd6, 0x96, 0xfa, 0x31, 0xb6, 0x0e,
+	0xf5, 0x38, 0xf1, 0xa2, 0x84, 0xd0, 0x43, 0xbd, 0xd4, 0x89, 0x9b, 0xa9, 0x29, 0xd4, 0xa0, 0xd6,
+	0x6d, 0xc2, 0xdc, 0x30, 0xfd, 0xa0, 0x83, 0xd6, 0xdc, 0xba, 0xc9, 0xc1, 0x51, 0x68, 0x0d, 0x6a,
+	0x98, 0xfa, 0x43, 0x1a, 0x72, 0x5c, 0x43, 0x45, 0x20, 0x06, 0xf2, 0xd7, 0xa1, 0x6e, 0xd2, 0x0a,
+	0xe9, 0x93, 0xdc, 0x9d, 0x35, 0x9d, 0x5a, 0xc8, 0x7e, 0x08, 0x57, 0xba, 0x84, 0x92, 0x6e, 0xaf,
+	0xdb, 0x8a, 0x70, 0x9b, 0x95, 0x60, 0xa3, 0x37, 0x4f, 0x71, 0xbe, 0x45, 0x49, 0xe2, 0x72, 0x0a,
+	0xdd, 0x0d, 0xce, 0x5f, 0x2c, 0x58, 0x48, 0xb9, 0x46, 0xde, 0xc9, 0xc7, 0x80, 0xba, 0x84, 0x62,
+	0xdf, 0x14, 0x29, 0x1a, 0xca, 0x82, 0x96, 0x73, 0xfa, 0x9c, 0xe1, 0xd6, 0x38, 0x8b, 0x2e, 0x0f,
+	0xed, 0x42, 0xa3, 0x47, 0x33, 0x24, 0x4d, 0x5c, 0x64, 0x70, 0xa8, 0x4b, 0x56, 0xc3, 0xea, 0x6f,
+	0x2c, 0x58, 0xd8, 0x3a, 0xf2, 0xe8, 0x21, 0xde, 0xed, 0xe7, 0x8e, 0xba, 0xd1, 0x0f, 0x20, 0x77,
+	0x8c, 0xcf, 0xf8, 0x0d, 0x96, 0x37, 0xdf, 0xd1, 0x84, 0x8f, 0x60, 0x58, 0x67, 0x99, 0xc0, 0x58,
+	0x58, 0xd0, 0x07, 0x1d, 0xbf, 0xa5, 0x25, 0xa8, 0xe8, 0x78, 0xa5, 0xa0, 0xe3, 0x0f, 0xd8, 0x18,
+	0x19, 0x2b, 0xbc, 0x1a, 0x99, 0xb8, 0xcb, 0x12, 0xc5, 0xa7, 0x03, 0x32, 0x67, 0x19, 0x72, 0x4f,
+	0xf1, 0x19, 0x2a, 0xc0, 0xcc, 0xae, 0xbb, 0xfd, 0xe9, 0xa3, 0x17, 0x4f, 0xaa, 0x6f, 0x21, 0x80,
+	0xe9, 0xdd, 0x97, 0x8f, 0x9f, 0x6d, 0x6f, 0x55, 0x2d, 0x96, 0x90, 0x69, 0x8b, 0x64, 0x42, 0x7e,
+	0x31, 0x01, 0xf3, 0x1f, 0xf7, 0xa8, 0x7e, 0xe8, 0xf3, 0x8b, 0x22, 0x6b, 0x7f, 0x5e, 0x74, 0x88,
+	0x13, 0x35, 0x6f, 0xaa, 0x41, 0x89, 0x03, 0xc5, 0xb4, 0x39, 0x26, 0x63, 0x73, 0x63, 0x32, 0x16,
+	0x7d, 0x08, 0x36, 0xa1, 0xed, 0x4e, 0xcf, 0xc7, 0xad, 0x7e, 0xca, 0xb5, 0x03, 0x42, 0xf7, 0xbd,
+	0x18, 0xc7, 0xb2, 0xd2, 0x34, 0x25, 0xc5, 0xb6, 0x24, 0xd8, 0x52, 0x78, 0x96, 0x34, 0x8a, 0xbb,
+	0xcd, 0x8f, 0xdc, 0x8a, 0xdb, 0x11, 0x09, 0x45, 0x23, 0xcd, 0xbb, 0x75, 0x89, 0x14, 0xee, 0xd8,
+	0xe3, 0x28, 0xe7, 0x4f, 0x39, 0x58, 0x48, 0xb9, 0x40, 0x06, 0xe6, 0x4f, 0xa0, 0x1a, 0xe3, 0x0e,
+	0x6e, 0xb3, 0x3e, 0x1b, 0xf0, 0xd9, 0x59, 0x85, 0xe5, 0x3d, 0xed, 0xbe, 0x47, 0x70, 0xaf, 0xef,
+	0xca, 0xf9, 0x5b, 0xbe, 0x15, 0x2a, 0x4a, 0x94, 0xf8, 0x8e, 0x59, 0xbb, 0x13, 0x63, 0x84, 0xe1,
+	0xc6, 0x02, 0x87, 0x49, 0x2f, 0xde, 0x82, 0xaa, 0x3c, 0x48, 0x78, 0xac, 0xce, 0x22, 0x82, 0xa0,
+	0x2c, 0xe0, 0xbb, 0xc7, 0xe2, 0x18, 0xf6, 0xbf, 0x2c, 0x28, 0x9b, 0x0a, 0xd9, 0x23, 0x42, 0x4b,
+	0x03, 0xbd, 0xde, 0x54, 0x34, 0x38, 0xaf, 0x06, 0xab, 0x50, 0x14, 0xe7, 0x6b, 0x89, 0x87, 0x81,
+	0xe8, 0x09, 0x05, 0x01, 0xdb, 0x66, 0x20, 0x56, 0xef, 0x8d, 0xe7, 0x85, 0xfc, 0x42, 0x57, 0x60,
+	0x76, 0x60, 0xdb, 0x24, 0x17, 0x9f, 0x0f, 0xa5, 0x55, 0x4c, 0x2e, 0xab, 0x16, 0x6c, 0xd6, 0x65,
+	0x73, 0xbd, 0x7c, 0x1f, 0x15, 0x24, 0xec, 0x05, 0x11, 0xc3, 0xd4, 0x41, 0x14, 0x74, 0xfb, 0xb7,
+	0xcc, 0xc7, 0x98, 0xbc, 0x5b, 0x64, 0x40, 0x75, 0xb3, 0xce, 0xef, 0x2c, 0x98, 0xdf, 0x23, 0x87,
+	0x34, 0x23, 0x4e, 0xcf, 0xeb, 0x74, 0xef, 0xc3, 0x7c, 0x8c, 0x23, 0xe2, 0x75, 0xc8, 0xe7, 0x66,
+	0x5d, 0x90, 0x49, 0x37, 0x37, 0xc0, 0x6a, 0xd2, 0x99, 0x59, 0x84, 0xf6, 0x1d, 0x82, 0xc5, 0xa3,
+	0xb2, 0xe4, 0x16, 0x09, 0x55, 0x1e, 0xc1, 0xb1, 0xf3, 0x1a, 0x16, 0x52, 0x56, 0xc9, 0xd0, 0x19,
+	0x7a, 0xaf, 0x5a, 0xe9, 0xf7, 0xea, 0x7d, 0x98, 0xef, 0xd1, 0x98, 0x1c, 0xb2, 0x72, 0x65, 0xaa,
+	0x9a, 0xe0, 0xaa, 0x1a, 0x0a, 0xbb, 0xad, 0xab, 0xfc, 0x21, 0x2c, 0xee, 0xf6, 0xf6, 0x3b, 0x24,
+	0x3e, 0xca, 0xf0, 0xc5, 0x7b, 0x80, 0xa4, 0xc0, 0xb4, 0xee, 0x9a, 0xc0, 0x68, 0x5c, 0xce, 0x55,
+	0xb0, 0xb3, 0x64, 0xc9, 0xda, 0xb0, 0x0a, 0xd7, 0x34, 0xf0, 0x4e, 0x90, 0x90, 0x03, 0xd2, 0xf6,
+	0xf4, 0xa6, 0xe6, 0x7c, 0x3d, 0x01, 0x2b, 0xa3, 0x69, 0xa4, 0x27, 0x3e, 0x82, 0x8a, 0x97, 0x24,
+	0x5e, 0xfb, 0x08, 0xfb, 0xa2, 0xd7, 0x9c, 0x5b, 0xda, 0xcb, 0x8a, 0x9e, 0x43, 0x63, 0xd6, 0x7f,
+	0x7d, 0x6c, 0x4a, 0x60, 0x2e, 0x2a, 0xba, 0x65, 0x1f, 0x1b, 0x84, 0xa3, 0x1a, 0x40, 0xee, 0xdb,
+	0x36, 0x00, 0x56, 0x8f, 0x32, 0x24, 0xf2, 0x5c, 0xc2, 0xe2, 0x45, 0x5a, 0x74, 0x9b, 0x69, 0xc6,
+	0x4f, 0x38, 0xde, 0xf9, 0x8d, 0x05, 0x4b, 0x7b, 0x21, 0xa6, 0x09, 0xc5, 0x71, 0x9c, 0xe5, 0xc1,
+	0x31, 0x55, 0x76, 0x0d, 0x6a, 0x34, 0x68, 0x51, 0xc6, 0x74, 0xd6, 0xea, 0xd1, 0x98, 0x89, 0xe1,
+	0x21, 0x9b, 0x77, 0x2b, 0x34, 0xe0, 0xc2, 0xce, 0x5e, 0x0a, 0x30, 0x9b, 0xd9, 0x06, 0xb4, 0x82,
+	0x52, 0xbc, 0xd3, 0x4b, 0x8a, 0x92, 0x5b, 0xe1, 0xfc, 0x76, 0x02, 0x96, 0x47, 0xd9, 0x23, 0x6f,
+	0xeb, 0x7f, 0x5b, 0x34, 0x9e, 0xc2, 0x0c, 0x1f, 0xa3, 0xb0, 0xd8, 0x2a, 0x99, 0x75, 0x73, 0xbc,
+	0x25, 0x1c, 0xed, 0xe3, 0xc8, 0x55, 0x12, 0xec, 0x97, 0x30, 0x23, 0x61, 0x97, 0xb1, 0xf2, 0x1a,
+	0x14, 0x08, 0x1d, 0x36, 0x12, 0x06, 0x69, 0xec, 0x2c, 0xc1, 0x15, 0xf5, 0x58, 0xce, 0x8a, 0xf1,
+	0xff, 0x58, 0x70, 0x35, 0x1b, 0x7f, 0xa9, 0xb7, 0xc7, 0x45, 0xde, 0x95, 0xd9, 0x4f, 0xc6, 0xdc,
+	0xa5, 0x9e, 0x8c, 0x93, 0x97, 0x7a, 0x32, 0x4e, 0x8d, 0x78, 0x32, 0x7e, 0x61, 0xc1, 0x8a, 0xde,
+	0x98, 0x33, 0x63, 0xf7, 0x12, 0x97, 0x70, 0x0f, 0x1a, 0x72, 0x64, 0xc8, 0x9a, 0xde, 0xeb, 0x02,
+	0x67, 0xce, 0xee, 0x7f, 0xb7, 0x60, 0x75, 0x8c, 0x09, 0x97, 0x0f, 0xd7, 0x1b, 0x50, 0xca, 0x52,
+	0x6e, 0x02, 0xd1, 0x12, 0x40, 0x6a, 0x78, 0x9e, 0xdd, 0xef, 0x8f, 0xcd, 0xab, 0x50, 0xcc, 0x98,
+	0x97, 0x0b, 0xfb, 0xda, 0xa4, 0xdc, 0x84, 0x99, 0x08, 0xf3, 0xaa, 0x24, 0xc7, 0x0e, 0xf5, 0xe9,
+	0xfc, 0xca, 0x82, 0xfa, 0x56, 0x84, 0xbd, 0x04, 0xbf, 0xe2, 0x49, 0xa0, 0x1c, 0xf9, 0x2e, 0xd4,
+	0x42, 0x56, 0x87, 0xdb, 0xad, 0x54, 0x27, 0xab, 0x0a, 0x84, 0x36, 0x15, 0xbe, 0x07, 0x48, 0xbd,
+	0xcf, 0x52, 0x03, 0x64, 0x4d, 0x62, 0x34, 0x72, 0x04, 0x93, 0x31, 0xc6, 0xbe, 0x3c, 0x09, 0xff,
+	0xdb, 0x99, 0x87, 0x86, 0x69, 0x86, 0xac, 0xf8, 0x1f, 0x41, 0xed, 0x79, 0x88, 0xe9, 0xb7, 0x37,
+	0xce, 0x69, 0x00, 0xd2, 0x25, 0x48, 0xb9, 0x0d, 0x40, 0x5b, 0x9d, 0x20, 0x36, 0x4f, 0xed, 0xcc,
+	0x41, 0xdd, 0x80, 0x4a, 0xe2, 0x39, 0xa8, 0x0b, 0xc8, 0x93, 0x37, 0x24, 0x1e, 0xec, 0x9f, 0xd6,
+	0xa1, 0x61, 0x82, 0x65, 0x00, 0xcc, 0xc3, 0x34, 0xe6, 0x10, 0x6e, 0x53, 0xde, 0x95, 0x5f, 0xce,
+	0xd7, 0x16, 0x34, 0xf7, 0x12, 0x2f, 0x62, 0x51, 0x15, 0x63, 0x1a, 0xf7, 0x62, 0x37, 0x6c, 0xab,
+	0x33, 0xdd, 0x84, 0x8a, 0x5c, 0xbd, 0xb5, 0xcc, 0xb7, 0x75, 0x59, 0x82, 0xe5, 0x23, 0x9c, 0x6d,
+	0x3e, 0x7b, 0x31, 0x8e, 0xb4, 0x84, 0xed, 0x7f, 0x33, 0x1c, 0xf3, 0xc8, 0x69, 0x10, 0x29, 0xef,
+	0xf6, 0xbf, 0x59, 0xf7, 0x6f, 0xe3, 0x48, 0x06, 0x2c, 0x96, 0x63, 0x91, 0x0e, 0x72, 0xae, 0xc0,
+	0x62, 0x86, 0x79, 0xe2, 0x50, 0x9b, 0x87, 0xfd, 0x6d, 0xff, 0x1e, 0x8e, 0x4e, 0x48, 0x1b, 0xa3,
+	0x97, 0x30, 0x23, 0x21, 0x68, 0x51, 0x2b, 0xa1, 0xe6, 0x6f, 0x02, 0xb6, 0x9d, 0x85, 0x92, 0x7e,
+	0xad, 0x7f, 0xf9, 0x8f, 0x7f, 0x7f, 0x35, 0x51, 0x42, 0x85, 0x8d, 0x93, 0x7b, 0x1b, 0xf2, 0xf7,
+	0x80, 0xcd, 0x7f, 0x56, 0xa0, 0x24, 0xdc, 0xaa, 0x14, 0x6d, 0xc3, 0x24, 0xdb, 0x68, 0xa2, 0x79,
+	0x4d, 0x94, 0xb6, 0xf1, 0xb4, 0x17, 0x52, 0x70, 0xb5, 0xfa, 0xe4, 0xf2, 0x01, 0xe5, 0x99, 0xfc,
+	0x90, 0x89, 0x78, 0x09, 0x33, 0x72, 0x97, 0x69, 0xd8, 0x6c, 0x2e, 0x48, 0x6d, 0x3b, 0x0b, 0x95,
+	0x65, 0xb3, 0xbc, 0x1a, 0xd4, 0x85, 0x92, 0xb1, 0xdc, 0x44, 0xd7, 0xd2, 0x3b, 0x47, 0x63, 0x63,
+	0x6a, 0xaf, 0x8c, 0x26, 0x90, 0x8a, 0x16, 0xb9, 0xa2, 0x3a, 0xaa, 0x31, 0x45, 0xb2, 0x24, 0x8b,
+	0x62, 0x8e, 0x7e, 0x0c, 0xf9, 0x47, 0x6a, 0x5d, 0x69, 0x67, 0x6e, 0x37, 0x85, 0x92, 0x2b, 0x63,
+	0x36, 0x9f, 0x4e, 0x83, 0xcb, 0x2f, 0xa3, 0xa2, 0x26, 0x3f, 0x66, 0x0e, 0x52, 0xdb, 0x42, 0xdd,
+	0x41, 0xe6, 0x8a, 0xc4, 0xb6, 0xb3, 0x50, 0x59, 0x0e, 0x92, 0xdb, 0x48, 0x14, 0x41, 0x65, 0xe8,
+	0xa5, 0x8d, 0x56, 0x35, 0x19, 0xd9, 0x0b, 0x0a, 0xdb, 0x19, 0x47, 0x22, 0xd5, 0x35, 0xb9, 0x3a,
+	0x84, 0xaa, 0x4c, 0x9d, 0x3e, 0x61, 0xa1, 0x1e, 0x34, 0x47, 0x0d, 0x82, 0x68, 0x2d, 0x7b, 0xee,
+	0xca, 0xea, 0x29, 0xf6, 0xbb, 0x17, 0xa2, 0x15, 0xe6, 0xdc, 0xb5, 0x50, 0x00, 0xf3, 0xd9, 0x53,
+	0x04, 0xba, 0x75, 0x81, 0x41, 0x43, 0xa8, 0xbc, 0x7d, 0xe1, 0x91, 0xe4, 0xae, 0x85, 0xc8, 0x60,
+	0x29, 0x6f, 0xa8, 0x7b, 0x27, 0x23, 0xc4, 0xb2, 0x94, 0xdd, 0x3c, 0x97, 0xae, 0xaf, 0xea, 0x0d,
+	0x2c, 0x8e, 0xec, 0x7f, 0x48, 0xf7, 0xd3, 0x79, 0x8d, 0xda, 0xbe, 0x73, 0x31, 0xe2, 0xbe, 0xe6,
+	0x13, 0xa8, 0x0e, 0x6f, 0x0c, 0x90, 0x73, 0xfe, 0x82, 0xc3, 0xbe, 0x3e, 0x96, 0xc6, 0x4c, 0x35,
+	0xa7, 0xcc, 0xeb, 0x44, 0x1f, 0xff, 0xc0, 0x5a, 0x43, 0xbf, 0xb4, 0xa0, 0x64, 0xec, 0x93, 0x8d,
+	0xd4, 0xce, 0xda, 0x61, 0xdb, 0x2b, 0xa3, 0x09, 0xa4, 0xbe, 0x0d, 0xae, 0xef, 0xf6, 0x03, 0x6b,
+	0xcd, 0xb9, 0xa1, 0x67, 0xdf, 0xc6, 0xcf, 0xcd, 0xa9, 0xed, 0x17, 0x1b, 0x11, 0xe7, 0x47, 0x18,
+	0x0a, 0xda, 0x8a, 0x19, 0x2d, 0x0d, 0x2f, 0x7d, 0x4d, 0x03, 0x96, 0x47, 0xa1, 0xa5, 0xfa, 0x05,
+	0xae, 0xbe, 0xe6, 0x18, 0x99, 0xcf, 0x0e, 0x7b, 0x20, 0xd5, 0xc8, 0x4e, 0xb3, 0x34, 0x76, 0xb7,
+	0x6c, 0x2f, 0x8f, 0x42, 0x9b, 0x99, 0xe9, 0x94, 0xb8, 0x1a, 0x81, 0xc4, 0x5c, 0xcf, 0x29, 0x54,
+	0x87, 0xf7, 0xb1, 0xc6, 0x65, 0x8e, 0xd8, 0x20, 0xdb, 0xd7, 0xc7, 0xd2, 0x48, 0xb5, 0x36, 0x57,
+	0xdb, 0x60, 0xce, 0xad, 0xf0, 0xfb, 0x14, 0x24, 0xc7, 0xf8, 0x2c, 0x46, 0x9f, 0x43, 0x65, 0x68,
+	0x33, 0x62, 0x94, 0xa1, 0xec, 0xb5, 0x93, 0xed, 0x8c, 0x23, 0x91, 0x5a, 0x57, 0xb8, 0x56, 0x9b,
+	0x69, 0x9d, 0x1b, 0xae, 0x44, 0x1b, 0x07, 0x3d, 0xea, 0x33, 0xdd, 0x43, 0x0f, 0x73, 0x43, 0x77,
+	0xf6, 0x2a, 0xc1, 0x76, 0xc6, 0x91, 0x98, 0xba, 0x33, 0x14, 0xb3, 0xf7, 0x35, 0x73, 0xf8, 0xaf,
+	0x2d, 0x40, 0xe9, 0x67, 0x35, 0xd2, 0x7f, 0x50, 0x1e, 0xf9, 0x82, 0xb7, 0xdf, 0x3e, 0x87, 0x4a,
+	0x5a, 0x71, 0x9d, 0x5b, 0xb1, 0xe4, 0x34, 0x53, 0x56, 0x84, 0x82, 0xe9, 0x81, 0xb5, 0xb6, 0xf9,
+	0xe7, 0x9c, 0x1a, 0xa5, 0x9e, 0x05, 0x9e, 0x8f, 0x23, 0xd5, 0xe2, 0x9f, 0x43, 0x51, 0x1f, 0xa5,
+	0x90, 0x1e, 0x5b, 0x19, 0xa3, 0x97, 0x7d, 0x6d, 0x24, 0x5e, 0xce, 0x60, 0xcf, 0xa1, 0xa8, 0xcf,
+	0x93, 0x86, 0xc0, 0x8c, 0x79, 0xd7, 0xbe, 0x36, 0x12, 0x2f, 0x05, 0x6e, 0x03, 0x0c, 0xc6, 0x48,
+	0x74, 0x55, 0x23, 0x4f, 0xcd, 0xa7, 0xf6, 0xd2, 0x08, 0xac, 0x14, 0xf5, 0x0c, 0x0a, 0xda, 0x94,
+	0x69, 0xa4, 0x59, 0x7a, 0x26, 0xb5, 0x97, 0x47, 0xa1, 0xa5, 0xb4, 0x9f, 0x42, 0x2d, 0x35, 0xb5,
+	0x21, 0x3d, 0x53, 0x46, 0x8d, 0x9c, 0xf6, 0x8d, 0xf1, 0x44, 0x42, 0xfe, 0xfe, 0x34, 0xff, 0x6f,
+	0x8f, 0xff, 0xfb, 0xef, 0x00, 0xb8, 0xcf, 0x79, 0x2d, 0x23, 0x22, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for VersionService service
+const _ = grpc.SupportPackageIsVersion6
 
+// VersionServiceClient is the client API for VersionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type VersionServiceClient interface {
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
 }
 
 type versionServiceClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewVersionServiceClient(cc *grpc.ClientConn) VersionServiceClient {
+func NewVersionServiceClient(cc grpc.ClientConnInterface) VersionServiceClient {
 	return &versionServiceClient{cc}
 }
 
 func (c *versionServiceClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
 	out := new(VersionResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.VersionService/Version", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.VersionService/Version", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for VersionService service
-
+// VersionServiceServer is the server API for VersionService service.
 type VersionServiceServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 }
 
+// UnimplementedVersionServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedVersionServiceServer struct {
+}
+
+func (*UnimplementedVersionServiceServer) Version(ctx context.Context, req *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+
 func RegisterVersionServiceServer(s *grpc.Server, srv VersionServiceServer) {
 	s.RegisterService(&_VersionService_serviceDesc, srv)
 }
@@ -1608,8 +3092,9 @@ var _VersionService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "api.proto",
 }
 
-// Client API for WalletService service
-
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type WalletServiceClient interface {
 	// Queries
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
@@ -1619,9 +3104,13 @@ type WalletServiceClient interface {
 	Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
 	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
 	// Notifications
+	//
+	// The REST gateway streams notifications as server-sent events of the
+	// /v1/notifications paths instead of annotated routes.
 	TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error)
 	SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error)
 	AccountNotifications(ctx context.Context, in *AccountNotificationsRequest, opts ...grpc.CallOption) (WalletService_AccountNotificationsClient, error)
+	ConfirmationNotifications(ctx context.Context, in *ConfirmationNotificationsRequest, opts ...grpc.CallOption) (WalletService_ConfirmationNotificationsClient, error)
 	// Control
 	ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error)
 	RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error)
@@ -1634,16 +3123,16 @@ type WalletServiceClient interface {
 }
 
 type walletServiceClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewWalletServiceClient(cc *grpc.ClientConn) WalletServiceClient {
+func NewWalletServiceClient(cc grpc.ClientConnInterface) WalletServiceClient {
 	return &walletServiceClient{cc}
 }
 
 func (c *walletServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
 	out := new(PingResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/Ping", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Ping", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1652,7 +3141,7 @@ func (c *walletServiceClient) Ping(ctx context.Context, in *PingRequest, opts ..
 
 func (c *walletServiceClient) Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error) {
 	out := new(NetworkResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/Network", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Network", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1661,7 +3150,7 @@ func (c *walletServiceClient) Network(ctx context.Context, in *NetworkRequest, o
 
 func (c *walletServiceClient) AccountNumber(ctx context.Context, in *AccountNumberRequest, opts ...grpc.CallOption) (*AccountNumberResponse, error) {
 	out := new(AccountNumberResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/AccountNumber", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/AccountNumber", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1670,7 +3159,7 @@ func (c *walletServiceClient) AccountNumber(ctx context.Context, in *AccountNumb
 
 func (c *walletServiceClient) Accounts(ctx context.Context, in *AccountsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
 	out := new(AccountsResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/Accounts", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Accounts", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1679,7 +3168,7 @@ func (c *walletServiceClient) Accounts(ctx context.Context, in *AccountsRequest,
 
 func (c *walletServiceClient) Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
 	out := new(BalanceResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/Balance", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Balance", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1688,7 +3177,7 @@ func (c *walletServiceClient) Balance(ctx context.Context, in *BalanceRequest, o
 
 func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
 	out := new(GetTransactionsResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/GetTransactions", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/GetTransactions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1696,7 +3185,7 @@ func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransa
 }
 
 func (c *walletServiceClient) TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_WalletService_serviceDesc.Streams[0], c.cc, "/walletrpc.WalletService/TransactionNotifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[0], "/walletrpc.WalletService/TransactionNotifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1728,7 +3217,7 @@ func (x *walletServiceTransactionNotificationsClient) Recv() (*TransactionNotifi
 }
 
 func (c *walletServiceClient) SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_WalletService_serviceDesc.Streams[1], c.cc, "/walletrpc.WalletService/SpentnessNotifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[1], "/walletrpc.WalletService/SpentnessNotifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1760,7 +3249,7 @@ func (x *walletServiceSpentnessNotificationsClient) Recv() (*SpentnessNotificati
 }
 
 func (c *walletServiceClient) AccountNotifications(ctx context.Context, in *AccountNotificationsRequest, opts ...grpc.CallOption) (WalletService_AccountNotificationsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_WalletService_serviceDesc.Streams[2], c.cc, "/walletrpc.WalletService/AccountNotifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[2], "/walletrpc.WalletService/AccountNotifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1791,9 +3280,41 @@ func (x *walletServiceAccountNotificationsClient) Recv() (*AccountNotificationsR
 	return m, nil
 }
 
+func (c *walletServiceClient) ConfirmationNotifications(ctx context.Context, in *ConfirmationNotificationsRequest, opts ...grpc.CallOption) (WalletService_ConfirmationNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[3], "/walletrpc.WalletService/ConfirmationNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceConfirmationNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_ConfirmationNotificationsClient interface {
+	Recv() (*ConfirmationNotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceConfirmationNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceConfirmationNotificationsClient) Recv() (*ConfirmationNotificationsResponse, error) {
+	m := new(ConfirmationNotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *walletServiceClient) ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error) {
 	out := new(ChangePassphraseResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/ChangePassphrase", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/ChangePassphrase", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1802,7 +3323,7 @@ func (c *walletServiceClient) ChangePassphrase(ctx context.Context, in *ChangePa
 
 func (c *walletServiceClient) RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error) {
 	out := new(RenameAccountResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/RenameAccount", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/RenameAccount", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1811,7 +3332,7 @@ func (c *walletServiceClient) RenameAccount(ctx context.Context, in *RenameAccou
 
 func (c *walletServiceClient) NextAccount(ctx context.Context, in *NextAccountRequest, opts ...grpc.CallOption) (*NextAccountResponse, error) {
 	out := new(NextAccountResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/NextAccount", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/NextAccount", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1820,7 +3341,7 @@ func (c *walletServiceClient) NextAccount(ctx context.Context, in *NextAccountRe
 
 func (c *walletServiceClient) NextAddress(ctx context.Context, in *NextAddressRequest, opts ...grpc.CallOption) (*NextAddressResponse, error) {
 	out := new(NextAddressResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/NextAddress", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/NextAddress", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1829,7 +3350,7 @@ func (c *walletServiceClient) NextAddress(ctx context.Context, in *NextAddressRe
 
 func (c *walletServiceClient) ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*ImportPrivateKeyResponse, error) {
 	out := new(ImportPrivateKeyResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/ImportPrivateKey", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/ImportPrivateKey", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1838,7 +3359,7 @@ func (c *walletServiceClient) ImportPrivateKey(ctx context.Context, in *ImportPr
 
 func (c *walletServiceClient) FundTransaction(ctx context.Context, in *FundTransactionRequest, opts ...grpc.CallOption) (*FundTransactionResponse, error) {
 	out := new(FundTransactionResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/FundTransaction", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/FundTransaction", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1847,7 +3368,7 @@ func (c *walletServiceClient) FundTransaction(ctx context.Context, in *FundTrans
 
 func (c *walletServiceClient) SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error) {
 	out := new(SignTransactionResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/SignTransaction", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/SignTransaction", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1856,15 +3377,14 @@ func (c *walletServiceClient) SignTransaction(ctx context.Context, in *SignTrans
 
 func (c *walletServiceClient) PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error) {
 	out := new(PublishTransactionResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletService/PublishTransaction", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/PublishTransaction", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for WalletService service
-
+// WalletServiceServer is the server API for WalletService service.
 type WalletServiceServer interface {
 	// Queries
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
@@ -1874,9 +3394,13 @@ type WalletServiceServer interface {
 	Balance(context.Context, *BalanceRequest) (*BalanceResponse, error)
 	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
 	// Notifications
+	//
+	// The REST gateway streams notifications as server-sent events of the
+	// /v1/notifications paths instead of annotated routes.
 	TransactionNotifications(*TransactionNotificationsRequest, WalletService_TransactionNotificationsServer) error
 	SpentnessNotifications(*SpentnessNotificationsRequest, WalletService_SpentnessNotificationsServer) error
 	AccountNotifications(*AccountNotificationsRequest, WalletService_AccountNotificationsServer) error
+	ConfirmationNotifications(*ConfirmationNotificationsRequest, WalletService_ConfirmationNotificationsServer) error
 	// Control
 	ChangePassphrase(context.Context, *ChangePassphraseRequest) (*ChangePassphraseResponse, error)
 	RenameAccount(context.Context, *RenameAccountRequest) (*RenameAccountResponse, error)
@@ -1888,6 +3412,65 @@ type WalletServiceServer interface {
 	PublishTransaction(context.Context, *PublishTransactionRequest) (*PublishTransactionResponse, error)
 }
 
+// UnimplementedWalletServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWalletServiceServer struct {
+}
+
+func (*UnimplementedWalletServiceServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedWalletServiceServer) Network(ctx context.Context, req *NetworkRequest) (*NetworkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Network not implemented")
+}
+func (*UnimplementedWalletServiceServer) AccountNumber(ctx context.Context, req *AccountNumberRequest) (*AccountNumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountNumber not implemented")
+}
+func (*UnimplementedWalletServiceServer) Accounts(ctx context.Context, req *AccountsRequest) (*AccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Accounts not implemented")
+}
+func (*UnimplementedWalletServiceServer) Balance(ctx context.Context, req *BalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Balance not implemented")
+}
+func (*UnimplementedWalletServiceServer) GetTransactions(ctx context.Context, req *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (*UnimplementedWalletServiceServer) TransactionNotifications(req *TransactionNotificationsRequest, srv WalletService_TransactionNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TransactionNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) SpentnessNotifications(req *SpentnessNotificationsRequest, srv WalletService_SpentnessNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SpentnessNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) AccountNotifications(req *AccountNotificationsRequest, srv WalletService_AccountNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method AccountNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) ConfirmationNotifications(req *ConfirmationNotificationsRequest, srv WalletService_ConfirmationNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ConfirmationNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) ChangePassphrase(ctx context.Context, req *ChangePassphraseRequest) (*ChangePassphraseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassphrase not implemented")
+}
+func (*UnimplementedWalletServiceServer) RenameAccount(ctx context.Context, req *RenameAccountRequest) (*RenameAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameAccount not implemented")
+}
+func (*UnimplementedWalletServiceServer) NextAccount(ctx context.Context, req *NextAccountRequest) (*NextAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextAccount not implemented")
+}
+func (*UnimplementedWalletServiceServer) NextAddress(ctx context.Context, req *NextAddressRequest) (*NextAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextAddress not implemented")
+}
+func (*UnimplementedWalletServiceServer) ImportPrivateKey(ctx context.Context, req *ImportPrivateKeyRequest) (*ImportPrivateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPrivateKey not implemented")
+}
+func (*UnimplementedWalletServiceServer) FundTransaction(ctx context.Context, req *FundTransactionRequest) (*FundTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FundTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) SignTransaction(ctx context.Context, req *SignTransactionRequest) (*SignTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) PublishTransaction(ctx context.Context, req *PublishTransactionRequest) (*PublishTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishTransaction not implemented")
+}
+
 func RegisterWalletServiceServer(s *grpc.Server, srv WalletServiceServer) {
 	s.RegisterService(&_WalletService_serviceDesc, srv)
 }
@@ -2063,6 +3646,27 @@ func (x *walletServiceAccountNotificationsServer) Send(m *AccountNotificationsRe
 	return x.ServerStream.SendMsg(m)
 }
 
+func _WalletService_ConfirmationNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConfirmationNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).ConfirmationNotifications(m, &walletServiceConfirmationNotificationsServer{stream})
+}
+
+type WalletService_ConfirmationNotificationsServer interface {
+	Send(*ConfirmationNotificationsResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceConfirmationNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceConfirmationNotificationsServer) Send(m *ConfirmationNotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WalletService_ChangePassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangePassphraseRequest)
 	if err := dec(in); err != nil {
@@ -2284,12 +3888,18 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _WalletService_AccountNotifications_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ConfirmationNotifications",
+			Handler:       _WalletService_ConfirmationNotifications_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
 
-// Client API for WalletLoaderService service
-
+// WalletLoaderServiceClient is the client API for WalletLoaderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type WalletLoaderServiceClient interface {
 	WalletExists(ctx context.Context, in *WalletExistsRequest, opts ...grpc.CallOption) (*WalletExistsResponse, error)
 	CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error)
@@ -2299,16 +3909,16 @@ type WalletLoaderServiceClient interface {
 }
 
 type walletLoaderServiceClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewWalletLoaderServiceClient(cc *grpc.ClientConn) WalletLoaderServiceClient {
+func NewWalletLoaderServiceClient(cc grpc.ClientConnInterface) WalletLoaderServiceClient {
 	return &walletLoaderServiceClient{cc}
 }
 
 func (c *walletLoaderServiceClient) WalletExists(ctx context.Context, in *WalletExistsRequest, opts ...grpc.CallOption) (*WalletExistsResponse, error) {
 	out := new(WalletExistsResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletLoaderService/WalletExists", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletLoaderService/WalletExists", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2317,7 +3927,7 @@ func (c *walletLoaderServiceClient) WalletExists(ctx context.Context, in *Wallet
 
 func (c *walletLoaderServiceClient) CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error) {
 	out := new(CreateWalletResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletLoaderService/CreateWallet", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletLoaderService/CreateWallet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2326,7 +3936,7 @@ func (c *walletLoaderServiceClient) CreateWallet(ctx context.Context, in *Create
 
 func (c *walletLoaderServiceClient) OpenWallet(ctx context.Context, in *OpenWalletRequest, opts ...grpc.CallOption) (*OpenWalletResponse, error) {
 	out := new(OpenWalletResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletLoaderService/OpenWallet", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletLoaderService/OpenWallet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2335,7 +3945,7 @@ func (c *walletLoaderServiceClient) OpenWallet(ctx context.Context, in *OpenWall
 
 func (c *walletLoaderServiceClient) CloseWallet(ctx context.Context, in *CloseWalletRequest, opts ...grpc.CallOption) (*CloseWalletResponse, error) {
 	out := new(CloseWalletResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletLoaderService/CloseWallet", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletLoaderService/CloseWallet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2344,15 +3954,14 @@ func (c *walletLoaderServiceClient) CloseWallet(ctx context.Context, in *CloseWa
 
 func (c *walletLoaderServiceClient) StartConsensusRpc(ctx context.Context, in *StartConsensusRpcRequest, opts ...grpc.CallOption) (*StartConsensusRpcResponse, error) {
 	out := new(StartConsensusRpcResponse)
-	err := grpc.Invoke(ctx, "/walletrpc.WalletLoaderService/StartConsensusRpc", in, out, c.cc, opts...)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletLoaderService/StartConsensusRpc", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for WalletLoaderService service
-
+// WalletLoaderServiceServer is the server API for WalletLoaderService service.
 type WalletLoaderServiceServer interface {
 	WalletExists(context.Context, *WalletExistsRequest) (*WalletExistsResponse, error)
 	CreateWallet(context.Context, *CreateWalletRequest) (*CreateWalletResponse, error)
@@ -2361,6 +3970,26 @@ type WalletLoaderServiceServer interface {
 	StartConsensusRpc(context.Context, *StartConsensusRpcRequest) (*StartConsensusRpcResponse, error)
 }
 
+// UnimplementedWalletLoaderServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWalletLoaderServiceServer struct {
+}
+
+func (*UnimplementedWalletLoaderServiceServer) WalletExists(ctx context.Context, req *WalletExistsRequest) (*WalletExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletExists not implemented")
+}
+func (*UnimplementedWalletLoaderServiceServer) CreateWallet(ctx context.Context, req *CreateWalletRequest) (*CreateWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWallet not implemented")
+}
+func (*UnimplementedWalletLoaderServiceServer) OpenWallet(ctx context.Context, req *OpenWalletRequest) (*OpenWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenWallet not implemented")
+}
+func (*UnimplementedWalletLoaderServiceServer) CloseWallet(ctx context.Context, req *CloseWalletRequest) (*CloseWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseWallet not implemented")
+}
+func (*UnimplementedWalletLoaderServiceServer) StartConsensusRpc(ctx context.Context, req *StartConsensusRpcRequest) (*StartConsensusRpcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartConsensusRpc not implemented")
+}
+
 func RegisterWalletLoaderServiceServer(s *grpc.Server, srv WalletLoaderServiceServer) {
 	s.RegisterService(&_WalletLoaderService_serviceDesc, srv)
 }
@@ -2483,159 +4112,3 @@ var _WalletLoaderService_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
-
-func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
-
-var fileDescriptor0 = []byte{
-	// 2391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x59, 0x5b, 0x73, 0xdc, 0x48,
-	0x15, 0x66, 0x2c, 0xdf, 0x72, 0xe6, 0xde, 0xbe, 0x4d, 0x94, 0x38, 0xc9, 0x2a, 0xbb, 0xd9, 0xec,
-	0x2e, 0x98, 0x60, 0x02, 0x2c, 0xc5, 0x56, 0xd8, 0xc4, 0x64, 0xd9, 0x21, 0xc1, 0x99, 0x92, 0x93,
-	0x4d, 0xaa, 0xa0, 0x98, 0x92, 0x35, 0xed, 0x58, 0x78, 0x46, 0x9a, 0x48, 0x9a, 0x38, 0xe1, 0x89,
-	0xa2, 0x8a, 0x47, 0x5e, 0x80, 0x07, 0x0a, 0x6a, 0x5f, 0xf8, 0x05, 0x54, 0xf1, 0xc2, 0x23, 0xfc,
-	0x06, 0x1e, 0xf9, 0x17, 0xfc, 0x02, 0xfa, 0x72, 0x7a, 0xd4, 0x2d, 0x69, 0xc6, 0xf6, 0x16, 0x6f,
-	0xa3, 0xaf, 0xcf, 0x39, 0x7d, 0xfa, 0xf4, 0xb9, 0xf6, 0xc0, 0x25, 0x6f, 0x1c, 0xec, 0x8c, 0xe3,
-	0x28, 0x8d, 0xc8, 0xa5, 0x53, 0x6f, 0x38, 0xa4, 0x69, 0x3c, 0xf6, 0x9d, 0x16, 0x34, 0xbe, 0xa0,
-	0x71, 0x12, 0x44, 0xa1, 0x4b, 0x5f, 0x4d, 0x68, 0x92, 0x3a, 0xff, 0xaa, 0x40, 0x73, 0x0a, 0x25,
-	0xe3, 0x28, 0x4c, 0x28, 0x79, 0x0f, 0x1a, 0xaf, 0x25, 0xd4, 0x4f, 0xd2, 0x38, 0x08, 0x5f, 0x76,
-	0x2a, 0x37, 0x2a, 0xb7, 0x2f, 0xb9, 0x75, 0x44, 0x0f, 0x04, 0x48, 0xd6, 0x61, 0x69, 0xe4, 0xfd,
-	0x32, 0x8a, 0x3b, 0x0b, 0x6c, 0xb5, 0xee, 0xca, 0x0f, 0x81, 0x06, 0x21, 0x43, 0x2d, 0x44, 0xf9,
-	0x07, 0x47, 0xc7, 0x5e, 0xea, 0x1f, 0x77, 0x16, 0x25, 0x2a, 0x3e, 0xc8, 0x35, 0x80, 0x71, 0x4c,
-	0x63, 0x3a, 0xa4, 0x5e, 0x42, 0x3b, 0x4b, 0x62, 0x13, 0x0d, 0xe1, 0x8a, 0x1c, 0x4e, 0x82, 0xe1,
-	0xa0, 0x3f, 0xa2, 0xa9, 0x37, 0xf0, 0x52, 0xaf, 0xb3, 0x2c, 0x15, 0x11, 0xe8, 0x4f, 0x11, 0x74,
-	0xfe, 0x69, 0x01, 0x79, 0x1a, 0x7b, 0x61, 0xe2, 0xf9, 0x29, 0x53, 0xef, 0x47, 0x0c, 0x0f, 0x86,
-	0x09, 0x21, 0xb0, 0x78, 0xec, 0x25, 0xc7, 0x42, 0xf9, 0x9a, 0x2b, 0x7e, 0x93, 0x1b, 0x50, 0x4d,
-	0x33, 0x4a, 0xa1, 0x79, 0xcd, 0xd5, 0x21, 0xf2, 0x03, 0x58, 0x1e, 0xd0, 0xc3, 0x20, 0x4d, 0xd8,
-	0x01, 0xac, 0xdb, 0xd5, 0xdd, 0x9b, 0x3b, 0x53, 0xf3, 0xed, 0x14, 0x37, 0xd9, 0xe9, 0x86, 0xe3,
-	0x49, 0xea, 0x22, 0x0b, 0xb9, 0x07, 0x2b, 0x7e, 0x4c, 0x07, 0x9c, 0x7b, 0x51, 0x70, 0xbf, 0x3b,
-	0x9f, 0xfb, 0xc9, 0x24, 0xe5, 0xec, 0x8a, 0x89, 0xb4, 0xc0, 0x3a, 0xa2, 0xd2, 0x12, 0x96, 0xcb,
-	0x7f, 0x92, 0xab, 0x70, 0x29, 0x0d, 0x46, 0xec, 0xa6, 0xbc, 0xd1, 0x58, 0x9c, 0xde, 0x72, 0x33,
-	0xc0, 0x7e, 0x05, 0x4b, 0x42, 0x01, 0x6e, 0xdf, 0x20, 0x1c, 0xd0, 0x37, 0xe2, 0xb0, 0xcc, 0xbe,
-	0xe2, 0x83, 0x7c, 0x00, 0x2d, 0x66, 0xcd, 0xd7, 0x41, 0x34, 0x49, 0xfa, 0x9e, 0xef, 0x47, 0x93,
-	0x30, 0xc5, 0xcb, 0x6a, 0x2a, 0xfc, 0xbe, 0x84, 0xc9, 0xfb, 0xd0, 0xcc, 0x48, 0x47, 0x82, 0xd2,
-	0x12, 0xbb, 0x35, 0xa6, 0x94, 0x02, 0xb5, 0x9f, 0xc2, 0xb2, 0xd4, 0x7a, 0xc6, 0x9e, 0x1d, 0x58,
-	0x31, 0xb7, 0x52, 0x9f, 0xc4, 0x86, 0xd5, 0x20, 0x4c, 0x69, 0x1c, 0x7a, 0x43, 0x21, 0x7b, 0xd5,
-	0x9d, 0x7e, 0x3b, 0x7f, 0xa9, 0x40, 0xed, 0xc1, 0x30, 0xf2, 0x4f, 0xe6, 0x5d, 0xde, 0x26, 0x2c,
-	0x1f, 0xd3, 0xe0, 0xe5, 0xb1, 0x94, 0xbc, 0xe4, 0xe2, 0x97, 0x69, 0x23, 0x2b, 0x67, 0x23, 0x72,
-	0x1f, 0x6a, 0xda, 0xfd, 0xaa, 0x8b, 0xd9, 0x9e, 0x7b, 0x31, 0xae, 0xc1, 0xe2, 0x3c, 0x81, 0x06,
-	0xda, 0xe9, 0x81, 0x37, 0xf4, 0x42, 0x9f, 0xea, 0xa7, 0xac, 0x98, 0xa7, 0xbc, 0x09, 0xf5, 0x34,
-	0x4a, 0xbd, 0x61, 0xff, 0x50, 0x92, 0x0a, 0x5d, 0x2d, 0x26, 0x90, 0x83, 0xc8, 0xee, 0xd4, 0xa1,
-	0xda, 0x63, 0x21, 0xa4, 0x82, 0xb0, 0x01, 0x35, 0xf9, 0x29, 0x03, 0x90, 0x87, 0xe9, 0x3e, 0x4d,
-	0x4f, 0xa3, 0xf8, 0x44, 0x51, 0x7c, 0x0c, 0xcd, 0x29, 0x92, 0x45, 0x29, 0xd7, 0xef, 0x35, 0xed,
-	0x87, 0x72, 0x05, 0x35, 0xa9, 0x4b, 0x14, 0xc9, 0x9d, 0xef, 0xc3, 0x3a, 0xea, 0xbe, 0x3f, 0x19,
-	0x1d, 0xd2, 0x18, 0x25, 0x92, 0x77, 0xa0, 0x86, 0x2a, 0xf7, 0x43, 0x6f, 0x44, 0x31, 0xc4, 0xab,
-	0x88, 0xed, 0x33, 0xc8, 0xb9, 0x07, 0x1b, 0x39, 0x56, 0x7d, 0x6b, 0xe4, 0x15, 0x2b, 0xd9, 0xd6,
-	0x1a, 0xb9, 0xd3, 0x86, 0x26, 0xf2, 0x27, 0xea, 0x1c, 0xff, 0xb0, 0xa0, 0x95, 0x61, 0x28, 0xee,
-	0x87, 0xb0, 0x8a, 0x8c, 0x09, 0x13, 0x94, 0x0f, 0xba, 0x3c, 0xb9, 0x02, 0xdc, 0x29, 0x13, 0xf9,
-	0x3a, 0x10, 0x7f, 0x12, 0xc7, 0x94, 0xe9, 0x73, 0xc8, 0x9d, 0xa8, 0x2f, 0x5c, 0x47, 0x06, 0x77,
-	0x0b, 0x57, 0x84, 0x77, 0x7d, 0xce, 0xdd, 0xe8, 0x0e, 0xac, 0xe7, 0xa8, 0xa5, 0x53, 0x59, 0xc2,
-	0xa9, 0x88, 0x41, 0x2f, 0x56, 0xec, 0xdf, 0x2c, 0xc0, 0x8a, 0x0a, 0x94, 0xf3, 0x9d, 0xbd, 0x60,
-	0xde, 0x85, 0x82, 0x79, 0x8b, 0x9e, 0x62, 0x15, 0x3d, 0x85, 0x1f, 0x8d, 0xbe, 0x91, 0x41, 0xd2,
-	0x3f, 0xa1, 0x6f, 0xfb, 0xd2, 0xe7, 0x64, 0x16, 0x6d, 0xa9, 0x95, 0x47, 0xf4, 0xed, 0x9e, 0x50,
-	0x8e, 0x51, 0xab, 0x90, 0xd2, 0xa8, 0x97, 0x24, 0xb5, 0x5a, 0x31, 0xa8, 0x47, 0xe3, 0x28, 0x4e,
-	0xe9, 0x40, 0xa3, 0x5e, 0x46, 0x6a, 0x5c, 0x51, 0xd4, 0xce, 0x0b, 0x58, 0x77, 0x29, 0x3f, 0x8b,
-	0xb2, 0x3f, 0x3a, 0xd2, 0x39, 0x0d, 0x72, 0x19, 0x56, 0x43, 0x7a, 0xaa, 0x1b, 0x63, 0x85, 0x7d,
-	0x0b, 0x3f, 0xdb, 0x82, 0x8d, 0x9c, 0x64, 0x8c, 0x83, 0xe7, 0x40, 0xf6, 0xd9, 0x19, 0x73, 0x1b,
-	0xf2, 0xaa, 0xe1, 0x25, 0xc9, 0xf8, 0x38, 0xe6, 0x55, 0x43, 0x26, 0x08, 0x0d, 0x39, 0x87, 0xe9,
-	0x9d, 0x4f, 0x60, 0xcd, 0x10, 0x7c, 0x31, 0xbf, 0xfe, 0x73, 0x05, 0xf5, 0x1a, 0x0c, 0x62, 0x9a,
-	0x28, 0xdf, 0x9e, 0x93, 0x13, 0xbe, 0x0b, 0x8b, 0x27, 0x2c, 0x3b, 0x0a, 0x4d, 0x1a, 0xbb, 0x8e,
-	0xe6, 0xdc, 0x45, 0x31, 0x3b, 0x8f, 0x18, 0xa5, 0x2b, 0xe8, 0x9d, 0x5d, 0x58, 0xe4, 0x5f, 0x2c,
-	0xd3, 0xb6, 0x1e, 0x74, 0x7b, 0x77, 0xee, 0xdc, 0xbd, 0xdb, 0x7f, 0xf8, 0xe2, 0xe9, 0x43, 0x77,
-	0xff, 0xfe, 0xe3, 0xd6, 0xd7, 0x74, 0xb4, 0xbb, 0x8f, 0x68, 0xc5, 0xf9, 0x26, 0x1e, 0x4d, 0x09,
-	0xc5, 0xa3, 0x71, 0xe5, 0x24, 0x84, 0x91, 0xae, 0x3e, 0x9d, 0x3f, 0x54, 0x60, 0xab, 0x2b, 0x2e,
-	0xbb, 0x17, 0x07, 0xaf, 0xbd, 0x94, 0xb2, 0x1b, 0x3f, 0xaf, 0xa9, 0x67, 0x27, 0xfb, 0x5b, 0xbc,
-	0x9e, 0x08, 0x71, 0xc2, 0xb5, 0x4e, 0x83, 0x23, 0xe1, 0xde, 0xac, 0x76, 0x8f, 0xa7, 0xbb, 0x3c,
-	0x0f, 0x8e, 0x78, 0x4e, 0x67, 0x5a, 0xf8, 0x5e, 0x28, 0x7c, 0x7a, 0xd5, 0xc5, 0x2f, 0xc7, 0x86,
-	0x4e, 0x51, 0x29, 0x74, 0x8b, 0x10, 0x1a, 0x18, 0x1e, 0x17, 0xf4, 0xc1, 0xef, 0xc0, 0x66, 0xcc,
-	0x38, 0x02, 0x56, 0x6d, 0x99, 0xb3, 0x87, 0x47, 0x41, 0x3c, 0xf2, 0x64, 0x51, 0x90, 0x05, 0x65,
-	0x43, 0xad, 0xee, 0xe9, 0x8b, 0x6c, 0xbf, 0xe6, 0x74, 0x3f, 0x34, 0x27, 0xab, 0x7d, 0x22, 0x4c,
-	0xc5, 0x3e, 0x96, 0x2b, 0x3f, 0x78, 0x21, 0x4a, 0xc6, 0x34, 0x1c, 0x78, 0x87, 0x43, 0x95, 0xf7,
-	0x33, 0x80, 0x97, 0xd8, 0x60, 0xc4, 0x64, 0x4e, 0x62, 0xda, 0x8f, 0xe9, 0xa9, 0x17, 0x0f, 0x54,
-	0x89, 0x55, 0xb0, 0x2b, 0x50, 0xe7, 0x4f, 0x0b, 0xb0, 0xf9, 0x63, 0x9a, 0x6a, 0x65, 0x69, 0xea,
-	0x63, 0x3b, 0xb0, 0xc6, 0xaa, 0x5a, 0x9c, 0xb2, 0x6a, 0xa1, 0xa7, 0x3a, 0x79, 0x33, 0x6d, 0xb5,
-	0x94, 0xe5, 0xba, 0x5d, 0xd8, 0xc8, 0xd3, 0x67, 0x15, 0xb4, 0xed, 0xae, 0x99, 0x1c, 0xb2, 0x9c,
-	0x7e, 0x08, 0x6d, 0xa6, 0x72, 0x6e, 0x07, 0x4b, 0xec, 0xd0, 0x94, 0x0b, 0x99, 0x7c, 0xa6, 0x8f,
-	0x49, 0x2b, 0xa5, 0x2f, 0x0a, 0x73, 0xb6, 0x75, 0x6a, 0x29, 0xfb, 0x1e, 0x5c, 0x61, 0x0d, 0x61,
-	0x30, 0x9a, 0x8c, 0x98, 0x09, 0x7c, 0x9e, 0x82, 0x8d, 0xda, 0xbc, 0x24, 0xf8, 0x2e, 0x23, 0x89,
-	0x2b, 0x28, 0x74, 0x33, 0x38, 0x7f, 0x67, 0xce, 0x5a, 0x30, 0x0d, 0xde, 0xc9, 0x67, 0x40, 0x18,
-	0x23, 0xbb, 0x5a, 0x43, 0xa4, 0x2c, 0x28, 0x5b, 0x5a, 0xcc, 0xe9, 0x7d, 0x86, 0xdb, 0x16, 0x2c,
-	0xba, 0x3c, 0xd2, 0x83, 0xf5, 0x49, 0x58, 0x22, 0x69, 0xe1, 0x3c, 0x8d, 0xc3, 0x1a, 0xb2, 0x1a,
-	0x5a, 0xb3, 0x26, 0x7b, 0x6b, 0xef, 0xd8, 0x0b, 0x5f, 0xd2, 0xde, 0x34, 0x76, 0xd4, 0x8d, 0x7e,
-	0x0c, 0x16, 0x0b, 0x10, 0x71, 0x83, 0x8d, 0xdd, 0x5b, 0x9a, 0xf0, 0x19, 0x0c, 0x3b, 0x3c, 0x12,
-	0x38, 0x0b, 0x77, 0xfa, 0x88, 0xf5, 0xc6, 0x5a, 0x80, 0xca, 0x8a, 0x57, 0x67, 0x68, 0xc6, 0xc6,
-	0xc9, 0x78, 0xe2, 0xd5, 0xc8, 0xe4, 0x5d, 0xd6, 0x19, 0x9a, 0x91, 0x39, 0xd7, 0xc0, 0x62, 0x92,
-	0x49, 0x15, 0x56, 0x7a, 0x6e, 0xf7, 0x8b, 0xfb, 0x4f, 0x1f, 0xb2, 0x0c, 0x03, 0xb0, 0xdc, 0x7b,
-	0xf6, 0xe0, 0x71, 0x77, 0x8f, 0xe5, 0x15, 0x16, 0x90, 0x45, 0x8d, 0x30, 0x20, 0x7f, 0xcd, 0x1c,
-	0xf6, 0xb3, 0x49, 0xa8, 0x1f, 0xfa, 0xec, 0xa4, 0xc8, 0xcb, 0x9f, 0x17, 0xbf, 0xa4, 0xa9, 0xea,
-	0x37, 0x55, 0xa3, 0x24, 0x40, 0xd9, 0x6d, 0xce, 0x89, 0x58, 0x6b, 0x4e, 0xc4, 0x92, 0x4f, 0xc0,
-	0x0e, 0x42, 0x7f, 0x38, 0x19, 0xd0, 0xfe, 0x34, 0xe4, 0xfc, 0x28, 0x08, 0x0f, 0x99, 0xd6, 0x09,
-	0x66, 0x9a, 0x0e, 0x52, 0x74, 0x91, 0x60, 0x4f, 0xad, 0xf3, 0xa0, 0x51, 0xdc, 0xbe, 0x38, 0x72,
-	0x3f, 0xf1, 0xe3, 0x60, 0x2c, 0x0b, 0xe9, 0xaa, 0xbb, 0x86, 0x8b, 0xd2, 0x1c, 0x07, 0x62, 0xc9,
-	0xf9, 0xab, 0x05, 0x5b, 0x05, 0x13, 0xa0, 0x63, 0xfe, 0x1c, 0x5a, 0x09, 0x9b, 0x68, 0x7c, 0x5e,
-	0x67, 0x23, 0xd1, 0x3b, 0x2b, 0xb7, 0xfc, 0x96, 0x76, 0xdf, 0x33, 0xb8, 0x77, 0x7a, 0xd8, 0x7f,
-	0xe3, 0xac, 0xd0, 0x54, 0xa2, 0xe4, 0x77, 0xc2, 0xcb, 0x9d, 0x6c, 0x23, 0x0c, 0x33, 0x56, 0x05,
-	0x86, 0x56, 0xbc, 0x0d, 0x2d, 0x3c, 0xc8, 0xf8, 0x44, 0x9d, 0x45, 0x3a, 0x41, 0x43, 0xe2, 0xbd,
-	0x13, 0x79, 0x0c, 0xfb, 0x3f, 0x15, 0x68, 0x98, 0x1b, 0xf2, 0x21, 0x42, 0x0b, 0x03, 0x3d, 0xdf,
-	0x34, 0x35, 0x5c, 0x64, 0x03, 0xa6, 0x8a, 0x3c, 0x5f, 0x5f, 0x0e, 0x06, 0xb2, 0x26, 0x54, 0x25,
-	0xd6, 0x15, 0xe3, 0x01, 0xcb, 0xf7, 0xc6, 0x78, 0x81, 0x5f, 0xe4, 0x0a, 0x5c, 0xca, 0x74, 0x5b,
-	0x14, 0xe2, 0x57, 0xc7, 0xa8, 0x15, 0x97, 0xcb, 0xb3, 0x05, 0xef, 0x75, 0x79, 0x5f, 0x8f, 0xf3,
-	0x51, 0x15, 0xb1, 0xa7, 0x81, 0x6c, 0xa6, 0x8e, 0xe2, 0x68, 0x34, 0xbd, 0x65, 0xd1, 0xc6, 0xac,
-	0xba, 0x35, 0x0e, 0xaa, 0x9b, 0x75, 0xfe, 0x58, 0x81, 0xcd, 0x83, 0xe0, 0x65, 0x58, 0xe2, 0xa7,
-	0x67, 0x55, 0x3a, 0xe6, 0x88, 0x09, 0x8d, 0x03, 0x6f, 0x18, 0xfc, 0xca, 0xcc, 0x0b, 0x18, 0x74,
-	0x1b, 0xd9, 0xaa, 0x26, 0x9d, 0xab, 0x15, 0x84, 0x53, 0x83, 0x50, 0x39, 0x54, 0xd6, 0xdd, 0x9a,
-	0x00, 0xbb, 0x12, 0x73, 0x5e, 0xc1, 0x56, 0x41, 0x2b, 0x74, 0x9d, 0xdc, 0xbc, 0x5a, 0x29, 0xce,
-	0xab, 0x77, 0x61, 0x73, 0x12, 0x26, 0x8c, 0x9d, 0xa9, 0x65, 0x6e, 0xb5, 0x20, 0xb6, 0x5a, 0x57,
-	0xab, 0x5d, 0x7d, 0xcb, 0x9f, 0xc0, 0xe5, 0xde, 0xe4, 0x70, 0x18, 0x24, 0xc7, 0x25, 0xb6, 0xf8,
-	0x06, 0x10, 0x14, 0x58, 0xdc, 0xbb, 0x2d, 0x57, 0x34, 0x2e, 0xe7, 0x2a, 0xd8, 0x65, 0xb2, 0x30,
-	0x37, 0xbc, 0x03, 0xd7, 0x35, 0x78, 0x3f, 0x4a, 0x83, 0xa3, 0xc0, 0xf7, 0xf4, 0xa2, 0xe6, 0x7c,
-	0xb9, 0x00, 0x37, 0x66, 0xd3, 0xa0, 0x25, 0x3e, 0x85, 0xa6, 0x97, 0xa6, 0x9e, 0x7f, 0xcc, 0xd4,
-	0x12, 0xb5, 0xe6, 0xcc, 0xd4, 0xde, 0x50, 0xf4, 0x02, 0x4d, 0x78, 0xfd, 0x1d, 0x50, 0x53, 0x02,
-	0x37, 0x11, 0x0b, 0x02, 0x05, 0x23, 0xe1, 0xac, 0x02, 0x60, 0x7d, 0xd5, 0x02, 0xc0, 0xf3, 0x51,
-	0x89, 0x44, 0x11, 0x4b, 0x54, 0x4e, 0xa4, 0x35, 0xb7, 0x53, 0x64, 0xfc, 0x5c, 0xac, 0x3b, 0xbf,
-	0xab, 0xc0, 0xf6, 0x01, 0x6b, 0x23, 0xd2, 0x90, 0xf5, 0x6b, 0x65, 0x16, 0x9c, 0x93, 0x65, 0x59,
-	0x31, 0x0f, 0xa3, 0x7e, 0xc8, 0x99, 0xde, 0xf6, 0x99, 0x2b, 0x70, 0x31, 0xc2, 0x65, 0x57, 0xdd,
-	0x66, 0x18, 0x09, 0x61, 0x6f, 0x9f, 0x49, 0x98, 0xf7, 0x6c, 0x19, 0xad, 0xa4, 0x94, 0x73, 0x7a,
-	0x5d, 0x51, 0x0a, 0x2d, 0x9c, 0xdf, 0x2f, 0xc0, 0xb5, 0x59, 0xfa, 0xe0, 0x6d, 0xfd, 0x7f, 0x93,
-	0xc6, 0x23, 0x58, 0x11, 0x6d, 0x14, 0x95, 0xaf, 0x4a, 0x66, 0xde, 0x9c, 0xaf, 0x89, 0x58, 0x66,
-	0x8c, 0xae, 0x92, 0x60, 0x3f, 0x83, 0x15, 0xc4, 0x2e, 0xa2, 0xe5, 0x75, 0xa8, 0x6a, 0xd1, 0x85,
-	0x4a, 0x42, 0x16, 0xc6, 0xce, 0x36, 0x5c, 0x51, 0xc3, 0x72, 0x99, 0x8f, 0xff, 0xb7, 0x02, 0x57,
-	0xcb, 0xd7, 0x2f, 0x34, 0x7b, 0x9c, 0x67, 0xae, 0x2c, 0x1f, 0x19, 0xad, 0x0b, 0x8d, 0x8c, 0x8b,
-	0x17, 0x1a, 0x19, 0x97, 0x66, 0x8c, 0x8c, 0xbf, 0xad, 0xc0, 0xda, 0x5e, 0x4c, 0x59, 0xfb, 0xfe,
-	0x5c, 0x5c, 0x97, 0x72, 0xd7, 0x8f, 0xa0, 0x3d, 0xe6, 0x19, 0xc3, 0xef, 0x17, 0x72, 0x6e, 0x4b,
-	0x2e, 0x68, 0xfd, 0x0b, 0xcb, 0x46, 0x6a, 0x92, 0x28, 0xb4, 0x3a, 0x6d, 0x5c, 0xd1, 0xc8, 0x09,
-	0x2c, 0x26, 0x94, 0x0e, 0xb0, 0xbe, 0x89, 0xdf, 0xce, 0x26, 0xac, 0x9b, 0x6a, 0x60, 0x6e, 0xfa,
-	0x14, 0xda, 0x4f, 0x98, 0x2b, 0x7c, 0x75, 0xe5, 0x9c, 0x75, 0x20, 0xba, 0x04, 0x94, 0xcb, 0xd0,
-	0xbd, 0x61, 0x94, 0x98, 0xa7, 0x76, 0x36, 0x98, 0x31, 0x74, 0x14, 0x89, 0x19, 0x2c, 0x91, 0x87,
-	0x6f, 0x82, 0x24, 0x7b, 0x29, 0xd9, 0x81, 0x75, 0x13, 0x46, 0x3f, 0x61, 0x05, 0x94, 0x0a, 0x44,
-	0xe8, 0xc4, 0x06, 0x26, 0xf9, 0xe5, 0x7c, 0x59, 0x81, 0xce, 0x01, 0xef, 0xe6, 0xf7, 0x38, 0x59,
-	0x98, 0x4c, 0x12, 0x77, 0xec, 0xab, 0x33, 0xb1, 0xd4, 0x87, 0x8f, 0x44, 0x7d, 0x73, 0x0a, 0x6c,
-	0x20, 0x8c, 0xe3, 0x22, 0x7f, 0xa3, 0x9b, 0x24, 0xfc, 0xca, 0xa7, 0xae, 0x35, 0xfd, 0xe6, 0x6b,
-	0xdc, 0x22, 0x8c, 0x5c, 0x59, 0x77, 0xfa, 0xcd, 0xeb, 0x94, 0x4f, 0x63, 0xf4, 0x6b, 0x8a, 0x05,
-	0x5c, 0x87, 0x9c, 0x2b, 0x70, 0xb9, 0x44, 0x3d, 0x79, 0xa8, 0x5d, 0x77, 0xfa, 0x2e, 0x7d, 0x40,
-	0xe3, 0xd7, 0x81, 0xcf, 0xd3, 0xfd, 0x0a, 0x22, 0xe4, 0xb2, 0x16, 0xec, 0xe6, 0xeb, 0xb5, 0x6d,
-	0x97, 0x2d, 0xa1, 0xcc, 0x7f, 0x57, 0xa1, 0x2e, 0x2d, 0xa8, 0x64, 0x7e, 0x0f, 0x16, 0xf9, 0x33,
-	0x1b, 0xd9, 0xd4, 0xb8, 0xb4, 0x67, 0x38, 0x7b, 0xab, 0x80, 0x4f, 0x6b, 0xcf, 0x0a, 0x3e, 0xa7,
-	0x19, 0xca, 0x98, 0x6f, 0x74, 0x86, 0x32, 0xf9, 0xc7, 0x3a, 0x17, 0xea, 0xc6, 0x53, 0x1a, 0xb9,
-	0x5e, 0x7c, 0xe1, 0x32, 0xde, 0xe7, 0xec, 0x1b, 0xb3, 0x09, 0x50, 0xe6, 0x1e, 0xac, 0xaa, 0xb7,
-	0x31, 0x62, 0x97, 0x3e, 0x98, 0x49, 0x49, 0x57, 0xe6, 0x3c, 0xa6, 0xf1, 0xa3, 0xa9, 0xa7, 0x26,
-	0xfd, 0x68, 0xe6, 0x7c, 0x6d, 0x1c, 0x2d, 0x3f, 0x0a, 0xbf, 0x80, 0x66, 0x6e, 0x22, 0x23, 0xef,
-	0x68, 0xe4, 0xe5, 0x83, 0xac, 0xed, 0xcc, 0x23, 0x41, 0xc9, 0x13, 0xe8, 0xcc, 0x6a, 0x0b, 0xc8,
-	0x87, 0xe5, 0x55, 0xb8, 0x2c, 0xf7, 0xda, 0x1f, 0x9d, 0x8b, 0x56, 0x6e, 0x7a, 0xa7, 0x42, 0x22,
-	0xd6, 0x24, 0x96, 0xd6, 0x14, 0x72, 0xfb, 0x1c, 0x65, 0x47, 0x6e, 0xf9, 0xc1, 0xb9, 0x0b, 0x14,
-	0xdb, 0x30, 0xc8, 0x9e, 0x68, 0x8d, 0xed, 0x6e, 0x95, 0xb8, 0x40, 0xd9, 0x66, 0xef, 0x9f, 0x49,
-	0x37, 0xdd, 0xea, 0x67, 0xd0, 0xca, 0x4f, 0x71, 0xc4, 0x39, 0x7b, 0xe8, 0xb4, 0x6f, 0xce, 0xa5,
-	0xc9, 0x9c, 0xdc, 0x78, 0xc7, 0x33, 0x9c, 0xbc, 0xec, 0xed, 0xd0, 0x70, 0xf2, 0xd2, 0x27, 0x40,
-	0xf2, 0x18, 0xaa, 0xda, 0x4b, 0x1d, 0xd9, 0xce, 0xbf, 0x9d, 0x99, 0xf2, 0xae, 0xcd, 0x5a, 0xce,
-	0x49, 0xc3, 0x6c, 0xb7, 0x3d, 0xf7, 0x25, 0xae, 0x28, 0x2d, 0xf7, 0xa6, 0xc6, 0x8c, 0x99, 0x7f,
-	0xa3, 0x32, 0x8c, 0x39, 0xe3, 0x55, 0xcd, 0x30, 0xe6, 0xac, 0x47, 0x2e, 0x1e, 0x56, 0xb9, 0x89,
-	0xd0, 0x08, 0xab, 0xf2, 0x71, 0xdb, 0x08, 0xab, 0x59, 0xe3, 0x28, 0x93, 0x9c, 0x1b, 0x37, 0x0c,
-	0xc9, 0xe5, 0x03, 0x92, 0x21, 0x79, 0xd6, 0xb4, 0xe2, 0x01, 0x29, 0x4e, 0x02, 0x44, 0xff, 0x0f,
-	0x6c, 0xe6, 0xd0, 0x61, 0xbf, 0x77, 0x06, 0x15, 0x66, 0xf5, 0xbf, 0x59, 0xaa, 0x5c, 0x3e, 0x8e,
-	0x3c, 0xd6, 0xc3, 0xa9, 0xdc, 0xfe, 0x04, 0x6a, 0x7a, 0xb9, 0x24, 0xfa, 0xdd, 0x95, 0x94, 0x57,
-	0xfb, 0xfa, 0xcc, 0x75, 0x3c, 0x0b, 0x13, 0xa8, 0xf7, 0x0c, 0x86, 0xc0, 0x92, 0x9e, 0xc6, 0x10,
-	0x58, 0xd6, 0x6c, 0x90, 0x2e, 0x40, 0xd6, 0x2a, 0x90, 0xab, 0x1a, 0x79, 0xa1, 0x07, 0xb1, 0xb7,
-	0x67, 0xac, 0x66, 0x6e, 0xac, 0x75, 0x12, 0x86, 0x1b, 0x17, 0xfb, 0x0e, 0xc3, 0x8d, 0x4b, 0x1a,
-	0x10, 0xf2, 0x0b, 0x68, 0x17, 0x2a, 0x33, 0xd1, 0x7d, 0x74, 0x56, 0x5b, 0x61, 0xbf, 0x3b, 0x9f,
-	0x48, 0xca, 0x3f, 0x5c, 0x16, 0x7f, 0x43, 0x7f, 0xfb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x22,
-	0xfc, 0x70, 0xb3, 0x93, 0x1e, 0x00, 0x00,
-}
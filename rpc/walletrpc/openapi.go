@@ -0,0 +1,9 @@
+package walletrpc
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI (Swagger 2.0) specification of the REST gateway
+// of the services, generated from the HTTP annotations of api.proto.
+//
+//go:embed api.swagger.json
+var OpenAPISpec []byte
@@ -0,0 +1,5864 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: v2/api.proto
+
+// Version 2 of the wallet service.  The service of this package covers the
+// public API of the wallet, including the key scopes, address types, PSBTs
+// and output leases that version 1 does not know about, so that clients need
+// not use version 1 next to it.  It is served next to
+// the version 1 services by the same gRPC server.
+//
+// A process may serve several wallets.  Requests that concern a wallet select
+// it with their wallet field, which is the name of a wallet loaded from the
+// wallets directory, or empty for the default wallet.
+
+package walletrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// AddressType selects the type of the addresses of an account.  Unless
+// noted otherwise, the unspecified type defaults to WITNESS_PUBKEY_HASH.
+type AddressType int32
+
+const (
+	AddressType_ADDRESS_TYPE_UNSPECIFIED   AddressType = 0
+	AddressType_PUBKEY_HASH                AddressType = 1
+	AddressType_NESTED_WITNESS_PUBKEY_HASH AddressType = 2
+	AddressType_WITNESS_PUBKEY_HASH        AddressType = 3
+	AddressType_TAPROOT_PUBKEY             AddressType = 4
+)
+
+var AddressType_name = map[int32]string{
+	0: "ADDRESS_TYPE_UNSPECIFIED",
+	1: "PUBKEY_HASH",
+	2: "NESTED_WITNESS_PUBKEY_HASH",
+	3: "WITNESS_PUBKEY_HASH",
+	4: "TAPROOT_PUBKEY",
+}
+
+var AddressType_value = map[string]int32{
+	"ADDRESS_TYPE_UNSPECIFIED":   0,
+	"PUBKEY_HASH":                1,
+	"NESTED_WITNESS_PUBKEY_HASH": 2,
+	"WITNESS_PUBKEY_HASH":        3,
+	"TAPROOT_PUBKEY":             4,
+}
+
+func (x AddressType) String() string {
+	return proto.EnumName(AddressType_name, int32(x))
+}
+
+func (AddressType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{0}
+}
+
+type CoinSelectionStrategy int32
+
+const (
+	CoinSelectionStrategy_LARGEST CoinSelectionStrategy = 0
+	CoinSelectionStrategy_RANDOM  CoinSelectionStrategy = 1
+)
+
+var CoinSelectionStrategy_name = map[int32]string{
+	0: "LARGEST",
+	1: "RANDOM",
+}
+
+var CoinSelectionStrategy_value = map[string]int32{
+	"LARGEST": 0,
+	"RANDOM":  1,
+}
+
+func (x CoinSelectionStrategy) String() string {
+	return proto.EnumName(CoinSelectionStrategy_name, int32(x))
+}
+
+func (CoinSelectionStrategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{1}
+}
+
+type ChangePassphraseRequest_Key int32
+
+const (
+	ChangePassphraseRequest_PRIVATE ChangePassphraseRequest_Key = 0
+	ChangePassphraseRequest_PUBLIC  ChangePassphraseRequest_Key = 1
+)
+
+var ChangePassphraseRequest_Key_name = map[int32]string{
+	0: "PRIVATE",
+	1: "PUBLIC",
+}
+
+var ChangePassphraseRequest_Key_value = map[string]int32{
+	"PRIVATE": 0,
+	"PUBLIC":  1,
+}
+
+func (x ChangePassphraseRequest_Key) String() string {
+	return proto.EnumName(ChangePassphraseRequest_Key_name, int32(x))
+}
+
+func (ChangePassphraseRequest_Key) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{28, 0}
+}
+
+// KeyScope is the BIP0043 purpose and coin type of an HD key hierarchy.
+type KeyScope struct {
+	Purpose              uint32   `protobuf:"varint,1,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Coin                 uint32   `protobuf:"varint,2,opt,name=coin,proto3" json:"coin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyScope) Reset()         { *m = KeyScope{} }
+func (m *KeyScope) String() string { return proto.CompactTextString(m) }
+func (*KeyScope) ProtoMessage()    {}
+func (*KeyScope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{0}
+}
+
+func (m *KeyScope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyScope.Unmarshal(m, b)
+}
+func (m *KeyScope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyScope.Marshal(b, m, deterministic)
+}
+func (m *KeyScope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyScope.Merge(m, src)
+}
+func (m *KeyScope) XXX_Size() int {
+	return xxx_messageInfo_KeyScope.Size(m)
+}
+func (m *KeyScope) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyScope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyScope proto.InternalMessageInfo
+
+func (m *KeyScope) GetPurpose() uint32 {
+	if m != nil {
+		return m.Purpose
+	}
+	return 0
+}
+
+func (m *KeyScope) GetCoin() uint32 {
+	if m != nil {
+		return m.Coin
+	}
+	return 0
+}
+
+type OutPoint struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutPoint) Reset()         { *m = OutPoint{} }
+func (m *OutPoint) String() string { return proto.CompactTextString(m) }
+func (*OutPoint) ProtoMessage()    {}
+func (*OutPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{1}
+}
+
+func (m *OutPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutPoint.Unmarshal(m, b)
+}
+func (m *OutPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutPoint.Marshal(b, m, deterministic)
+}
+func (m *OutPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutPoint.Merge(m, src)
+}
+func (m *OutPoint) XXX_Size() int {
+	return xxx_messageInfo_OutPoint.Size(m)
+}
+func (m *OutPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutPoint proto.InternalMessageInfo
+
+func (m *OutPoint) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *OutPoint) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type TransactionDetails struct {
+	Hash                 []byte                       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Transaction          []byte                       `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Debits               []*TransactionDetails_Input  `protobuf:"bytes,3,rep,name=debits,proto3" json:"debits,omitempty"`
+	Credits              []*TransactionDetails_Output `protobuf:"bytes,4,rep,name=credits,proto3" json:"credits,omitempty"`
+	Fee                  int64                        `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	Timestamp            int64                        `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Label                string                       `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *TransactionDetails) Reset()         { *m = TransactionDetails{} }
+func (m *TransactionDetails) String() string { return proto.CompactTextString(m) }
+func (*TransactionDetails) ProtoMessage()    {}
+func (*TransactionDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{2}
+}
+
+func (m *TransactionDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionDetails.Unmarshal(m, b)
+}
+func (m *TransactionDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionDetails.Marshal(b, m, deterministic)
+}
+func (m *TransactionDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionDetails.Merge(m, src)
+}
+func (m *TransactionDetails) XXX_Size() int {
+	return xxx_messageInfo_TransactionDetails.Size(m)
+}
+func (m *TransactionDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionDetails proto.InternalMessageInfo
+
+func (m *TransactionDetails) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *TransactionDetails) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *TransactionDetails) GetDebits() []*TransactionDetails_Input {
+	if m != nil {
+		return m.Debits
+	}
+	return nil
+}
+
+func (m *TransactionDetails) GetCredits() []*TransactionDetails_Output {
+	if m != nil {
+		return m.Credits
+	}
+	return nil
+}
+
+func (m *TransactionDetails) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *TransactionDetails) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *TransactionDetails) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type TransactionDetails_Input struct {
+	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	PreviousAccount      uint32   `protobuf:"varint,2,opt,name=previous_account,json=previousAccount,proto3" json:"previous_account,omitempty"`
+	PreviousAmount       int64    `protobuf:"varint,3,opt,name=previous_amount,json=previousAmount,proto3" json:"previous_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionDetails_Input) Reset()         { *m = TransactionDetails_Input{} }
+func (m *TransactionDetails_Input) String() string { return proto.CompactTextString(m) }
+func (*TransactionDetails_Input) ProtoMessage()    {}
+func (*TransactionDetails_Input) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{2, 0}
+}
+
+func (m *TransactionDetails_Input) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionDetails_Input.Unmarshal(m, b)
+}
+func (m *TransactionDetails_Input) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionDetails_Input.Marshal(b, m, deterministic)
+}
+func (m *TransactionDetails_Input) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionDetails_Input.Merge(m, src)
+}
+func (m *TransactionDetails_Input) XXX_Size() int {
+	return xxx_messageInfo_TransactionDetails_Input.Size(m)
+}
+func (m *TransactionDetails_Input) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionDetails_Input.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionDetails_Input proto.InternalMessageInfo
+
+func (m *TransactionDetails_Input) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *TransactionDetails_Input) GetPreviousAccount() uint32 {
+	if m != nil {
+		return m.PreviousAccount
+	}
+	return 0
+}
+
+func (m *TransactionDetails_Input) GetPreviousAmount() int64 {
+	if m != nil {
+		return m.PreviousAmount
+	}
+	return 0
+}
+
+type TransactionDetails_Output struct {
+	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Account              uint32   `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
+	Internal             bool     `protobuf:"varint,3,opt,name=internal,proto3" json:"internal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionDetails_Output) Reset()         { *m = TransactionDetails_Output{} }
+func (m *TransactionDetails_Output) String() string { return proto.CompactTextString(m) }
+func (*TransactionDetails_Output) ProtoMessage()    {}
+func (*TransactionDetails_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{2, 1}
+}
+
+func (m *TransactionDetails_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionDetails_Output.Unmarshal(m, b)
+}
+func (m *TransactionDetails_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionDetails_Output.Marshal(b, m, deterministic)
+}
+func (m *TransactionDetails_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionDetails_Output.Merge(m, src)
+}
+func (m *TransactionDetails_Output) XXX_Size() int {
+	return xxx_messageInfo_TransactionDetails_Output.Size(m)
+}
+func (m *TransactionDetails_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionDetails_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionDetails_Output proto.InternalMessageInfo
+
+func (m *TransactionDetails_Output) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *TransactionDetails_Output) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *TransactionDetails_Output) GetInternal() bool {
+	if m != nil {
+		return m.Internal
+	}
+	return false
+}
+
+type BlockDetails struct {
+	Hash                 []byte                `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               int32                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp            int64                 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Transactions         []*TransactionDetails `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BlockDetails) Reset()         { *m = BlockDetails{} }
+func (m *BlockDetails) String() string { return proto.CompactTextString(m) }
+func (*BlockDetails) ProtoMessage()    {}
+func (*BlockDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{3}
+}
+
+func (m *BlockDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockDetails.Unmarshal(m, b)
+}
+func (m *BlockDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockDetails.Marshal(b, m, deterministic)
+}
+func (m *BlockDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockDetails.Merge(m, src)
+}
+func (m *BlockDetails) XXX_Size() int {
+	return xxx_messageInfo_BlockDetails.Size(m)
+}
+func (m *BlockDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockDetails proto.InternalMessageInfo
+
+func (m *BlockDetails) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BlockDetails) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockDetails) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *BlockDetails) GetTransactions() []*TransactionDetails {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type PingRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingRequest) Reset()         { *m = PingRequest{} }
+func (m *PingRequest) String() string { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()    {}
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{4}
+}
+
+func (m *PingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
+}
+func (m *PingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingRequest.Marshal(b, m, deterministic)
+}
+func (m *PingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingRequest.Merge(m, src)
+}
+func (m *PingRequest) XXX_Size() int {
+	return xxx_messageInfo_PingRequest.Size(m)
+}
+func (m *PingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingRequest proto.InternalMessageInfo
+
+type PingResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingResponse) Reset()         { *m = PingResponse{} }
+func (m *PingResponse) String() string { return proto.CompactTextString(m) }
+func (*PingResponse) ProtoMessage()    {}
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{5}
+}
+
+func (m *PingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
+}
+func (m *PingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingResponse.Marshal(b, m, deterministic)
+}
+func (m *PingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResponse.Merge(m, src)
+}
+func (m *PingResponse) XXX_Size() int {
+	return xxx_messageInfo_PingResponse.Size(m)
+}
+func (m *PingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingResponse proto.InternalMessageInfo
+
+type NetworkRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkRequest) Reset()         { *m = NetworkRequest{} }
+func (m *NetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*NetworkRequest) ProtoMessage()    {}
+func (*NetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{6}
+}
+
+func (m *NetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkRequest.Unmarshal(m, b)
+}
+func (m *NetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *NetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkRequest.Merge(m, src)
+}
+func (m *NetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_NetworkRequest.Size(m)
+}
+func (m *NetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkRequest proto.InternalMessageInfo
+
+type NetworkResponse struct {
+	ActiveNetwork        uint32   `protobuf:"varint,1,opt,name=active_network,json=activeNetwork,proto3" json:"active_network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkResponse) Reset()         { *m = NetworkResponse{} }
+func (m *NetworkResponse) String() string { return proto.CompactTextString(m) }
+func (*NetworkResponse) ProtoMessage()    {}
+func (*NetworkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{7}
+}
+
+func (m *NetworkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkResponse.Unmarshal(m, b)
+}
+func (m *NetworkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkResponse.Marshal(b, m, deterministic)
+}
+func (m *NetworkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkResponse.Merge(m, src)
+}
+func (m *NetworkResponse) XXX_Size() int {
+	return xxx_messageInfo_NetworkResponse.Size(m)
+}
+func (m *NetworkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkResponse proto.InternalMessageInfo
+
+func (m *NetworkResponse) GetActiveNetwork() uint32 {
+	if m != nil {
+		return m.ActiveNetwork
+	}
+	return 0
+}
+
+type AccountsRequest struct {
+	// Only the accounts of this key scope are returned if set, otherwise
+	// the accounts of all active key scopes.
+	Scope *KeyScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountsRequest) Reset()         { *m = AccountsRequest{} }
+func (m *AccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountsRequest) ProtoMessage()    {}
+func (*AccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{8}
+}
+
+func (m *AccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsRequest.Unmarshal(m, b)
+}
+func (m *AccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsRequest.Merge(m, src)
+}
+func (m *AccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountsRequest.Size(m)
+}
+func (m *AccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsRequest proto.InternalMessageInfo
+
+func (m *AccountsRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *AccountsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type AccountsResponse struct {
+	Accounts             []*AccountsResponse_Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	CurrentBlockHash     []byte                      `protobuf:"bytes,2,opt,name=current_block_hash,json=currentBlockHash,proto3" json:"current_block_hash,omitempty"`
+	CurrentBlockHeight   int32                       `protobuf:"varint,3,opt,name=current_block_height,json=currentBlockHeight,proto3" json:"current_block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *AccountsResponse) Reset()         { *m = AccountsResponse{} }
+func (m *AccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse) ProtoMessage()    {}
+func (*AccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{9}
+}
+
+func (m *AccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsResponse.Unmarshal(m, b)
+}
+func (m *AccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsResponse.Merge(m, src)
+}
+func (m *AccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountsResponse.Size(m)
+}
+func (m *AccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsResponse proto.InternalMessageInfo
+
+func (m *AccountsResponse) GetAccounts() []*AccountsResponse_Account {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *AccountsResponse) GetCurrentBlockHash() []byte {
+	if m != nil {
+		return m.CurrentBlockHash
+	}
+	return nil
+}
+
+func (m *AccountsResponse) GetCurrentBlockHeight() int32 {
+	if m != nil {
+		return m.CurrentBlockHeight
+	}
+	return 0
+}
+
+type AccountsResponse_Account struct {
+	Scope                *KeyScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	AccountNumber        uint32    `protobuf:"varint,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName          string    `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	TotalBalance         int64     `protobuf:"varint,4,opt,name=total_balance,json=totalBalance,proto3" json:"total_balance,omitempty"`
+	ExternalKeyCount     uint32    `protobuf:"varint,5,opt,name=external_key_count,json=externalKeyCount,proto3" json:"external_key_count,omitempty"`
+	InternalKeyCount     uint32    `protobuf:"varint,6,opt,name=internal_key_count,json=internalKeyCount,proto3" json:"internal_key_count,omitempty"`
+	ImportedKeyCount     uint32    `protobuf:"varint,7,opt,name=imported_key_count,json=importedKeyCount,proto3" json:"imported_key_count,omitempty"`
+	ExtendedPublicKey    string    `protobuf:"bytes,8,opt,name=extended_public_key,json=extendedPublicKey,proto3" json:"extended_public_key,omitempty"`
+	MasterKeyFingerprint uint32    `protobuf:"varint,9,opt,name=master_key_fingerprint,json=masterKeyFingerprint,proto3" json:"master_key_fingerprint,omitempty"`
+	WatchOnly            bool      `protobuf:"varint,10,opt,name=watch_only,json=watchOnly,proto3" json:"watch_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *AccountsResponse_Account) Reset()         { *m = AccountsResponse_Account{} }
+func (m *AccountsResponse_Account) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse_Account) ProtoMessage()    {}
+func (*AccountsResponse_Account) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{9, 0}
+}
+
+func (m *AccountsResponse_Account) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsResponse_Account.Unmarshal(m, b)
+}
+func (m *AccountsResponse_Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsResponse_Account.Marshal(b, m, deterministic)
+}
+func (m *AccountsResponse_Account) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsResponse_Account.Merge(m, src)
+}
+func (m *AccountsResponse_Account) XXX_Size() int {
+	return xxx_messageInfo_AccountsResponse_Account.Size(m)
+}
+func (m *AccountsResponse_Account) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsResponse_Account.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsResponse_Account proto.InternalMessageInfo
+
+func (m *AccountsResponse_Account) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *AccountsResponse_Account) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *AccountsResponse_Account) GetTotalBalance() int64 {
+	if m != nil {
+		return m.TotalBalance
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetExternalKeyCount() uint32 {
+	if m != nil {
+		return m.ExternalKeyCount
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetInternalKeyCount() uint32 {
+	if m != nil {
+		return m.InternalKeyCount
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetImportedKeyCount() uint32 {
+	if m != nil {
+		return m.ImportedKeyCount
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetExtendedPublicKey() string {
+	if m != nil {
+		return m.ExtendedPublicKey
+	}
+	return ""
+}
+
+func (m *AccountsResponse_Account) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetWatchOnly() bool {
+	if m != nil {
+		return m.WatchOnly
+	}
+	return false
+}
+
+type BalanceRequest struct {
+	AccountNumber         uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	RequiredConfirmations int32  `protobuf:"varint,2,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	// Sum the balances of all accounts, ignoring account_number.
+	AllAccounts bool `protobuf:"varint,3,opt,name=all_accounts,json=allAccounts,proto3" json:"all_accounts,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceRequest) Reset()         { *m = BalanceRequest{} }
+func (m *BalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*BalanceRequest) ProtoMessage()    {}
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{10}
+}
+
+func (m *BalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceRequest.Unmarshal(m, b)
+}
+func (m *BalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *BalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceRequest.Merge(m, src)
+}
+func (m *BalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_BalanceRequest.Size(m)
+}
+func (m *BalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceRequest proto.InternalMessageInfo
+
+func (m *BalanceRequest) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *BalanceRequest) GetRequiredConfirmations() int32 {
+	if m != nil {
+		return m.RequiredConfirmations
+	}
+	return 0
+}
+
+func (m *BalanceRequest) GetAllAccounts() bool {
+	if m != nil {
+		return m.AllAccounts
+	}
+	return false
+}
+
+func (m *BalanceRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type BalanceResponse struct {
+	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Spendable            int64    `protobuf:"varint,2,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	ImmatureReward       int64    `protobuf:"varint,3,opt,name=immature_reward,json=immatureReward,proto3" json:"immature_reward,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceResponse) Reset()         { *m = BalanceResponse{} }
+func (m *BalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*BalanceResponse) ProtoMessage()    {}
+func (*BalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{11}
+}
+
+func (m *BalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceResponse.Unmarshal(m, b)
+}
+func (m *BalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *BalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceResponse.Merge(m, src)
+}
+func (m *BalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_BalanceResponse.Size(m)
+}
+func (m *BalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceResponse proto.InternalMessageInfo
+
+func (m *BalanceResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *BalanceResponse) GetSpendable() int64 {
+	if m != nil {
+		return m.Spendable
+	}
+	return 0
+}
+
+func (m *BalanceResponse) GetImmatureReward() int64 {
+	if m != nil {
+		return m.ImmatureReward
+	}
+	return 0
+}
+
+type ListUnspentRequest struct {
+	MinConfirmations int32 `protobuf:"varint,1,opt,name=min_confirmations,json=minConfirmations,proto3" json:"min_confirmations,omitempty"`
+	// Outputs with more confirmations are excluded.  Zero means no limit.
+	MaxConfirmations int32 `protobuf:"varint,2,opt,name=max_confirmations,json=maxConfirmations,proto3" json:"max_confirmations,omitempty"`
+	// Only outputs of the named account are returned if set.
+	AccountName string `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUnspentRequest) Reset()         { *m = ListUnspentRequest{} }
+func (m *ListUnspentRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUnspentRequest) ProtoMessage()    {}
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{12}
+}
+
+func (m *ListUnspentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnspentRequest.Unmarshal(m, b)
+}
+func (m *ListUnspentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnspentRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUnspentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnspentRequest.Merge(m, src)
+}
+func (m *ListUnspentRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUnspentRequest.Size(m)
+}
+func (m *ListUnspentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnspentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnspentRequest proto.InternalMessageInfo
+
+func (m *ListUnspentRequest) GetMinConfirmations() int32 {
+	if m != nil {
+		return m.MinConfirmations
+	}
+	return 0
+}
+
+func (m *ListUnspentRequest) GetMaxConfirmations() int32 {
+	if m != nil {
+		return m.MaxConfirmations
+	}
+	return 0
+}
+
+func (m *ListUnspentRequest) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *ListUnspentRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ListUnspentResponse struct {
+	Outputs              []*ListUnspentResponse_Output `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ListUnspentResponse) Reset()         { *m = ListUnspentResponse{} }
+func (m *ListUnspentResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUnspentResponse) ProtoMessage()    {}
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{13}
+}
+
+func (m *ListUnspentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnspentResponse.Unmarshal(m, b)
+}
+func (m *ListUnspentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnspentResponse.Marshal(b, m, deterministic)
+}
+func (m *ListUnspentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnspentResponse.Merge(m, src)
+}
+func (m *ListUnspentResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUnspentResponse.Size(m)
+}
+func (m *ListUnspentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnspentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnspentResponse proto.InternalMessageInfo
+
+func (m *ListUnspentResponse) GetOutputs() []*ListUnspentResponse_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type ListUnspentResponse_Output struct {
+	Outpoint             *OutPoint `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	Address              string    `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	AccountName          string    `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Amount               int64     `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	PkScript             []byte    `protobuf:"bytes,5,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	RedeemScript         []byte    `protobuf:"bytes,6,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	Confirmations        int64     `protobuf:"varint,7,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Spendable            bool      `protobuf:"varint,8,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListUnspentResponse_Output) Reset()         { *m = ListUnspentResponse_Output{} }
+func (m *ListUnspentResponse_Output) String() string { return proto.CompactTextString(m) }
+func (*ListUnspentResponse_Output) ProtoMessage()    {}
+func (*ListUnspentResponse_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{13, 0}
+}
+
+func (m *ListUnspentResponse_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnspentResponse_Output.Unmarshal(m, b)
+}
+func (m *ListUnspentResponse_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnspentResponse_Output.Marshal(b, m, deterministic)
+}
+func (m *ListUnspentResponse_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnspentResponse_Output.Merge(m, src)
+}
+func (m *ListUnspentResponse_Output) XXX_Size() int {
+	return xxx_messageInfo_ListUnspentResponse_Output.Size(m)
+}
+func (m *ListUnspentResponse_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnspentResponse_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnspentResponse_Output proto.InternalMessageInfo
+
+func (m *ListUnspentResponse_Output) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *ListUnspentResponse_Output) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ListUnspentResponse_Output) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *ListUnspentResponse_Output) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ListUnspentResponse_Output) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+func (m *ListUnspentResponse_Output) GetRedeemScript() []byte {
+	if m != nil {
+		return m.RedeemScript
+	}
+	return nil
+}
+
+func (m *ListUnspentResponse_Output) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+func (m *ListUnspentResponse_Output) GetSpendable() bool {
+	if m != nil {
+		return m.Spendable
+	}
+	return false
+}
+
+type ListLeasedOutputsRequest struct {
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLeasedOutputsRequest) Reset()         { *m = ListLeasedOutputsRequest{} }
+func (m *ListLeasedOutputsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListLeasedOutputsRequest) ProtoMessage()    {}
+func (*ListLeasedOutputsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{14}
+}
+
+func (m *ListLeasedOutputsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeasedOutputsRequest.Unmarshal(m, b)
+}
+func (m *ListLeasedOutputsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeasedOutputsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListLeasedOutputsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeasedOutputsRequest.Merge(m, src)
+}
+func (m *ListLeasedOutputsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListLeasedOutputsRequest.Size(m)
+}
+func (m *ListLeasedOutputsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeasedOutputsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeasedOutputsRequest proto.InternalMessageInfo
+
+func (m *ListLeasedOutputsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ListLeasedOutputsResponse struct {
+	Outputs              []*ListLeasedOutputsResponse_LeasedOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *ListLeasedOutputsResponse) Reset()         { *m = ListLeasedOutputsResponse{} }
+func (m *ListLeasedOutputsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListLeasedOutputsResponse) ProtoMessage()    {}
+func (*ListLeasedOutputsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{15}
+}
+
+func (m *ListLeasedOutputsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeasedOutputsResponse.Unmarshal(m, b)
+}
+func (m *ListLeasedOutputsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeasedOutputsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListLeasedOutputsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeasedOutputsResponse.Merge(m, src)
+}
+func (m *ListLeasedOutputsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListLeasedOutputsResponse.Size(m)
+}
+func (m *ListLeasedOutputsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeasedOutputsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeasedOutputsResponse proto.InternalMessageInfo
+
+func (m *ListLeasedOutputsResponse) GetOutputs() []*ListLeasedOutputsResponse_LeasedOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type ListLeasedOutputsResponse_LeasedOutput struct {
+	Id                   []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint             *OutPoint `protobuf:"bytes,2,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	Expiration           int64     `protobuf:"varint,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Amount               int64     `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	PkScript             []byte    `protobuf:"bytes,5,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) Reset() {
+	*m = ListLeasedOutputsResponse_LeasedOutput{}
+}
+func (m *ListLeasedOutputsResponse_LeasedOutput) String() string { return proto.CompactTextString(m) }
+func (*ListLeasedOutputsResponse_LeasedOutput) ProtoMessage()    {}
+func (*ListLeasedOutputsResponse_LeasedOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{15, 0}
+}
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeasedOutputsResponse_LeasedOutput.Unmarshal(m, b)
+}
+func (m *ListLeasedOutputsResponse_LeasedOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeasedOutputsResponse_LeasedOutput.Marshal(b, m, deterministic)
+}
+func (m *ListLeasedOutputsResponse_LeasedOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeasedOutputsResponse_LeasedOutput.Merge(m, src)
+}
+func (m *ListLeasedOutputsResponse_LeasedOutput) XXX_Size() int {
+	return xxx_messageInfo_ListLeasedOutputsResponse_LeasedOutput.Size(m)
+}
+func (m *ListLeasedOutputsResponse_LeasedOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeasedOutputsResponse_LeasedOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeasedOutputsResponse_LeasedOutput proto.InternalMessageInfo
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ListLeasedOutputsResponse_LeasedOutput) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+type GetTransactionRequest struct {
+	TransactionHash []byte `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{16}
+}
+
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *GetTransactionRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type GetTransactionResponse struct {
+	Transaction *TransactionDetails `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// The block fields are only set for mined transactions.
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight          int32    `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockTimestamp       int64    `protobuf:"varint,4,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Confirmations        int32    `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionResponse) Reset()         { *m = GetTransactionResponse{} }
+func (m *GetTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResponse) ProtoMessage()    {}
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{17}
+}
+
+func (m *GetTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionResponse.Merge(m, src)
+}
+func (m *GetTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionResponse.Size(m)
+}
+func (m *GetTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionResponse proto.InternalMessageInfo
+
+func (m *GetTransactionResponse) GetTransaction() *TransactionDetails {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *GetTransactionResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *GetTransactionResponse) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionResponse) GetBlockTimestamp() int64 {
+	if m != nil {
+		return m.BlockTimestamp
+	}
+	return 0
+}
+
+func (m *GetTransactionResponse) GetConfirmations() int32 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+type RescanStatusRequest struct {
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanStatusRequest) Reset()         { *m = RescanStatusRequest{} }
+func (m *RescanStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*RescanStatusRequest) ProtoMessage()    {}
+func (*RescanStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{18}
+}
+
+func (m *RescanStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanStatusRequest.Unmarshal(m, b)
+}
+func (m *RescanStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *RescanStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanStatusRequest.Merge(m, src)
+}
+func (m *RescanStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_RescanStatusRequest.Size(m)
+}
+func (m *RescanStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanStatusRequest proto.InternalMessageInfo
+
+func (m *RescanStatusRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type RescanStatusResponse struct {
+	Scanning             bool     `protobuf:"varint,1,opt,name=scanning,proto3" json:"scanning,omitempty"`
+	Started              int64    `protobuf:"varint,2,opt,name=started,proto3" json:"started,omitempty"`
+	StartHeight          int32    `protobuf:"varint,3,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	Height               int32    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	BestHeight           int32    `protobuf:"varint,5,opt,name=best_height,json=bestHeight,proto3" json:"best_height,omitempty"`
+	Progress             float64  `protobuf:"fixed64,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanStatusResponse) Reset()         { *m = RescanStatusResponse{} }
+func (m *RescanStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*RescanStatusResponse) ProtoMessage()    {}
+func (*RescanStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{19}
+}
+
+func (m *RescanStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanStatusResponse.Unmarshal(m, b)
+}
+func (m *RescanStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *RescanStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanStatusResponse.Merge(m, src)
+}
+func (m *RescanStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_RescanStatusResponse.Size(m)
+}
+func (m *RescanStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanStatusResponse proto.InternalMessageInfo
+
+func (m *RescanStatusResponse) GetScanning() bool {
+	if m != nil {
+		return m.Scanning
+	}
+	return false
+}
+
+func (m *RescanStatusResponse) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *RescanStatusResponse) GetStartHeight() int32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *RescanStatusResponse) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RescanStatusResponse) GetBestHeight() int32 {
+	if m != nil {
+		return m.BestHeight
+	}
+	return 0
+}
+
+func (m *RescanStatusResponse) GetProgress() float64 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+type TransactionNotificationsRequest struct {
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionNotificationsRequest) Reset()         { *m = TransactionNotificationsRequest{} }
+func (m *TransactionNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*TransactionNotificationsRequest) ProtoMessage()    {}
+func (*TransactionNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{20}
+}
+
+func (m *TransactionNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionNotificationsRequest.Unmarshal(m, b)
+}
+func (m *TransactionNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *TransactionNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionNotificationsRequest.Merge(m, src)
+}
+func (m *TransactionNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_TransactionNotificationsRequest.Size(m)
+}
+func (m *TransactionNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionNotificationsRequest proto.InternalMessageInfo
+
+func (m *TransactionNotificationsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type TransactionNotificationsResponse struct {
+	// Sorted by increasing height.
+	AttachedBlocks []*BlockDetails `protobuf:"bytes,1,rep,name=attached_blocks,json=attachedBlocks,proto3" json:"attached_blocks,omitempty"`
+	// The blocks with wallet transactions that are no longer in the best
+	// chain after a reorganization.
+	DetachedBlocks [][]byte `protobuf:"bytes,2,rep,name=detached_blocks,json=detachedBlocks,proto3" json:"detached_blocks,omitempty"`
+	// New unmined transactions, including transactions of detached blocks
+	// that are not mined or double spent in the new chain.
+	UnminedTransactions []*TransactionDetails `protobuf:"bytes,3,rep,name=unmined_transactions,json=unminedTransactions,proto3" json:"unmined_transactions,omitempty"`
+	// All unmined transaction hashes of the wallet.
+	UnminedTransactionHashes [][]byte `protobuf:"bytes,4,rep,name=unmined_transaction_hashes,json=unminedTransactionHashes,proto3" json:"unmined_transaction_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
+}
+
+func (m *TransactionNotificationsResponse) Reset()         { *m = TransactionNotificationsResponse{} }
+func (m *TransactionNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionNotificationsResponse) ProtoMessage()    {}
+func (*TransactionNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{21}
+}
+
+func (m *TransactionNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionNotificationsResponse.Unmarshal(m, b)
+}
+func (m *TransactionNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *TransactionNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionNotificationsResponse.Merge(m, src)
+}
+func (m *TransactionNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_TransactionNotificationsResponse.Size(m)
+}
+func (m *TransactionNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionNotificationsResponse proto.InternalMessageInfo
+
+func (m *TransactionNotificationsResponse) GetAttachedBlocks() []*BlockDetails {
+	if m != nil {
+		return m.AttachedBlocks
+	}
+	return nil
+}
+
+func (m *TransactionNotificationsResponse) GetDetachedBlocks() [][]byte {
+	if m != nil {
+		return m.DetachedBlocks
+	}
+	return nil
+}
+
+func (m *TransactionNotificationsResponse) GetUnminedTransactions() []*TransactionDetails {
+	if m != nil {
+		return m.UnminedTransactions
+	}
+	return nil
+}
+
+func (m *TransactionNotificationsResponse) GetUnminedTransactionHashes() [][]byte {
+	if m != nil {
+		return m.UnminedTransactionHashes
+	}
+	return nil
+}
+
+type SpentnessNotificationsRequest struct {
+	Account         uint32 `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	NoNotifyUnspent bool   `protobuf:"varint,2,opt,name=no_notify_unspent,json=noNotifyUnspent,proto3" json:"no_notify_unspent,omitempty"`
+	NoNotifySpent   bool   `protobuf:"varint,3,opt,name=no_notify_spent,json=noNotifySpent,proto3" json:"no_notify_spent,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpentnessNotificationsRequest) Reset()         { *m = SpentnessNotificationsRequest{} }
+func (m *SpentnessNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*SpentnessNotificationsRequest) ProtoMessage()    {}
+func (*SpentnessNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{22}
+}
+
+func (m *SpentnessNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentnessNotificationsRequest.Unmarshal(m, b)
+}
+func (m *SpentnessNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentnessNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *SpentnessNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentnessNotificationsRequest.Merge(m, src)
+}
+func (m *SpentnessNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_SpentnessNotificationsRequest.Size(m)
+}
+func (m *SpentnessNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentnessNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpentnessNotificationsRequest proto.InternalMessageInfo
+
+func (m *SpentnessNotificationsRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *SpentnessNotificationsRequest) GetNoNotifyUnspent() bool {
+	if m != nil {
+		return m.NoNotifyUnspent
+	}
+	return false
+}
+
+func (m *SpentnessNotificationsRequest) GetNoNotifySpent() bool {
+	if m != nil {
+		return m.NoNotifySpent
+	}
+	return false
+}
+
+func (m *SpentnessNotificationsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type SpentnessNotificationsResponse struct {
+	TransactionHash      []byte                                  `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	OutputIndex          uint32                                  `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	Spender              *SpentnessNotificationsResponse_Spender `protobuf:"bytes,3,opt,name=spender,proto3" json:"spender,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
+}
+
+func (m *SpentnessNotificationsResponse) Reset()         { *m = SpentnessNotificationsResponse{} }
+func (m *SpentnessNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*SpentnessNotificationsResponse) ProtoMessage()    {}
+func (*SpentnessNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{23}
+}
+
+func (m *SpentnessNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentnessNotificationsResponse.Unmarshal(m, b)
+}
+func (m *SpentnessNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentnessNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *SpentnessNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentnessNotificationsResponse.Merge(m, src)
+}
+func (m *SpentnessNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_SpentnessNotificationsResponse.Size(m)
+}
+func (m *SpentnessNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentnessNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpentnessNotificationsResponse proto.InternalMessageInfo
+
+func (m *SpentnessNotificationsResponse) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *SpentnessNotificationsResponse) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+func (m *SpentnessNotificationsResponse) GetSpender() *SpentnessNotificationsResponse_Spender {
+	if m != nil {
+		return m.Spender
+	}
+	return nil
+}
+
+type SpentnessNotificationsResponse_Spender struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	InputIndex           uint32   `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpentnessNotificationsResponse_Spender) Reset() {
+	*m = SpentnessNotificationsResponse_Spender{}
+}
+func (m *SpentnessNotificationsResponse_Spender) String() string { return proto.CompactTextString(m) }
+func (*SpentnessNotificationsResponse_Spender) ProtoMessage()    {}
+func (*SpentnessNotificationsResponse_Spender) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{23, 0}
+}
+
+func (m *SpentnessNotificationsResponse_Spender) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentnessNotificationsResponse_Spender.Unmarshal(m, b)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentnessNotificationsResponse_Spender.Marshal(b, m, deterministic)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentnessNotificationsResponse_Spender.Merge(m, src)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_Size() int {
+	return xxx_messageInfo_SpentnessNotificationsResponse_Spender.Size(m)
+}
+func (m *SpentnessNotificationsResponse_Spender) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentnessNotificationsResponse_Spender.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpentnessNotificationsResponse_Spender proto.InternalMessageInfo
+
+func (m *SpentnessNotificationsResponse_Spender) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *SpentnessNotificationsResponse_Spender) GetInputIndex() uint32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
+}
+
+type AccountNotificationsRequest struct {
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountNotificationsRequest) Reset()         { *m = AccountNotificationsRequest{} }
+func (m *AccountNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountNotificationsRequest) ProtoMessage()    {}
+func (*AccountNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{24}
+}
+
+func (m *AccountNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountNotificationsRequest.Unmarshal(m, b)
+}
+func (m *AccountNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountNotificationsRequest.Merge(m, src)
+}
+func (m *AccountNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountNotificationsRequest.Size(m)
+}
+func (m *AccountNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountNotificationsRequest proto.InternalMessageInfo
+
+func (m *AccountNotificationsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type AccountNotificationsResponse struct {
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName          string   `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	ExternalKeyCount     uint32   `protobuf:"varint,3,opt,name=external_key_count,json=externalKeyCount,proto3" json:"external_key_count,omitempty"`
+	InternalKeyCount     uint32   `protobuf:"varint,4,opt,name=internal_key_count,json=internalKeyCount,proto3" json:"internal_key_count,omitempty"`
+	ImportedKeyCount     uint32   `protobuf:"varint,5,opt,name=imported_key_count,json=importedKeyCount,proto3" json:"imported_key_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountNotificationsResponse) Reset()         { *m = AccountNotificationsResponse{} }
+func (m *AccountNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountNotificationsResponse) ProtoMessage()    {}
+func (*AccountNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{25}
+}
+
+func (m *AccountNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountNotificationsResponse.Unmarshal(m, b)
+}
+func (m *AccountNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountNotificationsResponse.Merge(m, src)
+}
+func (m *AccountNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountNotificationsResponse.Size(m)
+}
+func (m *AccountNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountNotificationsResponse proto.InternalMessageInfo
+
+func (m *AccountNotificationsResponse) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *AccountNotificationsResponse) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *AccountNotificationsResponse) GetExternalKeyCount() uint32 {
+	if m != nil {
+		return m.ExternalKeyCount
+	}
+	return 0
+}
+
+func (m *AccountNotificationsResponse) GetInternalKeyCount() uint32 {
+	if m != nil {
+		return m.InternalKeyCount
+	}
+	return 0
+}
+
+func (m *AccountNotificationsResponse) GetImportedKeyCount() uint32 {
+	if m != nil {
+		return m.ImportedKeyCount
+	}
+	return 0
+}
+
+type ConfirmationNotificationsRequest struct {
+	TransactionHash     []byte `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TargetConfirmations int32  `protobuf:"varint,2,opt,name=target_confirmations,json=targetConfirmations,proto3" json:"target_confirmations,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmationNotificationsRequest) Reset()         { *m = ConfirmationNotificationsRequest{} }
+func (m *ConfirmationNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmationNotificationsRequest) ProtoMessage()    {}
+func (*ConfirmationNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{26}
+}
+
+func (m *ConfirmationNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmationNotificationsRequest.Unmarshal(m, b)
+}
+func (m *ConfirmationNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmationNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ConfirmationNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmationNotificationsRequest.Merge(m, src)
+}
+func (m *ConfirmationNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmationNotificationsRequest.Size(m)
+}
+func (m *ConfirmationNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmationNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmationNotificationsRequest proto.InternalMessageInfo
+
+func (m *ConfirmationNotificationsRequest) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *ConfirmationNotificationsRequest) GetTargetConfirmations() int32 {
+	if m != nil {
+		return m.TargetConfirmations
+	}
+	return 0
+}
+
+func (m *ConfirmationNotificationsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ConfirmationNotificationsResponse struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Confirmations        int32    `protobuf:"varint,2,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight          int32    `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Reached              bool     `protobuf:"varint,5,opt,name=reached,proto3" json:"reached,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmationNotificationsResponse) Reset()         { *m = ConfirmationNotificationsResponse{} }
+func (m *ConfirmationNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfirmationNotificationsResponse) ProtoMessage()    {}
+func (*ConfirmationNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{27}
+}
+
+func (m *ConfirmationNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmationNotificationsResponse.Unmarshal(m, b)
+}
+func (m *ConfirmationNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmationNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ConfirmationNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmationNotificationsResponse.Merge(m, src)
+}
+func (m *ConfirmationNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ConfirmationNotificationsResponse.Size(m)
+}
+func (m *ConfirmationNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmationNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmationNotificationsResponse proto.InternalMessageInfo
+
+func (m *ConfirmationNotificationsResponse) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *ConfirmationNotificationsResponse) GetConfirmations() int32 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+func (m *ConfirmationNotificationsResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ConfirmationNotificationsResponse) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ConfirmationNotificationsResponse) GetReached() bool {
+	if m != nil {
+		return m.Reached
+	}
+	return false
+}
+
+type ChangePassphraseRequest struct {
+	Key           ChangePassphraseRequest_Key `protobuf:"varint,1,opt,name=key,proto3,enum=walletrpc.v2.ChangePassphraseRequest_Key" json:"key,omitempty"`
+	OldPassphrase []byte                      `protobuf:"bytes,2,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
+	NewPassphrase []byte                      `protobuf:"bytes,3,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangePassphraseRequest) Reset()         { *m = ChangePassphraseRequest{} }
+func (m *ChangePassphraseRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangePassphraseRequest) ProtoMessage()    {}
+func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{28}
+}
+
+func (m *ChangePassphraseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangePassphraseRequest.Unmarshal(m, b)
+}
+func (m *ChangePassphraseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangePassphraseRequest.Marshal(b, m, deterministic)
+}
+func (m *ChangePassphraseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangePassphraseRequest.Merge(m, src)
+}
+func (m *ChangePassphraseRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangePassphraseRequest.Size(m)
+}
+func (m *ChangePassphraseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangePassphraseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangePassphraseRequest proto.InternalMessageInfo
+
+func (m *ChangePassphraseRequest) GetKey() ChangePassphraseRequest_Key {
+	if m != nil {
+		return m.Key
+	}
+	return ChangePassphraseRequest_PRIVATE
+}
+
+func (m *ChangePassphraseRequest) GetOldPassphrase() []byte {
+	if m != nil {
+		return m.OldPassphrase
+	}
+	return nil
+}
+
+func (m *ChangePassphraseRequest) GetNewPassphrase() []byte {
+	if m != nil {
+		return m.NewPassphrase
+	}
+	return nil
+}
+
+func (m *ChangePassphraseRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ChangePassphraseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangePassphraseResponse) Reset()         { *m = ChangePassphraseResponse{} }
+func (m *ChangePassphraseResponse) String() string { return proto.CompactTextString(m) }
+func (*ChangePassphraseResponse) ProtoMessage()    {}
+func (*ChangePassphraseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{29}
+}
+
+func (m *ChangePassphraseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangePassphraseResponse.Unmarshal(m, b)
+}
+func (m *ChangePassphraseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangePassphraseResponse.Marshal(b, m, deterministic)
+}
+func (m *ChangePassphraseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangePassphraseResponse.Merge(m, src)
+}
+func (m *ChangePassphraseResponse) XXX_Size() int {
+	return xxx_messageInfo_ChangePassphraseResponse.Size(m)
+}
+func (m *ChangePassphraseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangePassphraseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangePassphraseResponse proto.InternalMessageInfo
+
+type CreateAccountRequest struct {
+	Passphrase []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// The key scope of the account.  Defaults to BIP0084.
+	Scope *KeyScope `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	Name  string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccountRequest) Reset()         { *m = CreateAccountRequest{} }
+func (m *CreateAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAccountRequest) ProtoMessage()    {}
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{30}
+}
+
+func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccountRequest.Unmarshal(m, b)
+}
+func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
+}
+func (m *CreateAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAccountRequest.Size(m)
+}
+func (m *CreateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
+
+func (m *CreateAccountRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *CreateAccountRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *CreateAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateAccountRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type CreateAccountResponse struct {
+	AccountNumber        uint32   `protobuf:"varint,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccountResponse) Reset()         { *m = CreateAccountResponse{} }
+func (m *CreateAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAccountResponse) ProtoMessage()    {}
+func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{31}
+}
+
+func (m *CreateAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccountResponse.Unmarshal(m, b)
+}
+func (m *CreateAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountResponse.Merge(m, src)
+}
+func (m *CreateAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAccountResponse.Size(m)
+}
+func (m *CreateAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountResponse proto.InternalMessageInfo
+
+func (m *CreateAccountResponse) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+type RenameAccountRequest struct {
+	// The key scope of the account.  Defaults to BIP0084.
+	Scope         *KeyScope `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	AccountNumber uint32    `protobuf:"varint,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	NewName       string    `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameAccountRequest) Reset()         { *m = RenameAccountRequest{} }
+func (m *RenameAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameAccountRequest) ProtoMessage()    {}
+func (*RenameAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{32}
+}
+
+func (m *RenameAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameAccountRequest.Unmarshal(m, b)
+}
+func (m *RenameAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *RenameAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameAccountRequest.Merge(m, src)
+}
+func (m *RenameAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameAccountRequest.Size(m)
+}
+func (m *RenameAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameAccountRequest proto.InternalMessageInfo
+
+func (m *RenameAccountRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *RenameAccountRequest) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *RenameAccountRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+func (m *RenameAccountRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type RenameAccountResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameAccountResponse) Reset()         { *m = RenameAccountResponse{} }
+func (m *RenameAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*RenameAccountResponse) ProtoMessage()    {}
+func (*RenameAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{33}
+}
+
+func (m *RenameAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameAccountResponse.Unmarshal(m, b)
+}
+func (m *RenameAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *RenameAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameAccountResponse.Merge(m, src)
+}
+func (m *RenameAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_RenameAccountResponse.Size(m)
+}
+func (m *RenameAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameAccountResponse proto.InternalMessageInfo
+
+type NextAddressRequest struct {
+	Account     uint32      `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	AddressType AddressType `protobuf:"varint,2,opt,name=address_type,json=addressType,proto3,enum=walletrpc.v2.AddressType" json:"address_type,omitempty"`
+	Change      bool        `protobuf:"varint,3,opt,name=change,proto3" json:"change,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextAddressRequest) Reset()         { *m = NextAddressRequest{} }
+func (m *NextAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*NextAddressRequest) ProtoMessage()    {}
+func (*NextAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{34}
+}
+
+func (m *NextAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextAddressRequest.Unmarshal(m, b)
+}
+func (m *NextAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *NextAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextAddressRequest.Merge(m, src)
+}
+func (m *NextAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_NextAddressRequest.Size(m)
+}
+func (m *NextAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextAddressRequest proto.InternalMessageInfo
+
+func (m *NextAddressRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *NextAddressRequest) GetAddressType() AddressType {
+	if m != nil {
+		return m.AddressType
+	}
+	return AddressType_ADDRESS_TYPE_UNSPECIFIED
+}
+
+func (m *NextAddressRequest) GetChange() bool {
+	if m != nil {
+		return m.Change
+	}
+	return false
+}
+
+func (m *NextAddressRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type NextAddressResponse struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextAddressResponse) Reset()         { *m = NextAddressResponse{} }
+func (m *NextAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*NextAddressResponse) ProtoMessage()    {}
+func (*NextAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{35}
+}
+
+func (m *NextAddressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextAddressResponse.Unmarshal(m, b)
+}
+func (m *NextAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextAddressResponse.Marshal(b, m, deterministic)
+}
+func (m *NextAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextAddressResponse.Merge(m, src)
+}
+func (m *NextAddressResponse) XXX_Size() int {
+	return xxx_messageInfo_NextAddressResponse.Size(m)
+}
+func (m *NextAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextAddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextAddressResponse proto.InternalMessageInfo
+
+func (m *NextAddressResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type LeaseOutputRequest struct {
+	// The 32 byte identifier of the lease.
+	Id              []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint        *OutPoint `protobuf:"bytes,2,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	DurationSeconds uint64    `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseOutputRequest) Reset()         { *m = LeaseOutputRequest{} }
+func (m *LeaseOutputRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaseOutputRequest) ProtoMessage()    {}
+func (*LeaseOutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{36}
+}
+
+func (m *LeaseOutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseOutputRequest.Unmarshal(m, b)
+}
+func (m *LeaseOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseOutputRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaseOutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseOutputRequest.Merge(m, src)
+}
+func (m *LeaseOutputRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaseOutputRequest.Size(m)
+}
+func (m *LeaseOutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseOutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseOutputRequest proto.InternalMessageInfo
+
+func (m *LeaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *LeaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *LeaseOutputRequest) GetDurationSeconds() uint64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+func (m *LeaseOutputRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type LeaseOutputResponse struct {
+	Expiration           int64    `protobuf:"varint,1,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseOutputResponse) Reset()         { *m = LeaseOutputResponse{} }
+func (m *LeaseOutputResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaseOutputResponse) ProtoMessage()    {}
+func (*LeaseOutputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{37}
+}
+
+func (m *LeaseOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseOutputResponse.Unmarshal(m, b)
+}
+func (m *LeaseOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaseOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseOutputResponse.Merge(m, src)
+}
+func (m *LeaseOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaseOutputResponse.Size(m)
+}
+func (m *LeaseOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseOutputResponse proto.InternalMessageInfo
+
+func (m *LeaseOutputResponse) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+type ReleaseOutputRequest struct {
+	Id       []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint *OutPoint `protobuf:"bytes,2,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseOutputRequest) Reset()         { *m = ReleaseOutputRequest{} }
+func (m *ReleaseOutputRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseOutputRequest) ProtoMessage()    {}
+func (*ReleaseOutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{38}
+}
+
+func (m *ReleaseOutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseOutputRequest.Unmarshal(m, b)
+}
+func (m *ReleaseOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseOutputRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseOutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseOutputRequest.Merge(m, src)
+}
+func (m *ReleaseOutputRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseOutputRequest.Size(m)
+}
+func (m *ReleaseOutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseOutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseOutputRequest proto.InternalMessageInfo
+
+func (m *ReleaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReleaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *ReleaseOutputRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ReleaseOutputResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseOutputResponse) Reset()         { *m = ReleaseOutputResponse{} }
+func (m *ReleaseOutputResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseOutputResponse) ProtoMessage()    {}
+func (*ReleaseOutputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{39}
+}
+
+func (m *ReleaseOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseOutputResponse.Unmarshal(m, b)
+}
+func (m *ReleaseOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseOutputResponse.Merge(m, src)
+}
+func (m *ReleaseOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseOutputResponse.Size(m)
+}
+func (m *ReleaseOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseOutputResponse proto.InternalMessageInfo
+
+type CreateTransactionRequest struct {
+	// The passphrase is not required if dry_run is set.
+	Passphrase []byte                             `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Outputs    []*CreateTransactionRequest_Output `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// Inputs are selected from the account of this key scope if set,
+	// otherwise from the account of all key scopes.
+	Scope                 *KeyScope             `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Account               uint32                `protobuf:"varint,4,opt,name=account,proto3" json:"account,omitempty"`
+	MinConfirmations      int32                 `protobuf:"varint,5,opt,name=min_confirmations,json=minConfirmations,proto3" json:"min_confirmations,omitempty"`
+	FeeSatPerKb           int64                 `protobuf:"varint,6,opt,name=fee_sat_per_kb,json=feeSatPerKb,proto3" json:"fee_sat_per_kb,omitempty"`
+	CoinSelectionStrategy CoinSelectionStrategy `protobuf:"varint,7,opt,name=coin_selection_strategy,json=coinSelectionStrategy,proto3,enum=walletrpc.v2.CoinSelectionStrategy" json:"coin_selection_strategy,omitempty"`
+	// Create the transaction without signing it or reserving a change
+	// address.
+	DryRun bool `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTransactionRequest) Reset()         { *m = CreateTransactionRequest{} }
+func (m *CreateTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateTransactionRequest) ProtoMessage()    {}
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{40}
+}
+
+func (m *CreateTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransactionRequest.Unmarshal(m, b)
+}
+func (m *CreateTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransactionRequest.Merge(m, src)
+}
+func (m *CreateTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateTransactionRequest.Size(m)
+}
+func (m *CreateTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransactionRequest proto.InternalMessageInfo
+
+func (m *CreateTransactionRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *CreateTransactionRequest) GetOutputs() []*CreateTransactionRequest_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *CreateTransactionRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *CreateTransactionRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *CreateTransactionRequest) GetMinConfirmations() int32 {
+	if m != nil {
+		return m.MinConfirmations
+	}
+	return 0
+}
+
+func (m *CreateTransactionRequest) GetFeeSatPerKb() int64 {
+	if m != nil {
+		return m.FeeSatPerKb
+	}
+	return 0
+}
+
+func (m *CreateTransactionRequest) GetCoinSelectionStrategy() CoinSelectionStrategy {
+	if m != nil {
+		return m.CoinSelectionStrategy
+	}
+	return CoinSelectionStrategy_LARGEST
+}
+
+func (m *CreateTransactionRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *CreateTransactionRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type CreateTransactionRequest_Output struct {
+	Amount               int64    `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	PkScript             []byte   `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTransactionRequest_Output) Reset()         { *m = CreateTransactionRequest_Output{} }
+func (m *CreateTransactionRequest_Output) String() string { return proto.CompactTextString(m) }
+func (*CreateTransactionRequest_Output) ProtoMessage()    {}
+func (*CreateTransactionRequest_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{40, 0}
+}
+
+func (m *CreateTransactionRequest_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransactionRequest_Output.Unmarshal(m, b)
+}
+func (m *CreateTransactionRequest_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransactionRequest_Output.Marshal(b, m, deterministic)
+}
+func (m *CreateTransactionRequest_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransactionRequest_Output.Merge(m, src)
+}
+func (m *CreateTransactionRequest_Output) XXX_Size() int {
+	return xxx_messageInfo_CreateTransactionRequest_Output.Size(m)
+}
+func (m *CreateTransactionRequest_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransactionRequest_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransactionRequest_Output proto.InternalMessageInfo
+
+func (m *CreateTransactionRequest_Output) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *CreateTransactionRequest_Output) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+type CreateTransactionResponse struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	ChangeOutputIndex    int32    `protobuf:"varint,2,opt,name=change_output_index,json=changeOutputIndex,proto3" json:"change_output_index,omitempty"`
+	TotalInput           int64    `protobuf:"varint,3,opt,name=total_input,json=totalInput,proto3" json:"total_input,omitempty"`
+	Fee                  int64    `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	PreviousPkScripts    [][]byte `protobuf:"bytes,5,rep,name=previous_pk_scripts,json=previousPkScripts,proto3" json:"previous_pk_scripts,omitempty"`
+	PreviousAmounts      []int64  `protobuf:"varint,6,rep,packed,name=previous_amounts,json=previousAmounts,proto3" json:"previous_amounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTransactionResponse) Reset()         { *m = CreateTransactionResponse{} }
+func (m *CreateTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateTransactionResponse) ProtoMessage()    {}
+func (*CreateTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{41}
+}
+
+func (m *CreateTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransactionResponse.Unmarshal(m, b)
+}
+func (m *CreateTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransactionResponse.Merge(m, src)
+}
+func (m *CreateTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateTransactionResponse.Size(m)
+}
+func (m *CreateTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransactionResponse proto.InternalMessageInfo
+
+func (m *CreateTransactionResponse) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *CreateTransactionResponse) GetChangeOutputIndex() int32 {
+	if m != nil {
+		return m.ChangeOutputIndex
+	}
+	return 0
+}
+
+func (m *CreateTransactionResponse) GetTotalInput() int64 {
+	if m != nil {
+		return m.TotalInput
+	}
+	return 0
+}
+
+func (m *CreateTransactionResponse) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *CreateTransactionResponse) GetPreviousPkScripts() [][]byte {
+	if m != nil {
+		return m.PreviousPkScripts
+	}
+	return nil
+}
+
+func (m *CreateTransactionResponse) GetPreviousAmounts() []int64 {
+	if m != nil {
+		return m.PreviousAmounts
+	}
+	return nil
+}
+
+type SendOutputsRequest struct {
+	Passphrase []byte                             `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Outputs    []*CreateTransactionRequest_Output `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// Inputs are selected from the account of this key scope if set,
+	// otherwise from the account of all key scopes.
+	Scope                 *KeyScope             `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Account               uint32                `protobuf:"varint,4,opt,name=account,proto3" json:"account,omitempty"`
+	MinConfirmations      int32                 `protobuf:"varint,5,opt,name=min_confirmations,json=minConfirmations,proto3" json:"min_confirmations,omitempty"`
+	FeeSatPerKb           int64                 `protobuf:"varint,6,opt,name=fee_sat_per_kb,json=feeSatPerKb,proto3" json:"fee_sat_per_kb,omitempty"`
+	CoinSelectionStrategy CoinSelectionStrategy `protobuf:"varint,7,opt,name=coin_selection_strategy,json=coinSelectionStrategy,proto3,enum=walletrpc.v2.CoinSelectionStrategy" json:"coin_selection_strategy,omitempty"`
+	Label                 string                `protobuf:"bytes,8,opt,name=label,proto3" json:"label,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendOutputsRequest) Reset()         { *m = SendOutputsRequest{} }
+func (m *SendOutputsRequest) String() string { return proto.CompactTextString(m) }
+func (*SendOutputsRequest) ProtoMessage()    {}
+func (*SendOutputsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{42}
+}
+
+func (m *SendOutputsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendOutputsRequest.Unmarshal(m, b)
+}
+func (m *SendOutputsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendOutputsRequest.Marshal(b, m, deterministic)
+}
+func (m *SendOutputsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendOutputsRequest.Merge(m, src)
+}
+func (m *SendOutputsRequest) XXX_Size() int {
+	return xxx_messageInfo_SendOutputsRequest.Size(m)
+}
+func (m *SendOutputsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendOutputsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendOutputsRequest proto.InternalMessageInfo
+
+func (m *SendOutputsRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SendOutputsRequest) GetOutputs() []*CreateTransactionRequest_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *SendOutputsRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *SendOutputsRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *SendOutputsRequest) GetMinConfirmations() int32 {
+	if m != nil {
+		return m.MinConfirmations
+	}
+	return 0
+}
+
+func (m *SendOutputsRequest) GetFeeSatPerKb() int64 {
+	if m != nil {
+		return m.FeeSatPerKb
+	}
+	return 0
+}
+
+func (m *SendOutputsRequest) GetCoinSelectionStrategy() CoinSelectionStrategy {
+	if m != nil {
+		return m.CoinSelectionStrategy
+	}
+	return CoinSelectionStrategy_LARGEST
+}
+
+func (m *SendOutputsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SendOutputsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type SendOutputsResponse struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	TransactionHash      []byte   `protobuf:"bytes,2,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendOutputsResponse) Reset()         { *m = SendOutputsResponse{} }
+func (m *SendOutputsResponse) String() string { return proto.CompactTextString(m) }
+func (*SendOutputsResponse) ProtoMessage()    {}
+func (*SendOutputsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{43}
+}
+
+func (m *SendOutputsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendOutputsResponse.Unmarshal(m, b)
+}
+func (m *SendOutputsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendOutputsResponse.Marshal(b, m, deterministic)
+}
+func (m *SendOutputsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendOutputsResponse.Merge(m, src)
+}
+func (m *SendOutputsResponse) XXX_Size() int {
+	return xxx_messageInfo_SendOutputsResponse.Size(m)
+}
+func (m *SendOutputsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendOutputsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendOutputsResponse proto.InternalMessageInfo
+
+func (m *SendOutputsResponse) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *SendOutputsResponse) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+type SignTransactionRequest struct {
+	Passphrase            []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	SerializedTransaction []byte `protobuf:"bytes,2,opt,name=serialized_transaction,json=serializedTransaction,proto3" json:"serialized_transaction,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignTransactionRequest) Reset()         { *m = SignTransactionRequest{} }
+func (m *SignTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SignTransactionRequest) ProtoMessage()    {}
+func (*SignTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{44}
+}
+
+func (m *SignTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignTransactionRequest.Unmarshal(m, b)
+}
+func (m *SignTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SignTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignTransactionRequest.Merge(m, src)
+}
+func (m *SignTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SignTransactionRequest.Size(m)
+}
+func (m *SignTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignTransactionRequest proto.InternalMessageInfo
+
+func (m *SignTransactionRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SignTransactionRequest) GetSerializedTransaction() []byte {
+	if m != nil {
+		return m.SerializedTransaction
+	}
+	return nil
+}
+
+func (m *SignTransactionRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type SignTransactionResponse struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	UnsignedInputIndexes []uint32 `protobuf:"varint,2,rep,packed,name=unsigned_input_indexes,json=unsignedInputIndexes,proto3" json:"unsigned_input_indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignTransactionResponse) Reset()         { *m = SignTransactionResponse{} }
+func (m *SignTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SignTransactionResponse) ProtoMessage()    {}
+func (*SignTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{45}
+}
+
+func (m *SignTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignTransactionResponse.Unmarshal(m, b)
+}
+func (m *SignTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SignTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignTransactionResponse.Merge(m, src)
+}
+func (m *SignTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SignTransactionResponse.Size(m)
+}
+func (m *SignTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignTransactionResponse proto.InternalMessageInfo
+
+func (m *SignTransactionResponse) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *SignTransactionResponse) GetUnsignedInputIndexes() []uint32 {
+	if m != nil {
+		return m.UnsignedInputIndexes
+	}
+	return nil
+}
+
+type FundPsbtRequest struct {
+	Psbt                  []byte                `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Scope                 *KeyScope             `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	Account               uint32                `protobuf:"varint,3,opt,name=account,proto3" json:"account,omitempty"`
+	MinConfirmations      int32                 `protobuf:"varint,4,opt,name=min_confirmations,json=minConfirmations,proto3" json:"min_confirmations,omitempty"`
+	FeeSatPerKb           int64                 `protobuf:"varint,5,opt,name=fee_sat_per_kb,json=feeSatPerKb,proto3" json:"fee_sat_per_kb,omitempty"`
+	CoinSelectionStrategy CoinSelectionStrategy `protobuf:"varint,6,opt,name=coin_selection_strategy,json=coinSelectionStrategy,proto3,enum=walletrpc.v2.CoinSelectionStrategy" json:"coin_selection_strategy,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FundPsbtRequest) Reset()         { *m = FundPsbtRequest{} }
+func (m *FundPsbtRequest) String() string { return proto.CompactTextString(m) }
+func (*FundPsbtRequest) ProtoMessage()    {}
+func (*FundPsbtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{46}
+}
+
+func (m *FundPsbtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundPsbtRequest.Unmarshal(m, b)
+}
+func (m *FundPsbtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundPsbtRequest.Marshal(b, m, deterministic)
+}
+func (m *FundPsbtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundPsbtRequest.Merge(m, src)
+}
+func (m *FundPsbtRequest) XXX_Size() int {
+	return xxx_messageInfo_FundPsbtRequest.Size(m)
+}
+func (m *FundPsbtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundPsbtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FundPsbtRequest proto.InternalMessageInfo
+
+func (m *FundPsbtRequest) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FundPsbtRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *FundPsbtRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *FundPsbtRequest) GetMinConfirmations() int32 {
+	if m != nil {
+		return m.MinConfirmations
+	}
+	return 0
+}
+
+func (m *FundPsbtRequest) GetFeeSatPerKb() int64 {
+	if m != nil {
+		return m.FeeSatPerKb
+	}
+	return 0
+}
+
+func (m *FundPsbtRequest) GetCoinSelectionStrategy() CoinSelectionStrategy {
+	if m != nil {
+		return m.CoinSelectionStrategy
+	}
+	return CoinSelectionStrategy_LARGEST
+}
+
+func (m *FundPsbtRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type FundPsbtResponse struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	ChangeOutputIndex    int32    `protobuf:"varint,2,opt,name=change_output_index,json=changeOutputIndex,proto3" json:"change_output_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FundPsbtResponse) Reset()         { *m = FundPsbtResponse{} }
+func (m *FundPsbtResponse) String() string { return proto.CompactTextString(m) }
+func (*FundPsbtResponse) ProtoMessage()    {}
+func (*FundPsbtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{47}
+}
+
+func (m *FundPsbtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundPsbtResponse.Unmarshal(m, b)
+}
+func (m *FundPsbtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundPsbtResponse.Marshal(b, m, deterministic)
+}
+func (m *FundPsbtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundPsbtResponse.Merge(m, src)
+}
+func (m *FundPsbtResponse) XXX_Size() int {
+	return xxx_messageInfo_FundPsbtResponse.Size(m)
+}
+func (m *FundPsbtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundPsbtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FundPsbtResponse proto.InternalMessageInfo
+
+func (m *FundPsbtResponse) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FundPsbtResponse) GetChangeOutputIndex() int32 {
+	if m != nil {
+		return m.ChangeOutputIndex
+	}
+	return 0
+}
+
+type FinalizePsbtRequest struct {
+	Passphrase []byte    `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Psbt       []byte    `protobuf:"bytes,2,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Scope      *KeyScope `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	Account    uint32    `protobuf:"varint,4,opt,name=account,proto3" json:"account,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FinalizePsbtRequest) Reset()         { *m = FinalizePsbtRequest{} }
+func (m *FinalizePsbtRequest) String() string { return proto.CompactTextString(m) }
+func (*FinalizePsbtRequest) ProtoMessage()    {}
+func (*FinalizePsbtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{48}
+}
+
+func (m *FinalizePsbtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinalizePsbtRequest.Unmarshal(m, b)
+}
+func (m *FinalizePsbtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinalizePsbtRequest.Marshal(b, m, deterministic)
+}
+func (m *FinalizePsbtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinalizePsbtRequest.Merge(m, src)
+}
+func (m *FinalizePsbtRequest) XXX_Size() int {
+	return xxx_messageInfo_FinalizePsbtRequest.Size(m)
+}
+func (m *FinalizePsbtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinalizePsbtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinalizePsbtRequest proto.InternalMessageInfo
+
+func (m *FinalizePsbtRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *FinalizePsbtRequest) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FinalizePsbtRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *FinalizePsbtRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *FinalizePsbtRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type FinalizePsbtResponse struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Transaction          []byte   `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FinalizePsbtResponse) Reset()         { *m = FinalizePsbtResponse{} }
+func (m *FinalizePsbtResponse) String() string { return proto.CompactTextString(m) }
+func (*FinalizePsbtResponse) ProtoMessage()    {}
+func (*FinalizePsbtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{49}
+}
+
+func (m *FinalizePsbtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinalizePsbtResponse.Unmarshal(m, b)
+}
+func (m *FinalizePsbtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinalizePsbtResponse.Marshal(b, m, deterministic)
+}
+func (m *FinalizePsbtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinalizePsbtResponse.Merge(m, src)
+}
+func (m *FinalizePsbtResponse) XXX_Size() int {
+	return xxx_messageInfo_FinalizePsbtResponse.Size(m)
+}
+func (m *FinalizePsbtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinalizePsbtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinalizePsbtResponse proto.InternalMessageInfo
+
+func (m *FinalizePsbtResponse) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FinalizePsbtResponse) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type PublishTransactionRequest struct {
+	SignedTransaction []byte `protobuf:"bytes,1,opt,name=signed_transaction,json=signedTransaction,proto3" json:"signed_transaction,omitempty"`
+	Label             string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishTransactionRequest) Reset()         { *m = PublishTransactionRequest{} }
+func (m *PublishTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishTransactionRequest) ProtoMessage()    {}
+func (*PublishTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{50}
+}
+
+func (m *PublishTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishTransactionRequest.Unmarshal(m, b)
+}
+func (m *PublishTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishTransactionRequest.Merge(m, src)
+}
+func (m *PublishTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishTransactionRequest.Size(m)
+}
+func (m *PublishTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishTransactionRequest proto.InternalMessageInfo
+
+func (m *PublishTransactionRequest) GetSignedTransaction() []byte {
+	if m != nil {
+		return m.SignedTransaction
+	}
+	return nil
+}
+
+func (m *PublishTransactionRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *PublishTransactionRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type PublishTransactionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishTransactionResponse) Reset()         { *m = PublishTransactionResponse{} }
+func (m *PublishTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishTransactionResponse) ProtoMessage()    {}
+func (*PublishTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{51}
+}
+
+func (m *PublishTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishTransactionResponse.Unmarshal(m, b)
+}
+func (m *PublishTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishTransactionResponse.Merge(m, src)
+}
+func (m *PublishTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishTransactionResponse.Size(m)
+}
+func (m *PublishTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishTransactionResponse proto.InternalMessageInfo
+
+type LabelTransactionRequest struct {
+	TransactionHash []byte `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Label           string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Overwrite       bool   `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelTransactionRequest) Reset()         { *m = LabelTransactionRequest{} }
+func (m *LabelTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*LabelTransactionRequest) ProtoMessage()    {}
+func (*LabelTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{52}
+}
+
+func (m *LabelTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelTransactionRequest.Unmarshal(m, b)
+}
+func (m *LabelTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *LabelTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelTransactionRequest.Merge(m, src)
+}
+func (m *LabelTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_LabelTransactionRequest.Size(m)
+}
+func (m *LabelTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelTransactionRequest proto.InternalMessageInfo
+
+func (m *LabelTransactionRequest) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *LabelTransactionRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *LabelTransactionRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+func (m *LabelTransactionRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type LabelTransactionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelTransactionResponse) Reset()         { *m = LabelTransactionResponse{} }
+func (m *LabelTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*LabelTransactionResponse) ProtoMessage()    {}
+func (*LabelTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{53}
+}
+
+func (m *LabelTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelTransactionResponse.Unmarshal(m, b)
+}
+func (m *LabelTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *LabelTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelTransactionResponse.Merge(m, src)
+}
+func (m *LabelTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_LabelTransactionResponse.Size(m)
+}
+func (m *LabelTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelTransactionResponse proto.InternalMessageInfo
+
+type SignMessageRequest struct {
+	Passphrase []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Address    string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Message    string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignMessageRequest) Reset()         { *m = SignMessageRequest{} }
+func (m *SignMessageRequest) String() string { return proto.CompactTextString(m) }
+func (*SignMessageRequest) ProtoMessage()    {}
+func (*SignMessageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{54}
+}
+
+func (m *SignMessageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignMessageRequest.Unmarshal(m, b)
+}
+func (m *SignMessageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignMessageRequest.Marshal(b, m, deterministic)
+}
+func (m *SignMessageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignMessageRequest.Merge(m, src)
+}
+func (m *SignMessageRequest) XXX_Size() int {
+	return xxx_messageInfo_SignMessageRequest.Size(m)
+}
+func (m *SignMessageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignMessageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignMessageRequest proto.InternalMessageInfo
+
+func (m *SignMessageRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SignMessageRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SignMessageRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *SignMessageRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type SignMessageResponse struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignMessageResponse) Reset()         { *m = SignMessageResponse{} }
+func (m *SignMessageResponse) String() string { return proto.CompactTextString(m) }
+func (*SignMessageResponse) ProtoMessage()    {}
+func (*SignMessageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{55}
+}
+
+func (m *SignMessageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignMessageResponse.Unmarshal(m, b)
+}
+func (m *SignMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignMessageResponse.Marshal(b, m, deterministic)
+}
+func (m *SignMessageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignMessageResponse.Merge(m, src)
+}
+func (m *SignMessageResponse) XXX_Size() int {
+	return xxx_messageInfo_SignMessageResponse.Size(m)
+}
+func (m *SignMessageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignMessageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignMessageResponse proto.InternalMessageInfo
+
+func (m *SignMessageResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type ImportAccountRequest struct {
+	Name                 string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ExtendedPublicKey    string `protobuf:"bytes,2,opt,name=extended_public_key,json=extendedPublicKey,proto3" json:"extended_public_key,omitempty"`
+	MasterKeyFingerprint uint32 `protobuf:"varint,3,opt,name=master_key_fingerprint,json=masterKeyFingerprint,proto3" json:"master_key_fingerprint,omitempty"`
+	// The address type of the account.  If unspecified, it is derived from
+	// the version of the extended public key.
+	AddressType AddressType `protobuf:"varint,4,opt,name=address_type,json=addressType,proto3,enum=walletrpc.v2.AddressType" json:"address_type,omitempty"`
+	// Only return the account and its first addresses, without importing
+	// it.
+	DryRun             bool   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	DryRunAddressCount uint32 `protobuf:"varint,6,opt,name=dry_run_address_count,json=dryRunAddressCount,proto3" json:"dry_run_address_count,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportAccountRequest) Reset()         { *m = ImportAccountRequest{} }
+func (m *ImportAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountRequest) ProtoMessage()    {}
+func (*ImportAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{56}
+}
+
+func (m *ImportAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountRequest.Unmarshal(m, b)
+}
+func (m *ImportAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountRequest.Merge(m, src)
+}
+func (m *ImportAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountRequest.Size(m)
+}
+func (m *ImportAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountRequest proto.InternalMessageInfo
+
+func (m *ImportAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ImportAccountRequest) GetExtendedPublicKey() string {
+	if m != nil {
+		return m.ExtendedPublicKey
+	}
+	return ""
+}
+
+func (m *ImportAccountRequest) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *ImportAccountRequest) GetAddressType() AddressType {
+	if m != nil {
+		return m.AddressType
+	}
+	return AddressType_ADDRESS_TYPE_UNSPECIFIED
+}
+
+func (m *ImportAccountRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ImportAccountRequest) GetDryRunAddressCount() uint32 {
+	if m != nil {
+		return m.DryRunAddressCount
+	}
+	return 0
+}
+
+func (m *ImportAccountRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ImportAccountResponse struct {
+	Account                 *AccountsResponse_Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	DryRunExternalAddresses []string                  `protobuf:"bytes,2,rep,name=dry_run_external_addresses,json=dryRunExternalAddresses,proto3" json:"dry_run_external_addresses,omitempty"`
+	DryRunInternalAddresses []string                  `protobuf:"bytes,3,rep,name=dry_run_internal_addresses,json=dryRunInternalAddresses,proto3" json:"dry_run_internal_addresses,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                  `json:"-"`
+	XXX_unrecognized        []byte                    `json:"-"`
+	XXX_sizecache           int32                     `json:"-"`
+}
+
+func (m *ImportAccountResponse) Reset()         { *m = ImportAccountResponse{} }
+func (m *ImportAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountResponse) ProtoMessage()    {}
+func (*ImportAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{57}
+}
+
+func (m *ImportAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountResponse.Unmarshal(m, b)
+}
+func (m *ImportAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountResponse.Merge(m, src)
+}
+func (m *ImportAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountResponse.Size(m)
+}
+func (m *ImportAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountResponse proto.InternalMessageInfo
+
+func (m *ImportAccountResponse) GetAccount() *AccountsResponse_Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetDryRunExternalAddresses() []string {
+	if m != nil {
+		return m.DryRunExternalAddresses
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetDryRunInternalAddresses() []string {
+	if m != nil {
+		return m.DryRunInternalAddresses
+	}
+	return nil
+}
+
+type ImportPrivateKeyRequest struct {
+	Passphrase    []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	PrivateKeyWif string `protobuf:"bytes,2,opt,name=private_key_wif,json=privateKeyWif,proto3" json:"private_key_wif,omitempty"`
+	// The address type of the key, which selects the key scope it is
+	// imported into.
+	AddressType AddressType `protobuf:"varint,3,opt,name=address_type,json=addressType,proto3,enum=walletrpc.v2.AddressType" json:"address_type,omitempty"`
+	Rescan      bool        `protobuf:"varint,4,opt,name=rescan,proto3" json:"rescan,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportPrivateKeyRequest) Reset()         { *m = ImportPrivateKeyRequest{} }
+func (m *ImportPrivateKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportPrivateKeyRequest) ProtoMessage()    {}
+func (*ImportPrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{58}
+}
+
+func (m *ImportPrivateKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPrivateKeyRequest.Unmarshal(m, b)
+}
+func (m *ImportPrivateKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPrivateKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportPrivateKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPrivateKeyRequest.Merge(m, src)
+}
+func (m *ImportPrivateKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportPrivateKeyRequest.Size(m)
+}
+func (m *ImportPrivateKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPrivateKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPrivateKeyRequest proto.InternalMessageInfo
+
+func (m *ImportPrivateKeyRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *ImportPrivateKeyRequest) GetPrivateKeyWif() string {
+	if m != nil {
+		return m.PrivateKeyWif
+	}
+	return ""
+}
+
+func (m *ImportPrivateKeyRequest) GetAddressType() AddressType {
+	if m != nil {
+		return m.AddressType
+	}
+	return AddressType_ADDRESS_TYPE_UNSPECIFIED
+}
+
+func (m *ImportPrivateKeyRequest) GetRescan() bool {
+	if m != nil {
+		return m.Rescan
+	}
+	return false
+}
+
+func (m *ImportPrivateKeyRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ImportPrivateKeyResponse struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportPrivateKeyResponse) Reset()         { *m = ImportPrivateKeyResponse{} }
+func (m *ImportPrivateKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportPrivateKeyResponse) ProtoMessage()    {}
+func (*ImportPrivateKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{59}
+}
+
+func (m *ImportPrivateKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPrivateKeyResponse.Unmarshal(m, b)
+}
+func (m *ImportPrivateKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPrivateKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportPrivateKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPrivateKeyResponse.Merge(m, src)
+}
+func (m *ImportPrivateKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportPrivateKeyResponse.Size(m)
+}
+func (m *ImportPrivateKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPrivateKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPrivateKeyResponse proto.InternalMessageInfo
+
+func (m *ImportPrivateKeyResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type ImportPublicKeyRequest struct {
+	PublicKey   []byte      `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	AddressType AddressType `protobuf:"varint,2,opt,name=address_type,json=addressType,proto3,enum=walletrpc.v2.AddressType" json:"address_type,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportPublicKeyRequest) Reset()         { *m = ImportPublicKeyRequest{} }
+func (m *ImportPublicKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportPublicKeyRequest) ProtoMessage()    {}
+func (*ImportPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{60}
+}
+
+func (m *ImportPublicKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPublicKeyRequest.Unmarshal(m, b)
+}
+func (m *ImportPublicKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPublicKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportPublicKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPublicKeyRequest.Merge(m, src)
+}
+func (m *ImportPublicKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportPublicKeyRequest.Size(m)
+}
+func (m *ImportPublicKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPublicKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPublicKeyRequest proto.InternalMessageInfo
+
+func (m *ImportPublicKeyRequest) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *ImportPublicKeyRequest) GetAddressType() AddressType {
+	if m != nil {
+		return m.AddressType
+	}
+	return AddressType_ADDRESS_TYPE_UNSPECIFIED
+}
+
+func (m *ImportPublicKeyRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ImportPublicKeyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportPublicKeyResponse) Reset()         { *m = ImportPublicKeyResponse{} }
+func (m *ImportPublicKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportPublicKeyResponse) ProtoMessage()    {}
+func (*ImportPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{61}
+}
+
+func (m *ImportPublicKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPublicKeyResponse.Unmarshal(m, b)
+}
+func (m *ImportPublicKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPublicKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportPublicKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPublicKeyResponse.Merge(m, src)
+}
+func (m *ImportPublicKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportPublicKeyResponse.Size(m)
+}
+func (m *ImportPublicKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPublicKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPublicKeyResponse proto.InternalMessageInfo
+
+type ImportTaprootScriptRequest struct {
+	// The key scope to import the script into.  Defaults to BIP0086.
+	Scope       *KeyScope                             `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	InternalKey []byte                                `protobuf:"bytes,2,opt,name=internal_key,json=internalKey,proto3" json:"internal_key,omitempty"`
+	Leaves      []*ImportTaprootScriptRequest_TapLeaf `protobuf:"bytes,3,rep,name=leaves,proto3" json:"leaves,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportTaprootScriptRequest) Reset()         { *m = ImportTaprootScriptRequest{} }
+func (m *ImportTaprootScriptRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportTaprootScriptRequest) ProtoMessage()    {}
+func (*ImportTaprootScriptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{62}
+}
+
+func (m *ImportTaprootScriptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTaprootScriptRequest.Unmarshal(m, b)
+}
+func (m *ImportTaprootScriptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTaprootScriptRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportTaprootScriptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTaprootScriptRequest.Merge(m, src)
+}
+func (m *ImportTaprootScriptRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportTaprootScriptRequest.Size(m)
+}
+func (m *ImportTaprootScriptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTaprootScriptRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTaprootScriptRequest proto.InternalMessageInfo
+
+func (m *ImportTaprootScriptRequest) GetScope() *KeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return nil
+}
+
+func (m *ImportTaprootScriptRequest) GetInternalKey() []byte {
+	if m != nil {
+		return m.InternalKey
+	}
+	return nil
+}
+
+func (m *ImportTaprootScriptRequest) GetLeaves() []*ImportTaprootScriptRequest_TapLeaf {
+	if m != nil {
+		return m.Leaves
+	}
+	return nil
+}
+
+func (m *ImportTaprootScriptRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type ImportTaprootScriptRequest_TapLeaf struct {
+	LeafVersion          uint32   `protobuf:"varint,1,opt,name=leaf_version,json=leafVersion,proto3" json:"leaf_version,omitempty"`
+	Script               []byte   `protobuf:"bytes,2,opt,name=script,proto3" json:"script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportTaprootScriptRequest_TapLeaf) Reset()         { *m = ImportTaprootScriptRequest_TapLeaf{} }
+func (m *ImportTaprootScriptRequest_TapLeaf) String() string { return proto.CompactTextString(m) }
+func (*ImportTaprootScriptRequest_TapLeaf) ProtoMessage()    {}
+func (*ImportTaprootScriptRequest_TapLeaf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{62, 0}
+}
+
+func (m *ImportTaprootScriptRequest_TapLeaf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTaprootScriptRequest_TapLeaf.Unmarshal(m, b)
+}
+func (m *ImportTaprootScriptRequest_TapLeaf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTaprootScriptRequest_TapLeaf.Marshal(b, m, deterministic)
+}
+func (m *ImportTaprootScriptRequest_TapLeaf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTaprootScriptRequest_TapLeaf.Merge(m, src)
+}
+func (m *ImportTaprootScriptRequest_TapLeaf) XXX_Size() int {
+	return xxx_messageInfo_ImportTaprootScriptRequest_TapLeaf.Size(m)
+}
+func (m *ImportTaprootScriptRequest_TapLeaf) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTaprootScriptRequest_TapLeaf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTaprootScriptRequest_TapLeaf proto.InternalMessageInfo
+
+func (m *ImportTaprootScriptRequest_TapLeaf) GetLeafVersion() uint32 {
+	if m != nil {
+		return m.LeafVersion
+	}
+	return 0
+}
+
+func (m *ImportTaprootScriptRequest_TapLeaf) GetScript() []byte {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+type ImportTaprootScriptResponse struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportTaprootScriptResponse) Reset()         { *m = ImportTaprootScriptResponse{} }
+func (m *ImportTaprootScriptResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportTaprootScriptResponse) ProtoMessage()    {}
+func (*ImportTaprootScriptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{63}
+}
+
+func (m *ImportTaprootScriptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportTaprootScriptResponse.Unmarshal(m, b)
+}
+func (m *ImportTaprootScriptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportTaprootScriptResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportTaprootScriptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportTaprootScriptResponse.Merge(m, src)
+}
+func (m *ImportTaprootScriptResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportTaprootScriptResponse.Size(m)
+}
+func (m *ImportTaprootScriptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportTaprootScriptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportTaprootScriptResponse proto.InternalMessageInfo
+
+func (m *ImportTaprootScriptResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type RescanRequest struct {
+	StartHeight int32 `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// The addresses to rescan for.  If empty, all active addresses of the
+	// wallet are rescanned for.
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// The name of the wallet the request is made to, or empty for the
+	// default wallet.
+	Wallet               string   `protobuf:"bytes,15,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanRequest) Reset()         { *m = RescanRequest{} }
+func (m *RescanRequest) String() string { return proto.CompactTextString(m) }
+func (*RescanRequest) ProtoMessage()    {}
+func (*RescanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{64}
+}
+
+func (m *RescanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanRequest.Unmarshal(m, b)
+}
+func (m *RescanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanRequest.Marshal(b, m, deterministic)
+}
+func (m *RescanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanRequest.Merge(m, src)
+}
+func (m *RescanRequest) XXX_Size() int {
+	return xxx_messageInfo_RescanRequest.Size(m)
+}
+func (m *RescanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanRequest proto.InternalMessageInfo
+
+func (m *RescanRequest) GetStartHeight() int32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *RescanRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *RescanRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type RescanResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanResponse) Reset()         { *m = RescanResponse{} }
+func (m *RescanResponse) String() string { return proto.CompactTextString(m) }
+func (*RescanResponse) ProtoMessage()    {}
+func (*RescanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9dd8ffb46251f1c6, []int{65}
+}
+
+func (m *RescanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanResponse.Unmarshal(m, b)
+}
+func (m *RescanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanResponse.Marshal(b, m, deterministic)
+}
+func (m *RescanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanResponse.Merge(m, src)
+}
+func (m *RescanResponse) XXX_Size() int {
+	return xxx_messageInfo_RescanResponse.Size(m)
+}
+func (m *RescanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("walletrpc.v2.AddressType", AddressType_name, AddressType_value)
+	proto.RegisterEnum("walletrpc.v2.CoinSelectionStrategy", CoinSelectionStrategy_name, CoinSelectionStrategy_value)
+	proto.RegisterEnum("walletrpc.v2.ChangePassphraseRequest_Key", ChangePassphraseRequest_Key_name, ChangePassphraseRequest_Key_value)
+	proto.RegisterType((*KeyScope)(nil), "walletrpc.v2.KeyScope")
+	proto.RegisterType((*OutPoint)(nil), "walletrpc.v2.OutPoint")
+	proto.RegisterType((*TransactionDetails)(nil), "walletrpc.v2.TransactionDetails")
+	proto.RegisterType((*TransactionDetails_Input)(nil), "walletrpc.v2.TransactionDetails.Input")
+	proto.RegisterType((*TransactionDetails_Output)(nil), "walletrpc.v2.TransactionDetails.Output")
+	proto.RegisterType((*BlockDetails)(nil), "walletrpc.v2.BlockDetails")
+	proto.RegisterType((*PingRequest)(nil), "walletrpc.v2.PingRequest")
+	proto.RegisterType((*PingResponse)(nil), "walletrpc.v2.PingResponse")
+	proto.RegisterType((*NetworkRequest)(nil), "walletrpc.v2.NetworkRequest")
+	proto.RegisterType((*NetworkResponse)(nil), "walletrpc.v2.NetworkResponse")
+	proto.RegisterType((*AccountsRequest)(nil), "walletrpc.v2.AccountsRequest")
+	proto.RegisterType((*AccountsResponse)(nil), "walletrpc.v2.AccountsResponse")
+	proto.RegisterType((*AccountsResponse_Account)(nil), "walletrpc.v2.AccountsResponse.Account")
+	proto.RegisterType((*BalanceRequest)(nil), "walletrpc.v2.BalanceRequest")
+	proto.RegisterType((*BalanceResponse)(nil), "walletrpc.v2.BalanceResponse")
+	proto.RegisterType((*ListUnspentRequest)(nil), "walletrpc.v2.ListUnspentRequest")
+	proto.RegisterType((*ListUnspentResponse)(nil), "walletrpc.v2.ListUnspentResponse")
+	proto.RegisterType((*ListUnspentResponse_Output)(nil), "walletrpc.v2.ListUnspentResponse.Output")
+	proto.RegisterType((*ListLeasedOutputsRequest)(nil), "walletrpc.v2.ListLeasedOutputsRequest")
+	proto.RegisterType((*ListLeasedOutputsResponse)(nil), "walletrpc.v2.ListLeasedOutputsResponse")
+	proto.RegisterType((*ListLeasedOutputsResponse_LeasedOutput)(nil), "walletrpc.v2.ListLeasedOutputsResponse.LeasedOutput")
+	proto.RegisterType((*GetTransactionRequest)(nil), "walletrpc.v2.GetTransactionRequest")
+	proto.RegisterType((*GetTransactionResponse)(nil), "walletrpc.v2.GetTransactionResponse")
+	proto.RegisterType((*RescanStatusRequest)(nil), "walletrpc.v2.RescanStatusRequest")
+	proto.RegisterType((*RescanStatusResponse)(nil), "walletrpc.v2.RescanStatusResponse")
+	proto.RegisterType((*TransactionNotificationsRequest)(nil), "walletrpc.v2.TransactionNotificationsRequest")
+	proto.RegisterType((*TransactionNotificationsResponse)(nil), "walletrpc.v2.TransactionNotificationsResponse")
+	proto.RegisterType((*SpentnessNotificationsRequest)(nil), "walletrpc.v2.SpentnessNotificationsRequest")
+	proto.RegisterType((*SpentnessNotificationsResponse)(nil), "walletrpc.v2.SpentnessNotificationsResponse")
+	proto.RegisterType((*SpentnessNotificationsResponse_Spender)(nil), "walletrpc.v2.SpentnessNotificationsResponse.Spender")
+	proto.RegisterType((*AccountNotificationsRequest)(nil), "walletrpc.v2.AccountNotificationsRequest")
+	proto.RegisterType((*AccountNotificationsResponse)(nil), "walletrpc.v2.AccountNotificationsResponse")
+	proto.RegisterType((*ConfirmationNotificationsRequest)(nil), "walletrpc.v2.ConfirmationNotificationsRequest")
+	proto.RegisterType((*ConfirmationNotificationsResponse)(nil), "walletrpc.v2.ConfirmationNotificationsResponse")
+	proto.RegisterType((*ChangePassphraseRequest)(nil), "walletrpc.v2.ChangePassphraseRequest")
+	proto.RegisterType((*ChangePassphraseResponse)(nil), "walletrpc.v2.ChangePassphraseResponse")
+	proto.RegisterType((*CreateAccountRequest)(nil), "walletrpc.v2.CreateAccountRequest")
+	proto.RegisterType((*CreateAccountResponse)(nil), "walletrpc.v2.CreateAccountResponse")
+	proto.RegisterType((*RenameAccountRequest)(nil), "walletrpc.v2.RenameAccountRequest")
+	proto.RegisterType((*RenameAccountResponse)(nil), "walletrpc.v2.RenameAccountResponse")
+	proto.RegisterType((*NextAddressRequest)(nil), "walletrpc.v2.NextAddressRequest")
+	proto.RegisterType((*NextAddressResponse)(nil), "walletrpc.v2.NextAddressResponse")
+	proto.RegisterType((*LeaseOutputRequest)(nil), "walletrpc.v2.LeaseOutputRequest")
+	proto.RegisterType((*LeaseOutputResponse)(nil), "walletrpc.v2.LeaseOutputResponse")
+	proto.RegisterType((*ReleaseOutputRequest)(nil), "walletrpc.v2.ReleaseOutputRequest")
+	proto.RegisterType((*ReleaseOutputResponse)(nil), "walletrpc.v2.ReleaseOutputResponse")
+	proto.RegisterType((*CreateTransactionRequest)(nil), "walletrpc.v2.CreateTransactionRequest")
+	proto.RegisterType((*CreateTransactionRequest_Output)(nil), "walletrpc.v2.CreateTransactionRequest.Output")
+	proto.RegisterType((*CreateTransactionResponse)(nil), "walletrpc.v2.CreateTransactionResponse")
+	proto.RegisterType((*SendOutputsRequest)(nil), "walletrpc.v2.SendOutputsRequest")
+	proto.RegisterType((*SendOutputsResponse)(nil), "walletrpc.v2.SendOutputsResponse")
+	proto.RegisterType((*SignTransactionRequest)(nil), "walletrpc.v2.SignTransactionRequest")
+	proto.RegisterType((*SignTransactionResponse)(nil), "walletrpc.v2.SignTransactionResponse")
+	proto.RegisterType((*FundPsbtRequest)(nil), "walletrpc.v2.FundPsbtRequest")
+	proto.RegisterType((*FundPsbtResponse)(nil), "walletrpc.v2.FundPsbtResponse")
+	proto.RegisterType((*FinalizePsbtRequest)(nil), "walletrpc.v2.FinalizePsbtRequest")
+	proto.RegisterType((*FinalizePsbtResponse)(nil), "walletrpc.v2.FinalizePsbtResponse")
+	proto.RegisterType((*PublishTransactionRequest)(nil), "walletrpc.v2.PublishTransactionRequest")
+	proto.RegisterType((*PublishTransactionResponse)(nil), "walletrpc.v2.PublishTransactionResponse")
+	proto.RegisterType((*LabelTransactionRequest)(nil), "walletrpc.v2.LabelTransactionRequest")
+	proto.RegisterType((*LabelTransactionResponse)(nil), "walletrpc.v2.LabelTransactionResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "walletrpc.v2.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "walletrpc.v2.SignMessageResponse")
+	proto.RegisterType((*ImportAccountRequest)(nil), "walletrpc.v2.ImportAccountRequest")
+	proto.RegisterType((*ImportAccountResponse)(nil), "walletrpc.v2.ImportAccountResponse")
+	proto.RegisterType((*ImportPrivateKeyRequest)(nil), "walletrpc.v2.ImportPrivateKeyRequest")
+	proto.RegisterType((*ImportPrivateKeyResponse)(nil), "walletrpc.v2.ImportPrivateKeyResponse")
+	proto.RegisterType((*ImportPublicKeyRequest)(nil), "walletrpc.v2.ImportPublicKeyRequest")
+	proto.RegisterType((*ImportPublicKeyResponse)(nil), "walletrpc.v2.ImportPublicKeyResponse")
+	proto.RegisterType((*ImportTaprootScriptRequest)(nil), "walletrpc.v2.ImportTaprootScriptRequest")
+	proto.RegisterType((*ImportTaprootScriptRequest_TapLeaf)(nil), "walletrpc.v2.ImportTaprootScriptRequest.TapLeaf")
+	proto.RegisterType((*ImportTaprootScriptResponse)(nil), "walletrpc.v2.ImportTaprootScriptResponse")
+	proto.RegisterType((*RescanRequest)(nil), "walletrpc.v2.RescanRequest")
+	proto.RegisterType((*RescanResponse)(nil), "walletrpc.v2.RescanResponse")
+}
+
+func init() {
+	proto.RegisterFile("v2/api.proto", fileDescriptor_9dd8ffb46251f1c6)
+}
+
+var fileDescriptor_9dd8ffb46251f1c6 = []byte{
+	// 3448 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x1b, 0x4b, 0x6f, 0x1c, 0x49,
+	0x79, 0x7b, 0x66, 0xec, 0x19, 0x7f, 0xf3, 0xf0, 0xa4, 0xfc, 0x9a, 0x74, 0x9c, 0xc4, 0xee, 0x6c,
+	0x12, 0x27, 0xbb, 0xb1, 0xb3, 0xde, 0x44, 0x2c, 0xda, 0x07, 0x38, 0xb6, 0xb3, 0xb1, 0x92, 0x75,
+	0xac, 0x1e, 0x67, 0xc3, 0xee, 0x4a, 0xb4, 0x7a, 0x66, 0xca, 0x76, 0x93, 0x99, 0xee, 0x4e, 0x77,
+	0x8f, 0xed, 0x01, 0x09, 0x71, 0x43, 0x1c, 0x90, 0xb8, 0xac, 0x40, 0x02, 0x71, 0x00, 0x21, 0xc4,
+	0x15, 0xc1, 0x99, 0x0b, 0x77, 0x90, 0xb8, 0xee, 0x2f, 0x40, 0x82, 0x03, 0x1c, 0xb9, 0xa0, 0x7a,
+	0x74, 0x77, 0xf5, 0x6b, 0x1e, 0x1b, 0xf6, 0xc6, 0x6d, 0xea, 0xab, 0xaf, 0xbe, 0xfa, 0xea, 0x7b,
+	0x54, 0x7d, 0x8f, 0x1e, 0xa8, 0x9c, 0x6e, 0x6e, 0xe8, 0xb6, 0xb1, 0x6e, 0x3b, 0x96, 0x67, 0xa1,
+	0xca, 0x99, 0xde, 0xed, 0x62, 0xcf, 0xb1, 0xdb, 0xeb, 0xa7, 0x9b, 0xca, 0x3b, 0x50, 0x7a, 0x8c,
+	0x07, 0xcd, 0xb6, 0x65, 0x63, 0xd4, 0x80, 0xa2, 0xdd, 0x77, 0x6c, 0xcb, 0xc5, 0x0d, 0x69, 0x45,
+	0x5a, 0xab, 0xaa, 0xfe, 0x10, 0x21, 0x28, 0xb4, 0x2d, 0xc3, 0x6c, 0xe4, 0x28, 0x98, 0xfe, 0x56,
+	0x1e, 0x43, 0xe9, 0x69, 0xdf, 0x3b, 0xb0, 0x0c, 0xd3, 0x43, 0xb7, 0xa0, 0xee, 0x39, 0xba, 0xe9,
+	0xea, 0x6d, 0xcf, 0xb0, 0x4c, 0xed, 0x44, 0x77, 0x4f, 0x28, 0x89, 0x8a, 0x3a, 0x2b, 0xc0, 0x1f,
+	0xe9, 0xee, 0x09, 0x9a, 0x87, 0x29, 0xc3, 0xec, 0xe0, 0x73, 0x4e, 0x8b, 0x0d, 0x94, 0x2f, 0xf2,
+	0x80, 0x0e, 0x43, 0xcc, 0x1d, 0xec, 0xe9, 0x46, 0xd7, 0x25, 0xfb, 0x0a, 0xb4, 0xe8, 0x6f, 0xb4,
+	0x02, 0x65, 0x81, 0x26, 0x25, 0x53, 0x51, 0x45, 0x10, 0xfa, 0x00, 0xa6, 0x3b, 0xb8, 0x65, 0x78,
+	0x6e, 0x23, 0xbf, 0x92, 0x5f, 0x2b, 0x6f, 0xde, 0x58, 0x17, 0x8f, 0xbc, 0x9e, 0xdc, 0x67, 0x7d,
+	0xcf, 0xb4, 0xfb, 0x9e, 0xca, 0x57, 0xa1, 0x2d, 0x28, 0xb6, 0x1d, 0xdc, 0x21, 0x04, 0x0a, 0x94,
+	0xc0, 0xcd, 0x91, 0x04, 0x9e, 0xf6, 0x3d, 0x42, 0xc1, 0x5f, 0x87, 0xea, 0x90, 0x3f, 0xc2, 0xb8,
+	0x31, 0xb5, 0x22, 0xad, 0xe5, 0x55, 0xf2, 0x13, 0x2d, 0xc3, 0x8c, 0x67, 0xf4, 0xb0, 0xeb, 0xe9,
+	0x3d, 0xbb, 0x31, 0x4d, 0xe1, 0x21, 0x80, 0x48, 0xa5, 0xab, 0xb7, 0x70, 0xb7, 0x51, 0x5c, 0x91,
+	0xd6, 0x66, 0x54, 0x36, 0x90, 0x5f, 0xc2, 0x14, 0xe5, 0x2c, 0x14, 0x9a, 0x24, 0x08, 0x8d, 0x48,
+	0xdd, 0x76, 0xf0, 0xa9, 0x61, 0xf5, 0x5d, 0x4d, 0x6f, 0xb7, 0xad, 0xbe, 0xe9, 0x71, 0xa9, 0xce,
+	0xfa, 0xf0, 0x2d, 0x06, 0x46, 0x37, 0x61, 0x36, 0x44, 0xed, 0x51, 0xcc, 0x3c, 0xe5, 0xa1, 0x16,
+	0x60, 0x52, 0xa8, 0x7c, 0x08, 0xd3, 0xec, 0x2c, 0x19, 0x7b, 0x36, 0xa0, 0x18, 0xdd, 0xca, 0x1f,
+	0x22, 0x19, 0x4a, 0x86, 0xe9, 0x61, 0xc7, 0xd4, 0xbb, 0x94, 0x76, 0x49, 0x0d, 0xc6, 0xca, 0x2f,
+	0x25, 0xa8, 0x3c, 0xe8, 0x5a, 0xed, 0x17, 0xc3, 0x14, 0xbb, 0x08, 0xd3, 0x27, 0xd8, 0x38, 0x3e,
+	0x61, 0x94, 0xa7, 0x54, 0x3e, 0x8a, 0x4a, 0x2e, 0x1f, 0x97, 0xdc, 0x0e, 0x54, 0x04, 0xdd, 0xfb,
+	0x1a, 0x5b, 0x19, 0xa5, 0x31, 0x35, 0xb2, 0x4a, 0xa9, 0x42, 0xf9, 0xc0, 0x30, 0x8f, 0x55, 0xfc,
+	0xb2, 0x8f, 0x5d, 0x4f, 0xa9, 0x41, 0x85, 0x0d, 0x5d, 0xdb, 0x32, 0x5d, 0xac, 0xd4, 0xa1, 0xb6,
+	0x8f, 0xbd, 0x33, 0xcb, 0x79, 0xe1, 0x63, 0xbc, 0x03, 0xb3, 0x01, 0x84, 0x21, 0xa1, 0xeb, 0x50,
+	0x23, 0xe4, 0x4e, 0xb1, 0x66, 0xb2, 0x19, 0x2e, 0xb9, 0x2a, 0x83, 0x72, 0x74, 0xe5, 0x39, 0xcc,
+	0x72, 0xad, 0xb8, 0x9c, 0x18, 0x7a, 0x13, 0xa6, 0x5c, 0xe2, 0x81, 0x74, 0x41, 0x79, 0x73, 0x31,
+	0xca, 0xbc, 0xef, 0x9f, 0x2a, 0x43, 0x22, 0x72, 0x62, 0xf3, 0x8d, 0x59, 0x6a, 0x2c, 0x7c, 0xa4,
+	0xfc, 0xb3, 0x00, 0xf5, 0x90, 0x32, 0x67, 0xea, 0x01, 0x94, 0xb8, 0x82, 0xdc, 0x86, 0x94, 0xe6,
+	0x0d, 0xf1, 0x15, 0x3e, 0x40, 0x0d, 0xd6, 0xa1, 0x37, 0x01, 0xb5, 0xfb, 0x8e, 0x83, 0x4d, 0x4f,
+	0x6b, 0x11, 0x25, 0x32, 0xff, 0x66, 0x8e, 0x57, 0xe7, 0x33, 0x54, 0xbb, 0xd4, 0xc1, 0xef, 0xc2,
+	0x7c, 0x0c, 0x9b, 0x29, 0x35, 0x4f, 0x95, 0x8a, 0x22, 0xf8, 0x74, 0x46, 0xfe, 0x63, 0x1e, 0x8a,
+	0xbe, 0xa1, 0x4e, 0x26, 0x0a, 0x2a, 0x72, 0xba, 0x50, 0x33, 0xfb, 0xbd, 0x16, 0x76, 0xb8, 0x51,
+	0x56, 0x39, 0x74, 0x9f, 0x02, 0xd1, 0x2a, 0x54, 0x02, 0x34, 0xbd, 0x87, 0x29, 0x2b, 0x33, 0x6a,
+	0xd9, 0x47, 0xd2, 0x7b, 0x18, 0x5d, 0x83, 0xaa, 0x67, 0x79, 0x7a, 0x57, 0x6b, 0xe9, 0x5d, 0xdd,
+	0x6c, 0xe3, 0x46, 0x81, 0x1a, 0x5a, 0x85, 0x02, 0x1f, 0x30, 0x18, 0x11, 0x04, 0x3e, 0x67, 0x26,
+	0xad, 0xbd, 0xc0, 0x03, 0x8d, 0xf9, 0xc1, 0x14, 0xdd, 0xb2, 0xee, 0xcf, 0x3c, 0xc6, 0x83, 0x6d,
+	0x7e, 0x14, 0x64, 0x98, 0x09, 0xec, 0x69, 0x86, 0x6d, 0x98, 0x29, 0xd8, 0x3d, 0xdb, 0x72, 0x3c,
+	0xdc, 0x11, 0xb0, 0x8b, 0x1c, 0x9b, 0xcf, 0x04, 0xd8, 0xeb, 0x30, 0x47, 0xf6, 0x33, 0x3b, 0xb8,
+	0xa3, 0xd9, 0xfd, 0x56, 0xd7, 0x68, 0x93, 0x45, 0x8d, 0x12, 0x3d, 0xd8, 0x05, 0x7f, 0xea, 0x80,
+	0xce, 0x3c, 0xc6, 0x03, 0x74, 0x0f, 0x16, 0x7b, 0xba, 0xeb, 0x61, 0x87, 0xd2, 0x3e, 0x32, 0xcc,
+	0x63, 0xec, 0xd8, 0x8e, 0x61, 0x7a, 0x8d, 0x19, 0xba, 0xc3, 0x3c, 0x9b, 0x7d, 0x8c, 0x07, 0x0f,
+	0xc3, 0x39, 0x74, 0x19, 0xe0, 0x4c, 0xf7, 0xda, 0x27, 0x9a, 0x65, 0x76, 0x07, 0x0d, 0xa0, 0x4e,
+	0x3d, 0x43, 0x21, 0x4f, 0xcd, 0xee, 0x40, 0xf9, 0x9d, 0x04, 0x35, 0x2e, 0x1a, 0xdf, 0x92, 0x93,
+	0x0a, 0x91, 0xd2, 0x14, 0x72, 0x1f, 0x16, 0x1d, 0xfc, 0xb2, 0x6f, 0x38, 0xb8, 0xa3, 0xb5, 0x2d,
+	0xf3, 0xc8, 0x70, 0x7a, 0x3a, 0x73, 0x5f, 0xe6, 0xfa, 0x0b, 0xfe, 0xec, 0xb6, 0x38, 0x49, 0xf5,
+	0xd8, 0xed, 0x6a, 0x81, 0x41, 0xb3, 0x6b, 0xa6, 0xac, 0x77, 0xbb, 0xbe, 0x15, 0x67, 0x3a, 0x87,
+	0x09, 0xb3, 0x01, 0xab, 0xdc, 0x35, 0xe6, 0x61, 0x8a, 0x6a, 0x97, 0xb2, 0x98, 0x57, 0xd9, 0x80,
+	0xdc, 0x36, 0xae, 0x8d, 0xcd, 0x8e, 0xde, 0xea, 0x62, 0xca, 0x4d, 0x5e, 0x0d, 0x01, 0xe4, 0x1e,
+	0x35, 0x7a, 0x3d, 0xdd, 0xeb, 0x3b, 0x58, 0x73, 0xf0, 0x99, 0xee, 0x74, 0xfc, 0x7b, 0xd4, 0x07,
+	0xab, 0x14, 0x4a, 0x64, 0x83, 0x9e, 0x18, 0xae, 0xf7, 0xcc, 0x24, 0x8b, 0x3d, 0x5f, 0x3e, 0x6f,
+	0xc0, 0x85, 0x9e, 0x61, 0xc6, 0xce, 0x2c, 0xd1, 0x33, 0xd7, 0x7b, 0x86, 0x19, 0x3d, 0x2e, 0x41,
+	0xd6, 0xcf, 0x53, 0x05, 0x54, 0xef, 0xe9, 0xe7, 0x49, 0xd9, 0x8c, 0xb0, 0xf1, 0x2c, 0xd9, 0xfc,
+	0x3b, 0x07, 0x73, 0x11, 0x5e, 0x83, 0xbb, 0xa3, 0x68, 0xd1, 0xb7, 0xc0, 0xbf, 0x3a, 0xd6, 0xa2,
+	0xde, 0x98, 0xb2, 0x26, 0x78, 0x08, 0xf9, 0x42, 0xf9, 0xa7, 0xb9, 0xe0, 0x41, 0xd9, 0x84, 0x12,
+	0x81, 0x92, 0x80, 0x21, 0xdd, 0xbb, 0xfd, 0x70, 0x42, 0x0d, 0xf0, 0xe8, 0x73, 0xd3, 0xe9, 0x38,
+	0xd8, 0x65, 0x07, 0x9f, 0x51, 0xfd, 0xe1, 0x98, 0xe7, 0xe5, 0x6f, 0x1d, 0x73, 0x66, 0x3e, 0x42,
+	0x97, 0x60, 0xc6, 0x7e, 0xa1, 0xb9, 0x6d, 0xc7, 0xb0, 0x99, 0xf7, 0x56, 0xd4, 0x92, 0xfd, 0xa2,
+	0x49, 0xc7, 0xe4, 0x22, 0x70, 0x70, 0x07, 0xe3, 0x9e, 0x8f, 0x30, 0x4d, 0x11, 0x2a, 0x0c, 0xc8,
+	0x91, 0x5e, 0x87, 0x6a, 0x54, 0x2b, 0x45, 0xba, 0x41, 0x14, 0x18, 0x35, 0xa5, 0x12, 0xf3, 0x9e,
+	0x00, 0xa0, 0x6c, 0x42, 0x83, 0x08, 0xf0, 0x09, 0xd6, 0x5d, 0xdc, 0x61, 0x22, 0x0a, 0x1e, 0x84,
+	0x2c, 0x4d, 0x7d, 0x9e, 0x83, 0x8b, 0x29, 0x8b, 0xb8, 0xbe, 0xf6, 0xe3, 0xfa, 0xba, 0x97, 0xd4,
+	0x57, 0xea, 0xca, 0x75, 0x11, 0x1a, 0xea, 0xee, 0xb7, 0x12, 0x54, 0xc4, 0x19, 0x54, 0x83, 0x9c,
+	0xd1, 0xe1, 0x6f, 0x76, 0xce, 0xe8, 0x44, 0x34, 0x9a, 0x1b, 0x53, 0xa3, 0x57, 0x00, 0xf0, 0xb9,
+	0x6d, 0x38, 0x54, 0x46, 0xdc, 0x79, 0x04, 0xc8, 0x97, 0x52, 0x9a, 0xf2, 0x29, 0x2c, 0x7c, 0x88,
+	0x3d, 0xe1, 0x95, 0xf7, 0x05, 0x39, 0x41, 0x60, 0x9a, 0x25, 0xf3, 0x7f, 0x48, 0xb0, 0x18, 0x27,
+	0x1e, 0x38, 0x48, 0x24, 0x14, 0x65, 0x46, 0x3d, 0x3a, 0xf4, 0x10, 0x17, 0x91, 0x3b, 0x36, 0xf1,
+	0xa8, 0xce, 0xb4, 0x82, 0xd7, 0x74, 0x15, 0x2a, 0x29, 0xaf, 0x68, 0xb9, 0x15, 0x3e, 0x9f, 0xe4,
+	0x4e, 0x62, 0x28, 0x61, 0x94, 0xc4, 0x44, 0x57, 0xa3, 0xe0, 0x43, 0x1f, 0x9a, 0xb4, 0xda, 0x29,
+	0x4a, 0x2c, 0x0a, 0x54, 0xee, 0xc0, 0x9c, 0x8a, 0xdd, 0xb6, 0x6e, 0x36, 0x3d, 0xdd, 0xeb, 0x8f,
+	0x34, 0xc9, 0x3f, 0x4b, 0x30, 0x1f, 0xc5, 0xe7, 0xc2, 0x91, 0xa1, 0x44, 0xa0, 0xa6, 0x61, 0x1e,
+	0x53, 0xc9, 0x94, 0xd4, 0x60, 0x4c, 0xdc, 0xda, 0xf5, 0x74, 0xf2, 0xa2, 0xf1, 0x2b, 0xd6, 0x1f,
+	0x92, 0xf3, 0xd2, 0x9f, 0xb1, 0xf3, 0x52, 0x18, 0x3f, 0x6f, 0x18, 0x27, 0x16, 0x22, 0x71, 0xe2,
+	0x55, 0x28, 0xb7, 0xb0, 0x1b, 0xac, 0x64, 0x87, 0x03, 0x02, 0xe2, 0x0b, 0x65, 0x28, 0xd9, 0x8e,
+	0x75, 0x4c, 0x6f, 0x13, 0xe2, 0xd5, 0x92, 0x1a, 0x8c, 0x95, 0xaf, 0xc3, 0x55, 0x41, 0x53, 0xfb,
+	0x96, 0x67, 0x1c, 0x19, 0x6d, 0x26, 0x91, 0x51, 0x12, 0xf8, 0x75, 0x0e, 0x56, 0xb2, 0xd7, 0x72,
+	0x69, 0x6c, 0xc3, 0xac, 0xee, 0x79, 0x7a, 0xfb, 0x04, 0x77, 0x58, 0x58, 0xe4, 0xfb, 0xa8, 0x1c,
+	0x35, 0x17, 0x31, 0x4a, 0x56, 0x6b, 0xfe, 0x12, 0x0a, 0x75, 0x89, 0xa6, 0x3b, 0x38, 0x4a, 0x24,
+	0xb7, 0x92, 0x5f, 0xab, 0xa8, 0xb5, 0x0e, 0x8e, 0x20, 0x36, 0x61, 0xbe, 0x6f, 0xf6, 0x0c, 0x13,
+	0x77, 0xb4, 0x48, 0x70, 0x9c, 0x1f, 0x33, 0x38, 0x9e, 0xe3, 0xab, 0x85, 0x29, 0x17, 0xbd, 0x07,
+	0x72, 0x0a, 0x51, 0x6a, 0xb7, 0x98, 0xc5, 0xdd, 0x15, 0xb5, 0x91, 0x5c, 0xf8, 0x88, 0xce, 0x2b,
+	0xbf, 0x91, 0xe0, 0x72, 0x93, 0x3c, 0x15, 0x26, 0x76, 0xdd, 0x54, 0xf9, 0x0a, 0xa9, 0x85, 0x14,
+	0x4d, 0x2d, 0x6e, 0xc3, 0x05, 0xd3, 0xd2, 0x4c, 0xb2, 0x68, 0xa0, 0xf5, 0xd9, 0x8b, 0x43, 0x0d,
+	0xa7, 0xa4, 0xce, 0x9a, 0x16, 0x25, 0x36, 0xe0, 0x0f, 0x11, 0xba, 0x01, 0xb3, 0x21, 0x2e, 0xc3,
+	0x64, 0x61, 0x42, 0xd5, 0xc7, 0xa4, 0x5c, 0x0c, 0xbb, 0x62, 0xaf, 0x64, 0xf1, 0xc9, 0x75, 0x39,
+	0xc1, 0xa5, 0xb2, 0x0a, 0x15, 0x76, 0x9b, 0x6a, 0x62, 0xd2, 0x5b, 0x66, 0xb0, 0x3d, 0x02, 0x22,
+	0xb7, 0x36, 0x7d, 0x14, 0xb0, 0x43, 0x19, 0x4d, 0xdc, 0xda, 0xc3, 0x99, 0xa1, 0xd3, 0x1d, 0xec,
+	0xa8, 0x3e, 0x11, 0xf9, 0x19, 0x14, 0x39, 0x6c, 0x12, 0x46, 0xaf, 0x42, 0xd9, 0x30, 0xe3, 0x7c,
+	0x82, 0x61, 0xfa, 0x6c, 0x2a, 0xf7, 0xe1, 0x12, 0x0f, 0xb2, 0x26, 0x72, 0x8e, 0x7f, 0x49, 0xb0,
+	0x9c, 0xbe, 0x4e, 0xcc, 0x9a, 0x46, 0x47, 0x8c, 0xf1, 0xe7, 0x3e, 0x97, 0x7c, 0xee, 0xd3, 0xa3,
+	0xf3, 0xfc, 0x44, 0xd1, 0x79, 0x61, 0xa2, 0xe8, 0x7c, 0x2a, 0x3d, 0x3a, 0x57, 0x7e, 0x26, 0xc1,
+	0x8a, 0x18, 0x9d, 0xa5, 0x4a, 0x6c, 0x02, 0xe5, 0xbc, 0x05, 0xf3, 0x9e, 0xee, 0x1c, 0x63, 0x2f,
+	0x35, 0x16, 0x9c, 0x63, 0x73, 0xd1, 0x70, 0x30, 0x4b, 0x1f, 0x7f, 0x95, 0x60, 0x75, 0x08, 0x6b,
+	0x93, 0x5b, 0x78, 0xe2, 0x51, 0xc9, 0xa5, 0x3c, 0x2a, 0xb1, 0x57, 0x2e, 0x3f, 0xea, 0x95, 0x2b,
+	0x24, 0x5f, 0xb9, 0x06, 0x14, 0x1d, 0x4c, 0xef, 0x38, 0x2a, 0xf6, 0x92, 0xea, 0x0f, 0x95, 0x2f,
+	0x24, 0x58, 0xda, 0x3e, 0xd1, 0xcd, 0x63, 0x7c, 0xa0, 0xbb, 0xae, 0x7d, 0xe2, 0xe8, 0x6e, 0x90,
+	0x8f, 0xbc, 0x0b, 0x79, 0x92, 0x17, 0x11, 0xde, 0x6b, 0x9b, 0xb7, 0xa2, 0x8e, 0x95, 0xb1, 0x86,
+	0x24, 0x99, 0x2a, 0x59, 0x45, 0x4c, 0xd3, 0xea, 0x76, 0x34, 0x3b, 0xc0, 0xe0, 0xcf, 0x73, 0xd5,
+	0xea, 0x76, 0xc2, 0x65, 0x04, 0xcd, 0xc4, 0x67, 0x22, 0x1a, 0x3b, 0x5f, 0xd5, 0xc4, 0x67, 0x02,
+	0x5a, 0x96, 0x46, 0xae, 0x40, 0x9e, 0x64, 0x68, 0x65, 0x28, 0x1e, 0xa8, 0x7b, 0x1f, 0x6f, 0x1d,
+	0xee, 0xd6, 0x5f, 0x43, 0x00, 0xd3, 0x07, 0xcf, 0x1e, 0x3c, 0xd9, 0xdb, 0xae, 0x4b, 0x8a, 0x0c,
+	0x8d, 0x24, 0xa7, 0xbc, 0x2e, 0xf1, 0x13, 0x09, 0xe6, 0xb7, 0x1d, 0xac, 0x7b, 0xd8, 0xcf, 0xda,
+	0xf9, 0xb9, 0xaf, 0x00, 0x08, 0xfc, 0x30, 0xd5, 0x09, 0x90, 0x30, 0xcd, 0xce, 0x8d, 0x93, 0x66,
+	0x23, 0x28, 0x08, 0x31, 0x36, 0xfd, 0x9d, 0x79, 0x9c, 0x0f, 0x60, 0x21, 0xc6, 0xd1, 0x44, 0x8e,
+	0x4e, 0x4a, 0x45, 0xf3, 0x2a, 0x26, 0x5b, 0xc4, 0x8e, 0xf4, 0x95, 0x54, 0x06, 0x2e, 0x42, 0x89,
+	0xe8, 0x4e, 0x38, 0x5d, 0xd1, 0xc4, 0x67, 0x43, 0xb3, 0xa5, 0x25, 0x58, 0x88, 0xf1, 0xc7, 0x95,
+	0xf1, 0x0b, 0x09, 0xd0, 0x3e, 0x3e, 0xf7, 0xb6, 0x58, 0x86, 0x32, 0xfa, 0x59, 0x7b, 0x0f, 0x2a,
+	0x3c, 0x9b, 0xd1, 0xbc, 0x01, 0xd7, 0x45, 0x6d, 0xf3, 0x62, 0xac, 0x3e, 0xc3, 0x30, 0x0e, 0x07,
+	0x36, 0x56, 0xcb, 0x7a, 0x38, 0x20, 0xfc, 0xb5, 0xa9, 0x5d, 0xf0, 0xf7, 0x8d, 0x8f, 0x32, 0xf9,
+	0xde, 0x80, 0xb9, 0x08, 0x77, 0x5c, 0x2d, 0x42, 0x86, 0x25, 0x45, 0x32, 0x2c, 0xe5, 0xe7, 0x24,
+	0x85, 0xc5, 0xba, 0x8b, 0x79, 0x5e, 0xc0, 0xcf, 0xf3, 0xbf, 0x48, 0x02, 0x6e, 0x41, 0xbd, 0xd3,
+	0x67, 0x01, 0xbf, 0xe6, 0xe2, 0xb6, 0x65, 0x76, 0x58, 0x32, 0x5f, 0x50, 0x67, 0x7d, 0x78, 0x93,
+	0x81, 0x33, 0x8f, 0x73, 0x1f, 0xe6, 0x22, 0xcc, 0xf1, 0xe3, 0x44, 0xd3, 0x0b, 0x29, 0x9e, 0x5e,
+	0x28, 0x0e, 0xb1, 0xae, 0xee, 0x57, 0x73, 0xaa, 0xa1, 0x16, 0xd3, 0x4d, 0x32, 0xab, 0xfc, 0x3d,
+	0x0f, 0x0d, 0xe6, 0x2c, 0x29, 0xa9, 0xcb, 0x28, 0x17, 0xfe, 0x30, 0xcc, 0xf6, 0x72, 0x34, 0xac,
+	0xbb, 0x13, 0xbb, 0xde, 0x32, 0x08, 0xc7, 0x53, 0xf4, 0xd0, 0xb1, 0xf2, 0xe3, 0x38, 0x96, 0x60,
+	0xce, 0x85, 0xa8, 0x39, 0xa7, 0xd6, 0x36, 0xa6, 0x32, 0x6a, 0x1b, 0xd7, 0xa0, 0x76, 0x84, 0xb1,
+	0xe6, 0xea, 0x9e, 0x66, 0x93, 0xaa, 0x54, 0x8b, 0xd7, 0xc4, 0xcb, 0x47, 0x18, 0x37, 0x75, 0xef,
+	0x00, 0x3b, 0x8f, 0x5b, 0xe8, 0x33, 0x58, 0x22, 0xad, 0x06, 0xcd, 0xc5, 0x5d, 0xcc, 0x5e, 0x22,
+	0xd7, 0x73, 0x74, 0x0f, 0x1f, 0x0f, 0x68, 0xc2, 0x5d, 0xdb, 0xbc, 0x16, 0x3b, 0xb2, 0x65, 0x98,
+	0x4d, 0x1f, 0xb7, 0xc9, 0x51, 0xd5, 0x85, 0x76, 0x1a, 0x18, 0x2d, 0x41, 0xb1, 0xe3, 0x0c, 0x34,
+	0xa7, 0x6f, 0xf2, 0xdc, 0x7c, 0xba, 0xe3, 0x0c, 0xd4, 0xbe, 0x99, 0xa5, 0x46, 0xf9, 0xfd, 0xa0,
+	0x92, 0x11, 0xe6, 0xa8, 0x52, 0x76, 0x8e, 0x9a, 0x8b, 0xe5, 0xa8, 0xff, 0x91, 0xe0, 0x62, 0x8a,
+	0x4e, 0xb8, 0xdd, 0xae, 0x24, 0x53, 0xc9, 0x58, 0x57, 0x63, 0x1d, 0xe6, 0x98, 0x87, 0x6b, 0x89,
+	0x88, 0x72, 0x4a, 0xbd, 0xc0, 0xa6, 0x9e, 0x0a, 0x71, 0xe5, 0x55, 0x28, 0xb3, 0x8a, 0x26, 0x0d,
+	0xe2, 0xfc, 0x4c, 0x9b, 0x82, 0x58, 0x53, 0x81, 0xf7, 0x28, 0x0a, 0x61, 0x8f, 0x62, 0x1d, 0xe6,
+	0x82, 0x2e, 0x41, 0x70, 0x10, 0xa2, 0x43, 0x12, 0xda, 0x5f, 0xf0, 0xa7, 0x0e, 0xf8, 0x89, 0xdc,
+	0x68, 0x03, 0xa2, 0xc7, 0x6a, 0x72, 0xd3, 0x2b, 0xf9, 0xb5, 0xbc, 0xd0, 0x80, 0x60, 0x60, 0xe5,
+	0x57, 0x79, 0x40, 0x4d, 0x6c, 0xc6, 0x0b, 0x1d, 0xff, 0x37, 0xf2, 0xaf, 0xd6, 0xc8, 0x83, 0xbe,
+	0x52, 0x49, 0xe8, 0x2b, 0x65, 0x5e, 0x54, 0x2d, 0x98, 0x8b, 0xe8, 0x68, 0x6c, 0xdb, 0x4c, 0x8b,
+	0x17, 0x73, 0xa9, 0xf1, 0xa2, 0xf2, 0x43, 0x09, 0x16, 0x9b, 0xc6, 0xb1, 0xf9, 0x25, 0x6e, 0xbc,
+	0xfb, 0xb0, 0xe8, 0x62, 0xc7, 0xd0, 0xbb, 0xc6, 0x77, 0xa3, 0x39, 0x28, 0xdf, 0x6b, 0x21, 0x9c,
+	0x15, 0xa8, 0x67, 0x9e, 0xf6, 0x25, 0x2c, 0x25, 0x18, 0x19, 0xfb, 0xc4, 0xf7, 0x60, 0xb1, 0x6f,
+	0xba, 0xc6, 0x31, 0xc9, 0x86, 0x85, 0xc4, 0x09, 0x33, 0x3b, 0xad, 0xaa, 0xf3, 0xfe, 0xec, 0x5e,
+	0x90, 0x42, 0x61, 0x57, 0xf9, 0x43, 0x0e, 0x66, 0x1f, 0xf6, 0xcd, 0xce, 0x81, 0xdb, 0x0a, 0x5e,
+	0x1e, 0x04, 0x05, 0xdb, 0x6d, 0x79, 0x7e, 0x2b, 0x8c, 0xfc, 0x9e, 0x30, 0x3c, 0x13, 0xac, 0x35,
+	0x3f, 0x86, 0xb5, 0x16, 0xc6, 0xb6, 0xd6, 0xa9, 0x89, 0xac, 0x75, 0xfa, 0x95, 0xad, 0x35, 0x4b,
+	0x53, 0x1f, 0x43, 0x3d, 0x94, 0x1a, 0x57, 0x51, 0x9a, 0xd8, 0x26, 0xbc, 0x22, 0x49, 0x91, 0x7e,
+	0xee, 0xa1, 0x61, 0x52, 0x93, 0x11, 0x55, 0x32, 0xca, 0x10, 0xfd, 0xbd, 0x73, 0x69, 0x2a, 0x7b,
+	0xc5, 0x0b, 0x26, 0x4b, 0x06, 0x4f, 0x60, 0x3e, 0xca, 0xea, 0x10, 0x39, 0x8c, 0x6c, 0x91, 0x2b,
+	0xe7, 0x70, 0x91, 0x36, 0x87, 0xdc, 0x93, 0x14, 0x3f, 0xbc, 0x03, 0x88, 0x5b, 0x76, 0xd2, 0x09,
+	0x2e, 0xb0, 0x19, 0xd1, 0xbf, 0x82, 0x3b, 0x26, 0x37, 0xce, 0x1d, 0xb3, 0x0c, 0x72, 0xda, 0xce,
+	0x61, 0x42, 0xb3, 0xf4, 0x84, 0xac, 0x7f, 0xb5, 0x5a, 0x6e, 0x3a, 0x4b, 0xcb, 0x30, 0x63, 0x9d,
+	0x62, 0xe7, 0xcc, 0x31, 0x3c, 0x3f, 0x68, 0x0e, 0x01, 0x99, 0x0c, 0xcb, 0xd0, 0x48, 0x72, 0xc4,
+	0xd9, 0xfd, 0x81, 0x04, 0x88, 0xdc, 0x21, 0x1f, 0x61, 0xd7, 0xd5, 0x8f, 0xf1, 0xb8, 0xf6, 0x93,
+	0xdd, 0xd5, 0x68, 0x40, 0xb1, 0xc7, 0x68, 0xf9, 0xe9, 0x08, 0x1f, 0x66, 0xb2, 0xf7, 0x36, 0xcc,
+	0x45, 0x38, 0xe0, 0x66, 0x41, 0x7a, 0x0f, 0xc6, 0xb1, 0x49, 0x5b, 0x52, 0x9c, 0x83, 0x10, 0xa0,
+	0xfc, 0x3e, 0x07, 0xf3, 0x7b, 0xb4, 0x6a, 0x11, 0x4b, 0xb2, 0xfc, 0x4c, 0x4f, 0x12, 0x32, 0xbd,
+	0x8c, 0x5e, 0x63, 0x6e, 0xf2, 0x5e, 0x63, 0x7e, 0x48, 0xaf, 0x31, 0x9e, 0x0c, 0x15, 0x26, 0x4a,
+	0x86, 0x84, 0x60, 0x6e, 0x2a, 0x12, 0xcc, 0xbd, 0x05, 0x0b, 0x7c, 0x42, 0xf3, 0xc9, 0x8b, 0x7d,
+	0x58, 0xc4, 0xd0, 0x38, 0xdd, 0xed, 0xa1, 0x1e, 0xf8, 0x37, 0x09, 0x16, 0x62, 0x42, 0xe3, 0xc2,
+	0xfe, 0x66, 0x34, 0xc5, 0x1b, 0xbf, 0xc7, 0x1e, 0x78, 0xfd, 0xbb, 0x20, 0xfb, 0x6c, 0x06, 0x35,
+	0x2c, 0xce, 0x2f, 0x7f, 0x52, 0x66, 0xd4, 0x25, 0xc6, 0xeb, 0x2e, 0x9f, 0xdf, 0xf2, 0xa7, 0xc5,
+	0xc5, 0x86, 0x99, 0x58, 0x9c, 0x17, 0x17, 0xef, 0x99, 0xb1, 0xc5, 0xca, 0x5f, 0x24, 0x58, 0x62,
+	0xa7, 0x3a, 0x70, 0x8c, 0x53, 0xdd, 0xc3, 0xa4, 0xfc, 0x31, 0xa6, 0x1d, 0xdf, 0x20, 0x5f, 0x95,
+	0xd0, 0x45, 0x54, 0xd5, 0x67, 0xc6, 0x11, 0xb7, 0x8a, 0xaa, 0x1d, 0xd0, 0x7a, 0x6e, 0x1c, 0x25,
+	0x74, 0x9b, 0x9f, 0x34, 0xd1, 0x75, 0x68, 0x83, 0x81, 0xda, 0x44, 0x49, 0xe5, 0xa3, 0x4c, 0x3d,
+	0xdd, 0x83, 0x46, 0xf2, 0x40, 0x23, 0xb3, 0xdd, 0x1f, 0x4b, 0xb0, 0xc8, 0x97, 0xf9, 0x96, 0xec,
+	0x8b, 0xe1, 0x32, 0x80, 0x60, 0xf7, 0xdc, 0x99, 0xec, 0xc0, 0xde, 0x5f, 0x39, 0x8d, 0x4f, 0x3d,
+	0xc5, 0xc5, 0x40, 0x2d, 0x21, 0x3b, 0xfc, 0xd6, 0xf9, 0x3c, 0x07, 0x32, 0x9b, 0x3b, 0xd4, 0x6d,
+	0xc7, 0xb2, 0x3c, 0x16, 0x8f, 0x7f, 0xb9, 0x42, 0xc9, 0x2a, 0x54, 0xc4, 0x3a, 0xa8, 0xff, 0x58,
+	0x08, 0x15, 0x50, 0xf4, 0x08, 0xa6, 0xbb, 0x58, 0x3f, 0xc5, 0x7e, 0xff, 0xe0, 0x6e, 0x94, 0x62,
+	0x36, 0x2b, 0xeb, 0x87, 0xba, 0xfd, 0x04, 0xeb, 0x47, 0x2a, 0x5f, 0x9f, 0x99, 0x5a, 0xed, 0x40,
+	0x91, 0xa3, 0x12, 0x7e, 0xba, 0x58, 0x3f, 0xd2, 0x4e, 0xb1, 0xe3, 0xfa, 0xcf, 0x4e, 0x55, 0x2d,
+	0x13, 0xd8, 0xc7, 0x0c, 0x44, 0xa8, 0x44, 0x72, 0x2c, 0x3e, 0x52, 0xbe, 0x06, 0x97, 0x52, 0x79,
+	0x19, 0xa9, 0xfb, 0x13, 0xa8, 0xb2, 0x16, 0x96, 0x2f, 0xc2, 0x78, 0x17, 0x4a, 0x4a, 0x76, 0xa1,
+	0x96, 0x61, 0x26, 0xee, 0xa0, 0x21, 0x20, 0x53, 0xab, 0x75, 0xa8, 0xf9, 0x3b, 0x31, 0xae, 0x6e,
+	0xff, 0x48, 0x82, 0xb2, 0x60, 0x1c, 0x68, 0x19, 0x1a, 0x5b, 0x3b, 0x3b, 0xea, 0x6e, 0xb3, 0xa9,
+	0x1d, 0x7e, 0x72, 0xb0, 0xab, 0x3d, 0xdb, 0x6f, 0x1e, 0xec, 0x6e, 0xef, 0x3d, 0xdc, 0xdb, 0xdd,
+	0xa9, 0xbf, 0x86, 0x66, 0xa1, 0x7c, 0xf0, 0xec, 0xc1, 0xe3, 0xdd, 0x4f, 0xb4, 0x47, 0x5b, 0xcd,
+	0x47, 0x75, 0x09, 0x5d, 0x01, 0x79, 0x7f, 0xb7, 0x79, 0xb8, 0xbb, 0xa3, 0x3d, 0xdf, 0x3b, 0xdc,
+	0x27, 0xab, 0xc4, 0xf9, 0x1c, 0x5a, 0x82, 0xb9, 0xb4, 0x89, 0x3c, 0x42, 0x50, 0x3b, 0xdc, 0x3a,
+	0x50, 0x9f, 0x3e, 0x3d, 0xe4, 0x13, 0xf5, 0xc2, 0xed, 0xbb, 0xb0, 0x90, 0x1a, 0xaf, 0x91, 0xe2,
+	0xe4, 0x93, 0x2d, 0xf5, 0xc3, 0xdd, 0xe6, 0x21, 0x2b, 0x4e, 0xaa, 0x5b, 0xfb, 0x3b, 0x4f, 0x3f,
+	0xaa, 0x4b, 0x9b, 0x7f, 0x5a, 0x84, 0xea, 0x73, 0x7a, 0xb4, 0x26, 0x76, 0x4e, 0x8d, 0x36, 0x46,
+	0xef, 0x43, 0x81, 0x7c, 0x3a, 0x85, 0x62, 0xf6, 0x2f, 0x7c, 0x5d, 0x25, 0xcb, 0x69, 0x53, 0x5c,
+	0x49, 0x0f, 0xa1, 0xc8, 0x3f, 0x94, 0x42, 0xcb, 0x51, 0xb4, 0xe8, 0x07, 0x58, 0xf2, 0xe5, 0x8c,
+	0x59, 0x4e, 0x67, 0x0f, 0x4a, 0xc1, 0x37, 0x21, 0x97, 0xb3, 0x6e, 0x63, 0x46, 0xe9, 0xca, 0xf0,
+	0xcb, 0x9a, 0xb0, 0xe4, 0x7f, 0x00, 0x14, 0x63, 0x29, 0xfa, 0xf1, 0x8b, 0x7c, 0x39, 0x63, 0x96,
+	0xd3, 0x51, 0xa1, 0x2c, 0x7c, 0x31, 0x81, 0x56, 0x86, 0x7c, 0x4c, 0xc1, 0xe8, 0xad, 0x8e, 0xfc,
+	0xdc, 0x02, 0x75, 0xe0, 0x42, 0xa2, 0xab, 0x8f, 0x6e, 0x8c, 0x6c, 0xfb, 0x33, 0xfa, 0x37, 0xc7,
+	0xfc, 0x3c, 0x00, 0x7d, 0x06, 0xb5, 0x68, 0x07, 0x1c, 0xc5, 0xa2, 0xfc, 0xd4, 0xe6, 0xbb, 0xfc,
+	0xfa, 0x70, 0x24, 0x4e, 0xfc, 0x19, 0x54, 0xc4, 0xfe, 0x31, 0x8a, 0x9d, 0x3a, 0xa5, 0x17, 0x2d,
+	0x2b, 0xc3, 0x50, 0x38, 0xd9, 0xef, 0x41, 0x23, 0xab, 0x29, 0x8b, 0xee, 0x64, 0x36, 0x40, 0xd3,
+	0x3a, 0x35, 0xf2, 0xfa, 0xb8, 0xe8, 0x6c, 0xeb, 0xbb, 0x12, 0xea, 0xc3, 0x62, 0x7a, 0xdb, 0x0e,
+	0xbd, 0x31, 0x5e, 0x73, 0x8f, 0x6d, 0xfc, 0xe6, 0x24, 0x9d, 0xc0, 0xbb, 0x12, 0xb2, 0x60, 0x3e,
+	0xad, 0xd7, 0x86, 0x6e, 0xa5, 0x5a, 0x78, 0xea, 0x96, 0xb7, 0xc7, 0x41, 0x0d, 0x36, 0xfc, 0x3e,
+	0x5c, 0xcc, 0x6c, 0x26, 0xa1, 0xf5, 0x78, 0x26, 0x38, 0xbc, 0x21, 0x26, 0x6f, 0x8c, 0x8d, 0x1f,
+	0xec, 0xaf, 0x43, 0x3d, 0xde, 0x1b, 0x41, 0xd7, 0xc7, 0xea, 0xf2, 0xc8, 0x37, 0x46, 0xa1, 0x71,
+	0x3b, 0xfa, 0x16, 0x54, 0x23, 0xfd, 0x0c, 0xa4, 0xa4, 0x55, 0xa0, 0xa2, 0x61, 0xb4, 0x7c, 0x6d,
+	0x28, 0x4e, 0x48, 0x39, 0xd2, 0x48, 0x40, 0x09, 0xb3, 0x4e, 0x76, 0x41, 0xe4, 0x6b, 0x43, 0x71,
+	0xc2, 0x9b, 0x46, 0x28, 0xf5, 0xc7, 0x6f, 0x9a, 0x64, 0x8f, 0x42, 0x5e, 0x1d, 0x82, 0x21, 0xdc,
+	0x5e, 0x61, 0x09, 0x3b, 0x71, 0x7b, 0x25, 0x2a, 0xea, 0xf2, 0xea, 0x10, 0x0c, 0x51, 0x02, 0x42,
+	0x61, 0x3c, 0x29, 0x81, 0x64, 0xa5, 0x5e, 0xbe, 0x36, 0x14, 0x27, 0xbc, 0x17, 0x13, 0xa5, 0xc1,
+	0xf8, 0xbd, 0x98, 0x55, 0x3b, 0x94, 0x6f, 0x8e, 0xc4, 0x0b, 0x65, 0x22, 0xd4, 0xcb, 0xe2, 0x32,
+	0x49, 0x96, 0x3b, 0xe5, 0xd5, 0x21, 0x18, 0x9c, 0xe6, 0xb7, 0x61, 0x36, 0x56, 0x95, 0x42, 0xb1,
+	0x7b, 0x34, 0xbd, 0x7a, 0x26, 0x5f, 0x1f, 0x81, 0x15, 0x3e, 0x8c, 0x7e, 0x2d, 0x25, 0xfe, 0x30,
+	0xc6, 0x2a, 0x53, 0xf2, 0x95, 0xac, 0xe9, 0xf0, 0xe6, 0x16, 0x4b, 0x12, 0xf1, 0x9b, 0x3b, 0xa5,
+	0xb2, 0x22, 0x2b, 0xc3, 0x50, 0x38, 0xd9, 0x63, 0x40, 0xc9, 0x0a, 0x01, 0x8a, 0x29, 0x25, 0xb3,
+	0x7a, 0x21, 0xaf, 0x8d, 0x46, 0xe4, 0x1b, 0xe9, 0x50, 0x8f, 0x67, 0xf6, 0xf1, 0xdb, 0x23, 0xa3,
+	0x16, 0x21, 0xdf, 0x18, 0x85, 0x26, 0x58, 0x48, 0x98, 0x9d, 0x27, 0x2c, 0x24, 0x51, 0x3a, 0x90,
+	0x57, 0x87, 0x60, 0x84, 0x5e, 0x13, 0x49, 0x43, 0xe3, 0x5e, 0x93, 0x96, 0xd8, 0xcb, 0xd7, 0x86,
+	0xe2, 0x84, 0x02, 0x89, 0x67, 0x4e, 0x71, 0x81, 0x64, 0xa4, 0x8a, 0xf2, 0x8d, 0x51, 0x68, 0xa1,
+	0x79, 0xc7, 0xd2, 0x9a, 0xb8, 0x79, 0xa7, 0x27, 0x61, 0xf2, 0xf5, 0x11, 0x58, 0x9c, 0xfe, 0x77,
+	0x60, 0x2e, 0x25, 0x07, 0x40, 0x6b, 0xe3, 0xa6, 0x2c, 0xf2, 0xad, 0x31, 0x30, 0x83, 0x6f, 0xba,
+	0xa6, 0x59, 0xe8, 0x81, 0x2e, 0xa5, 0x05, 0x24, 0x3e, 0xc5, 0xe5, 0xf4, 0x49, 0x46, 0xe4, 0xc1,
+	0x37, 0x3e, 0x7d, 0xff, 0xd8, 0xf0, 0x4e, 0xfa, 0xad, 0xf5, 0xb6, 0xd5, 0xdb, 0x70, 0x3d, 0xc7,
+	0xb2, 0x7a, 0xfc, 0xff, 0x03, 0x1b, 0x2d, 0xaf, 0xcd, 0x96, 0x6e, 0x38, 0x76, 0x7b, 0x23, 0xa0,
+	0xb2, 0x71, 0xba, 0xf9, 0x6e, 0x30, 0x68, 0x4d, 0xd3, 0xbf, 0xf5, 0xbc, 0xfd, 0xdf, 0x01, 0x00,
+	0xdb, 0x2d, 0x51, 0x46, 0xe6, 0x33, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WalletServiceClient interface {
+	// Queries
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error)
+	Accounts(ctx context.Context, in *AccountsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
+	Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+	ListLeasedOutputs(ctx context.Context, in *ListLeasedOutputsRequest, opts ...grpc.CallOption) (*ListLeasedOutputsResponse, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	RescanStatus(ctx context.Context, in *RescanStatusRequest, opts ...grpc.CallOption) (*RescanStatusResponse, error)
+	// Notifications
+	TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error)
+	SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error)
+	AccountNotifications(ctx context.Context, in *AccountNotificationsRequest, opts ...grpc.CallOption) (WalletService_AccountNotificationsClient, error)
+	ConfirmationNotifications(ctx context.Context, in *ConfirmationNotificationsRequest, opts ...grpc.CallOption) (WalletService_ConfirmationNotificationsClient, error)
+	// Control
+	ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
+	RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error)
+	NextAddress(ctx context.Context, in *NextAddressRequest, opts ...grpc.CallOption) (*NextAddressResponse, error)
+	LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error)
+	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
+	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*CreateTransactionResponse, error)
+	SendOutputs(ctx context.Context, in *SendOutputsRequest, opts ...grpc.CallOption) (*SendOutputsResponse, error)
+	SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error)
+	FundPsbt(ctx context.Context, in *FundPsbtRequest, opts ...grpc.CallOption) (*FundPsbtResponse, error)
+	FinalizePsbt(ctx context.Context, in *FinalizePsbtRequest, opts ...grpc.CallOption) (*FinalizePsbtResponse, error)
+	PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error)
+	LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error)
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error)
+	ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*ImportPrivateKeyResponse, error)
+	ImportPublicKey(ctx context.Context, in *ImportPublicKeyRequest, opts ...grpc.CallOption) (*ImportPublicKeyResponse, error)
+	ImportTaprootScript(ctx context.Context, in *ImportTaprootScriptRequest, opts ...grpc.CallOption) (*ImportTaprootScriptResponse, error)
+	Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (*RescanResponse, error)
+}
+
+type walletServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletServiceClient(cc grpc.ClientConnInterface) WalletServiceClient {
+	return &walletServiceClient{cc}
+}
+
+func (c *walletServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error) {
+	out := new(NetworkResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/Network", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Accounts(ctx context.Context, in *AccountsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
+	out := new(AccountsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/Accounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/Balance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	out := new(ListUnspentResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ListUnspent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListLeasedOutputs(ctx context.Context, in *ListLeasedOutputsRequest, opts ...grpc.CallOption) (*ListLeasedOutputsResponse, error) {
+	out := new(ListLeasedOutputsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ListLeasedOutputs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RescanStatus(ctx context.Context, in *RescanStatusRequest, opts ...grpc.CallOption) (*RescanStatusResponse, error) {
+	out := new(RescanStatusResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/RescanStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[0], "/walletrpc.v2.WalletService/TransactionNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceTransactionNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_TransactionNotificationsClient interface {
+	Recv() (*TransactionNotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceTransactionNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceTransactionNotificationsClient) Recv() (*TransactionNotificationsResponse, error) {
+	m := new(TransactionNotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[1], "/walletrpc.v2.WalletService/SpentnessNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceSpentnessNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_SpentnessNotificationsClient interface {
+	Recv() (*SpentnessNotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceSpentnessNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceSpentnessNotificationsClient) Recv() (*SpentnessNotificationsResponse, error) {
+	m := new(SpentnessNotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) AccountNotifications(ctx context.Context, in *AccountNotificationsRequest, opts ...grpc.CallOption) (WalletService_AccountNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[2], "/walletrpc.v2.WalletService/AccountNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceAccountNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_AccountNotificationsClient interface {
+	Recv() (*AccountNotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceAccountNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceAccountNotificationsClient) Recv() (*AccountNotificationsResponse, error) {
+	m := new(AccountNotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) ConfirmationNotifications(ctx context.Context, in *ConfirmationNotificationsRequest, opts ...grpc.CallOption) (WalletService_ConfirmationNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[3], "/walletrpc.v2.WalletService/ConfirmationNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceConfirmationNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_ConfirmationNotificationsClient interface {
+	Recv() (*ConfirmationNotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceConfirmationNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceConfirmationNotificationsClient) Recv() (*ConfirmationNotificationsResponse, error) {
+	m := new(ConfirmationNotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error) {
+	out := new(ChangePassphraseResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ChangePassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error) {
+	out := new(CreateAccountResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error) {
+	out := new(RenameAccountResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/RenameAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) NextAddress(ctx context.Context, in *NextAddressRequest, opts ...grpc.CallOption) (*NextAddressResponse, error) {
+	out := new(NextAddressResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/NextAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error) {
+	out := new(LeaseOutputResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/LeaseOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error) {
+	out := new(ReleaseOutputResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ReleaseOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*CreateTransactionResponse, error) {
+	out := new(CreateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/CreateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SendOutputs(ctx context.Context, in *SendOutputsRequest, opts ...grpc.CallOption) (*SendOutputsResponse, error) {
+	out := new(SendOutputsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/SendOutputs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error) {
+	out := new(SignTransactionResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/SignTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) FundPsbt(ctx context.Context, in *FundPsbtRequest, opts ...grpc.CallOption) (*FundPsbtResponse, error) {
+	out := new(FundPsbtResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/FundPsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) FinalizePsbt(ctx context.Context, in *FinalizePsbtRequest, opts ...grpc.CallOption) (*FinalizePsbtResponse, error) {
+	out := new(FinalizePsbtResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/FinalizePsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error) {
+	out := new(PublishTransactionResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/PublishTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error) {
+	out := new(LabelTransactionResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/LabelTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/SignMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error) {
+	out := new(ImportAccountResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ImportAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*ImportPrivateKeyResponse, error) {
+	out := new(ImportPrivateKeyResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ImportPrivateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportPublicKey(ctx context.Context, in *ImportPublicKeyRequest, opts ...grpc.CallOption) (*ImportPublicKeyResponse, error) {
+	out := new(ImportPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ImportPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportTaprootScript(ctx context.Context, in *ImportTaprootScriptRequest, opts ...grpc.CallOption) (*ImportTaprootScriptResponse, error) {
+	out := new(ImportTaprootScriptResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/ImportTaprootScript", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (*RescanResponse, error) {
+	out := new(RescanResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.v2.WalletService/Rescan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServiceServer is the server API for WalletService service.
+type WalletServiceServer interface {
+	// Queries
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	Network(context.Context, *NetworkRequest) (*NetworkResponse, error)
+	Accounts(context.Context, *AccountsRequest) (*AccountsResponse, error)
+	Balance(context.Context, *BalanceRequest) (*BalanceResponse, error)
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+	ListLeasedOutputs(context.Context, *ListLeasedOutputsRequest) (*ListLeasedOutputsResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	RescanStatus(context.Context, *RescanStatusRequest) (*RescanStatusResponse, error)
+	// Notifications
+	TransactionNotifications(*TransactionNotificationsRequest, WalletService_TransactionNotificationsServer) error
+	SpentnessNotifications(*SpentnessNotificationsRequest, WalletService_SpentnessNotificationsServer) error
+	AccountNotifications(*AccountNotificationsRequest, WalletService_AccountNotificationsServer) error
+	ConfirmationNotifications(*ConfirmationNotificationsRequest, WalletService_ConfirmationNotificationsServer) error
+	// Control
+	ChangePassphrase(context.Context, *ChangePassphraseRequest) (*ChangePassphraseResponse, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
+	RenameAccount(context.Context, *RenameAccountRequest) (*RenameAccountResponse, error)
+	NextAddress(context.Context, *NextAddressRequest) (*NextAddressResponse, error)
+	LeaseOutput(context.Context, *LeaseOutputRequest) (*LeaseOutputResponse, error)
+	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
+	CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error)
+	SendOutputs(context.Context, *SendOutputsRequest) (*SendOutputsResponse, error)
+	SignTransaction(context.Context, *SignTransactionRequest) (*SignTransactionResponse, error)
+	FundPsbt(context.Context, *FundPsbtRequest) (*FundPsbtResponse, error)
+	FinalizePsbt(context.Context, *FinalizePsbtRequest) (*FinalizePsbtResponse, error)
+	PublishTransaction(context.Context, *PublishTransactionRequest) (*PublishTransactionResponse, error)
+	LabelTransaction(context.Context, *LabelTransactionRequest) (*LabelTransactionResponse, error)
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	ImportAccount(context.Context, *ImportAccountRequest) (*ImportAccountResponse, error)
+	ImportPrivateKey(context.Context, *ImportPrivateKeyRequest) (*ImportPrivateKeyResponse, error)
+	ImportPublicKey(context.Context, *ImportPublicKeyRequest) (*ImportPublicKeyResponse, error)
+	ImportTaprootScript(context.Context, *ImportTaprootScriptRequest) (*ImportTaprootScriptResponse, error)
+	Rescan(context.Context, *RescanRequest) (*RescanResponse, error)
+}
+
+// UnimplementedWalletServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWalletServiceServer struct {
+}
+
+func (*UnimplementedWalletServiceServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedWalletServiceServer) Network(ctx context.Context, req *NetworkRequest) (*NetworkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Network not implemented")
+}
+func (*UnimplementedWalletServiceServer) Accounts(ctx context.Context, req *AccountsRequest) (*AccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Accounts not implemented")
+}
+func (*UnimplementedWalletServiceServer) Balance(ctx context.Context, req *BalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Balance not implemented")
+}
+func (*UnimplementedWalletServiceServer) ListUnspent(ctx context.Context, req *ListUnspentRequest) (*ListUnspentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnspent not implemented")
+}
+func (*UnimplementedWalletServiceServer) ListLeasedOutputs(ctx context.Context, req *ListLeasedOutputsRequest) (*ListLeasedOutputsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeasedOutputs not implemented")
+}
+func (*UnimplementedWalletServiceServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) RescanStatus(ctx context.Context, req *RescanStatusRequest) (*RescanStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescanStatus not implemented")
+}
+func (*UnimplementedWalletServiceServer) TransactionNotifications(req *TransactionNotificationsRequest, srv WalletService_TransactionNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TransactionNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) SpentnessNotifications(req *SpentnessNotificationsRequest, srv WalletService_SpentnessNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SpentnessNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) AccountNotifications(req *AccountNotificationsRequest, srv WalletService_AccountNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method AccountNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) ConfirmationNotifications(req *ConfirmationNotificationsRequest, srv WalletService_ConfirmationNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ConfirmationNotifications not implemented")
+}
+func (*UnimplementedWalletServiceServer) ChangePassphrase(ctx context.Context, req *ChangePassphraseRequest) (*ChangePassphraseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassphrase not implemented")
+}
+func (*UnimplementedWalletServiceServer) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*CreateAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (*UnimplementedWalletServiceServer) RenameAccount(ctx context.Context, req *RenameAccountRequest) (*RenameAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameAccount not implemented")
+}
+func (*UnimplementedWalletServiceServer) NextAddress(ctx context.Context, req *NextAddressRequest) (*NextAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextAddress not implemented")
+}
+func (*UnimplementedWalletServiceServer) LeaseOutput(ctx context.Context, req *LeaseOutputRequest) (*LeaseOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaseOutput not implemented")
+}
+func (*UnimplementedWalletServiceServer) ReleaseOutput(ctx context.Context, req *ReleaseOutputRequest) (*ReleaseOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseOutput not implemented")
+}
+func (*UnimplementedWalletServiceServer) CreateTransaction(ctx context.Context, req *CreateTransactionRequest) (*CreateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) SendOutputs(ctx context.Context, req *SendOutputsRequest) (*SendOutputsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOutputs not implemented")
+}
+func (*UnimplementedWalletServiceServer) SignTransaction(ctx context.Context, req *SignTransactionRequest) (*SignTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) FundPsbt(ctx context.Context, req *FundPsbtRequest) (*FundPsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FundPsbt not implemented")
+}
+func (*UnimplementedWalletServiceServer) FinalizePsbt(ctx context.Context, req *FinalizePsbtRequest) (*FinalizePsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizePsbt not implemented")
+}
+func (*UnimplementedWalletServiceServer) PublishTransaction(ctx context.Context, req *PublishTransactionRequest) (*PublishTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) LabelTransaction(ctx context.Context, req *LabelTransactionRequest) (*LabelTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelTransaction not implemented")
+}
+func (*UnimplementedWalletServiceServer) SignMessage(ctx context.Context, req *SignMessageRequest) (*SignMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMessage not implemented")
+}
+func (*UnimplementedWalletServiceServer) ImportAccount(ctx context.Context, req *ImportAccountRequest) (*ImportAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAccount not implemented")
+}
+func (*UnimplementedWalletServiceServer) ImportPrivateKey(ctx context.Context, req *ImportPrivateKeyRequest) (*ImportPrivateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPrivateKey not implemented")
+}
+func (*UnimplementedWalletServiceServer) ImportPublicKey(ctx context.Context, req *ImportPublicKeyRequest) (*ImportPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPublicKey not implemented")
+}
+func (*UnimplementedWalletServiceServer) ImportTaprootScript(ctx context.Context, req *ImportTaprootScriptRequest) (*ImportTaprootScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportTaprootScript not implemented")
+}
+func (*UnimplementedWalletServiceServer) Rescan(ctx context.Context, req *RescanRequest) (*RescanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rescan not implemented")
+}
+
+func RegisterWalletServiceServer(s *grpc.Server, srv WalletServiceServer) {
+	s.RegisterService(&_WalletService_serviceDesc, srv)
+}
+
+func _WalletService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Network_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Network(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/Network",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Network(ctx, req.(*NetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Accounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/Accounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Accounts(ctx, req.(*AccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Balance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/Balance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Balance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ListUnspent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListLeasedOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeasedOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListLeasedOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ListLeasedOutputs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListLeasedOutputs(ctx, req.(*ListLeasedOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RescanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RescanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/RescanStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RescanStatus(ctx, req.(*RescanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_TransactionNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransactionNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).TransactionNotifications(m, &walletServiceTransactionNotificationsServer{stream})
+}
+
+type WalletService_TransactionNotificationsServer interface {
+	Send(*TransactionNotificationsResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceTransactionNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceTransactionNotificationsServer) Send(m *TransactionNotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_SpentnessNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpentnessNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).SpentnessNotifications(m, &walletServiceSpentnessNotificationsServer{stream})
+}
+
+type WalletService_SpentnessNotificationsServer interface {
+	Send(*SpentnessNotificationsResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceSpentnessNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceSpentnessNotificationsServer) Send(m *SpentnessNotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_AccountNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AccountNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).AccountNotifications(m, &walletServiceAccountNotificationsServer{stream})
+}
+
+type WalletService_AccountNotificationsServer interface {
+	Send(*AccountNotificationsResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceAccountNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceAccountNotificationsServer) Send(m *AccountNotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_ConfirmationNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConfirmationNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).ConfirmationNotifications(m, &walletServiceConfirmationNotificationsServer{stream})
+}
+
+type WalletService_ConfirmationNotificationsServer interface {
+	Send(*ConfirmationNotificationsResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceConfirmationNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceConfirmationNotificationsServer) Send(m *ConfirmationNotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_ChangePassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePassphraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ChangePassphrase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ChangePassphrase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ChangePassphrase(ctx, req.(*ChangePassphraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RenameAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RenameAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/RenameAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RenameAccount(ctx, req.(*RenameAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_NextAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).NextAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/NextAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).NextAddress(ctx, req.(*NextAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_LeaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).LeaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/LeaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).LeaseOutput(ctx, req.(*LeaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReleaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReleaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ReleaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReleaseOutput(ctx, req.(*ReleaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/CreateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateTransaction(ctx, req.(*CreateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SendOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SendOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/SendOutputs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SendOutputs(ctx, req.(*SendOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/SignTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignTransaction(ctx, req.(*SignTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_FundPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundPsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).FundPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/FundPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).FundPsbt(ctx, req.(*FundPsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_FinalizePsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizePsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).FinalizePsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/FinalizePsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).FinalizePsbt(ctx, req.(*FinalizePsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_PublishTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).PublishTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/PublishTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).PublishTransaction(ctx, req.(*PublishTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_LabelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).LabelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/LabelTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).LabelTransaction(ctx, req.(*LabelTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ImportAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportAccount(ctx, req.(*ImportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ImportPrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportPrivateKey(ctx, req.(*ImportPrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ImportPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportPublicKey(ctx, req.(*ImportPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportTaprootScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTaprootScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportTaprootScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/ImportTaprootScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportTaprootScript(ctx, req.(*ImportTaprootScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Rescan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Rescan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.v2.WalletService/Rescan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Rescan(ctx, req.(*RescanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WalletService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "walletrpc.v2.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _WalletService_Ping_Handler,
+		},
+		{
+			MethodName: "Network",
+			Handler:    _WalletService_Network_Handler,
+		},
+		{
+			MethodName: "Accounts",
+			Handler:    _WalletService_Accounts_Handler,
+		},
+		{
+			MethodName: "Balance",
+			Handler:    _WalletService_Balance_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _WalletService_ListUnspent_Handler,
+		},
+		{
+			MethodName: "ListLeasedOutputs",
+			Handler:    _WalletService_ListLeasedOutputs_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _WalletService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "RescanStatus",
+			Handler:    _WalletService_RescanStatus_Handler,
+		},
+		{
+			MethodName: "ChangePassphrase",
+			Handler:    _WalletService_ChangePassphrase_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _WalletService_CreateAccount_Handler,
+		},
+		{
+			MethodName: "RenameAccount",
+			Handler:    _WalletService_RenameAccount_Handler,
+		},
+		{
+			MethodName: "NextAddress",
+			Handler:    _WalletService_NextAddress_Handler,
+		},
+		{
+			MethodName: "LeaseOutput",
+			Handler:    _WalletService_LeaseOutput_Handler,
+		},
+		{
+			MethodName: "ReleaseOutput",
+			Handler:    _WalletService_ReleaseOutput_Handler,
+		},
+		{
+			MethodName: "CreateTransaction",
+			Handler:    _WalletService_CreateTransaction_Handler,
+		},
+		{
+			MethodName: "SendOutputs",
+			Handler:    _WalletService_SendOutputs_Handler,
+		},
+		{
+			MethodName: "SignTransaction",
+			Handler:    _WalletService_SignTransaction_Handler,
+		},
+		{
+			MethodName: "FundPsbt",
+			Handler:    _WalletService_FundPsbt_Handler,
+		},
+		{
+			MethodName: "FinalizePsbt",
+			Handler:    _WalletService_FinalizePsbt_Handler,
+		},
+		{
+			MethodName: "PublishTransaction",
+			Handler:    _WalletService_PublishTransaction_Handler,
+		},
+		{
+			MethodName: "LabelTransaction",
+			Handler:    _WalletService_LabelTransaction_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _WalletService_SignMessage_Handler,
+		},
+		{
+			MethodName: "ImportAccount",
+			Handler:    _WalletService_ImportAccount_Handler,
+		},
+		{
+			MethodName: "ImportPrivateKey",
+			Handler:    _WalletService_ImportPrivateKey_Handler,
+		},
+		{
+			MethodName: "ImportPublicKey",
+			Handler:    _WalletService_ImportPublicKey_Handler,
+		},
+		{
+			MethodName: "ImportTaprootScript",
+			Handler:    _WalletService_ImportTaprootScript_Handler,
+		},
+		{
+			MethodName: "Rescan",
+			Handler:    _WalletService_Rescan_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TransactionNotifications",
+			Handler:       _WalletService_TransactionNotifications_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SpentnessNotifications",
+			Handler:       _WalletService_SpentnessNotifications_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AccountNotifications",
+			Handler:       _WalletService_AccountNotifications_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ConfirmationNotifications",
+			Handler:       _WalletService_ConfirmationNotifications_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v2/api.proto",
+}
@@ -0,0 +1,92 @@
+package run
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+
+	"github.com/stroomnetwork/btcwallet/backup"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+// backupConfig builds the configuration of the backup schedulers of the
+// wallets from the loaded config.  A nil config is returned when no backup
+// directory is configured.  The database of each scheduler is set when its
+// wallet is loaded.
+func backupConfig(cfg *Config) (*backup.Config, error) {
+	if cfg.BackupDir == "" {
+		return nil, nil
+	}
+	if cfg.BackupPass == "" {
+		return nil, errors.New("backuppass must be set when backupdir " +
+			"is used")
+	}
+
+	return &backup.Config{
+		Dir:         cfg.BackupDir,
+		Passphrase:  []byte(cfg.BackupPass),
+		Interval:    cfg.BackupInterval,
+		Generations: cfg.BackupGenerations,
+	}, nil
+}
+
+// startBackups backs up every wallet while it is loaded, until the runtime is
+// stopped.  The backups of the default wallet are written to the backup
+// directory, and those of a named wallet to the subdirectory of its name, as
+// in the wallets directory.
+func (r *Runtime) startBackups(backupCfg *backup.Config) {
+	var (
+		schedulers = make(map[string]*backup.Scheduler)
+		mu         sync.Mutex
+		stopped    bool
+	)
+	r.wallets.RunAfterLoad(func(name string, w *wallet.Wallet) {
+		c := *backupCfg
+		c.DB = w.Database()
+		if name != wallet.DefaultWalletName {
+			c.Dir = filepath.Join(c.Dir, wallet.WalletsDirName, name)
+		}
+		s, err := backup.New(&c)
+		if err != nil {
+			r.log.Errorf("Unable to back up wallet %q: %v", name, err)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+		schedulers[name] = s
+		s.Start()
+		r.log.Infof("Backing up wallet %q to %s every %v", name, c.Dir,
+			c.Interval)
+
+		// The backups stop once the wallet is unloaded.
+		go func() {
+			<-w.ShutdownChan()
+			s.Stop()
+
+			mu.Lock()
+			if schedulers[name] == s {
+				delete(schedulers, name)
+			}
+			mu.Unlock()
+		}()
+	})
+
+	// The backups are stopped before the wallets are unloaded.
+	r.onStop(func() {
+		mu.Lock()
+		stopped = true
+		stopping := make([]*backup.Scheduler, 0, len(schedulers))
+		for _, s := range schedulers {
+			stopping = append(stopping, s)
+		}
+		mu.Unlock()
+
+		for _, s := range stopping {
+			s.Stop()
+		}
+	})
+}
@@ -7,9 +7,6 @@ package run
 import (
 	"context"
 	"fmt"
-	"net"
-	"net/http"
-	_ "net/http/pprof" // nolint:gosec
 	"os"
 	"path/filepath"
 	"strings"
@@ -23,10 +20,6 @@ import (
 	"github.com/stroomnetwork/btcwallet/wallet"
 )
 
-var (
-	cfg *Config
-)
-
 type BtcwalletConfig struct {
 	BitcoindConfig *chain.BitcoindConfig
 	Config         *Config
@@ -70,7 +63,7 @@ func InitWallet(config *BtcwalletConfig) (*wallet.Wallet, error) {
 		return nil, err
 	}
 	config.Config = tcfg
-	return doInit(config)
+	return initRuntime(config)
 }
 
 // InitWalletWithConfig creates a new instance of the wallet with provided config
@@ -81,118 +74,49 @@ func InitWalletWithConfig(config *BtcwalletConfig) (*wallet.Wallet, error) {
 		return nil, err
 	}
 
-	return doInit(config)
+	return initRuntime(config)
 }
 
-func doInit(config *BtcwalletConfig) (*wallet.Wallet, error) {
+// initRuntime starts a runtime of the loaded config, which is stopped on an
+// interrupt signal of the process or a shutdown request, and waits for its
+// wallet to be ready.  The wallet is nil if the config defers loading it.
+func initRuntime(config *BtcwalletConfig) (*wallet.Wallet, error) {
 	err := checkConfigForNil(config)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg = config.Config
-
-	defer func() {
-		if logRotator != nil {
-			logRotator.Close()
-		}
-	}()
-
-	// Show version at startup.
-	log.Infof("Version %s", version())
-
-	if cfg.Profile != "" {
-		go func() {
-			listenAddr := net.JoinHostPort("", cfg.Profile)
-			log.Infof("Profile server listening on %s", listenAddr)
-			profileRedirect := http.RedirectHandler("/debug/pprof",
-				http.StatusSeeOther)
-			http.Handle("/", profileRedirect)
-			log.Errorf("%v", http.ListenAndServe(listenAddr, nil))
-		}()
-	}
-
-	dbDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
-	loader := wallet.NewLoader(
-		activeNet.Params, dbDir, true, cfg.DBTimeout, 250,
-	)
+	ctx, cancel := context.WithTimeout(context.Background(),
+		config.InitTimeout)
+	defer cancel()
 
-	// Create and start HTTP server to serve wallet client connections.
-	// This will be updated with the wallet and chain server RPC client
-	// created below after each is created.
-	rpcs, legacyRPCServer, err := startRPCServers(loader)
-	if err != nil {
-		log.Errorf("Unable to create RPC servers: %v", err)
+	r := newRuntime(config)
+	if err := r.Start(ctx); err != nil {
 		return nil, err
 	}
-
-	// Create and start chain RPC client so it's ready to connect to
-	// the wallet when loaded later.
-	if !cfg.NoInitialLoad {
-		go rpcClientConnectLoop(legacyRPCServer, loader, config.BitcoindConfig)
-	}
-
-	loader.RunAfterLoad(func(w *wallet.Wallet) {
-		startWalletRPCServices(w, rpcs, legacyRPCServer)
-	})
-
-	var w *wallet.Wallet
-	if !cfg.NoInitialLoad {
-		// Load the wallet database.  It must have been created already
-		// or this will return an appropriate error.
-		w, err = loader.OpenExistingWallet([]byte(cfg.WalletPass), cfg.CanConsolePrompt)
-		if err != nil {
-			log.Error(err)
-			return nil, err
-		}
-
-		err = waitForChainClientInitialized(w, config.InitTimeout)
-		if err != nil {
-			log.Error(err)
-			return nil, err
-		}
-
-		w.FeeCoefficient = config.FeeCoefficient
-
-		if config.RescanStartBlock != 0 {
-			rescanStartStamp, err := w.GetBlockStamp(config.RescanStartBlock)
-			if err != nil {
-				return nil, fmt.Errorf("cannot get rescan block stamp: %w", err)
-			}
-			w.RescanStartStamp = rescanStartStamp
+	addInterruptHandler(r.Stop)
+	go func() {
+		select {
+		case <-r.ShutdownRequested():
+			simulateInterrupt()
+		case <-r.quit:
 		}
-	}
+	}()
 
-	// Add interrupt handlers to shut down the various process components
-	// before exiting.  Interrupt handlers run in LIFO order, so the wallet
-	// (which should be closed last) is added first.
-	addInterruptHandler(func() {
-		err := loader.UnloadWallet()
-		if err != nil && err != wallet.ErrNotLoaded {
-			log.Errorf("Failed to close wallet: %v", err)
-		}
-	})
-	if rpcs != nil {
-		addInterruptHandler(func() {
-			// TODO: Does this need to wait for the grpc server to
-			// finish up any requests?
-			log.Warn("Stopping RPC server...")
-			rpcs.Stop()
-			log.Info("RPC server shutdown")
-		})
+	if config.Config.NoInitialLoad {
+		return nil, nil
 	}
-	if legacyRPCServer != nil {
-		addInterruptHandler(func() {
-			log.Warn("Stopping legacy RPC server...")
-			legacyRPCServer.Stop()
-			log.Info("Legacy RPC server shutdown")
-		})
-		go func() {
-			<-legacyRPCServer.RequestProcessShutdown()
-			simulateInterrupt()
-		}()
+	select {
+	case <-r.Ready():
+		return r.Wallet(), nil
+	case <-r.ShutdownRequested():
+		r.Stop()
+		return nil, ErrRuntimeStopped
+	case <-ctx.Done():
+		r.Stop()
+		return nil, fmt.Errorf("timeout waiting for chainClient to " +
+			"initialize")
 	}
-	return w, nil
 }
 
 func checkConfigForNil(config *BtcwalletConfig) error {
@@ -209,37 +133,23 @@ func checkConfigForNil(config *BtcwalletConfig) error {
 	return nil
 }
 
-func waitForChainClientInitialized(wallet *wallet.Wallet, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	ticker := time.NewTicker(100 * time.Millisecond)
-
-	for {
-		select {
-		case <-ticker.C:
-			if wallet.ChainClient() != nil {
-				return nil
-			}
-		case <-ctx.Done():
-			if wallet.ChainClient() == nil {
-				return fmt.Errorf("timeout waiting for chainClient to initialize")
-			}
-		}
-	}
-}
-
 // rpcClientConnectLoop continuously attempts a connection to the consensus RPC
 // server.  When a connection is established, the client is used to sync the
 // loaded wallet, either immediately or when loaded at a later time.
 //
 // The legacy RPC is optional.  If set, the connected RPC client will be
 // associated with the server for RPC passthrough and to enable additional
-// methods.
-func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Loader, bitcoindConfig *chain.BitcoindConfig) {
+// methods.  The SPV chain data, if used, is kept in the data directory.  The
+// optional synced function is called each time the wallet is associated with
+// a client.  The loop exits when the runtime is stopped.
+func (r *Runtime) rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server,
+	loader *wallet.Loader, netDir string, synced func(*wallet.Wallet)) {
+
+	cfg := r.cfg
+
 	var certs []byte
 	if !cfg.UseSPV {
-		certs = readCAFile()
+		certs = r.readCAFile()
 	}
 
 	for {
@@ -248,18 +158,23 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 			err         error
 		)
 
+		select {
+		case <-r.quit:
+			return
+		default:
+		}
+
 		if cfg.UseSPV {
 			var (
 				chainService *neutrino.ChainService
 				spvdb        walletdb.DB
 			)
-			netDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
 			spvdb, err = walletdb.Create(
 				"bdb", filepath.Join(netDir, "neutrino.db"),
 				true, cfg.DBTimeout,
 			)
 			if err != nil {
-				log.Errorf("Unable to create Neutrino DB: %s", err)
+				r.log.Errorf("Unable to create Neutrino DB: %s", err)
 				continue
 			}
 			defer spvdb.Close()
@@ -267,28 +182,38 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 				neutrino.Config{
 					DataDir:      netDir,
 					Database:     spvdb,
-					ChainParams:  *activeNet.Params,
+					ChainParams:  *cfg.activeNet.Params,
 					ConnectPeers: cfg.ConnectPeers,
 					AddPeers:     cfg.AddPeers,
 				})
 			if err != nil {
-				log.Errorf("Couldn't create Neutrino ChainService: %s", err)
+				r.log.Errorf("Couldn't create Neutrino ChainService: %s", err)
 				continue
 			}
-			chainClient = chain.NewNeutrinoClient(activeNet.Params, chainService)
+			chainClient = chain.NewNeutrinoClient(cfg.activeNet.Params, chainService)
 			err = chainClient.Start()
 			if err != nil {
-				log.Errorf("Couldn't start Neutrino client: %s", err)
+				r.log.Errorf("Couldn't start Neutrino client: %s", err)
 			}
 		} else {
-			if bitcoindConfig != nil {
-				bitcoindConfig.ChainParams = activeNet.Params
-				chainClient, err = chain.SetupBitcoind(bitcoindConfig)
+			if r.bitcoindConfig != nil {
+				// The config is copied, as it is shared by
+				// the connections of all wallets.
+				bitcoindConfig := *r.bitcoindConfig
+				bitcoindConfig.ChainParams = cfg.activeNet.Params
+				if bitcoindConfig.BlockPrefetch == (chain.BlockPrefetchConfig{}) {
+					bitcoindConfig.BlockPrefetch = chain.BlockPrefetchConfig{
+						Workers:            cfg.RescanWorkers,
+						BatchSize:          cfg.RescanBatchSize,
+						MaxBlocksPerSecond: cfg.RescanMaxBlockRate,
+					}
+				}
+				chainClient, err = chain.SetupBitcoind(&bitcoindConfig)
 			} else {
-				chainClient, err = startChainRPC(certs)
+				chainClient, err = r.startChainRPC(certs)
 			}
 			if err != nil {
-				log.Errorf("Unable to open connection to consensus RPC server: %v", err)
+				r.log.Errorf("Unable to open connection to consensus RPC server: %v", err)
 				continue
 			}
 		}
@@ -304,6 +229,9 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 			if legacyRPCServer != nil {
 				legacyRPCServer.SetChainServer(chainClient)
 			}
+			if synced != nil {
+				synced(w)
+			}
 		}
 		mu := new(sync.Mutex)
 		loader.RunAfterLoad(func(w *wallet.Wallet) {
@@ -315,7 +243,18 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 			}
 		})
 
-		chainClient.WaitForShutdown()
+		shutdown := make(chan struct{})
+		go func() {
+			chainClient.WaitForShutdown()
+			close(shutdown)
+		}()
+		select {
+		case <-shutdown:
+		case <-r.quit:
+			chainClient.Stop()
+			<-shutdown
+			return
+		}
 
 		mu.Lock()
 		associateRPCClient = nil
@@ -340,33 +279,33 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 	}
 }
 
-func readCAFile() []byte {
+func (r *Runtime) readCAFile() []byte {
 	// Read certificate file if TLS is not disabled.
 	var certs []byte
-	if !cfg.DisableClientTLS {
+	if !r.cfg.DisableClientTLS {
 		var err error
-		certs, err = os.ReadFile(cfg.CAFile.Value)
+		certs, err = os.ReadFile(r.cfg.CAFile.Value)
 		if err != nil {
-			log.Warnf("Cannot open CA file: %v", err)
+			r.log.Warnf("Cannot open CA file: %v", err)
 			// If there's an error reading the CA file, continue
 			// with nil certs and without the client connection.
 			certs = nil
 		}
 	} else {
-		log.Info("Chain server RPC TLS is disabled")
+		r.log.Info("Chain server RPC TLS is disabled")
 	}
 
 	return certs
 }
 
 // startChainRPC opens a RPC client connection to a btcd server for blockchain
-// services.  This function uses the RPC options from the global config and
+// services.  This function uses the RPC options from the runtime config and
 // there is no recovery in case the server is not available or if there is an
 // authentication error.  Instead, all requests to the client will simply error.
-func startChainRPC(certs []byte) (*chain.RPCClient, error) {
-	log.Infof("Attempting RPC client connection to %v", cfg.RPCConnect)
-	rpcc, err := chain.NewRPCClient(activeNet.Params, cfg.RPCConnect,
-		cfg.BtcdUsername, cfg.BtcdPassword, certs, cfg.DisableClientTLS, 0)
+func (r *Runtime) startChainRPC(certs []byte) (*chain.RPCClient, error) {
+	r.log.Infof("Attempting RPC client connection to %v", r.cfg.RPCConnect)
+	rpcc, err := chain.NewRPCClient(r.cfg.activeNet.Params, r.cfg.RPCConnect,
+		r.cfg.BtcdUsername, r.cfg.BtcdPassword, certs, r.cfg.DisableClientTLS, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -20,9 +20,16 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightninglabs/neutrino"
+	"github.com/stroomnetwork/btcwallet/backup"
+	"github.com/stroomnetwork/btcwallet/bip39"
 	"github.com/stroomnetwork/btcwallet/cfgutil"
+	"github.com/stroomnetwork/btcwallet/chain"
 	"github.com/stroomnetwork/btcwallet/netparams"
+	"github.com/stroomnetwork/btcwallet/slip39"
+	"github.com/stroomnetwork/btcwallet/snacl"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
 	"github.com/stroomnetwork/btcwallet/wallet"
+	"github.com/stroomnetwork/btcwallet/webhook"
 )
 
 const (
@@ -33,6 +40,9 @@ const (
 	defaultLogFilename      = "btcwallet.log"
 	defaultRPCMaxClients    = 10
 	defaultRPCMaxWebsockets = 25
+	defaultMaxReorgDepth    = 6
+	defaultMetricsPort      = "9332"
+	defaultTraceSampleRate  = 1.0
 )
 
 var (
@@ -63,14 +73,33 @@ type Config struct {
 	LogDir          string                  `long:"logdir" description:"Directory to log output."`
 	Profile         string                  `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	DBTimeout       time.Duration           `long:"dbtimeout" description:"The timeout value to use when opening the wallet database."`
+	DBBackend       string                  `long:"dbbackend" description:"Database backend of the wallets {bdb, sqlite, postgres}"`
+	DBPostgresDSN   string                  `long:"dbpostgresdsn" default-mask:"-" description:"Connection string of the PostgreSQL database of the wallet -- Required with the postgres dbbackend"`
 
 	// Feature flags used for fallback to the original implementation
 	CanConsolePrompt bool `long:"canconsoleprompt" description:"Enable interaction with Stdin, wallet options are obtained from the configuration provided otherwise"`
 
 	// Wallet options
-	WalletPrivatePass string `long:"walletprivatepass" default-mask:"-" description:"The private wallet passphrase"`
-	WalletPass        string `long:"walletpass" default-mask:"-" description:"The public wallet passphrase -- Only required if the wallet was created with one"`
-	BirthdayTimestamp int64  `long:"birthdaytimestamp" description:"Wallet birthday timestamp in seconds, default time.now()"`
+	WalletPrivatePass string   `long:"walletprivatepass" default-mask:"-" description:"The private wallet passphrase"`
+	WalletPass        string   `long:"walletpass" default-mask:"-" description:"The public wallet passphrase -- Only required if the wallet was created with one"`
+	BirthdayTimestamp int64    `long:"birthdaytimestamp" description:"Wallet birthday timestamp in seconds, default time.now()"`
+	MaxReorgDepth     int32    `long:"maxreorgdepth" description:"Halt sending and publishing transactions after a chain reorganization replaces more than this many blocks, also while the wallet was offline, until cleared with clearreorghalt -- 0 disables the check"`
+	Wallets           []string `long:"wallet" description:"Load the named wallet from the wallets directory at startup, in addition to the default wallet -- Can be specified multiple times"`
+	Argon2Time        uint32   `long:"argon2time" description:"Number of Argon2id passes over the memory when deriving the key of the private passphrase of a new wallet or of a changed passphrase"`
+	Argon2Memory      uint32   `long:"argon2memory" description:"Memory in KiB used by Argon2id when deriving the key of the private passphrase"`
+	Argon2Threads     uint8    `long:"argon2threads" description:"Number of threads used by Argon2id when deriving the key of the private passphrase"`
+
+	// Mnemonic options
+	MnemonicWords int    `long:"mnemonicwords" description:"Generate the seed of a new wallet as a BIP39 mnemonic of this many words, 12 to 24, when prompting on the console -- 0 generates a hexadecimal seed"`
+	Mnemonic      string `long:"mnemonic" default-mask:"-" description:"Create the wallet from the seed of this BIP39 mnemonic"`
+	MnemonicPass  string `long:"mnemonicpass" default-mask:"-" description:"The BIP39 passphrase of the mnemonic -- Only required if the mnemonic was created with one"`
+
+	// Seed share options
+	SeedShareGroups         []string `long:"seedsharegroup" description:"Split the seed of a new wallet into SLIP-39 shares, with a group of member shares in the form threshold-of-count, e.g. 2of3 -- Can be specified multiple times"`
+	SeedShareGroupThreshold int      `long:"seedsharegroupthreshold" description:"Number of share groups needed to restore the seed of a new wallet"`
+	SeedShareDir            string   `long:"seedsharedir" description:"Write the SLIP-39 shares of the seed of a new wallet to this directory, one file per share"`
+	SeedSharePass           string   `long:"seedsharepass" default-mask:"-" description:"Passphrase the seed is encrypted with in its SLIP-39 shares"`
+	SeedShares              []string `long:"seedshare" default-mask:"-" description:"Create the wallet from the seed restored from this SLIP-39 share -- Can be specified multiple times"`
 
 	// RPC client options
 	RPCConnect       string                  `short:"c" long:"rpcconnect" description:"Hostname/IP and port of btcd RPC server to connect to (default localhost:8334, testnet: localhost:18334, simnet: localhost:18556)"`
@@ -82,6 +111,11 @@ type Config struct {
 	ProxyUser        string                  `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass        string                  `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
 
+	// Rescan options
+	RescanWorkers      int `long:"rescanworkers" description:"Number of batches of blocks fetched concurrently from bitcoind during rescans and recovery"`
+	RescanBatchSize    int `long:"rescanbatchsize" description:"Number of blocks requested from bitcoind in a single batch during rescans and recovery"`
+	RescanMaxBlockRate int `long:"rescanmaxblockrate" description:"Maximum number of blocks per second requested from bitcoind during rescans and recovery -- 0 is unlimited"`
+
 	// SPV client options
 	UseSPV       bool          `long:"usespv" description:"Enables the experimental use of SPV rather than RPC for chain synchronization"`
 	AddPeers     []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
@@ -107,15 +141,78 @@ type Config struct {
 	LegacyRPCMaxWebsockets int64                   `long:"rpcmaxwebsockets" description:"Max number of legacy RPC websocket connections"`
 	Username               string                  `short:"u" long:"username" description:"Username for legacy RPC and btcd authentication (if btcdusername is unset)"`
 	Password               string                  `short:"P" long:"password" default-mask:"-" description:"Password for legacy RPC and btcd authentication (if btcdpassword is unset)"`
+	Macaroons              bool                    `long:"macaroons" description:"Enable authentication of RPC clients with macaroons, which is required by the gRPC server"`
+	MacaroonDir            string                  `long:"macaroondir" description:"Directory of the macaroon root key and the default admin and read-only macaroons (default: the network directory)"`
+	RPCRateLimits          []string                `long:"rpcratelimit" description:"Rate limit of the requests of each credential to an RPC method in the form method:requests-per-second[:burst] -- The method * applies to methods without a rate limit -- Can be specified multiple times"`
+	AuditLog               string                  `long:"auditlog" description:"Append state-changing RPC requests to this tamper-evident audit log"`
+	AuditLogKey            string                  `long:"auditlogkey" description:"File of the secret key of the audit log hashes, created if it doesn't exist -- Keep it apart from the audit log (default: auditlog.key in the network directory)"`
 
 	// EXPERIMENTAL RPC server options
 	//
 	// These options will change (and require changes to config files, etc.)
 	// when the new gRPC server is enabled.
 	ExperimentalRPCListeners []string `long:"experimentalrpclisten" description:"Listen for RPC connections on this interface/port"`
+	RESTListeners            []string `long:"restlisten" description:"Listen for connections to the REST/JSON gateway of the RPC server on this interface/port"`
+
+	// Webhook options
+	WebhookURLs          []string `long:"webhookurl" description:"POST deposit, confirmation and reorg events to this URL -- Can be specified multiple times"`
+	WebhookSecret        string   `long:"webhooksecret" default-mask:"-" description:"Secret used to sign webhook payloads with HMAC-SHA256"`
+	WebhookConfirmations int32    `long:"webhookconfs" description:"Number of confirmations after which a deposit is reported as confirmed"`
+	WebhookAccountConfs  []string `long:"webhookaccountconfs" description:"Confirmation threshold for a single account in the form account:confirmations -- Can be specified multiple times"`
+	WebhookWatchAddrs    []string `long:"webhookwatchaddr" description:"Only report deposits to this address instead of all wallet addresses -- Can be specified multiple times"`
+	WebhookMaxAttempts   int      `long:"webhookmaxattempts" description:"Number of delivery attempts before an event is moved to the dead-letter store"`
+
+	// Backup options
+	BackupDir         string        `long:"backupdir" description:"Write encrypted backups of the wallet databases to this directory"`
+	BackupPass        string        `long:"backuppass" default-mask:"-" description:"Passphrase the key of the backups is derived from"`
+	BackupInterval    time.Duration `long:"backupinterval" description:"Time between two backups of a wallet database.  Valid time units are {s, m, h}"`
+	BackupGenerations int           `long:"backupgenerations" description:"Number of most recent backups kept for each wallet"`
+
+	// Metrics options
+	MetricsListeners []string `long:"metricslisten" description:"Serve Prometheus metrics at /metrics and the liveness and readiness probes at /healthz and /readyz over HTTP on this interface/port (default port: 9332) -- Can be specified multiple times"`
+
+	// Tracing options
+	OTLPEndpoint    string   `long:"otlpendpoint" description:"Export OpenTelemetry traces of the RPC requests to the OTLP/gRPC collector at this host:port"`
+	OTLPInsecure    bool     `long:"otlpinsecure" description:"Connect to the OTLP collector without TLS"`
+	OTLPHeaders     []string `long:"otlpheader" default-mask:"-" description:"Header sent to the OTLP collector with the exported traces in the form key=value -- Can be specified multiple times"`
+	TraceSampleRate float64  `long:"tracesamplerate" description:"Fraction of the traces started by btcwallet that are exported; traces started by RPC clients are exported if the client sampled them"`
 
 	// Deprecated options
 	DataDir *cfgutil.ExplicitString `short:"b" long:"datadir" default-mask:"-" description:"DEPRECATED -- use appdata instead"`
+
+	// activeNet holds the parameters of the selected network once the
+	// config is loaded.
+	activeNet *netparams.Params
+
+	// seedShareGroups holds the parsed seed share groups.
+	seedShareGroups []slip39.Group
+}
+
+// ActiveNet returns the parameters of the network selected by the config.
+// Before the config is loaded, this returns the mainnet parameters.
+func (c *Config) ActiveNet() *netparams.Params {
+	if c.activeNet == nil {
+		return &netparams.MainNetParams
+	}
+	return c.activeNet
+}
+
+// dbOptions returns the loader options of the database backend of the
+// config.
+func (c *Config) dbOptions() []wallet.LoaderOption {
+	return []wallet.LoaderOption{
+		wallet.WithDBBackend(c.DBBackend),
+		wallet.WithPostgresDSN(c.DBPostgresDSN),
+	}
+}
+
+// argon2Options returns the Argon2id parameters of the config.
+func (c *Config) argon2Options() waddrmgr.Argon2Options {
+	return waddrmgr.Argon2Options{
+		Time:    c.Argon2Time,
+		Memory:  c.Argon2Memory,
+		Threads: c.Argon2Threads,
+	}
 }
 
 // cleanAndExpandPath expands environement variables and leading ~ in the
@@ -253,25 +350,38 @@ func parseAndSetDebugLevels(debugLevel string) error {
 
 func DefaultConfig() *Config {
 	return &Config{
-		DebugLevel:             defaultLogLevel,
-		ConfigFile:             cfgutil.NewExplicitString(defaultConfigFile),
-		AppDataDir:             cfgutil.NewExplicitString(defaultAppDataDir),
-		LogDir:                 defaultLogDir,
-		CanConsolePrompt:       true,
-		WalletPass:             wallet.InsecurePubPassphrase,
-		CAFile:                 cfgutil.NewExplicitString(""),
-		RPCKey:                 cfgutil.NewExplicitString(defaultRPCKeyFile),
-		RPCCert:                cfgutil.NewExplicitString(defaultRPCCertFile),
-		LegacyRPCMaxClients:    defaultRPCMaxClients,
-		LegacyRPCMaxWebsockets: defaultRPCMaxWebsockets,
-		DataDir:                cfgutil.NewExplicitString(defaultAppDataDir),
-		UseSPV:                 false,
-		AddPeers:               []string{},
-		ConnectPeers:           []string{},
-		MaxPeers:               neutrino.MaxPeers,
-		BanDuration:            neutrino.BanDuration,
-		BanThreshold:           neutrino.BanThreshold,
-		DBTimeout:              wallet.DefaultDBTimeout,
+		DebugLevel:              defaultLogLevel,
+		ConfigFile:              cfgutil.NewExplicitString(defaultConfigFile),
+		AppDataDir:              cfgutil.NewExplicitString(defaultAppDataDir),
+		LogDir:                  defaultLogDir,
+		CanConsolePrompt:        true,
+		WalletPass:              wallet.InsecurePubPassphrase,
+		CAFile:                  cfgutil.NewExplicitString(""),
+		RPCKey:                  cfgutil.NewExplicitString(defaultRPCKeyFile),
+		RPCCert:                 cfgutil.NewExplicitString(defaultRPCCertFile),
+		LegacyRPCMaxClients:     defaultRPCMaxClients,
+		LegacyRPCMaxWebsockets:  defaultRPCMaxWebsockets,
+		DataDir:                 cfgutil.NewExplicitString(defaultAppDataDir),
+		UseSPV:                  false,
+		AddPeers:                []string{},
+		ConnectPeers:            []string{},
+		MaxPeers:                neutrino.MaxPeers,
+		BanDuration:             neutrino.BanDuration,
+		BanThreshold:            neutrino.BanThreshold,
+		DBTimeout:               wallet.DefaultDBTimeout,
+		DBBackend:               wallet.DBBackendBolt,
+		MaxReorgDepth:           defaultMaxReorgDepth,
+		Argon2Time:              snacl.DefaultArgon2Time,
+		Argon2Memory:            snacl.DefaultArgon2Memory,
+		Argon2Threads:           snacl.DefaultArgon2Threads,
+		RescanWorkers:           chain.DefaultBlockPrefetchWorkers,
+		RescanBatchSize:         chain.DefaultBlockPrefetchBatchSize,
+		WebhookConfirmations:    webhook.DefaultConfirmations,
+		WebhookMaxAttempts:      webhook.DefaultMaxAttempts,
+		TraceSampleRate:         defaultTraceSampleRate,
+		BackupInterval:          backup.DefaultInterval,
+		BackupGenerations:       backup.DefaultGenerations,
+		SeedShareGroupThreshold: 1,
 	}
 }
 
@@ -388,6 +498,7 @@ func loadConfig(cfg *Config) error {
 
 	// Choose the active network params based on the selected network.
 	// Multiple networks can't be selected simultaneously.
+	activeNet := &netparams.MainNetParams
 	numNets := 0
 	if cfg.Regtest {
 		activeNet = &netparams.RegtestParams
@@ -435,16 +546,21 @@ func loadConfig(cfg *Config) error {
 			}
 		}
 
+		// The custom parameters are kept in a copy, so the configs of
+		// several runtimes may select different signets.
 		chainParams := chaincfg.CustomSignetParams(
 			sigNetChallenge, sigNetSeeds,
 		)
-		activeNet.Params = &chainParams
+		sigNet := netparams.SigNetParams
+		sigNet.Params = &chainParams
+		activeNet = &sigNet
 	}
 	if numNets > 1 {
 		str := "%s: The testnet, signet and simnet params can't be " +
 			"used together -- choose one"
 		return fmt.Errorf(str, funcName)
 	}
+	cfg.activeNet = activeNet
 
 	// Append the network type to the log directory so it is "namespaced"
 	// per network.
@@ -456,10 +572,6 @@ func loadConfig(cfg *Config) error {
 		return fmt.Errorf("Supported subsystems: %s", supportedSubsystems())
 	}
 
-	// Initialize log rotation.  After log rotation has been initialized, the
-	// logger variables may be used.
-	initLogRotator(filepath.Join(cfg.LogDir, defaultLogFilename))
-
 	// Parse, validate, and set debug log level(s).
 	if err := parseAndSetDebugLevels(cfg.DebugLevel); err != nil {
 		return fmt.Errorf("%s: %w", funcName, err)
@@ -479,11 +591,112 @@ func loadConfig(cfg *Config) error {
 			"wallet for network other than simnet!")
 	}
 
+	if cfg.MaxReorgDepth < 0 {
+		return fmt.Errorf("%s: maxreorgdepth may not be negative",
+			funcName)
+	}
+
+	switch cfg.DBBackend {
+	case wallet.DBBackendBolt, wallet.DBBackendSQLite:
+	case wallet.DBBackendPostgres:
+		if cfg.DBPostgresDSN == "" {
+			return fmt.Errorf("%s: dbpostgresdsn is required with "+
+				"the %s dbbackend", funcName, cfg.DBBackend)
+		}
+		if len(cfg.Wallets) != 0 {
+			return fmt.Errorf("%s: named wallets are not "+
+				"supported by the %s dbbackend", funcName,
+				cfg.DBBackend)
+		}
+	default:
+		return fmt.Errorf("%s: unknown dbbackend %q", funcName,
+			cfg.DBBackend)
+	}
+
+	// Argon2id needs at least 8 KiB of memory per thread.
+	if cfg.Argon2Time == 0 || cfg.Argon2Threads == 0 {
+		return fmt.Errorf("%s: argon2time and argon2threads must be "+
+			"positive", funcName)
+	}
+	if cfg.Argon2Memory < 8*uint32(cfg.Argon2Threads) {
+		return fmt.Errorf("%s: argon2memory must be at least 8 KiB "+
+			"per thread", funcName)
+	}
+
+	for _, name := range cfg.Wallets {
+		if name == wallet.DefaultWalletName ||
+			!wallet.ValidWalletName(name) {
+
+			return fmt.Errorf("%s: invalid wallet name %q", funcName,
+				name)
+		}
+	}
+
+	if cfg.RescanWorkers < 1 {
+		return fmt.Errorf("%s: rescanworkers must be positive",
+			funcName)
+	}
+	if cfg.RescanBatchSize < 1 {
+		return fmt.Errorf("%s: rescanbatchsize must be positive",
+			funcName)
+	}
+	if cfg.RescanMaxBlockRate < 0 {
+		return fmt.Errorf("%s: rescanmaxblockrate may not be negative",
+			funcName)
+	}
+
+	if cfg.MnemonicWords != 0 && (cfg.MnemonicWords < 12 ||
+		cfg.MnemonicWords > 24 || cfg.MnemonicWords%3 != 0) {
+
+		return fmt.Errorf("%s: mnemonicwords must be 12, 15, 18, 21 "+
+			"or 24", funcName)
+	}
+	if cfg.Mnemonic != "" {
+		if err := bip39.Validate(cfg.Mnemonic); err != nil {
+			return fmt.Errorf("%s: invalid mnemonic: %w", funcName,
+				err)
+		}
+		if len(cfg.SeedShares) > 0 {
+			return fmt.Errorf("%s: the mnemonic and seedshare "+
+				"options may not be used together", funcName)
+		}
+	}
+
+	for _, g := range cfg.SeedShareGroups {
+		group, err := slip39.ParseGroup(g)
+		if err != nil {
+			return fmt.Errorf("%s: invalid seedsharegroup: %w",
+				funcName, err)
+		}
+		cfg.seedShareGroups = append(cfg.seedShareGroups, group)
+	}
+	if len(cfg.seedShareGroups) > 0 {
+		if cfg.SeedShareDir == "" {
+			return fmt.Errorf("%s: the seedsharegroup option "+
+				"requires seedsharedir", funcName)
+		}
+		if cfg.SeedShareGroupThreshold < 1 ||
+			cfg.SeedShareGroupThreshold > len(cfg.seedShareGroups) {
+
+			return fmt.Errorf("%s: seedsharegroupthreshold must "+
+				"be between 1 and the number of share groups",
+				funcName)
+		}
+		cfg.SeedShareDir = cleanAndExpandPath(cfg.SeedShareDir)
+	}
+	for _, share := range cfg.SeedShares {
+		if err := slip39.Validate(share); err != nil {
+			return fmt.Errorf("%s: invalid seedshare: %w",
+				funcName, err)
+		}
+	}
+
 	// Ensure the wallet exists or create it when the create flag is set.
 	netDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
-	dbPath := filepath.Join(netDir, wallet.WalletDBName)
-
-	dbFileExists, err := cfgutil.FileExists(dbPath)
+	walletExists, err := wallet.NewLoader(
+		activeNet.Params, netDir, true, cfg.DBTimeout, 0,
+		cfg.dbOptions()...,
+	).WalletExists()
 	if err != nil {
 		return err
 	}
@@ -491,7 +704,7 @@ func loadConfig(cfg *Config) error {
 	if cfg.CreateTemp { // nolint:gocritic
 		tempWalletExists := false
 
-		if dbFileExists {
+		if walletExists {
 			str := fmt.Sprintf("The wallet already exists. Loading this " +
 				"wallet instead.")
 			log.Debug(str)
@@ -511,7 +724,7 @@ func loadConfig(cfg *Config) error {
 		}
 	} else {
 		// We consider create flag is always set and create the wallet whenever it does not exist
-		if !dbFileExists {
+		if !walletExists {
 			// Ensure the data directory for the specified network exists.
 			if err := checkCreateDir(netDir); err != nil {
 				return err
@@ -613,21 +826,36 @@ func loadConfig(cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("invalid network address in RPC listeners: %w", err)
 	}
+	cfg.RESTListeners, err = cfgutil.NormalizeAddresses(
+		cfg.RESTListeners, activeNet.RPCServerPort)
+	if err != nil {
+		return fmt.Errorf("invalid network address in REST listeners: %w", err)
+	}
 
-	// Both RPC servers may not listen on the same interface/port.
-	if len(cfg.LegacyRPCListeners) > 0 && len(cfg.ExperimentalRPCListeners) > 0 {
-		seenAddresses := make(map[string]struct{}, len(cfg.LegacyRPCListeners))
-		for _, addr := range cfg.LegacyRPCListeners {
-			seenAddresses[addr] = struct{}{}
-		}
-		for _, addr := range cfg.ExperimentalRPCListeners {
+	cfg.MetricsListeners, err = cfgutil.NormalizeAddresses(
+		cfg.MetricsListeners, defaultMetricsPort)
+	if err != nil {
+		return fmt.Errorf("invalid network address in metrics listeners: %w", err)
+	}
+
+	// The RPC servers, the REST gateway and the metrics server may not
+	// listen on the same interface/port.
+	seenAddresses := make(map[string]struct{})
+	for _, listeners := range [][]string{cfg.LegacyRPCListeners,
+		cfg.ExperimentalRPCListeners, cfg.RESTListeners,
+		cfg.MetricsListeners} {
+
+		for _, addr := range listeners {
 			_, seen := seenAddresses[addr]
 			if seen {
 				return fmt.Errorf("address `%s` may not be "+
-					"used as a listener address for both "+
-					"RPC servers", addr)
+					"used as a listener address for more "+
+					"than one RPC server", addr)
 			}
 		}
+		for _, addr := range listeners {
+			seenAddresses[addr] = struct{}{}
+		}
 	}
 
 	// Only allow server TLS to be disabled if the RPC server is bound to
@@ -655,6 +883,28 @@ func loadConfig(cfg *Config) error {
 	cfg.CAFile.Value = cleanAndExpandPath(cfg.CAFile.Value)
 	cfg.RPCCert.Value = cleanAndExpandPath(cfg.RPCCert.Value)
 	cfg.RPCKey.Value = cleanAndExpandPath(cfg.RPCKey.Value)
+	if cfg.MacaroonDir == "" {
+		cfg.MacaroonDir = networkDir(cfg.AppDataDir.Value, activeNet.Params)
+	}
+	cfg.MacaroonDir = cleanAndExpandPath(cfg.MacaroonDir)
+	if cfg.AuditLog != "" {
+		cfg.AuditLog = cleanAndExpandPath(cfg.AuditLog)
+		if cfg.AuditLogKey == "" {
+			cfg.AuditLogKey = filepath.Join(networkDir(
+				cfg.AppDataDir.Value, activeNet.Params),
+				"auditlog.key")
+		}
+		cfg.AuditLogKey = cleanAndExpandPath(cfg.AuditLogKey)
+	}
+	if cfg.BackupDir != "" {
+		// Backups are checked and restored as bbolt databases.
+		if cfg.DBBackend != wallet.DBBackendBolt {
+			return fmt.Errorf("%s: backupdir is only supported by "+
+				"the %s dbbackend", funcName,
+				wallet.DBBackendBolt)
+		}
+		cfg.BackupDir = cleanAndExpandPath(cfg.BackupDir)
+	}
 
 	// If the btcd username or password are unset, use the same auth as for
 	// the client.  The two settings were previously shared for btcd and
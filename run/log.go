@@ -9,18 +9,23 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/btcsuite/btclog"
 	"github.com/jrick/logrotate/rotator"
 )
 
 // logWriter implements an io.Writer that outputs to both standard output and
-// the write-end pipe of an initialized log rotator.
-type logWriter struct{}
+// the write-end pipe of an initialized log rotator, if any.
+type logWriter struct {
+	rotatorPipe *io.PipeWriter
+}
 
-func (logWriter) Write(p []byte) (n int, err error) {
+func (w logWriter) Write(p []byte) (n int, err error) {
 	_, _ = os.Stdout.Write(p)
-	_, _ = logRotatorPipe.Write(p)
+	if w.rotatorPipe != nil {
+		_, _ = w.rotatorPipe.Write(p)
+	}
 	return len(p), nil
 }
 
@@ -29,23 +34,12 @@ func (logWriter) Write(p []byte) (n int, err error) {
 // subsystems, add the subsystem logger variable here and to the
 // subsystemLoggers map.
 //
-// Loggers can not be used before the log rotator has been initialized with a
-// log file.  This must be performed early during application startup by calling
-// initLogRotator.
+// These loggers only write to standard output.  Each Runtime logs to the log
+// file of its own config with a logger of its own, see newRuntimeLog.
 var (
 	// backendLog is the logging backend used to create all subsystem loggers.
-	// The backend must not be used before the log rotator has been initialized,
-	// or data races and/or nil pointer dereferences will occur.
 	backendLog = btclog.NewBackend(logWriter{})
 
-	// logRotator is one of the logging outputs.  It should be closed on
-	// application shutdown.
-	logRotator *rotator.Rotator
-
-	// logRotatorPipe is the write-end pipe for writing to the log rotator.  It
-	// is written to by the Write method of the logWriter type.
-	logRotatorPipe *io.PipeWriter
-
 	log          = backendLog.Logger("BTCW")
 	walletLog    = backendLog.Logger("WLLT")
 	txmgrLog     = backendLog.Logger("TMGR")
@@ -53,6 +47,9 @@ var (
 	grpcLog      = backendLog.Logger("GRPC")
 	legacyRPCLog = backendLog.Logger("RPCS")
 	btcnLog      = backendLog.Logger("BTCN")
+	webhookLog   = backendLog.Logger("HOOK")
+	metricsLog   = backendLog.Logger("MTRC")
+	backupLog    = backendLog.Logger("BKUP")
 	ExampleLog   = backendLog.Logger("EXMPL")
 )
 
@@ -64,7 +61,10 @@ func init() {
 	rpcclient.UseLogger(chainLog)
 	rpcserver.UseLogger(grpcLog)
 	legacyrpc.UseLogger(legacyRPCLog)
-	neutrino.UseLogger(btcnLog)*/
+	neutrino.UseLogger(btcnLog)
+	webhook.UseLogger(webhookLog)
+	metrics.UseLogger(metricsLog)
+	backup.UseLogger(backupLog)*/
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -76,29 +76,86 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"GRPC": grpcLog,
 	"RPCS": legacyRPCLog,
 	"BTCN": btcnLog,
+	"HOOK": webhookLog,
+	"MTRC": metricsLog,
+	"BKUP": backupLog,
 }
 
-// initLogRotator initializes the logging rotater to write logs to logFile and
-// create roll files in the same directory.  It must be called before the
-// package-global log rotater variables are used.
-func initLogRotator(logFile string) {
+// initLogRotator initializes a logging rotator to write logs to logFile and
+// create roll files in the same directory.  The returned pipe is written to
+// by the rotator and must be closed to stop it.
+func initLogRotator(logFile string) (*rotator.Rotator, *io.PipeWriter, error) {
 	logDir, _ := filepath.Split(logFile)
 	err := os.MkdirAll(logDir, 0700)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to create log directory: %v\n", err)
-		os.Exit(1)
+		return nil, nil, fmt.Errorf("failed to create log directory: %w",
+			err)
 	}
 	r, err := rotator.New(logFile, 10*1024, false, 3)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to create file rotator: %v\n", err)
-		os.Exit(1)
+		return nil, nil, fmt.Errorf("failed to create file rotator: %w",
+			err)
 	}
 
 	pr, pw := io.Pipe()
 	go func() { _ = r.Run(pr) }()
 
-	logRotator = r
-	logRotatorPipe = pw
+	return r, pw, nil
+}
+
+// runtimeLog is the logger of a Runtime, which writes to standard output and
+// the log file of its config.
+type runtimeLog struct {
+	btclog.Logger
+
+	rotator     *rotator.Rotator
+	rotatorPipe *io.PipeWriter
+}
+
+// newRuntimeLog creates the logger of a Runtime with the config.  The debug
+// level of the config must have been validated.
+func newRuntimeLog(cfg *Config) (*runtimeLog, error) {
+	r, pw, err := initLogRotator(filepath.Join(cfg.LogDir,
+		defaultLogFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	backend := btclog.NewBackend(logWriter{rotatorPipe: pw})
+	logger := backend.Logger("BTCW")
+	logger.SetLevel(subsystemLevel(cfg.DebugLevel, "BTCW"))
+
+	return &runtimeLog{
+		Logger:      logger,
+		rotator:     r,
+		rotatorPipe: pw,
+	}, nil
+}
+
+// Close stops writing to the log file.
+func (l *runtimeLog) Close() {
+	_ = l.rotatorPipe.Close()
+	_ = l.rotator.Close()
+}
+
+// subsystemLevel returns the log level of a subsystem in the validated debug
+// level.  Subsystems without a level in the debug level log at info level.
+func subsystemLevel(debugLevel, subsystemID string) btclog.Level {
+	if !strings.Contains(debugLevel, ",") &&
+		!strings.Contains(debugLevel, "=") {
+
+		level, _ := btclog.LevelFromString(debugLevel)
+		return level
+	}
+
+	for _, logLevelPair := range strings.Split(debugLevel, ",") {
+		fields := strings.Split(logLevelPair, "=")
+		if len(fields) == 2 && fields[0] == subsystemID {
+			level, _ := btclog.LevelFromString(fields[1])
+			return level
+		}
+	}
+	return btclog.LevelInfo
 }
 
 // setLogLevel sets the logging level for provided subsystem.  Invalid
@@ -5,10 +5,14 @@
 package run
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -16,16 +20,31 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/stroomnetwork/btcwallet/rpc/audit"
 	"github.com/stroomnetwork/btcwallet/rpc/legacyrpc"
+	"github.com/stroomnetwork/btcwallet/rpc/macaroons"
+	"github.com/stroomnetwork/btcwallet/rpc/ratelimit"
 	"github.com/stroomnetwork/btcwallet/rpc/rpcserver"
+	"github.com/stroomnetwork/btcwallet/tracing"
 	"github.com/stroomnetwork/btcwallet/wallet"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// gatewayBufferSize is the buffer size of the in-memory connection of
+	// the REST gateway to the gRPC server.
+	gatewayBufferSize = 1 << 20
+
+	// gatewayReadHeaderTimeout is the time allowed to REST clients to send
+	// the headers of a request.
+	gatewayReadHeaderTimeout = 10 * time.Second
 )
 
 // openRPCKeyPair creates or loads the RPC TLS keypair specified by the
-// application config.  This function respects the cfg.OneTimeTLSKey setting.
-func openRPCKeyPair() (tls.Certificate, error) {
+// runtime config.  This function respects the cfg.OneTimeTLSKey setting.
+func (r *Runtime) openRPCKeyPair() (tls.Certificate, error) {
 	// Check for existence of the TLS key file.  If one time TLS keys are
 	// enabled but a key already exists, this function should error since
 	// it's possible that a persistent certificate was copied to a remote
@@ -34,31 +53,31 @@ func openRPCKeyPair() (tls.Certificate, error) {
 	// acceptable if the previous execution used a one time TLS key.
 	// Otherwise, both the cert and key should be read from disk.  If the
 	// cert is missing, the read error will occur in LoadX509KeyPair.
-	_, e := os.Stat(cfg.RPCKey.Value)
+	_, e := os.Stat(r.cfg.RPCKey.Value)
 	keyExists := !os.IsNotExist(e)
 	switch {
-	case cfg.OneTimeTLSKey && keyExists:
+	case r.cfg.OneTimeTLSKey && keyExists:
 		err := fmt.Errorf("one time TLS keys are enabled, but TLS key "+
-			"`%s` already exists", cfg.RPCKey.Value)
+			"`%s` already exists", r.cfg.RPCKey.Value)
 		return tls.Certificate{}, err
-	case cfg.OneTimeTLSKey:
-		return generateRPCKeyPair(false)
+	case r.cfg.OneTimeTLSKey:
+		return r.generateRPCKeyPair(false)
 	case !keyExists:
-		return generateRPCKeyPair(true)
+		return r.generateRPCKeyPair(true)
 	default:
-		return tls.LoadX509KeyPair(cfg.RPCCert.Value, cfg.RPCKey.Value)
+		return tls.LoadX509KeyPair(r.cfg.RPCCert.Value, r.cfg.RPCKey.Value)
 	}
 }
 
 // generateRPCKeyPair generates a new RPC TLS keypair and writes the cert and
 // possibly also the key in PEM format to the paths specified by the config.  If
 // successful, the new keypair is returned.
-func generateRPCKeyPair(writeKey bool) (tls.Certificate, error) {
-	log.Infof("Generating TLS certificates...")
+func (r *Runtime) generateRPCKeyPair(writeKey bool) (tls.Certificate, error) {
+	r.log.Infof("Generating TLS certificates...")
 
 	// Create directories for cert and key files if they do not yet exist.
-	certDir, _ := filepath.Split(cfg.RPCCert.Value)
-	keyDir, _ := filepath.Split(cfg.RPCKey.Value)
+	certDir, _ := filepath.Split(r.cfg.RPCCert.Value)
+	keyDir, _ := filepath.Split(r.cfg.RPCKey.Value)
 	err := os.MkdirAll(certDir, 0700)
 	if err != nil {
 		return tls.Certificate{}, err
@@ -81,38 +100,95 @@ func generateRPCKeyPair(writeKey bool) (tls.Certificate, error) {
 	}
 
 	// Write cert and (potentially) the key files.
-	err = os.WriteFile(cfg.RPCCert.Value, cert, 0600)
+	err = os.WriteFile(r.cfg.RPCCert.Value, cert, 0600)
 	if err != nil {
 		return tls.Certificate{}, err
 	}
 	if writeKey {
-		err = os.WriteFile(cfg.RPCKey.Value, key, 0600)
+		err = os.WriteFile(r.cfg.RPCKey.Value, key, 0600)
 		if err != nil {
-			rmErr := os.Remove(cfg.RPCCert.Value)
+			rmErr := os.Remove(r.cfg.RPCCert.Value)
 			if rmErr != nil {
-				log.Warnf("Cannot remove written certificates: %v",
+				r.log.Warnf("Cannot remove written certificates: %v",
 					rmErr)
 			}
 			return tls.Certificate{}, err
 		}
 	}
 
-	log.Info("Done generating TLS certificates")
+	r.log.Info("Done generating TLS certificates")
 	return keyPair, nil
 }
 
-func startRPCServers(walletLoader *wallet.Loader) (*grpc.Server, *legacyrpc.Server, error) {
+// rateLimiter returns the limiter of the configured RPC rate limits, or nil if
+// there are none.
+func (r *Runtime) rateLimiter() (*ratelimit.Limiter, error) {
+	if len(r.cfg.RPCRateLimits) == 0 {
+		return nil, nil
+	}
+	rules := make([]ratelimit.Rule, 0, len(r.cfg.RPCRateLimits))
+	for _, s := range r.cfg.RPCRateLimits {
+		rule, err := ratelimit.ParseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return ratelimit.New(rules), nil
+}
+
+// openAuditLog opens the configured audit log, or returns nil if auditing is
+// disabled.
+func (r *Runtime) openAuditLog() (*audit.Log, error) {
+	if r.cfg.AuditLog == "" {
+		return nil, nil
+	}
+	key, err := audit.OpenKey(r.cfg.AuditLogKey)
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := audit.Open(r.cfg.AuditLog, key)
+	if err != nil {
+		return nil, err
+	}
+	seq, head := auditLog.Head()
+	r.log.Infof("Auditing RPC requests to %s (%d entries, head %s)",
+		r.cfg.AuditLog, seq, head)
+	return auditLog, nil
+}
+
+func (r *Runtime) startRPCServers(wallets *wallet.MultiLoader,
+	auditLog *audit.Log) (*grpc.Server, *legacyrpc.Server, error) {
+
 	var (
 		server       *grpc.Server
 		legacyServer *legacyrpc.Server
 		legacyListen = net.Listen
 		keyPair      tls.Certificate
+		macaroonSvc  *macaroons.Service
 		err          error
 	)
-	if cfg.DisableServerTLS {
-		log.Info("Server TLS is disabled.  Only legacy RPC may be used")
+	limiter, err := r.rateLimiter()
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.cfg.Macaroons {
+		macaroonSvc, err = macaroons.OpenService(r.cfg.MacaroonDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = macaroonSvc.WriteDefaultMacaroons(r.cfg.MacaroonDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.log.Infof("RPC clients authenticate with the macaroons in %s",
+			r.cfg.MacaroonDir)
+	}
+
+	if r.cfg.DisableServerTLS {
+		r.log.Info("Server TLS is disabled.  Only legacy RPC may be used")
 	} else {
-		keyPair, err = openRPCKeyPair()
+		keyPair, err = r.openRPCKeyPair()
 		if err != nil {
 			return nil, nil, err
 		}
@@ -127,62 +203,218 @@ func startRPCServers(walletLoader *wallet.Loader) (*grpc.Server, *legacyrpc.Serv
 			return tls.Listen(net, laddr, tlsConfig)
 		}
 
-		if len(cfg.ExperimentalRPCListeners) != 0 {
-			listeners := makeListeners(cfg.ExperimentalRPCListeners, net.Listen)
-			if len(listeners) == 0 {
+		// The REST gateway forwards its requests to the gRPC server,
+		// so the server is created for either.
+		if len(r.cfg.ExperimentalRPCListeners) != 0 ||
+			len(r.cfg.RESTListeners) != 0 {
+
+			listeners := r.makeListeners(r.cfg.ExperimentalRPCListeners, net.Listen)
+			if len(r.cfg.ExperimentalRPCListeners) != 0 &&
+				len(listeners) == 0 {
+
 				err := errors.New("failed to create listeners for RPC server")
 				return nil, nil, err
 			}
 			creds := credentials.NewServerTLSFromCert(&keyPair)
-			server = grpc.NewServer(grpc.Creds(creds))
+			// Requests are traced first, so the spans include
+			// the time spent in the other interceptors.
+			tracer := tracing.NewServerInterceptor(r.tracerProvider)
+			opts := []grpc.ServerOption{
+				grpc.Creds(creds),
+				grpc.ChainUnaryInterceptor(
+					tracer.UnaryServerInterceptor(),
+				),
+				grpc.ChainStreamInterceptor(
+					tracer.StreamServerInterceptor(),
+				),
+			}
+			if r.metrics != nil {
+				opts = append(opts, grpc.ChainUnaryInterceptor(
+					r.metrics.UnaryServerInterceptor(),
+				))
+			}
+			if macaroonSvc != nil {
+				auth := rpcserver.NewAuthenticator(
+					macaroonSvc, wallets,
+				)
+				opts = append(opts,
+					grpc.ChainUnaryInterceptor(
+						auth.UnaryServerInterceptor(),
+					),
+					grpc.ChainStreamInterceptor(
+						auth.StreamServerInterceptor(),
+					),
+				)
+			}
+			// The request policy identifies callers by the
+			// macaroons checked by the authenticator, so it
+			// must be chained after it.
+			if limiter != nil || auditLog != nil {
+				policy := rpcserver.NewRequestPolicy(
+					limiter, auditLog,
+				)
+				opts = append(opts,
+					grpc.ChainUnaryInterceptor(
+						policy.UnaryServerInterceptor(),
+					),
+					grpc.ChainStreamInterceptor(
+						policy.StreamServerInterceptor(),
+					),
+				)
+			}
+			server = grpc.NewServer(opts...)
 			rpcserver.StartVersionService(server)
-			rpcserver.StartWalletLoaderService(server, walletLoader, activeNet)
+			rpcserver.StartWalletLoaderService(
+				server, wallets.DefaultLoader(), r.cfg.activeNet,
+			)
+			rpcserver.StartWalletService(server, wallets.DefaultLoader())
+			rpcserver.StartWalletServiceV2(server, wallets)
 			for _, lis := range listeners {
 				lis := lis
 				go func() {
-					log.Infof("Experimental RPC server listening on %s",
+					r.log.Infof("Experimental RPC server listening on %s",
 						lis.Addr())
 					err := server.Serve(lis)
-					log.Tracef("Finished serving expimental RPC: %v",
+					r.log.Tracef("Finished serving expimental RPC: %v",
 						err)
 				}()
 			}
+			if len(r.cfg.RESTListeners) != 0 {
+				err := r.startRESTGateway(server, keyPair)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
 		}
 	}
 
-	if cfg.Username == "" || cfg.Password == "" {
-		log.Info("Legacy RPC server disabled (requires username and password)")
-	} else if len(cfg.LegacyRPCListeners) != 0 {
-		listeners := makeListeners(cfg.LegacyRPCListeners, legacyListen)
+	basicAuth := r.cfg.Username != "" && r.cfg.Password != ""
+	if !basicAuth && macaroonSvc == nil {
+		r.log.Info("Legacy RPC server disabled (requires username and " +
+			"password or macaroons)")
+	} else if len(r.cfg.LegacyRPCListeners) != 0 {
+		listeners := r.makeListeners(r.cfg.LegacyRPCListeners, legacyListen)
 		if len(listeners) == 0 {
 			err := errors.New("failed to create listeners for legacy RPC server")
 			return nil, nil, err
 		}
 		opts := legacyrpc.Options{
-			Username:            cfg.Username,
-			Password:            cfg.Password,
-			MaxPOSTClients:      cfg.LegacyRPCMaxClients,
-			MaxWebsocketClients: cfg.LegacyRPCMaxWebsockets,
+			Macaroons:           macaroonSvc,
+			RateLimiter:         limiter,
+			AuditLog:            auditLog,
+			MaxPOSTClients:      r.cfg.LegacyRPCMaxClients,
+			MaxWebsocketClients: r.cfg.LegacyRPCMaxWebsockets,
+			PublicPassphrase:    []byte(r.cfg.WalletPass),
+			TracerProvider:      r.tracerProvider,
+		}
+		if r.metrics != nil {
+			opts.ObserveRequest = r.metrics.ObserveRequest
+		}
+		if basicAuth {
+			opts.Username = r.cfg.Username
+			opts.Password = r.cfg.Password
 		}
-		legacyServer = legacyrpc.NewServer(&opts, walletLoader, listeners)
+		legacyServer = legacyrpc.NewServer(&opts, wallets, listeners)
 	}
 
 	return server, legacyServer, nil
 }
 
+// startRESTGateway serves the REST gateway of the gRPC server on the REST
+// listeners, with the TLS keypair of the server.  The gateway reaches the
+// server over an in-memory connection, so its requests pass the interceptors
+// of the server like any other.
+func (r *Runtime) startRESTGateway(server *grpc.Server,
+	keyPair tls.Certificate) error {
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"http/1.1"},
+	}
+	listeners := r.makeListeners(r.cfg.RESTListeners,
+		func(net string, laddr string) (net.Listener, error) {
+			return tls.Listen(net, laddr, tlsConfig)
+		})
+	if len(listeners) == 0 {
+		return errors.New("failed to create listeners for REST gateway")
+	}
+
+	gatewayListener := bufconn.Listen(gatewayBufferSize)
+	go func() {
+		err := server.Serve(gatewayListener)
+		r.log.Tracef("Finished serving REST gateway: %v", err)
+	}()
+
+	// Rather than the names of its certificate, which need not include
+	// the name of an in-memory connection, the server is verified by the
+	// certificate of the keypair.
+	leaf := keyPair.Certificate[0]
+	clientTLSConfig := &tls.Config{
+		InsecureSkipVerify: true, // nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte,
+			_ [][]*x509.Certificate) error {
+
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], leaf) {
+				return errors.New("unexpected RPC server " +
+					"certificate")
+			}
+			return nil
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context,
+			_ string) (net.Conn, error) {
+
+			return gatewayListener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(
+			credentials.NewTLS(clientTLSConfig),
+		),
+	)
+	if err != nil {
+		return err
+	}
+	r.onStop(func() {
+		_ = conn.Close()
+	})
+
+	handler, err := rpcserver.NewGateway(context.Background(), conn)
+	if err != nil {
+		return err
+	}
+	for _, lis := range listeners {
+		httpServer := &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: gatewayReadHeaderTimeout,
+		}
+		lis := lis
+		go func() {
+			r.log.Infof("REST gateway listening on %s", lis.Addr())
+			err := httpServer.Serve(lis)
+			r.log.Tracef("Finished serving REST gateway: %v", err)
+		}()
+		r.onStop(func() {
+			_ = httpServer.Close()
+		})
+	}
+	return nil
+}
+
 type listenFunc func(net string, laddr string) (net.Listener, error)
 
 // makeListeners splits the normalized listen addresses into IPv4 and IPv6
 // addresses and creates new net.Listeners for each with the passed listen func.
 // Invalid addresses are logged and skipped.
-func makeListeners(normalizedListenAddrs []string, listen listenFunc) []net.Listener {
+func (r *Runtime) makeListeners(normalizedListenAddrs []string, listen listenFunc) []net.Listener {
 	ipv4Addrs := make([]string, 0, len(normalizedListenAddrs)*2)
 	ipv6Addrs := make([]string, 0, len(normalizedListenAddrs)*2)
 	for _, addr := range normalizedListenAddrs {
 		host, _, err := net.SplitHostPort(addr)
 		if err != nil {
 			// Shouldn't happen due to already being normalized.
-			log.Errorf("`%s` is not a normalized "+
+			r.log.Errorf("`%s` is not a normalized "+
 				"listener address", addr)
 			continue
 		}
@@ -207,7 +439,7 @@ func makeListeners(normalizedListenAddrs []string, listen listenFunc) []net.List
 		ip := net.ParseIP(host)
 		switch {
 		case ip == nil:
-			log.Warnf("`%s` is not a valid IP address", host)
+			r.log.Warnf("`%s` is not a valid IP address", host)
 		case ip.To4() == nil:
 			ipv6Addrs = append(ipv6Addrs, addr)
 		default:
@@ -218,7 +450,7 @@ func makeListeners(normalizedListenAddrs []string, listen listenFunc) []net.List
 	for _, addr := range ipv4Addrs {
 		listener, err := listen("tcp4", addr)
 		if err != nil {
-			log.Warnf("Can't listen on %s: %v", addr, err)
+			r.log.Warnf("Can't listen on %s: %v", addr, err)
 			continue
 		}
 		listeners = append(listeners, listener)
@@ -226,7 +458,7 @@ func makeListeners(normalizedListenAddrs []string, listen listenFunc) []net.List
 	for _, addr := range ipv6Addrs {
 		listener, err := listen("tcp6", addr)
 		if err != nil {
-			log.Warnf("Can't listen on %s: %v", addr, err)
+			r.log.Warnf("Can't listen on %s: %v", addr, err)
 			continue
 		}
 		listeners = append(listeners, listener)
@@ -234,14 +466,10 @@ func makeListeners(normalizedListenAddrs []string, listen listenFunc) []net.List
 	return listeners
 }
 
-// startWalletRPCServices associates each of the (optionally-nil) RPC servers
-// with a wallet to enable remote wallet access.  For the GRPC server, this
-// registers the WalletService service, and for the legacy JSON-RPC server it
-// enables methods that require a loaded wallet.
-func startWalletRPCServices(wallet *wallet.Wallet, server *grpc.Server, legacyServer *legacyrpc.Server) {
-	if server != nil {
-		rpcserver.StartWalletService(server, wallet)
-	}
+// startWalletRPCServices associates the (optionally-nil) legacy RPC server
+// with a wallet to enable the methods that require a loaded wallet.  The
+// services of the gRPC server look up the loaded wallet by themselves.
+func startWalletRPCServices(wallet *wallet.Wallet, legacyServer *legacyrpc.Server) {
 	if legacyServer != nil {
 		legacyServer.RegisterWallet(wallet)
 	}
@@ -0,0 +1,557 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof" // nolint:gosec
+	"sync"
+	"time"
+
+	"github.com/stroomnetwork/btcwallet/chain"
+	"github.com/stroomnetwork/btcwallet/metrics"
+	"github.com/stroomnetwork/btcwallet/rpc/legacyrpc"
+	"github.com/stroomnetwork/btcwallet/tracing"
+	"github.com/stroomnetwork/btcwallet/wallet"
+	"github.com/stroomnetwork/btcwallet/webhook"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+var (
+	// ErrRuntimeStarted describes the error condition of starting a
+	// runtime that was started before.
+	ErrRuntimeStarted = errors.New("runtime already started")
+
+	// ErrRuntimeStopped describes the error condition of a runtime that
+	// was stopped, or requested to shut down, before it was ready.
+	ErrRuntimeStopped = errors.New("runtime stopped")
+)
+
+// Runtime runs a wallet process: the wallets of its config, their chain
+// connections and the RPC servers serving them.  All state is owned by the
+// runtime, so several runtimes with different configs may run in a single
+// process, and a stopped runtime may be replaced by a new one.
+//
+// The neutrino peer limits of an SPV config are process-wide settings of the
+// neutrino package, so runtimes using SPV share those of the config loaded
+// last.
+type Runtime struct {
+	cfg              *Config
+	bitcoindConfig   *chain.BitcoindConfig
+	feeCoefficient   float64
+	rescanStartBlock uint64
+
+	log             *runtimeLog
+	metrics         *metrics.Metrics
+	tracerProvider  trace.TracerProvider
+	wallets         *wallet.MultiLoader
+	rpcs            *grpc.Server
+	legacyRPCServer *legacyrpc.Server
+
+	// ready is closed once the default wallet is ready, and shutdown when
+	// a shutdown of the runtime is requested.
+	ready        chan struct{}
+	readyOnce    sync.Once
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+
+	mu        sync.Mutex
+	started   bool
+	stopped   bool
+	stopFuncs []func()
+}
+
+// NewRuntime loads the config of the runtime and returns a runtime that is
+// ready to be started.  Loading the config creates the default wallet if it
+// does not exist yet.
+func NewRuntime(config *BtcwalletConfig) (*Runtime, error) {
+	err := checkConfigForNil(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadConfig(config.Config); err != nil {
+		return nil, err
+	}
+	return newRuntime(config), nil
+}
+
+// newRuntime returns a runtime of the loaded config.
+func newRuntime(config *BtcwalletConfig) *Runtime {
+	return &Runtime{
+		cfg:              config.Config,
+		bitcoindConfig:   config.BitcoindConfig,
+		feeCoefficient:   config.FeeCoefficient,
+		rescanStartBlock: config.RescanStartBlock,
+		ready:            make(chan struct{}),
+		shutdown:         make(chan struct{}),
+		quit:             make(chan struct{}),
+	}
+}
+
+// Start starts the RPC servers and chain connections of the runtime and
+// loads its wallets.  The context bounds the start up only; once started, the
+// runtime runs until Stop is called.  A runtime that failed to start is
+// stopped again, and may not be restarted.
+//
+// Start does not wait for the default wallet to sync with the chain, which is
+// reported by the Ready channel instead.
+func (r *Runtime) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		return ErrRuntimeStarted
+	}
+	r.started = true
+	r.mu.Unlock()
+
+	if err := r.start(ctx); err != nil {
+		if r.log != nil {
+			r.log.Error(err)
+		}
+		r.Stop()
+		return err
+	}
+	return nil
+}
+
+func (r *Runtime) start(ctx context.Context) error {
+	cfg := r.cfg
+
+	var err error
+	r.log, err = newRuntimeLog(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Show version at startup.
+	r.log.Infof("Version %s", version())
+
+	if cfg.Profile != "" {
+		r.startProfileServer()
+	}
+
+	hookCfg, err := webhookConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid webhook configuration: %w", err)
+	}
+
+	backupCfg, err := backupConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid backup configuration: %w", err)
+	}
+
+	traceCfg, err := tracingConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid tracing configuration: %w", err)
+	}
+	r.tracerProvider = otel.GetTracerProvider()
+	if traceCfg != nil {
+		provider, err := tracing.NewProvider(ctx, traceCfg)
+		if err != nil {
+			return fmt.Errorf("unable to export traces: %w", err)
+		}
+		r.tracerProvider = provider
+
+		// The provider is shut down after everything else is stopped,
+		// so the spans of the last requests are exported.
+		r.onStop(func() {
+			err := tracing.Shutdown(provider, tracerShutdownTimeout)
+			if err != nil {
+				r.log.Errorf("Failed to export traces: %v", err)
+			}
+		})
+	}
+
+	loaderOpts := []wallet.LoaderOption{
+		wallet.WithMaxReorgDepth(cfg.MaxReorgDepth),
+		wallet.WithArgon2Options(cfg.argon2Options()),
+		wallet.WithTracerProvider(r.tracerProvider),
+	}
+	loaderOpts = append(loaderOpts, cfg.dbOptions()...)
+	if len(cfg.MetricsListeners) != 0 {
+		r.metrics = metrics.New()
+		loaderOpts = append(loaderOpts, wallet.WithMetrics(r.metrics))
+	}
+
+	dbDir := networkDir(cfg.AppDataDir.Value, cfg.activeNet.Params)
+	r.wallets = wallet.NewMultiLoader(
+		cfg.activeNet.Params, dbDir, true, cfg.DBTimeout, 250,
+		loaderOpts...,
+	)
+	loader := r.wallets.DefaultLoader()
+
+	// Stop functions run in reverse order, so the wallets (which should be
+	// closed last) are added first.
+	r.onStop(func() {
+		if err := r.wallets.UnloadAll(); err != nil {
+			r.log.Errorf("Failed to close wallet: %v", err)
+		}
+	})
+
+	// The probes are served while the wallets are loaded, so they report
+	// the runtime as alive but not yet ready.
+	if r.metrics != nil {
+		if err := r.startMetricsServer(); err != nil {
+			return fmt.Errorf("unable to start metrics server: %w",
+				err)
+		}
+	}
+
+	auditLog, err := r.openAuditLog()
+	if err != nil {
+		return fmt.Errorf("unable to open audit log: %w", err)
+	}
+	if auditLog != nil {
+		r.onStop(func() {
+			if err := auditLog.Close(); err != nil {
+				r.log.Errorf("Failed to close audit log: %v",
+					err)
+			}
+		})
+	}
+
+	// Create and start HTTP server to serve wallet client connections.
+	// This will be updated with the wallet and chain server RPC client
+	// created below after each is created.
+	r.rpcs, r.legacyRPCServer, err = r.startRPCServers(r.wallets, auditLog)
+	if err != nil {
+		return fmt.Errorf("unable to create RPC servers: %w", err)
+	}
+	if r.rpcs != nil {
+		rpcs := r.rpcs
+		r.onStop(func() {
+			// TODO: Does this need to wait for the grpc server to
+			// finish up any requests?
+			r.log.Warn("Stopping RPC server...")
+			rpcs.Stop()
+			r.log.Info("RPC server shutdown")
+		})
+	}
+	if r.legacyRPCServer != nil {
+		legacyRPCServer := r.legacyRPCServer
+		r.onStop(func() {
+			r.log.Warn("Stopping legacy RPC server...")
+			legacyRPCServer.Stop()
+			r.log.Info("Legacy RPC server shutdown")
+		})
+		r.goRun(func() {
+			select {
+			case <-legacyRPCServer.RequestProcessShutdown():
+				r.log.Info("Received shutdown request")
+				r.requestShutdown()
+			case <-r.quit:
+			}
+		})
+	}
+
+	// Create and start chain RPC client so it's ready to connect to
+	// the wallet when loaded later.  Without an initial load, the wallet
+	// is loaded and connected over RPC, and is ready once loaded.
+	if !cfg.NoInitialLoad {
+		r.goRun(func() {
+			r.rpcClientConnectLoop(
+				r.legacyRPCServer, loader, dbDir, r.walletSynced,
+			)
+		})
+	} else {
+		loader.RunAfterLoad(func(*wallet.Wallet) {
+			r.setReady()
+		})
+	}
+
+	r.wallets.RunAfterLoad(func(_ string, w *wallet.Wallet) {
+		w.FeeCoefficient = r.feeCoefficient
+	})
+	loader.RunAfterLoad(func(w *wallet.Wallet) {
+		startWalletRPCServices(w, r.legacyRPCServer)
+	})
+
+	// Named wallets are synced by chain clients of their own, which are
+	// started when each is first loaded and keep syncing it when it is
+	// loaded again.  Their SPV data is kept next to the wallet database.
+	var (
+		connecting   = make(map[string]bool)
+		connectingMu sync.Mutex
+	)
+	r.wallets.RunAfterLoad(func(name string, _ *wallet.Wallet) {
+		if name == wallet.DefaultWalletName {
+			return
+		}
+
+		connectingMu.Lock()
+		started := connecting[name]
+		connecting[name] = true
+		connectingMu.Unlock()
+		if started {
+			return
+		}
+
+		l, err := r.wallets.Loader(name)
+		if err != nil {
+			r.log.Errorf("Unable to sync wallet %q: %v", name, err)
+			return
+		}
+		dir, _ := r.wallets.WalletDir(name)
+		r.goRun(func() {
+			r.rpcClientConnectLoop(nil, l, dir, nil)
+		})
+	})
+
+	// Every loaded wallet has a webhook dispatcher of its own, replaced
+	// when the wallet is loaded again after it was unloaded.
+	if hookCfg != nil {
+		var (
+			dispatchers  = make(map[string]*webhook.Dispatcher)
+			dispatcherMu sync.Mutex
+		)
+		r.wallets.RunAfterLoad(func(name string, w *wallet.Wallet) {
+			dispatcherMu.Lock()
+			defer dispatcherMu.Unlock()
+
+			if d, ok := dispatchers[name]; ok {
+				d.Stop()
+				delete(dispatchers, name)
+			}
+			d, err := r.startWebhookDispatcher(name, w, hookCfg)
+			if err != nil {
+				r.log.Errorf("Unable to start webhook "+
+					"dispatcher of wallet %q: %v", name, err)
+				return
+			}
+			dispatchers[name] = d
+		})
+		r.onStop(func() {
+			dispatcherMu.Lock()
+			defer dispatcherMu.Unlock()
+
+			if len(dispatchers) > 0 {
+				r.log.Info("Stopping webhook dispatchers...")
+			}
+			for _, d := range dispatchers {
+				d.Stop()
+			}
+		})
+	}
+
+	if backupCfg != nil {
+		r.startBackups(backupCfg)
+	}
+
+	if cfg.NoInitialLoad {
+		return nil
+	}
+
+	// Load the wallet database.  It must have been created already or
+	// this will return an appropriate error.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, err = loader.OpenExistingWallet(
+		[]byte(cfg.WalletPass), cfg.CanConsolePrompt,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range cfg.Wallets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		_, err := r.wallets.LoadWallet(name, []byte(cfg.WalletPass))
+		if err != nil {
+			return fmt.Errorf("unable to load wallet %q: %w", name,
+				err)
+		}
+		r.log.Infof("Loaded wallet %q", name)
+	}
+
+	return nil
+}
+
+// startProfileServer serves the HTTP profiles of the process on the profile
+// port of the config until the runtime is stopped.
+func (r *Runtime) startProfileServer() {
+	listenAddr := net.JoinHostPort("", r.cfg.Profile)
+	mux := http.NewServeMux()
+	mux.Handle("/debug/", http.DefaultServeMux)
+	mux.Handle("/", http.RedirectHandler("/debug/pprof",
+		http.StatusSeeOther))
+	server := &http.Server{
+		Addr:    listenAddr,
+		Handler: mux,
+	}
+
+	r.goRun(func() {
+		r.log.Infof("Profile server listening on %s", listenAddr)
+		err := server.ListenAndServe()
+		if err != http.ErrServerClosed {
+			r.log.Errorf("%v", err)
+		}
+	})
+	r.onStop(func() {
+		_ = server.Close()
+	})
+}
+
+// tracerShutdownTimeout is the time allowed to export the spans not yet
+// exported when the runtime is stopped.
+const tracerShutdownTimeout = 5 * time.Second
+
+// metricsReadHeaderTimeout is the time allowed to metrics clients to send the
+// headers of a request.
+const metricsReadHeaderTimeout = 10 * time.Second
+
+// startMetricsServer serves the metrics of the runtime and its health probes
+// on the metrics listeners of the config until the runtime is stopped.  The
+// runtime is ready once the wallets loaded at startup are synced to a current
+// chain backend.
+func (r *Runtime) startMetricsServer() error {
+	if err := r.metrics.CollectWallets(r.wallets); err != nil {
+		return err
+	}
+	var required []string
+	if !r.cfg.NoInitialLoad {
+		required = append(required, wallet.DefaultWalletName)
+		required = append(required, r.cfg.Wallets...)
+	}
+	readiness := metrics.NewReadiness(r.wallets, required...)
+
+	listeners := r.makeListeners(r.cfg.MetricsListeners, net.Listen)
+	if len(listeners) == 0 {
+		return errors.New("failed to create listeners for metrics " +
+			"server")
+	}
+	server := &http.Server{
+		Handler:           metrics.NewHandler(r.metrics, readiness),
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+	for _, lis := range listeners {
+		lis := lis
+		r.goRun(func() {
+			r.log.Infof("Metrics server listening on %s",
+				lis.Addr())
+			err := server.Serve(lis)
+			if err != http.ErrServerClosed {
+				r.log.Errorf("Metrics server: %v", err)
+			}
+		})
+	}
+	r.onStop(func() {
+		_ = server.Close()
+	})
+	return nil
+}
+
+// walletSynced marks the runtime ready once the default wallet is connected
+// to a chain client.
+func (r *Runtime) walletSynced(w *wallet.Wallet) {
+	select {
+	case <-r.ready:
+		return
+	default:
+	}
+
+	if r.rescanStartBlock != 0 {
+		stamp, err := w.GetBlockStamp(r.rescanStartBlock)
+		if err != nil {
+			r.log.Errorf("Cannot get rescan block stamp: %v", err)
+			r.requestShutdown()
+			return
+		}
+		w.RescanStartStamp = stamp
+	}
+	r.setReady()
+}
+
+func (r *Runtime) setReady() {
+	r.readyOnce.Do(func() {
+		close(r.ready)
+	})
+}
+
+func (r *Runtime) requestShutdown() {
+	r.shutdownOnce.Do(func() {
+		close(r.shutdown)
+	})
+}
+
+// Ready returns a channel that is closed once the default wallet is loaded
+// and connected to the chain, or, when the config defers loading the wallet,
+// once it is loaded over RPC.
+func (r *Runtime) Ready() <-chan struct{} {
+	return r.ready
+}
+
+// ShutdownRequested returns a channel that is closed when a shutdown of the
+// runtime was requested, either by the stop method of the legacy RPC server
+// or because the runtime failed after it was started.  The runtime is not
+// stopped until Stop is called.
+func (r *Runtime) ShutdownRequested() <-chan struct{} {
+	return r.shutdown
+}
+
+// Wallet returns the default wallet, or nil if it is not loaded.
+func (r *Runtime) Wallet() *wallet.Wallet {
+	if r.wallets == nil {
+		return nil
+	}
+	w, _ := r.wallets.Wallet(wallet.DefaultWalletName)
+	return w
+}
+
+// Wallets returns the loader of the wallets of the runtime, or nil before the
+// runtime is started.
+func (r *Runtime) Wallets() *wallet.MultiLoader {
+	return r.wallets
+}
+
+// Stop stops the RPC servers and chain connections of the runtime, closes its
+// wallets and waits for its goroutines to exit.  Stop may be called more than
+// once, and returns immediately when the runtime was not started.
+func (r *Runtime) Stop() {
+	r.mu.Lock()
+	if !r.started || r.stopped {
+		r.mu.Unlock()
+		return
+	}
+	r.stopped = true
+	stopFuncs := r.stopFuncs
+	r.stopFuncs = nil
+	r.mu.Unlock()
+
+	close(r.quit)
+	for i := len(stopFuncs) - 1; i >= 0; i-- {
+		stopFuncs[i]()
+	}
+	r.wg.Wait()
+
+	if r.log != nil {
+		r.log.Info("Shutdown complete")
+		r.log.Close()
+	}
+}
+
+// onStop adds a function to run when the runtime is stopped.  The functions
+// run in reverse order of their addition.
+func (r *Runtime) onStop(fn func()) {
+	r.mu.Lock()
+	r.stopFuncs = append(r.stopFuncs, fn)
+	r.mu.Unlock()
+}
+
+// goRun runs a goroutine that Stop waits for.  The goroutine must exit once
+// the quit channel of the runtime is closed.
+func (r *Runtime) goRun(fn func()) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		fn()
+	}()
+}
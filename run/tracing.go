@@ -0,0 +1,39 @@
+package run
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stroomnetwork/btcwallet/tracing"
+)
+
+// tracingConfig builds the configuration of the export of traces from the
+// loaded config.  A nil config is returned when no OTLP endpoint is
+// configured.
+func tracingConfig(cfg *Config) (*tracing.Config, error) {
+	if cfg.OTLPEndpoint == "" {
+		return nil, nil
+	}
+	if cfg.TraceSampleRate < 0 || cfg.TraceSampleRate > 1 {
+		return nil, fmt.Errorf("tracesamplerate must be between 0 " +
+			"and 1")
+	}
+
+	headers := make(map[string]string, len(cfg.OTLPHeaders))
+	for _, s := range cfg.OTLPHeaders {
+		key, value, ok := strings.Cut(s, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid otlpheader: expected " +
+				"key=value")
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+
+	return &tracing.Config{
+		Endpoint:   cfg.OTLPEndpoint,
+		Insecure:   cfg.OTLPInsecure,
+		Headers:    headers,
+		SampleRate: cfg.TraceSampleRate,
+	}, nil
+}
@@ -14,10 +14,13 @@ import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/wire"
-	"github.com/btcsuite/btcwallet/walletdb"
 	_ "github.com/btcsuite/btcwallet/walletdb/bdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/postgres"
+	_ "github.com/btcsuite/btcwallet/walletdb/sqlite"
+	"github.com/stroomnetwork/btcwallet/bip39"
 	"github.com/stroomnetwork/btcwallet/internal/legacy/keystore"
 	"github.com/stroomnetwork/btcwallet/internal/prompt"
+	"github.com/stroomnetwork/btcwallet/slip39"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
 	"github.com/stroomnetwork/btcwallet/wallet"
 )
@@ -101,15 +104,17 @@ func convertLegacyKeystore(legacyKeyStore *keystore.Store, w *wallet.Wallet) {
 //
 // The new wallet will reside at the provided path.
 func createWallet(cfg *Config) error {
-	dbDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
+	dbDir := networkDir(cfg.AppDataDir.Value, cfg.activeNet.Params)
 	loader := wallet.NewLoader(
-		activeNet.Params, dbDir, true, cfg.DBTimeout, 250,
+		cfg.activeNet.Params, dbDir, true, cfg.DBTimeout, 250,
+		append(cfg.dbOptions(),
+			wallet.WithArgon2Options(cfg.argon2Options()))...,
 	)
 
 	// When there is a legacy keystore, open it now to ensure any errors
 	// don't end up exiting the process after the user has spent time
 	// entering a bunch of information.
-	netDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
+	netDir := networkDir(cfg.AppDataDir.Value, cfg.activeNet.Params)
 	keystorePath := filepath.Join(netDir, keystore.Filename)
 	var legacyKeyStore *keystore.Store
 	_, err := os.Stat(keystorePath)
@@ -192,15 +197,30 @@ func createWallet(cfg *Config) error {
 		// Ascertain the wallet generation seed.  This will either be an
 		// automatically generated value the user has already confirmed or a
 		// value the user has entered which has already been validated.
-		seed, err = prompt.Seed(reader)
-		if err != nil {
-			return err
+		// A seed which is split into shares is never displayed, so it is
+		// generated when the wallet is created instead.
+		if cfg.Mnemonic == "" && len(cfg.SeedShares) == 0 &&
+			len(cfg.seedShareGroups) == 0 {
+
+			seed, err = prompt.Seed(reader, cfg.MnemonicWords)
+			if err != nil {
+				return err
+			}
 		}
 	} else {
 		pubPass = []byte(cfg.WalletPass)
 		seed = []byte(nil)
 	}
 
+	// The seed of a mnemonic is used like a seed entered on the console,
+	// so it may be split into shares as well.
+	if cfg.Mnemonic != "" {
+		seed, err = bip39.Seed(cfg.Mnemonic, []byte(cfg.MnemonicPass))
+		if err != nil {
+			return err
+		}
+	}
+
 	fmt.Println("Creating the wallet...")
 	var birthday time.Time
 	if cfg.BirthdayTimestamp > 0 {
@@ -209,7 +229,40 @@ func createWallet(cfg *Config) error {
 		birthday = time.Now()
 	}
 
-	w, err := loader.CreateNewWallet(pubPass, privPass, seed, birthday)
+	var w *wallet.Wallet
+	switch {
+	case len(cfg.SeedShares) > 0 && len(cfg.seedShareGroups) > 0:
+		seed, err = slip39.Combine(
+			cfg.SeedShares, []byte(cfg.SeedSharePass),
+		)
+		if err != nil {
+			return err
+		}
+		fallthrough
+
+	case len(cfg.seedShareGroups) > 0:
+		w, err = loader.CreateNewWalletWithShares(
+			pubPass, privPass, seed, &wallet.SeedShares{
+				GroupThreshold: cfg.SeedShareGroupThreshold,
+				Groups:         cfg.seedShareGroups,
+				Passphrase:     []byte(cfg.SeedSharePass),
+				Export: func(mnemonics [][]string) error {
+					return writeSeedShares(
+						cfg.SeedShareDir, mnemonics,
+					)
+				},
+			}, birthday,
+		)
+
+	case len(cfg.SeedShares) > 0:
+		w, err = loader.CreateNewWalletFromShares(
+			pubPass, privPass, cfg.SeedShares,
+			[]byte(cfg.SeedSharePass), birthday,
+		)
+
+	default:
+		w, err = loader.CreateNewWallet(pubPass, privPass, seed, birthday)
+	}
 	if err != nil {
 		return err
 	}
@@ -225,6 +278,49 @@ func createWallet(cfg *Config) error {
 	return nil
 }
 
+// writeSeedShares writes each of the SLIP-39 share mnemonics of the seed of a
+// new wallet to its own file in dir, named after the numbers of its group and
+// member.  No file is left behind when any of them can't be written.
+func writeSeedShares(dir string, mnemonics [][]string) error {
+	if err := checkCreateDir(dir); err != nil {
+		return err
+	}
+
+	var written []string
+	writeShare := func(name, mnemonic string) error {
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(
+			path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600,
+		)
+		if err != nil {
+			return err
+		}
+		written = append(written, path)
+		_, err = fmt.Fprintln(f, mnemonic)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+	for g, group := range mnemonics {
+		for m, mnemonic := range group {
+			name := fmt.Sprintf("share-g%d-m%d.txt", g+1, m+1)
+			if err := writeShare(name, mnemonic); err != nil {
+				for _, path := range written {
+					_ = os.Remove(path)
+				}
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("Wrote %d seed shares to %s\n", len(written), dir)
+	fmt.Println("IMPORTANT: Hand each share to its holder and remove\n" +
+		"it from this directory.  The seed is not displayed and\n" +
+		"can only be restored from the shares.")
+	return nil
+}
+
 // createSimulationWallet is intended to be called from the rpcclient
 // and used to create a wallet for actors involved in simulations.
 func createSimulationWallet(cfg *Config) error {
@@ -234,22 +330,19 @@ func createSimulationWallet(cfg *Config) error {
 	// Public passphrase is the default.
 	pubPass := []byte(wallet.InsecurePubPassphrase)
 
-	netDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
+	netDir := networkDir(cfg.AppDataDir.Value, cfg.activeNet.Params)
 
 	// Create the wallet.
-	dbPath := filepath.Join(netDir, wallet.WalletDBName)
 	fmt.Println("Creating the wallet...")
-
-	// Create the wallet database backed by bolt db.
-	db, err := walletdb.Create("bdb", dbPath, true, cfg.DBTimeout)
+	loader := wallet.NewLoader(
+		cfg.activeNet.Params, netDir, true, cfg.DBTimeout, 250,
+		cfg.dbOptions()...,
+	)
+	_, err := loader.CreateNewWallet(pubPass, privPass, nil, time.Now())
 	if err != nil {
 		return err
 	}
-	defer db.Close()
-
-	// Create the wallet.
-	err = wallet.Create(db, pubPass, privPass, nil, activeNet.Params, time.Now())
-	if err != nil {
+	if err := loader.UnloadWallet(); err != nil {
 		return err
 	}
 
@@ -0,0 +1,112 @@
+package run
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/stroomnetwork/btcwallet/wallet"
+	"github.com/stroomnetwork/btcwallet/webhook"
+)
+
+// webhookConfig builds the webhook dispatcher configuration from the
+// loaded config.  A nil config is returned when no webhook URLs are
+// configured.
+func webhookConfig(cfg *Config) (*webhook.Config, error) {
+	if len(cfg.WebhookURLs) == 0 {
+		return nil, nil
+	}
+	if cfg.WebhookSecret == "" {
+		return nil, fmt.Errorf("webhooksecret must be set when " +
+			"webhookurl is used")
+	}
+
+	endpoints := make([]webhook.Endpoint, 0, len(cfg.WebhookURLs))
+	for _, url := range cfg.WebhookURLs {
+		endpoints = append(endpoints, webhook.Endpoint{
+			URL:    url,
+			Secret: []byte(cfg.WebhookSecret),
+		})
+	}
+
+	accountConfs := make(map[uint32]int32, len(cfg.WebhookAccountConfs))
+	for _, s := range cfg.WebhookAccountConfs {
+		fields := strings.Split(s, ":")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid webhookaccountconfs "+
+				"`%s`: expected account:confirmations", s)
+		}
+		account, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account in "+
+				"webhookaccountconfs `%s`: %w", s, err)
+		}
+		confs, err := strconv.ParseInt(fields[1], 10, 32)
+		if err != nil || confs <= 0 {
+			return nil, fmt.Errorf("invalid confirmations in "+
+				"webhookaccountconfs `%s`", s)
+		}
+		accountConfs[uint32(account)] = int32(confs)
+	}
+
+	watched := make([]btcutil.Address, 0, len(cfg.WebhookWatchAddrs))
+	for _, s := range cfg.WebhookWatchAddrs {
+		addr, err := btcutil.DecodeAddress(s, cfg.activeNet.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhookwatchaddr "+
+				"`%s`: %w", s, err)
+		}
+		if !addr.IsForNet(cfg.activeNet.Params) {
+			return nil, fmt.Errorf("webhookwatchaddr `%s` is not "+
+				"for network %s", s, cfg.activeNet.Params.Name)
+		}
+		watched = append(watched, addr)
+	}
+
+	return &webhook.Config{
+		Endpoints:            endpoints,
+		ChainParams:          cfg.activeNet.Params,
+		WatchAddresses:       watched,
+		DefaultConfirmations: cfg.WebhookConfirmations,
+		AccountConfirmations: accountConfs,
+		MaxAttempts:          cfg.WebhookMaxAttempts,
+	}, nil
+}
+
+// startWebhookDispatcher creates a webhook dispatcher for the named wallet,
+// keeping undeliverable events in the wallet database, and starts it.
+func (r *Runtime) startWebhookDispatcher(name string, w *wallet.Wallet,
+	hookCfg *webhook.Config) (*webhook.Dispatcher, error) {
+
+	deadLetters, err := webhook.NewDBDeadLetterStore(w.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	c := *hookCfg
+	c.Wallet = name
+	c.DeadLetters = deadLetters
+	d, err := webhook.New(&c)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Start(w); err != nil {
+		return nil, err
+	}
+
+	r.log.Infof("Webhook dispatcher of wallet %q delivering to %d %s",
+		name, len(c.Endpoints), pickNoun(len(c.Endpoints), "endpoint",
+			"endpoints"))
+
+	return d, nil
+}
+
+// pickNoun returns the singular or plural form of a noun depending
+// on the count n.
+func pickNoun(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,74 @@
+package slip39
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// baseIterationCount is the number of PBKDF2 iterations of all rounds
+	// of the cipher at iteration exponent 0.
+	baseIterationCount = 10000
+
+	// roundCount is the number of rounds of the Feistel network.
+	roundCount = 4
+)
+
+// cipherSalt returns the salt of the round function.  Shares that are not
+// extendable bind the encryption to the identifier.
+func cipherSalt(id uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	salt := append([]byte(nil), customizationOrig...)
+	return binary.BigEndian.AppendUint16(salt, id)
+}
+
+// roundFunction returns the output of round i of the Feistel network for the
+// right half r.
+func roundFunction(i byte, passphrase []byte, exp uint8, salt,
+	r []byte) []byte {
+
+	password := append([]byte{i}, passphrase...)
+	iterations := (baseIterationCount << exp) / roundCount
+	return pbkdf2.Key(password, append(salt[:len(salt):len(salt)], r...),
+		iterations, len(r), sha256.New)
+}
+
+// feistel runs the rounds of the four round Feistel network in the given
+// order over the secret.
+func feistel(secret, passphrase []byte, exp uint8, id uint16,
+	extendable bool, rounds []byte) []byte {
+
+	half := len(secret) / 2
+	l := append([]byte(nil), secret[:half]...)
+	r := append([]byte(nil), secret[half:]...)
+	salt := cipherSalt(id, extendable)
+	for _, i := range rounds {
+		f := roundFunction(i, passphrase, exp, salt, r)
+		for j := range l {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+	}
+	return append(r, l...)
+}
+
+// encrypt encrypts the master secret with the passphrase.
+func encrypt(masterSecret, passphrase []byte, exp uint8, id uint16,
+	extendable bool) []byte {
+
+	return feistel(masterSecret, passphrase, exp, id, extendable,
+		[]byte{0, 1, 2, 3})
+}
+
+// decrypt decrypts the encrypted master secret with the passphrase.  Any
+// passphrase decrypts it, to a different master secret.
+func decrypt(encrypted, passphrase []byte, exp uint8, id uint16,
+	extendable bool) []byte {
+
+	return feistel(encrypted, passphrase, exp, id, extendable,
+		[]byte{3, 2, 1, 0})
+}
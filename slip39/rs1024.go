@@ -0,0 +1,71 @@
+package slip39
+
+const (
+	// checksumWords is the number of words of the checksum at the end of
+	// each mnemonic.
+	checksumWords = 3
+)
+
+var (
+	// customizationOrig and customizationExtendable are the
+	// customization strings of the checksums and the salt of the cipher
+	// for shares that are not and that are extendable.
+	customizationOrig       = []byte("shamir")
+	customizationExtendable = []byte("shamir_extendable")
+)
+
+// rs1024Gen is the generator of the Reed-Solomon code over GF(1024) used for
+// the checksums.
+var rs1024Gen = [10]uint32{
+	0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+	0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+}
+
+// customization returns the customization string of a share.
+func customization(extendable bool) []byte {
+	if extendable {
+		return customizationExtendable
+	}
+	return customizationOrig
+}
+
+// rs1024Polymod returns the remainder of the polynomial of the customization
+// string and the values.
+func rs1024Polymod(custom []byte, values []int) uint32 {
+	chk := uint32(1)
+	step := func(v uint32) {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 != 0 {
+				chk ^= rs1024Gen[i]
+			}
+		}
+	}
+	for _, c := range custom {
+		step(uint32(c))
+	}
+	for _, v := range values {
+		step(uint32(v))
+	}
+	return chk
+}
+
+// rs1024Checksum returns the checksum words of the data words.
+func rs1024Checksum(custom []byte, data []int) []int {
+	values := append(data[:len(data):len(data)], make([]int, checksumWords)...)
+	polymod := rs1024Polymod(custom, values) ^ 1
+
+	checksum := make([]int, checksumWords)
+	for i := range checksum {
+		shift := 10 * (checksumWords - 1 - i)
+		checksum[i] = int(polymod>>shift) & (radix - 1)
+	}
+	return checksum
+}
+
+// rs1024Verify returns whether the words, which end with the checksum, have a
+// valid checksum.
+func rs1024Verify(custom []byte, words []int) bool {
+	return rs1024Polymod(custom, words) == 1
+}
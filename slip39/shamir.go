@@ -0,0 +1,183 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"io"
+)
+
+const (
+	// maxShareCount is the maximum number of shares a secret can be split
+	// into, limited by the four bits of the share indices.
+	maxShareCount = 16
+
+	// digestLength is the size of the digest of the secret kept in the
+	// share at digestIndex.
+	digestLength = 4
+
+	// secretIndex and digestIndex are the x coordinates of the shares
+	// holding the secret and its digest.
+	secretIndex = 255
+	digestIndex = 254
+)
+
+// rawShare is a point of the polynomials of a secret shared in GF(256): the
+// share index x and one y coordinate for each byte of the secret.
+type rawShare struct {
+	x     byte
+	value []byte
+}
+
+// expTable and logTable are the powers of the generator x+1 of the
+// multiplicative group of GF(256) with the Rijndael polynomial
+// x^8 + x^4 + x^3 + x + 1, and their logarithms.
+var expTable, logTable = func() ([255]byte, [256]byte) {
+	var exp [255]byte
+	var log [256]byte
+
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+
+		// Multiply by x+1 and reduce by the Rijndael polynomial.
+		poly = poly<<1 ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}()
+
+// interpolate returns the value at x of the polynomials through the shares
+// using Lagrange interpolation.  The shares must have distinct indices and
+// values of the same length.
+func interpolate(shares []rawShare, x byte) ([]byte, error) {
+	seen := make(map[byte]bool, len(shares))
+	for _, s := range shares {
+		if seen[s.x] {
+			return nil, ErrDuplicateShare
+		}
+		seen[s.x] = true
+		if len(s.value) != len(shares[0].value) {
+			return nil, ErrMismatchedShares
+		}
+	}
+	for _, s := range shares {
+		if s.x == x {
+			return append([]byte(nil), s.value...), nil
+		}
+	}
+
+	// The basis polynomial of share i at x is the product of
+	// (x - x_j) / (x_i - x_j) over the other shares j, computed with
+	// logarithms.  Subtraction is xor in GF(256).
+	logProd := 0
+	for _, s := range shares {
+		logProd += int(logTable[s.x^x])
+	}
+
+	result := make([]byte, len(shares[0].value))
+	for _, s := range shares {
+		logBasis := logProd - int(logTable[s.x^x])
+		for _, other := range shares {
+			if other.x != s.x {
+				logBasis -= int(logTable[s.x^other.x])
+			}
+		}
+		logBasis = (logBasis%255 + 255) % 255
+
+		for i, y := range s.value {
+			if y != 0 {
+				result[i] ^= expTable[(int(logTable[y])+logBasis)%255]
+			}
+		}
+	}
+	return result, nil
+}
+
+// secretDigest returns the digest of the secret kept with the random part in
+// the share at digestIndex.
+func secretDigest(random, secret []byte) []byte {
+	mac := hmac.New(sha256.New, random)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// splitSecret splits the secret into count shares, any threshold of which
+// restore it.
+func splitSecret(threshold, count int, secret []byte) ([]rawShare, error) {
+	switch {
+	case threshold < 1:
+		return nil, ErrInvalidThreshold
+	case threshold > count:
+		return nil, ErrInvalidThreshold
+	case count > maxShareCount:
+		return nil, ErrTooManyShares
+	}
+
+	shares := make([]rawShare, 0, count)
+	if threshold == 1 {
+		for i := 0; i < count; i++ {
+			shares = append(shares, rawShare{
+				x:     byte(i),
+				value: append([]byte(nil), secret...),
+			})
+		}
+		return shares, nil
+	}
+
+	// The polynomials are defined by threshold-2 random shares, the
+	// digest share and the secret itself.
+	for i := 0; i < threshold-2; i++ {
+		value := make([]byte, len(secret))
+		if _, err := io.ReadFull(rand.Reader, value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), value: value})
+	}
+	random := make([]byte, len(secret)-digestLength)
+	if _, err := io.ReadFull(rand.Reader, random); err != nil {
+		return nil, err
+	}
+	base := append(shares[:len(shares):len(shares)],
+		rawShare{
+			x:     digestIndex,
+			value: append(secretDigest(random, secret), random...),
+		},
+		rawShare{x: secretIndex, value: secret},
+	)
+
+	for i := threshold - 2; i < count; i++ {
+		value, err := interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), value: value})
+	}
+	return shares, nil
+}
+
+// recoverSecret restores the secret from threshold of its shares and checks
+// it against its digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return append([]byte(nil), shares[0].value...), nil
+	}
+
+	secret, err := interpolate(shares, secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := interpolate(shares, digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	digest := secretDigest(digestShare[digestLength:], secret)
+	if subtle.ConstantTimeCompare(digest, digestShare[:digestLength]) != 1 {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
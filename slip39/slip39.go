@@ -0,0 +1,427 @@
+// Package slip39 splits a master secret into mnemonic shares, and restores it
+// from them, as specified by SLIP-0039.
+//
+// The secret is encrypted with a passphrase and split in two levels: into
+// groups, a threshold of which is needed to restore the secret, and the share
+// of each group into member shares, a threshold of which is needed to restore
+// the group's share.  Each member share is encoded as a mnemonic of 20 words
+// for a 128-bit secret or 33 words for a 256-bit secret.
+package slip39
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+const (
+	// radix is the number of words of the word list.  Each word encodes
+	// radixBits bits.
+	radix     = 1024
+	radixBits = 10
+
+	// idBits, extendableBits and iterationExpBits are the sizes of the
+	// fields of the first two words of a mnemonic.
+	idBits           = 15
+	extendableBits   = 1
+	iterationExpBits = 4
+
+	// idExpWords and paramsWords are the number of words holding the
+	// identifier and iteration exponent, and the group and member
+	// parameters.
+	idExpWords  = 2
+	paramsWords = 2
+
+	// metadataWords is the number of words of a mnemonic that are not the
+	// share value.
+	metadataWords = idExpWords + paramsWords + checksumWords
+
+	// minSecretBytes is the minimum size of the master secret.
+	minSecretBytes = 16
+
+	// minMnemonicWords is the number of words of the mnemonics of a
+	// secret of the minimum size.
+	minMnemonicWords = metadataWords +
+		(minSecretBytes*8+radixBits-1)/radixBits
+)
+
+// DefaultIterationExponent is the iteration exponent of the shares created by
+// Split.  The passphrase encryption of the master secret runs 10000 << e
+// iterations of PBKDF2.
+const DefaultIterationExponent = 1
+
+var (
+	// ErrInvalidSecret describes a master secret that is shorter than
+	// 128 bits or has an odd number of bytes.
+	ErrInvalidSecret = errors.New("master secret must be at least 128 " +
+		"bits and an even number of bytes")
+
+	// ErrInvalidThreshold describes a threshold that is less than one or
+	// greater than the number of shares.
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and " +
+		"the number of shares")
+
+	// ErrTooManyShares describes more than 16 groups or members of a
+	// group.
+	ErrTooManyShares = errors.New("at most 16 shares are supported")
+
+	// ErrInvalidMnemonic describes a mnemonic with unknown words or of a
+	// wrong length or padding.
+	ErrInvalidMnemonic = errors.New("invalid share mnemonic")
+
+	// ErrInvalidChecksum describes a mnemonic with a wrong checksum.
+	ErrInvalidChecksum = errors.New("invalid share mnemonic checksum")
+
+	// ErrMismatchedShares describes shares that don't belong to the same
+	// secret.
+	ErrMismatchedShares = errors.New("shares do not belong to the same " +
+		"secret")
+
+	// ErrDuplicateShare describes two different shares with the same
+	// index.
+	ErrDuplicateShare = errors.New("shares have the same index")
+
+	// ErrInsufficientShares describes shares that are not enough to
+	// restore the secret.
+	ErrInsufficientShares = errors.New("insufficient shares to restore " +
+		"the secret")
+
+	// ErrInvalidDigest describes shares that restore a secret that does
+	// not match its digest, because one of them is wrong.
+	ErrInvalidDigest = errors.New("invalid digest of the restored secret")
+)
+
+// Group describes a group of member shares, Threshold of which are needed to
+// restore the share of the group.
+type Group struct {
+	Threshold int
+	Count     int
+}
+
+// String returns the group in the form threshold-of-count, e.g. 2of3.
+func (g Group) String() string {
+	return fmt.Sprintf("%dof%d", g.Threshold, g.Count)
+}
+
+// ParseGroup parses a group in the form threshold-of-count, e.g. 2of3.
+func ParseGroup(s string) (Group, error) {
+	var g Group
+	_, err := fmt.Sscanf(s, "%dof%d", &g.Threshold, &g.Count)
+	if err != nil || fmt.Sprintf("%dof%d", g.Threshold, g.Count) != s {
+		return Group{}, fmt.Errorf("invalid share group %q: must be "+
+			"in the form threshold-of-count, e.g. 2of3", s)
+	}
+	if g.Threshold < 1 || g.Threshold > g.Count {
+		return Group{}, fmt.Errorf("invalid share group %q: %w", s,
+			ErrInvalidThreshold)
+	}
+	if g.Count > maxShareCount {
+		return Group{}, fmt.Errorf("invalid share group %q: %w", s,
+			ErrTooManyShares)
+	}
+	return g, nil
+}
+
+// share is a decoded share mnemonic.
+type share struct {
+	id             uint16
+	extendable     bool
+	iterationExp   uint8
+	groupIndex     int
+	groupThreshold int
+	groupCount     int
+	memberIndex    int
+	memberThresh   int
+	value          []byte
+}
+
+// commonParams are the parameters of a share that all shares of a secret
+// share.
+type commonParams struct {
+	id             uint16
+	extendable     bool
+	iterationExp   uint8
+	groupThreshold int
+	groupCount     int
+}
+
+// common returns the parameters all shares of the secret of s share.
+func (s *share) common() commonParams {
+	return commonParams{
+		id:             s.id,
+		extendable:     s.extendable,
+		iterationExp:   s.iterationExp,
+		groupThreshold: s.groupThreshold,
+		groupCount:     s.groupCount,
+	}
+}
+
+// mnemonic encodes the share as a mnemonic.
+func (s *share) mnemonic() string {
+	ext := 0
+	if s.extendable {
+		ext = 1
+	}
+	idExp := int(s.id)<<(extendableBits+iterationExpBits) |
+		ext<<iterationExpBits | int(s.iterationExp)
+	params := s.groupIndex<<16 | (s.groupThreshold-1)<<12 |
+		(s.groupCount-1)<<8 | s.memberIndex<<4 | (s.memberThresh - 1)
+
+	valueWords := (len(s.value)*8 + radixBits - 1) / radixBits
+	words := make([]int, 0, metadataWords+valueWords)
+	words = append(words, intToWords(big.NewInt(int64(idExp)),
+		idExpWords)...)
+	words = append(words, intToWords(big.NewInt(int64(params)),
+		paramsWords)...)
+	words = append(words, intToWords(new(big.Int).SetBytes(s.value),
+		valueWords)...)
+	words = append(words, rs1024Checksum(
+		customization(s.extendable), words)...)
+
+	strs := make([]string, len(words))
+	for i, w := range words {
+		strs[i] = wordList[w]
+	}
+	return strings.Join(strs, " ")
+}
+
+// intToWords returns the n word indices of the big-endian radix 1024
+// representation of v.
+func intToWords(v *big.Int, n int) []int {
+	v = new(big.Int).Set(v)
+	mask := big.NewInt(radix - 1)
+	words := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		words[i] = int(new(big.Int).And(v, mask).Int64())
+		v.Rsh(v, radixBits)
+	}
+	return words
+}
+
+// wordsToInt returns the value of the big-endian radix 1024 word indices.
+func wordsToInt(words []int) *big.Int {
+	v := new(big.Int)
+	for _, w := range words {
+		v.Lsh(v, radixBits)
+		v.Or(v, big.NewInt(int64(w)))
+	}
+	return v
+}
+
+// parseShare decodes a share mnemonic.  Words are separated by white space
+// and may be given in any case.
+func parseShare(mnemonic string) (*share, error) {
+	fields := strings.Fields(strings.ToLower(mnemonic))
+	words := make([]int, len(fields))
+	for i, f := range fields {
+		w, ok := wordIndex[f]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q",
+				ErrInvalidMnemonic, f)
+		}
+		words[i] = w
+	}
+	if len(words) < minMnemonicWords {
+		return nil, fmt.Errorf("%w: too few words", ErrInvalidMnemonic)
+	}
+	paddingBits := (radixBits * (len(words) - metadataWords)) % 16
+	if paddingBits > 8 {
+		return nil, fmt.Errorf("%w: wrong number of words",
+			ErrInvalidMnemonic)
+	}
+
+	idExp := int(wordsToInt(words[:idExpWords]).Int64())
+	s := &share{
+		id:           uint16(idExp >> (extendableBits + iterationExpBits)),
+		extendable:   (idExp>>iterationExpBits)&1 == 1,
+		iterationExp: uint8(idExp & (1<<iterationExpBits - 1)),
+	}
+	if !rs1024Verify(customization(s.extendable), words) {
+		return nil, ErrInvalidChecksum
+	}
+
+	params := int(wordsToInt(
+		words[idExpWords : idExpWords+paramsWords]).Int64())
+	s.groupIndex = params >> 16 & 0xf
+	s.groupThreshold = params>>12&0xf + 1
+	s.groupCount = params>>8&0xf + 1
+	s.memberIndex = params >> 4 & 0xf
+	s.memberThresh = params&0xf + 1
+	if s.groupCount < s.groupThreshold {
+		return nil, fmt.Errorf("%w: group threshold exceeds the "+
+			"number of groups", ErrInvalidMnemonic)
+	}
+
+	valueWords := words[idExpWords+paramsWords : len(words)-checksumWords]
+	valueBytes := (radixBits*len(valueWords) - paddingBits + 7) / 8
+	value := wordsToInt(valueWords)
+	if value.BitLen() > valueBytes*8 {
+		return nil, fmt.Errorf("%w: invalid padding",
+			ErrInvalidMnemonic)
+	}
+	s.value = value.FillBytes(make([]byte, valueBytes))
+
+	return s, nil
+}
+
+// Validate checks that a share mnemonic consists of words of the word list
+// and has a valid length and checksum.
+func Validate(mnemonic string) error {
+	_, err := parseShare(mnemonic)
+	return err
+}
+
+// Split encrypts the master secret with the passphrase and splits it into
+// share mnemonics.  The secret is split into one share for each of the
+// groups, groupThreshold of which are needed to restore it, and the share of
+// each group into member shares as described by the group.  The mnemonics are
+// returned by group.
+//
+// The master secret must be at least 128 bits and an even number of bytes.
+// The passphrase may be empty.  Groups of more than one member must need more
+// than one member to restore the group's share.
+func Split(masterSecret, passphrase []byte, groupThreshold int,
+	groups []Group) ([][]string, error) {
+
+	if len(masterSecret) < minSecretBytes || len(masterSecret)%2 != 0 {
+		return nil, ErrInvalidSecret
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, ErrInvalidThreshold
+	}
+	for _, g := range groups {
+		if g.Threshold < 1 || g.Threshold > g.Count {
+			return nil, ErrInvalidThreshold
+		}
+		if g.Threshold == 1 && g.Count > 1 {
+			return nil, fmt.Errorf("group %v: use 1of1 instead of "+
+				"several shares that each restore the group",
+				g)
+		}
+	}
+
+	var idBytes [2]byte
+	if _, err := io.ReadFull(rand.Reader, idBytes[:]); err != nil {
+		return nil, err
+	}
+	id := binary.BigEndian.Uint16(idBytes[:]) & (1<<idBits - 1)
+
+	const extendable = true
+	encrypted := encrypt(masterSecret, passphrase,
+		DefaultIterationExponent, id, extendable)
+
+	groupShares, err := splitSecret(groupThreshold, len(groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([][]string, len(groups))
+	for i, g := range groups {
+		memberShares, err := splitSecret(g.Threshold, g.Count,
+			groupShares[i].value)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range memberShares {
+			s := share{
+				id:             id,
+				extendable:     extendable,
+				iterationExp:   DefaultIterationExponent,
+				groupIndex:     int(groupShares[i].x),
+				groupThreshold: groupThreshold,
+				groupCount:     len(groups),
+				memberIndex:    int(m.x),
+				memberThresh:   g.Threshold,
+				value:          m.value,
+			}
+			mnemonics[i] = append(mnemonics[i], s.mnemonic())
+		}
+	}
+	return mnemonics, nil
+}
+
+// Combine restores the master secret from share mnemonics and decrypts it
+// with the passphrase.  The mnemonics must include the threshold of member
+// shares of the threshold of groups, and may include more shares.  A wrong
+// passphrase restores a different master secret without an error.
+func Combine(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, ErrInsufficientShares
+	}
+
+	var params commonParams
+	groups := make(map[int][]*share)
+	for i, m := range mnemonics {
+		s, err := parseShare(m)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			params = s.common()
+		} else if s.common() != params {
+			return nil, ErrMismatchedShares
+		}
+
+		dup := false
+		for _, other := range groups[s.groupIndex] {
+			if other.memberThresh != s.memberThresh ||
+				len(other.value) != len(s.value) {
+
+				return nil, ErrMismatchedShares
+			}
+			if other.memberIndex == s.memberIndex {
+				if string(other.value) != string(s.value) {
+					return nil, ErrDuplicateShare
+				}
+				dup = true
+			}
+		}
+		if !dup {
+			groups[s.groupIndex] = append(groups[s.groupIndex], s)
+		}
+	}
+
+	// Restore the shares of the groups with enough member shares, until
+	// there are enough of them to restore the secret.
+	var groupShares []rawShare
+	for index := 0; index < maxShareCount; index++ {
+		members := groups[index]
+		if len(members) == 0 || len(members) < members[0].memberThresh {
+			continue
+		}
+
+		thresh := members[0].memberThresh
+		memberShares := make([]rawShare, thresh)
+		for i, m := range members[:thresh] {
+			memberShares[i] = rawShare{
+				x:     byte(m.memberIndex),
+				value: m.value,
+			}
+		}
+		value, err := recoverSecret(thresh, memberShares)
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, rawShare{
+			x:     byte(index),
+			value: value,
+		})
+		if len(groupShares) == params.groupThreshold {
+			break
+		}
+	}
+	if len(groupShares) < params.groupThreshold {
+		return nil, ErrInsufficientShares
+	}
+
+	encrypted, err := recoverSecret(params.groupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, params.iterationExp, params.id,
+		params.extendable), nil
+}
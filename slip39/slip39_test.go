@@ -0,0 +1,194 @@
+package slip39
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestVectors checks mnemonics of the SLIP-0039 test vectors, which are
+// encrypted with the passphrase TREZOR.
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mnemonics []string
+		secret    string
+		err       error
+	}{{
+		name: "no sharing",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result " +
+				"length solution fridge kidney coal piece " +
+				"deal husband erode duke ajar critical " +
+				"decision keyboard",
+		},
+		secret: "bb54aac4b89dc868ba37d9cc21b2cece",
+	}, {
+		name: "invalid checksum",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result " +
+				"length solution fridge kidney coal piece " +
+				"deal husband erode duke ajar critical " +
+				"decision kidney",
+		},
+		err: ErrInvalidChecksum,
+	}, {
+		name: "2 of 3 members",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife " +
+				"fancy gross oasis cylinder mustang wrist " +
+				"rescue view short owner flip making " +
+				"coding armed",
+			"shadow pistol academic acid actress prayer class " +
+				"unknown daughter sweater depict flip twice " +
+				"unkind craft early superior advocate guest " +
+				"smoking",
+		},
+		secret: "b43ceb7e57a0ea8766221624d01b0864",
+	}, {
+		name: "1 of 3 members given, 2 needed",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife " +
+				"fancy gross oasis cylinder mustang wrist " +
+				"rescue view short owner flip making " +
+				"coding armed",
+		},
+		err: ErrInsufficientShares,
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			secret, err := Combine(test.mnemonics, []byte("TREZOR"))
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.secret, hex.EncodeToString(secret))
+		})
+	}
+}
+
+// TestSplitCombine checks that the secret is restored from any threshold of
+// groups and members, and only from them.
+func TestSplitCombine(t *testing.T) {
+	t.Parallel()
+
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+	passphrase := []byte("custody")
+	groups := []Group{{2, 3}, {3, 5}, {1, 1}}
+
+	mnemonics, err := Split(secret, passphrase, 2, groups)
+	require.NoError(t, err)
+	require.Len(t, mnemonics, len(groups))
+	for i, g := range groups {
+		require.Len(t, mnemonics[i], g.Count)
+		for _, m := range mnemonics[i] {
+			require.NoError(t, Validate(m))
+			require.Len(t, strings.Fields(m), 33)
+		}
+	}
+
+	combine := func(mnemonics ...string) ([]byte, error) {
+		return Combine(mnemonics, passphrase)
+	}
+
+	// Any two groups restore the secret.
+	got, err := combine(mnemonics[0][0], mnemonics[0][2],
+		mnemonics[2][0])
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	got, err = combine(mnemonics[1][4], mnemonics[1][1], mnemonics[1][2],
+		mnemonics[0][1], mnemonics[0][2])
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	// Extra and repeated shares are ignored.
+	got, err = combine(append(append([]string{mnemonics[2][0]},
+		mnemonics[1]...), mnemonics[1][0])...)
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	// One group, or groups without enough members, are not enough.
+	_, err = combine(mnemonics[2][0])
+	require.ErrorIs(t, err, ErrInsufficientShares)
+	_, err = combine(mnemonics[2][0], mnemonics[1][0], mnemonics[1][1])
+	require.ErrorIs(t, err, ErrInsufficientShares)
+
+	// A wrong passphrase restores a different secret.
+	got, err = Combine([]string{mnemonics[0][0], mnemonics[0][1],
+		mnemonics[2][0]}, []byte("wrong"))
+	require.NoError(t, err)
+	require.NotEqual(t, secret, got)
+
+	// Shares of another split of the same secret don't mix.
+	other, err := Split(secret, passphrase, 2, groups)
+	require.NoError(t, err)
+	_, err = combine(mnemonics[2][0], other[0][0], other[0][1])
+	require.ErrorIs(t, err, ErrMismatchedShares)
+}
+
+// TestSplitInvalid checks the rejected secrets and groups.
+func TestSplitInvalid(t *testing.T) {
+	t.Parallel()
+
+	secret := make([]byte, 16)
+	tests := []struct {
+		name      string
+		secret    []byte
+		threshold int
+		groups    []Group
+	}{
+		{"short secret", secret[:14], 1, []Group{{1, 1}}},
+		{"odd secret", append(secret, 0), 1, []Group{{1, 1}}},
+		{"group threshold", secret, 2, []Group{{1, 1}}},
+		{"member threshold", secret, 1, []Group{{3, 2}}},
+		{"too many members", secret, 1, []Group{{2, 17}}},
+		{"1 of several", secret, 1, []Group{{1, 3}}},
+	}
+	for _, test := range tests {
+		_, err := Split(test.secret, nil, test.threshold, test.groups)
+		require.Error(t, err, test.name)
+	}
+}
+
+func TestParseGroup(t *testing.T) {
+	t.Parallel()
+
+	g, err := ParseGroup("3of5")
+	require.NoError(t, err)
+	require.Equal(t, Group{3, 5}, g)
+	require.Equal(t, "3of5", g.String())
+
+	for _, s := range []string{"3", "3of", "of5", "3of5x", "0of2",
+		"3of2", "2of17"} {
+
+		_, err := ParseGroup(s)
+		require.Error(t, err, s)
+	}
+}
+
+// TestWordList checks the properties of the word list SLIP-0039 relies on.
+func TestWordList(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, sort.StringsAreSorted(wordList[:]))
+	prefixes := make(map[string]bool)
+	for _, w := range wordList {
+		require.True(t, len(w) >= 4 && len(w) <= 8, w)
+		prefixes[w[:4]] = true
+	}
+	require.Len(t, prefixes, radix)
+}
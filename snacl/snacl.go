@@ -10,10 +10,12 @@ import (
 	"crypto/subtle"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"runtime/debug"
 
 	"github.com/stroomnetwork/btcwallet/internal/zero"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/nacl/secretbox"
 	"golang.org/x/crypto/scrypt"
 )
@@ -27,6 +29,7 @@ var (
 	ErrInvalidPassword = errors.New("invalid password")
 	ErrMalformed       = errors.New("malformed data")
 	ErrDecryptFailed   = errors.New("unable to decrypt")
+	ErrUnknownKDF      = errors.New("unknown key derivation function")
 )
 
 // Various constants needed for encryption scheme.
@@ -38,6 +41,51 @@ const (
 	DefaultN  = 16384 // 2^14
 	DefaultR  = 8
 	DefaultP  = 1
+
+	// Default Argon2id parameters, the second recommended option of
+	// RFC 9106 with four lanes.  Memory is in KiB.
+	DefaultArgon2Time    = 3
+	DefaultArgon2Memory  = 64 * 1024
+	DefaultArgon2Threads = 4
+)
+
+// KDF identifies the function a secret key is derived from a passphrase with.
+type KDF uint8
+
+// The supported key derivation functions.
+const (
+	// KDFScrypt derives keys with scrypt, using the N, R and P
+	// parameters.
+	KDFScrypt KDF = iota
+
+	// KDFArgon2id derives keys with Argon2id, using the Time, Memory and
+	// Threads parameters.
+	KDFArgon2id
+)
+
+// String returns the name of the key derivation function.
+func (k KDF) String() string {
+	switch k {
+	case KDFScrypt:
+		return "scrypt"
+	case KDFArgon2id:
+		return "argon2id"
+	default:
+		return fmt.Sprintf("KDF(%d)", uint8(k))
+	}
+}
+
+// Sizes of the marshalled parameters.  Scrypt parameters are marshalled
+// without a header, as they were before other key derivation functions were
+// supported, so they can still be read by older versions.  Other key
+// derivation functions are marshalled with a header of the format version and
+// the function, and are told apart from scrypt parameters by their size.
+const (
+	scryptParamsSize = KeySize + sha256.Size + 24
+
+	paramsVersion      = 1
+	paramsHeaderSize   = 2
+	argon2idParamsSize = paramsHeaderSize + KeySize + sha256.Size + 9
 )
 
 // CryptoKey represents a secret key which can be used to encrypt and decrypt
@@ -95,11 +143,19 @@ func GenerateCryptoKey() (*CryptoKey, error) {
 
 // Parameters are not secret and can be stored in plain text.
 type Parameters struct {
+	KDF    KDF
 	Salt   [KeySize]byte
 	Digest [sha256.Size]byte
-	N      int
-	R      int
-	P      int
+
+	// Scrypt parameters.
+	N int
+	R int
+	P int
+
+	// Argon2id parameters.  Memory is in KiB.
+	Time    uint32
+	Memory  uint32
+	Threads uint8
 }
 
 // SecretKey houses a crypto key and the parameters needed to derive it from a
@@ -111,13 +167,28 @@ type SecretKey struct {
 
 // deriveKey fills out the Key field.
 func (sk *SecretKey) deriveKey(password *[]byte) error {
-	key, err := scrypt.Key(*password, sk.Parameters.Salt[:],
-		sk.Parameters.N,
-		sk.Parameters.R,
-		sk.Parameters.P,
-		len(sk.Key))
-	if err != nil {
-		return err
+	params := &sk.Parameters
+
+	var key []byte
+	switch params.KDF {
+	case KDFScrypt:
+		var err error
+		key, err = scrypt.Key(*password, params.Salt[:], params.N,
+			params.R, params.P, len(sk.Key))
+		if err != nil {
+			return err
+		}
+
+	case KDFArgon2id:
+		if params.Time < 1 || params.Threads < 1 {
+			return errors.New("argon2id time and threads must be " +
+				"at least 1")
+		}
+		key = argon2.IDKey(*password, params.Salt[:], params.Time,
+			params.Memory, params.Threads, uint32(len(sk.Key)))
+
+	default:
+		return ErrUnknownKDF
 	}
 	copy(sk.Key[:], key)
 	zero.Bytes(key)
@@ -127,7 +198,8 @@ func (sk *SecretKey) deriveKey(password *[]byte) error {
 	// between means you end up needing twice the amount of memory.  For
 	// example, if your scrypt parameters are such that you require 1GB and
 	// you call it twice in a row, without this you end up allocating 2GB
-	// since the first GB probably hasn't been released yet.
+	// since the first GB probably hasn't been released yet.  The same
+	// goes for the memory of Argon2id.
 	debug.FreeOSMemory()
 
 	return nil
@@ -138,11 +210,36 @@ func (sk *SecretKey) deriveKey(password *[]byte) error {
 func (sk *SecretKey) Marshal() []byte {
 	params := &sk.Parameters
 
-	// The marshalled format for the the params is as follows:
+	// The marshalled format for scrypt params is as follows:
 	//   <salt><digest><N><R><P>
 	//
 	// KeySize + sha256.Size + N (8 bytes) + R (8 bytes) + P (8 bytes)
-	marshalled := make([]byte, KeySize+sha256.Size+24)
+	//
+	// The marshalled format for Argon2id params is as follows:
+	//   <version><kdf><salt><digest><time><memory><threads>
+	//
+	// version (1 byte) + kdf (1 byte) + KeySize + sha256.Size +
+	// time (4 bytes) + memory (4 bytes) + threads (1 byte)
+	if params.KDF == KDFArgon2id {
+		marshalled := make([]byte, argon2idParamsSize)
+		marshalled[0] = paramsVersion
+		marshalled[1] = byte(params.KDF)
+
+		b := marshalled[paramsHeaderSize:]
+		copy(b[:KeySize], params.Salt[:])
+		b = b[KeySize:]
+		copy(b[:sha256.Size], params.Digest[:])
+		b = b[sha256.Size:]
+		binary.LittleEndian.PutUint32(b[:4], params.Time)
+		b = b[4:]
+		binary.LittleEndian.PutUint32(b[:4], params.Memory)
+		b = b[4:]
+		b[0] = params.Threads
+
+		return marshalled
+	}
+
+	marshalled := make([]byte, scryptParamsSize)
 
 	b := marshalled
 	copy(b[:KeySize], params.Salt[:])
@@ -159,30 +256,51 @@ func (sk *SecretKey) Marshal() []byte {
 }
 
 // Unmarshal unmarshalls the parameters needed to derive the secret key from a
-// passphrase into sk.
+// passphrase into sk.  See Marshal for the format.
 func (sk *SecretKey) Unmarshal(marshalled []byte) error {
 	if sk.Key == nil {
 		sk.Key = (*CryptoKey)(&[KeySize]byte{})
 	}
 
-	// The marshalled format for the the params is as follows:
-	//   <salt><digest><N><R><P>
-	//
-	// KeySize + sha256.Size + N (8 bytes) + R (8 bytes) + P (8 bytes)
-	if len(marshalled) != KeySize+sha256.Size+24 {
+	params := &sk.Parameters
+	if len(marshalled) == scryptParamsSize {
+		*params = Parameters{KDF: KDFScrypt}
+		copy(params.Salt[:], marshalled[:KeySize])
+		marshalled = marshalled[KeySize:]
+		copy(params.Digest[:], marshalled[:sha256.Size])
+		marshalled = marshalled[sha256.Size:]
+		params.N = int(binary.LittleEndian.Uint64(marshalled[:8]))
+		marshalled = marshalled[8:]
+		params.R = int(binary.LittleEndian.Uint64(marshalled[:8]))
+		marshalled = marshalled[8:]
+		params.P = int(binary.LittleEndian.Uint64(marshalled[:8]))
+
+		return nil
+	}
+
+	if len(marshalled) < paramsHeaderSize ||
+		marshalled[0] != paramsVersion {
+
+		return ErrMalformed
+	}
+	if KDF(marshalled[1]) != KDFArgon2id {
+		return ErrUnknownKDF
+	}
+	if len(marshalled) != argon2idParamsSize {
 		return ErrMalformed
 	}
 
-	params := &sk.Parameters
+	*params = Parameters{KDF: KDFArgon2id}
+	marshalled = marshalled[paramsHeaderSize:]
 	copy(params.Salt[:], marshalled[:KeySize])
 	marshalled = marshalled[KeySize:]
 	copy(params.Digest[:], marshalled[:sha256.Size])
 	marshalled = marshalled[sha256.Size:]
-	params.N = int(binary.LittleEndian.Uint64(marshalled[:8]))
-	marshalled = marshalled[8:]
-	params.R = int(binary.LittleEndian.Uint64(marshalled[:8]))
-	marshalled = marshalled[8:]
-	params.P = int(binary.LittleEndian.Uint64(marshalled[:8]))
+	params.Time = binary.LittleEndian.Uint32(marshalled[:4])
+	marshalled = marshalled[4:]
+	params.Memory = binary.LittleEndian.Uint32(marshalled[:4])
+	marshalled = marshalled[4:]
+	params.Threads = marshalled[0]
 
 	return nil
 }
@@ -221,15 +339,38 @@ func (sk *SecretKey) Decrypt(in []byte) ([]byte, error) {
 	return sk.Key.Decrypt(in)
 }
 
-// NewSecretKey returns a SecretKey structure based on the passed parameters.
+// NewSecretKey returns a SecretKey structure derived with scrypt using the
+// passed parameters.
 func NewSecretKey(password *[]byte, N, r, p int) (*SecretKey, error) { // nolint:gocritic
+	return newSecretKey(password, Parameters{
+		KDF: KDFScrypt,
+		N:   N,
+		R:   r,
+		P:   p,
+	})
+}
+
+// NewArgon2idSecretKey returns a SecretKey structure derived with Argon2id
+// using the passed parameters.  Memory is in KiB.
+func NewArgon2idSecretKey(password *[]byte, time, memory uint32,
+	threads uint8) (*SecretKey, error) {
+
+	return newSecretKey(password, Parameters{
+		KDF:     KDFArgon2id,
+		Time:    time,
+		Memory:  memory,
+		Threads: threads,
+	})
+}
+
+// newSecretKey returns a SecretKey structure derived with the key derivation
+// function and parameters of params and a new salt.
+func newSecretKey(password *[]byte, params Parameters) (*SecretKey, error) {
 	sk := SecretKey{
-		Key: (*CryptoKey)(&[KeySize]byte{}),
+		Key:        (*CryptoKey)(&[KeySize]byte{}),
+		Parameters: params,
 	}
 	// setup parameters
-	sk.Parameters.N = N
-	sk.Parameters.R = r
-	sk.Parameters.P = p
 	_, err := io.ReadFull(prng, sk.Parameters.Salt[:])
 	if err != nil {
 		return nil, err
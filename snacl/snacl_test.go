@@ -114,3 +114,60 @@ func TestDeriveKeyInvalid(t *testing.T) {
 		t.Errorf("unexpected DeriveKey key failure: %v", err)
 	}
 }
+
+func TestMarshalScryptUnversioned(t *testing.T) {
+	if len(params) != KeySize+32+24 {
+		t.Errorf("scrypt parameters are %d bytes, want %d",
+			len(params), KeySize+32+24)
+	}
+}
+
+func TestArgon2idSecretKey(t *testing.T) {
+	sk, err := NewArgon2idSecretKey(&password, 1, 64, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marshalled := sk.Marshal()
+	if marshalled[0] != paramsVersion || KDF(marshalled[1]) != KDFArgon2id {
+		t.Fatalf("unexpected header %x", marshalled[:2])
+	}
+
+	var sk2 SecretKey
+	if err := sk2.Unmarshal(marshalled); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if sk2.Parameters != sk.Parameters {
+		t.Fatalf("parameters not equal: got %+v, want %+v",
+			sk2.Parameters, sk.Parameters)
+	}
+	if err := sk2.DeriveKey(&password); err != nil {
+		t.Fatalf("unexpected DeriveKey error: %v", err)
+	}
+	if !bytes.Equal(sk2.Key[:], sk.Key[:]) {
+		t.Fatalf("keys not equal")
+	}
+
+	bogusPass := []byte("bogus")
+	if err := sk2.DeriveKey(&bogusPass); err != ErrInvalidPassword {
+		t.Fatalf("unexpected DeriveKey error: %v", err)
+	}
+}
+
+func TestUnmarshalUnknownKDF(t *testing.T) {
+	sk, err := NewArgon2idSecretKey(&password, 1, 64, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marshalled := sk.Marshal()
+
+	marshalled[1] = 0xff
+	var sk2 SecretKey
+	if err := sk2.Unmarshal(marshalled); err != ErrUnknownKDF {
+		t.Errorf("unexpected unmarshal error: %v", err)
+	}
+
+	marshalled[0] = paramsVersion + 1
+	if err := sk2.Unmarshal(marshalled); err != ErrMalformed {
+		t.Errorf("unexpected unmarshal error: %v", err)
+	}
+}
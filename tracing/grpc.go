@@ -0,0 +1,138 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// instrumentationName is the name of the tracer of the spans of the RPC
+// servers.
+const instrumentationName = "github.com/stroomnetwork/btcwallet/tracing"
+
+// metadataCarrier adapts the metadata of a gRPC request to a
+// propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+// Get returns the first value of the key.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set sets the value of the key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the keys of the metadata.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ServerInterceptor starts a server span for each request to a gRPC server,
+// as a child of the trace context sent by the client, if any.
+type ServerInterceptor struct {
+	tracer trace.Tracer
+}
+
+// NewServerInterceptor returns the interceptor starting the spans of the
+// requests to a gRPC server with the tracer provider.
+func NewServerInterceptor(provider trace.TracerProvider) *ServerInterceptor {
+	return &ServerInterceptor{
+		tracer: provider.Tracer(instrumentationName),
+	}
+}
+
+// start starts the span of a request to the full gRPC method.
+func (i *ServerInterceptor) start(ctx context.Context,
+	fullMethod string) (context.Context, trace.Span) {
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = Propagator.Extract(ctx, metadataCarrier(md))
+	}
+
+	service, method := splitFullMethod(fullMethod)
+	return i.tracer.Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", method),
+		),
+	)
+}
+
+// end records the status of the request and ends its span.
+func end(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(
+		attribute.Int64("rpc.grpc.status_code", int64(st.Code())),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, st.Message())
+	}
+	span.End()
+}
+
+// UnaryServerInterceptor returns the interceptor tracing unary requests.
+func (i *ServerInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		ctx, span := i.start(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		end(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor tracing streaming requests.
+// The span of a stream lasts until the stream ends.
+func (i *ServerInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+
+		ctx, span := i.start(ss.Context(), info.FullMethod)
+		err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+		end(span, err)
+		return err
+	}
+}
+
+// tracedStream is a server stream with the context of its span.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the span of the stream.
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// splitFullMethod splits a full gRPC method of the form /package.Service/Method
+// into its service and method.
+func splitFullMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	i := strings.LastIndex(fullMethod, "/")
+	if i < 0 {
+		return "", fullMethod
+	}
+	return fullMethod[:i], fullMethod[i+1:]
+}
@@ -0,0 +1,140 @@
+// Package tracing exports OpenTelemetry spans of the requests served by the
+// RPC servers, and propagates their trace context from the clients of the
+// servers to the wallet and chain backend calls made for them.
+//
+// Spans are exported over OTLP to a collector by the provider returned by
+// NewProvider, or kept in memory by the provider of NewMemoryProvider, which
+// is meant for tests.  The trace context of requests is read from the W3C
+// traceparent, tracestate and baggage headers (or gRPC metadata) with
+// Propagator.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// DefaultServiceName is the service name of the spans of a provider, unless
+// configured otherwise.
+const DefaultServiceName = "btcwallet"
+
+// Propagator reads and writes the trace context and baggage of requests in
+// the W3C formats.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{}, propagation.Baggage{},
+)
+
+// Config configures the export of spans over OTLP.
+type Config struct {
+	// Endpoint is the host:port of the OTLP/gRPC collector.
+	Endpoint string
+
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool
+
+	// Headers are sent with each export request, for example to
+	// authenticate with the collector.
+	Headers map[string]string
+
+	// SampleRate is the fraction of the traces started by the process
+	// that are sampled.  Traces started by clients are sampled if the
+	// client sampled them.
+	SampleRate float64
+
+	// ServiceName is the service name of the spans, or DefaultServiceName
+	// if empty.
+	ServiceName string
+}
+
+// NewProvider returns a tracer provider exporting spans over OTLP/gRPC as
+// configured.  The connection to the collector is established in the
+// background, so an unreachable collector only delays the export of spans.
+// The provider must be shut down to flush the spans not yet exported.
+func NewProvider(ctx context.Context, cfg *Config) (*sdktrace.TracerProvider,
+	error) {
+
+	if cfg.Endpoint == "" {
+		return nil, errors.New("no OTLP endpoint")
+	}
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		return nil, errors.New("sample rate must be between 0 and 1")
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(cfg.Headers) != 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sampler := sdktrace.ParentBased(
+		sdktrace.TraceIDRatioBased(cfg.SampleRate),
+	)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(serviceResource(cfg.ServiceName)),
+	), nil
+}
+
+// NewMemoryProvider returns a tracer provider sampling every span and
+// exporting them synchronously to the returned in-memory exporter.
+func NewMemoryProvider() (*sdktrace.TracerProvider,
+	*tracetest.InMemoryExporter) {
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithResource(serviceResource("")),
+	)
+	return provider, exporter
+}
+
+// serviceResource returns the resource describing the service of the spans.
+func serviceResource(name string) *resource.Resource {
+	if name == "" {
+		name = DefaultServiceName
+	}
+	return resource.NewSchemaless(
+		attribute.String("service.name", name),
+	)
+}
+
+// Shutdown shuts down the provider, exporting the spans not yet exported
+// within the timeout.
+func Shutdown(provider *sdktrace.TracerProvider, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return provider.Shutdown(ctx)
+}
+
+// Extract returns the context with the trace context and baggage of the HTTP
+// headers of a request.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return Propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// IsPropagationHeader returns whether the HTTP header or gRPC metadata key,
+// in any case, carries the trace context or baggage of a request.
+func IsPropagationHeader(key string) bool {
+	return http.CanonicalHeaderKey(key) == "Traceparent" ||
+		http.CanonicalHeaderKey(key) == "Tracestate" ||
+		http.CanonicalHeaderKey(key) == "Baggage"
+}
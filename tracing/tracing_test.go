@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-" +
+		"00f067aa0ba902b7-01"
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+// TestUnaryServerInterceptor checks that the span of a gRPC request is a
+// child of the trace context sent by the client, and that the handler is
+// called with the context of the span.
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	provider, exporter := NewMemoryProvider()
+	interceptor := NewServerInterceptor(provider).UnaryServerInterceptor()
+
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("traceparent", testTraceParent))
+	info := &grpc.UnaryServerInfo{
+		FullMethod: "/walletrpc.WalletService/Balance",
+	}
+	var handlerSpan trace.SpanContext
+	_, err := interceptor(ctx, nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return nil, status.Error(grpccodes.NotFound, "no account")
+		},
+	)
+	if status.Code(err) != grpccodes.NotFound {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "walletrpc.WalletService/Balance" {
+		t.Errorf("unexpected span name %q", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindServer {
+		t.Errorf("unexpected span kind %v", span.SpanKind)
+	}
+	if got := span.SpanContext.TraceID().String(); got != testTraceID {
+		t.Errorf("trace ID %s, want %s", got, testTraceID)
+	}
+	if got := span.Parent.SpanID().String(); got != testSpanID {
+		t.Errorf("parent span ID %s, want %s", got, testSpanID)
+	}
+	if !handlerSpan.Equal(span.SpanContext) {
+		t.Errorf("handler called in span %v, want %v", handlerSpan,
+			span.SpanContext)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("unexpected status %v", span.Status)
+	}
+
+	want := []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "walletrpc.WalletService"),
+		attribute.String("rpc.method", "Balance"),
+		attribute.Int64("rpc.grpc.status_code",
+			int64(grpccodes.NotFound)),
+	}
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	for _, attr := range want {
+		if attrs[attr.Key] != attr.Value {
+			t.Errorf("attribute %s: got %v, want %v", attr.Key,
+				attrs[attr.Key].Emit(), attr.Value.Emit())
+		}
+	}
+}
+
+// TestExtract checks that the trace context of the headers of an HTTP
+// request is extracted, and that its headers are recognized in any case.
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	header := make(http.Header)
+	header.Set("Traceparent", testTraceParent)
+	ctx := Extract(context.Background(), header)
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsRemote() || sc.TraceID().String() != testTraceID {
+		t.Fatalf("unexpected span context %v", sc)
+	}
+
+	for _, key := range []string{"traceparent", "Tracestate", "BAGGAGE"} {
+		if !IsPropagationHeader(key) {
+			t.Errorf("%s is not a propagation header", key)
+		}
+	}
+	if IsPropagationHeader("Authorization") {
+		t.Error("Authorization is a propagation header")
+	}
+}
+
+// TestNewProviderConfig checks that invalid configs are refused.
+func TestNewProviderConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []*Config{
+		{SampleRate: 1},
+		{Endpoint: "localhost:4317", SampleRate: -0.1},
+		{Endpoint: "localhost:4317", SampleRate: 1.5},
+	}
+	for _, cfg := range tests {
+		provider, err := NewProvider(context.Background(), cfg)
+		if err == nil {
+			_ = provider.Shutdown(context.Background())
+			t.Errorf("config %+v was accepted", cfg)
+		}
+	}
+}
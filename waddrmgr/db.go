@@ -205,6 +205,7 @@ var (
 	// scopeBucket -> scope -> metaBucket -> lastAccountNameKey
 	// scopeBucket -> scope -> coinTypePrivKey
 	// scopeBucket -> scope -> coinTypePubKey
+	// scopeBucket -> scope -> retiredAcctBucket
 	scopeBucketName = []byte("scope")
 
 	// coinTypePrivKeyName is the name of the key within a particular scope
@@ -261,6 +262,21 @@ var (
 	// addresses hash if the address has been used or not.
 	usedAddrBucketName = []byte("usedaddrs")
 
+	// retiredAcctBucketName is the name of the bucket that maps each
+	// account derived from a root key that was rotated out to the account
+	// derived from the next root key that replaces it.  The bucket is
+	// only created once a root key is rotated.
+	//
+	// account_id => account_id
+	retiredAcctBucketName = []byte("retiredacct")
+
+	// coldAcctBucketName is the name of the bucket that stores the
+	// spending mode of the watch-only accounts that were made cold.  The
+	// bucket is only created once the mode of an account is set.
+	//
+	// account_id => spending_mode
+	coldAcctBucketName = []byte("coldacct")
+
 	// meta is used to store meta-data about the address manager
 	// e.g. last account number
 	metaBucketName = []byte("meta")
@@ -1332,6 +1348,114 @@ func putLastAccount(ns walletdb.ReadWriteBucket, scope *KeyScope,
 	return nil
 }
 
+// fetchAccountSuccessor returns the account that replaced a retired account,
+// and false if the account isn't retired.
+func fetchAccountSuccessor(ns walletdb.ReadBucket, scope *KeyScope,
+	account uint32) (uint32, bool, error) {
+
+	scopedBucket, err := fetchReadScopeBucket(ns, scope)
+	if err != nil {
+		return 0, false, err
+	}
+
+	bucket := scopedBucket.NestedReadBucket(retiredAcctBucketName)
+	if bucket == nil {
+		return 0, false, nil
+	}
+
+	val := bucket.Get(uint32ToBytes(account))
+	if val == nil {
+		return 0, false, nil
+	}
+	if len(val) != 4 {
+		str := fmt.Sprintf("malformed successor of account %d stored "+
+			"in database", account)
+		return 0, false, managerError(ErrDatabase, str, nil)
+	}
+
+	return binary.LittleEndian.Uint32(val), true, nil
+}
+
+// putAccountSuccessor retires an account, recording the account that
+// replaces it.
+func putAccountSuccessor(ns walletdb.ReadWriteBucket, scope *KeyScope,
+	account, successor uint32) error {
+
+	scopedBucket, err := fetchWriteScopeBucket(ns, scope)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := scopedBucket.CreateBucketIfNotExists(
+		retiredAcctBucketName,
+	)
+	if err != nil {
+		str := "failed to create retired account bucket"
+		return managerError(ErrDatabase, str, err)
+	}
+
+	err = bucket.Put(uint32ToBytes(account), uint32ToBytes(successor))
+	if err != nil {
+		str := fmt.Sprintf("failed to retire account %d", account)
+		return managerError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// fetchAccountSpendingMode returns the spending mode set for an account, and
+// false if none was set.
+func fetchAccountSpendingMode(ns walletdb.ReadBucket, scope *KeyScope,
+	account uint32) (SpendingMode, bool, error) {
+
+	scopedBucket, err := fetchReadScopeBucket(ns, scope)
+	if err != nil {
+		return 0, false, err
+	}
+
+	bucket := scopedBucket.NestedReadBucket(coldAcctBucketName)
+	if bucket == nil {
+		return 0, false, nil
+	}
+
+	val := bucket.Get(uint32ToBytes(account))
+	if val == nil {
+		return 0, false, nil
+	}
+	if len(val) != 1 {
+		str := fmt.Sprintf("malformed spending mode of account %d "+
+			"stored in database", account)
+		return 0, false, managerError(ErrDatabase, str, nil)
+	}
+
+	return SpendingMode(val[0]), true, nil
+}
+
+// putAccountSpendingMode stores the spending mode of an account.
+func putAccountSpendingMode(ns walletdb.ReadWriteBucket, scope *KeyScope,
+	account uint32, mode SpendingMode) error {
+
+	scopedBucket, err := fetchWriteScopeBucket(ns, scope)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := scopedBucket.CreateBucketIfNotExists(
+		coldAcctBucketName,
+	)
+	if err != nil {
+		str := "failed to create cold account bucket"
+		return managerError(ErrDatabase, str, err)
+	}
+
+	err = bucket.Put(uint32ToBytes(account), []byte{byte(mode)})
+	if err != nil {
+		str := fmt.Sprintf("failed to set spending mode of account %d",
+			account)
+		return managerError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
 // deserializeAddressRow deserializes the passed serialized address
 // information.  This is used as a common base for the various address types to
 // deserialize the common parts.
@@ -129,3 +129,104 @@ func TestStoreMaxReorgDepth(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestRepairSyncState ensures that a synced-to block which disagrees with the
+// block hash index is detected and rewound to the last indexed block below it.
+func TestRepairSyncState(t *testing.T) {
+	t.Parallel()
+
+	teardown, db, _ := setupManager(t)
+	defer teardown()
+
+	blocks := make([]*BlockStamp, 0, 5)
+	for i := int32(1000); i < 1005; i++ {
+		var hash chainhash.Hash
+		binary.BigEndian.PutUint32(hash[:], uint32(i))
+		blocks = append(blocks, &BlockStamp{Hash: hash, Height: i})
+	}
+
+	// Write the blocks, then replace the index entry of the tip and
+	// corrupt the one below it.
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		for _, block := range blocks {
+			if err := PutSyncedTo(ns, block); err != nil {
+				return err
+			}
+		}
+
+		tip := blocks[len(blocks)-1]
+		if err := addBlockHash(ns, tip.Height, chainhash.Hash{1}); err != nil {
+			return err
+		}
+		var rawHeight [4]byte
+		binary.BigEndian.PutUint32(rawHeight[:], uint32(tip.Height-1))
+		return ns.NestedReadWriteBucket(syncBucketName).Put(
+			rawHeight[:], []byte{1, 2, 3},
+		)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		problems, err := CheckSyncState(ns)
+		if err != nil {
+			return err
+		}
+		if len(problems) != 2 {
+			return fmt.Errorf("expected 2 problems, got %v",
+				problems)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		repaired, err := RepairSyncState(ns)
+		if err != nil {
+			return err
+		}
+		if len(repaired) != 2 {
+			return fmt.Errorf("expected 2 repairs, got %v",
+				repaired)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The malformed entry is gone, so the tip must have been rewound past
+	// it, and the sync state must now be consistent.
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		syncedTo, err := fetchSyncedTo(ns)
+		if err != nil {
+			return err
+		}
+		want := blocks[len(blocks)-3]
+		if syncedTo.Height != want.Height || syncedTo.Hash != want.Hash {
+			return fmt.Errorf("expected synced to block %d (%v), "+
+				"got %d (%v)", want.Height, want.Hash,
+				syncedTo.Height, syncedTo.Hash)
+		}
+
+		problems, err := CheckSyncState(ns)
+		if err != nil {
+			return err
+		}
+		if len(problems) != 0 {
+			return fmt.Errorf("unexpected problems after "+
+				"repair: %v", problems)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
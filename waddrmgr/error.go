@@ -143,6 +143,11 @@ const (
 	// ErrAccountNotCached is returned when we attempt to perform an
 	// operation that relies on an account begin cached but it isn't.
 	ErrAccountNotCached
+
+	// ErrAccountRetired indicates that a new address was requested from
+	// an account derived from a root key that was rotated out.  Only its
+	// existing addresses are still used.
+	ErrAccountRetired
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -170,6 +175,7 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrEmptyPassphrase:   "ErrEmptyPassphrase",
 	ErrScopeNotFound:     "ErrScopeNotFound",
 	ErrAccountNotCached:  "ErrAccountNotCached",
+	ErrAccountRetired:    "ErrAccountRetired",
 }
 
 // String returns the ErrorCode as a human-readable name.
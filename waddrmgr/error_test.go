@@ -39,6 +39,7 @@ func TestErrorCodeStringer(t *testing.T) {
 		{waddrmgr.ErrWrongNet, "ErrWrongNet"},
 		{waddrmgr.ErrCallBackBreak, "ErrCallBackBreak"},
 		{waddrmgr.ErrEmptyPassphrase, "ErrEmptyPassphrase"},
+		{waddrmgr.ErrAccountRetired, "ErrAccountRetired"},
 		{0xffff, "Unknown ErrorCode (65535)"},
 	}
 	t.Logf("Running %d tests", len(tests))
@@ -7,6 +7,7 @@ package waddrmgr
 import (
 	"crypto/rand"
 	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -96,10 +97,57 @@ func isReservedAccountNum(acct uint32) bool {
 	return acct == ImportedAddrAccount
 }
 
-// ScryptOptions is used to hold the scrypt parameters needed when deriving new
-// passphrase keys.
+// ScryptOptions is used to hold the parameters of the key derivation function
+// needed when deriving new passphrase keys.  Keys are derived with scrypt using
+// N, R and P, unless Argon2 is set.
 type ScryptOptions struct {
 	N, R, P int
+
+	// Argon2, if not nil, selects Argon2id with these parameters instead
+	// of scrypt.
+	Argon2 *Argon2Options
+}
+
+// Argon2Options is used to hold the Argon2id parameters needed when deriving
+// new passphrase keys.  Memory is in KiB.
+type Argon2Options struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+// kdfOptions returns the options the key with the passed parameters was
+// derived with.
+func kdfOptions(params *snacl.Parameters) *ScryptOptions {
+	if params.KDF == snacl.KDFArgon2id {
+		return &ScryptOptions{Argon2: &Argon2Options{
+			Time:    params.Time,
+			Memory:  params.Memory,
+			Threads: params.Threads,
+		}}
+	}
+	return &ScryptOptions{N: params.N, R: params.R, P: params.P}
+}
+
+// weakerThan returns whether keys derived with o are cheaper to brute force
+// than keys derived with other.  Argon2id is considered stronger than scrypt,
+// and parameters of the same function are compared by their cost.
+func (o *ScryptOptions) weakerThan(other *ScryptOptions) bool {
+	switch {
+	case o.Argon2 == nil && other.Argon2 != nil:
+		return true
+
+	case o.Argon2 != nil && other.Argon2 == nil:
+		return false
+
+	case o.Argon2 != nil:
+		return uint64(o.Argon2.Memory)*uint64(o.Argon2.Time) <
+			uint64(other.Argon2.Memory)*uint64(other.Argon2.Time)
+
+	default:
+		return uint64(o.N)*uint64(o.R)*uint64(o.P) <
+			uint64(other.N)*uint64(other.R)*uint64(other.P)
+	}
 }
 
 // OpenCallbacks houses caller-provided callbacks that may be called when
@@ -125,6 +173,15 @@ var DefaultScryptOptions = ScryptOptions{
 	P: 1,
 }
 
+// DefaultArgon2Options are the default options used with Argon2id.
+var DefaultArgon2Options = ScryptOptions{
+	Argon2: &Argon2Options{
+		Time:    snacl.DefaultArgon2Time,
+		Memory:  snacl.DefaultArgon2Memory,
+		Threads: snacl.DefaultArgon2Threads,
+	},
+}
+
 // FastScryptOptions are the scrypt options that should be used for testing
 // purposes only where speed is more important than security.
 var FastScryptOptions = ScryptOptions{
@@ -221,6 +278,44 @@ type AccountProperties struct {
 	// AddrSchema, if non-nil, specifies an address schema override for
 	// address generation only applicable to the account.
 	AddrSchema *ScopeAddrSchema
+
+	// IsRetired indicates whether the account was derived from a root key
+	// that was rotated out.  New addresses of a retired account are
+	// derived from the account that replaced it.
+	IsRetired bool
+
+	// SpendingMode is how the outputs of the account are spent: by the
+	// wallet for hot accounts, or by an external signer for cold ones.
+	SpendingMode SpendingMode
+}
+
+// SpendingMode describes how the outputs of an account are spent.
+type SpendingMode uint8
+
+const (
+	// SpendingModeHot is the mode of the accounts whose spends are
+	// created by the wallet and signed with the keys it holds, if any.
+	// The spends of hot watch-only accounts are left unsigned to the
+	// caller.
+	SpendingModeHot SpendingMode = iota
+
+	// SpendingModeCold is the mode of the watch-only accounts, such as
+	// the accounts imported from an extended public key with
+	// ImportAccount, made cold with SetAccountSpendingMode.  Their spends
+	// are handed off to be signed outside of the wallet.
+	SpendingModeCold
+)
+
+// String returns the name of the spending mode.
+func (m SpendingMode) String() string {
+	switch m {
+	case SpendingModeHot:
+		return "hot"
+	case SpendingModeCold:
+		return "cold"
+	default:
+		return fmt.Sprintf("unknown spending mode %d", uint8(m))
+	}
 }
 
 // unlockDeriveInfo houses the information needed to derive a private key for a
@@ -241,6 +336,12 @@ type SecretKeyGenerator func(
 // defaultNewSecretKey returns a new secret key.  See newSecretKey.
 func defaultNewSecretKey(passphrase *[]byte,
 	config *ScryptOptions) (*snacl.SecretKey, error) {
+
+	if config.Argon2 != nil {
+		return snacl.NewArgon2idSecretKey(passphrase,
+			config.Argon2.Time, config.Argon2.Memory,
+			config.Argon2.Threads)
+	}
 	return snacl.NewSecretKey(passphrase, config.N, config.R, config.P)
 }
 
@@ -420,6 +521,27 @@ func (m *Manager) IsWatchOnlyAccount(ns walletdb.ReadBucket, keyScope KeyScope,
 	return scopedMgr.IsWatchOnlyAccount(ns, account)
 }
 
+// AccountSpendingMode returns whether the account with the given key scope is
+// hot, with its spends created by the wallet, or cold, with its spends signed
+// outside of the wallet.  Every account of a watch-only wallet is cold, while
+// the imported account of other wallets is hot.
+func (m *Manager) AccountSpendingMode(ns walletdb.ReadBucket,
+	keyScope KeyScope, account uint32) (SpendingMode, error) {
+
+	if m.WatchOnly() {
+		return SpendingModeCold, nil
+	}
+	if account == ImportedAddrAccount {
+		return SpendingModeHot, nil
+	}
+
+	scopedMgr, err := m.FetchScopedKeyManager(keyScope)
+	if err != nil {
+		return 0, err
+	}
+	return scopedMgr.AccountSpendingMode(ns, account)
+}
+
 // lock performs a best try effort to remove and zero all secret keys associated
 // with the address manager.
 //
@@ -685,6 +807,116 @@ func (m *Manager) NeuterRootKey(ns walletdb.ReadWriteBucket) error {
 	return ns.NestedReadWriteBucket(mainBucketName).Delete(masterHDPrivName)
 }
 
+// RotateRoot replaces the master HD root key of the manager with a new root
+// key.  In every scope, each account derived from the previous root key is
+// retired and renamed after the fingerprint of that key, and is replaced by an
+// account at the same BIP0044 account index of the new root key, which takes
+// over its name.  Retired accounts keep their addresses and private keys, so
+// their outputs stay spendable and are still found when rescanning, but new
+// addresses are only derived from their successors.  See ActiveAccount.
+//
+// The manager must be unlocked, and its root private key must not have been
+// neutered.
+func (m *Manager) RotateRoot(ns walletdb.ReadWriteBucket,
+	rootKey *hdkeychain.ExtendedKey) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.WatchOnly() {
+		return managerError(ErrWatchingOnly, errWatchingOnly, nil)
+	}
+	if m.IsLocked() {
+		return managerError(ErrLocked, errLocked, nil)
+	}
+	if !rootKey.IsPrivate() {
+		str := "new root key is not a private key"
+		return managerError(ErrKeyChain, str, nil)
+	}
+
+	// The previous root key is needed to name the accounts it is
+	// retiring, and must differ from the new one.
+	masterRootPrivEnc, _ := fetchMasterHDKeys(ns)
+	if masterRootPrivEnc == nil {
+		return managerError(ErrWatchingOnly, "", nil)
+	}
+	serializedMasterRootPriv, err := m.cryptoKeyPriv.Decrypt(
+		masterRootPrivEnc,
+	)
+	if err != nil {
+		str := "failed to decrypt master root serialized private key"
+		return managerError(ErrLocked, str, err)
+	}
+	oldRootKey, err := hdkeychain.NewKeyFromString(
+		string(serializedMasterRootPriv),
+	)
+	zero.Bytes(serializedMasterRootPriv)
+	if err != nil {
+		str := "failed to create master extended private key"
+		return managerError(ErrKeyChain, str, err)
+	}
+	defer oldRootKey.Zero()
+
+	oldFingerprint, err := rootFingerprint(oldRootKey)
+	if err != nil {
+		return err
+	}
+	newFingerprint, err := rootFingerprint(rootKey)
+	if err != nil {
+		return err
+	}
+	if oldFingerprint == newFingerprint {
+		str := "new root key has the fingerprint of the current root key"
+		return managerError(ErrKeyChain, str, nil)
+	}
+
+	rootPubKey, err := rootKey.Neuter()
+	if err != nil {
+		str := "failed to neuter master extended key"
+		return managerError(ErrKeyChain, str, err)
+	}
+	masterHDPrivKeyEnc, err := m.cryptoKeyPriv.Encrypt(
+		[]byte(rootKey.String()),
+	)
+	if err != nil {
+		str := "failed to encrypt master root private key"
+		return managerError(ErrCrypto, str, err)
+	}
+	masterHDPubKeyEnc, err := m.cryptoKeyPub.Encrypt(
+		[]byte(rootPubKey.String()),
+	)
+	if err != nil {
+		str := "failed to encrypt master root public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	err = putMasterHDKeys(ns, masterHDPrivKeyEnc, masterHDPubKeyEnc)
+	if err != nil {
+		return maybeConvertDbError(err)
+	}
+
+	retiredSuffix := fmt.Sprintf("-%08x", oldFingerprint)
+	for _, scopedMgr := range m.scopedManagers {
+		err := scopedMgr.rotateRoot(ns, rootKey, retiredSuffix)
+		if err != nil {
+			return maybeConvertDbError(err)
+		}
+	}
+
+	return nil
+}
+
+// rootFingerprint returns the BIP0032 fingerprint of a root key, the first
+// four bytes of the hash of its public key.
+func rootFingerprint(rootKey *hdkeychain.ExtendedKey) (uint32, error) {
+	pubKey, err := rootKey.ECPubKey()
+	if err != nil {
+		str := "failed to derive master public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	hash := btcutil.Hash160(pubKey.SerializeCompressed())
+	return binary.BigEndian.Uint32(hash[:4]), nil
+}
+
 // Address returns a managed address given the passed address if it is known to
 // the address manager. A managed address differs from the passed address in
 // that it also potentially contains extra information needed to sign
@@ -872,9 +1104,12 @@ func (m *Manager) ChainParams() *chaincfg.Params {
 // ChangePassphrase changes either the public or private passphrase to the
 // provided value depending on the private flag.  In order to change the
 // private password, the address manager must not be watching-only.  The new
-// passphrase keys are derived using the scrypt parameters in the options, so
-// changing the passphrase may be used to bump the computational difficulty
-// needed to brute force the passphrase.
+// passphrase keys are derived using the key derivation function and parameters
+// in the options, so changing the passphrase may be used to bump the
+// computational difficulty needed to brute force the passphrase, or to switch
+// from scrypt to Argon2id.  If the current key was derived with stronger
+// options, those are kept instead, so changing the passphrase never weakens
+// its key.
 func (m *Manager) ChangePassphrase(ns walletdb.ReadWriteBucket, oldPassphrase,
 	newPassphrase []byte, private bool, config *ScryptOptions) error {
 
@@ -911,6 +1146,10 @@ func (m *Manager) ChangePassphrase(ns walletdb.ReadWriteBucket, oldPassphrase,
 	}
 	defer secretKey.Zero()
 
+	if current := kdfOptions(&secretKey.Parameters); config.weakerThan(current) {
+		config = current
+	}
+
 	// Generate a new master key from the passphrase which is used to secure
 	// the actual secret keys.
 	newMasterKey, err := newSecretKey(&newPassphrase, config)
@@ -1784,8 +2023,11 @@ func createManagerKeyScope(ns walletdb.ReadWriteBucket,
 // address manager in order to gain access to any private keys and
 // information.
 //
-// If a config structure is passed to the function, that configuration
-// will override the defaults.
+// The key of the private passphrase is derived with the options of the
+// passed config, or DefaultArgon2Options if it is nil.  The key of the public
+// passphrase, which is derived every time the manager is opened, is derived
+// with scrypt: with the config if it selects scrypt, or DefaultScryptOptions
+// otherwise.
 //
 // A ManagerError with an error code of ErrAlreadyExists will be
 // returned the address manager already exists in the specified
@@ -1821,12 +2063,16 @@ func Create(ns walletdb.ReadWriteBucket, rootKey *hdkeychain.ExtendedKey,
 	}
 
 	if config == nil {
-		config = &DefaultScryptOptions
+		config = &DefaultArgon2Options
+	}
+	pubConfig := config
+	if config.Argon2 != nil {
+		pubConfig = &DefaultScryptOptions
 	}
 
 	// Generate new master keys.  These master keys are used to protect the
 	// crypto keys that will be generated next.
-	masterKeyPub, err := newSecretKey(&pubPassphrase, config)
+	masterKeyPub, err := newSecretKey(&pubPassphrase, pubConfig)
 	if err != nil {
 		str := "failed to master public key"
 		return managerError(ErrCrypto, str, err)
@@ -2310,6 +2310,93 @@ func TestEncryptDecrypt(t *testing.T) {
 	}
 }
 
+// TestChangePassphraseArgon2id tests that changing the private passphrase
+// upgrades its key from scrypt to Argon2id, that the upgraded key survives
+// reopening the manager, and that a later change with scrypt options doesn't
+// downgrade it.
+func TestChangePassphraseArgon2id(t *testing.T) {
+	t.Parallel()
+
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+
+	argon2 := &ScryptOptions{Argon2: &Argon2Options{
+		Time: 1, Memory: 64, Threads: 1,
+	}}
+	newPass := []byte("new-passphrase")
+	changePass := func(old, new []byte, config *ScryptOptions) {
+		t.Helper()
+
+		err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return mgr.ChangePassphrase(ns, old, new, true, config)
+		})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, snacl.KDFScrypt, mgr.masterKeyPriv.Parameters.KDF)
+	changePass(privPassphrase, newPass, argon2)
+	require.Equal(t, snacl.KDFArgon2id, mgr.masterKeyPriv.Parameters.KDF)
+
+	changePass(newPass, privPassphrase, fastScrypt)
+	require.Equal(t, snacl.KDFArgon2id, mgr.masterKeyPriv.Parameters.KDF)
+	require.EqualValues(t, 64, mgr.masterKeyPriv.Parameters.Memory)
+
+	var reopened *Manager
+	err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		var err error
+		reopened, err = Open(ns, pubPassphrase, &chaincfg.MainNetParams)
+		if err != nil {
+			return err
+		}
+		return reopened.Unlock(ns, privPassphrase)
+	})
+	require.NoError(t, err)
+	defer reopened.Close()
+	require.Equal(t, snacl.KDFArgon2id,
+		reopened.masterKeyPriv.Parameters.KDF)
+}
+
+// TestCreateArgon2id tests that a manager created with Argon2id options
+// derives the key of its private passphrase with Argon2id, and the key of its
+// public passphrase with scrypt.
+func TestCreateArgon2id(t *testing.T) {
+	t.Parallel()
+
+	teardown, db := emptyDB(t)
+	defer teardown()
+
+	argon2 := &ScryptOptions{Argon2: &Argon2Options{
+		Time: 1, Memory: 64, Threads: 1,
+	}}
+	var mgr *Manager
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		err = Create(
+			ns, rootKey, pubPassphrase, privPassphrase,
+			&chaincfg.MainNetParams, argon2, time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+		mgr, err = Open(ns, pubPassphrase, &chaincfg.MainNetParams)
+		if err != nil {
+			return err
+		}
+		return mgr.Unlock(ns, privPassphrase)
+	})
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	require.Equal(t, snacl.KDFArgon2id, mgr.masterKeyPriv.Parameters.KDF)
+	require.EqualValues(t, 64, mgr.masterKeyPriv.Parameters.Memory)
+	require.Equal(t, snacl.KDFScrypt, mgr.masterKeyPub.Parameters.KDF)
+}
+
 // TestScopedKeyManagerManagement tests that callers are able to properly
 // create, retrieve, and utilize new scoped managers outside the set of default
 // created scopes.
@@ -2659,6 +2746,173 @@ func TestRootHDKeyNeutering(t *testing.T) {
 	}
 }
 
+// TestRootKeyRotation tests that rotating the root key retires the accounts
+// derived from the previous root key, and replaces them with accounts of the
+// new root key under the same names.
+func TestRootKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	teardown, db := emptyDB(t)
+	defer teardown()
+
+	var mgr *Manager
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		err = Create(
+			ns, rootKey, pubPassphrase, privPassphrase,
+			&chaincfg.MainNetParams, fastScrypt, time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+
+		mgr, err = Open(ns, pubPassphrase, &chaincfg.MainNetParams)
+		if err != nil {
+			return err
+		}
+
+		return mgr.Unlock(ns, privPassphrase)
+	})
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	scopedMgr, err := mgr.FetchScopedKeyManager(KeyScopeBIP0084)
+	require.NoError(t, err)
+
+	// Derive an address of the default account, and create a second
+	// account, before rotating the root key.
+	var oldAddr ManagedAddress
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+
+		addrs, err := scopedMgr.NextExternalAddresses(
+			ns, DefaultAccountNum, 1,
+		)
+		if err != nil {
+			return err
+		}
+		oldAddr = addrs[0]
+
+		_, err = scopedMgr.NewAccount(ns, "savings")
+		return err
+	})
+	require.NoError(t, err)
+
+	newSeed := bytes.Repeat([]byte{0x01}, hdkeychain.RecommendedSeedLen)
+	newRootKey, err := hdkeychain.NewMaster(
+		newSeed, &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return mgr.RotateRoot(ns, newRootKey)
+	})
+	require.NoError(t, err)
+
+	oldFingerprint, err := rootFingerprint(rootKey)
+	require.NoError(t, err)
+	suffix := fmt.Sprintf("-%08x", oldFingerprint)
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+
+		// The accounts of the previous root key are renamed and
+		// replaced, in order, by accounts taking over their names.
+		for name, account := range map[string]uint32{
+			defaultAccountName: DefaultAccountNum,
+			"savings":          1,
+		} {
+			props, err := scopedMgr.AccountProperties(ns, account)
+			require.NoError(t, err)
+			require.True(t, props.IsRetired)
+			require.Equal(t, name+suffix, props.AccountName)
+
+			active, err := scopedMgr.ActiveAccount(ns, account)
+			require.NoError(t, err)
+			require.Equal(t, account+2, active)
+
+			lookup, err := scopedMgr.LookupAccount(ns, name)
+			require.NoError(t, err)
+			require.Equal(t, active, lookup)
+
+			props, err = scopedMgr.AccountProperties(ns, active)
+			require.NoError(t, err)
+			require.False(t, props.IsRetired)
+			require.Equal(t, name, props.AccountName)
+
+			// The replacing account is at the same BIP0044
+			// account index of the new root key.
+			path := []uint32{
+				KeyScopeBIP0084.Purpose +
+					hdkeychain.HardenedKeyStart,
+				KeyScopeBIP0084.Coin +
+					hdkeychain.HardenedKeyStart,
+				account + hdkeychain.HardenedKeyStart,
+			}
+			acctKey := newRootKey
+			for _, index := range path {
+				acctKey, err = acctKey.Derive(index)
+				require.NoError(t, err)
+			}
+			wantPubKey, err := acctKey.ECPubKey()
+			require.NoError(t, err)
+			pubKey, err := props.AccountPubKey.ECPubKey()
+			require.NoError(t, err)
+			require.True(t, wantPubKey.IsEqual(pubKey))
+
+			// No new address is derived from the retired account.
+			_, err = scopedMgr.NextExternalAddresses(ns, account, 1)
+			require.True(t, IsError(err, ErrAccountRetired))
+			_, err = scopedMgr.NextInternalAddresses(ns, account, 1)
+			require.True(t, IsError(err, ErrAccountRetired))
+
+			_, err = scopedMgr.NextExternalAddresses(ns, active, 1)
+			require.NoError(t, err)
+		}
+
+		// The addresses of the retired account keep their private
+		// keys, so their outputs can still be spent.
+		addr, err := mgr.Address(ns, oldAddr.Address())
+		require.NoError(t, err)
+		pubKeyAddr, ok := addr.(ManagedPubKeyAddress)
+		require.True(t, ok)
+		require.EqualValues(
+			t, DefaultAccountNum, pubKeyAddr.InternalAccount(),
+		)
+		_, err = pubKeyAddr.PrivKey()
+		require.NoError(t, err)
+
+		// Rotating to the current root key again is refused.
+		err = mgr.RotateRoot(ns, newRootKey)
+		require.True(t, IsError(err, ErrKeyChain))
+
+		// A second rotation only retires the accounts of the current
+		// root key, and accounts retired earlier follow their chain of
+		// successors.
+		newSeed[0] = 0x02
+		thirdRootKey, err := hdkeychain.NewMaster(
+			newSeed, &chaincfg.MainNetParams,
+		)
+		require.NoError(t, err)
+		require.NoError(t, mgr.RotateRoot(ns, thirdRootKey))
+
+		active, err := scopedMgr.ActiveAccount(ns, DefaultAccountNum)
+		require.NoError(t, err)
+		require.Equal(t, uint32(4), active)
+
+		lookup, err := scopedMgr.LookupAccount(ns, defaultAccountName)
+		require.NoError(t, err)
+		require.Equal(t, active, lookup)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 // TestNewRawAccount tests that callers are able to properly create, and use
 // raw accounts created with only an account number, and not a string which is
 // eventually mapped to an account number.
@@ -2848,6 +3102,53 @@ func TestNewRawAccountHybrid(t *testing.T) {
 		t.Fatalf("unable to create new account: %v", err)
 	}
 
+	// Every account is hot until the watch-only account is made cold,
+	// even though the manager is locked.  The accounts with keys and the
+	// imported account can't be made cold.
+	checkSpendingModes := func(coldMode SpendingMode) {
+		err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+			ns := tx.ReadBucket(waddrmgrNamespaceKey)
+			for account, want := range map[uint32]SpendingMode{
+				DefaultAccountNum:   SpendingModeHot,
+				ImportedAddrAccount: SpendingModeHot,
+				accountNum:          coldMode,
+			} {
+				mode, err := mgr.AccountSpendingMode(
+					ns, KeyScopeBIP0044, account,
+				)
+				require.NoError(t, err)
+				require.Equal(t, want, mode, "account %d",
+					account)
+
+				props, err := scopedMgr.AccountProperties(
+					ns, account,
+				)
+				require.NoError(t, err)
+				require.Equal(t, want, props.SpendingMode)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	setSpendingMode := func(account uint32, mode SpendingMode) error {
+		return walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return scopedMgr.SetAccountSpendingMode(
+				ns, account, mode,
+			)
+		})
+	}
+	checkSpendingModes(SpendingModeHot)
+	require.NoError(t, setSpendingMode(accountNum, SpendingModeCold))
+	checkSpendingModes(SpendingModeCold)
+	for _, account := range []uint32{DefaultAccountNum, ImportedAddrAccount} {
+		err := setSpendingMode(account, SpendingModeCold)
+		require.True(t, IsError(err, ErrInvalidAccount), err)
+	}
+	require.NoError(t, setSpendingMode(accountNum, SpendingModeHot))
+	checkSpendingModes(SpendingModeHot)
+	require.NoError(t, setSpendingMode(accountNum, SpendingModeCold))
+
 	testNewRawAccount(t, mgr, db, accountNum, scopedMgr)
 }
 
@@ -601,8 +601,19 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 		props.MasterKeyFingerprint = acctInfo.masterKeyFingerprint
 		props.IsWatchOnly = s.rootManager.WatchOnly() ||
 			acctInfo.acctKeyPriv == nil
+		props.SpendingMode, err = s.spendingMode(ns, account, acctInfo)
+		if err != nil {
+			return nil, err
+		}
 		props.AddrSchema = acctInfo.addrSchema
 
+		_, props.IsRetired, err = fetchAccountSuccessor(
+			ns, &s.scope, account,
+		)
+		if err != nil {
+			return nil, err
+		}
+
 		// Export the account public key with the correct version
 		// corresponding to the manager's key scope for non-watch-only
 		// accounts. This isn't done for watch-only accounts to maintain
@@ -622,6 +633,9 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 	} else {
 		props.AccountName = ImportedAddrAccountName // reserved, nonchangable
 		props.IsWatchOnly = s.rootManager.WatchOnly()
+		if props.IsWatchOnly {
+			props.SpendingMode = SpendingModeCold
+		}
 
 		// Could be more efficient if this was tracked by the db.
 		var importedKeyCount uint32
@@ -1009,6 +1023,17 @@ func (s *ScopedKeyManager) nextAddresses(ns walletdb.ReadWriteBucket,
 	account uint32, numAddresses uint32, internal bool) ([]ManagedAddress,
 	error) {
 
+	// Accounts of a root key that was rotated out keep their addresses,
+	// but no new ones are derived from them.
+	_, retired, err := fetchAccountSuccessor(ns, &s.scope, account)
+	if err != nil {
+		return nil, err
+	}
+	if retired {
+		str := fmt.Sprintf("account %d is retired", account)
+		return nil, managerError(ErrAccountRetired, str, nil)
+	}
+
 	// The next address can only be generated for accounts that have
 	// already been created.
 	acctInfo, err := s.loadAccountInfo(ns, account)
@@ -1718,6 +1743,220 @@ func (s *ScopedKeyManager) newAccount(ns walletdb.ReadWriteBucket,
 	return putLastAccount(ns, &s.scope, account)
 }
 
+// ActiveAccount returns the account that new addresses of the given account
+// are derived from: the account itself, or if it was retired when the root key
+// was rotated, the account of the current root key that replaced it.
+func (s *ScopedKeyManager) ActiveAccount(ns walletdb.ReadBucket,
+	account uint32) (uint32, error) {
+
+	// An account retired by several rotations was replaced by an
+	// account that was retired in turn.
+	for {
+		successor, retired, err := fetchAccountSuccessor(
+			ns, &s.scope, account,
+		)
+		if err != nil || !retired {
+			return account, err
+		}
+		account = successor
+	}
+}
+
+// rotateRoot derives the cointype keys of the scope from a new root key, and
+// replaces each account derived from the previous root key with an account at
+// the same BIP0044 account index of the new root key.  The replacing account
+// takes over the name of the account, which is retired and renamed with the
+// given suffix.
+//
+// NOTE: This function MUST be called with the manager lock held for writes.
+func (s *ScopedKeyManager) rotateRoot(ns walletdb.ReadWriteBucket,
+	root *hdkeychain.ExtendedKey, retiredSuffix string) error {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	coinTypeKeyPriv, err := deriveCoinTypeKey(root, s.scope)
+	if err != nil {
+		str := "failed to derive cointype extended key"
+		return managerError(ErrKeyChain, str, err)
+	}
+	defer coinTypeKeyPriv.Zero()
+	coinTypeKeyPub, err := coinTypeKeyPriv.Neuter()
+	if err != nil {
+		str := "failed to convert cointype private key"
+		return managerError(ErrKeyChain, str, err)
+	}
+	coinTypePubEnc, err := s.rootManager.cryptoKeyPub.Encrypt(
+		[]byte(coinTypeKeyPub.String()),
+	)
+	if err != nil {
+		str := "failed to encrypt cointype public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	coinTypePrivEnc, err := s.rootManager.cryptoKeyPriv.Encrypt(
+		[]byte(coinTypeKeyPriv.String()),
+	)
+	if err != nil {
+		str := "failed to encrypt cointype private key"
+		return managerError(ErrCrypto, str, err)
+	}
+	err = putCoinTypeKeys(ns, &s.scope, coinTypePubEnc, coinTypePrivEnc)
+	if err != nil {
+		return err
+	}
+
+	// Only the accounts derived from the previous root key are replaced.
+	// Imported and watch-only accounts, and accounts that were already
+	// retired, are left as they are.
+	type rotatedAccount struct {
+		number uint32
+		name   string
+		index  uint32
+	}
+	var accounts []rotatedAccount
+	err = forEachAccount(ns, &s.scope, func(account uint32) error {
+		if account == ImportedAddrAccount {
+			return nil
+		}
+		_, retired, err := fetchAccountSuccessor(ns, &s.scope, account)
+		if err != nil || retired {
+			return err
+		}
+
+		row, err := fetchAccountInfo(ns, &s.scope, account)
+		if err != nil {
+			return err
+		}
+		defaultRow, ok := row.(*dbDefaultAccountRow)
+		if !ok {
+			return nil
+		}
+		acctKeyPub, err := s.rootManager.cryptoKeyPub.Decrypt(
+			defaultRow.pubKeyEncrypted,
+		)
+		if err != nil {
+			str := fmt.Sprintf("failed to decrypt public key for "+
+				"account %d", account)
+			return managerError(ErrCrypto, str, err)
+		}
+		acctKey, err := hdkeychain.NewKeyFromString(string(acctKeyPub))
+		if err != nil {
+			str := fmt.Sprintf("failed to create extended public "+
+				"key for account %d", account)
+			return managerError(ErrKeyChain, str, err)
+		}
+
+		accounts = append(accounts, rotatedAccount{
+			number: account,
+			name:   defaultRow.name,
+			index: acctKey.ChildIndex() -
+				hdkeychain.HardenedKeyStart,
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lastAccount, err := fetchLastAccount(ns, &s.scope)
+	if err != nil {
+		return err
+	}
+	for _, acct := range accounts {
+		acctKeyPriv, err := deriveAccountKey(coinTypeKeyPriv, acct.index)
+		if err != nil {
+			str := fmt.Sprintf("failed to derive key for account "+
+				"%d", acct.number)
+			return managerError(ErrKeyChain, str, err)
+		}
+		err = s.putRotatedAccount(
+			ns, acct.number, lastAccount+1, acct.name,
+			acct.name+retiredSuffix, acctKeyPriv,
+		)
+		acctKeyPriv.Zero()
+		if err != nil {
+			return err
+		}
+		lastAccount++
+	}
+
+	return nil
+}
+
+// putRotatedAccount retires an account, renaming it, and stores the account
+// replacing it under the given number and the previous name of the retired
+// account.
+//
+// NOTE: This function MUST be called with the manager lock held for writes.
+func (s *ScopedKeyManager) putRotatedAccount(ns walletdb.ReadWriteBucket,
+	retired, successor uint32, name, retiredName string,
+	acctKeyPriv *hdkeychain.ExtendedKey) error {
+
+	if err := ValidateAccountName(retiredName); err != nil {
+		return err
+	}
+	if _, err := s.lookupAccount(ns, retiredName); err == nil {
+		str := fmt.Sprintf("account %q already exists", retiredName)
+		return managerError(ErrDuplicateAccount, str, nil)
+	}
+
+	// Rename the retired account, freeing its name for its successor.
+	row, err := fetchAccountInfo(ns, &s.scope, retired)
+	if err != nil {
+		return err
+	}
+	retiredRow := row.(*dbDefaultAccountRow)
+	if err := deleteAccountIDIndex(ns, &s.scope, retired); err != nil {
+		return err
+	}
+	if err := deleteAccountNameIndex(ns, &s.scope, name); err != nil {
+		return err
+	}
+	err = putDefaultAccountInfo(
+		ns, &s.scope, retired, retiredRow.pubKeyEncrypted,
+		retiredRow.privKeyEncrypted, retiredRow.nextExternalIndex,
+		retiredRow.nextInternalIndex, retiredName,
+	)
+	if err != nil {
+		return err
+	}
+	delete(s.acctInfo, retired)
+
+	acctKeyPub, err := acctKeyPriv.Neuter()
+	if err != nil {
+		str := fmt.Sprintf("failed to convert public key for account "+
+			"%d", successor)
+		return managerError(ErrKeyChain, str, err)
+	}
+	acctPubEnc, err := s.rootManager.cryptoKeyPub.Encrypt(
+		[]byte(acctKeyPub.String()),
+	)
+	if err != nil {
+		str := fmt.Sprintf("failed to encrypt public key for account "+
+			"%d", successor)
+		return managerError(ErrCrypto, str, err)
+	}
+	acctPrivEnc, err := s.rootManager.cryptoKeyPriv.Encrypt(
+		[]byte(acctKeyPriv.String()),
+	)
+	if err != nil {
+		str := fmt.Sprintf("failed to encrypt private key for account "+
+			"%d", successor)
+		return managerError(ErrCrypto, str, err)
+	}
+	err = putDefaultAccountInfo(
+		ns, &s.scope, successor, acctPubEnc, acctPrivEnc, 0, 0, name,
+	)
+	if err != nil {
+		return err
+	}
+	if err := putLastAccount(ns, &s.scope, successor); err != nil {
+		return err
+	}
+
+	return putAccountSuccessor(ns, &s.scope, retired, successor)
+}
+
 // NewAccountWatchingOnly is similar to NewAccount, but for watch-only wallets.
 //
 // The master key fingerprint denotes the fingerprint of the root key
@@ -2504,6 +2743,79 @@ func (s *ScopedKeyManager) IsWatchOnlyAccount(ns walletdb.ReadBucket,
 	return acctInfo.acctKeyPriv == nil, nil
 }
 
+// AccountSpendingMode returns whether the account is hot, with its spends
+// created by the wallet, or cold, with its spends signed outside of the wallet.
+// Unlike IsWatchOnlyAccount, the mode of an account doesn't depend on whether
+// the manager is locked.
+func (s *ScopedKeyManager) AccountSpendingMode(ns walletdb.ReadBucket,
+	account uint32) (SpendingMode, error) {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	acctInfo, err := s.loadAccountInfo(ns, account)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.spendingMode(ns, account, acctInfo)
+}
+
+// spendingMode returns the spending mode of an account.  Every account of a
+// watch-only manager is cold, while the watch-only accounts of other managers
+// are only cold once made so with SetAccountSpendingMode.
+func (s *ScopedKeyManager) spendingMode(ns walletdb.ReadBucket, account uint32,
+	acctInfo *accountInfo) (SpendingMode, error) {
+
+	if s.rootManager.WatchOnly() {
+		return SpendingModeCold, nil
+	}
+	if acctInfo.acctKeyEncrypted != nil {
+		return SpendingModeHot, nil
+	}
+
+	mode, ok, err := fetchAccountSpendingMode(ns, &s.scope, account)
+	if err != nil || !ok {
+		return SpendingModeHot, err
+	}
+	return mode, nil
+}
+
+// SetAccountSpendingMode sets the spending mode of a watch-only account of a
+// manager with private keys.  Such accounts are hot until made cold, which
+// hands off their spends to be signed outside of the wallet.  The accounts
+// with private keys and the imported account are always hot.
+func (s *ScopedKeyManager) SetAccountSpendingMode(ns walletdb.ReadWriteBucket,
+	account uint32, mode SpendingMode) error {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.rootManager.WatchOnly() {
+		return managerError(ErrWatchingOnly, errWatchingOnly, nil)
+	}
+	if mode != SpendingModeHot && mode != SpendingModeCold {
+		str := fmt.Sprintf("unknown spending mode %d", mode)
+		return managerError(ErrInvalidAccount, str, nil)
+	}
+	if account == ImportedAddrAccount {
+		str := "the imported account can't be cold"
+		return managerError(ErrInvalidAccount, str, nil)
+	}
+
+	acctInfo, err := s.loadAccountInfo(ns, account)
+	if err != nil {
+		return err
+	}
+	if acctInfo.acctKeyEncrypted != nil && mode == SpendingModeCold {
+		str := fmt.Sprintf("account %d has private keys and can't be "+
+			"cold", account)
+		return managerError(ErrInvalidAccount, str, nil)
+	}
+
+	return putAccountSpendingMode(ns, &s.scope, account, mode)
+}
+
 // cloneKeyWithVersion clones an extended key to use the version corresponding
 // to the manager's key scope. This should only be used for non-watch-only
 // accounts as they are stored within the database using the legacy BIP-0044
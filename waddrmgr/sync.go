@@ -5,6 +5,8 @@
 package waddrmgr
 
 import (
+	"encoding/binary"
+	"fmt"
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -133,3 +135,150 @@ func (m *Manager) SetBirthdayBlock(ns walletdb.ReadWriteBucket,
 	}
 	return putBirthdayBlockVerification(ns, verified)
 }
+
+// syncCheck holds the result of comparing the synced-to block stamp against
+// the block hash index.
+type syncCheck struct {
+	problems []string
+
+	// malformed are the keys of block index entries whose value is not a
+	// block hash.
+	malformed [][]byte
+
+	// rewind, if set, is the block stamp the synced-to state must be
+	// rewound to so that it agrees with the block hash index again.
+	rewind *BlockStamp
+
+	// indexRewind is set when rewind is not already part of the block
+	// hash index.
+	indexRewind bool
+}
+
+// checkSyncState verifies that the synced-to block is present in the block
+// hash index with a matching hash.
+func checkSyncState(ns walletdb.ReadBucket) (*syncCheck, error) {
+	bucket := ns.NestedReadBucket(syncBucketName)
+	if bucket == nil {
+		str := "sync bucket does not exist"
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+
+	syncedTo, err := fetchSyncedTo(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		check   syncCheck
+		tipHash *chainhash.Hash
+		below   *BlockStamp
+	)
+	err = bucket.ForEach(func(k, v []byte) error {
+		// All other keys of the sync bucket are longer than a
+		// serialized height.
+		if len(k) != 4 {
+			return nil
+		}
+		height := int32(binary.BigEndian.Uint32(k))
+		if len(v) != chainhash.HashSize {
+			check.problems = append(check.problems, fmt.Sprintf(
+				"block index entry for height %d is malformed",
+				height))
+			check.malformed = append(check.malformed,
+				append([]byte(nil), k...))
+			return nil
+		}
+
+		var hash chainhash.Hash
+		copy(hash[:], v)
+		switch {
+		case height == syncedTo.Height:
+			tipHash = &hash
+		case height < syncedTo.Height:
+			// Keys are iterated in ascending height order.
+			below = &BlockStamp{Height: height, Hash: hash}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case tipHash == nil:
+		check.problems = append(check.problems, fmt.Sprintf(
+			"synced-to block %d (%v) is missing from the block "+
+				"index", syncedTo.Height, syncedTo.Hash))
+	case *tipHash != syncedTo.Hash:
+		check.problems = append(check.problems, fmt.Sprintf(
+			"synced-to block %d is %v, but the block index "+
+				"has %v", syncedTo.Height, syncedTo.Hash,
+			tipHash))
+	default:
+		return &check, nil
+	}
+
+	if below == nil {
+		below, err = FetchStartBlock(ns)
+		if err != nil {
+			return nil, err
+		}
+		check.indexRewind = true
+	}
+
+	// The header of the block being rewound to is not available offline,
+	// so keep the timestamp of the old tip. It is replaced as soon as the
+	// wallet connects the next block.
+	below.Timestamp = syncedTo.Timestamp
+	check.rewind = below
+
+	return &check, nil
+}
+
+// CheckSyncState verifies that the block the manager is synced to agrees with
+// the block hash index kept for reorg detection. A description of each
+// inconsistency is returned, or nil if there are none.
+func CheckSyncState(ns walletdb.ReadBucket) ([]string, error) {
+	check, err := checkSyncState(ns)
+	if err != nil {
+		return nil, err
+	}
+	return check.problems, nil
+}
+
+// RepairSyncState fixes the inconsistencies reported by CheckSyncState.
+// Malformed block index entries are removed, and a synced-to block that does
+// not match the index is rewound to the highest indexed block below it, or to
+// the start block if there is none. The wallet then syncs forward from there
+// on its next start. The descriptions of the repaired inconsistencies are
+// returned.
+func RepairSyncState(ns walletdb.ReadWriteBucket) ([]string, error) {
+	check, err := checkSyncState(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := ns.NestedReadWriteBucket(syncBucketName)
+	for _, k := range check.malformed {
+		if err := bucket.Delete(k); err != nil {
+			str := "failed to delete malformed block index entry"
+			return nil, managerError(ErrDatabase, str, err)
+		}
+	}
+
+	if check.rewind != nil {
+		if check.indexRewind {
+			err := addBlockHash(
+				ns, check.rewind.Height, check.rewind.Hash,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := updateSyncedTo(ns, check.rewind); err != nil {
+			return nil, err
+		}
+	}
+
+	return check.problems, nil
+}
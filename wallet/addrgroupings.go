@@ -0,0 +1,205 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+)
+
+// AddressGroupingEntry is an address of a group returned by
+// AddressGroupings.
+type AddressGroupingEntry struct {
+	// Address is the wallet address.
+	Address btcutil.Address
+
+	// Balance is the sum of the unspent outputs paying to the address.
+	Balance btcutil.Amount
+
+	// Account is the name of the account of the address.
+	Account string
+}
+
+// addressGroups is a union-find structure over encoded addresses.
+type addressGroups struct {
+	parent map[string]string
+	addrs  map[string]btcutil.Address
+}
+
+// add adds the address as a group of its own if it is not known yet.
+func (g *addressGroups) add(addr btcutil.Address) string {
+	key := addr.EncodeAddress()
+	if _, ok := g.parent[key]; !ok {
+		g.parent[key] = key
+		g.addrs[key] = addr
+	}
+	return key
+}
+
+// find returns the representative address of the group of key.
+func (g *addressGroups) find(key string) string {
+	for g.parent[key] != key {
+		g.parent[key] = g.parent[g.parent[key]]
+		key = g.parent[key]
+	}
+	return key
+}
+
+// union merges the groups of the addresses.
+func (g *addressGroups) union(addrs []btcutil.Address) {
+	if len(addrs) == 0 {
+		return
+	}
+	root := g.find(g.add(addrs[0]))
+	for _, addr := range addrs[1:] {
+		other := g.find(g.add(addr))
+		if other != root {
+			g.parent[other] = root
+		}
+	}
+}
+
+// AddressGroupings returns the wallet addresses grouped by common ownership,
+// as made public by the transactions of the wallet.  Addresses of the inputs
+// of a transaction are grouped together with its change outputs, and groups
+// sharing an address are merged.  Addresses that were only ever paid to form
+// a group of their own.
+func (w *Wallet) AddressGroupings() ([][]AddressGroupingEntry, error) {
+	groups := &addressGroups{
+		parent: make(map[string]string),
+		addrs:  make(map[string]btcutil.Address),
+	}
+	balances := make(map[string]btcutil.Amount)
+	accounts := make(map[string]string)
+
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		err := w.TxStore.RangeTransactions(txmgrNs, 0, -1,
+			func(details []wtxmgr.TxDetails) (bool, error) {
+				for i := range details {
+					err := w.groupTxAddresses(
+						txmgrNs, &details[i], groups,
+					)
+					if err != nil {
+						return false, err
+					}
+				}
+				return false, nil
+			})
+		if err != nil {
+			return err
+		}
+
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for _, output := range unspent {
+			addr := w.scriptAddress(output.PkScript)
+			if addr == nil {
+				continue
+			}
+			balances[groups.add(addr)] += output.Amount
+		}
+
+		for key, addr := range groups.addrs {
+			scopedMgr, account, err := w.Manager.AddrAccount(
+				addrmgrNs, addr,
+			)
+			if err != nil {
+				continue
+			}
+			name, err := scopedMgr.AccountName(addrmgrNs, account)
+			if err != nil {
+				return err
+			}
+			accounts[key] = name
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byRoot := make(map[string][]AddressGroupingEntry)
+	for key, addr := range groups.addrs {
+		root := groups.find(key)
+		byRoot[root] = append(byRoot[root], AddressGroupingEntry{
+			Address: addr,
+			Balance: balances[key],
+			Account: accounts[key],
+		})
+	}
+
+	result := make([][]AddressGroupingEntry, 0, len(byRoot))
+	for _, group := range byRoot {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Address.EncodeAddress() <
+				group[j].Address.EncodeAddress()
+		})
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i][0].Address.EncodeAddress() <
+			result[j][0].Address.EncodeAddress()
+	})
+
+	return result, nil
+}
+
+// groupTxAddresses adds the wallet addresses of the transaction to the
+// groups, grouping the addresses of its inputs with its change outputs.
+func (w *Wallet) groupTxAddresses(ns walletdb.ReadBucket,
+	details *wtxmgr.TxDetails, groups *addressGroups) error {
+
+	var inputAddrs []btcutil.Address
+	if len(details.Debits) > 0 {
+		var block *wtxmgr.Block
+		if details.Block.Height != -1 {
+			block = &details.Block.Block
+		}
+		prevScripts, err := w.TxStore.PreviousPkScripts(
+			ns, &details.TxRecord, block,
+		)
+		if err != nil {
+			return err
+		}
+		for _, script := range prevScripts {
+			if addr := w.scriptAddress(script); addr != nil {
+				inputAddrs = append(inputAddrs, addr)
+			}
+		}
+	}
+
+	for _, credit := range details.Credits {
+		txOut := details.MsgTx.TxOut[credit.Index]
+		addr := w.scriptAddress(txOut.PkScript)
+		if addr == nil {
+			continue
+		}
+		if credit.Change && len(inputAddrs) > 0 {
+			inputAddrs = append(inputAddrs, addr)
+			continue
+		}
+		groups.add(addr)
+	}
+
+	groups.union(inputAddrs)
+	return nil
+}
+
+// scriptAddress returns the address an output script pays to, or nil if it
+// doesn't pay to a single address.
+func (w *Wallet) scriptAddress(script []byte) btcutil.Address {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		script, w.chainParams,
+	)
+	if err != nil || len(addrs) == 0 {
+		return nil
+	}
+	return addrs[0]
+}
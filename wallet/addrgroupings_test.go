@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// TestAddressGroupings checks that addresses spent together are grouped with
+// the change of the spending transaction, while addresses that were only paid
+// to form groups of their own.
+func TestAddressGroupings(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.chainClient.(*mockChainClient).getBlockHeader = &wire.BlockHeader{}
+
+	scope := waddrmgr.KeyScopeBIP0084
+	newAddr := func(change bool) btcutil.Address {
+		t.Helper()
+
+		newAddrFn := w.NewAddress
+		if change {
+			newAddrFn = w.NewChangeAddress
+		}
+		addr, err := newAddrFn(waddrmgr.DefaultAccountNum, scope)
+		require.NoError(t, err)
+		return addr
+	}
+	payTo := func(addr btcutil.Address, amount int64) *wire.TxOut {
+		t.Helper()
+
+		script, err := txscript.PayToAddrScript(addr)
+		require.NoError(t, err)
+		return wire.NewTxOut(amount, script)
+	}
+
+	addr1, addr2, addr3 := newAddr(false), newAddr(false), newAddr(false)
+	change := newAddr(true)
+	foreign := &wire.TxOut{Value: 1000, PkScript: []byte{txscript.OP_TRUE}}
+
+	var height int32
+	addTx := func(tx *wire.MsgTx) {
+		t.Helper()
+
+		height++
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		require.NoError(t, err)
+		block := confTestBlock(height, 0)
+		require.NoError(t, walletdb.Update(w.db,
+			func(dbtx walletdb.ReadWriteTx) error {
+				return w.addRelevantTx(dbtx, rec, &block)
+			},
+		))
+	}
+	receive := func(addr btcutil.Address, amount int64) wire.OutPoint {
+		t.Helper()
+
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{
+				Hash: chainhash.Hash{byte(height + 100)},
+			},
+		})
+		tx.AddTxOut(payTo(addr, amount))
+		addTx(tx)
+		return wire.OutPoint{Hash: tx.TxHash()}
+	}
+
+	op1 := receive(addr1, 10000)
+	op2 := receive(addr2, 20000)
+	receive(addr3, 30000)
+
+	// Spending the outputs of the first two addresses together links
+	// them, and the change of the transaction.
+	spend := wire.NewMsgTx(2)
+	spend.AddTxIn(&wire.TxIn{PreviousOutPoint: op1})
+	spend.AddTxIn(&wire.TxIn{PreviousOutPoint: op2})
+	spend.AddTxOut(foreign)
+	spend.AddTxOut(payTo(change, 25000))
+	addTx(spend)
+
+	groupings, err := w.AddressGroupings()
+	require.NoError(t, err)
+
+	balances := make([]map[string]btcutil.Amount, 0, len(groupings))
+	for _, grouping := range groupings {
+		group := make(map[string]btcutil.Amount)
+		for _, entry := range grouping {
+			require.Equal(t, "default", entry.Account)
+			group[entry.Address.EncodeAddress()] = entry.Balance
+		}
+		balances = append(balances, group)
+	}
+	require.ElementsMatch(t, []map[string]btcutil.Amount{
+		{
+			addr1.EncodeAddress():  0,
+			addr2.EncodeAddress():  0,
+			change.EncodeAddress(): 25000,
+		},
+		{
+			addr3.EncodeAddress(): 30000,
+		},
+	}, balances)
+}
@@ -259,7 +259,8 @@ func (w *Wallet) connectBlock(dbtx walletdb.ReadWriteTx, b wtxmgr.BlockMeta) err
 	//
 	// TODO: move all notifications outside of the database transaction.
 	w.NtfnServer.notifyAttachedBlock(dbtx, &b)
-	return nil
+	w.checkConfirmations(dbtx, b.Height)
+	return w.reorgBlockConnected(dbtx, b.Height)
 }
 
 // disconnectBlock handles a chain server reorganize by rolling back all
@@ -288,7 +289,7 @@ func (w *Wallet) disconnectBlock(dbtx walletdb.ReadWriteTx, b wtxmgr.BlockMeta)
 			if err != nil {
 				return err
 			}
-			b.Hash = *hash
+			bs.Hash = *hash
 
 			client := w.ChainClient()
 			header, err := client.GetBlockHeader(hash)
@@ -306,10 +307,28 @@ func (w *Wallet) disconnectBlock(dbtx walletdb.ReadWriteTx, b wtxmgr.BlockMeta)
 			if err != nil {
 				return err
 			}
+
+			err = w.reorgBlocksDisconnected(
+				dbtx, b.Height, b.Height,
+			)
+			if err != nil {
+				return err
+			}
+
+			// The coinbase outputs of the block were removed.
+			if err := w.dropStaleColdSpends(dbtx); err != nil {
+				return err
+			}
+
+			// Watched transactions may have been reorged back
+			// below their target depth.
+			w.checkConfirmations(dbtx, bs.Height)
 		}
 	}
 
-	// Notify interested clients of the disconnected block.
+	// Notify interested clients of the disconnected block.  The hash is
+	// the one of the removed block, not of its parent the wallet is now
+	// synced to.
 	w.NtfnServer.notifyDetachedBlock(&b.Hash)
 
 	return nil
@@ -330,6 +349,14 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 		return err
 	}
 
+	// A watched transaction may have been mined in a block the wallet
+	// is already synced to.
+	if block != nil {
+		w.checkConfirmations(
+			dbtx, w.Manager.SyncedTo().Height, rec.Hash,
+		)
+	}
+
 	// If the transaction has already been recorded, we can return early.
 	// Note: Returning here is safe as we're within the context of an atomic
 	// database transaction, so we don't need to worry about the MarkUsed
@@ -338,6 +365,12 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 		return nil
 	}
 
+	// The transaction may spend the inputs of pending cold spends, or
+	// have replaced the transactions creating them.
+	if err := w.dropStaleColdSpends(dbtx); err != nil {
+		return err
+	}
+
 	// Check every output to determine whether it is controlled by a wallet
 	// key.  If so, mark the output as a credit.
 	for i, output := range rec.MsgTx.TxOut {
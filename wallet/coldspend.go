@@ -0,0 +1,512 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"github.com/stroomnetwork/btcwallet/wallet/txauthor"
+)
+
+var (
+	// ErrColdSpendNotFound is returned when no pending cold spend matches
+	// a transaction.
+	ErrColdSpendNotFound = errors.New("cold spend not found")
+
+	// ErrColdSpendInvalid is returned when a signed cold spend doesn't
+	// validate against the outputs it spends.
+	ErrColdSpendInvalid = errors.New("invalid cold spend signature")
+
+	// coldSpendNamespaceKey is the top-level bucket the pending cold
+	// spends are persisted under, keyed by the hash of their unsigned
+	// transaction.
+	coldSpendNamespaceKey = []byte("wcoldspend")
+)
+
+// ColdSpend is a transaction spending from a cold account, created by the
+// wallet and handed off as a PSBT to be signed outside of it.  Its inputs are
+// locked until it is completed or canceled.
+type ColdSpend struct {
+	// KeyScope and Account are the account the transaction spends from.
+	KeyScope waddrmgr.KeyScope
+	Account  uint32
+
+	// Packet is the unsigned PSBT of the transaction, with the outputs it
+	// spends and the BIP-0032 derivation paths of its inputs and change.
+	Packet *psbt.Packet
+
+	// Created is the time the transaction was created.
+	Created time.Time
+}
+
+// TxHash returns the hash of the unsigned transaction, which identifies the
+// cold spend.
+func (s *ColdSpend) TxHash() chainhash.Hash {
+	return s.Packet.UnsignedTx.TxHash()
+}
+
+func serializeColdSpend(s *ColdSpend) ([]byte, error) {
+	var b bytes.Buffer
+	var v [20]byte
+	binary.BigEndian.PutUint32(v[0:4], s.KeyScope.Purpose)
+	binary.BigEndian.PutUint32(v[4:8], s.KeyScope.Coin)
+	binary.BigEndian.PutUint32(v[8:12], s.Account)
+	binary.BigEndian.PutUint64(v[12:20], uint64(s.Created.Unix()))
+	b.Write(v[:])
+	if err := s.Packet.Serialize(&b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func deserializeColdSpend(v []byte) (*ColdSpend, error) {
+	if len(v) < 20 {
+		return nil, fmt.Errorf("malformed cold spend: %d bytes", len(v))
+	}
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(v[20:]), false)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cold spend: %w", err)
+	}
+	return &ColdSpend{
+		KeyScope: waddrmgr.KeyScope{
+			Purpose: binary.BigEndian.Uint32(v[0:4]),
+			Coin:    binary.BigEndian.Uint32(v[4:8]),
+		},
+		Account: binary.BigEndian.Uint32(v[8:12]),
+		Packet:  packet,
+		Created: time.Unix(int64(binary.BigEndian.Uint64(v[12:20])), 0),
+	}, nil
+}
+
+// fetchColdSpend reads the pending cold spend of a transaction.
+func fetchColdSpend(dbtx walletdb.ReadTx,
+	txHash *chainhash.Hash) (*ColdSpend, error) {
+
+	ns := dbtx.ReadBucket(coldSpendNamespaceKey)
+	if ns == nil {
+		return nil, ErrColdSpendNotFound
+	}
+	v := ns.Get(txHash[:])
+	if v == nil {
+		return nil, ErrColdSpendNotFound
+	}
+	return deserializeColdSpend(v)
+}
+
+// forEachColdSpend calls f with each pending cold spend.
+func forEachColdSpend(dbtx walletdb.ReadTx, f func(*ColdSpend) error) error {
+	ns := dbtx.ReadBucket(coldSpendNamespaceKey)
+	if ns == nil {
+		return nil
+	}
+	return ns.ForEach(func(_, v []byte) error {
+		s, err := deserializeColdSpend(v)
+		if err != nil {
+			return err
+		}
+		return f(s)
+	})
+}
+
+// putColdSpend persists a pending cold spend.
+func putColdSpend(dbtx walletdb.ReadWriteTx, s *ColdSpend) error {
+	v, err := serializeColdSpend(s)
+	if err != nil {
+		return err
+	}
+	ns, err := dbtx.CreateTopLevelBucket(coldSpendNamespaceKey)
+	if err != nil {
+		return err
+	}
+	txHash := s.TxHash()
+	return ns.Put(txHash[:], v)
+}
+
+// deleteColdSpend removes the pending cold spend of a transaction.
+func deleteColdSpend(dbtx walletdb.ReadWriteTx, txHash *chainhash.Hash) error {
+	ns := dbtx.ReadWriteBucket(coldSpendNamespaceKey)
+	if ns == nil {
+		return ErrColdSpendNotFound
+	}
+	if ns.Get(txHash[:]) == nil {
+		return ErrColdSpendNotFound
+	}
+	return ns.Delete(txHash[:])
+}
+
+// SetAccountSpendingMode sets the spending mode of a watch-only account of a
+// wallet with private keys, such as an account imported with ImportAccount.
+// Such accounts are hot until made cold: the transactions spending from them
+// are returned unsigned to the caller of CreateSimpleTx.  Once the account is
+// cold, they are recorded as pending cold spends instead.
+func (w *Wallet) SetAccountSpendingMode(scope waddrmgr.KeyScope,
+	account uint32, mode waddrmgr.SpendingMode) error {
+
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		return err
+	}
+
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return manager.SetAccountSpendingMode(addrmgrNs, account, mode)
+	})
+}
+
+// coldSpendScope returns the key scope of the cold account spent from, or nil
+// if the spends of the account are created by the wallet.  Only the cold
+// accounts of wallets with private keys are handed off: watch-only wallets
+// leave the signing of every transaction to their caller.
+//
+// Without a key scope, coin selection spans the accounts of the same number in
+// every scope, so the account is resolved as the cold account of that number
+// in any active scope, and the spend is then limited to the scope returned.
+func (w *Wallet) coldSpendScope(addrmgrNs walletdb.ReadBucket,
+	keyScope *waddrmgr.KeyScope, account uint32) (*waddrmgr.KeyScope,
+	error) {
+
+	if w.Manager.WatchOnly() {
+		return nil, nil
+	}
+
+	var scopes []waddrmgr.KeyScope
+	if keyScope != nil {
+		scopes = append(scopes, *keyScope)
+	} else {
+		for _, scopedMgr := range w.Manager.ActiveScopedKeyManagers() {
+			scopes = append(scopes, scopedMgr.Scope())
+		}
+	}
+
+	var coldScope *waddrmgr.KeyScope
+	for _, scope := range scopes {
+		mode, err := w.Manager.AccountSpendingMode(
+			addrmgrNs, scope, account,
+		)
+		if keyScope == nil &&
+			waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound) {
+
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if mode != waddrmgr.SpendingModeCold {
+			continue
+		}
+		if coldScope != nil {
+			return nil, fmt.Errorf("account %d is cold in both key "+
+				"scopes %v and %v, a key scope must be given",
+				account, *coldScope, scope)
+		}
+		scope := scope
+		coldScope = &scope
+	}
+	return coldScope, nil
+}
+
+// putNewColdSpend records an unsigned transaction spending from a cold account
+// as a pending cold spend, with a PSBT holding everything an external signer
+// needs.  It is called within the database transaction that created the
+// transaction, so that the spend is recorded along with its change address.
+// The inputs of the spend must be locked once the database transaction is
+// committed.
+func (w *Wallet) putNewColdSpend(dbtx walletdb.ReadWriteTx,
+	keyScope waddrmgr.KeyScope, account uint32,
+	tx *txauthor.AuthoredTx) (*ColdSpend, error) {
+
+	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+	packet, err := psbt.NewFromUnsignedTx(tx.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create PSBT: %w", err)
+	}
+
+	for i, txIn := range tx.Tx.TxIn {
+		utxo := &wire.TxOut{
+			Value:    int64(tx.PrevInputValues[i]),
+			PkScript: tx.PrevScripts[i],
+		}
+		walletAddr, err := w.outputAddr(addrmgrNs, utxo.PkScript)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching UTXO address: "+
+				"%w", err)
+		}
+		addr, witnessProgram, _, err := w.scriptForOutputAddr(
+			walletAddr, utxo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching UTXO script: "+
+				"%w", err)
+		}
+		derivation := bip32Derivation(addr)
+
+		if txscript.IsPayToTaproot(utxo.PkScript) {
+			addInputInfoSegWitV1(&packet.Inputs[i], utxo, derivation)
+			continue
+		}
+
+		prevHash := &txIn.PreviousOutPoint.Hash
+		prevTx, err := w.TxStore.TxDetails(txmgrNs, prevHash)
+		if err != nil {
+			return nil, err
+		}
+		if prevTx == nil {
+			return nil, fmt.Errorf("transaction %v spent by input "+
+				"%d not found", prevHash, i)
+		}
+		addInputInfoSegWitV0(
+			&packet.Inputs[i], &prevTx.MsgTx, utxo, derivation,
+			addr, witnessProgram,
+		)
+	}
+
+	// The change output is described as well, so the signer can check
+	// that it pays back to the account.
+	if tx.ChangeIndex >= 0 {
+		changeTxOut := tx.Tx.TxOut[tx.ChangeIndex]
+		walletAddr, err := w.outputAddr(addrmgrNs, changeTxOut.PkScript)
+		if err != nil {
+			return nil, fmt.Errorf("error querying wallet for "+
+				"change addr: %w", err)
+		}
+		addr, ok := walletAddr.(waddrmgr.ManagedPubKeyAddress)
+		if !ok {
+			return nil, fmt.Errorf("change addr %s is not a "+
+				"public key address", walletAddr.Address())
+		}
+		changeOutputInfo, err := createOutputInfo(changeTxOut, addr)
+		if err != nil {
+			return nil, fmt.Errorf("error adding output info to "+
+				"change output: %w", err)
+		}
+		packet.Outputs[tx.ChangeIndex] = *changeOutputInfo
+	}
+
+	spend := &ColdSpend{
+		KeyScope: keyScope,
+		Account:  account,
+		Packet:   packet,
+		Created:  time.Now(),
+	}
+	if err := putColdSpend(dbtx, spend); err != nil {
+		return nil, err
+	}
+	return spend, nil
+}
+
+// isUnspent returns whether an output is a credit of the wallet, mined or not,
+// that isn't spent by any transaction known to the wallet.
+func (w *Wallet) isUnspent(txmgrNs walletdb.ReadBucket,
+	op *wire.OutPoint) (bool, error) {
+
+	details, err := w.TxStore.TxDetails(txmgrNs, &op.Hash)
+	if err != nil || details == nil {
+		return false, err
+	}
+	for _, credit := range details.Credits {
+		if credit.Index == op.Index {
+			return !credit.Spent, nil
+		}
+	}
+	return false, nil
+}
+
+// dropStaleColdSpends removes the pending cold spends that can no longer be
+// published because one of their inputs isn't an unspent output of the wallet
+// anymore: it was spent by another transaction, such as the signed cold spend
+// published outside of the wallet, or the transaction creating it was removed
+// as a double spend or by a reorg.  The inputs of the removed spends are
+// unlocked once the database transaction is committed.
+func (w *Wallet) dropStaleColdSpends(dbtx walletdb.ReadWriteTx) error {
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+	var stale []*ColdSpend
+	err := forEachColdSpend(dbtx, func(s *ColdSpend) error {
+		for _, txIn := range s.Packet.UnsignedTx.TxIn {
+			unspent, err := w.isUnspent(
+				txmgrNs, &txIn.PreviousOutPoint,
+			)
+			if err != nil {
+				return err
+			}
+			if !unspent {
+				stale = append(stale, s)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+
+	for _, s := range stale {
+		txHash := s.TxHash()
+		if err := deleteColdSpend(dbtx, &txHash); err != nil {
+			return err
+		}
+		log.Infof("Dropped cold spend %v of account %d in key scope "+
+			"%v: its inputs are no longer unspent", txHash,
+			s.Account, s.KeyScope)
+	}
+
+	dbtx.OnCommit(func() {
+		for _, s := range stale {
+			for _, txIn := range s.Packet.UnsignedTx.TxIn {
+				w.UnlockOutpoint(txIn.PreviousOutPoint)
+			}
+		}
+	})
+	return nil
+}
+
+// loadColdSpends drops the stale pending cold spends and locks the inputs of
+// the others when the wallet is opened.
+func (w *Wallet) loadColdSpends() error {
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		if err := w.dropStaleColdSpends(dbtx); err != nil {
+			return err
+		}
+		return forEachColdSpend(dbtx, func(s *ColdSpend) error {
+			for _, txIn := range s.Packet.UnsignedTx.TxIn {
+				w.LockOutpoint(txIn.PreviousOutPoint)
+			}
+			return nil
+		})
+	})
+}
+
+// ColdSpend returns the pending cold spend of a transaction, given the hash of
+// the unsigned transaction.
+func (w *Wallet) ColdSpend(txHash chainhash.Hash) (*ColdSpend, error) {
+	var spend *ColdSpend
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		var err error
+		spend, err = fetchColdSpend(dbtx, &txHash)
+		return err
+	})
+	return spend, err
+}
+
+// ColdSpends returns the pending cold spends, oldest first.
+func (w *Wallet) ColdSpends() ([]*ColdSpend, error) {
+	var spends []*ColdSpend
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		return forEachColdSpend(dbtx, func(s *ColdSpend) error {
+			spends = append(spends, s)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(spends, func(i, j int) bool {
+		return spends[i].Created.Before(spends[j].Created)
+	})
+	return spends, nil
+}
+
+// CancelColdSpend forgets a pending cold spend and unlocks its inputs.  A
+// signature made for it afterwards is not accepted by CompleteColdSpend.
+func (w *Wallet) CancelColdSpend(txHash chainhash.Hash) error {
+	var spend *ColdSpend
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		var err error
+		spend, err = fetchColdSpend(dbtx, &txHash)
+		if err != nil {
+			return err
+		}
+		return deleteColdSpend(dbtx, &txHash)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, txIn := range spend.Packet.UnsignedTx.TxIn {
+		w.UnlockOutpoint(txIn.PreviousOutPoint)
+	}
+	return nil
+}
+
+// CompleteColdSpend publishes a cold spend signed outside of the wallet.  The
+// signed PSBT must be for the unsigned transaction of a pending cold spend,
+// and its inputs must be signed, finalized or not.  The signatures are checked
+// against the outputs spent as recorded when the spend was handed off, rather
+// than as described by the signed PSBT, before the transaction is published.
+//
+// The pending cold spend is removed once the transaction is published, and the
+// published transaction is returned.
+func (w *Wallet) CompleteColdSpend(ctx context.Context, packet *psbt.Packet,
+	label string) (*wire.MsgTx, error) {
+
+	if err := packet.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("invalid PSBT: %w", err)
+	}
+
+	// The hash of the unsigned transaction commits to every input and
+	// output, so a matching cold spend is for the same transaction.
+	txHash := packet.UnsignedTx.TxHash()
+	spend, err := w.ColdSpend(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrColdSpendInvalid, err)
+	}
+	tx, err := psbt.Extract(packet)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrColdSpendInvalid, err)
+	}
+
+	prevScripts := make([][]byte, len(tx.TxIn))
+	inputValues := make([]btcutil.Amount, len(tx.TxIn))
+	for i, txIn := range spend.Packet.UnsignedTx.TxIn {
+		in := spend.Packet.Inputs[i]
+		utxo := in.WitnessUtxo
+		if utxo == nil && in.NonWitnessUtxo != nil {
+			utxo = in.NonWitnessUtxo.TxOut[txIn.PreviousOutPoint.Index]
+		}
+		if utxo == nil {
+			return nil, fmt.Errorf("cold spend %v is missing the "+
+				"output spent by input %d", txHash, i)
+		}
+		prevScripts[i] = utxo.PkScript
+		inputValues[i] = btcutil.Amount(utxo.Value)
+	}
+	if err := validateMsgTx(tx, prevScripts, inputValues); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrColdSpendInvalid, err)
+	}
+
+	if err := w.PublishTransaction(ctx, tx, label); err != nil {
+		return nil, err
+	}
+
+	// Recording the published transaction already dropped the spend,
+	// whose inputs it spends.
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		return deleteColdSpend(dbtx, &txHash)
+	})
+	if err != nil && !errors.Is(err, ErrColdSpendNotFound) {
+		return nil, err
+	}
+	for _, txIn := range tx.TxIn {
+		w.UnlockOutpoint(txIn.PreviousOutPoint)
+	}
+
+	return tx, nil
+}
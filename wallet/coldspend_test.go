@@ -0,0 +1,261 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// signColdSpend signs the inputs of a cold spend with the keys of the root
+// key the cold account was derived from, as an external signer would.
+func signColdSpend(t *testing.T, root *hdkeychain.ExtendedKey,
+	packet *psbt.Packet) {
+
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut)
+	for i, txIn := range packet.UnsignedTx.TxIn {
+		prevOuts[txIn.PreviousOutPoint] = packet.Inputs[i].WitnessUtxo
+	}
+	sigHashes := txscript.NewTxSigHashes(
+		packet.UnsignedTx, txscript.NewMultiPrevOutFetcher(prevOuts),
+	)
+
+	updater, err := psbt.NewUpdater(packet)
+	require.NoError(t, err)
+	for i, in := range packet.Inputs {
+		require.Len(t, in.Bip32Derivation, 1)
+
+		key := root
+		for _, index := range in.Bip32Derivation[0].Bip32Path {
+			key, err = key.Derive(index)
+			require.NoError(t, err)
+		}
+		privKey, err := key.ECPrivKey()
+		require.NoError(t, err)
+
+		witness, err := txscript.WitnessSignature(
+			packet.UnsignedTx, sigHashes, i, in.WitnessUtxo.Value,
+			in.WitnessUtxo.PkScript, txscript.SigHashAll, privKey,
+			true,
+		)
+		require.NoError(t, err)
+
+		_, err = updater.Sign(i, witness[0], witness[1], nil, nil)
+		require.NoError(t, err)
+	}
+}
+
+// TestColdSpend tests that spends from a cold account of a wallet with private
+// keys are handed off unsigned, and published once signed outside of the
+// wallet.
+func TestColdSpend(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	w.Start()
+	defer w.Stop()
+
+	scope := waddrmgr.KeyScopeBIP0084
+
+	// Import the account of another seed as the cold account.
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	require.NoError(t, err)
+	root, err := hdkeychain.NewMaster(seed, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	acctPub := deriveAcctPubKey(t, root, scope, hardenedKey(0))
+	addrType := waddrmgr.WitnessPubKey
+	props, err := w.ImportAccount("cold", acctPub, 0, &addrType)
+	require.NoError(t, err)
+	require.Equal(t, waddrmgr.SpendingModeHot, props.SpendingMode)
+	account := props.AccountNumber
+
+	hotProps, err := w.AccountProperties(scope, 0)
+	require.NoError(t, err)
+	require.Equal(t, waddrmgr.SpendingModeHot, hotProps.SpendingMode)
+
+	addr, err := w.NewAddress(account, scope)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+	const testAmt = 1_000_000
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(testAmt, pkScript)},
+	})
+
+	// The spend pays to the hot account.
+	hotAddr, err := w.NewAddress(0, scope)
+	require.NoError(t, err)
+	hotPkScript, err := txscript.PayToAddrScript(hotAddr)
+	require.NoError(t, err)
+	outputs := []*wire.TxOut{wire.NewTxOut(100_000, hotPkScript)}
+
+	// Until the imported account is made cold, its spends are returned
+	// unsigned to the caller, as for any watch-only account.
+	tx, err := w.CreateSimpleTx(
+		context.Background(), &scope, account, outputs, 1, 1000,
+		CoinSelectionLargest, false,
+	)
+	require.NoError(t, err)
+	require.Empty(t, tx.Tx.TxIn[0].Witness)
+	require.False(t, w.LockedOutpoint(tx.Tx.TxIn[0].PreviousOutPoint))
+	spends, err := w.ColdSpends()
+	require.NoError(t, err)
+	require.Empty(t, spends)
+
+	// The accounts with keys can't be made cold.
+	err = w.SetAccountSpendingMode(scope, 0, waddrmgr.SpendingModeCold)
+	require.True(t, waddrmgr.IsError(err, waddrmgr.ErrInvalidAccount))
+
+	err = w.SetAccountSpendingMode(
+		scope, account, waddrmgr.SpendingModeCold,
+	)
+	require.NoError(t, err)
+	props, err = w.AccountProperties(scope, account)
+	require.NoError(t, err)
+	require.Equal(t, waddrmgr.SpendingModeCold, props.SpendingMode)
+
+	// Cold spends don't need the wallet to be unlocked.
+	w.Lock()
+
+	createColdSpend := func(keyScope *waddrmgr.KeyScope) *ColdSpend {
+		tx, err := w.CreateSimpleTx(
+			context.Background(), keyScope, account, outputs, 1,
+			1000, CoinSelectionLargest, false,
+		)
+		require.NoError(t, err)
+		require.Len(t, tx.Tx.TxIn, 1)
+		require.Empty(t, tx.Tx.TxIn[0].Witness)
+
+		spend, err := w.ColdSpend(tx.Tx.TxHash())
+		require.NoError(t, err)
+		require.Equal(t, scope, spend.KeyScope)
+		require.Equal(t, account, spend.Account)
+		require.NotNil(t, spend.Packet.Inputs[0].WitnessUtxo)
+		require.Len(t, spend.Packet.Inputs[0].Bip32Derivation, 1)
+		require.True(t, w.LockedOutpoint(
+			tx.Tx.TxIn[0].PreviousOutPoint,
+		))
+
+		// The change is paid back to the cold account, and described
+		// in the PSBT.
+		require.GreaterOrEqual(t, tx.ChangeIndex, 0)
+		change := spend.Packet.Outputs[tx.ChangeIndex]
+		require.Len(t, change.Bip32Derivation, 1)
+		require.Equal(
+			t, hardenedKey(0), change.Bip32Derivation[0].Bip32Path[2],
+		)
+		require.Equal(
+			t, uint32(waddrmgr.InternalBranch),
+			change.Bip32Derivation[0].Bip32Path[3],
+		)
+		return spend
+	}
+
+	// A canceled cold spend releases its input.
+	spend := createColdSpend(&scope)
+	outpoint := spend.Packet.UnsignedTx.TxIn[0].PreviousOutPoint
+	require.NoError(t, w.CancelColdSpend(spend.TxHash()))
+	require.False(t, w.LockedOutpoint(outpoint))
+	spends, err = w.ColdSpends()
+	require.NoError(t, err)
+	require.Empty(t, spends)
+	require.ErrorIs(
+		t, w.CancelColdSpend(spend.TxHash()), ErrColdSpendNotFound,
+	)
+
+	// Without a key scope, the spend is from the cold account of the key
+	// scope it was imported in.
+	spend = createColdSpend(nil)
+	spends, err = w.ColdSpends()
+	require.NoError(t, err)
+	require.Len(t, spends, 1)
+
+	// The inputs of pending cold spends are locked again when the wallet
+	// is opened.
+	w.UnlockOutpoint(outpoint)
+	require.NoError(t, w.loadColdSpends())
+	require.True(t, w.LockedOutpoint(outpoint))
+
+	// The funds of the cold account are accounted for until the spend is
+	// published.
+	balances, err := w.CalculateAccountBalances(account, 0)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(testAmt), balances.Total)
+
+	// An unsigned spend is refused.
+	_, err = w.CompleteColdSpend(context.Background(), spend.Packet, "")
+	require.ErrorIs(t, err, ErrColdSpendInvalid)
+
+	// So is a spend that isn't pending.
+	other, err := psbt.New(
+		[]*wire.OutPoint{{Index: 1}}, outputs, 2, 0,
+		[]uint32{wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+	_, err = w.CompleteColdSpend(context.Background(), other, "")
+	require.ErrorIs(t, err, ErrColdSpendNotFound)
+
+	spend, err = w.ColdSpend(spend.TxHash())
+	require.NoError(t, err)
+	signColdSpend(t, root, spend.Packet)
+	completedTx, err := w.CompleteColdSpend(
+		context.Background(), spend.Packet, "cold",
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, completedTx.TxIn[0].Witness)
+
+	spends, err = w.ColdSpends()
+	require.NoError(t, err)
+	require.Empty(t, spends)
+	require.False(t, w.LockedOutpoint(outpoint))
+
+	// Once published, the spend is accounted for like any other, with
+	// only the change left in the cold account.
+	var change btcutil.Amount
+	for _, txOut := range completedTx.TxOut {
+		change += btcutil.Amount(txOut.Value)
+	}
+	change -= btcutil.Amount(outputs[0].Value)
+	balances, err = w.CalculateAccountBalances(account, 0)
+	require.NoError(t, err)
+	require.Equal(t, change, balances.Total)
+	balances, err = w.CalculateAccountBalances(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(outputs[0].Value), balances.Total)
+
+	// A pending cold spend whose input is spent by another transaction is
+	// dropped, and its input unlocked.
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{Sequence: 1}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(testAmt, pkScript)},
+	})
+	spend = createColdSpend(&scope)
+	outpoint = spend.Packet.UnsignedTx.TxIn[0].PreviousOutPoint
+	conflict := &wire.MsgTx{
+		Version: 2,
+		TxIn:    []*wire.TxIn{{PreviousOutPoint: outpoint}},
+		TxOut:   []*wire.TxOut{wire.NewTxOut(testAmt/2, hotPkScript)},
+	}
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(conflict, time.Now())
+	require.NoError(t, err)
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		return w.addRelevantTx(dbtx, rec, nil)
+	})
+	require.NoError(t, err)
+	spends, err = w.ColdSpends()
+	require.NoError(t, err)
+	require.Empty(t, spends)
+	require.False(t, w.LockedOutpoint(outpoint))
+}
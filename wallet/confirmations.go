@@ -0,0 +1,369 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// ConfirmationNotification describes the confirmation depth of a wallet
+// transaction relative to the depth a client is waiting for.
+type ConfirmationNotification struct {
+	// TxHash is the hash of the watched transaction.
+	TxHash chainhash.Hash
+
+	// TargetConfirmations is the depth the client is waiting for.
+	TargetConfirmations int32
+
+	// Confirmations is the number of blocks the transaction is buried
+	// under, including the block it is mined in, or 0 if unmined.
+	Confirmations int32
+
+	// BlockHash is the hash of the block the transaction is mined in, or
+	// nil if the transaction is unmined.
+	BlockHash *chainhash.Hash
+
+	// BlockHeight is the height of the block the transaction is mined in,
+	// or -1 if the transaction is unmined.
+	BlockHeight int32
+
+	// Reached is true when the transaction has at least
+	// TargetConfirmations confirmations.  A notification with Reached set
+	// to false that follows one with Reached set to true reports that the
+	// transaction was reorged back below the target depth.
+	Reached bool
+}
+
+// confWatcher tracks a single transaction for a
+// ConfirmationNotificationsClient.  Notifications are queued without bound
+// so that chain processing never blocks on a slow client, and delivered in
+// order by the watcher's goroutine.
+type confWatcher struct {
+	txHash chainhash.Hash
+	target int32
+
+	// primed and reached are protected by the confirmationWatchers mutex.
+	// primed is false until the initial state was queued, and dirty
+	// records whether a chain update was skipped before that.
+	primed  bool
+	dirty   bool
+	reached bool
+
+	mu     sync.Mutex
+	queue  []*ConfirmationNotification
+	signal chan struct{}
+
+	c    chan *ConfirmationNotification
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (wt *confWatcher) enqueue(n *ConfirmationNotification) {
+	wt.mu.Lock()
+	wt.queue = append(wt.queue, n)
+	wt.mu.Unlock()
+
+	select {
+	case wt.signal <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends queued notifications to the client until the watcher is
+// stopped.  It must be run as a goroutine.
+func (wt *confWatcher) deliver() {
+	defer wt.wg.Done()
+
+	for {
+		wt.mu.Lock()
+		if len(wt.queue) == 0 {
+			wt.mu.Unlock()
+			select {
+			case <-wt.signal:
+				continue
+			case <-wt.quit:
+				return
+			}
+		}
+		n := wt.queue[0]
+		wt.queue[0] = nil
+		wt.queue = wt.queue[1:]
+		wt.mu.Unlock()
+
+		select {
+		case wt.c <- n:
+		case <-wt.quit:
+			return
+		}
+	}
+}
+
+// confirmationWatchers is the set of transactions watched by all
+// ConfirmationNotificationsClients of a wallet.
+type confirmationWatchers struct {
+	mu       sync.Mutex
+	watchers map[chainhash.Hash]map[*confWatcher]struct{}
+}
+
+func newConfirmationWatchers() *confirmationWatchers {
+	return &confirmationWatchers{
+		watchers: make(map[chainhash.Hash]map[*confWatcher]struct{}),
+	}
+}
+
+func (s *confirmationWatchers) add(wt *confWatcher) {
+	s.mu.Lock()
+	set, ok := s.watchers[wt.txHash]
+	if !ok {
+		set = make(map[*confWatcher]struct{})
+		s.watchers[wt.txHash] = set
+	}
+	set[wt] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *confirmationWatchers) remove(wt *confWatcher) {
+	s.mu.Lock()
+	set := s.watchers[wt.txHash]
+	delete(set, wt)
+	if len(set) == 0 {
+		delete(s.watchers, wt.txHash)
+	}
+	s.mu.Unlock()
+}
+
+// hashes returns the hashes of all watched transactions.
+func (s *confirmationWatchers) hashes() []chainhash.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashes := make([]chainhash.Hash, 0, len(s.watchers))
+	for hash := range s.watchers {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// update applies the confirmation state of a transaction to all of its
+// watchers, queueing a notification for every watcher whose target depth
+// was reached or lost.
+func (s *confirmationWatchers) update(st *txConfState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for wt := range s.watchers[st.txHash] {
+		if !wt.primed {
+			wt.dirty = true
+			continue
+		}
+		reached := st.found && st.confs >= wt.target
+		if reached == wt.reached {
+			continue
+		}
+		wt.reached = reached
+		wt.enqueue(st.notification(wt.target))
+	}
+}
+
+// txConfState is the confirmation state of a transaction as recorded by the
+// transaction store.
+type txConfState struct {
+	txHash      chainhash.Hash
+	found       bool
+	confs       int32
+	blockHash   *chainhash.Hash
+	blockHeight int32
+}
+
+func (st *txConfState) notification(target int32) *ConfirmationNotification {
+	return &ConfirmationNotification{
+		TxHash:              st.txHash,
+		TargetConfirmations: target,
+		Confirmations:       st.confs,
+		BlockHash:           st.blockHash,
+		BlockHeight:         st.blockHeight,
+		Reached:             st.found && st.confs >= target,
+	}
+}
+
+// txConfirmationState looks up the confirmation state of a transaction
+// with the chain synced to height tip.
+func (w *Wallet) txConfirmationState(dbtx walletdb.ReadTx,
+	txHash *chainhash.Hash, tip int32) (*txConfState, error) {
+
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+	st := &txConfState{txHash: *txHash, blockHeight: -1}
+	details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return st, nil
+	}
+
+	st.found = true
+	if details.Block.Height == -1 {
+		return st, nil
+	}
+	blockHash := details.Block.Hash
+	st.blockHash = &blockHash
+	st.blockHeight = details.Block.Height
+	if st.blockHeight <= tip {
+		st.confs = tip - st.blockHeight + 1
+	}
+	return st, nil
+}
+
+// checkConfirmations recomputes the confirmation state of the given watched
+// transactions, or of all watched transactions if txHashes is nil, with the
+// chain synced to height tip.  Clients are notified once dbtx commits.
+func (w *Wallet) checkConfirmations(dbtx walletdb.ReadWriteTx, tip int32,
+	txHashes ...chainhash.Hash) {
+
+	if txHashes == nil {
+		txHashes = w.confWatchers.hashes()
+	}
+
+	var states []*txConfState
+	for i := range txHashes {
+		st, err := w.txConfirmationState(dbtx, &txHashes[i], tip)
+		if err != nil {
+			log.Errorf("Cannot query confirmations of transaction "+
+				"%v: %v", txHashes[i], err)
+			continue
+		}
+		states = append(states, st)
+	}
+	if len(states) == 0 {
+		return
+	}
+
+	dbtx.OnCommit(func() {
+		for _, st := range states {
+			w.confWatchers.update(st)
+		}
+	})
+}
+
+// ConfirmationNotificationsClient receives ConfirmationNotifications for a
+// single transaction over the channel C.
+type ConfirmationNotificationsClient struct {
+	C <-chan *ConfirmationNotification
+
+	watchers *confirmationWatchers
+	watcher  *confWatcher
+}
+
+// ConfirmationNotifications returns a client for receiving notifications
+// about the confirmation depth of a wallet transaction relative to
+// targetConfs.  The first notification describes the current state of the
+// transaction.  After that, a notification is sent each time the
+// transaction reaches the target depth, or is reorged back below it.
+// ErrNoTx is returned if the transaction is not recorded by the wallet.
+//
+// When finished, the Done method should be called on the client to
+// disassociate it from the wallet.
+func (w *Wallet) ConfirmationNotifications(txHash *chainhash.Hash,
+	targetConfs int32) (*ConfirmationNotificationsClient, error) {
+
+	if targetConfs < 1 {
+		return nil, fmt.Errorf("target confirmations must be positive, "+
+			"got %d", targetConfs)
+	}
+
+	wt := &confWatcher{
+		txHash: *txHash,
+		target: targetConfs,
+		signal: make(chan struct{}, 1),
+		c:      make(chan *ConfirmationNotification),
+		quit:   make(chan struct{}),
+	}
+	w.confWatchers.add(wt)
+
+	// Determine the initial state of the transaction.  Chain updates
+	// committed while it is being read mark the watcher dirty, in which
+	// case the state is read again.
+	for {
+		var st *txConfState
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+			var err error
+			st, err = w.txConfirmationState(
+				dbtx, txHash, w.Manager.SyncedTo().Height,
+			)
+			return err
+		})
+		if err == nil && !st.found {
+			err = ErrNoTx
+		}
+		if err != nil {
+			w.confWatchers.remove(wt)
+			return nil, err
+		}
+
+		w.confWatchers.mu.Lock()
+		if wt.dirty {
+			wt.dirty = false
+			w.confWatchers.mu.Unlock()
+			continue
+		}
+		n := st.notification(targetConfs)
+		wt.primed = true
+		wt.reached = n.Reached
+		wt.enqueue(n)
+		w.confWatchers.mu.Unlock()
+		break
+	}
+
+	wt.wg.Add(1)
+	go wt.deliver()
+
+	return &ConfirmationNotificationsClient{
+		C:        wt.c,
+		watchers: w.confWatchers,
+		watcher:  wt,
+	}, nil
+}
+
+// Done deregisters the client from the wallet and closes C.  It must be
+// called exactly once when the client is finished receiving notifications.
+func (c *ConfirmationNotificationsClient) Done() {
+	c.watchers.remove(c.watcher)
+	close(c.watcher.quit)
+	c.watcher.wg.Wait()
+	close(c.watcher.c)
+}
+
+// WaitForConfirmations blocks until the wallet transaction txHash has at
+// least targetConfs confirmations and returns its confirmation state.
+// ErrNoTx is returned if the transaction is not recorded by the wallet.  If
+// the context is canceled or the wallet shuts down first, the context error
+// or ErrWalletShuttingDown is returned.
+func (w *Wallet) WaitForConfirmations(ctx context.Context,
+	txHash *chainhash.Hash,
+	targetConfs int32) (*ConfirmationNotification, error) {
+
+	client, err := w.ConfirmationNotifications(txHash, targetConfs)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Done()
+
+	for {
+		select {
+		case n := <-client.C:
+			if n.Reached {
+				return n, nil
+			}
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-w.quitChan():
+			return nil, ErrWalletShuttingDown
+		}
+	}
+}
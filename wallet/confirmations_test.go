@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stretchr/testify/require"
+)
+
+// confTestBlock returns the block meta of a test block at the given height.
+// The fork byte allows creating competing blocks at the same height.
+func confTestBlock(height int32, fork byte) wtxmgr.BlockMeta {
+	return wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{
+			Hash:   chainhash.Hash{byte(height), byte(height >> 8), fork},
+			Height: height,
+		},
+		Time: time.Unix(int64(height), 0),
+	}
+}
+
+// recvConfirmation receives the next notification from the client.
+func recvConfirmation(t *testing.T,
+	c *ConfirmationNotificationsClient) *ConfirmationNotification {
+
+	t.Helper()
+
+	select {
+	case n := <-c.C:
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for confirmation notification")
+		return nil
+	}
+}
+
+// requireNoConfirmation ensures no notification is pending on the client.
+func requireNoConfirmation(t *testing.T, c *ConfirmationNotificationsClient) {
+	t.Helper()
+
+	select {
+	case n := <-c.C:
+		t.Fatalf("unexpected confirmation notification: %v", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestConfirmationNotifications checks that watched transactions are
+// reported when reaching the target depth and when reorged back below it.
+func TestConfirmationNotifications(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.chainClient.(*mockChainClient).getBlockHeader = &wire.BlockHeader{}
+	w.SetChainSynced(true)
+
+	update := func(f func(walletdb.ReadWriteTx) error) {
+		t.Helper()
+		require.NoError(t, walletdb.Update(w.db, f))
+	}
+
+	rec, err := wtxmgr.NewTxRecord(TstSerializedTx, time.Now())
+	require.NoError(t, err)
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.addRelevantTx(dbtx, rec, nil)
+	})
+
+	// Watching an unknown transaction is an error.
+	_, err = w.ConfirmationNotifications(&chainhash.Hash{1}, 2)
+	require.ErrorIs(t, err, ErrNoTx)
+
+	client, err := w.ConfirmationNotifications(&rec.Hash, 2)
+	require.NoError(t, err)
+	defer client.Done()
+
+	// The first notification reports the current, unmined state.
+	n := recvConfirmation(t, client)
+	require.Equal(t, rec.Hash, n.TxHash)
+	require.False(t, n.Reached)
+	require.Zero(t, n.Confirmations)
+	require.EqualValues(t, -1, n.BlockHeight)
+	require.Nil(t, n.BlockHash)
+
+	// Mining the transaction gives it a single confirmation, which is not
+	// enough to be reported.
+	height := w.Manager.SyncedTo().Height + 1
+	block := confTestBlock(height, 0)
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		if err := w.addRelevantTx(dbtx, rec, &block); err != nil {
+			return err
+		}
+		return w.connectBlock(dbtx, block)
+	})
+	requireNoConfirmation(t, client)
+
+	// The next block brings it to the target depth.
+	next := confTestBlock(height+1, 0)
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.connectBlock(dbtx, next)
+	})
+	n = recvConfirmation(t, client)
+	require.True(t, n.Reached)
+	require.EqualValues(t, 2, n.Confirmations)
+	require.EqualValues(t, 2, n.TargetConfirmations)
+	require.Equal(t, height, n.BlockHeight)
+	require.Equal(t, block.Hash, *n.BlockHash)
+
+	// Further blocks don't change the reported state.
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.connectBlock(dbtx, confTestBlock(height+2, 0))
+	})
+	requireNoConfirmation(t, client)
+
+	// Disconnecting the two blocks on top reorgs the transaction back
+	// below the target depth.
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.disconnectBlock(dbtx, confTestBlock(height+2, 0))
+	})
+	requireNoConfirmation(t, client)
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.disconnectBlock(dbtx, next)
+	})
+	n = recvConfirmation(t, client)
+	require.False(t, n.Reached)
+	require.EqualValues(t, 1, n.Confirmations)
+
+	// Waiting for the target depth returns once a competing block is
+	// connected on top.
+	done := make(chan error, 1)
+	go func() {
+		n, err := w.WaitForConfirmations(
+			context.Background(), &rec.Hash, 2,
+		)
+		if err == nil && !n.Reached {
+			t.Errorf("target depth not reached")
+		}
+		done <- err
+	}()
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.connectBlock(dbtx, confTestBlock(height+1, 1))
+	})
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for confirmations")
+	}
+	n = recvConfirmation(t, client)
+	require.True(t, n.Reached)
+
+	// Waiting is canceled together with its context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = w.WaitForConfirmations(ctx, &rec.Hash, 100)
+	require.ErrorIs(t, err, context.Canceled)
+}
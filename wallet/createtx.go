@@ -6,6 +6,7 @@
 package wallet
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -20,6 +21,7 @@ import (
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
 	"github.com/stroomnetwork/btcwallet/wallet/txauthor"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func makeInputSource(eligible []Coin) txauthor.InputSource {
@@ -137,16 +139,27 @@ func (s secretSource) GetScript(addr btcutil.Address) ([]byte, error) {
 // included based on the wallet's current relay fee. The wallet must be
 // unlocked to create the transaction.
 //
+// In a wallet with private keys, the transaction of a spend from a cold account
+// is not signed, and is recorded as a pending cold spend within the same
+// database transaction, with its inputs locked, unless skipColdSpend is set.
+// Without a coin selection key scope, only the outputs of the cold account of
+// the scope it resolves to are selected, and its change is paid back to it.
+//
 // NOTE: The dryRun argument can be set true to create a tx that doesn't alter
 // the database. A tx created with this set to true will intentionally have no
 // input scripts added and SHOULD NOT be broadcasted.
-func (w *Wallet) txToOutputs(outputs []*wire.TxOut,
+func (w *Wallet) txToOutputs(ctx context.Context, outputs []*wire.TxOut,
 	coinSelectKeyScope, changeKeyScope *waddrmgr.KeyScope,
 	account uint32, minconf int32, feeSatPerKb btcutil.Amount,
-	strategy CoinSelectionStrategy, dryRun bool,
+	strategy CoinSelectionStrategy, dryRun, skipColdSpend bool,
 	selectedUtxos []wire.OutPoint,
 	allowUtxo func(utxo wtxmgr.Credit) bool) (
-	*txauthor.AuthoredTx, error) {
+	_ *txauthor.AuthoredTx, err error) {
+
+	ctx, span := w.startSpan(ctx, "wallet.txToOutputs",
+		attribute.Int("wallet.selected_utxos", len(selectedUtxos)),
+	)
+	defer func() { endSpan(span, err) }()
 
 	chainClient, err := w.requireChainClient()
 	if err != nil {
@@ -154,7 +167,12 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut,
 	}
 
 	// Get current block's height and hash.
-	bs, err := chainClient.BlockStamp()
+	var bs *waddrmgr.BlockStamp
+	err = w.chainCall(ctx, "BlockStamp", func() error {
+		var err error
+		bs, err = chainClient.BlockStamp()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -176,8 +194,26 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut,
 	w.newAddrMtx.Lock()
 	defer w.newAddrMtx.Unlock()
 
-	var tx *txauthor.AuthoredTx
-	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+	var (
+		tx        *txauthor.AuthoredTx
+		coldSpend *ColdSpend
+	)
+	err = w.updateDB(ctx, func(dbtx walletdb.ReadWriteTx) error {
+		// Spends from cold accounts are signed outside of the wallet.
+		coldScope, err := w.coldSpendScope(
+			dbtx.ReadBucket(waddrmgrNamespaceKey),
+			coinSelectKeyScope, account,
+		)
+		if err != nil {
+			return err
+		}
+		if coldScope != nil && coinSelectKeyScope == nil {
+			coinSelectKeyScope = coldScope
+			if changeKeyScope == nil {
+				changeKeyScope = coldScope
+			}
+		}
+
 		addrmgrNs, changeSource, err := w.addrMgrWithChangeSource(
 			dbtx, changeKeyScope, account,
 		)
@@ -291,7 +327,8 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut,
 		if err != nil {
 			return err
 		}
-		if !watchOnly || containsTaprootInput(tx) {
+
+		if (!watchOnly || containsTaprootInput(tx)) && coldScope == nil {
 
 			if err != nil {
 				return err
@@ -332,7 +369,19 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut,
 			if err != nil {
 				return err
 			}
-			if err := chainClient.NotifyReceived(addrs); err != nil {
+			err = w.chainCall(ctx, "NotifyReceived", func() error {
+				return chainClient.NotifyReceived(addrs)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if coldScope != nil && !skipColdSpend {
+			coldSpend, err = w.putNewColdSpend(
+				dbtx, *coldScope, account, tx,
+			)
+			if err != nil {
 				return err
 			}
 		}
@@ -343,6 +392,18 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut,
 		return nil, err
 	}
 
+	// The inputs of a cold spend are locked once it is recorded, so no
+	// other transaction spends them while it is signed.
+	if coldSpend != nil {
+		for _, txIn := range tx.Tx.TxIn {
+			w.LockOutpoint(txIn.PreviousOutPoint)
+		}
+
+		log.Infof("Created cold spend %v from account %d of scope %v, "+
+			"waiting for signature", coldSpend.TxHash(), account,
+			coldSpend.KeyScope)
+	}
+
 	return tx, nil
 }
 
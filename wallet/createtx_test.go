@@ -6,6 +6,7 @@ package wallet
 
 import (
 	"bytes"
+	"context"
 	"testing"
 	"time"
 
@@ -80,8 +81,9 @@ func TestTxToOutputsDryRun(t *testing.T) {
 	// First do a few dry-runs, making sure the number of addresses in the
 	// database us not inflated.
 	dryRunTx, err := w.txToOutputs(
+		context.Background(),
 		txOuts, nil, nil, 0, 1, 1000, CoinSelectionLargest, true,
-		nil, alwaysAllowUtxo,
+		false, nil, alwaysAllowUtxo,
 	)
 	if err != nil {
 		t.Fatalf("unable to author tx: %v", err)
@@ -98,8 +100,9 @@ func TestTxToOutputsDryRun(t *testing.T) {
 	}
 
 	dryRunTx2, err := w.txToOutputs(
+		context.Background(),
 		txOuts, nil, nil, 0, 1, 1000, CoinSelectionLargest, true,
-		nil, alwaysAllowUtxo,
+		false, nil, alwaysAllowUtxo,
 	)
 	if err != nil {
 		t.Fatalf("unable to author tx: %v", err)
@@ -134,8 +137,9 @@ func TestTxToOutputsDryRun(t *testing.T) {
 	// Now we do a proper, non-dry run. This should add a change address
 	// to the database.
 	tx, err := w.txToOutputs(
+		context.Background(),
 		txOuts, nil, nil, 0, 1, 1000, CoinSelectionLargest, false,
-		nil, alwaysAllowUtxo,
+		false, nil, alwaysAllowUtxo,
 	)
 	if err != nil {
 		t.Fatalf("unable to author tx: %v", err)
@@ -285,8 +289,10 @@ func estTxToOutputsRandom(t *testing.T) {
 
 	createTx := func() *txauthor.AuthoredTx {
 		tx, err := w.txToOutputs(
+			context.Background(),
 			txOuts, nil, nil, 0, 1, feeSatPerKb,
-			CoinSelectionRandom, true, nil, alwaysAllowUtxo,
+			CoinSelectionRandom, true, false, nil,
+			alwaysAllowUtxo,
 		)
 		require.NoError(t, err)
 		return tx
@@ -357,8 +363,9 @@ func TestCreateSimpleCustomChange(t *testing.T) {
 		PkScript: p2trScript,
 	}
 	tx1, err := w.txToOutputs(
+		context.Background(),
 		[]*wire.TxOut{targetTxOut}, nil, nil, 0, 1, 1000,
-		CoinSelectionLargest, true, nil, alwaysAllowUtxo,
+		CoinSelectionLargest, true, false, nil, alwaysAllowUtxo,
 	)
 	require.NoError(t, err)
 
@@ -382,9 +389,10 @@ func TestCreateSimpleCustomChange(t *testing.T) {
 		PkScript: p2trScript,
 	}
 	tx2, err := w.txToOutputs(
+		context.Background(),
 		[]*wire.TxOut{targetTxOut}, &waddrmgr.KeyScopeBIP0086,
 		&waddrmgr.KeyScopeBIP0084, 0, 1, 1000, CoinSelectionLargest,
-		true, nil, alwaysAllowUtxo,
+		true, false, nil, alwaysAllowUtxo,
 	)
 	require.NoError(t, err)
 
@@ -467,8 +475,10 @@ func TestSelectUtxosTxoToOutpoint(t *testing.T) {
 		PkScript: p2trScript,
 	}
 	tx1, err := w.txToOutputs(
+		context.Background(),
 		[]*wire.TxOut{targetTxOut}, nil, nil, 0, 1, 1000,
-		CoinSelectionLargest, true, selectUtxos, alwaysAllowUtxo,
+		CoinSelectionLargest, true, false, selectUtxos,
+		alwaysAllowUtxo,
 	)
 	require.NoError(t, err)
 
@@ -0,0 +1,275 @@
+package wallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// DumpedKey is a private key exported by DumpWallet.
+type DumpedKey struct {
+	// WIF is the private key.
+	WIF *btcutil.WIF
+
+	// Address is the wallet address of the key.
+	Address btcutil.Address
+
+	// Time is the earliest time the key may have been used, or the zero
+	// time if it is unknown.
+	Time time.Time
+
+	// Account is the name of the account the key belongs to.
+	Account string
+
+	// Internal is set for change keys.
+	Internal bool
+
+	// DerivationPath is the BIP32 path of the key, in the form
+	// m/purpose'/coin'/account'/branch/index, or empty for imported keys.
+	DerivationPath string
+}
+
+// DumpedScript is a redeem script exported by DumpWallet.
+type DumpedScript struct {
+	// Script is the redeem script.
+	Script []byte
+
+	// Address is the pay-to-script-hash address of the script.
+	Address btcutil.Address
+
+	// Time is the earliest time the script may have been used, or the
+	// zero time if it is unknown.
+	Time time.Time
+}
+
+// WalletDump holds the private keys and scripts of a wallet.
+type WalletDump struct {
+	Keys     []DumpedKey
+	Scripts  []DumpedScript
+	SyncedTo waddrmgr.BlockStamp
+}
+
+// DumpWallet exports the private keys and pay-to-script-hash redeem scripts
+// of all active addresses of the wallet.  Addresses without a private key are
+// skipped.  The wallet must be unlocked.
+func (w *Wallet) DumpWallet() (*WalletDump, error) {
+	heldUnlock, err := w.holdUnlock()
+	if err != nil {
+		return nil, err
+	}
+	defer heldUnlock.release()
+
+	dump := &WalletDump{
+		SyncedTo: w.Manager.SyncedTo(),
+	}
+	birthday := w.Manager.Birthday()
+
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+
+		// The addresses are collected first, since the scoped managers
+		// can't be queried while they are iterated.
+		var addrs []btcutil.Address
+		err := w.Manager.ForEachActiveAddress(
+			addrmgrNs, func(addr btcutil.Address) error {
+				addrs = append(addrs, addr)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, addr := range addrs {
+			maddr, err := w.Manager.Address(addrmgrNs, addr)
+			if err != nil {
+				return err
+			}
+
+			switch maddr := maddr.(type) {
+			case waddrmgr.ManagedPubKeyAddress:
+				key, err := w.dumpKey(addrmgrNs, maddr)
+				if waddrmgr.IsError(err, waddrmgr.ErrWatchingOnly) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				key.Time = birthday
+				dump.Keys = append(dump.Keys, *key)
+
+			case waddrmgr.ManagedScriptAddress:
+				if _, ok := addr.(*btcutil.AddressScriptHash); !ok {
+					continue
+				}
+				script, err := maddr.Script()
+				if err != nil {
+					return err
+				}
+				dump.Scripts = append(dump.Scripts, DumpedScript{
+					Script:  script,
+					Address: addr,
+					Time:    birthday,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dump, nil
+}
+
+// dumpKey exports the private key of the address.
+func (w *Wallet) dumpKey(ns walletdb.ReadBucket,
+	maddr waddrmgr.ManagedPubKeyAddress) (*DumpedKey, error) {
+
+	wif, err := maddr.ExportPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	scopedMgr, account, err := w.Manager.AddrAccount(ns, maddr.Address())
+	if err != nil {
+		return nil, err
+	}
+	accountName, err := scopedMgr.AccountName(ns, account)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &DumpedKey{
+		WIF:      wif,
+		Address:  maddr.Address(),
+		Account:  accountName,
+		Internal: maddr.Internal(),
+	}
+	if scope, path, ok := maddr.DerivationInfo(); ok {
+		key.DerivationPath = fmt.Sprintf("m/%d'/%d'/%s/%d/%d",
+			scope.Purpose, scope.Coin, formatChildIndex(path.Account),
+			path.Branch, path.Index)
+	}
+
+	return key, nil
+}
+
+// formatChildIndex formats a BIP32 child index, marking hardened indices
+// with an apostrophe.
+func formatChildIndex(index uint32) string {
+	if index >= hdkeychain.HardenedKeyStart {
+		return fmt.Sprintf("%d'", index-hdkeychain.HardenedKeyStart)
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+// ImportDump imports the private keys and redeem scripts of a wallet dump,
+// and rescans the chain for them starting at the earliest time of the
+// imported items.  Keys are imported into the imported account of the key
+// scope matching their address type, since the address manager can't add
+// private keys to other accounts: the Account of the dumped keys is not
+// restored.  Keys and scripts that are already known to the wallet are
+// skipped, and keep their account.  The addresses of the imported items are
+// returned.
+func (w *Wallet) ImportDump(dump *WalletDump) ([]btcutil.Address, error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// The rescan starts at the earliest time of the imported items, or at
+	// the genesis block if any of them has no known time.
+	var earliest time.Time
+	unknownTime := false
+	updateEarliest := func(t time.Time) {
+		if t.IsZero() {
+			unknownTime = true
+		} else if earliest.IsZero() || t.Before(earliest) {
+			earliest = t
+		}
+	}
+	for _, key := range dump.Keys {
+		updateEarliest(key.Time)
+	}
+	for _, script := range dump.Scripts {
+		updateEarliest(script.Time)
+	}
+
+	bs := waddrmgr.BlockStamp{
+		Hash:      *w.chainParams.GenesisHash,
+		Timestamp: w.chainParams.GenesisBlock.Header.Timestamp,
+	}
+	if !unknownTime && earliest.After(bs.Timestamp) {
+		startBlock, err := locateBirthdayBlock(chainClient, earliest)
+		if err != nil {
+			return nil, err
+		}
+		bs = *startBlock
+	}
+
+	var imported []btcutil.Address
+	for _, key := range dump.Keys {
+		keyStamp := bs
+		addr, err := w.ImportPrivateKey(
+			importKeyScope(key.Address), key.WIF, &keyStamp, false,
+		)
+		if waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress) {
+			continue
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		decoded, err := btcutil.DecodeAddress(addr, w.chainParams)
+		if err != nil {
+			return imported, err
+		}
+		imported = append(imported, decoded)
+	}
+
+	for _, script := range dump.Scripts {
+		addr, err := w.ImportP2SHRedeemScript(script.Script)
+		if err != nil {
+			return imported, err
+		}
+		imported = append(imported, addr)
+	}
+
+	if len(imported) == 0 {
+		return nil, nil
+	}
+
+	job := &RescanJob{
+		Addrs:      imported,
+		BlockStamp: bs,
+	}
+	select {
+	case err := <-w.SubmitRescan(job):
+		return imported, err
+	case <-w.quitChan():
+		return imported, ErrWalletShuttingDown
+	}
+}
+
+// importKeyScope returns the key scope a private key of a dumped address is
+// imported into, so that the imported key produces the same address type.
+func importKeyScope(addr btcutil.Address) waddrmgr.KeyScope {
+	switch addr.(type) {
+	case *btcutil.AddressScriptHash:
+		return waddrmgr.KeyScopeBIP0049Plus
+
+	case *btcutil.AddressWitnessPubKeyHash:
+		return waddrmgr.KeyScopeBIP0084
+
+	case *btcutil.AddressTaproot:
+		return waddrmgr.KeyScopeBIP0086
+
+	default:
+		return waddrmgr.KeyScopeBIP0044
+	}
+}
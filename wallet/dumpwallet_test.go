@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// TestDumpWalletImportDump checks that the keys and scripts dumped from one
+// wallet are imported into another one, followed by a rescan for them.
+func TestDumpWalletImportDump(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(
+		waddrmgr.DefaultAccountNum, waddrmgr.KeyScopeBIP0084,
+	)
+	require.NoError(t, err)
+	changeAddr, err := w.NewChangeAddress(
+		waddrmgr.DefaultAccountNum, waddrmgr.KeyScopeBIP0049Plus,
+	)
+	require.NoError(t, err)
+	script := []byte{txscript.OP_TRUE}
+	scriptAddr, err := w.ImportP2SHRedeemScript(script)
+	require.NoError(t, err)
+
+	dump, err := w.DumpWallet()
+	require.NoError(t, err)
+
+	keys := make(map[string]DumpedKey)
+	for _, key := range dump.Keys {
+		keys[key.Address.EncodeAddress()] = key
+	}
+	key, ok := keys[addr.EncodeAddress()]
+	require.True(t, ok)
+	require.Equal(t, "default", key.Account)
+	require.False(t, key.Internal)
+	require.Equal(t, "m/84'/0'/0'/0/0", key.DerivationPath)
+	require.Equal(t, w.Manager.Birthday(), key.Time)
+
+	key, ok = keys[changeAddr.EncodeAddress()]
+	require.True(t, ok)
+	require.True(t, key.Internal)
+	require.Equal(t, "m/49'/0'/0'/1/0", key.DerivationPath)
+
+	require.Len(t, dump.Scripts, 1)
+	require.Equal(t, script, dump.Scripts[0].Script)
+	require.Equal(t, scriptAddr.EncodeAddress(),
+		dump.Scripts[0].Address.EncodeAddress())
+
+	// Private keys can't be dumped from a locked wallet.
+	w.Lock()
+	_, err = w.DumpWallet()
+	require.True(t, waddrmgr.IsError(err, waddrmgr.ErrLocked))
+
+	w2, cleanup2 := testWallet(t)
+	defer cleanup2()
+
+	chainClient := w2.chainClient.(*mockChainClient)
+	chainClient.getBlockHashFunc = func() (*chainhash.Hash, error) {
+		return &chainhash.Hash{1}, nil
+	}
+	chainClient.getBlockHeader = &wire.BlockHeader{Timestamp: time.Now()}
+
+	// The birthday block is set once the wallet synced to the chain.
+	err = walletdb.Update(w2.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w2.Manager.SetBirthdayBlock(ns, waddrmgr.BlockStamp{
+			Hash:   chainhash.Hash{2},
+			Height: 10,
+		}, true)
+	})
+	require.NoError(t, err)
+
+	// The wallet isn't started, so the rescan job is received here.
+	jobs := make(chan *RescanJob, 1)
+	go func() {
+		job := <-w2.rescanAddJob
+		job.err <- nil
+		jobs <- job
+	}()
+
+	imported, err := w2.ImportDump(dump)
+	require.NoError(t, err)
+	require.Len(t, imported, len(dump.Keys)+1)
+
+	job := <-jobs
+	require.ElementsMatch(t, imported, job.Addrs)
+	require.Equal(t, chainhash.Hash{1}, job.BlockStamp.Hash)
+
+	for _, a := range []btcutil.Address{addr, changeAddr, scriptAddr} {
+		have, err := w2.HaveAddress(a)
+		require.NoError(t, err)
+		require.True(t, have, "address %v not imported", a)
+	}
+
+	// Importing the dump again skips the known keys.
+	imported, err = w2.ImportDump(&WalletDump{Keys: dump.Keys})
+	require.NoError(t, err)
+	require.Empty(t, imported)
+}
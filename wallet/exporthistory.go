@@ -0,0 +1,485 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// historyBatchBlocks is the number of block heights read in a single database
+// transaction by ExportHistory.  Entries are written between batches, so a slow
+// writer does not keep a database transaction open, and at most one batch of
+// entries is held in memory.
+const historyBatchBlocks = 1000
+
+// HistoryFormat is the encoding of an exported transaction history.
+type HistoryFormat uint8
+
+const (
+	// HistoryCSV encodes the history as comma-separated values with a
+	// header row.  Address lists are separated by semicolons.
+	HistoryCSV HistoryFormat = iota
+
+	// HistoryJSONLines encodes the history as one JSON object per line.
+	HistoryJSONLines
+)
+
+// String returns the name of the format as accepted by ParseHistoryFormat.
+func (f HistoryFormat) String() string {
+	switch f {
+	case HistoryCSV:
+		return "csv"
+	case HistoryJSONLines:
+		return "jsonl"
+	default:
+		return fmt.Sprintf("HistoryFormat(%d)", uint8(f))
+	}
+}
+
+// ParseHistoryFormat returns the format with the given name, either "csv" or
+// "jsonl".
+func ParseHistoryFormat(s string) (HistoryFormat, error) {
+	switch strings.ToLower(s) {
+	case "csv":
+		return HistoryCSV, nil
+	case "jsonl", "jsonlines":
+		return HistoryJSONLines, nil
+	default:
+		return 0, fmt.Errorf("unknown history format %q", s)
+	}
+}
+
+// HistoryFilter selects the transactions exported by ExportHistory.
+type HistoryFilter struct {
+	// StartHeight is the height of the first block whose transactions are
+	// exported.
+	StartHeight int32
+
+	// EndHeight is the height of the last block whose transactions are
+	// exported.  -1 exports all blocks from StartHeight on, followed by
+	// the unmined transactions.
+	EndHeight int32
+
+	// Account, if not empty, limits the export to the accounts with this
+	// name in any key scope.
+	Account string
+}
+
+// HistoryEntry is the effect of a transaction on a single account.  A
+// transaction that debits or credits several accounts has an entry for each of
+// them.  Amounts are in satoshis, so the entries can be valued in any currency
+// from their block time.
+type HistoryEntry struct {
+	TxID string `json:"txid"`
+
+	// KeyScope and Account identify the account, e.g. m/84'/0' and
+	// default.  Both are empty if the account of a wallet output could
+	// not be determined.
+	KeyScope string `json:"keyscope"`
+	Account  string `json:"account"`
+
+	// Debit is the value of the account's outputs spent by the
+	// transaction, and Credit the value of the outputs it pays to the
+	// account, including change.
+	Debit  btcutil.Amount `json:"debit"`
+	Credit btcutil.Amount `json:"credit"`
+
+	// Fee is the fee paid by the transaction.  It is only known if the
+	// wallet funded every input, and is reported on the entry of the
+	// first account funding the transaction only, so that summing the
+	// fees of all entries doesn't count a fee twice.
+	Fee btcutil.Amount `json:"fee"`
+
+	// Net is Credit minus Debit.
+	Net btcutil.Amount `json:"net"`
+
+	Label string `json:"label"`
+
+	// BlockHeight is -1 and the block fields are empty for unmined
+	// transactions.  Times are RFC 3339 in UTC.
+	BlockHeight   int32  `json:"blockheight"`
+	BlockHash     string `json:"blockhash"`
+	BlockTime     string `json:"blocktime"`
+	Received      string `json:"received"`
+	Confirmations int32  `json:"confirmations"`
+
+	// Status is unconfirmed, confirmed, or immature for coinbase
+	// transactions that can't be spent yet.
+	Status string `json:"status"`
+
+	// Addresses are the addresses of the account the transaction pays
+	// to.  Counterparties are the addresses of the outputs that don't
+	// belong to the wallet: the recipients of a payment, or the change
+	// addresses of the sender of a deposit.
+	Addresses      []string `json:"addresses"`
+	Counterparties []string `json:"counterparties"`
+}
+
+// historyColumns are the CSV header of an exported history.
+var historyColumns = []string{
+	"txid", "keyscope", "account", "debit", "credit", "fee", "net",
+	"label", "blockheight", "blockhash", "blocktime", "received",
+	"confirmations", "status", "addresses", "counterparties",
+}
+
+// record returns the CSV columns of the entry.
+func (e *HistoryEntry) record() []string {
+	return []string{
+		e.TxID, e.KeyScope, e.Account,
+		strconv.FormatInt(int64(e.Debit), 10),
+		strconv.FormatInt(int64(e.Credit), 10),
+		strconv.FormatInt(int64(e.Fee), 10),
+		strconv.FormatInt(int64(e.Net), 10),
+		e.Label,
+		strconv.FormatInt(int64(e.BlockHeight), 10),
+		e.BlockHash, e.BlockTime, e.Received,
+		strconv.FormatInt(int64(e.Confirmations), 10),
+		e.Status,
+		strings.Join(e.Addresses, ";"),
+		strings.Join(e.Counterparties, ";"),
+	}
+}
+
+// historyEncoder writes history entries in one of the history formats.
+type historyEncoder interface {
+	encode(e *HistoryEntry) error
+	flush() error
+}
+
+type csvHistoryEncoder struct {
+	w *csv.Writer
+}
+
+func (c *csvHistoryEncoder) encode(e *HistoryEntry) error {
+	return c.w.Write(e.record())
+}
+
+func (c *csvHistoryEncoder) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonHistoryEncoder struct {
+	enc *json.Encoder
+}
+
+func (j *jsonHistoryEncoder) encode(e *HistoryEntry) error {
+	return j.enc.Encode(e)
+}
+
+func (j *jsonHistoryEncoder) flush() error {
+	return nil
+}
+
+// newHistoryEncoder returns an encoder of the format writing to out.  The CSV
+// header is written immediately.
+func newHistoryEncoder(out io.Writer, format HistoryFormat) (historyEncoder,
+	error) {
+
+	switch format {
+	case HistoryCSV:
+		w := csv.NewWriter(out)
+		if err := w.Write(historyColumns); err != nil {
+			return nil, err
+		}
+		return &csvHistoryEncoder{w: w}, nil
+
+	case HistoryJSONLines:
+		enc := json.NewEncoder(out)
+		enc.SetEscapeHTML(false)
+		return &jsonHistoryEncoder{enc: enc}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown history format %v", format)
+	}
+}
+
+// ExportHistory writes the transaction history of the wallet selected by
+// filter to out.  Mined transactions are written in block order, followed by
+// the unmined transactions if the filter includes them.  The number of
+// entries written is returned.
+//
+// The history is read in batches of blocks, so the export of a large wallet
+// uses little memory and doesn't hold a database transaction open while out
+// is written to.
+func (w *Wallet) ExportHistory(out io.Writer, filter HistoryFilter,
+	format HistoryFormat) (int, error) {
+
+	return w.exportHistory(out, filter, format, historyBatchBlocks)
+}
+
+// exportHistory implements ExportHistory, reading batchBlocks block heights
+// per database transaction.
+func (w *Wallet) exportHistory(out io.Writer, filter HistoryFilter,
+	format HistoryFormat, batchBlocks int32) (int, error) {
+
+	if filter.StartHeight < 0 {
+		return 0, errors.New("start height must not be negative")
+	}
+	if filter.EndHeight != -1 && filter.EndHeight < filter.StartHeight {
+		return 0, errors.New("end height is below start height")
+	}
+
+	enc, err := newHistoryEncoder(out, format)
+	if err != nil {
+		return 0, err
+	}
+
+	syncHeight := w.Manager.SyncedTo().Height
+
+	var n int
+	for start := filter.StartHeight; ; {
+		// The last batch of an open-ended export also picks up
+		// transactions recorded above the synced-to height during a
+		// rescan, and the unmined transactions.
+		end := start + batchBlocks - 1
+		last := false
+		switch {
+		case filter.EndHeight == -1 && end >= syncHeight:
+			end, last = -1, true
+		case filter.EndHeight != -1 && end >= filter.EndHeight:
+			end, last = filter.EndHeight, true
+		}
+
+		var entries []HistoryEntry
+		err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+			txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+			rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+				for i := range details {
+					e, err := w.historyEntries(
+						tx, &details[i], syncHeight,
+						filter.Account,
+					)
+					if err != nil {
+						return false, err
+					}
+					entries = append(entries, e...)
+				}
+				return false, nil
+			}
+
+			return w.TxStore.RangeTransactions(
+				txmgrNs, start, end, rangeFn,
+			)
+		})
+		if err != nil {
+			return n, err
+		}
+
+		for i := range entries {
+			if err := enc.encode(&entries[i]); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if err := enc.flush(); err != nil {
+			return n, err
+		}
+
+		if last {
+			return n, nil
+		}
+		start = end + 1
+	}
+}
+
+// historyAccount identifies the account of a history entry.  The zero value
+// stands for wallet outputs whose account is unknown.
+type historyAccount struct {
+	scope   waddrmgr.KeyScope
+	account uint32
+}
+
+// historyEntries returns the entries of the accounts a transaction debits or
+// credits, ordered by key scope and account number.  If account is not empty,
+// only the entries of accounts with this name are returned.
+func (w *Wallet) historyEntries(dbtx walletdb.ReadTx,
+	details *wtxmgr.TxDetails, syncHeight int32,
+	account string) ([]HistoryEntry, error) {
+
+	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+	byAccount := make(map[historyAccount]*HistoryEntry)
+	entry := func(addr btcutil.Address) *HistoryEntry {
+		var key historyAccount
+		var name string
+		if addr != nil {
+			mgr, acct, err := w.Manager.AddrAccount(addrmgrNs, addr)
+			if err == nil {
+				key = historyAccount{mgr.Scope(), acct}
+				name, err = mgr.AccountName(addrmgrNs, acct)
+			}
+			if err != nil {
+				log.Errorf("Cannot fetch account of %v in "+
+					"transaction %v: %v", addr,
+					details.Hash, err)
+				key, name = historyAccount{}, ""
+			}
+		}
+
+		e, ok := byAccount[key]
+		if !ok {
+			e = &HistoryEntry{Account: name}
+			if key != (historyAccount{}) {
+				e.KeyScope = key.scope.String()
+			}
+			byAccount[key] = e
+		}
+		return e
+	}
+
+	// Credits are attributed to the account of their address.  All other
+	// outputs are payments to counterparties.
+	counterparties := []string{}
+	credited := make(map[uint32]bool, len(details.Credits))
+	for _, cred := range details.Credits {
+		credited[cred.Index] = true
+		addr := w.outputAddress(details.MsgTx.TxOut[cred.Index].PkScript)
+		e := entry(addr)
+		e.Credit += cred.Amount
+		if addr != nil {
+			e.Addresses = append(e.Addresses, addr.EncodeAddress())
+		}
+	}
+	for i, txOut := range details.MsgTx.TxOut {
+		if credited[uint32(i)] {
+			continue
+		}
+		if addr := w.outputAddress(txOut.PkScript); addr != nil {
+			counterparties = append(
+				counterparties, addr.EncodeAddress(),
+			)
+		}
+	}
+
+	// Debits are attributed to the account of the output they spend.
+	for _, deb := range details.Debits {
+		prevOP := &details.MsgTx.TxIn[deb.Index].PreviousOutPoint
+		prev, err := w.TxStore.TxDetails(txmgrNs, &prevOP.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		var addr btcutil.Address
+		if prev != nil && int(prevOP.Index) < len(prev.MsgTx.TxOut) {
+			addr = w.outputAddress(
+				prev.MsgTx.TxOut[prevOP.Index].PkScript,
+			)
+		}
+		entry(addr).Debit += deb.Amount
+	}
+
+	keys := make([]historyAccount, 0, len(byAccount))
+	for key := range byAccount {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.scope.Purpose != b.scope.Purpose {
+			return a.scope.Purpose < b.scope.Purpose
+		}
+		if a.scope.Coin != b.scope.Coin {
+			return a.scope.Coin < b.scope.Coin
+		}
+		return a.account < b.account
+	})
+
+	// The fee is only known if every input is a debit.  It is reported
+	// on the entry of the first account funding the transaction.
+	var (
+		fee    btcutil.Amount
+		feeKey *historyAccount
+	)
+	if len(details.Debits) == len(details.MsgTx.TxIn) {
+		for _, deb := range details.Debits {
+			fee += deb.Amount
+		}
+		for _, txOut := range details.MsgTx.TxOut {
+			fee -= btcutil.Amount(txOut.Value)
+		}
+		for i := range keys {
+			if byAccount[keys[i]].Debit != 0 {
+				feeKey = &keys[i]
+				break
+			}
+		}
+	}
+
+	common := HistoryEntry{
+		TxID:           details.Hash.String(),
+		Label:          details.Label,
+		BlockHeight:    details.Block.Height,
+		Received:       historyTime(details.Received),
+		Counterparties: counterparties,
+		Status:         "unconfirmed",
+	}
+	if details.Block.Height != -1 {
+		common.BlockHash = details.Block.Hash.String()
+		common.BlockTime = historyTime(details.Block.Time)
+		common.Confirmations = confirms(details.Block.Height, syncHeight)
+		common.Status = "confirmed"
+		if RecvCategory(details, syncHeight, w.chainParams) ==
+			CreditImmature {
+
+			common.Status = "immature"
+		}
+	}
+
+	entries := make([]HistoryEntry, 0, len(keys))
+	for _, key := range keys {
+		e := byAccount[key]
+		if account != "" && e.Account != account {
+			continue
+		}
+
+		entry := common
+		entry.KeyScope = e.KeyScope
+		entry.Account = e.Account
+		entry.Debit = e.Debit
+		entry.Credit = e.Credit
+		entry.Net = e.Credit - e.Debit
+		entry.Addresses = e.Addresses
+		if entry.Addresses == nil {
+			entry.Addresses = []string{}
+		}
+		if feeKey != nil && key == *feeKey {
+			entry.Fee = fee
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// outputAddress returns the address an output script pays to, or nil if it
+// doesn't pay to a single address.
+func (w *Wallet) outputAddress(pkScript []byte) btcutil.Address {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		pkScript, w.chainParams,
+	)
+	if err != nil || len(addrs) != 1 {
+		return nil
+	}
+	return addrs[0]
+}
+
+// historyTime formats a time of an exported history.
+func historyTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,170 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// TestExportHistory checks the entries exported for deposits to two accounts,
+// a payment funded by both of them, and an unmined deposit, in both formats
+// and across batches.
+func TestExportHistory(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.chainClient.(*mockChainClient).getBlockHeader = &wire.BlockHeader{}
+
+	scope := waddrmgr.KeyScopeBIP0084
+	savings, err := w.NextAccount(scope, "savings")
+	require.NoError(t, err)
+
+	newAddr := func(account uint32, change bool) btcutil.Address {
+		t.Helper()
+
+		newAddrFn := w.NewAddress
+		if change {
+			newAddrFn = w.NewChangeAddress
+		}
+		addr, err := newAddrFn(account, scope)
+		require.NoError(t, err)
+		return addr
+	}
+	payTo := func(addr btcutil.Address, amount int64) *wire.TxOut {
+		t.Helper()
+
+		script, err := txscript.PayToAddrScript(addr)
+		require.NoError(t, err)
+		return wire.NewTxOut(amount, script)
+	}
+	addTx := func(tx *wire.MsgTx, block *wtxmgr.BlockMeta) {
+		t.Helper()
+
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Unix(100, 0))
+		require.NoError(t, err)
+		require.NoError(t, walletdb.Update(w.db,
+			func(dbtx walletdb.ReadWriteTx) error {
+				return w.addRelevantTx(dbtx, rec, block)
+			},
+		))
+	}
+	receive := func(addr btcutil.Address, amount int64,
+		block *wtxmgr.BlockMeta) wire.OutPoint {
+
+		t.Helper()
+
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{
+				Hash: chainhash.Hash{byte(amount >> 8)},
+			},
+		})
+		tx.AddTxOut(payTo(addr, amount))
+		addTx(tx, block)
+		return wire.OutPoint{Hash: tx.TxHash()}
+	}
+
+	defaultAddr := newAddr(waddrmgr.DefaultAccountNum, false)
+	savingsAddr := newAddr(savings, false)
+	change := newAddr(waddrmgr.DefaultAccountNum, true)
+	foreign, err := btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), w.chainParams,
+	)
+	require.NoError(t, err)
+
+	block1, block2, block3 := confTestBlock(1, 0), confTestBlock(2, 0),
+		confTestBlock(3, 0)
+	op1 := receive(defaultAddr, 10000, &block1)
+	op2 := receive(savingsAddr, 20000, &block2)
+
+	spend := wire.NewMsgTx(2)
+	spend.AddTxIn(&wire.TxIn{PreviousOutPoint: op1})
+	spend.AddTxIn(&wire.TxIn{PreviousOutPoint: op2})
+	spend.AddTxOut(payTo(foreign, 12000))
+	spend.AddTxOut(payTo(change, 17000))
+	addTx(spend, &block3)
+
+	receive(defaultAddr, 5000, nil)
+
+	require.NoError(t, walletdb.Update(w.db,
+		func(dbtx walletdb.ReadWriteTx) error {
+			ns := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+				Height: 3, Hash: block3.Hash,
+			})
+		},
+	))
+
+	type summary struct {
+		account             string
+		height              int32
+		debit, credit, fee  btcutil.Amount
+		net                 btcutil.Amount
+		confirmations       int32
+		status              string
+		addrs, counterparty int
+	}
+	want := []summary{
+		{"default", 1, 0, 10000, 0, 10000, 3, "confirmed", 1, 0},
+		{"savings", 2, 0, 20000, 0, 20000, 2, "confirmed", 1, 0},
+		{"default", 3, 10000, 17000, 1000, 7000, 1, "confirmed", 1, 1},
+		{"savings", 3, 20000, 0, 0, -20000, 1, "confirmed", 0, 1},
+		{"default", -1, 0, 5000, 0, 5000, 0, "unconfirmed", 1, 0},
+	}
+	summarize := func(e HistoryEntry) summary {
+		return summary{
+			e.Account, e.BlockHeight, e.Debit, e.Credit, e.Fee,
+			e.Net, e.Confirmations, e.Status, len(e.Addresses),
+			len(e.Counterparties),
+		}
+	}
+
+	// A batch of a single block exercises the batch boundaries.
+	for _, batch := range []int32{1, historyBatchBlocks} {
+		var buf bytes.Buffer
+		n, err := w.exportHistory(&buf, HistoryFilter{EndHeight: -1},
+			HistoryJSONLines, batch)
+		require.NoError(t, err)
+		require.Equal(t, len(want), n)
+
+		var got []summary
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var e HistoryEntry
+			require.NoError(t, dec.Decode(&e))
+			require.Equal(t, scope.String(), e.KeyScope)
+			got = append(got, summarize(e))
+		}
+		require.Equal(t, want, got)
+	}
+
+	// The savings account of the first three blocks only.
+	var buf bytes.Buffer
+	n, err := w.ExportHistory(&buf, HistoryFilter{
+		StartHeight: 0, EndHeight: 3, Account: "savings",
+	}, HistoryCSV)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, historyColumns, records[0])
+	require.Equal(t, spend.TxHash().String(), records[2][0])
+	require.Equal(t, []string{"20000", "0", "0", "-20000"},
+		records[2][3:7])
+	require.Equal(t, foreign.EncodeAddress(), records[2][15])
+}
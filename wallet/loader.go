@@ -15,8 +15,12 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stroomnetwork/btcwallet/backup"
+	"github.com/stroomnetwork/btcwallet/bip39"
 	"github.com/stroomnetwork/btcwallet/internal/prompt"
+	"github.com/stroomnetwork/btcwallet/slip39"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -26,6 +30,21 @@ const (
 	// DefaultDBTimeout is the default timeout value when opening the wallet
 	// database.
 	DefaultDBTimeout = 60 * time.Second
+
+	// DBBackendBolt is the walletdb driver of the bbolt database backend,
+	// the default backend of a Loader.
+	DBBackendBolt = "bdb"
+
+	// DBBackendSQLite is the walletdb driver of the SQLite database
+	// backend.  The driver must be registered by importing the
+	// walletdb/sqlite package.
+	DBBackendSQLite = "sqlite"
+
+	// DBBackendPostgres is the walletdb driver of the PostgreSQL database
+	// backend, whose database is given by WithPostgresDSN rather than the
+	// database directory of the loader.  The driver must be registered by
+	// importing the walletdb/postgres package.
+	DBBackendPostgres = "postgres"
 )
 
 var (
@@ -40,17 +59,28 @@ var (
 	// ErrExists describes the error condition of attempting to create a new
 	// wallet when one exists already.
 	ErrExists = errors.New("wallet already exists")
+
+	// ErrNotExists describes the error condition of attempting to load a
+	// wallet that has not been created.
+	ErrNotExists = errors.New("wallet does not exist")
 )
 
 // loaderConfig contains the configuration options for the loader.
 type loaderConfig struct {
 	walletSyncRetryInterval time.Duration
+	maxReorgDepth           int32
+	metrics                 Metrics
+	tracerProvider          trace.TracerProvider
+	privPassKDF             *waddrmgr.ScryptOptions
+	dbBackend               string
+	postgresDSN             string
 }
 
 // defaultLoaderConfig returns the default configuration options for the loader.
 func defaultLoaderConfig() *loaderConfig {
 	return &loaderConfig{
 		walletSyncRetryInterval: defaultSyncRetryInterval,
+		dbBackend:               DBBackendBolt,
 	}
 }
 
@@ -65,6 +95,41 @@ func WithWalletSyncRetryInterval(interval time.Duration) LoaderOption {
 	}
 }
 
+// WithArgon2Options specifies the Argon2id parameters the key of the private
+// passphrase of a wallet is derived with, when the wallet is created and when
+// its private passphrase is changed.
+func WithArgon2Options(opts waddrmgr.Argon2Options) LoaderOption {
+	return func(c *loaderConfig) {
+		c.privPassKDF = &waddrmgr.ScryptOptions{Argon2: &opts}
+	}
+}
+
+// WithDBBackend specifies the walletdb driver of the wallet database in the
+// database directory of the loader, DBBackendBolt, DBBackendSQLite or
+// DBBackendPostgres.
+func WithDBBackend(backend string) LoaderOption {
+	return func(c *loaderConfig) {
+		c.dbBackend = backend
+	}
+}
+
+// WithPostgresDSN specifies the connection string of the database of the
+// DBBackendPostgres backend.
+func WithPostgresDSN(dsn string) LoaderOption {
+	return func(c *loaderConfig) {
+		c.postgresDSN = dsn
+	}
+}
+
+// WithMaxReorgDepth specifies the maximum number of blocks that may be
+// disconnected by a chain reorganization before the loaded wallet halts.
+// Zero, the default, disables the check.
+func WithMaxReorgDepth(depth int32) LoaderOption {
+	return func(c *loaderConfig) {
+		c.maxReorgDepth = depth
+	}
+}
+
 // Loader implements the creating of new and opening of existing wallets, while
 // providing a callback system for other subsystems to handle the loading of a
 // wallet.  This is primarily intended for use by the RPC servers, to enable
@@ -86,6 +151,10 @@ type Loader struct {
 	walletCreated  func(db walletdb.ReadWriteTx) error
 	db             walletdb.DB
 	mu             sync.Mutex
+
+	// loadHook is run every time a wallet is loaded, unlike the callbacks
+	// which only run for the first.  It is used by the MultiLoader.
+	loadHook func(*Wallet)
 }
 
 // NewLoader constructs a Loader with an optional recovery window. If the
@@ -149,6 +218,10 @@ func (l *Loader) onLoaded(w *Wallet) {
 		fn(w)
 	}
 
+	if l.loadHook != nil {
+		l.loadHook(w)
+	}
+
 	l.wallet = w
 	l.callbacks = nil // not needed anymore
 }
@@ -222,6 +295,83 @@ func (l *Loader) CreateNewWalletExtendedKey(pubPassphrase, privPassphrase []byte
 	)
 }
 
+// SeedShares describes how the seed of a new wallet is split into SLIP-39
+// share mnemonics: into one share for each of the groups, GroupThreshold of
+// which are needed to restore the seed, and the share of each group into
+// member shares as described by the group.  The seed is encrypted in the
+// shares with Passphrase, which may be empty.
+type SeedShares struct {
+	GroupThreshold int
+	Groups         []slip39.Group
+	Passphrase     []byte
+
+	// Export is called with the share mnemonics, by group, before the
+	// wallet is created.  The wallet isn't created if it returns an
+	// error, so that no wallet is left without a backup of its seed.
+	Export func(mnemonics [][]string) error
+}
+
+// CreateNewWalletWithShares creates a new wallet like CreateNewWallet after
+// splitting its seed into SLIP-39 share mnemonics and exporting them.  If
+// seed is nil, a seed of the recommended length is generated.  The seed
+// itself is never returned, so no single holder of a share can restore the
+// wallet.
+func (l *Loader) CreateNewWalletWithShares(pubPassphrase, privPassphrase,
+	seed []byte, shares *SeedShares, bday time.Time) (*Wallet, error) {
+
+	if seed == nil {
+		var err error
+		seed, err = hdkeychain.GenerateSeed(
+			hdkeychain.RecommendedSeedLen,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mnemonics, err := slip39.Split(
+		seed, shares.Passphrase, shares.GroupThreshold, shares.Groups,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to split seed: %w", err)
+	}
+	if err := shares.Export(mnemonics); err != nil {
+		return nil, fmt.Errorf("unable to export seed shares: %w", err)
+	}
+
+	return l.CreateNewWallet(pubPassphrase, privPassphrase, seed, bday)
+}
+
+// CreateNewWalletFromShares creates a new wallet from the seed restored from
+// SLIP-39 share mnemonics, which rebuilds the address manager root of the
+// wallet the shares were made for.  The passphrase is the one the shares were
+// made with; a wrong passphrase restores a different wallet.
+func (l *Loader) CreateNewWalletFromShares(pubPassphrase, privPassphrase []byte,
+	mnemonics []string, sharePassphrase []byte,
+	bday time.Time) (*Wallet, error) {
+
+	seed, err := slip39.Combine(mnemonics, sharePassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore seed: %w", err)
+	}
+	return l.CreateNewWallet(pubPassphrase, privPassphrase, seed, bday)
+}
+
+// CreateNewWalletFromMnemonic creates a new wallet from the seed derived from a
+// BIP-39 mnemonic and optional passphrase, so the address manager root is the
+// one other BIP-39 wallets derive from the same mnemonic.  Like the share
+// passphrase, a wrong mnemonic passphrase restores a different wallet.
+func (l *Loader) CreateNewWalletFromMnemonic(pubPassphrase,
+	privPassphrase []byte, mnemonic string, mnemonicPassphrase []byte,
+	bday time.Time) (*Wallet, error) {
+
+	seed, err := bip39.Seed(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive seed: %w", err)
+	}
+	return l.CreateNewWallet(pubPassphrase, privPassphrase, seed, bday)
+}
+
 // CreateNewWatchingOnlyWallet creates a new wallet using the provided
 // public passphrase.  No seed or private passphrase may be provided
 // since the wallet is watching-only.
@@ -253,54 +403,74 @@ func (l *Loader) createNewWallet(pubPassphrase, privPassphrase []byte,
 	}
 
 	if l.localDB {
-		dbPath := filepath.Join(l.dbDirPath, WalletDBName)
-
-		// Create the wallet database backed by bolt db.
+		// Create the wallet database of the configured backend.
 		err = os.MkdirAll(l.dbDirPath, 0700)
 		if err != nil {
 			return nil, err
 		}
-		l.db, err = walletdb.Create(
-			"bdb", dbPath, l.noFreelistSync, l.timeout,
-		)
+		l.db, err = walletdb.Create(l.cfg.dbBackend, l.dbArgs()...)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// Initialize the newly created database for the wallet before opening.
-	if isWatchingOnly {
-		err := CreateWatchingOnlyWithCallback(
-			l.db, pubPassphrase, l.chainParams, bday,
-			l.walletCreated,
-		)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := CreateWithCallback(
-			l.db, pubPassphrase, privPassphrase, rootKey,
-			l.chainParams, bday, l.walletCreated,
-		)
-		if err != nil {
-			return nil, err
-		}
+	err = create(
+		l.db, pubPassphrase, privPassphrase, rootKey, l.chainParams,
+		bday, isWatchingOnly, l.cfg.privPassKDF, l.walletCreated,
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	// Open the newly-created wallet.
 	w, err := OpenWithRetry(
-		l.db, pubPassphrase, nil, l.chainParams, l.recoveryWindow,
-		l.cfg.walletSyncRetryInterval,
+		l.walletDB(), pubPassphrase, nil, l.chainParams,
+		l.recoveryWindow, l.cfg.walletSyncRetryInterval,
 	)
 	if err != nil {
 		return nil, err
 	}
+	l.configure(w)
 	w.Start()
 
 	l.onLoaded(w)
 	return w, nil
 }
 
+// dbArgs returns the arguments the driver of the database backend opens and
+// creates the wallet database with.
+func (l *Loader) dbArgs() []interface{} {
+	dbPath := filepath.Join(l.dbDirPath, WalletDBName)
+	switch l.cfg.dbBackend {
+	case DBBackendSQLite:
+		return []interface{}{dbPath, l.timeout}
+	case DBBackendPostgres:
+		return []interface{}{l.cfg.postgresDSN, l.timeout}
+	}
+	return []interface{}{dbPath, l.noFreelistSync, l.timeout}
+}
+
+// walletDB returns the database the loaded wallet is opened with, which is
+// instrumented if the loader was given metrics.
+func (l *Loader) walletDB() walletdb.DB {
+	if l.cfg.metrics == nil {
+		return l.db
+	}
+	return NewMeteredDB(l.db, l.cfg.metrics)
+}
+
+// configure applies the options of the loader to a wallet before it is
+// started.
+func (l *Loader) configure(w *Wallet) {
+	w.SetMaxReorgDepth(l.cfg.maxReorgDepth)
+	w.privPassKDF = l.cfg.privPassKDF
+	w.SetMetrics(l.cfg.metrics)
+	if l.cfg.tracerProvider != nil {
+		w.SetTracerProvider(l.cfg.tracerProvider)
+	}
+}
+
 var errNoConsole = errors.New("db upgrade requires console access for additional input")
 
 func noConsole() ([]byte, error) {
@@ -328,11 +498,8 @@ func (l *Loader) OpenExistingWallet(pubPassphrase []byte,
 			return nil, err
 		}
 
-		// Open the database using the boltdb backend.
-		dbPath := filepath.Join(l.dbDirPath, WalletDBName)
-		l.db, err = walletdb.Open(
-			"bdb", dbPath, l.noFreelistSync, l.timeout,
-		)
+		// Open the database using the configured backend.
+		l.db, err = walletdb.Open(l.cfg.dbBackend, l.dbArgs()...)
 		if err != nil {
 			log.Errorf("Failed to open database: %v", err)
 			return nil, err
@@ -352,8 +519,8 @@ func (l *Loader) OpenExistingWallet(pubPassphrase []byte,
 		}
 	}
 	w, err := OpenWithRetry(
-		l.db, pubPassphrase, cbs, l.chainParams, l.recoveryWindow,
-		l.cfg.walletSyncRetryInterval,
+		l.walletDB(), pubPassphrase, cbs, l.chainParams,
+		l.recoveryWindow, l.cfg.walletSyncRetryInterval,
 	)
 	if err != nil {
 		// If opening the wallet fails (e.g. because of wrong
@@ -368,15 +535,59 @@ func (l *Loader) OpenExistingWallet(pubPassphrase []byte,
 
 		return nil, err
 	}
+	l.configure(w)
 	w.Start()
 
 	l.onLoaded(w)
 	return w, nil
 }
 
-// WalletExists returns whether a file exists at the loader's database path.
-// This may return an error for unexpected I/O failures.
+// RestoreBackup restores the wallet database from an encrypted backup written
+// by the backup package, which is decrypted with the passphrase and checked
+// before it is restored.  The wallet must not exist, and is not loaded.  This
+// returns ErrExists if the wallet exists already, and an error for a loader
+// with an externally provided DB or a database backend other than bbolt.
+func (l *Loader) RestoreBackup(backupPath string, passphrase []byte) error {
+	defer l.mu.Unlock()
+	l.mu.Lock()
+
+	if l.wallet != nil {
+		return ErrLoaded
+	}
+	if !l.localDB {
+		return errors.New("backups can only be restored to a local " +
+			"wallet database")
+	}
+	if l.cfg.dbBackend != DBBackendBolt {
+		return fmt.Errorf("backups can't be restored to a %s wallet "+
+			"database", l.cfg.dbBackend)
+	}
+
+	if err := checkCreateDir(l.dbDirPath); err != nil {
+		return err
+	}
+	dbPath := filepath.Join(l.dbDirPath, WalletDBName)
+	err := backup.Restore(backupPath, dbPath, passphrase)
+	if errors.Is(err, backup.ErrExists) {
+		return ErrExists
+	}
+	return err
+}
+
+// WalletExists returns whether a file exists at the loader's database path,
+// or for the PostgreSQL backend whether the database can be opened.  This may
+// return an error for unexpected I/O failures.
 func (l *Loader) WalletExists() (bool, error) {
+	if l.localDB && l.cfg.dbBackend == DBBackendPostgres {
+		db, err := walletdb.Open(l.cfg.dbBackend, l.dbArgs()...)
+		if errors.Is(err, walletdb.ErrDbDoesNotExist) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, db.Close()
+	}
 	if l.localDB {
 		dbPath := filepath.Join(l.dbDirPath, WalletDBName)
 		return fileExists(dbPath)
@@ -0,0 +1,382 @@
+package wallet
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	_ "github.com/btcsuite/btcwallet/walletdb/postgres"
+	_ "github.com/btcsuite/btcwallet/walletdb/sqlite"
+	"github.com/stroomnetwork/btcwallet/backup"
+	"github.com/stroomnetwork/btcwallet/bip39"
+	"github.com/stroomnetwork/btcwallet/slip39"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// TestLoaderRestoreBackup checks that a wallet restored from a backup of its
+// database can be opened, and that a backup isn't restored over an existing
+// wallet.
+func TestLoaderRestoreBackup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pubPass := []byte("hello")
+	privPass := []byte("world")
+	backupPass := []byte("backup")
+
+	l := NewLoader(
+		&chaincfg.TestNet3Params, filepath.Join(dir, "wallet"), true,
+		defaultDBTimeout, 250,
+		WithWalletSyncRetryInterval(10*time.Millisecond),
+	)
+	w, err := l.CreateNewWallet(pubPass, privPass, nil, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	err = w.RenameAccount(waddrmgr.KeyScopeBIP0084, 0, "savings")
+	if err != nil {
+		t.Fatalf("unable to rename account: %v", err)
+	}
+
+	s, err := backup.New(&backup.Config{
+		DB:         w.Database(),
+		Dir:        filepath.Join(dir, "backups"),
+		Passphrase: backupPass,
+		ScryptN:    16,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	backupPath, err := s.Backup()
+	if err != nil {
+		t.Fatalf("unable to back up wallet: %v", err)
+	}
+
+	// A backup isn't restored over the loaded wallet, nor over its
+	// database once unloaded.
+	if err := l.RestoreBackup(backupPath, backupPass); err != ErrLoaded {
+		t.Fatalf("restoring loaded wallet: got %v, want %v", err,
+			ErrLoaded)
+	}
+	if err := l.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+	if err := l.RestoreBackup(backupPath, backupPass); err != ErrExists {
+		t.Fatalf("restoring existing wallet: got %v, want %v", err,
+			ErrExists)
+	}
+
+	restored := NewLoader(
+		&chaincfg.TestNet3Params, filepath.Join(dir, "restored"), true,
+		defaultDBTimeout, 250,
+		WithWalletSyncRetryInterval(10*time.Millisecond),
+	)
+	if err := restored.RestoreBackup(backupPath, backupPass); err != nil {
+		t.Fatalf("unable to restore wallet: %v", err)
+	}
+	w, err = restored.OpenExistingWallet(pubPass, false)
+	if err != nil {
+		t.Fatalf("unable to open restored wallet: %v", err)
+	}
+	defer restored.UnloadWallet()
+
+	name, err := w.AccountName(waddrmgr.KeyScopeBIP0084, 0)
+	if err != nil {
+		t.Fatalf("unable to get account of restored wallet: %v", err)
+	}
+	if name != "savings" {
+		t.Fatalf("restored account name %q, want %q", name, "savings")
+	}
+}
+
+// TestLoaderSQLite checks that a wallet created with the SQLite database
+// backend can be reopened by its loader.
+func TestLoaderSQLite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pubPass := []byte("hello")
+	privPass := []byte("world")
+
+	l := NewLoader(
+		&chaincfg.TestNet3Params, dir, true, defaultDBTimeout, 250,
+		WithWalletSyncRetryInterval(10*time.Millisecond),
+		WithDBBackend(DBBackendSQLite),
+	)
+	w, err := l.CreateNewWallet(pubPass, privPass, nil, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	err = w.RenameAccount(waddrmgr.KeyScopeBIP0084, 0, "savings")
+	if err != nil {
+		t.Fatalf("unable to rename account: %v", err)
+	}
+	if err := l.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+
+	exists, err := l.WalletExists()
+	if err != nil || !exists {
+		t.Fatalf("wallet does not exist: %v", err)
+	}
+	w, err = l.OpenExistingWallet(pubPass, false)
+	if err != nil {
+		t.Fatalf("unable to open wallet: %v", err)
+	}
+	defer func() {
+		if err := l.UnloadWallet(); err != nil {
+			t.Errorf("unable to unload wallet: %v", err)
+		}
+	}()
+	name, err := w.AccountName(waddrmgr.KeyScopeBIP0084, 0)
+	if err != nil {
+		t.Fatalf("unable to get account name: %v", err)
+	}
+	if name != "savings" {
+		t.Fatalf("account name %q after reopening, want %q", name,
+			"savings")
+	}
+
+	// Backups are bbolt databases, which aren't restored to a SQLite
+	// wallet.
+	if err := l.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+	if err := l.RestoreBackup("backup", nil); err == nil {
+		t.Fatal("backup restored to a SQLite wallet")
+	}
+	if _, err := l.OpenExistingWallet(pubPass, false); err != nil {
+		t.Fatalf("unable to open wallet: %v", err)
+	}
+}
+
+// TestLoaderPostgres checks that a wallet created with the PostgreSQL
+// database backend can be reopened by its loader.  It is run against the
+// database of the WALLETDB_POSTGRES_DSN environment variable, in a schema of
+// its own.
+func TestLoaderPostgres(t *testing.T) {
+	t.Parallel()
+
+	dsn := os.Getenv("WALLETDB_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("WALLETDB_POSTGRES_DSN is not set")
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("WALLETDB_POSTGRES_DSN must be a URL: %v", err)
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	schema := fmt.Sprintf("wallet_test_%d", os.Getpid())
+	if _, err := db.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("unable to create schema: %v", err)
+	}
+	defer func() {
+		_, _ = db.Exec("DROP SCHEMA " + schema + " CASCADE")
+	}()
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	pubPass := []byte("hello")
+	privPass := []byte("world")
+	l := NewLoader(
+		&chaincfg.TestNet3Params, t.TempDir(), true, defaultDBTimeout,
+		250, WithWalletSyncRetryInterval(10*time.Millisecond),
+		WithDBBackend(DBBackendPostgres), WithPostgresDSN(u.String()),
+	)
+	exists, err := l.WalletExists()
+	if err != nil || exists {
+		t.Fatalf("wallet exists before it was created: %v", err)
+	}
+	w, err := l.CreateNewWallet(pubPass, privPass, nil, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	err = w.RenameAccount(waddrmgr.KeyScopeBIP0084, 0, "savings")
+	if err != nil {
+		t.Fatalf("unable to rename account: %v", err)
+	}
+	if err := l.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+
+	exists, err = l.WalletExists()
+	if err != nil || !exists {
+		t.Fatalf("wallet does not exist: %v", err)
+	}
+	w, err = l.OpenExistingWallet(pubPass, false)
+	if err != nil {
+		t.Fatalf("unable to open wallet: %v", err)
+	}
+	defer func() {
+		if err := l.UnloadWallet(); err != nil {
+			t.Errorf("unable to unload wallet: %v", err)
+		}
+	}()
+	name, err := w.AccountName(waddrmgr.KeyScopeBIP0084, 0)
+	if err != nil {
+		t.Fatalf("unable to get account name: %v", err)
+	}
+	if name != "savings" {
+		t.Fatalf("account name %q after reopening, want %q", name,
+			"savings")
+	}
+}
+
+// TestLoaderSeedShares checks that a wallet created from the SLIP-39 shares of
+// the seed of another wallet derives the same account keys.
+func TestLoaderSeedShares(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pubPass := []byte("hello")
+	privPass := []byte("world")
+	shares := &SeedShares{
+		GroupThreshold: 1,
+		Groups:         []slip39.Group{{Threshold: 2, Count: 3}},
+		Passphrase:     []byte("custody"),
+	}
+
+	accountKey := func(name string, create func(*Loader) (*Wallet,
+		error)) string {
+
+		t.Helper()
+
+		l := NewLoader(
+			&chaincfg.TestNet3Params, filepath.Join(dir, name),
+			true, defaultDBTimeout, 250,
+			WithWalletSyncRetryInterval(10*time.Millisecond),
+		)
+		w, err := create(l)
+		if err != nil {
+			t.Fatalf("unable to create %s wallet: %v", name, err)
+		}
+		defer l.UnloadWallet()
+
+		props, err := w.AccountProperties(waddrmgr.KeyScopeBIP0084, 0)
+		if err != nil {
+			t.Fatalf("unable to get account: %v", err)
+		}
+		return props.AccountPubKey.String()
+	}
+
+	var mnemonics [][]string
+	shares.Export = func(m [][]string) error {
+		mnemonics = m
+		return nil
+	}
+	want := accountKey("orig", func(l *Loader) (*Wallet, error) {
+		return l.CreateNewWalletWithShares(
+			pubPass, privPass, nil, shares, time.Now(),
+		)
+	})
+	if len(mnemonics) != 1 || len(mnemonics[0]) != 3 {
+		t.Fatalf("got %d groups of shares, want 1 group of 3",
+			len(mnemonics))
+	}
+
+	got := accountKey("restored", func(l *Loader) (*Wallet, error) {
+		return l.CreateNewWalletFromShares(
+			pubPass, privPass, mnemonics[0][1:], shares.Passphrase,
+			time.Now(),
+		)
+	})
+	if got != want {
+		t.Fatalf("restored account key %v, want %v", got, want)
+	}
+
+	// A single share isn't enough, and no wallet is created if the shares
+	// can't be exported.
+	l := NewLoader(
+		&chaincfg.TestNet3Params, filepath.Join(dir, "single"), true,
+		defaultDBTimeout, 250,
+	)
+	_, err := l.CreateNewWalletFromShares(
+		pubPass, privPass, mnemonics[0][:1], shares.Passphrase,
+		time.Now(),
+	)
+	if !errors.Is(err, slip39.ErrInsufficientShares) {
+		t.Fatalf("restoring from one share: got %v, want %v", err,
+			slip39.ErrInsufficientShares)
+	}
+
+	errExport := errors.New("export failed")
+	shares.Export = func([][]string) error { return errExport }
+	_, err = l.CreateNewWalletWithShares(
+		pubPass, privPass, nil, shares, time.Now(),
+	)
+	if !errors.Is(err, errExport) {
+		t.Fatalf("failed export: got %v, want %v", err, errExport)
+	}
+	if exists, err := l.WalletExists(); err != nil || exists {
+		t.Fatalf("wallet created after failed export: %v", err)
+	}
+}
+
+// TestLoaderMnemonic checks that a wallet created from a BIP-39 mnemonic
+// derives the addresses of the BIP-0084 test vector, as other wallets do.
+func TestLoaderMnemonic(t *testing.T) {
+	t.Parallel()
+
+	l := NewLoader(
+		&chaincfg.MainNetParams, t.TempDir(), true, defaultDBTimeout,
+		250, WithWalletSyncRetryInterval(10*time.Millisecond),
+	)
+	mnemonic := "abandon abandon abandon abandon abandon abandon " +
+		"abandon abandon abandon abandon abandon about"
+	w, err := l.CreateNewWalletFromMnemonic(
+		[]byte("hello"), []byte("world"), mnemonic, nil, time.Now(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	defer l.UnloadWallet()
+
+	props, err := w.AccountProperties(waddrmgr.KeyScopeBIP0084, 0)
+	if err != nil {
+		t.Fatalf("unable to get account: %v", err)
+	}
+	key, err := props.AccountPubKey.Derive(0)
+	if err == nil {
+		key, err = key.Derive(0)
+	}
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		t.Fatalf("unable to get public key: %v", err)
+	}
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()),
+		&chaincfg.MainNetParams,
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	const want = "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"
+	if addr.String() != want {
+		t.Fatalf("first address: got %v, want %v", addr, want)
+	}
+
+	_, err = l.CreateNewWalletFromMnemonic(
+		[]byte("hello"), []byte("world"), mnemonic+" about", nil,
+		time.Now(),
+	)
+	if !errors.Is(err, bip39.ErrInvalidWordCount) {
+		t.Fatalf("invalid mnemonic: got %v, want %v", err,
+			bip39.ErrInvalidWordCount)
+	}
+}
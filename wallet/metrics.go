@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// Metrics receives measurements of the operations of a wallet that can't be
+// observed from its exported state.  Implementations must be safe for
+// concurrent access.
+type Metrics interface {
+	// ObserveDBTx is called with the duration of each managed database
+	// transaction of the wallet, including the time spent waiting for the
+	// database lock.
+	ObserveDBTx(readWrite bool, d time.Duration)
+
+	// BroadcastFailed is called with the error of each transaction the
+	// chain backend refused to broadcast.  Transactions refused because
+	// they are already in the mempool or confirmed are not failures.
+	BroadcastFailed(err error)
+}
+
+// WithMetrics specifies the receiver of the measurements of the loaded
+// wallets.  The database of a wallet is instrumented when it is opened, so
+// this must be given to the loader rather than set on a loaded wallet.
+func WithMetrics(m Metrics) LoaderOption {
+	return func(c *loaderConfig) {
+		c.metrics = m
+	}
+}
+
+// SetMetrics sets the receiver of the measurements of the wallet.  It must be
+// called before the wallet is started.  Database transactions are only
+// measured if the database was wrapped with NewMeteredDB.
+func (w *Wallet) SetMetrics(m Metrics) {
+	w.metrics = m
+}
+
+// meteredDB measures the duration of the managed transactions of a database.
+type meteredDB struct {
+	walletdb.DB
+	metrics Metrics
+}
+
+// NewMeteredDB returns the database with the duration of its managed
+// transactions, those run with View and Update, reported to the metrics.
+func NewMeteredDB(db walletdb.DB, m Metrics) walletdb.DB {
+	return &meteredDB{DB: db, metrics: m}
+}
+
+// View implements walletdb.DB.
+func (db *meteredDB) View(f func(tx walletdb.ReadTx) error,
+	reset func()) error {
+
+	start := time.Now()
+	err := db.DB.View(f, reset)
+	db.metrics.ObserveDBTx(false, time.Since(start))
+	return err
+}
+
+// Update implements walletdb.DB.
+func (db *meteredDB) Update(f func(tx walletdb.ReadWriteTx) error,
+	reset func()) error {
+
+	start := time.Now()
+	err := db.DB.Update(f, reset)
+	db.metrics.ObserveDBTx(true, time.Since(start))
+	return err
+}
@@ -0,0 +1,256 @@
+package wallet
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+const (
+	// DefaultWalletName is the name of the wallet in the database
+	// directory of a MultiLoader, which is the only wallet of a Loader.
+	DefaultWalletName = ""
+
+	// WalletsDirName is the name of the directory of the named wallets,
+	// relative to the database directory of a MultiLoader.
+	WalletsDirName = "wallets"
+)
+
+// ErrInvalidWalletName describes the error condition of a wallet name that
+// can't be used as the name of a directory.
+var ErrInvalidWalletName = errors.New("invalid wallet name")
+
+// ErrNamedWalletsUnsupported describes the error condition of a named wallet
+// with the PostgreSQL database backend, whose only database is that of the
+// default wallet.
+var ErrNamedWalletsUnsupported = errors.New("named wallets are not " +
+	"supported by the postgres database backend")
+
+// walletNameRegexp matches valid names of named wallets.  Names are limited
+// to portable file names, so they can never refer to a directory outside of
+// the wallets directory.
+var walletNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// MultiLoader serves several wallets from a single process.  Besides the
+// default wallet in the database directory, named wallets are kept in
+// subdirectories of the wallets directory, each with its own Loader and
+// wallet database, so they may be loaded and unloaded independently.
+//
+// MultiLoader is safe for concurrent access.
+type MultiLoader struct {
+	chainParams    *chaincfg.Params
+	dbDirPath      string
+	noFreelistSync bool
+	timeout        time.Duration
+	recoveryWindow uint32
+	opts           []LoaderOption
+	cfg            *loaderConfig
+
+	mu        sync.Mutex
+	loaders   map[string]*Loader
+	callbacks []func(string, *Wallet)
+}
+
+// NewMultiLoader constructs a MultiLoader of the wallets in the database
+// directory.  The arguments are those of NewLoader, and are used for the
+// Loader of every wallet.
+func NewMultiLoader(chainParams *chaincfg.Params, dbDirPath string,
+	noFreelistSync bool, timeout time.Duration, recoveryWindow uint32,
+	opts ...LoaderOption) *MultiLoader {
+
+	cfg := defaultLoaderConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &MultiLoader{
+		chainParams:    chainParams,
+		dbDirPath:      dbDirPath,
+		noFreelistSync: noFreelistSync,
+		timeout:        timeout,
+		recoveryWindow: recoveryWindow,
+		opts:           opts,
+		cfg:            cfg,
+		loaders:        make(map[string]*Loader),
+	}
+}
+
+// ChainParams returns the network parameters of the wallets.
+func (m *MultiLoader) ChainParams() *chaincfg.Params {
+	return m.chainParams
+}
+
+// ValidWalletName returns whether the name may be used as the name of a
+// wallet.  The default wallet is always valid.
+func ValidWalletName(name string) bool {
+	return name == DefaultWalletName || walletNameRegexp.MatchString(name)
+}
+
+// WalletDir returns the directory of the database of the named wallet.
+func (m *MultiLoader) WalletDir(name string) (string, error) {
+	if !ValidWalletName(name) {
+		return "", ErrInvalidWalletName
+	}
+	if name == DefaultWalletName {
+		return m.dbDirPath, nil
+	}
+	return filepath.Join(m.dbDirPath, WalletsDirName, name), nil
+}
+
+// Loader returns the loader of the named wallet, which may be used to create
+// the wallet.  Wallets created or opened by the loader are known to the
+// MultiLoader.
+func (m *MultiLoader) Loader(name string) (*Loader, error) {
+	dir, err := m.WalletDir(name)
+	if err != nil {
+		return nil, err
+	}
+	if name != DefaultWalletName &&
+		m.cfg.dbBackend == DBBackendPostgres {
+
+		return nil, ErrNamedWalletsUnsupported
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.loaders[name]; ok {
+		return l, nil
+	}
+	l := NewLoader(
+		m.chainParams, dir, m.noFreelistSync, m.timeout,
+		m.recoveryWindow, m.opts...,
+	)
+	l.loadHook = func(w *Wallet) {
+		m.onLoaded(name, w)
+	}
+	m.loaders[name] = l
+	return l, nil
+}
+
+// DefaultLoader returns the loader of the default wallet.
+func (m *MultiLoader) DefaultLoader() *Loader {
+	l, _ := m.Loader(DefaultWalletName)
+	return l
+}
+
+// onLoaded executes each added callback for a loaded wallet.
+func (m *MultiLoader) onLoaded(name string, w *Wallet) {
+	m.mu.Lock()
+	callbacks := m.callbacks
+	m.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(name, w)
+	}
+}
+
+// RunAfterLoad adds a function to be executed for every wallet that is
+// loaded, including the wallets that are loaded already.  Unlike the
+// callbacks of a Loader, the function is also executed when a wallet is
+// loaded again after it was unloaded.  It must not call the methods of the
+// loader of the wallet.
+func (m *MultiLoader) RunAfterLoad(fn func(name string, w *Wallet)) {
+	m.mu.Lock()
+	m.callbacks = append(m.callbacks, fn)
+	loaders := make(map[string]*Loader, len(m.loaders))
+	for name, l := range m.loaders {
+		loaders[name] = l
+	}
+	m.mu.Unlock()
+
+	for name, l := range loaders {
+		if w, ok := l.LoadedWallet(); ok {
+			fn(name, w)
+		}
+	}
+}
+
+// LoadWallet opens the existing named wallet with the public passphrase.
+// This returns ErrLoaded if the wallet is loaded already.
+func (m *MultiLoader) LoadWallet(name string,
+	pubPassphrase []byte) (*Wallet, error) {
+
+	l, err := m.Loader(name)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := l.WalletExists()
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotExists
+	}
+	return l.OpenExistingWallet(pubPassphrase, false)
+}
+
+// UnloadWallet stops the named wallet and closes its database.  This returns
+// ErrNotLoaded if the wallet is not loaded.
+func (m *MultiLoader) UnloadWallet(name string) error {
+	if !ValidWalletName(name) {
+		return ErrInvalidWalletName
+	}
+
+	m.mu.Lock()
+	l, ok := m.loaders[name]
+	m.mu.Unlock()
+	if !ok {
+		return ErrNotLoaded
+	}
+	return l.UnloadWallet()
+}
+
+// UnloadAll unloads every loaded wallet, returning the first error.
+func (m *MultiLoader) UnloadAll() error {
+	m.mu.Lock()
+	loaders := make([]*Loader, 0, len(m.loaders))
+	for _, l := range m.loaders {
+		loaders = append(loaders, l)
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, l := range loaders {
+		err := l.UnloadWallet()
+		if err != nil && err != ErrNotLoaded && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Wallet returns the named wallet and whether it is loaded.
+func (m *MultiLoader) Wallet(name string) (*Wallet, bool) {
+	m.mu.Lock()
+	l, ok := m.loaders[name]
+	m.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return l.LoadedWallet()
+}
+
+// LoadedWallets returns the sorted names of the loaded wallets.
+func (m *MultiLoader) LoadedWallets() []string {
+	m.mu.Lock()
+	loaders := make(map[string]*Loader, len(m.loaders))
+	for name, l := range m.loaders {
+		loaders[name] = l
+	}
+	m.mu.Unlock()
+
+	var names []string
+	for name, l := range loaders {
+		if _, ok := l.LoadedWallet(); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
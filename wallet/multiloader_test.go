@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// TestMultiLoader checks that named wallets are created in their own
+// directories and can be loaded and unloaded independently.
+func TestMultiLoader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pubPass := []byte("hello")
+	privPass := []byte("world")
+
+	m := NewMultiLoader(
+		&chaincfg.TestNet3Params, dir, true, defaultDBTimeout, 250,
+		WithWalletSyncRetryInterval(10*time.Millisecond),
+	)
+	defer func() {
+		if err := m.UnloadAll(); err != nil {
+			t.Errorf("unable to unload wallets: %v", err)
+		}
+	}()
+
+	var (
+		mu     sync.Mutex
+		loaded []string
+	)
+	m.RunAfterLoad(func(name string, w *Wallet) {
+		mu.Lock()
+		loaded = append(loaded, name)
+		mu.Unlock()
+	})
+
+	for _, name := range []string{DefaultWalletName, "alice", "bob"} {
+		l, err := m.Loader(name)
+		if err != nil {
+			t.Fatalf("unable to get loader of %q: %v", name, err)
+		}
+		_, err = l.CreateNewWallet(pubPass, privPass, nil, time.Now())
+		if err != nil {
+			t.Fatalf("unable to create wallet %q: %v", name, err)
+		}
+	}
+
+	want := []string{DefaultWalletName, "alice", "bob"}
+	if got := m.LoadedWallets(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("loaded wallets %q, want %q", got, want)
+	}
+	alice, ok := m.Wallet("alice")
+	if !ok {
+		t.Fatal("wallet alice is not loaded")
+	}
+	dbPath := filepath.Join(dir, WalletsDirName, "alice", WalletDBName)
+	if exists, err := fileExists(dbPath); err != nil || !exists {
+		t.Fatalf("wallet database %s does not exist: %v", dbPath, err)
+	}
+
+	// Unloading a wallet leaves the others loaded.
+	if err := m.UnloadWallet("alice"); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+	if !alice.ShuttingDown() {
+		t.Fatal("unloaded wallet was not stopped")
+	}
+	want = []string{DefaultWalletName, "bob"}
+	if got := m.LoadedWallets(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("loaded wallets %q, want %q", got, want)
+	}
+	if err := m.UnloadWallet("alice"); err != ErrNotLoaded {
+		t.Fatalf("unloading an unloaded wallet: got %v, want %v",
+			err, ErrNotLoaded)
+	}
+
+	// The callbacks run again when a wallet is loaded again.
+	if _, err := m.LoadWallet("alice", pubPass); err != nil {
+		t.Fatalf("unable to load wallet: %v", err)
+	}
+	if _, err := m.LoadWallet("alice", pubPass); err != ErrLoaded {
+		t.Fatalf("loading a loaded wallet: got %v, want %v", err,
+			ErrLoaded)
+	}
+	mu.Lock()
+	want = []string{DefaultWalletName, "alice", "bob", "alice"}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Fatalf("callbacks ran for %q, want %q", loaded, want)
+	}
+	mu.Unlock()
+
+	if _, err := m.LoadWallet("carol", pubPass); err != ErrNotExists {
+		t.Fatalf("loading a missing wallet: got %v, want %v", err,
+			ErrNotExists)
+	}
+	for _, name := range []string{"..", "a/b", ".hidden", "a b"} {
+		_, err := m.Loader(name)
+		if !errors.Is(err, ErrInvalidWalletName) {
+			t.Fatalf("loader of %q: got %v, want %v", name, err,
+				ErrInvalidWalletName)
+		}
+	}
+}
+
+// TestMultiLoaderPostgres checks that named wallets are refused with the
+// PostgreSQL database backend, which only keeps the default wallet.
+func TestMultiLoaderPostgres(t *testing.T) {
+	t.Parallel()
+
+	m := NewMultiLoader(
+		&chaincfg.TestNet3Params, t.TempDir(), true, defaultDBTimeout,
+		250, WithDBBackend(DBBackendPostgres),
+	)
+	if _, err := m.Loader("alice"); err != ErrNamedWalletsUnsupported {
+		t.Fatalf("loader of named wallet: got %v, want %v", err,
+			ErrNamedWalletsUnsupported)
+	}
+	if _, err := m.Loader(DefaultWalletName); err != nil {
+		t.Fatalf("unable to get loader of default wallet: %v", err)
+	}
+}
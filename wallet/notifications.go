@@ -35,6 +35,7 @@ type NotificationServer struct {
 	currentTxNtfn  *TransactionNotifications // coalesce this since wallet does not add mined txs together
 	spentness      map[uint32][]chan *SpentnessNotifications
 	accountClients []chan *AccountNotification
+	haltClients    []chan *ReorgHalt
 	mu             sync.Mutex // Only protects registered client channels
 	wallet         *Wallet    // smells like hacks
 }
@@ -613,3 +614,58 @@ func (c *AccountNotificationsClient) Done() {
 		s.mu.Unlock()
 	}()
 }
+
+func (s *NotificationServer) notifyReorgHalt(halt *ReorgHalt) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, c := range s.haltClients {
+		h := *halt
+		c <- &h
+	}
+}
+
+// ReorgHaltNotificationsClient receives a ReorgHalt over the channel C each
+// time the wallet is halted by a deep chain reorganization.
+type ReorgHaltNotificationsClient struct {
+	C      chan *ReorgHalt
+	server *NotificationServer
+}
+
+// ReorgHaltNotifications returns a client for receiving ReorgHalt
+// notifications over a channel.  The channel is unbuffered.  When finished,
+// the client's Done method should be called to disassociate the client from
+// the server.
+func (s *NotificationServer) ReorgHaltNotifications() ReorgHaltNotificationsClient {
+	c := make(chan *ReorgHalt)
+	s.mu.Lock()
+	s.haltClients = append(s.haltClients, c)
+	s.mu.Unlock()
+	return ReorgHaltNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+
+// Done deregisters the client from the server and drains any remaining
+// messages.  It must be called exactly once when the client is finished
+// receiving notifications.
+func (c *ReorgHaltNotificationsClient) Done() {
+	go func() {
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.haltClients
+		for i, ch := range clients {
+			if c.C == ch {
+				clients[i] = clients[len(clients)-1]
+				s.haltClients = clients[:len(clients)-1]
+				close(ch)
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
@@ -6,6 +6,7 @@ package wallet
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 
@@ -89,11 +90,14 @@ func (w *Wallet) FundPsbt(packet *psbt.Packet, keyScope *waddrmgr.KeyScope,
 	case len(txIn) == 0:
 		// We ask the underlying wallet to fund a TX for us. This
 		// includes everything we need, specifically fee estimation and
-		// change address creation.
+		// change address creation. The funded packet is returned to
+		// the caller for signing, so spends from cold accounts aren't
+		// recorded as pending cold spends.
 		tx, err = w.CreateSimpleTx(
-			keyScope, account, packet.UnsignedTx.TxOut, minConfs,
+			context.TODO(), keyScope, account,
+			packet.UnsignedTx.TxOut, minConfs,
 			feeSatPerKB, coinSelectionStrategy, false,
-			optFuncs...,
+			append(optFuncs, withoutColdSpend())...,
 		)
 		if err != nil {
 			return 0, fmt.Errorf("error creating funding TX: %w",
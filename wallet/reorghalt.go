@@ -0,0 +1,317 @@
+package wallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+var (
+	// ErrWalletHalted is returned when attempting to send or publish a
+	// transaction while the wallet is halted after a chain reorganization
+	// deeper than the configured maximum.
+	ErrWalletHalted = errors.New("wallet halted after deep chain " +
+		"reorganization, operator override required")
+
+	// reorgHaltNamespaceKey is the top-level bucket the reorg halt state is
+	// persisted under, so that a halted wallet stays halted over restarts.
+	reorgHaltNamespaceKey = []byte("wreorghalt")
+
+	// reorgHaltKey is the key of the serialized ReorgHalt.
+	reorgHaltKey = []byte("halt")
+
+	// reorgStateKey is the key of the serialized reorgState.
+	reorgStateKey = []byte("reorg")
+)
+
+// ReorgHalt describes why the wallet was halted.
+type ReorgHalt struct {
+	// Depth is the number of blocks below the chain tip seen before the
+	// reorganization that were disconnected when the halt was triggered.
+	Depth int32
+
+	// ForkHeight is the height of the lowest block that was disconnected.
+	ForkHeight int32
+
+	// MaxDepth is the maximum tolerated reorg depth at the time of the
+	// halt.
+	MaxDepth int32
+
+	// Time is the time the wallet was halted.
+	Time time.Time
+}
+
+// Error implements the error interface so a ReorgHalt can be reported with
+// the errors it causes.
+func (h *ReorgHalt) Error() string {
+	return fmt.Sprintf("chain reorganization of %d blocks down to height "+
+		"%d exceeds the maximum tolerated depth of %d", h.Depth,
+		h.ForkHeight, h.MaxDepth)
+}
+
+func serializeReorgHalt(h *ReorgHalt) []byte {
+	var v [20]byte
+	binary.BigEndian.PutUint32(v[0:4], uint32(h.Depth))
+	binary.BigEndian.PutUint32(v[4:8], uint32(h.ForkHeight))
+	binary.BigEndian.PutUint32(v[8:12], uint32(h.MaxDepth))
+	binary.BigEndian.PutUint64(v[12:20], uint64(h.Time.Unix()))
+	return v[:]
+}
+
+func deserializeReorgHalt(v []byte) (*ReorgHalt, error) {
+	if len(v) != 20 {
+		return nil, fmt.Errorf("malformed reorg halt state: %d bytes",
+			len(v))
+	}
+	return &ReorgHalt{
+		Depth:      int32(binary.BigEndian.Uint32(v[0:4])),
+		ForkHeight: int32(binary.BigEndian.Uint32(v[4:8])),
+		MaxDepth:   int32(binary.BigEndian.Uint32(v[8:12])),
+		Time:       time.Unix(int64(binary.BigEndian.Uint64(v[12:20])), 0),
+	}, nil
+}
+
+// fetchReorgHalt reads the persisted halt state, returning nil if the
+// wallet is not halted.
+func fetchReorgHalt(dbtx walletdb.ReadTx) (*ReorgHalt, error) {
+	ns := dbtx.ReadBucket(reorgHaltNamespaceKey)
+	if ns == nil {
+		return nil, nil
+	}
+	v := ns.Get(reorgHaltKey)
+	if v == nil {
+		return nil, nil
+	}
+	return deserializeReorgHalt(v)
+}
+
+// putReorgHalt persists the halt state, or removes it if h is nil.
+func putReorgHalt(dbtx walletdb.ReadWriteTx, h *ReorgHalt) error {
+	if h == nil {
+		ns := dbtx.ReadWriteBucket(reorgHaltNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		return ns.Delete(reorgHaltKey)
+	}
+
+	ns, err := dbtx.CreateTopLevelBucket(reorgHaltNamespaceKey)
+	if err != nil {
+		return err
+	}
+	return ns.Put(reorgHaltKey, serializeReorgHalt(h))
+}
+
+// reorgState is the persisted progress of the chain reorganization in
+// progress.  It is kept until the chain advances past the tip seen before
+// the reorganization started, so that repeated disconnects and connects
+// below that tip, and blocks rolled back while the wallet was offline, all
+// count towards the same depth.
+type reorgState struct {
+	// Tip is the height of the chain tip before the reorganization.
+	Tip int32
+
+	// ForkHeight is the height of the lowest block disconnected since.
+	ForkHeight int32
+}
+
+// depth returns the number of blocks the reorganization replaced.
+func (s *reorgState) depth() int32 {
+	return s.Tip - s.ForkHeight + 1
+}
+
+// fetchReorgState reads the persisted reorg progress, returning nil if no
+// reorganization is in progress.
+func fetchReorgState(dbtx walletdb.ReadTx) (*reorgState, error) {
+	ns := dbtx.ReadBucket(reorgHaltNamespaceKey)
+	if ns == nil {
+		return nil, nil
+	}
+	v := ns.Get(reorgStateKey)
+	if v == nil {
+		return nil, nil
+	}
+	if len(v) != 8 {
+		return nil, fmt.Errorf("malformed reorg state: %d bytes",
+			len(v))
+	}
+	return &reorgState{
+		Tip:        int32(binary.BigEndian.Uint32(v[0:4])),
+		ForkHeight: int32(binary.BigEndian.Uint32(v[4:8])),
+	}, nil
+}
+
+// putReorgState persists the reorg progress, or removes it if s is nil.
+func putReorgState(dbtx walletdb.ReadWriteTx, s *reorgState) error {
+	if s == nil {
+		ns := dbtx.ReadWriteBucket(reorgHaltNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		return ns.Delete(reorgStateKey)
+	}
+
+	ns, err := dbtx.CreateTopLevelBucket(reorgHaltNamespaceKey)
+	if err != nil {
+		return err
+	}
+	var v [8]byte
+	binary.BigEndian.PutUint32(v[0:4], uint32(s.Tip))
+	binary.BigEndian.PutUint32(v[4:8], uint32(s.ForkHeight))
+	return ns.Put(reorgStateKey, v[:])
+}
+
+// reorgSafety holds the maximum tolerated reorg depth and whether the wallet
+// was halted because it was exceeded.
+type reorgSafety struct {
+	mu       sync.Mutex
+	maxDepth int32
+	halt     *ReorgHalt
+}
+
+// SetMaxReorgDepth sets the maximum number of blocks a chain reorganization
+// may replace before the wallet halts.  Zero disables the check.
+func (w *Wallet) SetMaxReorgDepth(depth int32) {
+	w.reorgSafety.mu.Lock()
+	w.reorgSafety.maxDepth = depth
+	w.reorgSafety.mu.Unlock()
+}
+
+// ReorgHalted returns why the wallet is halted, or nil if it is not.
+func (w *Wallet) ReorgHalted() *ReorgHalt {
+	w.reorgSafety.mu.Lock()
+	defer w.reorgSafety.mu.Unlock()
+
+	if w.reorgSafety.halt == nil {
+		return nil
+	}
+	h := *w.reorgSafety.halt
+	return &h
+}
+
+// ClearReorgHalt is the operator override that resumes sending and
+// publishing transactions after the wallet was halted by a deep chain
+// reorganization.  It must only be called once the new chain was verified
+// to be legitimate, so the reorganization in progress is accepted with it.
+func (w *Wallet) ClearReorgHalt() error {
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		if err := putReorgState(dbtx, nil); err != nil {
+			return err
+		}
+		return putReorgHalt(dbtx, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	w.reorgSafety.mu.Lock()
+	halt := w.reorgSafety.halt
+	w.reorgSafety.halt = nil
+	w.reorgSafety.mu.Unlock()
+
+	if halt != nil {
+		log.Warnf("Reorg halt cleared by operator override: %v", halt)
+	}
+	return nil
+}
+
+// checkReorgHalt returns ErrWalletHalted if the wallet is halted.
+func (w *Wallet) checkReorgHalt() error {
+	if halt := w.ReorgHalted(); halt != nil {
+		return fmt.Errorf("%w: %v", ErrWalletHalted, halt)
+	}
+	return nil
+}
+
+// loadReorgHalt restores the halt state persisted by a previous run.
+func (w *Wallet) loadReorgHalt() error {
+	var halt *ReorgHalt
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		var err error
+		halt, err = fetchReorgHalt(dbtx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if halt != nil {
+		log.Criticalf("Wallet is halted: %v", halt)
+	}
+
+	w.reorgSafety.mu.Lock()
+	w.reorgSafety.halt = halt
+	w.reorgSafety.mu.Unlock()
+	return nil
+}
+
+// reorgBlockConnected ends the reorganization in progress once the chain
+// advances past the tip seen before it started.
+func (w *Wallet) reorgBlockConnected(dbtx walletdb.ReadWriteTx,
+	height int32) error {
+
+	state, err := fetchReorgState(dbtx)
+	if err != nil || state == nil || height <= state.Tip {
+		return err
+	}
+	return putReorgState(dbtx, nil)
+}
+
+// reorgBlocksDisconnected accounts for the blocks from forkHeight up to tip
+// being disconnected and halts the wallet once the reorganization in
+// progress replaced more blocks than tolerated.  The reorg progress and the
+// halt are persisted in dbtx and take effect once it commits.
+func (w *Wallet) reorgBlocksDisconnected(dbtx walletdb.ReadWriteTx, tip,
+	forkHeight int32) error {
+
+	state, err := fetchReorgState(dbtx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case state == nil:
+		state = &reorgState{Tip: tip, ForkHeight: forkHeight}
+	case forkHeight < state.ForkHeight:
+		state.ForkHeight = forkHeight
+	}
+	if err := putReorgState(dbtx, state); err != nil {
+		return err
+	}
+
+	s := &w.reorgSafety
+	s.mu.Lock()
+	maxDepth, halted := s.maxDepth, s.halt != nil
+	s.mu.Unlock()
+
+	depth := state.depth()
+	if maxDepth <= 0 || depth <= maxDepth || halted {
+		return nil
+	}
+
+	halt := &ReorgHalt{
+		Depth:      depth,
+		ForkHeight: state.ForkHeight,
+		MaxDepth:   maxDepth,
+		Time:       time.Now(),
+	}
+	if err := putReorgHalt(dbtx, halt); err != nil {
+		return err
+	}
+
+	dbtx.OnCommit(func() {
+		s.mu.Lock()
+		if s.halt == nil {
+			s.halt = halt
+		}
+		s.mu.Unlock()
+
+		log.Criticalf("Halting wallet: %v.  Sending and publishing "+
+			"transactions is refused until the halt is cleared "+
+			"by the operator", halt)
+		w.NtfnServer.notifyReorgHalt(halt)
+	})
+	return nil
+}
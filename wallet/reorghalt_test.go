@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReorgHalt checks that the wallet halts once a reorg replaces more
+// blocks than tolerated, that the halt survives a restart and that it
+// is lifted by the operator override.
+func TestReorgHalt(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.chainClient.(*mockChainClient).getBlockHeader = &wire.BlockHeader{}
+	w.SetChainSynced(true)
+	w.SetMaxReorgDepth(2)
+
+	update := func(f func(walletdb.ReadWriteTx) error) {
+		t.Helper()
+		require.NoError(t, walletdb.Update(w.db, f))
+	}
+	connect := func(height int32, fork byte) {
+		t.Helper()
+		update(func(dbtx walletdb.ReadWriteTx) error {
+			return w.connectBlock(dbtx, confTestBlock(height, fork))
+		})
+	}
+	disconnect := func(height int32, fork byte) {
+		t.Helper()
+		update(func(dbtx walletdb.ReadWriteTx) error {
+			return w.disconnectBlock(dbtx, confTestBlock(height, fork))
+		})
+	}
+
+	halts := w.NtfnServer.ReorgHaltNotifications()
+
+	base := w.Manager.SyncedTo().Height
+	for i := int32(1); i <= 4; i++ {
+		connect(base+i, 0)
+	}
+
+	// Reorgs within the tolerated depth don't halt the wallet, and the
+	// depth is reset once the chain advances past the old tip.
+	disconnect(base+4, 0)
+	disconnect(base+3, 0)
+	connect(base+3, 1)
+	connect(base+4, 1)
+	connect(base+5, 1)
+	require.Nil(t, w.ReorgHalted())
+
+	// Repeated rounds of disconnects and connects below the old tip count
+	// towards the same reorg depth, so a third block replaced exceeds the
+	// maximum depth.
+	disconnect(base+5, 1)
+	disconnect(base+4, 1)
+	connect(base+4, 2)
+	disconnect(base+4, 2)
+	require.Nil(t, w.ReorgHalted())
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+			return w.disconnectBlock(dbtx, confTestBlock(base+3, 1))
+		})
+	}()
+	select {
+	case halt := <-halts.C:
+		require.EqualValues(t, 3, halt.Depth)
+		require.EqualValues(t, 2, halt.MaxDepth)
+		require.Equal(t, base+3, halt.ForkHeight)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for halt notification")
+	}
+	require.NoError(t, <-errc)
+	require.NotNil(t, w.ReorgHalted())
+
+	err := w.PublishTransaction(
+		context.Background(), wire.NewMsgTx(2), "",
+	)
+	require.ErrorIs(t, err, ErrWalletHalted)
+	_, err = w.SendOutputs(
+		context.Background(), nil, nil, 0, 1, 1000, CoinSelectionLargest, "",
+	)
+	require.ErrorIs(t, err, ErrWalletHalted)
+
+	// The halt is persisted and restored when the wallet is reopened.
+	w.reorgSafety.halt = nil
+	require.NoError(t, w.loadReorgHalt())
+	require.NotNil(t, w.ReorgHalted())
+
+	// Clearing the halt resumes the wallet, also after a restart.
+	require.NoError(t, w.ClearReorgHalt())
+	require.Nil(t, w.ReorgHalted())
+	require.NoError(t, w.loadReorgHalt())
+	require.Nil(t, w.ReorgHalted())
+
+	halts.Done()
+
+	// Blocks rolled back at startup, after being reorged out while the
+	// wallet was offline, count towards the depth as well.
+	update(func(dbtx walletdb.ReadWriteTx) error {
+		return w.reorgBlocksDisconnected(dbtx, base+10, base+8)
+	})
+	require.NotNil(t, w.ReorgHalted())
+	require.EqualValues(t, 3, w.ReorgHalted().Depth)
+}
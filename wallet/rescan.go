@@ -5,6 +5,8 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -40,6 +42,70 @@ type RescanJob struct {
 	err         chan error
 }
 
+// RescanStatus describes the progress of the rescan currently performed by
+// the wallet.
+type RescanStatus struct {
+	// Started is the time the rescan started.
+	Started time.Time
+
+	// StartHeight is the height the rescan started from.
+	StartHeight int32
+
+	// Height is the height of the last block that was rescanned.
+	Height int32
+
+	// BestHeight is the height the rescan is expected to end at, or zero
+	// if the chain backend doesn't report it.
+	BestHeight int32
+}
+
+// Progress returns the fraction of the blocks between the start and best
+// height that were rescanned, or zero if the best height is unknown.
+func (s *RescanStatus) Progress() float64 {
+	if s.BestHeight <= s.StartHeight {
+		return 0
+	}
+	if s.Height >= s.BestHeight {
+		return 1
+	}
+	return float64(s.Height-s.StartHeight) /
+		float64(s.BestHeight-s.StartHeight)
+}
+
+// Scanning returns the status of the rescan currently performed by the
+// wallet, or nil if no rescan is running.
+func (w *Wallet) Scanning() *RescanStatus {
+	status := w.rescanStatus.Load()
+	if status == nil {
+		return nil
+	}
+	statusCopy := *status
+	return &statusCopy
+}
+
+// startRescanStatus records that a rescan of the batch is started.
+func (w *Wallet) startRescanStatus(batch *rescanBatch) {
+	w.rescanStatus.Store(&RescanStatus{
+		Started:     time.Now(),
+		StartHeight: batch.bs.Height,
+		Height:      batch.bs.Height,
+	})
+}
+
+// updateRescanStatus records the progress of the running rescan.
+func (w *Wallet) updateRescanStatus(n *chain.RescanProgress) {
+	status := w.rescanStatus.Load()
+	if status == nil {
+		return
+	}
+	newStatus := *status
+	newStatus.Height = n.Height
+	if n.BestHeight != 0 {
+		newStatus.BestHeight = n.BestHeight
+	}
+	w.rescanStatus.Store(&newStatus)
+}
+
 // rescanBatch is a collection of one or more RescanJobs that were merged
 // together before a rescan is performed.
 type rescanBatch struct {
@@ -119,6 +185,7 @@ func (w *Wallet) rescanBatchHandler() {
 				// Set current batch as this job and send
 				// request.
 				curBatch = job.batch()
+				w.startRescanStatus(curBatch)
 				select {
 				case w.rescanBatch <- curBatch:
 				case <-quit:
@@ -144,6 +211,7 @@ func (w *Wallet) rescanBatchHandler() {
 						"currently running")
 					continue
 				}
+				w.updateRescanStatus(n)
 				select {
 				case w.rescanProgress <- &RescanProgressMsg{
 					Addresses:    curBatch.addrs,
@@ -177,7 +245,9 @@ func (w *Wallet) rescanBatchHandler() {
 
 				curBatch, nextBatch = nextBatch, nil
 
+				w.rescanStatus.Store(nil)
 				if curBatch != nil {
+					w.startRescanStatus(curBatch)
 					select {
 					case w.rescanBatch <- curBatch:
 					case <-quit:
@@ -211,8 +281,14 @@ out:
 		select {
 		case msg := <-w.rescanProgress:
 			n := msg.Notification
-			log.Infof("Rescanned through block %v (height %d)",
-				n.Hash, n.Height)
+			if n.BestHeight == 0 {
+				log.Infof("Rescanned through block %v "+
+					"(height %d)", n.Hash, n.Height)
+				continue
+			}
+			log.Infof("Rescanned through block %v (height %d of "+
+				"%d, ETA %v)", n.Hash, n.Height, n.BestHeight,
+				n.ETA.Round(time.Second))
 
 		case msg := <-w.rescanFinished:
 			n := msg.Notification
@@ -259,6 +335,7 @@ out:
 			if err != nil {
 				log.Errorf("Rescan for %d %s failed: %v", numAddrs,
 					noun, err)
+				w.rescanStatus.Store(nil)
 			}
 			batch.done(err)
 		case <-quit:
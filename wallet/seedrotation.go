@@ -0,0 +1,164 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+var (
+	// ErrAccountNotRetired is returned when sweeping an account that
+	// wasn't retired by a seed rotation.
+	ErrAccountNotRetired = errors.New("account is not retired")
+
+	// ErrNothingToSweep is returned when a retired account has no
+	// outputs to sweep, or only outputs worth less than the fee of
+	// spending them.
+	ErrNothingToSweep = errors.New("no outputs to sweep")
+)
+
+// RotateSeed replaces the root key of the wallet with the root key of a new
+// seed.  Every account derived from the previous seed is retired: it is
+// renamed after the fingerprint of the previous root key and replaced by an
+// account of the new seed, at the same account index and under the same name.
+//
+// Retired accounts keep their addresses and keys, so their outputs stay
+// spendable and their transactions are still found when rescanning, but new
+// addresses and change of a retired account are derived from the account that
+// replaced it.  SweepRetiredAccount moves the funds of a retired account to
+// its replacement.
+//
+// The wallet must be unlocked.
+func (w *Wallet) RotateSeed(seed []byte) error {
+	rootKey, err := hdkeychain.NewMaster(seed, w.chainParams)
+	if err != nil {
+		return fmt.Errorf("invalid seed: %w", err)
+	}
+	defer rootKey.Zero()
+
+	// New addresses can't be derived while the accounts are replaced.
+	w.newAddrMtx.Lock()
+	defer w.newAddrMtx.Unlock()
+
+	var props []*waddrmgr.AccountProperties
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		if err := w.Manager.RotateRoot(addrmgrNs, rootKey); err != nil {
+			return err
+		}
+
+		// Collect the renamed and the replacing accounts, whose
+		// properties changed, for notification.
+		for _, scopedMgr := range w.Manager.ActiveScopedKeyManagers() {
+			err := scopedMgr.ForEachAccount(
+				addrmgrNs, func(account uint32) error {
+					p, err := scopedMgr.AccountProperties(
+						addrmgrNs, account,
+					)
+					if err != nil {
+						return err
+					}
+					props = append(props, p)
+					return nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range props {
+		w.NtfnServer.notifyAccountProperties(p)
+	}
+	return nil
+}
+
+// SweepRetiredAccount spends every output of an account retired by a seed
+// rotation with at least minconf confirmations to a change address of the
+// account that replaced it, and publishes the transaction.  The fee rate is
+// given in satoshis per kilobyte.
+//
+// ErrAccountNotRetired is returned if the account wasn't retired, and
+// ErrNothingToSweep if it has no output worth sweeping.
+func (w *Wallet) SweepRetiredAccount(ctx context.Context,
+	scope waddrmgr.KeyScope, account uint32, minconf int32,
+	satPerKb btcutil.Amount, label string) (*wire.MsgTx, error) {
+
+	scopedMgr, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return nil, err
+	}
+	var bs *waddrmgr.BlockStamp
+	err = w.chainCall(ctx, "BlockStamp", func() error {
+		var err error
+		bs, err = chainClient.BlockStamp()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var outpoints []wire.OutPoint
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		props, err := scopedMgr.AccountProperties(addrmgrNs, account)
+		if err != nil {
+			return err
+		}
+		if !props.IsRetired {
+			return ErrAccountNotRetired
+		}
+
+		eligible, err := w.findEligibleOutputs(
+			tx, &scope, account, minconf, bs, nil,
+		)
+		if err != nil {
+			return err
+		}
+		for _, credit := range eligible {
+			outpoints = append(outpoints, credit.OutPoint)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(outpoints) == 0 {
+		return nil, ErrNothingToSweep
+	}
+
+	// Without outputs, the whole value of the inputs less the fee goes to
+	// change, which is derived from the replacing account.
+	authoredTx, err := w.CreateSimpleTx(
+		ctx, &scope, account, nil, minconf, satPerKb,
+		CoinSelectionLargest, false, WithCustomSelectUtxos(outpoints),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(authoredTx.Tx.TxOut) == 0 {
+		return nil, ErrNothingToSweep
+	}
+
+	err = w.PublishTransaction(ctx, authoredTx.Tx, label)
+	if err != nil {
+		return nil, err
+	}
+	return authoredTx.Tx, nil
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// TestRotateSeed tests that after a seed rotation, new addresses and change
+// are derived from the new seed, and that the funds of the retired accounts
+// can be swept to the accounts replacing them.
+func TestRotateSeed(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	w.Start()
+	defer w.Stop()
+
+	scope := waddrmgr.KeyScopeBIP0084
+
+	// Fund the default account before the rotation.
+	oldAddr, err := w.NewAddress(0, scope)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(oldAddr)
+	require.NoError(t, err)
+	const testAmt = 1_000_000
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(testAmt, pkScript)},
+	})
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	require.NoError(t, err)
+	require.NoError(t, w.RotateSeed(seed))
+
+	// The default account is retired and renamed, and the funds it holds
+	// are still accounted for.
+	name, err := w.AccountName(scope, 0)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(name, "default-"), name)
+	balances, err := w.CalculateAccountBalances(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(testAmt), balances.Total)
+
+	successor, err := w.AccountNumber(scope, "default")
+	require.NoError(t, err)
+	require.NotZero(t, successor)
+
+	// New addresses of the retired account come from its successor.
+	newAddr, err := w.NewAddress(0, scope)
+	require.NoError(t, err)
+	info, err := w.AddressInfo(newAddr)
+	require.NoError(t, err)
+	require.Equal(t, successor, info.InternalAccount())
+
+	_, err = w.SweepRetiredAccount(
+		context.Background(), scope, successor, 1, 1000, "",
+	)
+	require.ErrorIs(t, err, ErrAccountNotRetired)
+
+	// The sweep spends the output of the retired account to a change
+	// address of its successor.
+	tx, err := w.SweepRetiredAccount(
+		context.Background(), scope, 0, 1, 1000, "sweep",
+	)
+	require.NoError(t, err)
+	require.Len(t, tx.TxIn, 1)
+	require.Len(t, tx.TxOut, 1)
+	require.Less(t, tx.TxOut[0].Value, int64(testAmt))
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		tx.TxOut[0].PkScript, w.chainParams,
+	)
+	require.NoError(t, err)
+	info, err = w.AddressInfo(addrs[0])
+	require.NoError(t, err)
+	require.Equal(t, successor, info.InternalAccount())
+	require.True(t, info.Internal())
+
+	// Nothing is left to sweep.
+	_, err = w.SweepRetiredAccount(
+		context.Background(), scope, 0, 1, 1000, "",
+	)
+	require.ErrorIs(t, err, ErrNothingToSweep)
+}
@@ -5,10 +5,13 @@
 package wallet
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
@@ -27,6 +30,15 @@ func (w *Wallet) ScriptForOutput(output *wire.TxOut) (
 		return nil, nil, nil, err
 	}
 
+	return w.scriptForOutputAddr(walletAddr, output)
+}
+
+// scriptForOutputAddr returns the address, witness program and redeem script
+// for a given UTXO paying to an address of the wallet.
+func (w *Wallet) scriptForOutputAddr(walletAddr waddrmgr.ManagedAddress,
+	output *wire.TxOut) (waddrmgr.ManagedPubKeyAddress, []byte, []byte,
+	error) {
+
 	pubKeyAddr, ok := walletAddr.(waddrmgr.ManagedPubKeyAddress)
 	if !ok {
 		return nil, nil, nil, fmt.Errorf("address %s is not a "+
@@ -136,3 +148,23 @@ func (w *Wallet) ComputeInputScript(tx *wire.MsgTx, output *wire.TxOut,
 
 	return witnessScript, sigScript, nil
 }
+
+// SignMessage signs the message with the private key of the address, in the
+// format of the signmessage command of Bitcoin Core.  The returned signature
+// is the compact signature over the double SHA256 hash of the magic prefix
+// and message, from which the public key can be recovered.
+func (w *Wallet) SignMessage(addr btcutil.Address, message string) ([]byte,
+	error) {
+
+	privKey, err := w.PrivKeyForAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	_ = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
+	_ = wire.WriteVarString(&buf, 0, message)
+	messageHash := chainhash.DoubleHashB(buf.Bytes())
+
+	return ecdsa.SignCompact(privKey, messageHash, true), nil
+}
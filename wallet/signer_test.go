@@ -5,9 +5,12 @@
 package wallet
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
@@ -107,3 +110,46 @@ func runTestCase(t *testing.T, w *Wallet, scope waddrmgr.KeyScope,
 		t.Fatalf("error validating tx: %v", err)
 	}
 }
+
+// TestSignMessage checks that the public key of the address is recovered from
+// the signature of a message.
+func TestSignMessage(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+
+	const message = "hello"
+	sig, err := w.SignMessage(addr, message)
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_ = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
+	_ = wire.WriteVarString(&buf, 0, message)
+	pubKey, compressed, err := ecdsa.RecoverCompact(
+		sig, chainhash.DoubleHashB(buf.Bytes()),
+	)
+	if err != nil {
+		t.Fatalf("unable to recover public key: %v", err)
+	}
+	if !compressed {
+		t.Fatalf("recovered public key is not compressed")
+	}
+
+	recovered, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), w.ChainParams(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	if recovered.EncodeAddress() != addr.EncodeAddress() {
+		t.Fatalf("recovered address %v, want %v", recovered, addr)
+	}
+}
@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SpendCheck authorizes a transaction before the wallet publishes it.
+type SpendCheck func(tx *wire.MsgTx) error
+
+// spendCheckKey is the context key of the SpendCheck of a caller.
+type spendCheckKey struct{}
+
+// WithSpendCheck returns a context that makes the wallet check every
+// transaction it creates or is handed for publishing on behalf of the caller
+// with check before the transaction is published.  Publishing fails with the
+// error of check.  This lets servers apply the same spending rules to every
+// method that publishes transactions, using the transaction as it is sent
+// rather than the amounts the caller requested.
+func WithSpendCheck(ctx context.Context, check SpendCheck) context.Context {
+	return context.WithValue(ctx, spendCheckKey{}, check)
+}
+
+// checkSpend checks the transaction with the SpendCheck of the context, if
+// any.
+func checkSpend(ctx context.Context, tx *wire.MsgTx) error {
+	check, ok := ctx.Value(spendCheckKey{}).(SpendCheck)
+	if !ok {
+		return nil
+	}
+	return check(tx)
+}
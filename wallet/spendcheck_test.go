@@ -0,0 +1,40 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSpendCheck checks that transactions are published only if the spend
+// check of the context allows them.
+func TestSpendCheck(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	errDenied := errors.New("denied")
+	tx := wire.NewMsgTx(2)
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+
+	var checked *wire.MsgTx
+	ctx := WithSpendCheck(context.Background(),
+		func(tx *wire.MsgTx) error {
+			checked = tx
+			return errDenied
+		},
+	)
+	err := w.PublishTransaction(ctx, tx, "")
+	require.ErrorIs(t, err, errDenied)
+	require.Same(t, tx, checked)
+
+	// The denied transaction was not recorded by the wallet.
+	txHash := tx.TxHash()
+	details, err := UnstableAPI(w).TxDetails(&txHash)
+	require.NoError(t, err)
+	require.Nil(t, details)
+}
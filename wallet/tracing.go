@@ -0,0 +1,82 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the spans of the wallet.
+const tracerName = "github.com/stroomnetwork/btcwallet/wallet"
+
+// WithTracerProvider specifies the tracer provider of the spans of the loaded
+// wallets.  By default, wallets use the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) LoaderOption {
+	return func(c *loaderConfig) {
+		c.tracerProvider = provider
+	}
+}
+
+// SetTracerProvider sets the tracer provider of the spans of the wallet.  It
+// must be called before the wallet is started.
+func (w *Wallet) SetTracerProvider(provider trace.TracerProvider) {
+	w.tracer = provider.Tracer(tracerName)
+}
+
+// defaultTracer returns the tracer of the global tracer provider, which
+// follows the provider even when it is set after the wallet is opened.
+func defaultTracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// startSpan starts a span of the wallet as a child of the span of the
+// context, if any.
+func (w *Wallet) startSpan(ctx context.Context, name string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	return w.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the error, if not nil, as the status of the span and ends
+// the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// chainCall calls the chain backend in a span named after the method of the
+// chain.Interface called.
+func (w *Wallet) chainCall(ctx context.Context, method string,
+	call func() error) error {
+
+	_, span := w.tracer.Start(ctx, "chain."+method,
+		trace.WithSpanKind(trace.SpanKindClient))
+	err := call()
+	endSpan(span, err)
+	return err
+}
+
+// updateDB runs a read-write transaction of the wallet database in a span.
+// A transaction rolled back with walletdb.ErrDryRunRollBack is not an error
+// of the span.
+func (w *Wallet) updateDB(ctx context.Context,
+	f func(tx walletdb.ReadWriteTx) error) error {
+
+	_, span := w.tracer.Start(ctx, "walletdb.Update")
+	err := walletdb.Update(w.db, f)
+	if errors.Is(err, walletdb.ErrDryRunRollBack) {
+		span.SetAttributes(attribute.Bool("walletdb.rolled_back", true))
+		endSpan(span, nil)
+	} else {
+		endSpan(span, err)
+	}
+	return err
+}
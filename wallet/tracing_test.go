@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/tracing"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTxToOutputsSpans checks that the chain and database calls made to
+// create a transaction are traced as children of the span of the caller, and
+// that the rollback of a dry run isn't reported as an error.
+func TestTxToOutputsSpans(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	provider, exporter := tracing.NewMemoryProvider()
+	w.SetTracerProvider(provider)
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(100000, pkScript)},
+	})
+
+	ctx, parent := provider.Tracer("test").Start(
+		context.Background(), "request",
+	)
+	_, err = w.txToOutputs(
+		ctx, []*wire.TxOut{wire.NewTxOut(10000, pkScript)}, nil, nil,
+		0, 1, 1000, CoinSelectionLargest, true, false, nil,
+		alwaysAllowUtxo,
+	)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	require.Len(t, spans, 4)
+
+	request := spans["request"]
+	txToOutputs := spans["wallet.txToOutputs"]
+	require.Equal(t, request.SpanContext.SpanID(), txToOutputs.Parent.SpanID())
+	require.Equal(t, codes.Unset, txToOutputs.Status.Code)
+
+	for _, name := range []string{"chain.BlockStamp", "walletdb.Update"} {
+		span, ok := spans[name]
+		require.True(t, ok, "no span %s", name)
+		require.Equal(t, txToOutputs.SpanContext.SpanID(),
+			span.Parent.SpanID(), "parent of span %s", name)
+		require.Equal(t, codes.Unset, span.Status.Code,
+			"status of span %s", name)
+	}
+	require.Contains(t, spans["walletdb.Update"].Attributes,
+		attribute.Bool("walletdb.rolled_back", true))
+}
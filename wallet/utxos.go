@@ -129,6 +129,20 @@ func (w *Wallet) FetchInputInfo(prevOut *wire.OutPoint) (*wire.MsgTx,
 // passed output script. This function is used to look up the proper key which
 // should be used to sign a specified input.
 func (w *Wallet) fetchOutputAddr(script []byte) (waddrmgr.ManagedAddress, error) {
+	var walletAddr waddrmgr.ManagedAddress
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		var err error
+		walletAddr, err = w.outputAddr(addrmgrNs, script)
+		return err
+	})
+	return walletAddr, err
+}
+
+// outputAddr is fetchOutputAddr within a database transaction.
+func (w *Wallet) outputAddr(addrmgrNs walletdb.ReadBucket,
+	script []byte) (waddrmgr.ManagedAddress, error) {
+
 	_, addrs, _, err := txscript.ExtractPkScriptAddrs(script, w.chainParams)
 	if err != nil {
 		return nil, err
@@ -138,7 +152,7 @@ func (w *Wallet) fetchOutputAddr(script []byte) (waddrmgr.ManagedAddress, error)
 	// Therefore, we simply select the key for the first address we know
 	// of.
 	for _, addr := range addrs {
-		addr, err := w.AddressInfo(addr)
+		addr, err := w.Manager.Address(addrmgrNs, addr)
 		if err == nil {
 			return addr, nil
 		}
@@ -194,6 +208,22 @@ func (w *Wallet) FetchOutpointInfo(prevOut *wire.OutPoint) (*wire.MsgTx,
 	}, confs, nil
 }
 
+// FetchPrevOutput returns the output spent by the passed outpoint if its
+// transaction is known to the wallet, or nil otherwise, so that the wallet
+// may be used as a txscript.PrevOutputFetcher.  Unlike FetchOutpointInfo, the
+// output doesn't need to be under the control of the wallet.
+func (w *Wallet) FetchPrevOutput(prevOut wire.OutPoint) *wire.TxOut {
+	txDetail, err := UnstableAPI(w).TxDetails(&prevOut.Hash)
+	if err != nil || txDetail == nil {
+		return nil
+	}
+	txOuts := txDetail.TxRecord.MsgTx.TxOut
+	if prevOut.Index >= uint32(len(txOuts)) {
+		return nil
+	}
+	return txOuts[prevOut.Index]
+}
+
 // FetchDerivationInfo queries for the wallet's knowledge of the passed
 // pkScript and constructs the derivation info and returns it.
 func (w *Wallet) FetchDerivationInfo(pkScript []byte) (*psbt.Bip32Derivation,
@@ -208,9 +238,17 @@ func (w *Wallet) FetchDerivationInfo(pkScript []byte) (*psbt.Bip32Derivation,
 	if !ok {
 		return nil, ErrNotMine
 	}
+
+	return bip32Derivation(pubKeyAddr), nil
+}
+
+// bip32Derivation returns the derivation info of a wallet address.
+func bip32Derivation(
+	pubKeyAddr waddrmgr.ManagedPubKeyAddress) *psbt.Bip32Derivation {
+
 	keyScope, derivationPath, _ := pubKeyAddr.DerivationInfo()
 
-	derivation := &psbt.Bip32Derivation{
+	return &psbt.Bip32Derivation{
 		PubKey:               pubKeyAddr.PubKey().SerializeCompressed(),
 		MasterKeyFingerprint: derivationPath.MasterKeyFingerprint,
 		Bip32Path: []uint32{
@@ -221,6 +259,4 @@ func (w *Wallet) FetchDerivationInfo(pkScript []byte) (*psbt.Bip32Derivation,
 			derivationPath.Index,
 		},
 	}
-
-	return derivation, nil
 }
@@ -7,6 +7,7 @@ package wallet
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -32,6 +33,8 @@ import (
 	"github.com/stroomnetwork/btcwallet/chain"
 	"github.com/stroomnetwork/btcwallet/waddrmgr"
 	"github.com/stroomnetwork/btcwallet/wallet/txauthor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -155,6 +158,10 @@ type Wallet struct {
 	rescanLock          sync.Mutex
 	RescanStartStamp    *waddrmgr.BlockStamp
 
+	// rescanStatus is the status of the rescan currently being
+	// performed, or nil if no rescan is running.
+	rescanStatus atomic.Pointer[RescanStatus]
+
 	// Channel for transaction creation requests.
 	createTxRequests chan createTxRequest
 
@@ -166,8 +173,30 @@ type Wallet struct {
 	changePassphrase   chan changePassphraseRequest
 	changePassphrases  chan changePassphrasesRequest
 
+	// unlockedUntil is the unix time the wallet will be locked again, or
+	// zero if it is locked or has no known lock time.
+	unlockedUntil atomic.Int64
+
 	NtfnServer *NotificationServer
 
+	// confWatchers are the transactions watched for reaching or losing
+	// a target confirmation depth.
+	confWatchers *confirmationWatchers
+
+	// reorgSafety halts the wallet on chain reorganizations deeper than
+	// tolerated.
+	reorgSafety reorgSafety
+
+	// privPassKDF are the options the key of a new private passphrase is
+	// derived with, or nil for waddrmgr.DefaultArgon2Options.
+	privPassKDF *waddrmgr.ScryptOptions
+
+	// metrics receives measurements of the wallet, if set.
+	metrics Metrics
+
+	// tracer starts the spans of the operations of the wallet.
+	tracer trace.Tracer
+
 	chainParams *chaincfg.Params
 	wg          sync.WaitGroup
 
@@ -331,6 +360,12 @@ func (w *Wallet) ShuttingDown() bool {
 	}
 }
 
+// ShutdownChan returns a channel that is closed once the wallet starts
+// shutting down.
+func (w *Wallet) ShutdownChan() <-chan struct{} {
+	return w.quitChan()
+}
+
 // WaitForShutdown blocks until all wallet goroutines have finished executing.
 func (w *Wallet) WaitForShutdown() {
 	w.chainClientLock.Lock()
@@ -495,6 +530,7 @@ func (w *Wallet) syncWithChain(birthdayStamp *waddrmgr.BlockStamp) error {
 	// before catching up with the rescan.
 	rollback := false
 	rollbackStamp := w.Manager.SyncedTo()
+	syncedHeight := rollbackStamp.Height
 	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
 		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
 		txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
@@ -552,7 +588,19 @@ func (w *Wallet) syncWithChain(birthdayStamp *waddrmgr.BlockStamp) error {
 		// stale state. `Rollback` unconfirms transactions at and beyond
 		// the passed height, so add one to the new synced-to height to
 		// prevent unconfirming transactions in the synced-to block.
-		return w.TxStore.Rollback(txmgrNs, rollbackStamp.Height+1)
+		err = w.TxStore.Rollback(txmgrNs, rollbackStamp.Height+1)
+		if err != nil {
+			return err
+		}
+		if err := w.dropStaleColdSpends(tx); err != nil {
+			return err
+		}
+
+		// The blocks were reorged out while the wallet was offline, so
+		// they count towards the reorg depth like disconnected ones.
+		return w.reorgBlocksDisconnected(
+			tx, syncedHeight, rollbackStamp.Height+1,
+		)
 	})
 	if err != nil {
 		return err
@@ -1230,6 +1278,7 @@ func logFilterBlocksResp(block wtxmgr.BlockMeta,
 
 type (
 	createTxRequest struct {
+		ctx                   context.Context
 		coinSelectKeyScope    *waddrmgr.KeyScope
 		changeKeyScope        *waddrmgr.KeyScope
 		account               uint32
@@ -1241,6 +1290,7 @@ type (
 		resp                  chan createTxResponse
 		selectUtxos           []wire.OutPoint
 		allowUtxo             func(wtxmgr.Credit) bool
+		skipColdSpend         bool
 	}
 	createTxResponse struct {
 		tx  *txauthor.AuthoredTx
@@ -1264,11 +1314,28 @@ out:
 	for {
 		select {
 		case txr := <-w.createTxRequests:
+			// Spends from cold accounts are handed off unsigned, so
+			// they don't need the wallet to be unlocked.
+			var coldSpend bool
+			err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+				addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+				coldScope, err := w.coldSpendScope(
+					addrmgrNs, txr.coinSelectKeyScope,
+					txr.account,
+				)
+				coldSpend = coldScope != nil
+				return err
+			})
+			if err != nil {
+				txr.resp <- createTxResponse{nil, err}
+				continue
+			}
+
 			// If the wallet can be locked because it contains
 			// private key material, we need to prevent it from
 			// doing so while we are assembling the transaction.
 			release := func() {}
-			if !w.Manager.WatchOnly() {
+			if !w.Manager.WatchOnly() && !coldSpend {
 				heldUnlock, err := w.holdUnlock()
 				if err != nil {
 					txr.resp <- createTxResponse{nil, err}
@@ -1279,10 +1346,11 @@ out:
 			}
 
 			tx, err := w.txToOutputs(
-				txr.outputs, txr.coinSelectKeyScope,
+				txr.ctx, txr.outputs, txr.coinSelectKeyScope,
 				txr.changeKeyScope, txr.account, txr.minconf,
 				txr.feeSatPerKB, txr.coinSelectionStrategy,
-				txr.dryRun, txr.selectUtxos, txr.allowUtxo,
+				txr.dryRun, txr.skipColdSpend, txr.selectUtxos,
+				txr.allowUtxo,
 			)
 
 			release()
@@ -1301,6 +1369,7 @@ type txCreateOptions struct {
 	changeKeyScope *waddrmgr.KeyScope
 	selectUtxos    []wire.OutPoint
 	allowUtxo      func(wtxmgr.Credit) bool
+	skipColdSpend  bool
 }
 
 // TxCreateOption is a set of optional arguments to modify the tx creation
@@ -1340,6 +1409,15 @@ func WithUtxoFilter(allowUtxo func(utxo wtxmgr.Credit) bool) TxCreateOption {
 	}
 }
 
+// withoutColdSpend is used to return the unsigned transaction of a spend from
+// a cold account without recording it as a pending cold spend, for callers
+// which hand it off themselves.
+func withoutColdSpend() TxCreateOption {
+	return func(opts *txCreateOptions) {
+		opts.skipColdSpend = true
+	}
+}
+
 // CreateSimpleTx creates a new signed transaction spending unspent outputs with
 // at least minconf confirmations spending to any number of address/amount
 // pairs. Only unspent outputs belonging to the given key scope and account will
@@ -1355,12 +1433,30 @@ func WithUtxoFilter(allowUtxo func(utxo wtxmgr.Credit) bool) TxCreateOption {
 // tx creation process such as using a custom change scope, which otherwise
 // defaults to the same as the specified coin selection scope.
 //
+// The context carries the span of the caller, if any, which is the parent of
+// the spans of the creation of the transaction.
+//
+// In a wallet with private keys, the transaction of a spend from an account
+// made cold with SetAccountSpendingMode is not signed: it is recorded as a
+// pending cold spend, with its inputs locked, to be signed outside of the
+// wallet and published with CompleteColdSpend.  Without a key scope, the
+// account is the cold account of that number in any key scope, if there is
+// one.
+//
 // NOTE: The dryRun argument can be set true to create a tx that doesn't alter
 // the database. A tx created with this set to true SHOULD NOT be broadcast.
-func (w *Wallet) CreateSimpleTx(coinSelectKeyScope *waddrmgr.KeyScope,
-	account uint32, outputs []*wire.TxOut, minconf int32,
-	satPerKb btcutil.Amount, coinSelectionStrategy CoinSelectionStrategy,
-	dryRun bool, optFuncs ...TxCreateOption) (*txauthor.AuthoredTx, error) {
+func (w *Wallet) CreateSimpleTx(ctx context.Context,
+	coinSelectKeyScope *waddrmgr.KeyScope, account uint32,
+	outputs []*wire.TxOut, minconf int32, satPerKb btcutil.Amount,
+	coinSelectionStrategy CoinSelectionStrategy, dryRun bool,
+	optFuncs ...TxCreateOption) (_ *txauthor.AuthoredTx, err error) {
+
+	ctx, span := w.startSpan(ctx, "wallet.CreateSimpleTx",
+		attribute.Int64("wallet.account", int64(account)),
+		attribute.Int("wallet.outputs", len(outputs)),
+		attribute.Bool("wallet.dry_run", dryRun),
+	)
+	defer func() { endSpan(span, err) }()
 
 	opts := defaultTxCreateOptions()
 	for _, optFunc := range optFuncs {
@@ -1374,6 +1470,7 @@ func (w *Wallet) CreateSimpleTx(coinSelectKeyScope *waddrmgr.KeyScope,
 	}
 
 	req := createTxRequest{
+		ctx:                   ctx,
 		coinSelectKeyScope:    coinSelectKeyScope,
 		changeKeyScope:        opts.changeKeyScope,
 		account:               account,
@@ -1385,6 +1482,7 @@ func (w *Wallet) CreateSimpleTx(coinSelectKeyScope *waddrmgr.KeyScope,
 		resp:                  make(chan createTxResponse),
 		selectUtxos:           opts.selectUtxos,
 		allowUtxo:             opts.allowUtxo,
+		skipColdSpend:         opts.skipColdSpend,
 	}
 	w.createTxRequests <- req
 	resp := <-req.resp
@@ -1395,6 +1493,7 @@ type (
 	unlockRequest struct {
 		passphrase []byte
 		lockAfter  <-chan time.Time // nil prevents the timeout.
+		lockAt     time.Time        // zero if unknown or no timeout.
 		err        chan error
 	}
 
@@ -1453,6 +1552,11 @@ out:
 				continue
 			}
 			timeout = req.lockAfter
+			var lockAt int64
+			if !req.lockAt.IsZero() {
+				lockAt = req.lockAt.Unix()
+			}
+			w.unlockedUntil.Store(lockAt)
 			if timeout == nil {
 				log.Info("The wallet has been unlocked without a time limit")
 			} else {
@@ -1462,11 +1566,15 @@ out:
 			continue
 
 		case req := <-w.changePassphrase:
+			kdfOptions := &waddrmgr.DefaultScryptOptions
+			if req.private {
+				kdfOptions = w.privPassKDFOptions()
+			}
 			err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
 				addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
 				return w.Manager.ChangePassphrase(
 					addrmgrNs, req.old, req.new, req.private,
-					&waddrmgr.DefaultScryptOptions,
+					kdfOptions,
 				)
 			})
 			req.err <- err
@@ -1485,7 +1593,7 @@ out:
 
 				return w.Manager.ChangePassphrase(
 					addrmgrNs, req.privateOld, req.privateNew,
-					true, &waddrmgr.DefaultScryptOptions,
+					true, w.privPassKDFOptions(),
 				)
 			})
 			req.err <- err
@@ -1530,6 +1638,7 @@ out:
 		<-w.endRecovery()
 
 		timeout = nil
+		w.unlockedUntil.Store(0)
 		err := w.Manager.Lock()
 		if err != nil && !waddrmgr.IsError(err, waddrmgr.ErrLocked) {
 			log.Errorf("Could not lock wallet: %v", err)
@@ -1555,6 +1664,36 @@ func (w *Wallet) Unlock(passphrase []byte, lock <-chan time.Time) error {
 	return <-err
 }
 
+// UnlockFor unlocks the wallet's address manager and relocks it once timeout
+// has passed.  A zero timeout keeps the wallet unlocked until it is locked
+// explicitly.  Unlike Unlock, the time the wallet will be locked again is
+// reported by UnlockedUntil.
+func (w *Wallet) UnlockFor(passphrase []byte, timeout time.Duration) error {
+	if timeout == 0 {
+		return w.Unlock(passphrase, nil)
+	}
+
+	err := make(chan error, 1)
+	w.unlockRequests <- unlockRequest{
+		passphrase: passphrase,
+		lockAfter:  time.After(timeout),
+		lockAt:     time.Now().Add(timeout),
+		err:        err,
+	}
+	return <-err
+}
+
+// UnlockedUntil returns the time the wallet will be locked again after it was
+// unlocked with UnlockFor.  The zero time is returned if the wallet is locked
+// or the time is unknown.
+func (w *Wallet) UnlockedUntil() time.Time {
+	lockAt := w.unlockedUntil.Load()
+	if lockAt == 0 || w.Manager.IsLocked() {
+		return time.Time{}
+	}
+	return time.Unix(lockAt, 0)
+}
+
 // Lock locks the wallet's address manager.
 func (w *Wallet) Lock() {
 	w.lockRequests <- struct{}{}
@@ -1593,10 +1732,25 @@ func (c heldUnlock) release() {
 	c <- struct{}{}
 }
 
+// privPassKDFOptions returns the options the key of a new private passphrase
+// is derived with.
+func (w *Wallet) privPassKDFOptions() *waddrmgr.ScryptOptions {
+	if w.privPassKDF == nil {
+		return &waddrmgr.DefaultArgon2Options
+	}
+	return w.privPassKDF
+}
+
 // ChangePrivatePassphrase attempts to change the passphrase for a wallet from
 // old to new.  Changing the passphrase is synchronized with all other address
 // manager locking and unlocking.  The lock state will be the same as it was
 // before the password change.
+//
+// The key of the new passphrase is derived with Argon2id, with the parameters
+// of the WithArgon2Options of the loader or the defaults, so changing the
+// passphrase, even to the same one, upgrades wallets whose key was derived
+// with scrypt.  The public passphrase keeps using scrypt, as its key is
+// derived every time the wallet is opened.
 func (w *Wallet) ChangePrivatePassphrase(old, new []byte) error {
 	err := make(chan error, 1)
 	w.changePassphrase <- changePassphraseRequest{
@@ -1621,7 +1775,8 @@ func (w *Wallet) ChangePublicPassphrase(old, new []byte) error {
 }
 
 // ChangePassphrases modifies the public and private passphrase of the wallet
-// atomically.
+// atomically.  The key of the private passphrase is derived with Argon2id, as
+// with ChangePrivatePassphrase.
 func (w *Wallet) ChangePassphrases(publicOld, publicNew, privateOld,
 	privateNew []byte) error {
 
@@ -1684,6 +1839,23 @@ type Balances struct {
 // are not indexed by the accounts they credit to, and all unspent transaction
 // outputs must be iterated.
 func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balances, error) {
+	return w.calculateBalances(confirms, func(acct uint32) bool {
+		return acct == account
+	})
+}
+
+// CalculateWalletBalances sums the amounts of all unspent transaction outputs
+// of the wallet, regardless of the account they belong to, grouped the same
+// way as CalculateAccountBalances.
+func (w *Wallet) CalculateWalletBalances(confirms int32) (Balances, error) {
+	return w.calculateBalances(confirms, nil)
+}
+
+// calculateBalances sums the amounts of the unspent transaction outputs of the
+// accounts matching the filter.  A nil filter includes all outputs.
+func (w *Wallet) calculateBalances(confirms int32,
+	filter func(account uint32) bool) (Balances, error) {
+
 	var bals Balances
 	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
 		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
@@ -1700,14 +1872,16 @@ func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balan
 		for i := range unspent {
 			output := &unspent[i]
 
-			var outputAcct uint32
-			_, addrs, _, err := txscript.ExtractPkScriptAddrs(
-				output.PkScript, w.chainParams)
-			if err == nil && len(addrs) > 0 {
-				_, outputAcct, err = w.Manager.AddrAccount(addrmgrNs, addrs[0])
-			}
-			if err != nil || outputAcct != account {
-				continue
+			if filter != nil {
+				var outputAcct uint32
+				_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+					output.PkScript, w.chainParams)
+				if err == nil && len(addrs) > 0 {
+					_, outputAcct, err = w.Manager.AddrAccount(addrmgrNs, addrs[0])
+				}
+				if err != nil || !filter(outputAcct) {
+					continue
+				}
 			}
 
 			bals.Total += output.Amount
@@ -3175,6 +3349,19 @@ func (w *Wallet) ReleaseOutput(id wtxmgr.LockID, op wire.OutPoint) error {
 	})
 }
 
+// UnminedTxHashes returns the hashes of the transactions of the wallet not
+// known to have been mined into a block.
+func (w *Wallet) UnminedTxHashes() ([]*chainhash.Hash, error) {
+	var hashes []*chainhash.Hash
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		hashes, err = w.TxStore.UnminedTxHashes(txmgrNs)
+		return err
+	})
+	return hashes, err
+}
+
 // resendUnminedTxs iterates through all transactions that spend from wallet
 // credits that are not known to have been mined into a block, and attempts
 // to send each to the chain server for relay.
@@ -3193,7 +3380,7 @@ func (w *Wallet) resendUnminedTxs() {
 	}
 
 	for _, tx := range txs {
-		txHash, err := w.publishTransaction(tx)
+		txHash, err := w.publishTransaction(context.Background(), tx)
 		if err != nil {
 			log.Debugf("Unable to rebroadcast transaction %v: %v",
 				tx.TxHash(), err)
@@ -3275,6 +3462,13 @@ func (w *Wallet) newAddress(addrmgrNs walletdb.ReadWriteBucket, account uint32,
 		return nil, nil, err
 	}
 
+	// Addresses of an account retired by a seed rotation are derived from
+	// the account that replaced it.
+	account, err = manager.ActiveAccount(addrmgrNs, account)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Get next address from wallet.
 	addrs, err := manager.NextExternalAddresses(addrmgrNs, account, 1)
 	if err != nil {
@@ -3342,6 +3536,13 @@ func (w *Wallet) newChangeAddress(addrmgrNs walletdb.ReadWriteBucket,
 		return nil, err
 	}
 
+	// Change of an account retired by a seed rotation goes to the account
+	// that replaced it.
+	account, err = manager.ActiveAccount(addrmgrNs, account)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get next chained change address from wallet for account.
 	addrs, err := manager.NextInternalAddresses(addrmgrNs, account, 1)
 	if err != nil {
@@ -3501,35 +3702,46 @@ func (w *Wallet) TotalReceivedForAddr(addr btcutil.Address, minConf int32) (btcu
 // selected. This is done to handle the default account case, where a user wants
 // to fund a PSBT with inputs regardless of their type (NP2WKH, P2WKH, etc.). It
 // returns the transaction upon success.
-func (w *Wallet) SendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
-	account uint32, minconf int32, satPerKb btcutil.Amount,
-	coinSelectionStrategy CoinSelectionStrategy, label string) (*wire.MsgTx,
-	error) {
+func (w *Wallet) SendOutputs(ctx context.Context, outputs []*wire.TxOut,
+	keyScope *waddrmgr.KeyScope, account uint32, minconf int32,
+	satPerKb btcutil.Amount, coinSelectionStrategy CoinSelectionStrategy,
+	label string) (*wire.MsgTx, error) {
 
 	return w.sendOutputs(
-		outputs, keyScope, account, minconf, satPerKb,
+		ctx, outputs, keyScope, account, minconf, satPerKb,
 		coinSelectionStrategy, label,
 	)
 }
 
 // SendOutputsWithInput creates and sends payment transactions using the
 // provided selected utxos. It returns the transaction upon success.
-func (w *Wallet) SendOutputsWithInput(outputs []*wire.TxOut,
-	keyScope *waddrmgr.KeyScope,
+func (w *Wallet) SendOutputsWithInput(ctx context.Context,
+	outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 	account uint32, minconf int32, satPerKb btcutil.Amount,
 	coinSelectionStrategy CoinSelectionStrategy, label string,
 	selectedUtxos []wire.OutPoint) (*wire.MsgTx, error) {
 
-	return w.sendOutputs(outputs, keyScope, account, minconf, satPerKb,
-		coinSelectionStrategy, label, selectedUtxos...)
+	return w.sendOutputs(ctx, outputs, keyScope, account, minconf,
+		satPerKb, coinSelectionStrategy, label, selectedUtxos...)
 }
 
 // sendOutputs creates and sends payment transactions. It returns the
 // transaction upon success.
-func (w *Wallet) sendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
-	account uint32, minconf int32, satPerKb btcutil.Amount,
-	coinSelectionStrategy CoinSelectionStrategy, label string,
-	selectedUtxos ...wire.OutPoint) (*wire.MsgTx, error) {
+func (w *Wallet) sendOutputs(ctx context.Context, outputs []*wire.TxOut,
+	keyScope *waddrmgr.KeyScope, account uint32, minconf int32,
+	satPerKb btcutil.Amount, coinSelectionStrategy CoinSelectionStrategy,
+	label string, selectedUtxos ...wire.OutPoint) (_ *wire.MsgTx,
+	err error) {
+
+	ctx, span := w.startSpan(ctx, "wallet.SendOutputs",
+		attribute.Int64("wallet.account", int64(account)),
+		attribute.Int("wallet.outputs", len(outputs)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if err := w.checkReorgHalt(); err != nil {
+		return nil, err
+	}
 
 	// Ensure the outputs to be created adhere to the network's consensus
 	// rules.
@@ -3547,7 +3759,7 @@ func (w *Wallet) sendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 	// continue to re-broadcast the transaction upon restarts until it has
 	// been confirmed.
 	createdTx, err := w.CreateSimpleTx(
-		keyScope, account, outputs, minconf, satPerKb,
+		ctx, keyScope, account, outputs, minconf, satPerKb,
 		coinSelectionStrategy, false, WithCustomSelectUtxos(
 			selectedUtxos,
 		),
@@ -3563,7 +3775,17 @@ func (w *Wallet) sendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 		return createdTx.Tx, ErrTxUnsigned
 	}
 
-	txHash, err := w.reliablyPublishTransaction(createdTx.Tx, label)
+	// Spends from cold accounts are likewise left unsigned, pending a
+	// signature from outside of the wallet.
+	unsignedHash := createdTx.Tx.TxHash()
+	if _, err := w.ColdSpend(unsignedHash); err == nil {
+		return createdTx.Tx, fmt.Errorf("%w: cold spend %v is waiting "+
+			"for a signature", ErrTxUnsigned, unsignedHash)
+	} else if !errors.Is(err, ErrColdSpendNotFound) {
+		return nil, err
+	}
+
+	txHash, err := w.reliablyPublishTransaction(ctx, createdTx.Tx, label)
 	if err != nil {
 		return nil, err
 	}
@@ -3799,8 +4021,14 @@ func (e *ErrInMempool) Unwrap() error {
 //
 // This function is unstable and will be removed once syncing code is moved out
 // of the wallet.
-func (w *Wallet) PublishTransaction(tx *wire.MsgTx, label string) error {
-	_, err := w.reliablyPublishTransaction(tx, label)
+func (w *Wallet) PublishTransaction(ctx context.Context, tx *wire.MsgTx,
+	label string) error {
+
+	if err := w.checkReorgHalt(); err != nil {
+		return err
+	}
+
+	_, err := w.reliablyPublishTransaction(ctx, tx, label)
 	return err
 }
 
@@ -3809,14 +4037,24 @@ func (w *Wallet) PublishTransaction(tx *wire.MsgTx, label string) error {
 // relevant database state, and finally possible removing the transaction from
 // the database (along with cleaning up all inputs used, and outputs created) if
 // the transaction is rejected by the backend.
-func (w *Wallet) reliablyPublishTransaction(tx *wire.MsgTx,
-	label string) (*chainhash.Hash, error) {
+func (w *Wallet) reliablyPublishTransaction(ctx context.Context,
+	tx *wire.MsgTx, label string) (_ *chainhash.Hash, err error) {
+
+	ctx, span := w.startSpan(ctx, "wallet.reliablyPublishTransaction",
+		attribute.String("wallet.txid", tx.TxHash().String()),
+	)
+	defer func() { endSpan(span, err) }()
 
 	chainClient, err := w.requireChainClient()
 	if err != nil {
 		return nil, err
 	}
 
+	// The caller may restrict the transactions published on its behalf.
+	if err := checkSpend(ctx, tx); err != nil {
+		return nil, err
+	}
+
 	// As we aim for this to be general reliable transaction broadcast API,
 	// we'll write this tx to disk as an unconfirmed transaction. This way,
 	// upon restarts, we'll always rebroadcast it, and also add it to our
@@ -3829,7 +4067,7 @@ func (w *Wallet) reliablyPublishTransaction(tx *wire.MsgTx,
 	// Along the way, we'll extract our relevant destination addresses from
 	// the transaction.
 	var ourAddrs []btcutil.Address
-	err = walletdb.Update(w.db, func(dbTx walletdb.ReadWriteTx) error {
+	err = w.updateDB(ctx, func(dbTx walletdb.ReadWriteTx) error {
 		addrmgrNs := dbTx.ReadWriteBucket(waddrmgrNamespaceKey)
 		for _, txOut := range tx.TxOut {
 			_, addrs, _, err := txscript.ExtractPkScriptAddrs(
@@ -3872,25 +4110,44 @@ func (w *Wallet) reliablyPublishTransaction(tx *wire.MsgTx,
 	// We'll also ask to be notified of the transaction once it confirms
 	// on-chain. This is done outside of the database transaction to prevent
 	// backend interaction within it.
-	if err := chainClient.NotifyReceived(ourAddrs); err != nil {
+	err = w.chainCall(ctx, "NotifyReceived", func() error {
+		return chainClient.NotifyReceived(ourAddrs)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return w.publishTransaction(tx)
+	return w.publishTransaction(ctx, tx)
 }
 
 // publishTransaction attempts to send an unconfirmed transaction to the
 // wallet's current backend. In the event that sending the transaction fails for
 // whatever reason, it will be removed from the wallet's unconfirmed transaction
 // store.
-func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
+func (w *Wallet) publishTransaction(ctx context.Context,
+	tx *wire.MsgTx) (_ *chainhash.Hash, err error) {
+
+	txid := tx.TxHash()
+	ctx, span := w.startSpan(ctx, "wallet.publishTransaction",
+		attribute.String("wallet.txid", txid.String()),
+	)
+	defer func() { endSpan(span, err) }()
+
 	chainClient, err := w.requireChainClient()
 	if err != nil {
 		return nil, err
 	}
 
-	txid := tx.TxHash()
-	_, rpcErr := chainClient.SendRawTransaction(tx, false)
+	// Nothing is broadcast while the chain may be under attack, including
+	// rebroadcasts of transactions published earlier.
+	if err := w.checkReorgHalt(); err != nil {
+		return nil, err
+	}
+
+	rpcErr := w.chainCall(ctx, "SendRawTransaction", func() error {
+		_, err := chainClient.SendRawTransaction(tx, false)
+		return err
+	})
 	if rpcErr == nil {
 		return &txid, nil
 	}
@@ -3903,7 +4160,7 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
 	case errors.Is(rpcErr, chain.ErrTxAlreadyKnown),
 		errors.Is(rpcErr, chain.ErrTxAlreadyConfirmed):
 
-		dbErr := walletdb.Update(w.db, func(dbTx walletdb.ReadWriteTx) error {
+		dbErr := w.updateDB(ctx, func(dbTx walletdb.ReadWriteTx) error {
 			txmgrNs := dbTx.ReadWriteBucket(wtxmgrNamespaceKey)
 			txRec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
 			if err != nil {
@@ -3924,12 +4181,15 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
 
 	// Log the causing error, even if we know how to handle it.
 	log.Infof("%v: broadcast failed because of: %v", txid, rpcErr)
+	if w.metrics != nil {
+		w.metrics.BroadcastFailed(rpcErr)
+	}
 
 	// If the transaction was rejected for whatever other reason, then
 	// we'll remove it from the transaction store, as otherwise, we'll
 	// attempt to continually re-broadcast it, and the UTXO state of the
 	// wallet won't be accurate.
-	dbErr := walletdb.Update(w.db, func(dbTx walletdb.ReadWriteTx) error {
+	dbErr := w.updateDB(ctx, func(dbTx walletdb.ReadWriteTx) error {
 		txmgrNs := dbTx.ReadWriteBucket(wtxmgrNamespaceKey)
 		txRec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
 		if err != nil {
@@ -3983,7 +4243,8 @@ func CreateWithCallback(db walletdb.DB, pubPass, privPass []byte,
 	birthday time.Time, cb func(walletdb.ReadWriteTx) error) error {
 
 	return create(
-		db, pubPass, privPass, rootKey, params, birthday, false, cb,
+		db, pubPass, privPass, rootKey, params, birthday, false, nil,
+		cb,
 	)
 }
 
@@ -3995,19 +4256,21 @@ func CreateWatchingOnlyWithCallback(db walletdb.DB, pubPass []byte,
 	cb func(walletdb.ReadWriteTx) error) error {
 
 	return create(
-		db, pubPass, nil, nil, params, birthday, true, cb,
+		db, pubPass, nil, nil, params, birthday, true, nil, cb,
 	)
 }
 
 // Create creates an new wallet, writing it to an empty database.  If the passed
 // root key is non-nil, it is used.  Otherwise, a secure random seed of the
-// recommended length is generated.
+// recommended length is generated.  The key of the private passphrase is
+// derived with Argon2id and waddrmgr.DefaultArgon2Options.
 func Create(db walletdb.DB, pubPass, privPass []byte,
 	rootKey *hdkeychain.ExtendedKey, params *chaincfg.Params,
 	birthday time.Time) error {
 
 	return create(
 		db, pubPass, privPass, rootKey, params, birthday, false, nil,
+		nil,
 	)
 }
 
@@ -4019,13 +4282,17 @@ func CreateWatchingOnly(db walletdb.DB, pubPass []byte,
 	params *chaincfg.Params, birthday time.Time) error {
 
 	return create(
-		db, pubPass, nil, nil, params, birthday, true, nil,
+		db, pubPass, nil, nil, params, birthday, true, nil, nil,
 	)
 }
 
+// create initializes a new wallet in an empty database.  The key of the
+// private passphrase is derived with the KDF options, or the defaults of
+// waddrmgr.Create if they are nil.
 func create(db walletdb.DB, pubPass, privPass []byte,
 	rootKey *hdkeychain.ExtendedKey, params *chaincfg.Params,
 	birthday time.Time, isWatchingOnly bool,
+	kdfOptions *waddrmgr.ScryptOptions,
 	cb func(walletdb.ReadWriteTx) error) error {
 
 	// If no root key was provided, we create one now from a random seed.
@@ -4064,8 +4331,8 @@ func create(db walletdb.DB, pubPass, privPass []byte,
 		}
 
 		err = waddrmgr.Create(
-			addrmgrNs, rootKey, pubPass, privPass, params, nil,
-			birthday,
+			addrmgrNs, rootKey, pubPass, privPass, params,
+			kdfOptions, birthday,
 		)
 		if err != nil {
 			return err
@@ -4165,6 +4432,8 @@ func OpenWithRetry(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		chainParams:         params,
 		quit:                make(chan struct{}),
 		syncRetryInterval:   syncRetryInterval,
+		confWatchers:        newConfirmationWatchers(),
+		tracer:              defaultTracer(),
 	}
 
 	w.NtfnServer = newNotificationServer(w)
@@ -4172,5 +4441,12 @@ func OpenWithRetry(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		w.NtfnServer.notifyUnspentOutput(0, hash, index)
 	}
 
+	if err := w.loadReorgHalt(); err != nil {
+		return nil, err
+	}
+	if err := w.loadColdSpends(); err != nil {
+		return nil, err
+	}
+
 	return w, nil
 }
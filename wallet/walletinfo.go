@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+)
+
+// TransactionCount returns the number of mined and unmined transactions
+// relevant to the wallet.
+func (w *Wallet) TransactionCount() (int, error) {
+	var n int
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, 0, -1,
+			func(details []wtxmgr.TxDetails) (bool, error) {
+				n += len(details)
+				return false, nil
+			})
+	})
+	return n, err
+}
+
+// KeyPoolSize returns the number of external and internal addresses of the
+// default account of all active key scopes that were derived but not used
+// yet.  Addresses are derived on demand, so these are the closest equivalent
+// to the pre-generated key pool of other wallets.
+func (w *Wallet) KeyPoolSize() (external, internal int, err error) {
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		for _, scopedMgr := range w.Manager.ActiveScopedKeyManagers() {
+			err := scopedMgr.ForEachAccountAddress(
+				addrmgrNs, waddrmgr.DefaultAccountNum,
+				func(maddr waddrmgr.ManagedAddress) error {
+					if maddr.Used(addrmgrNs) {
+						return nil
+					}
+					if maddr.Internal() {
+						internal++
+					} else {
+						external++
+					}
+					return nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return external, internal, err
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/walletdb/sqlbase"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib" // Register the pgx database/sql driver.
+)
+
+const (
+	// tableName is the name of the table of the buckets and key/value
+	// pairs.
+	tableName = "walletdb"
+
+	// maxConns is the maximum number of connections to the database.
+	maxConns = 16
+
+	// SQLSTATE codes of the errors after which a transaction is retried.
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+// schema creates the table of the buckets and key/value pairs.  Values of the
+// bytea type are compared byte-wise, whatever the collation of the database.
+var schema = []string{`CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	parent_id BIGINT REFERENCES %[1]s (id) ON DELETE CASCADE,
+	key BYTEA NOT NULL,
+	value BYTEA,
+	sequence BIGINT NOT NULL DEFAULT 0,
+	UNIQUE (parent_id, key)
+)`}
+
+// isRetryable returns whether the error is a serialization failure or a
+// deadlock, so the transaction may succeed if retried.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	switch pgErr.Code {
+	case codeSerializationFailure, codeDeadlockDetected:
+		return true
+	}
+	return false
+}
+
+// tableExists reports whether the table of the buckets and key/value pairs
+// exists in the search path of the connection.
+func tableExists(ctx context.Context, sqlDB *sql.DB) (bool, error) {
+	var exists bool
+	err := sqlDB.QueryRowContext(
+		ctx, "SELECT to_regclass($1) IS NOT NULL", tableName,
+	).Scan(&exists)
+	return exists, err
+}
+
+// openDB opens the database of the connection string.
+// walletdb.ErrDbDoesNotExist is returned if the database doesn't exist and
+// the create flag is not set, and walletdb.ErrDbExists if it exists and the
+// create flag is set.
+func openDB(connStr string, create bool,
+	timeout time.Duration) (walletdb.DB, error) {
+
+	sqlDB, err := sql.Open("pgx", connStr)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(maxConns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	exists, err := tableExists(ctx, sqlDB)
+	switch {
+	case err != nil:
+		_ = sqlDB.Close()
+		return nil, err
+	case !create && !exists:
+		_ = sqlDB.Close()
+		return nil, walletdb.ErrDbDoesNotExist
+	case create && exists:
+		_ = sqlDB.Close()
+		return nil, walletdb.ErrDbExists
+	}
+
+	db, err := sqlbase.NewDB(&sqlbase.Config{
+		DB: sqlDB,
+		TxOptions: sql.TxOptions{
+			Isolation: sql.LevelSerializable,
+		},
+		Table:       tableName,
+		Schema:      schema,
+		IsRetryable: isRetryable,
+	})
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
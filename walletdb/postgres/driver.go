@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+const (
+	dbType = "postgres"
+)
+
+// parseArgs parses the arguments from the walletdb Open/Create methods.
+func parseArgs(funcName string,
+	args ...interface{}) (string, time.Duration, error) {
+
+	if len(args) != 2 {
+		return "", 0, fmt.Errorf("invalid arguments to %s.%s -- "+
+			"expected connection string and timeout option", dbType,
+			funcName)
+	}
+
+	connStr, ok := args[0].(string)
+	if !ok {
+		return "", 0, fmt.Errorf("first argument to %s.%s is invalid "+
+			"-- expected connection string", dbType, funcName)
+	}
+
+	timeout, ok := args[1].(time.Duration)
+	if !ok {
+		return "", 0, fmt.Errorf("second argument to %s.%s is "+
+			"invalid -- expected timeout time.Duration", dbType,
+			funcName)
+	}
+
+	return connStr, timeout, nil
+}
+
+// openDBDriver is the callback provided during driver registration that opens
+// an existing database for use.
+func openDBDriver(args ...interface{}) (walletdb.DB, error) {
+	connStr, timeout, err := parseArgs("Open", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return openDB(connStr, false, timeout)
+}
+
+// createDBDriver is the callback provided during driver registration that
+// creates, initializes, and opens a database for use.
+func createDBDriver(args ...interface{}) (walletdb.DB, error) {
+	connStr, timeout, err := parseArgs("Create", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return openDB(connStr, true, timeout)
+}
+
+func init() {
+	// Register the driver.
+	driver := walletdb.Driver{
+		DbType: dbType,
+		Create: createDBDriver,
+		Open:   openDBDriver,
+	}
+	if err := walletdb.RegisterDriver(driver); err != nil {
+		panic(fmt.Sprintf("Failed to register database driver '%s': %v",
+			dbType, err))
+	}
+}
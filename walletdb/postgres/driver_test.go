@@ -0,0 +1,222 @@
+package postgres_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/postgres"
+)
+
+// TestCreateOpenFail ensures that errors related to creating and opening a
+// database are handled properly.
+func TestCreateOpenFail(t *testing.T) {
+	// Ensure that attempting to open or create a database with invalid
+	// parameters returns the expected errors.
+	tests := []struct {
+		open    bool
+		args    []interface{}
+		wantErr string
+	}{
+		{true, []interface{}{1, 2, 3}, "invalid arguments to " +
+			"postgres.Open -- expected connection string and " +
+			"timeout option"},
+		{true, []interface{}{1, defaultDBTimeout}, "first argument to " +
+			"postgres.Open is invalid -- expected connection string"},
+		{true, []interface{}{"postgres://", 1}, "second argument to " +
+			"postgres.Open is invalid -- expected timeout " +
+			"time.Duration"},
+		{false, []interface{}{1, 2, 3}, "invalid arguments to " +
+			"postgres.Create -- expected connection string and " +
+			"timeout option"},
+		{false, []interface{}{1, defaultDBTimeout}, "first argument " +
+			"to postgres.Create is invalid -- expected connection " +
+			"string"},
+		{false, []interface{}{"postgres://", 1}, "second argument to " +
+			"postgres.Create is invalid -- expected timeout " +
+			"time.Duration"},
+	}
+	for _, test := range tests {
+		var err error
+		if test.open {
+			_, err = walletdb.Open(dbType, test.args...)
+		} else {
+			_, err = walletdb.Create(dbType, test.args...)
+		}
+		if err == nil || err.Error() != test.wantErr {
+			t.Errorf("%v: did not receive expected error - got %v, "+
+				"want %v", test.args, err, test.wantErr)
+		}
+	}
+
+	dsn := newTestDSN(t)
+
+	// Ensure that attempting to open a database that doesn't exist returns
+	// the expected error.
+	_, err := walletdb.Open(dbType, dsn, defaultDBTimeout)
+	if err != walletdb.ErrDbDoesNotExist {
+		t.Errorf("Open: did not receive expected error - got %v, "+
+			"want %v", err, walletdb.ErrDbDoesNotExist)
+	}
+
+	// Ensure that creating a database that already exists returns the
+	// expected error.
+	db, err := walletdb.Create(dbType, dsn, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Create: unexpected error: %v", err)
+	}
+	db.Close()
+	_, err = walletdb.Create(dbType, dsn, defaultDBTimeout)
+	if err != walletdb.ErrDbExists {
+		t.Errorf("Create: did not receive expected error - got %v, "+
+			"want %v", err, walletdb.ErrDbExists)
+	}
+}
+
+// TestPersistence ensures that values stored are still valid after closing
+// and reopening the database.
+func TestPersistence(t *testing.T) {
+	dsn := newTestDSN(t)
+	db, err := walletdb.Create(dbType, dsn, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Failed to create test database (%s) %v", dbType, err)
+	}
+
+	// Create a namespace and put some values into it so they can be tested
+	// for existence on re-open.
+	storeValues := map[string]string{
+		"ns1key1": "foo1",
+		"ns1key2": "foo2",
+		"ns1key3": "foo3",
+	}
+	ns1Key := []byte("ns1")
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns1, err := tx.CreateTopLevelBucket(ns1Key)
+		if err != nil {
+			return err
+		}
+
+		for k, v := range storeValues {
+			if err := ns1.Put([]byte(k), []byte(v)); err != nil {
+				return fmt.Errorf("Put: unexpected error: %w",
+					err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ns1 Update: unexpected error: %v", err)
+	}
+
+	// Close and reopen the database to ensure the values persist.
+	db.Close()
+	db, err = walletdb.Open(dbType, dsn, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Failed to open test database (%s) %v", dbType, err)
+	}
+	defer db.Close()
+
+	// Ensure the values previously stored in the namespace still exist
+	// and are correct.
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns1 := tx.ReadBucket(ns1Key)
+		if ns1 == nil {
+			return fmt.Errorf("ReadTx.ReadBucket: unexpected nil "+
+				"bucket %s", ns1Key)
+		}
+
+		for k, v := range storeValues {
+			gotVal := ns1.Get([]byte(k))
+			if !reflect.DeepEqual(gotVal, []byte(v)) {
+				return fmt.Errorf("Get: key '%s' does not "+
+					"match expected value - got %s, want %s",
+					k, gotVal, v)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ns1 View: unexpected error: %v", err)
+	}
+}
+
+// TestSerializationRetry ensures that of two transactions incrementing the
+// same counter concurrently, the one failing to serialize is retried after
+// calling its reset function, so that no increment is lost.
+func TestSerializationRetry(t *testing.T) {
+	db, err := walletdb.Create(dbType, newTestDSN(t), defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Failed to create test database (%s) %v", dbType, err)
+	}
+	defer db.Close()
+
+	bucketKey, counterKey := []byte("bucket"), []byte("counter")
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		b, err := tx.CreateTopLevelBucket(bucketKey)
+		if err != nil {
+			return err
+		}
+		return b.Put(counterKey, make([]byte, 8))
+	})
+	if err != nil {
+		t.Fatalf("Update: unexpected error: %v", err)
+	}
+
+	// The first attempts of both transactions read the counter before
+	// either writes it, so one of them can't be serialized.
+	var (
+		read    sync.WaitGroup
+		wg      sync.WaitGroup
+		resets  [2]int
+		results [2]error
+	)
+	read.Add(2)
+	for i := range resets {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = db.Update(func(tx walletdb.ReadWriteTx) error {
+				b := tx.ReadWriteBucket(bucketKey)
+				v := b.Get(counterKey)
+				counter := binary.BigEndian.Uint64(v)
+				if resets[i] == 1 {
+					read.Done()
+					read.Wait()
+				}
+
+				v = make([]byte, 8)
+				binary.BigEndian.PutUint64(v, counter+1)
+				return b.Put(counterKey, v)
+			}, func() {
+				resets[i]++
+			})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range results {
+		if err != nil {
+			t.Fatalf("Update %d: unexpected error: %v", i, err)
+		}
+	}
+	if resets[0]+resets[1] < 3 {
+		t.Fatalf("got %v resets, want a retry", resets)
+	}
+
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		v := tx.ReadBucket(bucketKey).Get(counterKey)
+		if counter := binary.BigEndian.Uint64(v); counter != 2 {
+			t.Errorf("got counter %d, want 2", counter)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("View: unexpected error: %v", err)
+	}
+}
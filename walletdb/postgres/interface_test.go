@@ -0,0 +1,12 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcwallet/walletdb/walletdbtest"
+)
+
+// TestInterface performs all interfaces tests for this database driver.
+func TestInterface(t *testing.T) {
+	walletdbtest.TestInterface(t, dbType, newTestDSN(t), defaultDBTimeout)
+}
@@ -0,0 +1,144 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	// dbType is the database type name for this driver.
+	dbType = "postgres"
+
+	// defaultDBTimeout is the value of db timeout for testing.
+	defaultDBTimeout = 10 * time.Second
+
+	// dsnEnv is the environment variable of the connection string of a
+	// database the tests are run against, instead of an embedded
+	// PostgreSQL server.
+	dsnEnv = "WALLETDB_POSTGRES_DSN"
+)
+
+var (
+	// testDSN is the connection string of the database of the tests.
+	testDSN string
+
+	// skipReason is the reason the tests requiring a database are skipped,
+	// if there is no database to test against.
+	skipReason string
+
+	// schemaCount is the number of schemas created by newTestDSN.
+	schemaCount atomic.Int32
+)
+
+// TestMain runs the tests against the database of the dsnEnv environment
+// variable if set, or an embedded PostgreSQL server otherwise.  The binaries
+// of the embedded server are downloaded on the first run.
+func TestMain(m *testing.M) {
+	testDSN = os.Getenv(dsnEnv)
+	if testDSN != "" {
+		os.Exit(m.Run())
+	}
+
+	// PostgreSQL refuses to run as root.
+	if os.Geteuid() == 0 {
+		skipReason = fmt.Sprintf("unable to run an embedded PostgreSQL "+
+			"server as root, set %s to test against a database",
+			dsnEnv)
+		os.Exit(m.Run())
+	}
+
+	os.Exit(runEmbedded(m))
+}
+
+// runEmbedded runs the tests against an embedded PostgreSQL server.
+func runEmbedded(m *testing.M) int {
+	tempDir, err := os.MkdirTemp("", "postgrestest")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create temp dir: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(tempDir)
+
+	port, err := freePort()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to find a free port: %v\n", err)
+		return 1
+	}
+
+	cfg := embeddedpostgres.DefaultConfig().
+		Port(port).
+		RuntimePath(filepath.Join(tempDir, "runtime")).
+		DataPath(filepath.Join(tempDir, "data")).
+		Logger(io.Discard)
+	server := embeddedpostgres.NewDatabase(cfg)
+	if err := server.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to start PostgreSQL: %v\n", err)
+		return 1
+	}
+	defer server.Stop()
+
+	testDSN = cfg.GetConnectionURL() + "?sslmode=disable"
+	return m.Run()
+}
+
+// freePort returns a TCP port that is free to listen on.
+func freePort() (uint32, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return uint32(l.Addr().(*net.TCPAddr).Port), nil
+}
+
+// newTestDSN returns the connection string of a new empty schema of the test
+// database, so each test creates its own walletdb table.
+func newTestDSN(t *testing.T) string {
+	t.Helper()
+
+	if skipReason != "" {
+		t.Skip(skipReason)
+	}
+
+	u, err := url.Parse(testDSN)
+	if err != nil {
+		t.Fatalf("%s must be a URL: %v", dsnEnv, err)
+	}
+
+	db, err := sql.Open("pgx", testDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	schema := fmt.Sprintf("walletdb_test_%d_%d", os.Getpid(),
+		schemaCount.Add(1))
+	if _, err := db.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("unable to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		db, err := sql.Open("pgx", testDSN)
+		if err != nil {
+			return
+		}
+		defer db.Close()
+		_, _ = db.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
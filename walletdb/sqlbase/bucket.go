@@ -0,0 +1,438 @@
+package sqlbase
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+const (
+	// maxKeySize is the maximum length of a key, as for the bbolt driver.
+	maxKeySize = 32768
+
+	// maxValueSize is the maximum length of a value, as for the bbolt
+	// driver.
+	maxValueSize = (1 << 31) - 2
+
+	// forEachBatchSize is the number of rows of a bucket read by each query
+	// of ForEach.  The rows are read in batches so that the function
+	// called for each row may query the transaction, which some databases
+	// don't allow while the rows of another query are being read.
+	forEachBatchSize = 100
+)
+
+// row is a key/value pair or a nested bucket of a bucket.
+type row struct {
+	key    []byte
+	value  []byte
+	bucket bool
+}
+
+// bucket is an internal type used to represent a collection of key/value pairs
+// and implements the walletdb Bucket interfaces.
+type bucket struct {
+	tx *transaction
+	id int64
+}
+
+// Enforce bucket implements the walletdb Bucket interfaces.
+var _ walletdb.ReadWriteBucket = (*bucket)(nil)
+
+// lookup returns the identifier and value of the row of the key, and whether
+// the row is a bucket.  A nil row is returned if the key doesn't exist.
+func (b *bucket) lookup(key []byte) (*row, int64, error) {
+	if b.tx.err != nil {
+		return nil, 0, b.tx.err
+	}
+
+	var (
+		r  = row{key: key}
+		id int64
+	)
+	err := b.tx.sqlTx.QueryRow(b.tx.db.queries.get, b.id, key).Scan(
+		&id, &r.value, &r.bucket,
+	)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, 0, nil
+	case err != nil:
+		return nil, 0, b.tx.fail(convertErr(err))
+	}
+	if !r.bucket && r.value == nil {
+		r.value = []byte{}
+	}
+	return &r, id, nil
+}
+
+// query returns the rows of the bucket selected by the query, whose first
+// argument is the identifier of the bucket.
+func (b *bucket) query(query string, args ...interface{}) ([]row, error) {
+	if b.tx.err != nil {
+		return nil, b.tx.err
+	}
+
+	rows, err := b.tx.sqlTx.Query(query, append([]interface{}{b.id},
+		args...)...)
+	if err != nil {
+		return nil, b.tx.fail(convertErr(err))
+	}
+	defer rows.Close()
+
+	var result []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.key, &r.value, &r.bucket); err != nil {
+			return nil, b.tx.fail(convertErr(err))
+		}
+		if !r.bucket && r.value == nil {
+			r.value = []byte{}
+		}
+		result = append(result, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, b.tx.fail(convertErr(err))
+	}
+	return result, nil
+}
+
+// queryRow returns the first row of the bucket selected by the query, or nil
+// if there is none.
+func (b *bucket) queryRow(query string, args ...interface{}) *row {
+	rows, err := b.query(query, args...)
+	if err != nil || len(rows) == 0 {
+		return nil
+	}
+	return &rows[0]
+}
+
+// NestedReadWriteBucket retrieves a nested bucket with the given key.  Returns
+// nil if the bucket does not exist.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) NestedReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
+	if len(key) == 0 {
+		return nil
+	}
+
+	r, id, err := b.lookup(key)
+	if err != nil || r == nil || !r.bucket {
+		return nil
+	}
+	return &bucket{tx: b.tx, id: id}
+}
+
+// NestedReadBucket retrieves a nested bucket with the given key.  Returns nil
+// if the bucket does not exist.
+//
+// This function is part of the walletdb.ReadBucket interface implementation.
+func (b *bucket) NestedReadBucket(key []byte) walletdb.ReadBucket {
+	return b.NestedReadWriteBucket(key)
+}
+
+// createBucket creates a nested bucket with the given key.  The existing
+// bucket is returned if there is one and it may exist.
+func (b *bucket) createBucket(key []byte,
+	mayExist bool) (walletdb.ReadWriteBucket, error) {
+
+	if err := b.tx.checkWritable(); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(key) == 0:
+		return nil, walletdb.ErrBucketNameRequired
+	case len(key) > maxKeySize:
+		return nil, walletdb.ErrKeyTooLarge
+	}
+
+	r, id, err := b.lookup(key)
+	switch {
+	case err != nil:
+		return nil, err
+	case r != nil && !r.bucket:
+		return nil, walletdb.ErrIncompatibleValue
+	case r != nil && !mayExist:
+		return nil, walletdb.ErrBucketExists
+	case r != nil:
+		return &bucket{tx: b.tx, id: id}, nil
+	}
+
+	err = b.tx.sqlTx.QueryRow(b.tx.db.queries.insert, b.id, key).Scan(&id)
+	if err != nil {
+		return nil, b.tx.fail(convertErr(err))
+	}
+	return &bucket{tx: b.tx, id: id}, nil
+}
+
+// CreateBucket creates and returns a new nested bucket with the given key.
+// Returns ErrBucketExists if the bucket already exists, ErrBucketNameRequired
+// if the key is empty, or ErrIncompatibleValue if the key is the key of a
+// value.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) CreateBucket(key []byte) (walletdb.ReadWriteBucket, error) {
+	return b.createBucket(key, false)
+}
+
+// CreateBucketIfNotExists creates and returns a new nested bucket with the
+// given key if it does not already exist.  Returns ErrBucketNameRequired if the
+// key is empty or ErrIncompatibleValue if the key is the key of a value.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) CreateBucketIfNotExists(
+	key []byte) (walletdb.ReadWriteBucket, error) {
+
+	return b.createBucket(key, true)
+}
+
+// DeleteNestedBucket removes a nested bucket with the given key, and all the
+// buckets and key/value pairs it contains.  Returns ErrTxNotWritable if
+// attempted against a read-only transaction and ErrBucketNotFound if the
+// specified bucket does not exist.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) DeleteNestedBucket(key []byte) error {
+	if err := b.tx.checkWritable(); err != nil {
+		return err
+	}
+
+	// As with the bbolt driver, an empty key is the key of a value rather
+	// than of a bucket.
+	if len(key) == 0 {
+		return walletdb.ErrIncompatibleValue
+	}
+
+	r, id, err := b.lookup(key)
+	switch {
+	case err != nil:
+		return err
+	case r == nil:
+		return walletdb.ErrBucketNotFound
+	case !r.bucket:
+		return walletdb.ErrIncompatibleValue
+	}
+
+	_, err = b.tx.sqlTx.Exec(b.tx.db.queries.deleteBucket, id)
+	if err != nil {
+		return b.tx.fail(convertErr(err))
+	}
+	return nil
+}
+
+// ForEach invokes the passed function with every key/value pair in the bucket.
+// This includes nested buckets, in which case the value is nil, but it does not
+// include the key/value pairs within those nested buckets.
+//
+// This function is part of the walletdb.ReadBucket interface implementation.
+func (b *bucket) ForEach(fn func(k, v []byte) error) error {
+	rows, err := b.query(b.tx.db.queries.first, forEachBatchSize)
+	for err == nil && len(rows) != 0 {
+		for _, r := range rows {
+			if err := fn(r.key, r.value); err != nil {
+				return err
+			}
+		}
+		if len(rows) < forEachBatchSize {
+			break
+		}
+
+		last := rows[len(rows)-1].key
+		rows, err = b.query(b.tx.db.queries.next, last,
+			forEachBatchSize)
+	}
+	return err
+}
+
+// Put saves the specified key/value pair to the bucket.  Keys that do not
+// already exist are added and keys that already exist are overwritten.  Returns
+// ErrTxNotWritable if attempted against a read-only transaction, and
+// ErrIncompatibleValue if the key is the key of a nested bucket.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) Put(key, value []byte) error {
+	if err := b.tx.checkWritable(); err != nil {
+		return err
+	}
+	switch {
+	case len(key) == 0:
+		return walletdb.ErrKeyRequired
+	case len(key) > maxKeySize:
+		return walletdb.ErrKeyTooLarge
+	case len(value) > maxValueSize:
+		return walletdb.ErrValueTooLarge
+	}
+
+	// A NULL value is a bucket, so a nil value is stored as an empty one.
+	if value == nil {
+		value = []byte{}
+	}
+
+	// The upsert doesn't update the row of a nested bucket.
+	res, err := b.tx.sqlTx.Exec(b.tx.db.queries.upsert, b.id, key, value)
+	if err != nil {
+		return b.tx.fail(convertErr(err))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return b.tx.fail(convertErr(err))
+	}
+	if n == 0 {
+		return walletdb.ErrIncompatibleValue
+	}
+	return nil
+}
+
+// Get returns the value for the given key.  Returns nil if the key does not
+// exist in this bucket, or is the key of a nested bucket.
+//
+// This function is part of the walletdb.ReadBucket interface implementation.
+func (b *bucket) Get(key []byte) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+
+	r, _, err := b.lookup(key)
+	if err != nil || r == nil {
+		return nil
+	}
+	return r.value
+}
+
+// Delete removes the specified key from the bucket.  Deleting a key that does
+// not exist does not return an error.  Returns ErrTxNotWritable if attempted
+// against a read-only transaction, and ErrIncompatibleValue if the key is the
+// key of a nested bucket.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) Delete(key []byte) error {
+	if err := b.tx.checkWritable(); err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		return nil
+	}
+
+	return b.delete(key)
+}
+
+// delete deletes the key/value pair of the key, if any.
+func (b *bucket) delete(key []byte) error {
+	res, err := b.tx.sqlTx.Exec(b.tx.db.queries.delete, b.id, key)
+	if err != nil {
+		return b.tx.fail(convertErr(err))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return b.tx.fail(convertErr(err))
+	}
+	if n != 0 {
+		return nil
+	}
+
+	// Nothing was deleted, because the key doesn't exist or is the key of
+	// a nested bucket.
+	r, _, err := b.lookup(key)
+	switch {
+	case err != nil:
+		return err
+	case r != nil && r.bucket:
+		return walletdb.ErrIncompatibleValue
+	}
+	return nil
+}
+
+// ReadCursor returns a new cursor, allowing for iteration over the bucket's
+// key/value pairs and nested buckets in forward or backward order.
+//
+// This function is part of the walletdb.ReadBucket interface implementation.
+func (b *bucket) ReadCursor() walletdb.ReadCursor {
+	return b.ReadWriteCursor()
+}
+
+// ReadWriteCursor returns a new cursor, allowing for iteration over the
+// bucket's key/value pairs and nested buckets in forward or backward order.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) ReadWriteCursor() walletdb.ReadWriteCursor {
+	return &cursor{bucket: b}
+}
+
+// Tx returns the bucket's transaction.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) Tx() walletdb.ReadWriteTx {
+	return b.tx
+}
+
+// NextSequence returns an autoincrementing integer for the bucket.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) NextSequence() (uint64, error) {
+	if err := b.tx.checkWritable(); err != nil {
+		return 0, err
+	}
+
+	var seq int64
+	err := b.tx.sqlTx.QueryRow(b.tx.db.queries.nextSequence, b.id).Scan(
+		&seq,
+	)
+	if err != nil {
+		return 0, b.tx.fail(convertErr(err))
+	}
+	return uint64(seq), nil
+}
+
+// SetSequence updates the sequence number for the bucket.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) SetSequence(v uint64) error {
+	if err := b.tx.checkWritable(); err != nil {
+		return err
+	}
+
+	_, err := b.tx.sqlTx.Exec(b.tx.db.queries.setSequence, b.id, int64(v))
+	if err != nil {
+		return b.tx.fail(convertErr(err))
+	}
+	return nil
+}
+
+// Sequence returns the current integer for the bucket without incrementing it.
+//
+// This function is part of the walletdb.ReadWriteBucket interface
+// implementation.
+func (b *bucket) Sequence() uint64 {
+	if b.tx.err != nil {
+		return 0
+	}
+
+	var seq int64
+	err := b.tx.sqlTx.QueryRow(b.tx.db.queries.sequence, b.id).Scan(&seq)
+	if err != nil {
+		_ = b.tx.fail(convertErr(err))
+		return 0
+	}
+	return uint64(seq)
+}
+
+// convertErr converts the errors of database/sql to the equivalent walletdb
+// error.
+func convertErr(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrTxDone):
+		return walletdb.ErrTxClosed
+	case errors.Is(err, sql.ErrConnDone):
+		return walletdb.ErrDbNotOpen
+	}
+	return err
+}
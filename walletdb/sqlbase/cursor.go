@@ -0,0 +1,102 @@
+package sqlbase
+
+import (
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// cursor represents a cursor over key/value pairs and nested buckets of a
+// bucket.  Each move of the cursor queries the row after or before the key it
+// is positioned at, so the cursor remains valid when the bucket is modified.
+type cursor struct {
+	bucket *bucket
+
+	// key is the key the cursor is positioned at, which is the key of the
+	// last pair returned, or the key sought if there is no pair after it.
+	key        []byte
+	positioned bool
+}
+
+// Enforce cursor implements the walletdb.ReadWriteCursor interface.
+var _ walletdb.ReadWriteCursor = (*cursor)(nil)
+
+// move positions the cursor at the row, if any, and returns its pair.  The
+// cursor isn't moved if there is no row.
+func (c *cursor) move(r *row) ([]byte, []byte) {
+	if r == nil {
+		return nil, nil
+	}
+
+	c.key = r.key
+	c.positioned = true
+	return r.key, r.value
+}
+
+// First positions the cursor at the first key/value pair and returns the pair.
+//
+// This function is part of the walletdb.ReadCursor interface implementation.
+func (c *cursor) First() (key, value []byte) {
+	return c.move(c.bucket.queryRow(c.bucket.tx.db.queries.first, 1))
+}
+
+// Last positions the cursor at the last key/value pair and returns the pair.
+//
+// This function is part of the walletdb.ReadCursor interface implementation.
+func (c *cursor) Last() (key, value []byte) {
+	return c.move(c.bucket.queryRow(c.bucket.tx.db.queries.last))
+}
+
+// Next moves the cursor one key/value pair forward and returns the new pair.
+// The cursor is positioned at the first pair if it wasn't positioned yet.
+//
+// This function is part of the walletdb.ReadCursor interface implementation.
+func (c *cursor) Next() (key, value []byte) {
+	if !c.positioned {
+		return c.First()
+	}
+	return c.move(c.bucket.queryRow(c.bucket.tx.db.queries.next, c.key, 1))
+}
+
+// Prev moves the cursor one key/value pair backward and returns the new pair.
+// The cursor is positioned at the last pair if it wasn't positioned yet.
+//
+// This function is part of the walletdb.ReadCursor interface implementation.
+func (c *cursor) Prev() (key, value []byte) {
+	if !c.positioned {
+		return c.Last()
+	}
+	return c.move(c.bucket.queryRow(c.bucket.tx.db.queries.prev, c.key))
+}
+
+// Seek positions the cursor at the passed seek key.  If the key does not
+// exist, the cursor is moved to the next key after seek.  Returns the new pair.
+//
+// This function is part of the walletdb.ReadCursor interface implementation.
+func (c *cursor) Seek(seek []byte) (key, value []byte) {
+	r := c.bucket.queryRow(c.bucket.tx.db.queries.seek, seek)
+	if r == nil {
+		// Position the cursor after the last pair before the key, so
+		// Prev returns that pair.
+		c.key = append([]byte{}, seek...)
+		c.positioned = true
+		return nil, nil
+	}
+	return c.move(r)
+}
+
+// Delete removes the current key/value pair the cursor is at without
+// invalidating the cursor.  Returns ErrTxNotWritable if attempted on a
+// read-only transaction, or ErrIncompatibleValue if attempted when the cursor
+// points to a nested bucket.
+//
+// This function is part of the walletdb.ReadWriteCursor interface
+// implementation.
+func (c *cursor) Delete() error {
+	if err := c.bucket.tx.checkWritable(); err != nil {
+		return err
+	}
+	if !c.positioned || len(c.key) == 0 {
+		return nil
+	}
+
+	return c.bucket.delete(c.key)
+}
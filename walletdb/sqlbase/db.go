@@ -0,0 +1,330 @@
+package sqlbase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a transaction is retried
+	// after a retryable error, unless configured otherwise.
+	DefaultMaxRetries = 10
+
+	// retryBackoff is the delay before the first retry of a transaction.
+	// The delay doubles with each retry, up to maxRetryBackoff.
+	retryBackoff = 10 * time.Millisecond
+
+	// maxRetryBackoff is the maximum delay before a retry.
+	maxRetryBackoff = time.Second
+)
+
+// validTable matches the names of tables that may be interpolated in queries.
+var validTable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config configures a DB.
+type Config struct {
+	// DB is the connection pool of the read-write transactions.
+	DB *sql.DB
+
+	// ReadDB is the connection pool of the read-only transactions, or DB
+	// if nil.
+	ReadDB *sql.DB
+
+	// TxOptions are the options of the read-write transactions.  The
+	// read-only transactions use the same isolation level.
+	TxOptions sql.TxOptions
+
+	// Table is the name of the table of the buckets and key/value pairs.
+	Table string
+
+	// Schema are the statements creating the table, and its indexes, if
+	// they do not exist.  The %[1]s verb is replaced by the name of the
+	// table.
+	Schema []string
+
+	// IsRetryable reports whether a transaction that failed with the error
+	// may succeed if retried, such as after a serialization failure.  If
+	// nil, transactions are not retried.
+	IsRetryable func(error) bool
+
+	// MaxRetries is the number of times a transaction is retried, or
+	// DefaultMaxRetries if zero.
+	MaxRetries int
+}
+
+// queries are the statements of a DB, with the name of its table.
+type queries struct {
+	get          string
+	insert       string
+	upsert       string
+	delete       string
+	deleteBucket string
+	first        string
+	last         string
+	next         string
+	prev         string
+	seek         string
+	sequence     string
+	nextSequence string
+	setSequence  string
+}
+
+// newQueries returns the statements of the table.
+func newQueries(table string) *queries {
+	f := func(query string) string {
+		return fmt.Sprintf(query, table)
+	}
+	const cols = "key, value, value IS NULL"
+	return &queries{
+		get: f("SELECT id, value, value IS NULL FROM %s " +
+			"WHERE parent_id = $1 AND key = $2"),
+		insert: f("INSERT INTO %s (parent_id, key, value) " +
+			"VALUES ($1, $2, NULL) RETURNING id"),
+		upsert: f("INSERT INTO %[1]s (parent_id, key, value) " +
+			"VALUES ($1, $2, $3) ON CONFLICT (parent_id, key) " +
+			"DO UPDATE SET value = excluded.value " +
+			"WHERE %[1]s.value IS NOT NULL"),
+		delete: f("DELETE FROM %s WHERE parent_id = $1 AND key = $2 " +
+			"AND value IS NOT NULL"),
+		deleteBucket: f("DELETE FROM %s WHERE id = $1"),
+		first: f("SELECT " + cols + " FROM %s WHERE parent_id = $1 " +
+			"ORDER BY key LIMIT $2"),
+		last: f("SELECT " + cols + " FROM %s WHERE parent_id = $1 " +
+			"ORDER BY key DESC LIMIT 1"),
+		next: f("SELECT " + cols + " FROM %s WHERE parent_id = $1 " +
+			"AND key > $2 ORDER BY key LIMIT $3"),
+		prev: f("SELECT " + cols + " FROM %s WHERE parent_id = $1 " +
+			"AND key < $2 ORDER BY key DESC LIMIT 1"),
+		seek: f("SELECT " + cols + " FROM %s WHERE parent_id = $1 " +
+			"AND key >= $2 ORDER BY key LIMIT 1"),
+		sequence: f("SELECT sequence FROM %s WHERE id = $1"),
+		nextSequence: f("UPDATE %s SET sequence = sequence + 1 " +
+			"WHERE id = $1 RETURNING sequence"),
+		setSequence: f("UPDATE %s SET sequence = $2 WHERE id = $1"),
+	}
+}
+
+// DB is a walletdb.DB backed by a SQL database.  Drivers may embed it to
+// implement the methods that depend on the database, such as Copy.
+type DB struct {
+	db          *sql.DB
+	readDB      *sql.DB
+	txOptions   sql.TxOptions
+	queries     *queries
+	isRetryable func(error) bool
+	maxRetries  int
+}
+
+// Enforce DB implements the walletdb.DB interface.
+var _ walletdb.DB = (*DB)(nil)
+
+// NewDB returns the DB of the configured connections, creating its table and
+// root row if they do not exist.
+func NewDB(cfg *Config) (*DB, error) {
+	if !validTable.MatchString(cfg.Table) {
+		return nil, fmt.Errorf("invalid table name %q", cfg.Table)
+	}
+
+	db := &DB{
+		db:          cfg.DB,
+		readDB:      cfg.ReadDB,
+		txOptions:   cfg.TxOptions,
+		queries:     newQueries(cfg.Table),
+		isRetryable: cfg.IsRetryable,
+		maxRetries:  cfg.MaxRetries,
+	}
+	if db.readDB == nil {
+		db.readDB = db.db
+	}
+	if db.isRetryable == nil {
+		db.isRetryable = func(error) bool { return false }
+	}
+	if db.maxRetries == 0 {
+		db.maxRetries = DefaultMaxRetries
+	}
+
+	err := db.execute(true, func(tx *transaction) error {
+		for _, stmt := range cfg.Schema {
+			_, err := tx.sqlTx.Exec(fmt.Sprintf(stmt, cfg.Table))
+			if err != nil {
+				return err
+			}
+		}
+		_, err := tx.sqlTx.Exec(fmt.Sprintf("INSERT INTO %s "+
+			"(id, parent_id, key, value) VALUES (0, NULL, $1, "+
+			"NULL) ON CONFLICT DO NOTHING", cfg.Table), []byte{})
+		return err
+	}, func() {})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create table %s: %w",
+			cfg.Table, err)
+	}
+
+	return db, nil
+}
+
+// beginTx starts a transaction.
+func (db *DB) beginTx(writable bool) (*transaction, error) {
+	pool := db.readDB
+	opts := db.txOptions
+	if writable {
+		pool = db.db
+	} else {
+		opts.ReadOnly = true
+	}
+
+	sqlTx, err := pool.BeginTx(context.Background(), &opts)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{
+		db:       db,
+		sqlTx:    sqlTx,
+		writable: writable,
+	}, nil
+}
+
+// BeginReadTx opens a database read transaction.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) BeginReadTx() (walletdb.ReadTx, error) {
+	return db.beginTx(false)
+}
+
+// BeginReadWriteTx opens a database read+write transaction.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
+	return db.beginTx(true)
+}
+
+// Copy is not supported by the SQL databases without the help of their
+// driver, which must implement it.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) Copy(w io.Writer) error {
+	return errors.New("copy not supported by the database")
+}
+
+// Close closes the connections to the database.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) Close() error {
+	err := db.db.Close()
+	if db.readDB != db.db {
+		if readErr := db.readDB.Close(); err == nil {
+			err = readErr
+		}
+	}
+	return err
+}
+
+// PrintStats returns the statistics of the connection pools.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) PrintStats() string {
+	stats := db.db.Stats()
+	s := fmt.Sprintf("read-write connections: %d open, %d in use, "+
+		"%d waits (%v)", stats.OpenConnections, stats.InUse,
+		stats.WaitCount, stats.WaitDuration)
+	if db.readDB != db.db {
+		stats = db.readDB.Stats()
+		s += fmt.Sprintf("; read connections: %d open, %d in use",
+			stats.OpenConnections, stats.InUse)
+	}
+	return s
+}
+
+// View opens a database read transaction and executes the function f with the
+// transaction passed as a parameter.  After f exits, the transaction is rolled
+// back.  If f errors, its error is returned, not a rollback error (if any
+// occur).  The transaction is retried after a retryable error, in which case
+// the reset function is called before each retry, as it is before the first
+// attempt.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) View(f func(tx walletdb.ReadTx) error, reset func()) error {
+	return db.execute(false, func(tx *transaction) error {
+		return f(tx)
+	}, reset)
+}
+
+// Update opens a database read/write transaction and executes the function f
+// with the transaction passed as a parameter.  After f exits, if f did not
+// error, the transaction is committed.  Otherwise, if f did error, the
+// transaction is rolled back.  If the rollback fails, the original error
+// returned by f is still returned.  If the commit fails, the commit error is
+// returned.  The transaction is retried after a retryable error, in which case
+// the reset function is called before each retry, as it is before the first
+// attempt.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *DB) Update(f func(tx walletdb.ReadWriteTx) error,
+	reset func()) error {
+
+	return db.execute(true, func(tx *transaction) error {
+		return f(tx)
+	}, reset)
+}
+
+// execute runs f in a transaction until it succeeds, fails with an error that
+// isn't retryable, or the retries are exhausted.
+func (db *DB) execute(writable bool, f func(tx *transaction) error,
+	reset func()) error {
+
+	backoff := retryBackoff
+	for retry := 0; ; retry++ {
+		reset()
+
+		err := db.executeOnce(writable, f)
+		if err == nil || retry == db.maxRetries || !db.isRetryable(err) {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// executeOnce runs f in a transaction, which is committed if writable and f
+// succeeds, and rolled back otherwise.
+func (db *DB) executeOnce(writable bool, f func(tx *transaction) error) error {
+	tx, err := db.beginTx(writable)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the transaction rolls back in the event of a panic.
+	defer func() {
+		if !tx.closed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	err = f(tx)
+
+	// A failed query of the transaction is the cause of the failure of f,
+	// if any, which may have acted on its missing result.
+	if tx.err != nil {
+		err = tx.err
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if !writable {
+		return tx.Rollback()
+	}
+	return tx.Commit()
+}
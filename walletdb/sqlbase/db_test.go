@@ -0,0 +1,122 @@
+package sqlbase
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "modernc.org/sqlite"
+)
+
+// errConflict is the retryable error of the test transactions.
+var errConflict = errors.New("conflict")
+
+// newTestDB returns a DB in a SQLite database file of the test.
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "db.sqlite") +
+		"?_pragma=foreign_keys(1)"
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	db, err := NewDB(&Config{
+		DB:    sqlDB,
+		Table: "walletdb",
+		Schema: []string{`CREATE TABLE IF NOT EXISTS %[1]s (
+			id INTEGER PRIMARY KEY,
+			parent_id INTEGER REFERENCES %[1]s (id)
+				ON DELETE CASCADE,
+			key BLOB NOT NULL,
+			value BLOB,
+			sequence INTEGER NOT NULL DEFAULT 0,
+			UNIQUE (parent_id, key)
+		)`},
+		IsRetryable: func(err error) bool {
+			return errors.Is(err, errConflict)
+		},
+		MaxRetries: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// TestUpdateRetry ensures that a transaction failing with a retryable error is
+// rolled back and retried, with reset called before each attempt, and that
+// the retries stop after the configured maximum.
+func TestUpdateRetry(t *testing.T) {
+	db := newTestDB(t)
+
+	var attempts, resets int
+	err := db.Update(func(tx walletdb.ReadWriteTx) error {
+		attempts++
+		b, err := tx.CreateTopLevelBucket([]byte("bucket"))
+		if err != nil {
+			return err
+		}
+		err = b.Put([]byte("attempts"), []byte{byte(attempts)})
+		if err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return errConflict
+		}
+		return nil
+	}, func() {
+		resets++
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if attempts != 3 || resets != 3 {
+		t.Fatalf("got %d attempts and %d resets, want 3 and 3",
+			attempts, resets)
+	}
+
+	// Only the writes of the committed attempt are stored.
+	err = db.View(func(tx walletdb.ReadTx) error {
+		v := tx.ReadBucket([]byte("bucket")).Get([]byte("attempts"))
+		if len(v) != 1 || v[0] != 3 {
+			t.Errorf("got value %x, want 03", v)
+		}
+		return nil
+	}, func() {})
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	// The error of the last attempt is returned when the retries are
+	// exhausted.
+	attempts = 0
+	err = db.View(func(tx walletdb.ReadTx) error {
+		attempts++
+		return errConflict
+	}, func() {})
+	if !errors.Is(err, errConflict) {
+		t.Fatalf("View: got error %v, want %v", err, errConflict)
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", attempts)
+	}
+
+	// Errors that aren't retryable are returned without retrying.
+	attempts = 0
+	errFatal := errors.New("fatal")
+	err = db.Update(func(tx walletdb.ReadWriteTx) error {
+		attempts++
+		return errFatal
+	}, func() {})
+	if err != errFatal || attempts != 1 {
+		t.Fatalf("Update: got error %v after %d attempts, want %v "+
+			"after 1", err, attempts, errFatal)
+	}
+}
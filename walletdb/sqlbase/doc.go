@@ -0,0 +1,23 @@
+/*
+Package sqlbase implements walletdb on top of a SQL database, and is shared by
+the drivers of SQL databases, the sqlite and postgres drivers.
+
+Buckets and key/value pairs are rows of a single table:
+
+	id        the identifier of the row
+	parent_id the identifier of the bucket of the row
+	key       the key of the row in its bucket
+	value     the value of a key/value pair, or NULL for a bucket
+	sequence  the sequence of a bucket
+
+The top level buckets are the children of a root row with the identifier 0,
+which is not itself a bucket of the database.  Deleting a bucket deletes its
+rows with the ON DELETE CASCADE constraint of parent_id.  Keys are compared
+byte-wise, as by the bbolt driver, so cursors iterate over the rows of a bucket
+in the same order.
+
+Drivers provide the connections, the statements creating the table and the
+errors after which a transaction is retried.  Queries use the $1, $2, ...
+placeholders, which must be supported by the database/sql driver.
+*/
+package sqlbase
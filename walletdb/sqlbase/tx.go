@@ -0,0 +1,148 @@
+package sqlbase
+
+import (
+	"database/sql"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+// rootID is the identifier of the row whose children are the top level
+// buckets.
+const rootID = 0
+
+// transaction represents a database transaction.  It can either be read-only
+// or read-write and implements the walletdb Tx interfaces.
+//
+// The first query of the transaction that fails is its error, which is
+// returned by Commit, since the methods of buckets that only read do not
+// return errors.
+type transaction struct {
+	db       *DB
+	sqlTx    *sql.Tx
+	writable bool
+	closed   bool
+	err      error
+	onCommit []func()
+}
+
+// Enforce transaction implements the walletdb.ReadWriteTx interface.
+var _ walletdb.ReadWriteTx = (*transaction)(nil)
+
+// fail records the error of a query as the error of the transaction, unless
+// one was already recorded, and returns it.
+func (tx *transaction) fail(err error) error {
+	if tx.err == nil {
+		tx.err = err
+	}
+	return err
+}
+
+// checkWritable returns the error of a write to the transaction, if any.
+func (tx *transaction) checkWritable() error {
+	switch {
+	case tx.closed:
+		return walletdb.ErrTxClosed
+	case !tx.writable:
+		return walletdb.ErrTxNotWritable
+	}
+	return tx.err
+}
+
+// root returns the root bucket of the transaction, whose nested buckets are
+// the top level buckets.
+func (tx *transaction) root() *bucket {
+	return &bucket{tx: tx, id: rootID}
+}
+
+// ReadBucket opens the root bucket for read only access.  If the bucket
+// described by the key does not exist, nil is returned.
+//
+// This function is part of the walletdb.ReadTx interface implementation.
+func (tx *transaction) ReadBucket(key []byte) walletdb.ReadBucket {
+	return tx.ReadWriteBucket(key)
+}
+
+// ForEachBucket will iterate through all top level buckets.
+//
+// This function is part of the walletdb.ReadTx interface implementation.
+func (tx *transaction) ForEachBucket(fn func(key []byte) error) error {
+	return tx.root().ForEach(func(k, _ []byte) error {
+		return fn(k)
+	})
+}
+
+// ReadWriteBucket opens the root bucket for read/write access.  If the bucket
+// described by the key does not exist, nil is returned.
+//
+// This function is part of the walletdb.ReadWriteTx interface implementation.
+func (tx *transaction) ReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
+	return tx.root().NestedReadWriteBucket(key)
+}
+
+// CreateTopLevelBucket creates the top level bucket for a key if it does not
+// exist.  The newly-created bucket it returned.
+//
+// This function is part of the walletdb.ReadWriteTx interface implementation.
+func (tx *transaction) CreateTopLevelBucket(
+	key []byte) (walletdb.ReadWriteBucket, error) {
+
+	return tx.root().CreateBucketIfNotExists(key)
+}
+
+// DeleteTopLevelBucket deletes the top level bucket for a key.  This errors if
+// the bucket can not be found or the key keys a single value instead of a
+// bucket.
+//
+// This function is part of the walletdb.ReadWriteTx interface implementation.
+func (tx *transaction) DeleteTopLevelBucket(key []byte) error {
+	return tx.root().DeleteNestedBucket(key)
+}
+
+// Commit commits all changes that have been made through the root bucket and
+// all of its sub-buckets to persistent storage.  The transaction is rolled
+// back instead if one of its queries failed, and the error of the query is
+// returned.
+//
+// This function is part of the walletdb.ReadWriteTx interface implementation.
+func (tx *transaction) Commit() error {
+	if tx.closed {
+		return walletdb.ErrTxClosed
+	}
+	if !tx.writable {
+		return walletdb.ErrTxNotWritable
+	}
+	if tx.err != nil {
+		_ = tx.Rollback()
+		return tx.err
+	}
+
+	tx.closed = true
+	if err := tx.sqlTx.Commit(); err != nil {
+		return err
+	}
+	for _, f := range tx.onCommit {
+		f()
+	}
+	return nil
+}
+
+// Rollback undoes all changes that have been made to the root bucket and all
+// of its sub-buckets.
+//
+// This function is part of the walletdb.ReadTx interface implementation.
+func (tx *transaction) Rollback() error {
+	if tx.closed {
+		return walletdb.ErrTxClosed
+	}
+
+	tx.closed = true
+	return tx.sqlTx.Rollback()
+}
+
+// OnCommit takes a function closure that will be executed when the transaction
+// successfully gets committed.
+//
+// This function is part of the walletdb.ReadWriteTx interface implementation.
+func (tx *transaction) OnCommit(f func()) {
+	tx.onCommit = append(tx.onCommit, f)
+}
@@ -0,0 +1,188 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/walletdb/sqlbase"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	// tableName is the name of the table of the buckets and key/value
+	// pairs.
+	tableName = "walletdb"
+
+	// maxReadConns is the maximum number of concurrent read transactions.
+	maxReadConns = 16
+)
+
+// schema creates the table of the buckets and key/value pairs.
+var schema = []string{`CREATE TABLE IF NOT EXISTS %[1]s (
+	id INTEGER PRIMARY KEY,
+	parent_id INTEGER REFERENCES %[1]s (id) ON DELETE CASCADE,
+	key BLOB NOT NULL,
+	value BLOB,
+	sequence INTEGER NOT NULL DEFAULT 0,
+	UNIQUE (parent_id, key)
+)`}
+
+// db is a walletdb.DB stored in a SQLite database file.
+type db struct {
+	*sqlbase.DB
+
+	path   string
+	readDB *sql.DB
+}
+
+// Enforce db implements the walletdb.DB interface.
+var _ walletdb.DB = (*db)(nil)
+
+// isRetryable returns whether the error is caused by a lock held by another
+// connection to the database, so the transaction may succeed if retried.
+func isRetryable(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	// The primary result code is the low byte of an extended code.
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+	return false
+}
+
+// convertErr converts the errors of opening a database to the equivalent
+// walletdb error.
+func convertErr(err error) error {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) &&
+		sqliteErr.Code()&0xff == sqlite3.SQLITE_NOTADB {
+
+		return walletdb.ErrInvalid
+	}
+	return err
+}
+
+// dsn returns the data source name of the connections to the database file.
+func dsn(dbPath string, timeout time.Duration, pragmas ...string) string {
+	// The path is escaped as a URI filename, which SQLite unescapes.
+	uri := url.URL{Scheme: "file", Path: filepath.ToSlash(dbPath)}
+	query := url.Values{
+		"_pragma": append([]string{
+			fmt.Sprintf("busy_timeout(%d)", timeout.Milliseconds()),
+			"foreign_keys(1)",
+			"synchronous(FULL)",
+		}, pragmas...),
+	}
+	return uri.String() + "?" + query.Encode()
+}
+
+// fileExists reports whether the named file or directory exists.
+func fileExists(name string) bool {
+	if _, err := os.Stat(name); err != nil {
+		if os.IsNotExist(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// openDB opens the database at the provided path.  walletdb.ErrDbDoesNotExist
+// is returned if the database doesn't exist and the create flag is not set,
+// and walletdb.ErrDbExists if it exists and the create flag is set.
+func openDB(dbPath string, create bool,
+	timeout time.Duration) (walletdb.DB, error) {
+
+	switch exists := fileExists(dbPath); {
+	case !create && !exists:
+		return nil, walletdb.ErrDbDoesNotExist
+	case create && exists:
+		return nil, walletdb.ErrDbExists
+	}
+
+	// The file is created before SQLite opens it, so it is only readable
+	// by its owner, as are the WAL files SQLite creates next to it.
+	if create {
+		f, err := os.OpenFile(dbPath, os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read-write transactions use the single connection of their pool, so
+	// they wait for each other rather than failing to lock the database.
+	// Beginning them immediately takes the lock of the database before
+	// reading it, so they can't fail to upgrade a read lock.
+	writeDB, err := sql.Open(dbType, dsn(dbPath, timeout,
+		"journal_mode(WAL)")+"&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	writeDB.SetMaxOpenConns(1)
+
+	readDB, err := sql.Open(dbType, dsn(dbPath, timeout))
+	if err != nil {
+		_ = writeDB.Close()
+		return nil, err
+	}
+	readDB.SetMaxOpenConns(maxReadConns)
+
+	base, err := sqlbase.NewDB(&sqlbase.Config{
+		DB:          writeDB,
+		ReadDB:      readDB,
+		Table:       tableName,
+		Schema:      schema,
+		IsRetryable: isRetryable,
+	})
+	if err != nil {
+		_ = writeDB.Close()
+		_ = readDB.Close()
+		if create {
+			_ = os.Remove(dbPath)
+		}
+		return nil, convertErr(err)
+	}
+
+	return &db{DB: base, path: dbPath, readDB: readDB}, nil
+}
+
+// Copy writes a copy of the database to the provided writer.  The copy is a
+// snapshot of the database written to a temporary file next to it, which is
+// removed once copied.
+//
+// This function is part of the walletdb.DB interface implementation.
+func (db *db) Copy(w io.Writer) error {
+	dir, err := os.MkdirTemp(filepath.Dir(db.path), ".walletdb-copy")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "copy.sqlite")
+	if _, err := db.readDB.Exec("VACUUM INTO $1", path); err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
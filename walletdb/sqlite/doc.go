@@ -0,0 +1,31 @@
+/*
+Package sqlite implements an instance of walletdb that uses SQLite for the
+backing datastore.  The database is accessed with a pure Go build of SQLite,
+so the driver doesn't require cgo.
+
+# Usage
+
+This package is only a driver to the walletdb package and provides the database
+type of "sqlite".  The only parameters the Open and Create functions take are
+the database path as a string, and a timeout value as a time.Duration for which
+a transaction waits for the lock of the database held by another process:
+
+	db, err := walletdb.Open("sqlite", "path/to/wallet.sqlite", 60*time.Second)
+	if err != nil {
+		// Handle error
+	}
+
+	db, err := walletdb.Create("sqlite", "path/to/wallet.sqlite", 60*time.Second)
+	if err != nil {
+		// Handle error
+	}
+
+The buckets and key/value pairs are rows of the walletdb table, as described by
+the sqlbase package, so the database may be inspected with the sqlite3 shell
+while it is in use.  The database is in WAL mode: read transactions run
+concurrently with the single read-write transaction, which don't block each
+other.  Transactions failing because another process holds the lock of the
+database for longer than the timeout are retried, calling the reset function
+of View and Update before each retry.
+*/
+package sqlite
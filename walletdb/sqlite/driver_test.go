@@ -0,0 +1,212 @@
+package sqlite_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/sqlite"
+)
+
+const (
+	// dbType is the database type name for this driver.
+	dbType = "sqlite"
+
+	// defaultDBTimeout is the value of db timeout for testing.
+	defaultDBTimeout = 10 * time.Second
+)
+
+// TestCreateOpenFail ensures that errors related to creating and opening a
+// database are handled properly.
+func TestCreateOpenFail(t *testing.T) {
+	dir := t.TempDir()
+
+	// Ensure that attempting to open a database that doesn't exist returns
+	// the expected error.
+	wantErr := walletdb.ErrDbDoesNotExist
+	_, err := walletdb.Open(
+		dbType, filepath.Join(dir, "noexist.sqlite"), defaultDBTimeout,
+	)
+	if err != wantErr {
+		t.Errorf("Open: did not receive expected error - got %v, "+
+			"want %v", err, wantErr)
+		return
+	}
+
+	// Ensure that attempting to open or create a database with invalid
+	// parameters returns the expected errors.
+	tests := []struct {
+		open    bool
+		args    []interface{}
+		wantErr string
+	}{
+		{true, []interface{}{1, 2, 3}, "invalid arguments to sqlite.Open " +
+			"-- expected database path and timeout option"},
+		{true, []interface{}{1, defaultDBTimeout}, "first argument to " +
+			"sqlite.Open is invalid -- expected database path string"},
+		{true, []interface{}{"noexist.sqlite", 1}, "second argument " +
+			"to sqlite.Open is invalid -- expected timeout " +
+			"time.Duration"},
+		{false, []interface{}{1, 2, 3}, "invalid arguments to " +
+			"sqlite.Create -- expected database path and timeout " +
+			"option"},
+		{false, []interface{}{1, defaultDBTimeout}, "first argument " +
+			"to sqlite.Create is invalid -- expected database path " +
+			"string"},
+		{false, []interface{}{"noexist.sqlite", 1}, "second argument " +
+			"to sqlite.Create is invalid -- expected timeout " +
+			"time.Duration"},
+	}
+	for _, test := range tests {
+		var err error
+		if test.open {
+			_, err = walletdb.Open(dbType, test.args...)
+		} else {
+			_, err = walletdb.Create(dbType, test.args...)
+		}
+		if err == nil || err.Error() != test.wantErr {
+			t.Errorf("%v: did not receive expected error - got %v, "+
+				"want %v", test.args, err, test.wantErr)
+		}
+	}
+
+	// Ensure that creating a database that already exists, or opening a
+	// file that isn't a database, returns the expected errors.
+	dbPath := filepath.Join(dir, "db.sqlite")
+	db, err := walletdb.Create(dbType, dbPath, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Create: unexpected error: %v", err)
+	}
+	db.Close()
+	_, err = walletdb.Create(dbType, dbPath, defaultDBTimeout)
+	if err != walletdb.ErrDbExists {
+		t.Errorf("Create: did not receive expected error - got %v, "+
+			"want %v", err, walletdb.ErrDbExists)
+	}
+
+	notDBPath := filepath.Join(dir, "notdb")
+	err = os.WriteFile(notDBPath, bytes.Repeat([]byte{0xff}, 4096), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = walletdb.Open(dbType, notDBPath, defaultDBTimeout)
+	if err != walletdb.ErrInvalid {
+		t.Errorf("Open: did not receive expected error - got %v, "+
+			"want %v", err, walletdb.ErrInvalid)
+	}
+
+	// Ensure operations against a closed database return the expected
+	// error.
+	wantErr = walletdb.ErrDbNotOpen
+	db, err = walletdb.Open(dbType, dbPath, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	db.Close()
+	if _, err := db.BeginReadTx(); err == nil {
+		t.Errorf("BeginReadTx: did not receive an error on a closed " +
+			"database")
+	}
+}
+
+// TestPersistence ensures that values stored are still valid after closing
+// and reopening the database, and that a copy of the database holds the same
+// values.
+func TestPersistence(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "db.sqlite")
+	db, err := walletdb.Create(dbType, dbPath, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Failed to create test database (%s) %v", dbType, err)
+	}
+
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("database file mode %v, want 0600", perm)
+	}
+
+	// Create a namespace and put some values into it so they can be tested
+	// for existence on re-open.
+	storeValues := map[string]string{
+		"ns1key1": "foo1",
+		"ns1key2": "foo2",
+		"ns1key3": "foo3",
+	}
+	ns1Key := []byte("ns1")
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns1, err := tx.CreateTopLevelBucket(ns1Key)
+		if err != nil {
+			return err
+		}
+
+		for k, v := range storeValues {
+			if err := ns1.Put([]byte(k), []byte(v)); err != nil {
+				return fmt.Errorf("Put: unexpected error: %w",
+					err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ns1 Update: unexpected error: %v", err)
+	}
+
+	var copied bytes.Buffer
+	if err := db.Copy(&copied); err != nil {
+		t.Fatalf("Copy: unexpected error: %v", err)
+	}
+
+	// Close and reopen the database to ensure the values persist.
+	db.Close()
+	db, err = walletdb.Open(dbType, dbPath, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Failed to open test database (%s) %v", dbType, err)
+	}
+	defer db.Close()
+
+	copyPath := filepath.Join(dir, "copy.sqlite")
+	if err := os.WriteFile(copyPath, copied.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	copyDB, err := walletdb.Open(dbType, copyPath, defaultDBTimeout)
+	if err != nil {
+		t.Fatalf("Failed to open copy of database (%s) %v", dbType,
+			err)
+	}
+	defer copyDB.Close()
+
+	// Ensure the values previously stored in the namespace still exist
+	// and are correct, in the database and its copy.
+	for _, db := range []walletdb.DB{db, copyDB} {
+		err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+			ns1 := tx.ReadBucket(ns1Key)
+			if ns1 == nil {
+				return fmt.Errorf("ReadTx.ReadBucket: "+
+					"unexpected nil bucket %s", ns1Key)
+			}
+
+			for k, v := range storeValues {
+				gotVal := ns1.Get([]byte(k))
+				if !reflect.DeepEqual(gotVal, []byte(v)) {
+					return fmt.Errorf("Get: key '%s' does "+
+						"not match expected value - got "+
+						"%s, want %s", k, gotVal, v)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ns1 View: unexpected error: %v", err)
+		}
+	}
+}
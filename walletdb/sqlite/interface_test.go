@@ -0,0 +1,14 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcwallet/walletdb/walletdbtest"
+)
+
+// TestInterface performs all interfaces tests for this database driver.
+func TestInterface(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "db.sqlite")
+	walletdbtest.TestInterface(t, dbType, dbPath, defaultDBTimeout)
+}
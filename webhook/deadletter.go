@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+var (
+	// namespaceKey is the top-level bucket the webhook dispatcher stores
+	// its state under.
+	namespaceKey = []byte("webhook")
+
+	// deadLetterBucketKey is the nested bucket holding deliveries that
+	// exhausted their retries.
+	deadLetterBucketKey = []byte("deadletters")
+
+	// ErrDeadLetterNotFound is returned when a dead letter with the
+	// requested ID does not exist.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+)
+
+// DeadLetter is a delivery that could not be completed after the maximum
+// number of attempts.
+type DeadLetter struct {
+	// ID is assigned by the store when the dead letter is added.
+	ID uint64 `json:"-"`
+
+	// URL is the endpoint the delivery was addressed to.
+	URL string `json:"url"`
+
+	// Event is the undelivered payload.
+	Event Event `json:"event"`
+
+	// Attempts is the number of delivery attempts made.
+	Attempts int `json:"attempts"`
+
+	// LastError describes the failure of the final attempt.
+	LastError string `json:"last_error"`
+
+	// Failed is the time the delivery was given up on.
+	Failed time.Time `json:"failed"`
+}
+
+// DeadLetterStore persists deliveries that exhausted their retries.
+type DeadLetterStore interface {
+	// Put adds a dead letter to the store and returns its assigned ID.
+	Put(*DeadLetter) (uint64, error)
+
+	// List returns all dead letters in the order they were added.
+	List() ([]*DeadLetter, error)
+
+	// Delete removes the dead letter with the given ID.
+	Delete(id uint64) error
+}
+
+// dbDeadLetterStore is a DeadLetterStore backed by a walletdb database.
+type dbDeadLetterStore struct {
+	db walletdb.DB
+}
+
+// A compile-time assertion to ensure dbDeadLetterStore satisfies the
+// DeadLetterStore interface.
+var _ DeadLetterStore = (*dbDeadLetterStore)(nil)
+
+// NewDBDeadLetterStore returns a DeadLetterStore that keeps dead letters in
+// a dedicated top-level bucket of db.  This is usually the wallet database,
+// so undelivered events survive restarts together with the wallet state.
+func NewDBDeadLetterStore(db walletdb.DB) (DeadLetterStore, error) {
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(namespaceKey)
+		if err != nil {
+			return err
+		}
+		_, err = ns.CreateBucketIfNotExists(deadLetterBucketKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbDeadLetterStore{db: db}, nil
+}
+
+func deadLetterKey(id uint64) []byte {
+	var k [8]byte
+	binary.BigEndian.PutUint64(k[:], id)
+	return k[:]
+}
+
+// Put adds a dead letter to the store and returns its assigned ID.
+func (s *dbDeadLetterStore) Put(dl *DeadLetter) (uint64, error) {
+	v, err := json.Marshal(dl)
+	if err != nil {
+		return 0, err
+	}
+
+	var id uint64
+	err = walletdb.Update(s.db, func(tx walletdb.ReadWriteTx) error {
+		bucket := tx.ReadWriteBucket(namespaceKey).
+			NestedReadWriteBucket(deadLetterBucketKey)
+
+		id, err = bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		return bucket.Put(deadLetterKey(id), v)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	dl.ID = id
+	return id, nil
+}
+
+// List returns all dead letters in the order they were added.
+func (s *dbDeadLetterStore) List() ([]*DeadLetter, error) {
+	var dls []*DeadLetter
+	err := walletdb.View(s.db, func(tx walletdb.ReadTx) error {
+		bucket := tx.ReadBucket(namespaceKey).
+			NestedReadBucket(deadLetterBucketKey)
+
+		return bucket.ForEach(func(k, v []byte) error {
+			dl := new(DeadLetter)
+			if err := json.Unmarshal(v, dl); err != nil {
+				return err
+			}
+			dl.ID = binary.BigEndian.Uint64(k)
+			dls = append(dls, dl)
+			return nil
+		})
+	})
+	return dls, err
+}
+
+// Delete removes the dead letter with the given ID.
+func (s *dbDeadLetterStore) Delete(id uint64) error {
+	return walletdb.Update(s.db, func(tx walletdb.ReadWriteTx) error {
+		bucket := tx.ReadWriteBucket(namespaceKey).
+			NestedReadWriteBucket(deadLetterBucketKey)
+
+		k := deadLetterKey(id)
+		if bucket.Get(k) == nil {
+			return ErrDeadLetterNotFound
+		}
+		return bucket.Delete(k)
+	})
+}
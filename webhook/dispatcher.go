@@ -0,0 +1,792 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+const (
+	// DefaultConfirmations is the confirmation threshold used for
+	// accounts without an explicit threshold.
+	DefaultConfirmations = 6
+
+	// DefaultReorgHorizon is the number of blocks past its confirmation
+	// threshold that a deposit is still watched for reorgs.
+	DefaultReorgHorizon = 100
+
+	// DefaultMaxAttempts is the default number of delivery attempts made
+	// for an event before it is moved to the dead-letter store.
+	DefaultMaxAttempts = 5
+
+	// DefaultRetryBackoff is the delay before the first retry of a failed
+	// delivery.  The delay doubles with every further attempt.
+	DefaultRetryBackoff = time.Second
+
+	// DefaultMaxRetryBackoff caps the exponential retry delay.
+	DefaultMaxRetryBackoff = time.Minute
+
+	// DefaultTimeout is the default timeout of a single delivery request.
+	DefaultTimeout = 10 * time.Second
+
+	// userAgent is sent with every delivery request.
+	userAgent = "btcwallet-webhook"
+)
+
+var (
+	// ErrNoEndpoints is returned when a dispatcher is created without any
+	// endpoints to deliver to.
+	ErrNoEndpoints = errors.New("no webhook endpoints configured")
+
+	// ErrUnknownEndpoint is returned when a dead letter is replayed for an
+	// endpoint that is no longer configured.
+	ErrUnknownEndpoint = errors.New("unknown webhook endpoint")
+)
+
+// Endpoint is a URL events are delivered to, together with the secret used to
+// sign the payloads sent to it.
+type Endpoint struct {
+	URL    string
+	Secret []byte
+}
+
+// Config houses the parameters of a Dispatcher.
+type Config struct {
+	// Endpoints lists the URLs every event is POSTed to.
+	Endpoints []Endpoint
+
+	// ChainParams are the parameters of the wallet's network, used to
+	// encode output addresses.
+	ChainParams *chaincfg.Params
+
+	// Wallet is the name of the wallet the events are about, which is
+	// reported with every event and prefixes its ID.  It is empty for the
+	// default wallet.
+	Wallet string
+
+	// WatchAddresses restricts deposit events to outputs paying to one of
+	// these addresses.  When empty, deposits to every external wallet
+	// address are reported.
+	WatchAddresses []btcutil.Address
+
+	// DefaultConfirmations is the confirmation threshold for accounts not
+	// listed in AccountConfirmations.
+	DefaultConfirmations int32
+
+	// AccountConfirmations overrides the confirmation threshold per
+	// account number.
+	AccountConfirmations map[uint32]int32
+
+	// ReorgHorizon is the number of blocks past the confirmation threshold
+	// a deposit is still watched for reorgs.
+	ReorgHorizon int32
+
+	// MaxAttempts is the number of delivery attempts made for an event
+	// before it is given up on.
+	MaxAttempts int
+
+	// RetryBackoff is the delay before the first retry.  It doubles with
+	// every further attempt, up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+
+	// Client is the HTTP client used for deliveries.  If nil, a client
+	// with DefaultTimeout is used.
+	Client *http.Client
+
+	// DeadLetters receives the deliveries that exhausted their retries.
+	// If nil, such deliveries are only logged.
+	DeadLetters DeadLetterStore
+}
+
+// trackedCredit is a reported deposit that is followed until it is
+// confirmed deeply enough to no longer be affected by reorgs.
+type trackedCredit struct {
+	event     Event
+	blockHash *chainhash.Hash
+	height    int32
+	confirmed bool
+}
+
+// Dispatcher turns wallet transaction notifications into webhook events and
+// delivers them to the configured endpoints.  Deliveries to each endpoint
+// are made in the order the events were created, and a failing endpoint does
+// not delay deliveries to the others.
+type Dispatcher struct {
+	cfg     Config
+	watched map[string]struct{}
+	workers map[string]*endpointWorker
+
+	// credits and tipHeight are only accessed by the notification
+	// handler.
+	credits   map[wire.OutPoint]*trackedCredit
+	tipHeight int32
+
+	started bool
+	mu      sync.Mutex
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a Dispatcher from cfg, filling unset options with their
+// defaults.
+func New(cfg *Config) (*Dispatcher, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	if cfg.ChainParams == nil {
+		return nil, errors.New("chain params not set")
+	}
+
+	c := *cfg
+	if c.DefaultConfirmations <= 0 {
+		c.DefaultConfirmations = DefaultConfirmations
+	}
+	if c.ReorgHorizon <= 0 {
+		c.ReorgHorizon = DefaultReorgHorizon
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = DefaultRetryBackoff
+	}
+	if c.MaxRetryBackoff < c.RetryBackoff {
+		c.MaxRetryBackoff = DefaultMaxRetryBackoff
+		if c.MaxRetryBackoff < c.RetryBackoff {
+			c.MaxRetryBackoff = c.RetryBackoff
+		}
+	}
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	for acct, confs := range c.AccountConfirmations {
+		if confs <= 0 {
+			return nil, fmt.Errorf("invalid confirmation threshold "+
+				"%d for account %d", confs, acct)
+		}
+	}
+
+	d := &Dispatcher{
+		cfg:     c,
+		watched: make(map[string]struct{}, len(c.WatchAddresses)),
+		workers: make(map[string]*endpointWorker, len(c.Endpoints)),
+		credits: make(map[wire.OutPoint]*trackedCredit),
+		quit:    make(chan struct{}),
+	}
+	for _, addr := range c.WatchAddresses {
+		d.watched[addr.EncodeAddress()] = struct{}{}
+	}
+	for _, ep := range c.Endpoints {
+		if _, ok := d.workers[ep.URL]; ok {
+			return nil, fmt.Errorf("duplicate webhook endpoint %s",
+				ep.URL)
+		}
+		d.workers[ep.URL] = &endpointWorker{
+			d:      d,
+			ep:     ep,
+			signal: make(chan struct{}, 1),
+		}
+	}
+
+	return d, nil
+}
+
+// Start registers the dispatcher for transaction notifications of the
+// wallet's notification server and begins delivering events.  The deposits
+// still followed for confirmations and reorgs are first restored from the
+// wallet's transactions, so that their events are emitted across restarts.
+func (d *Dispatcher) Start(w *wallet.Wallet) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.started {
+		return nil
+	}
+
+	// Register for notifications before restoring the deposits, so that
+	// none are missed in between.  Transactions both restored and
+	// notified are only reported once.
+	client := w.NtfnServer.TransactionNotifications()
+	haltClient := w.NtfnServer.ReorgHaltNotifications()
+	if err := d.restoreCredits(w); err != nil {
+		client.Done()
+		haltClient.Done()
+		return err
+	}
+	d.started = true
+
+	d.startWorkers()
+
+	d.wg.Add(1)
+	go d.notificationHandler(client)
+
+	d.wg.Add(1)
+	go d.haltHandler(haltClient)
+
+	return nil
+}
+
+// restoreCredits tracks the deposits of the wallet mined within the reorg
+// horizon of the block the wallet is synced to, or not mined yet, as they
+// were when the wallet last processed that block.  Their deposit events, and
+// the confirmation events of the ones confirmed at that block, were emitted
+// then and are not emitted again.
+func (d *Dispatcher) restoreCredits(w *wallet.Wallet) error {
+	d.tipHeight = w.Manager.SyncedTo().Height
+
+	maxThreshold := d.cfg.DefaultConfirmations
+	for _, confs := range d.cfg.AccountConfirmations {
+		if confs > maxThreshold {
+			maxThreshold = confs
+		}
+	}
+	start := d.tipHeight - maxThreshold - d.cfg.ReorgHorizon + 1
+	if start < 0 {
+		start = 0
+	}
+
+	res, err := w.GetTransactions(
+		wallet.NewBlockIdentifierFromHeight(start), nil, "", nil,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to restore webhook deposits: %w", err)
+	}
+	for i := range res.MinedTransactions {
+		block := &res.MinedTransactions[i]
+		for j := range block.Transactions {
+			d.trackTransaction(&block.Transactions[j], block)
+		}
+	}
+	for i := range res.UnminedTransactions {
+		d.trackTransaction(&res.UnminedTransactions[i], nil)
+	}
+
+	for _, c := range d.credits {
+		if c.blockHash == nil {
+			continue
+		}
+		confs := d.tipHeight - c.height + 1
+		c.confirmed = confs >= d.confirmationThreshold(c.event.Account)
+	}
+
+	log.Debugf("Restored %d webhook %s", len(d.credits),
+		pickNoun(len(d.credits), "deposit", "deposits"))
+
+	return nil
+}
+
+// startWorkers launches the delivery goroutine of every endpoint.
+func (d *Dispatcher) startWorkers() {
+	for _, w := range d.workers {
+		d.wg.Add(1)
+		go w.run()
+	}
+}
+
+// Stop signals all dispatcher goroutines to exit and waits for them to
+// finish.  Events that were not delivered yet are moved to the dead-letter
+// store.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	select {
+	case <-d.quit:
+	default:
+		close(d.quit)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+// DeadLetters returns the deliveries that exhausted their retries.
+func (d *Dispatcher) DeadLetters() ([]*DeadLetter, error) {
+	if d.cfg.DeadLetters == nil {
+		return nil, nil
+	}
+	return d.cfg.DeadLetters.List()
+}
+
+// Replay removes the dead letter with the given ID from the dead-letter
+// store and queues its event for delivery again.
+func (d *Dispatcher) Replay(id uint64) error {
+	if d.cfg.DeadLetters == nil {
+		return ErrDeadLetterNotFound
+	}
+
+	dls, err := d.cfg.DeadLetters.List()
+	if err != nil {
+		return err
+	}
+	for _, dl := range dls {
+		if dl.ID != id {
+			continue
+		}
+
+		w, ok := d.workers[dl.URL]
+		if !ok {
+			return ErrUnknownEndpoint
+		}
+		if err := d.cfg.DeadLetters.Delete(id); err != nil {
+			return err
+		}
+		event := dl.Event
+		w.enqueue(&event)
+		return nil
+	}
+
+	return ErrDeadLetterNotFound
+}
+
+// notificationHandler processes transaction notifications until the
+// dispatcher is stopped.  It must be run as a goroutine.
+func (d *Dispatcher) notificationHandler(
+	client wallet.TransactionNotificationsClient) {
+
+	defer d.wg.Done()
+	defer client.Done()
+
+	for {
+		select {
+		case n, ok := <-client.C:
+			if !ok {
+				return
+			}
+			d.processNotification(n)
+
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+// haltHandler emits a halt event each time the wallet halts after a deep
+// chain reorganization.  It must be run as a goroutine.
+func (d *Dispatcher) haltHandler(client wallet.ReorgHaltNotificationsClient) {
+	defer d.wg.Done()
+	defer client.Done()
+
+	for {
+		select {
+		case halt, ok := <-client.C:
+			if !ok {
+				return
+			}
+			d.emitHalt(halt)
+
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+// confirmationThreshold returns the number of confirmations required for a
+// deposit to the account to be reported as confirmed.
+func (d *Dispatcher) confirmationThreshold(account uint32) int32 {
+	if confs, ok := d.cfg.AccountConfirmations[account]; ok {
+		return confs
+	}
+	return d.cfg.DefaultConfirmations
+}
+
+// processNotification updates the tracked deposits from a transaction
+// notification and queues the resulting events.  Events are created in the
+// order reorgs, deposits and confirmations, matching the order in which the
+// chain changes described by the notification happened.
+func (d *Dispatcher) processNotification(n *wallet.TransactionNotifications) {
+	// Credits mined in a detached block are invalidated until they are
+	// mined again.
+	if len(n.DetachedBlocks) > 0 {
+		detached := make(map[chainhash.Hash]struct{},
+			len(n.DetachedBlocks))
+		for _, hash := range n.DetachedBlocks {
+			detached[*hash] = struct{}{}
+		}
+		for _, c := range d.credits {
+			if c.blockHash == nil {
+				continue
+			}
+			if _, ok := detached[*c.blockHash]; !ok {
+				continue
+			}
+
+			d.emit(c.event, EventReorg, c.blockHash, c.height, 0)
+			c.blockHash = nil
+			c.height = 0
+			c.confirmed = false
+		}
+	}
+
+	for i := range n.UnminedTransactions {
+		d.processTransaction(&n.UnminedTransactions[i], nil)
+	}
+
+	for i := range n.AttachedBlocks {
+		block := &n.AttachedBlocks[i]
+		d.tipHeight = block.Height
+		for j := range block.Transactions {
+			d.processTransaction(&block.Transactions[j], block)
+		}
+	}
+
+	unmined := make(map[chainhash.Hash]struct{},
+		len(n.UnminedTransactionHashes))
+	for _, hash := range n.UnminedTransactionHashes {
+		unmined[*hash] = struct{}{}
+	}
+
+	for op, c := range d.credits {
+		// Unmined deposits that are no longer in the unconfirmed set
+		// were either double spent or evicted, and will never confirm.
+		if c.blockHash == nil {
+			if _, ok := unmined[op.Hash]; !ok {
+				delete(d.credits, op)
+			}
+			continue
+		}
+
+		confs := d.tipHeight - c.height + 1
+		threshold := d.confirmationThreshold(c.event.Account)
+		switch {
+		case !c.confirmed && confs >= threshold:
+			d.emit(c.event, EventConfirmation, c.blockHash,
+				c.height, confs)
+			c.confirmed = true
+
+		case c.confirmed && confs > threshold+d.cfg.ReorgHorizon:
+			delete(d.credits, op)
+		}
+	}
+}
+
+// processTransaction tracks the deposits of a transaction seen unmined or in
+// the given block, and emits deposit events for the ones not seen before.
+func (d *Dispatcher) processTransaction(tx *wallet.TransactionSummary,
+	block *wallet.Block) {
+
+	for _, c := range d.trackTransaction(tx, block) {
+		var confs int32
+		if c.blockHash != nil {
+			confs = d.tipHeight - c.height + 1
+		}
+		d.emit(c.event, EventDeposit, c.blockHash, c.height, confs)
+	}
+}
+
+// trackTransaction tracks the deposits of a transaction seen unmined or in the
+// given block, and returns the ones not seen before.
+func (d *Dispatcher) trackTransaction(tx *wallet.TransactionSummary,
+	block *wallet.Block) []*trackedCredit {
+
+	var (
+		msgTx   *wire.MsgTx
+		tracked []*trackedCredit
+	)
+	for _, output := range tx.MyOutputs {
+		// Change outputs are not deposits.
+		if output.Internal {
+			continue
+		}
+
+		op := wire.OutPoint{Hash: *tx.Hash, Index: output.Index}
+		if c, ok := d.credits[op]; ok {
+			if block != nil && c.blockHash == nil {
+				c.blockHash = block.Hash
+				c.height = block.Height
+			}
+			continue
+		}
+
+		if msgTx == nil {
+			msgTx = new(wire.MsgTx)
+			err := msgTx.Deserialize(bytes.NewReader(tx.Transaction))
+			if err != nil {
+				log.Errorf("Unable to decode transaction %v: %v",
+					tx.Hash, err)
+				return tracked
+			}
+		}
+		if int(output.Index) >= len(msgTx.TxOut) {
+			log.Errorf("Output %v out of range", op)
+			continue
+		}
+		txOut := msgTx.TxOut[output.Index]
+
+		var address string
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			txOut.PkScript, d.cfg.ChainParams,
+		)
+		if err == nil && len(addrs) > 0 {
+			address = addrs[0].EncodeAddress()
+		}
+		if len(d.watched) > 0 {
+			if _, ok := d.watched[address]; !ok {
+				continue
+			}
+		}
+
+		c := &trackedCredit{
+			event: Event{
+				TxID:    tx.Hash.String(),
+				Vout:    output.Index,
+				Address: address,
+				Amount:  txOut.Value,
+				Account: output.Account,
+			},
+		}
+		if block != nil {
+			c.blockHash = block.Hash
+			c.height = block.Height
+		}
+		d.credits[op] = c
+		tracked = append(tracked, c)
+	}
+
+	return tracked
+}
+
+// emit completes an event from the credit template and queues it for
+// delivery to every endpoint.
+func (d *Dispatcher) emit(template Event, typ EventType,
+	blockHash *chainhash.Hash, height, confs int32) {
+
+	event := template
+	event.Type = typ
+	event.Created = time.Now().UTC()
+	event.Wallet = d.cfg.Wallet
+	event.Confirmations = confs
+	event.ID = fmt.Sprintf("%s:%s:%d", typ, event.TxID, event.Vout)
+	if blockHash != nil {
+		event.BlockHash = blockHash.String()
+		event.BlockHeight = height
+		event.ID += ":" + event.BlockHash
+	}
+	event.ID = d.eventID(event.ID)
+
+	log.Debugf("Queueing %s event %s", typ, event.ID)
+
+	for _, w := range d.workers {
+		e := event
+		w.enqueue(&e)
+	}
+}
+
+// emitHalt queues a halt event on every endpoint.
+func (d *Dispatcher) emitHalt(halt *wallet.ReorgHalt) {
+	event := Event{
+		ID: d.eventID(fmt.Sprintf("%s:%d:%d", EventHalt,
+			halt.ForkHeight, halt.Time.Unix())),
+		Type:        EventHalt,
+		Created:     time.Now().UTC(),
+		Wallet:      d.cfg.Wallet,
+		BlockHeight: halt.ForkHeight,
+		ReorgDepth:  halt.Depth,
+	}
+
+	log.Debugf("Queueing %s event %s", EventHalt, event.ID)
+
+	for _, w := range d.workers {
+		e := event
+		w.enqueue(&e)
+	}
+}
+
+// eventID prefixes an event ID with the name of the wallet, so that the IDs of
+// the events of different wallets never collide.
+func (d *Dispatcher) eventID(id string) string {
+	if d.cfg.Wallet == "" {
+		return id
+	}
+	return d.cfg.Wallet + "/" + id
+}
+
+// backoff returns the delay before the given retry attempt.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.RetryBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxRetryBackoff {
+			return d.cfg.MaxRetryBackoff
+		}
+	}
+	return delay
+}
+
+// endpointWorker delivers events to a single endpoint in the order they were
+// queued.
+type endpointWorker struct {
+	d       *Dispatcher
+	ep      Endpoint
+	mu      sync.Mutex
+	pending []*Event
+	signal  chan struct{}
+}
+
+// enqueue adds an event to the end of the worker's queue.
+func (w *endpointWorker) enqueue(e *Event) {
+	w.mu.Lock()
+	w.pending = append(w.pending, e)
+	w.mu.Unlock()
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the event at the front of the queue, if any.
+func (w *endpointWorker) next() *Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return nil
+	}
+	e := w.pending[0]
+	w.pending[0] = nil
+	w.pending = w.pending[1:]
+	return e
+}
+
+// run delivers queued events until the dispatcher is stopped.  It must be run
+// as a goroutine.
+func (w *endpointWorker) run() {
+	defer w.d.wg.Done()
+
+	for {
+		e := w.next()
+		if e == nil {
+			select {
+			case <-w.signal:
+				continue
+			case <-w.d.quit:
+				return
+			}
+		}
+
+		attempts, err := w.deliver(e)
+		if err != nil {
+			w.deadLetter(e, attempts, err)
+		}
+
+		select {
+		case <-w.d.quit:
+			// Keep the events that were not delivered so they can
+			// be replayed once the dispatcher runs again.
+			for e := w.next(); e != nil; e = w.next() {
+				w.deadLetter(e, 0, errors.New("dispatcher "+
+					"stopped"))
+			}
+			return
+		default:
+		}
+	}
+}
+
+// deliver POSTs the event to the endpoint, retrying with exponential backoff
+// until it is accepted, the attempts are exhausted or the dispatcher is
+// stopped.  The number of attempts made is returned together with the error
+// of the final one.
+func (w *endpointWorker) deliver(e *Event) (int, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+
+	var attempt int
+	for {
+		attempt++
+		err = w.post(e, body)
+		if err == nil {
+			log.Debugf("Delivered %s event %s to %s", e.Type, e.ID,
+				w.ep.URL)
+			return attempt, nil
+		}
+		if attempt >= w.d.cfg.MaxAttempts {
+			return attempt, err
+		}
+
+		delay := w.d.backoff(attempt)
+		log.Warnf("Delivery of event %s to %s failed, retrying in "+
+			"%v: %v", e.ID, w.ep.URL, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-w.d.quit:
+			return attempt, err
+		}
+	}
+}
+
+// post makes a single delivery attempt.
+func (w *endpointWorker) post(e *Event, body []byte) error {
+	req, err := http.NewRequest(
+		http.MethodPost, w.ep.URL, bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, string(e.Type))
+	req.Header.Set(DeliveryHeader, e.ID)
+	req.Header.Set(SignatureHeader, Sign(w.ep.Secret, body))
+
+	resp, err := w.d.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount of the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// deadLetter records an event that could not be delivered.
+func (w *endpointWorker) deadLetter(e *Event, attempts int, err error) {
+	log.Errorf("Giving up delivery of event %s to %s after %d %s: %v",
+		e.ID, w.ep.URL, attempts, pickNoun(attempts, "attempt",
+			"attempts"), err)
+
+	if w.d.cfg.DeadLetters == nil {
+		return
+	}
+
+	dl := &DeadLetter{
+		URL:       w.ep.URL,
+		Event:     *e,
+		Attempts:  attempts,
+		LastError: err.Error(),
+		Failed:    time.Now().UTC(),
+	}
+	if _, err := w.d.cfg.DeadLetters.Put(dl); err != nil {
+		log.Errorf("Unable to store dead letter for event %s: %v",
+			e.ID, err)
+	}
+}
+
+// pickNoun returns the singular or plural form of a noun depending
+// on the count n.
+func pickNoun(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,607 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/bdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/stretchr/testify/require"
+	"github.com/stroomnetwork/btcwallet/chain"
+	"github.com/stroomnetwork/btcwallet/waddrmgr"
+	"github.com/stroomnetwork/btcwallet/wallet"
+)
+
+var (
+	testSecret = []byte("s3cret")
+
+	testAddr, _ = btcutil.DecodeAddress(
+		"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+		&chaincfg.TestNet3Params,
+	)
+)
+
+// receiver is an httptest server recording the events it receives.
+type receiver struct {
+	t      *testing.T
+	srv    *httptest.Server
+	mu     sync.Mutex
+	events []Event
+	fail   bool
+	got    chan struct{}
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{t: t, got: make(chan struct{}, 100)}
+	r.srv = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	t.Cleanup(r.srv.Close)
+	return r
+}
+
+func (r *receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+
+	r.mu.Lock()
+	fail := r.fail
+	r.mu.Unlock()
+	if fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		r.got <- struct{}{}
+		return
+	}
+
+	sig := req.Header.Get(SignatureHeader)
+	if !VerifySignature(testSecret, body, sig) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var e Event
+	require.NoError(r.t, json.Unmarshal(body, &e))
+	require.Equal(r.t, string(e.Type), req.Header.Get(EventHeader))
+	require.Equal(r.t, e.ID, req.Header.Get(DeliveryHeader))
+
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+	r.got <- struct{}{}
+}
+
+func (r *receiver) setFail(fail bool) {
+	r.mu.Lock()
+	r.fail = fail
+	r.mu.Unlock()
+}
+
+// waitEvents waits until n requests were received and returns the recorded
+// events.
+func (r *receiver) waitEvents(n int) []Event {
+	for i := 0; i < n; i++ {
+		select {
+		case <-r.got:
+		case <-time.After(5 * time.Second):
+			r.t.Fatalf("timeout waiting for request %d", i)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func newTestDispatcher(t *testing.T, r *receiver, cfg Config) *Dispatcher {
+	cfg.Endpoints = []Endpoint{{URL: r.srv.URL, Secret: testSecret}}
+	cfg.ChainParams = &chaincfg.TestNet3Params
+	if cfg.RetryBackoff == 0 {
+		cfg.RetryBackoff = time.Millisecond
+	}
+
+	d, err := New(&cfg)
+	require.NoError(t, err)
+
+	d.startWorkers()
+	t.Cleanup(d.Stop)
+
+	return d
+}
+
+// depositSummary returns a transaction summary for a transaction paying
+// amount to testAddr at output 0 of the given account, plus a change output.
+func depositSummary(t *testing.T, amount int64,
+	account uint32) wallet.TransactionSummary {
+
+	pkScript, err := txscript.PayToAddrScript(testAddr)
+	require.NoError(t, err)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(wire.NewTxOut(amount, pkScript))
+	tx.AddTxOut(wire.NewTxOut(1000, pkScript))
+
+	var buf bytes.Buffer
+	require.NoError(t, tx.Serialize(&buf))
+	hash := tx.TxHash()
+
+	return wallet.TransactionSummary{
+		Hash:        &hash,
+		Transaction: buf.Bytes(),
+		MyOutputs: []wallet.TransactionSummaryOutput{
+			{Index: 0, Account: account},
+			{Index: 1, Account: account, Internal: true},
+		},
+	}
+}
+
+func testBlock(height int32, txs ...wallet.TransactionSummary) wallet.Block {
+	hash := chainhash.Hash{byte(height), byte(height >> 8), 0xaa}
+	return wallet.Block{
+		Hash:         &hash,
+		Height:       height,
+		Transactions: txs,
+	}
+}
+
+// TestDepositAndConfirmation checks that a deposit is reported when first
+// seen unmined, and that a confirmation event follows once the account's
+// threshold is reached.
+func TestDepositAndConfirmation(t *testing.T) {
+	t.Parallel()
+
+	r := newReceiver(t)
+	d := newTestDispatcher(t, r, Config{
+		AccountConfirmations: map[uint32]int32{1: 3},
+	})
+
+	tx := depositSummary(t, 50000, 1)
+	d.processNotification(&wallet.TransactionNotifications{
+		UnminedTransactions:      []wallet.TransactionSummary{tx},
+		UnminedTransactionHashes: []*chainhash.Hash{tx.Hash},
+	})
+
+	events := r.waitEvents(1)
+	require.Len(t, events, 1)
+	require.Equal(t, EventDeposit, events[0].Type)
+	require.Equal(t, tx.Hash.String(), events[0].TxID)
+	require.Equal(t, testAddr.EncodeAddress(), events[0].Address)
+	require.EqualValues(t, 50000, events[0].Amount)
+	require.EqualValues(t, 1, events[0].Account)
+	require.Zero(t, events[0].Confirmations)
+	require.Empty(t, events[0].BlockHash)
+
+	// Mine the transaction and two more blocks on top.  Only the third
+	// block brings the deposit to the three confirmations required.
+	d.processNotification(&wallet.TransactionNotifications{
+		AttachedBlocks: []wallet.Block{testBlock(100, tx)},
+	})
+	d.processNotification(&wallet.TransactionNotifications{
+		AttachedBlocks: []wallet.Block{testBlock(101)},
+	})
+	d.processNotification(&wallet.TransactionNotifications{
+		AttachedBlocks: []wallet.Block{testBlock(102)},
+	})
+
+	events = r.waitEvents(1)
+	require.Len(t, events, 2)
+	require.Equal(t, EventConfirmation, events[1].Type)
+	require.EqualValues(t, 3, events[1].Confirmations)
+	require.EqualValues(t, 100, events[1].BlockHeight)
+
+	// Further blocks don't report the deposit again.
+	d.processNotification(&wallet.TransactionNotifications{
+		AttachedBlocks: []wallet.Block{testBlock(103)},
+	})
+	select {
+	case <-r.got:
+		t.Fatalf("unexpected event")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// chainClient is a chain backend delivering the notifications sent by the
+// test to the wallet.
+type chainClient struct {
+	ntfns chan interface{}
+}
+
+var _ chain.Interface = (*chainClient)(nil)
+
+func (c *chainClient) Start() error     { return nil }
+func (c *chainClient) Stop()            {}
+func (c *chainClient) WaitForShutdown() {}
+func (c *chainClient) IsCurrent() bool  { return true }
+func (c *chainClient) BackEnd() string  { return "test" }
+
+func (c *chainClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return nil, 0, nil
+}
+
+func (c *chainClient) GetBlock(*chainhash.Hash) (*wire.MsgBlock, error) {
+	return nil, nil
+}
+
+func (c *chainClient) GetBlockHash(int64) (*chainhash.Hash, error) {
+	return nil, nil
+}
+
+func (c *chainClient) GetBlockHeader(*chainhash.Hash) (*wire.BlockHeader,
+	error) {
+
+	return &wire.BlockHeader{}, nil
+}
+
+func (c *chainClient) FilterBlocks(*chain.FilterBlocksRequest) (
+	*chain.FilterBlocksResponse, error) {
+
+	return nil, nil
+}
+
+func (c *chainClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	return &waddrmgr.BlockStamp{}, nil
+}
+
+func (c *chainClient) SendRawTransaction(*wire.MsgTx, bool) (*chainhash.Hash,
+	error) {
+
+	return nil, nil
+}
+
+func (c *chainClient) Rescan(*chainhash.Hash, []btcutil.Address,
+	map[wire.OutPoint]btcutil.Address) error {
+
+	return nil
+}
+
+func (c *chainClient) NotifyReceived([]btcutil.Address) error { return nil }
+func (c *chainClient) NotifyBlocks() error                    { return nil }
+
+func (c *chainClient) Notifications() <-chan interface{} { return c.ntfns }
+
+func (c *chainClient) PublicNotifications() <-chan interface{} {
+	return nil
+}
+
+func (c *chainClient) TestMempoolAccept([]*wire.MsgTx, float64) (
+	[]*btcjson.TestMempoolAcceptResult, error) {
+
+	return nil, nil
+}
+
+func (c *chainClient) MapRPCErr(err error) error { return err }
+
+func (c *chainClient) EstimateFee(int64) (float64, error) { return 0, nil }
+
+// chainBlock returns the block meta of a test block at the given height.  The
+// fork byte allows creating competing blocks at the same height.
+func chainBlock(height int32, fork byte) wtxmgr.BlockMeta {
+	return wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{
+			Hash:   chainhash.Hash{byte(height), byte(height >> 8), fork},
+			Height: height,
+		},
+		Time: time.Unix(int64(height), 0),
+	}
+}
+
+// newTestWallet creates and starts a wallet synced to a test chain backend,
+// whose notifications are sent by the test.
+func newTestWallet(t *testing.T) (*wallet.Wallet, *chainClient) {
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	require.NoError(t, err)
+	loader := wallet.NewLoader(
+		&chaincfg.TestNet3Params, t.TempDir(), true, 10*time.Second, 250,
+	)
+	w, err := loader.CreateNewWallet(
+		[]byte("hello"), []byte("world"), seed, time.Now(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = loader.UnloadWallet() })
+
+	backend := &chainClient{ntfns: make(chan interface{}, 10)}
+	w.Start()
+	w.SynchronizeRPC(backend)
+	w.SetChainSynced(true)
+	t.Cleanup(w.Stop)
+
+	return w, backend
+}
+
+// startWalletDispatcher creates a dispatcher delivering to r with the
+// confirmation threshold, and starts it for the wallet.
+func startWalletDispatcher(t *testing.T, w *wallet.Wallet, r *receiver,
+	confs int32) *Dispatcher {
+
+	d, err := New(&Config{
+		Endpoints:            []Endpoint{{URL: r.srv.URL, Secret: testSecret}},
+		ChainParams:          &chaincfg.TestNet3Params,
+		DefaultConfirmations: confs,
+		RetryBackoff:         time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, d.Start(w))
+	t.Cleanup(d.Stop)
+
+	return d
+}
+
+// depositMiner returns a function connecting a block with a deposit to the
+// wallet.
+func depositMiner(t *testing.T, w *wallet.Wallet,
+	backend *chainClient) func(wtxmgr.BlockMeta, int64) *wire.MsgTx {
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	return func(block wtxmgr.BlockMeta, amount int64) *wire.MsgTx {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{})
+		tx.AddTxOut(wire.NewTxOut(amount, pkScript))
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		require.NoError(t, err)
+
+		backend.ntfns <- chain.RelevantTx{TxRecord: rec, Block: &block}
+		backend.ntfns <- chain.BlockConnected(block)
+		return tx
+	}
+}
+
+// TestReorg checks that disconnecting the block of a reported deposit from
+// the wallet emits a reorg event for that deposit only, and that the deposit
+// is confirmed again once re-mined.
+func TestReorg(t *testing.T) {
+	t.Parallel()
+
+	w, backend := newTestWallet(t)
+	r := newReceiver(t)
+	startWalletDispatcher(t, w, r, 1)
+	mine := depositMiner(t, w, backend)
+
+	// Mine a deposit in each of two blocks.
+	height := w.Manager.SyncedTo().Height + 1
+	parent := chainBlock(height, 0)
+	tip := chainBlock(height+1, 0)
+	mine(parent, 1e8)
+	events := r.waitEvents(2)
+	require.Equal(t, EventDeposit, events[0].Type)
+	require.Equal(t, EventConfirmation, events[1].Type)
+	tx := mine(tip, 2e8)
+	events = r.waitEvents(2)
+	require.Equal(t, EventDeposit, events[2].Type)
+	require.Equal(t, tx.TxHash().String(), events[2].TxID)
+	require.Equal(t, EventConfirmation, events[3].Type)
+
+	// Replace the tip with a longer competing chain that includes the
+	// deposit of the removed block one block later.  Only that deposit is
+	// reorged, and the one of its parent is left alone.  Mining it again
+	// emits a new confirmation, but no new deposit.
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	require.NoError(t, err)
+	remined := chainBlock(height+2, 1)
+	backend.ntfns <- chain.BlockDisconnected(tip)
+	backend.ntfns <- chain.BlockConnected(chainBlock(height+1, 1))
+	backend.ntfns <- chain.RelevantTx{TxRecord: rec, Block: &remined}
+	backend.ntfns <- chain.BlockConnected(remined)
+
+	events = r.waitEvents(2)
+	require.Len(t, events, 6)
+	require.Equal(t, EventReorg, events[4].Type)
+	require.Equal(t, tx.TxHash().String(), events[4].TxID)
+	require.Equal(t, tip.Hash.String(), events[4].BlockHash)
+	require.Zero(t, events[4].Confirmations)
+	require.Equal(t, EventConfirmation, events[5].Type)
+	require.Equal(t, tx.TxHash().String(), events[5].TxID)
+	require.EqualValues(t, height+2, events[5].BlockHeight)
+
+	select {
+	case <-r.got:
+		t.Fatalf("unexpected event")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestRestart checks that a deposit reported before the dispatcher is
+// restarted is still confirmed and watched for reorgs afterwards, without
+// being reported again.
+func TestRestart(t *testing.T) {
+	t.Parallel()
+
+	w, backend := newTestWallet(t)
+	r := newReceiver(t)
+	d := startWalletDispatcher(t, w, r, 2)
+	mine := depositMiner(t, w, backend)
+
+	// The deposit is mined on top of a block the wallet knows, which the
+	// chain is later reorged back to.
+	height := w.Manager.SyncedTo().Height + 2
+	backend.ntfns <- chain.BlockConnected(chainBlock(height-1, 0))
+	block := chainBlock(height, 0)
+	tx := mine(block, 1e8)
+	events := r.waitEvents(1)
+	require.Equal(t, EventDeposit, events[0].Type)
+
+	// Wait for the wallet to process the block before restarting.
+	require.Eventually(t, func() bool {
+		return w.Manager.SyncedTo().Height == height
+	}, 5*time.Second, 10*time.Millisecond)
+	d.Stop()
+	startWalletDispatcher(t, w, r, 2)
+
+	tip := chainBlock(height+1, 0)
+	backend.ntfns <- chain.BlockConnected(tip)
+	events = r.waitEvents(1)
+	require.Len(t, events, 2)
+	require.Equal(t, EventConfirmation, events[1].Type)
+	require.Equal(t, tx.TxHash().String(), events[1].TxID)
+	require.EqualValues(t, 2, events[1].Confirmations)
+
+	// Detached blocks are notified once a longer chain is attached.
+	backend.ntfns <- chain.BlockDisconnected(tip)
+	backend.ntfns <- chain.BlockDisconnected(block)
+	backend.ntfns <- chain.BlockConnected(chainBlock(height, 1))
+	backend.ntfns <- chain.BlockConnected(chainBlock(height+1, 1))
+	backend.ntfns <- chain.BlockConnected(chainBlock(height+2, 1))
+	events = r.waitEvents(1)
+	require.Len(t, events, 3)
+	require.Equal(t, EventReorg, events[2].Type)
+	require.Equal(t, tx.TxHash().String(), events[2].TxID)
+
+	select {
+	case <-r.got:
+		t.Fatalf("unexpected event")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestWatchAddresses checks that deposits to addresses that are not watched
+// are ignored.
+func TestWatchAddresses(t *testing.T) {
+	t.Parallel()
+
+	other, err := btcutil.DecodeAddress(
+		"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", &chaincfg.TestNet3Params,
+	)
+	require.NoError(t, err)
+
+	r := newReceiver(t)
+	d := newTestDispatcher(t, r, Config{
+		WatchAddresses: []btcutil.Address{other},
+	})
+
+	tx := depositSummary(t, 50000, 0)
+	d.processNotification(&wallet.TransactionNotifications{
+		UnminedTransactions:      []wallet.TransactionSummary{tx},
+		UnminedTransactionHashes: []*chainhash.Hash{tx.Hash},
+	})
+	require.Empty(t, d.credits)
+}
+
+// TestDeadLetter checks that deliveries are retried, moved to the dead-letter
+// store once the attempts are exhausted, and can be replayed from there.
+func TestDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	db, err := walletdb.Create(
+		"bdb", filepath.Join(t.TempDir(), "webhook.db"), true,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store, err := NewDBDeadLetterStore(db)
+	require.NoError(t, err)
+
+	r := newReceiver(t)
+	r.setFail(true)
+	d := newTestDispatcher(t, r, Config{
+		MaxAttempts: 3,
+		DeadLetters: store,
+	})
+
+	tx := depositSummary(t, 50000, 0)
+	d.processNotification(&wallet.TransactionNotifications{
+		UnminedTransactions:      []wallet.TransactionSummary{tx},
+		UnminedTransactionHashes: []*chainhash.Hash{tx.Hash},
+	})
+
+	// All three attempts are made before the delivery is given up on.
+	r.waitEvents(3)
+	var dls []*DeadLetter
+	require.Eventually(t, func() bool {
+		dls, err = d.DeadLetters()
+		require.NoError(t, err)
+		return len(dls) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, r.srv.URL, dls[0].URL)
+	require.Equal(t, 3, dls[0].Attempts)
+	require.Equal(t, EventDeposit, dls[0].Event.Type)
+
+	// Once the endpoint recovers, the dead letter can be replayed.
+	r.setFail(false)
+	require.NoError(t, d.Replay(dls[0].ID))
+
+	events := r.waitEvents(1)
+	require.Len(t, events, 1)
+	require.Equal(t, dls[0].Event.ID, events[0].ID)
+
+	dls, err = d.DeadLetters()
+	require.NoError(t, err)
+	require.Empty(t, dls)
+	require.ErrorIs(t, d.Replay(1), ErrDeadLetterNotFound)
+}
+
+// TestHalt checks that a wallet halt is reported with the reorg details.
+func TestHalt(t *testing.T) {
+	t.Parallel()
+
+	r := newReceiver(t)
+	d := newTestDispatcher(t, r, Config{})
+
+	d.emitHalt(&wallet.ReorgHalt{
+		Depth:      7,
+		ForkHeight: 500,
+		MaxDepth:   6,
+		Time:       time.Unix(1700000000, 0),
+	})
+
+	events := r.waitEvents(1)
+	require.Equal(t, EventHalt, events[0].Type)
+	require.Equal(t, "halt:500:1700000000", events[0].ID)
+	require.EqualValues(t, 7, events[0].ReorgDepth)
+	require.EqualValues(t, 500, events[0].BlockHeight)
+	require.Empty(t, events[0].TxID)
+	require.Empty(t, events[0].Wallet)
+}
+
+// TestWalletName checks that the events of a named wallet report the wallet
+// and have IDs of their own.
+func TestWalletName(t *testing.T) {
+	t.Parallel()
+
+	r := newReceiver(t)
+	d := newTestDispatcher(t, r, Config{Wallet: "bridge"})
+
+	tx := depositSummary(t, 50000, 0)
+	d.processNotification(&wallet.TransactionNotifications{
+		UnminedTransactions:      []wallet.TransactionSummary{tx},
+		UnminedTransactionHashes: []*chainhash.Hash{tx.Hash},
+	})
+	d.emitHalt(&wallet.ReorgHalt{
+		ForkHeight: 500,
+		Time:       time.Unix(1700000000, 0),
+	})
+
+	events := r.waitEvents(2)
+	require.Equal(t, "bridge", events[0].Wallet)
+	require.Equal(t, "bridge/deposit:"+tx.Hash.String()+":0", events[0].ID)
+	require.Equal(t, "bridge", events[1].Wallet)
+	require.Equal(t, "bridge/halt:500:1700000000", events[1].ID)
+}
+
+// TestVerifySignature checks the signature helpers.
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"id":"x"}`)
+	sig := Sign(testSecret, body)
+	require.True(t, VerifySignature(testSecret, body, sig))
+	require.False(t, VerifySignature([]byte("other"), body, sig))
+	require.False(t, VerifySignature(testSecret, []byte("{}"), sig))
+	require.False(t, VerifySignature(testSecret, body, sig[7:]))
+}
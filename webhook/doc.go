@@ -0,0 +1,24 @@
+/*
+Package webhook delivers wallet events to external HTTP endpoints.
+
+The Dispatcher subscribes to a wallet's NotificationServer and turns the
+transaction notifications into three kinds of events:
+
+  - deposit: a wallet output paying to an external (receive) address was
+    seen, either in the mempool or in a newly attached block.
+  - confirmation: a previously reported deposit reached the confirmation
+    threshold configured for its account.
+  - reorg: a block containing a previously reported deposit was detached
+    from the main chain, invalidating the credit until it is mined again.
+
+In addition, a halt event is emitted when the wallet halts after a chain
+reorganization deeper than its configured maximum.
+
+Every event is encoded as a JSON object and POSTed to each configured
+endpoint.  The request carries an HMAC-SHA256 signature of the body in the
+X-Btcwallet-Signature header, keyed by the endpoint's secret, so receivers
+can authenticate the payload with VerifySignature.  Failed deliveries are
+retried with exponential backoff, and deliveries that exhaust their retries
+are persisted in a DeadLetterStore so they can be inspected and replayed.
+*/
+package webhook
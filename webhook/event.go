@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the HTTP header carrying the hex encoded
+	// HMAC-SHA256 of the request body, prefixed with "sha256=".
+	SignatureHeader = "X-Btcwallet-Signature"
+
+	// EventHeader is the HTTP header carrying the event type of the
+	// payload.
+	EventHeader = "X-Btcwallet-Event"
+
+	// DeliveryHeader is the HTTP header carrying the unique event ID.  The
+	// same ID is sent for every retry of an event, so receivers can use it
+	// to deduplicate deliveries.
+	DeliveryHeader = "X-Btcwallet-Delivery"
+
+	// signaturePrefix names the hash function used for the signature.
+	signaturePrefix = "sha256="
+)
+
+// EventType describes the kind of wallet event a payload reports.
+type EventType string
+
+const (
+	// EventDeposit is emitted when a wallet output paying to an external
+	// address is first seen, either unmined or in a block.
+	EventDeposit EventType = "deposit"
+
+	// EventConfirmation is emitted once a reported deposit reaches the
+	// confirmation threshold of its account.
+	EventConfirmation EventType = "confirmation"
+
+	// EventReorg is emitted when the block containing a reported deposit
+	// is detached from the main chain.
+	EventReorg EventType = "reorg"
+
+	// EventHalt is emitted when the wallet halts sending and publishing
+	// transactions after a chain reorganization deeper than tolerated.
+	EventHalt EventType = "halt"
+)
+
+// Event is the JSON payload POSTed to webhook endpoints.  Block fields are
+// omitted for unmined deposits and for reorged credits.  Halt events carry
+// no transaction fields; they report the reorg depth and the height of the
+// last disconnected block instead.  The wallet is omitted for the events of
+// the default wallet.
+type Event struct {
+	ID            string    `json:"id"`
+	Type          EventType `json:"type"`
+	Created       time.Time `json:"created"`
+	Wallet        string    `json:"wallet,omitempty"`
+	TxID          string    `json:"txid"`
+	Vout          uint32    `json:"vout"`
+	Address       string    `json:"address"`
+	Amount        int64     `json:"amount"`
+	Account       uint32    `json:"account"`
+	BlockHash     string    `json:"block_hash,omitempty"`
+	BlockHeight   int32     `json:"block_height,omitempty"`
+	Confirmations int32     `json:"confirmations"`
+	ReorgDepth    int32     `json:"reorg_depth,omitempty"`
+}
+
+// Sign returns the value of the SignatureHeader for the given body and
+// endpoint secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is a valid SignatureHeader value
+// for body under secret.  The comparison is performed in constant time.
+func VerifySignature(secret, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	got, err := hex.DecodeString(signature[len(signaturePrefix):])
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}